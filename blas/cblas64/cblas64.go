@@ -12,7 +12,7 @@ import (
 var cblas64 blas.Complex64 = gonum.Implementation{}
 
 // Use sets the BLAS complex64 implementation to be used by subsequent BLAS calls.
-// The default implementation is cgo.Implementation.
+// The default implementation is gonum.Implementation.
 func Use(b blas.Complex64) {
 	cblas64 = b
 }
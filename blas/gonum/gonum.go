@@ -6,7 +6,12 @@
 
 package gonum
 
-import "math"
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/math32"
+)
 
 type Implementation struct{}
 
@@ -40,9 +45,8 @@ const (
 // [SD]gemm behavior constants. These are kept here to keep them out of the
 // way during single precision code genration.
 const (
-	blockSize   = 64 // b x b matrix
-	minParBlock = 4  // minimum number of blocks needed to go parallel
-	buffMul     = 4  // how big is the buffer relative to the number of workers
+	minParBlock = 4 // minimum number of blocks needed to go parallel
+	buffMul     = 4 // how big is the buffer relative to the number of workers
 )
 
 // subMul is a common type shared by [SD]gemm.
@@ -121,6 +125,33 @@ func checkZVector(name byte, n int, x []complex128, incX int) {
 	}
 }
 
+func checkCMatrix(name byte, m, n int, a []complex64, lda int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic("blas: illegal stride of " + string(name))
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("blas: insufficient " + string(name) + " matrix slice length")
+	}
+}
+
+func checkCVector(name byte, n int, x []complex64, incX int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic("blas: insufficient " + string(name) + " vector slice length")
+	}
+}
+
 // blocks returns the number of divisions of the dimension length with the given
 // block size.
 func blocks(dim, bsize int) int {
@@ -131,3 +162,68 @@ func blocks(dim, bsize int) int {
 func dcabs1(z complex128) float64 {
 	return math.Abs(real(z)) + math.Abs(imag(z))
 }
+
+// scabs1 returns |real(z)|+|imag(z)|.
+func scabs1(z complex64) float32 {
+	return math32.Abs(real(z)) + math32.Abs(imag(z))
+}
+
+// packedOffset returns the index of the diagonal element of the ith row of an
+// n×n triangular matrix that is stored in row-major packed form.
+func packedOffset(uplo blas.Uplo, n, i int) int {
+	if uplo == blas.Upper {
+		return i*n - i*(i-1)/2
+	}
+	return i * (i + 1) / 2
+}
+
+// packedRow returns a function that gives the offset and the range of the
+// strictly off-diagonal elements of a row of an n×n triangular matrix stored
+// in row-major packed form, so that A[i][j] is stored at off+j.
+func packedRow(uplo blas.Uplo, n int) func(i int) (off, lo, hi int) {
+	if uplo == blas.Upper {
+		return func(i int) (off, lo, hi int) {
+			return packedOffset(blas.Upper, n, i) - i, i + 1, n
+		}
+	}
+	return func(i int) (off, lo, hi int) {
+		return packedOffset(blas.Lower, n, i), 0, i
+	}
+}
+
+// bandRow returns a function that gives the offset and the range of the
+// strictly off-diagonal elements of a row of an n×n triangular band matrix
+// with k off-diagonals, so that A[i][j] is stored at off+j.
+func bandRow(uplo blas.Uplo, n, k, lda int) func(i int) (off, lo, hi int) {
+	if uplo == blas.Upper {
+		return func(i int) (off, lo, hi int) {
+			return i*lda - i, i + 1, min(n, i+k+1)
+		}
+	}
+	return func(i int) (off, lo, hi int) {
+		return i*lda - i + k, max(0, i-k), i
+	}
+}
+
+// checkTriangular panics if the parameters of a triangular operation are
+// not valid.
+func checkTriangular(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if diag != blas.NonUnit && diag != blas.Unit {
+		panic(badDiag)
+	}
+}
+
+// triRange returns the range of columns [jmin, jmax) of the ith row of the
+// uplo triangle of an n×n matrix, including the diagonal.
+func triRange(uplo blas.Uplo, n, i int) (jmin, jmax int) {
+	if uplo == blas.Upper {
+		return i, n
+	}
+	return 0, i + 1
+}
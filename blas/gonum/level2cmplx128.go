@@ -356,7 +356,7 @@ func (Implementation) Zher2(uplo blas.Uplo, n int, alpha complex128, x []complex
 			return
 		}
 		for i := 0; i < n; i++ {
-			if x[i] != 0 || y[i] != 0 {
+			if x[ix] != 0 || y[iy] != 0 {
 				tmp1 := alpha * x[ix]
 				tmp2 := cmplx.Conj(alpha) * y[iy]
 				aii := real(a[i*lda+i]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
@@ -396,7 +396,7 @@ func (Implementation) Zher2(uplo blas.Uplo, n int, alpha complex128, x []complex
 		return
 	}
 	for i := 0; i < n; i++ {
-		if x[i] != 0 || y[i] != 0 {
+		if x[ix] != 0 || y[iy] != 0 {
 			tmp1 := alpha * x[ix]
 			tmp2 := cmplx.Conj(alpha) * y[iy]
 			jx := kx
@@ -416,3 +416,621 @@ func (Implementation) Zher2(uplo blas.Uplo, n int, alpha complex128, x []complex
 		iy += incY
 	}
 }
+
+// Zgbmv performs one of the matrix-vector operations
+//  y = alpha * A * x + beta * y    if trans = blas.NoTrans
+//  y = alpha * A^T * x + beta * y  if trans = blas.Trans
+//  y = alpha * A^H * x + beta * y  if trans = blas.ConjTrans
+// where alpha and beta are scalars, x and y are vectors, and A is an m×n band
+// matrix with kL sub-diagonals and kU super-diagonals.
+func (Implementation) Zgbmv(trans blas.Transpose, m, n, kL, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	switch trans {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if kL < 0 {
+		panic(kLLT0)
+	}
+	if kU < 0 {
+		panic(kULT0)
+	}
+	if lda < kL+kU+1 {
+		panic(badLdA)
+	}
+	if m > 0 && n > 0 && len(a) < lda*(min(m, n+kL)-1)+kL+kU+1 {
+		panic(badLdA)
+	}
+	var lenX, lenY int
+	if trans == blas.NoTrans {
+		lenX, lenY = n, m
+	} else {
+		lenX, lenY = m, n
+	}
+	checkZVector('x', lenX, x, incX)
+	checkZVector('y', lenY, y, incY)
+
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - lenX) * incX
+	}
+	if incY < 0 {
+		ky = (1 - lenY) * incY
+	}
+
+	// Form y := beta*y.
+	if beta != 1 {
+		iy := ky
+		for i := 0; i < lenY; i++ {
+			if beta == 0 {
+				y[iy] = 0
+			} else {
+				y[iy] *= beta
+			}
+			iy += incY
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	// The element A[i][j] is stored in a[i*lda+j-i+kL].
+	switch trans {
+	case blas.NoTrans:
+		iy := ky
+		for i := 0; i < min(m, n+kL); i++ {
+			jmin := max(0, i-kL)
+			jmax := min(n, i+kU+1)
+			var sum complex128
+			jx := kx + jmin*incX
+			for j := jmin; j < jmax; j++ {
+				sum += a[i*lda+j-i+kL] * x[jx]
+				jx += incX
+			}
+			y[iy] += alpha * sum
+			iy += incY
+		}
+	case blas.Trans:
+		ix := kx
+		for i := 0; i < min(m, n+kL); i++ {
+			jmin := max(0, i-kL)
+			jmax := min(n, i+kU+1)
+			tmp := alpha * x[ix]
+			jy := ky + jmin*incY
+			for j := jmin; j < jmax; j++ {
+				y[jy] += tmp * a[i*lda+j-i+kL]
+				jy += incY
+			}
+			ix += incX
+		}
+	case blas.ConjTrans:
+		ix := kx
+		for i := 0; i < min(m, n+kL); i++ {
+			jmin := max(0, i-kL)
+			jmax := min(n, i+kU+1)
+			tmp := alpha * x[ix]
+			jy := ky + jmin*incY
+			for j := jmin; j < jmax; j++ {
+				y[jy] += tmp * cmplx.Conj(a[i*lda+j-i+kL])
+				jy += incY
+			}
+			ix += incX
+		}
+	}
+}
+
+// Zhemv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian matrix. The imaginary parts of the diagonal elements of A are
+// ignored and assumed to be zero.
+func (Implementation) Zhemv(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkZMatrix('A', n, n, a, lda)
+	checkZVector('x', n, x, incX)
+	checkZVector('y', n, y, incY)
+
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	zhemv(n, alpha, a, func(i int) (off, lo, hi int) {
+		if uplo == blas.Upper {
+			return i * lda, i + 1, n
+		}
+		return i * lda, 0, i
+	}, x, incX, beta, y, incY)
+}
+
+// Zhbmv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian band matrix with k super-diagonals. The imaginary parts of the
+// diagonal elements of A are ignored and assumed to be zero.
+func (Implementation) Zhbmv(uplo blas.Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda < k+1 {
+		panic(badLdA)
+	}
+	if n > 0 && len(a) < lda*(n-1)+k+1 {
+		panic(badLdA)
+	}
+	checkZVector('x', n, x, incX)
+	checkZVector('y', n, y, incY)
+
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	zhemv(n, alpha, a, bandRow(uplo, n, k, lda), x, incX, beta, y, incY)
+}
+
+// Zhpmv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian matrix in packed form. The imaginary parts of the diagonal
+// elements of A are ignored and assumed to be zero.
+func (Implementation) Zhpmv(uplo blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	checkZVector('x', n, x, incX)
+	checkZVector('y', n, y, incY)
+
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	zhemv(n, alpha, ap, packedRow(uplo, n), x, incX, beta, y, incY)
+}
+
+// Zhpr performs the Hermitian rank-one operation
+//  A += alpha * x * x^H
+// where alpha is a real scalar, x is an n element vector, and A is an n×n
+// Hermitian matrix in packed form. On entry, the imaginary parts of the
+// diagonal elements of A are ignored and assumed to be zero, on return they
+// will be set to zero.
+func (Implementation) Zhpr(uplo blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkZVector('x', n, x, incX)
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	ix := kx
+	for i := 0; i < n; i++ {
+		tmp := complex(alpha*real(x[ix]), alpha*imag(x[ix]))
+		var jmin, jmax, off int
+		if uplo == blas.Upper {
+			jmin, jmax = i+1, n
+			off = packedOffset(blas.Upper, n, i) - i
+		} else {
+			jmin, jmax = 0, i
+			off = packedOffset(blas.Lower, n, i)
+		}
+		jx := kx + jmin*incX
+		for j := jmin; j < jmax; j++ {
+			ap[off+j] += tmp * cmplx.Conj(x[jx])
+			jx += incX
+		}
+		aii := real(ap[off+i]) + real(tmp*cmplx.Conj(x[ix]))
+		ap[off+i] = complex(aii, 0)
+		ix += incX
+	}
+}
+
+// Zhpr2 performs the Hermitian rank-two operation
+//  A += alpha*x*y^H + conj(alpha)*y*x^H
+// where alpha is a complex scalar, x and y are n element vectors, and A is an
+// n×n Hermitian matrix in packed form. On entry, the imaginary parts of the
+// diagonal elements are ignored and assumed to be zero. On return they will
+// be set to zero.
+func (Implementation) Zhpr2(uplo blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkZVector('x', n, x, incX)
+	checkZVector('y', n, y, incY)
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+	ix := kx
+	iy := ky
+	for i := 0; i < n; i++ {
+		tmp1 := alpha * x[ix]
+		tmp2 := cmplx.Conj(alpha) * y[iy]
+		var jmin, jmax, off int
+		if uplo == blas.Upper {
+			jmin, jmax = i+1, n
+			off = packedOffset(blas.Upper, n, i) - i
+		} else {
+			jmin, jmax = 0, i
+			off = packedOffset(blas.Lower, n, i)
+		}
+		jx := kx + jmin*incX
+		jy := ky + jmin*incY
+		for j := jmin; j < jmax; j++ {
+			ap[off+j] += tmp1*cmplx.Conj(y[jy]) + tmp2*cmplx.Conj(x[jx])
+			jx += incX
+			jy += incY
+		}
+		aii := real(ap[off+i]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
+		ap[off+i] = complex(aii, 0)
+		ix += incX
+		iy += incY
+	}
+}
+
+// Ztrmv performs one of the matrix-vector operations
+//  x = A * x    if trans = blas.NoTrans
+//  x = A^T * x  if trans = blas.Trans
+//  x = A^H * x  if trans = blas.ConjTrans
+// where x is a vector, and A is an n×n triangular matrix.
+func (Implementation) Ztrmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	checkZMatrix('A', n, n, a, lda)
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrmv(uplo, trans, diag, n, a, func(i int) (off, lo, hi int) {
+		if uplo == blas.Upper {
+			return i * lda, i + 1, n
+		}
+		return i * lda, 0, i
+	}, x, incX)
+}
+
+// Ztbmv performs one of the matrix-vector operations
+//  x = A * x    if trans = blas.NoTrans
+//  x = A^T * x  if trans = blas.Trans
+//  x = A^H * x  if trans = blas.ConjTrans
+// where x is a vector, and A is an n×n triangular band matrix with k+1
+// diagonals.
+func (Implementation) Ztbmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	checkZBand(n, k, a, lda)
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrmv(uplo, trans, diag, n, a, bandRow(uplo, n, k, lda), x, incX)
+}
+
+// Ztpmv performs one of the matrix-vector operations
+//  x = A * x    if trans = blas.NoTrans
+//  x = A^T * x  if trans = blas.Trans
+//  x = A^H * x  if trans = blas.ConjTrans
+// where x is a vector, and A is an n×n triangular matrix in packed form.
+func (Implementation) Ztpmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrmv(uplo, trans, diag, n, ap, packedRow(uplo, n), x, incX)
+}
+
+// Ztrsv solves one of the systems of equations
+//  A * x = b    if trans = blas.NoTrans
+//  A^T * x = b  if trans = blas.Trans
+//  A^H * x = b  if trans = blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular matrix.
+// On entry, x contains the values of b, and the solution is stored in-place
+// into x.
+//
+// No test for singularity or near-singularity is included in this routine.
+// Such tests must be performed before calling this routine.
+func (Implementation) Ztrsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	checkZMatrix('A', n, n, a, lda)
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrsv(uplo, trans, diag, n, a, func(i int) (off, lo, hi int) {
+		if uplo == blas.Upper {
+			return i * lda, i + 1, n
+		}
+		return i * lda, 0, i
+	}, x, incX)
+}
+
+// Ztbsv solves one of the systems of equations
+//  A * x = b    if trans = blas.NoTrans
+//  A^T * x = b  if trans = blas.Trans
+//  A^H * x = b  if trans = blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular band matrix
+// with k+1 diagonals. On entry, x contains the values of b, and the solution
+// is stored in-place into x.
+//
+// No test for singularity or near-singularity is included in this routine.
+// Such tests must be performed before calling this routine.
+func (Implementation) Ztbsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	checkZBand(n, k, a, lda)
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrsv(uplo, trans, diag, n, a, bandRow(uplo, n, k, lda), x, incX)
+}
+
+// Ztpsv solves one of the systems of equations
+//  A * x = b    if trans = blas.NoTrans
+//  A^T * x = b  if trans = blas.Trans
+//  A^H * x = b  if trans = blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular matrix in
+// packed form. On entry, x contains the values of b, and the solution is
+// stored in-place into x.
+//
+// No test for singularity or near-singularity is included in this routine.
+// Such tests must be performed before calling this routine.
+func (Implementation) Ztpsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	checkTriangular(uplo, trans, diag)
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	checkZVector('x', n, x, incX)
+
+	if n == 0 {
+		return
+	}
+	ztrsv(uplo, trans, diag, n, ap, packedRow(uplo, n), x, incX)
+}
+
+// checkZBand panics if a does not hold an n×n band matrix with k
+// off-diagonals in either triangle.
+func checkZBand(n, k int, a []complex128, lda int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda < k+1 {
+		panic(badLdA)
+	}
+	if n > 0 && len(a) < lda*(n-1)+k+1 {
+		panic(badLdA)
+	}
+}
+
+// ztrmv computes x = op(A) * x for an n×n triangular matrix A. The strictly
+// off-diagonal elements of the ith row of A that are stored are A[i][j] = a[off+j]
+// for lo <= j < hi, and the diagonal element is a[off+i], where off, lo and
+// hi are returned by row(i).
+func ztrmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, row func(i int) (off, lo, hi int), x []complex128, incX int) {
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := diag == blas.NonUnit
+	if trans == blas.NoTrans {
+		// Each element of x depends only on elements that have not
+		// been overwritten yet.
+		for k := 0; k < n; k++ {
+			i := k
+			if uplo == blas.Lower {
+				i = n - 1 - k
+			}
+			off, lo, hi := row(i)
+			ix := kx + i*incX
+			var sum complex128
+			jx := kx + lo*incX
+			for j := lo; j < hi; j++ {
+				sum += a[off+j] * x[jx]
+				jx += incX
+			}
+			if nonUnit {
+				sum += a[off+i] * x[ix]
+			} else {
+				sum += x[ix]
+			}
+			x[ix] = sum
+		}
+		return
+	}
+	conj := trans == blas.ConjTrans
+	for k := 0; k < n; k++ {
+		i := n - 1 - k
+		if uplo == blas.Lower {
+			i = k
+		}
+		off, lo, hi := row(i)
+		ix := kx + i*incX
+		xi := x[ix]
+		if nonUnit {
+			aii := a[off+i]
+			if conj {
+				aii = cmplx.Conj(aii)
+			}
+			x[ix] = aii * xi
+		}
+		jx := kx + lo*incX
+		for j := lo; j < hi; j++ {
+			aij := a[off+j]
+			if conj {
+				aij = cmplx.Conj(aij)
+			}
+			x[jx] += aij * xi
+			jx += incX
+		}
+	}
+}
+
+// ztrsv solves op(A) * x = b for an n×n triangular matrix A stored as
+// described in ztrmv. On entry x holds b and on return it holds the solution.
+func ztrsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, row func(i int) (off, lo, hi int), x []complex128, incX int) {
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := diag == blas.NonUnit
+	if trans == blas.NoTrans {
+		// Substitute from the end of x that does not depend on the
+		// unknown elements.
+		for k := 0; k < n; k++ {
+			i := n - 1 - k
+			if uplo == blas.Lower {
+				i = k
+			}
+			off, lo, hi := row(i)
+			ix := kx + i*incX
+			sum := x[ix]
+			jx := kx + lo*incX
+			for j := lo; j < hi; j++ {
+				sum -= a[off+j] * x[jx]
+				jx += incX
+			}
+			if nonUnit {
+				sum /= a[off+i]
+			}
+			x[ix] = sum
+		}
+		return
+	}
+	conj := trans == blas.ConjTrans
+	for k := 0; k < n; k++ {
+		i := k
+		if uplo == blas.Lower {
+			i = n - 1 - k
+		}
+		off, lo, hi := row(i)
+		ix := kx + i*incX
+		if nonUnit {
+			aii := a[off+i]
+			if conj {
+				aii = cmplx.Conj(aii)
+			}
+			x[ix] /= aii
+		}
+		xi := x[ix]
+		jx := kx + lo*incX
+		for j := lo; j < hi; j++ {
+			aij := a[off+j]
+			if conj {
+				aij = cmplx.Conj(aij)
+			}
+			x[jx] -= aij * xi
+			jx += incX
+		}
+	}
+}
+
+// zhemv computes y = alpha * A * x + beta * y for an n×n Hermitian matrix A
+// whose stored triangle is described by row as in ztrmv.
+func zhemv(n int, alpha complex128, a []complex128, row func(i int) (off, lo, hi int), x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+
+	// Form y := beta*y.
+	if beta != 1 {
+		iy := ky
+		for i := 0; i < n; i++ {
+			if beta == 0 {
+				y[iy] = 0
+			} else {
+				y[iy] *= beta
+			}
+			iy += incY
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+	ix := kx
+	iy := ky
+	for i := 0; i < n; i++ {
+		off, lo, hi := row(i)
+		tmp1 := alpha * x[ix]
+		var tmp2 complex128
+		jx := kx + lo*incX
+		jy := ky + lo*incY
+		for j := lo; j < hi; j++ {
+			aij := a[off+j]
+			y[jy] += tmp1 * cmplx.Conj(aij)
+			tmp2 += aij * x[jx]
+			jx += incX
+			jy += incY
+		}
+		y[iy] += tmp1*complex(real(a[off+i]), 0) + alpha*tmp2
+		ix += incX
+		iy += incY
+	}
+}
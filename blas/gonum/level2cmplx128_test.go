@@ -29,3 +29,51 @@ func TestZher(t *testing.T) {
 func TestZher2(t *testing.T) {
 	testblas.Zher2Test(t, impl)
 }
+
+func TestZgbmv(t *testing.T) {
+	testblas.ZgbmvTest(t, impl)
+}
+
+func TestZhemv(t *testing.T) {
+	testblas.ZhemvTest(t, impl)
+}
+
+func TestZhbmv(t *testing.T) {
+	testblas.ZhbmvTest(t, impl)
+}
+
+func TestZhpmv(t *testing.T) {
+	testblas.ZhpmvTest(t, impl)
+}
+
+func TestZhpr(t *testing.T) {
+	testblas.ZhprTest(t, impl)
+}
+
+func TestZhpr2(t *testing.T) {
+	testblas.Zhpr2Test(t, impl)
+}
+
+func TestZtrmv(t *testing.T) {
+	testblas.ZtrmvTest(t, impl)
+}
+
+func TestZtbmv(t *testing.T) {
+	testblas.ZtbmvTest(t, impl)
+}
+
+func TestZtpmv(t *testing.T) {
+	testblas.ZtpmvTest(t, impl)
+}
+
+func TestZtrsv(t *testing.T) {
+	testblas.ZtrsvTest(t, impl)
+}
+
+func TestZtbsv(t *testing.T) {
+	testblas.ZtbsvTest(t, impl)
+}
+
+func TestZtpsv(t *testing.T) {
+	testblas.ZtpsvTest(t, impl)
+}
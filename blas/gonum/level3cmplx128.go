@@ -0,0 +1,621 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/asm/c128"
+)
+
+// Zgemm performs one of the matrix-matrix operations
+//  C = alpha * op(A) * op(B) + beta * C
+// where op(X) is one of
+//  op(X) = X  or  op(X) = X^T  or  op(X) = X^H,
+// alpha and beta are scalars, and A, B and C are matrices, with op(A) an m×k
+// matrix, op(B) a k×n matrix and C an m×n matrix.
+func (Implementation) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if tA == blas.NoTrans {
+		checkZMatrix('a', m, k, a, lda)
+	} else {
+		checkZMatrix('a', k, m, a, lda)
+	}
+	if tB == blas.NoTrans {
+		checkZMatrix('b', k, n, b, ldb)
+	} else {
+		checkZMatrix('b', n, k, b, ldb)
+	}
+	checkZMatrix('c', m, n, c, ldc)
+
+	if m == 0 || n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	// Form C = beta * C.
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ci {
+					ci[j] = 0
+				}
+			} else {
+				c128.ScalUnitary(beta, ci)
+			}
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	for i := 0; i < m; i++ {
+		ci := c[i*ldc : i*ldc+n]
+		for l := 0; l < k; l++ {
+			var ail complex128
+			switch tA {
+			case blas.NoTrans:
+				ail = a[i*lda+l]
+			case blas.Trans:
+				ail = a[l*lda+i]
+			case blas.ConjTrans:
+				ail = cmplx.Conj(a[l*lda+i])
+			}
+			tmp := alpha * ail
+			switch tB {
+			case blas.NoTrans:
+				c128.AxpyUnitary(tmp, b[l*ldb:l*ldb+n], ci)
+			case blas.Trans:
+				c128.AxpyInc(tmp, b[l:], ci, uintptr(n), uintptr(ldb), 1, 0, 0)
+			case blas.ConjTrans:
+				for j := range ci {
+					ci[j] += tmp * cmplx.Conj(b[j*ldb+l])
+				}
+			}
+		}
+	}
+}
+
+// Zsymm performs one of the matrix-matrix operations
+//  C = alpha * A * B + beta * C  if side == blas.Left
+//  C = alpha * B * A + beta * C  if side == blas.Right
+// where alpha and beta are scalars, A is an m×m or n×n symmetric matrix and B
+// and C are m×n matrices.
+func (Implementation) Zsymm(side blas.Side, uplo blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	zsymm(side, uplo, false, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// Zhemm performs one of the matrix-matrix operations
+//  C = alpha * A * B + beta * C  if side == blas.Left
+//  C = alpha * B * A + beta * C  if side == blas.Right
+// where alpha and beta are scalars, A is an m×m or n×n Hermitian matrix and B
+// and C are m×n matrices. The imaginary parts of the diagonal elements of A
+// are assumed to be zero.
+func (Implementation) Zhemm(side blas.Side, uplo blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	zsymm(side, uplo, true, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// zsymm implements Zsymm and, if herm is true, Zhemm.
+func zsymm(side blas.Side, uplo blas.Uplo, herm bool, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if side != blas.Left && side != blas.Right {
+		panic(badSide)
+	}
+	if uplo != blas.Lower && uplo != blas.Upper {
+		panic(badUplo)
+	}
+	na := m
+	if side == blas.Right {
+		na = n
+	}
+	checkZMatrix('a', na, na, a, lda)
+	checkZMatrix('b', m, n, b, ldb)
+	checkZMatrix('c', m, n, c, ldc)
+
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	// Form C = beta * C.
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ci {
+					ci[j] = 0
+				}
+			} else {
+				c128.ScalUnitary(beta, ci)
+			}
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	// at returns the element A[i][j] of the full matrix.
+	at := func(i, j int) complex128 {
+		switch {
+		case i == j:
+			if herm {
+				return complex(real(a[i*lda+i]), 0)
+			}
+			return a[i*lda+i]
+		case (uplo == blas.Upper) == (i < j):
+			return a[i*lda+j]
+		default:
+			if herm {
+				return cmplx.Conj(a[j*lda+i])
+			}
+			return a[j*lda+i]
+		}
+	}
+
+	if side == blas.Left {
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			for l := 0; l < m; l++ {
+				c128.AxpyUnitary(alpha*at(i, l), b[l*ldb:l*ldb+n], ci)
+			}
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		ci := c[i*ldc : i*ldc+n]
+		for l := 0; l < n; l++ {
+			tmp := alpha * b[i*ldb+l]
+			for j := range ci {
+				ci[j] += tmp * at(l, j)
+			}
+		}
+	}
+}
+
+// Zsyrk performs one of the symmetric rank-k operations
+//  C = alpha * A * A^T + beta * C  if trans == blas.NoTrans
+//  C = alpha * A^T * A + beta * C  if trans == blas.Trans
+// where alpha and beta are scalars, C is an n×n symmetric matrix and A is
+// an n×k matrix in the first case and a k×n matrix in the second case. Only
+// the uplo triangle of C is referenced and updated.
+func (Implementation) Zsyrk(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	if uplo != blas.Lower && uplo != blas.Upper {
+		panic(badUplo)
+	}
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTranspose)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if trans == blas.NoTrans {
+		checkZMatrix('a', n, k, a, lda)
+	} else {
+		checkZMatrix('a', k, n, a, lda)
+	}
+	checkZMatrix('c', n, n, c, ldc)
+
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		jmin, jmax := triRange(uplo, n, i)
+		ci := c[i*ldc+jmin : i*ldc+jmax]
+		if beta == 0 {
+			for j := range ci {
+				ci[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ci)
+		}
+		if alpha == 0 {
+			continue
+		}
+		if trans == blas.NoTrans {
+			ai := a[i*lda : i*lda+k]
+			for j := jmin; j < jmax; j++ {
+				ci[j-jmin] += alpha * c128.DotuUnitary(ai, a[j*lda:j*lda+k])
+			}
+			continue
+		}
+		for l := 0; l < k; l++ {
+			c128.AxpyUnitary(alpha*a[l*lda+i], a[l*lda+jmin:l*lda+jmax], ci)
+		}
+	}
+}
+
+// Zherk performs one of the Hermitian rank-k operations
+//  C = alpha * A * A^H + beta * C  if trans == blas.NoTrans
+//  C = alpha * A^H * A + beta * C  if trans == blas.ConjTrans
+// where alpha and beta are real scalars, C is an n×n Hermitian matrix and A
+// is an n×k matrix in the first case and a k×n matrix in the second case.
+// Only the uplo triangle of C is referenced and updated. The imaginary parts
+// of the diagonal elements of C are assumed to be zero, and on return they
+// will be set to zero.
+func (Implementation) Zherk(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	if uplo != blas.Lower && uplo != blas.Upper {
+		panic(badUplo)
+	}
+	if trans != blas.NoTrans && trans != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if trans == blas.NoTrans {
+		checkZMatrix('a', n, k, a, lda)
+	} else {
+		checkZMatrix('a', k, n, a, lda)
+	}
+	checkZMatrix('c', n, n, c, ldc)
+
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	calpha := complex(alpha, 0)
+	for i := 0; i < n; i++ {
+		jmin, jmax := triRange(uplo, n, i)
+		ci := c[i*ldc+jmin : i*ldc+jmax]
+		if beta == 0 {
+			for j := range ci {
+				ci[j] = 0
+			}
+		} else if beta != 1 {
+			c128.DscalUnitary(beta, ci)
+		}
+		if alpha != 0 {
+			if trans == blas.NoTrans {
+				ai := a[i*lda : i*lda+k]
+				for j := jmin; j < jmax; j++ {
+					ci[j-jmin] += calpha * c128.DotcUnitary(a[j*lda:j*lda+k], ai)
+				}
+			} else {
+				for l := 0; l < k; l++ {
+					tmp := calpha * cmplx.Conj(a[l*lda+i])
+					c128.AxpyUnitary(tmp, a[l*lda+jmin:l*lda+jmax], ci)
+				}
+			}
+		}
+		c[i*ldc+i] = complex(real(c[i*ldc+i]), 0)
+	}
+}
+
+// Zsyr2k performs one of the symmetric rank-2k operations
+//  C = alpha * A * B^T + alpha * B * A^T + beta * C  if trans == blas.NoTrans
+//  C = alpha * A^T * B + alpha * B^T * A + beta * C  if trans == blas.Trans
+// where alpha and beta are scalars, C is an n×n symmetric matrix and A and B
+// are n×k matrices in the first case and k×n matrices in the second case.
+// Only the uplo triangle of C is referenced and updated.
+func (Implementation) Zsyr2k(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if uplo != blas.Lower && uplo != blas.Upper {
+		panic(badUplo)
+	}
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTranspose)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if trans == blas.NoTrans {
+		checkZMatrix('a', n, k, a, lda)
+		checkZMatrix('b', n, k, b, ldb)
+	} else {
+		checkZMatrix('a', k, n, a, lda)
+		checkZMatrix('b', k, n, b, ldb)
+	}
+	checkZMatrix('c', n, n, c, ldc)
+
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		jmin, jmax := triRange(uplo, n, i)
+		ci := c[i*ldc+jmin : i*ldc+jmax]
+		if beta == 0 {
+			for j := range ci {
+				ci[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ci)
+		}
+		if alpha == 0 {
+			continue
+		}
+		if trans == blas.NoTrans {
+			ai := a[i*lda : i*lda+k]
+			bi := b[i*ldb : i*ldb+k]
+			for j := jmin; j < jmax; j++ {
+				sum := c128.DotuUnitary(ai, b[j*ldb:j*ldb+k]) + c128.DotuUnitary(bi, a[j*lda:j*lda+k])
+				ci[j-jmin] += alpha * sum
+			}
+			continue
+		}
+		for l := 0; l < k; l++ {
+			c128.AxpyUnitary(alpha*a[l*lda+i], b[l*ldb+jmin:l*ldb+jmax], ci)
+			c128.AxpyUnitary(alpha*b[l*ldb+i], a[l*lda+jmin:l*lda+jmax], ci)
+		}
+	}
+}
+
+// Zher2k performs one of the Hermitian rank-2k operations
+//  C = alpha * A * B^H + conj(alpha) * B * A^H + beta * C  if trans == blas.NoTrans
+//  C = alpha * A^H * B + conj(alpha) * B^H * A + beta * C  if trans == blas.ConjTrans
+// where alpha is a complex scalar, beta is a real scalar, C is an n×n
+// Hermitian matrix and A and B are n×k matrices in the first case and k×n
+// matrices in the second case. Only the uplo triangle of C is referenced and
+// updated. The imaginary parts of the diagonal elements of C are assumed to
+// be zero, and on return they will be set to zero.
+func (Implementation) Zher2k(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	if uplo != blas.Lower && uplo != blas.Upper {
+		panic(badUplo)
+	}
+	if trans != blas.NoTrans && trans != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if trans == blas.NoTrans {
+		checkZMatrix('a', n, k, a, lda)
+		checkZMatrix('b', n, k, b, ldb)
+	} else {
+		checkZMatrix('a', k, n, a, lda)
+		checkZMatrix('b', k, n, b, ldb)
+	}
+	checkZMatrix('c', n, n, c, ldc)
+
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	conjAlpha := cmplx.Conj(alpha)
+	for i := 0; i < n; i++ {
+		jmin, jmax := triRange(uplo, n, i)
+		ci := c[i*ldc+jmin : i*ldc+jmax]
+		if beta == 0 {
+			for j := range ci {
+				ci[j] = 0
+			}
+		} else if beta != 1 {
+			c128.DscalUnitary(beta, ci)
+		}
+		if alpha != 0 {
+			if trans == blas.NoTrans {
+				ai := a[i*lda : i*lda+k]
+				bi := b[i*ldb : i*ldb+k]
+				for j := jmin; j < jmax; j++ {
+					ci[j-jmin] += alpha*c128.DotcUnitary(b[j*ldb:j*ldb+k], ai) + conjAlpha*c128.DotcUnitary(a[j*lda:j*lda+k], bi)
+				}
+			} else {
+				for l := 0; l < k; l++ {
+					c128.AxpyUnitary(alpha*cmplx.Conj(a[l*lda+i]), b[l*ldb+jmin:l*ldb+jmax], ci)
+					c128.AxpyUnitary(conjAlpha*cmplx.Conj(b[l*ldb+i]), a[l*lda+jmin:l*lda+jmax], ci)
+				}
+			}
+		}
+		c[i*ldc+i] = complex(real(c[i*ldc+i]), 0)
+	}
+}
+
+// Ztrmm performs one of the matrix-matrix operations
+//  B = alpha * op(A) * B  if side == blas.Left
+//  B = alpha * B * op(A)  if side == blas.Right
+// where op(A) is one of
+//  op(A) = A  or  op(A) = A^T  or  op(A) = A^H,
+// alpha is a scalar, B is an m×n matrix and A is a unit or non-unit, upper or
+// lower triangular matrix of order m if side == blas.Left and of order n if
+// side == blas.Right.
+func (Implementation) Ztrmm(side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if side != blas.Left && side != blas.Right {
+		panic(badSide)
+	}
+	checkTriangular(uplo, trans, diag)
+	na := m
+	if side == blas.Right {
+		na = n
+	}
+	checkZMatrix('a', na, na, a, lda)
+	checkZMatrix('b', m, n, b, ldb)
+
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			bi := b[i*ldb : i*ldb+n]
+			for j := range bi {
+				bi[j] = 0
+			}
+		}
+		return
+	}
+
+	nonUnit := diag == blas.NonUnit
+	conj := trans == blas.ConjTrans
+	// opA returns the element op(A)[i][j] in the triangle of op(A).
+	opA := func(i, j int) complex128 {
+		if trans == blas.NoTrans {
+			return a[i*lda+j]
+		}
+		if conj {
+			return cmplx.Conj(a[j*lda+i])
+		}
+		return a[j*lda+i]
+	}
+	// opUpper is whether op(A) is upper triangular.
+	opUpper := (uplo == blas.Upper) == (trans == blas.NoTrans)
+
+	if side == blas.Left {
+		// Row i of op(A) * B depends only on rows of B that have not
+		// been overwritten yet.
+		for k := 0; k < m; k++ {
+			i := k
+			if !opUpper {
+				i = m - 1 - k
+			}
+			bi := b[i*ldb : i*ldb+n]
+			if nonUnit {
+				c128.ScalUnitary(alpha*opA(i, i), bi)
+			} else if alpha != 1 {
+				c128.ScalUnitary(alpha, bi)
+			}
+			lmin, lmax := i+1, m
+			if !opUpper {
+				lmin, lmax = 0, i
+			}
+			for l := lmin; l < lmax; l++ {
+				c128.AxpyUnitary(alpha*opA(i, l), b[l*ldb:l*ldb+n], bi)
+			}
+		}
+		return
+	}
+
+	// Each row of B is multiplied independently from the right.
+	for i := 0; i < m; i++ {
+		bi := b[i*ldb : i*ldb+n]
+		for k := 0; k < n; k++ {
+			j := n - 1 - k
+			if !opUpper {
+				j = k
+			}
+			// Column j of op(A) has its non-zero elements in rows
+			// l <= j if op(A) is upper triangular and l >= j otherwise.
+			lmin, lmax := 0, j
+			if !opUpper {
+				lmin, lmax = j+1, n
+			}
+			var sum complex128
+			for l := lmin; l < lmax; l++ {
+				sum += bi[l] * opA(l, j)
+			}
+			if nonUnit {
+				sum += bi[j] * opA(j, j)
+			} else {
+				sum += bi[j]
+			}
+			bi[j] = alpha * sum
+		}
+	}
+}
+
+// Ztrsm solves one of the matrix equations
+//  op(A) * X = alpha * B  if side == blas.Left
+//  X * op(A) = alpha * B  if side == blas.Right
+// where alpha is a scalar, X and B are m×n matrices, A is a unit or
+// non-unit, upper or lower triangular matrix and op(A) is one of
+//  op(A) = A  or  op(A) = A^T  or  op(A) = A^H.
+// On entry, B contains the right-hand side matrix B, and on return it
+// contains the solution matrix X.
+//
+// No test for singularity or near-singularity is included in this routine.
+// Such tests must be performed before calling this routine.
+func (Implementation) Ztrsm(side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if side != blas.Left && side != blas.Right {
+		panic(badSide)
+	}
+	checkTriangular(uplo, trans, diag)
+	na := m
+	if side == blas.Right {
+		na = n
+	}
+	checkZMatrix('a', na, na, a, lda)
+	checkZMatrix('b', m, n, b, ldb)
+
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			bi := b[i*ldb : i*ldb+n]
+			for j := range bi {
+				bi[j] = 0
+			}
+		}
+		return
+	}
+
+	nonUnit := diag == blas.NonUnit
+	conj := trans == blas.ConjTrans
+	// opA returns the element op(A)[i][j] in the triangle of op(A).
+	opA := func(i, j int) complex128 {
+		if trans == blas.NoTrans {
+			return a[i*lda+j]
+		}
+		if conj {
+			return cmplx.Conj(a[j*lda+i])
+		}
+		return a[j*lda+i]
+	}
+	// opUpper is whether op(A) is upper triangular.
+	opUpper := (uplo == blas.Upper) == (trans == blas.NoTrans)
+
+	if side == blas.Left {
+		// Substitute rows of X starting from the end that does not
+		// depend on the unknown rows.
+		for k := 0; k < m; k++ {
+			i := m - 1 - k
+			if !opUpper {
+				i = k
+			}
+			bi := b[i*ldb : i*ldb+n]
+			if alpha != 1 {
+				c128.ScalUnitary(alpha, bi)
+			}
+			lmin, lmax := i+1, m
+			if !opUpper {
+				lmin, lmax = 0, i
+			}
+			for l := lmin; l < lmax; l++ {
+				c128.AxpyUnitary(-opA(i, l), b[l*ldb:l*ldb+n], bi)
+			}
+			if nonUnit {
+				aii := opA(i, i)
+				for j := range bi {
+					bi[j] /= aii
+				}
+			}
+		}
+		return
+	}
+
+	// Each row of X is solved independently from the right.
+	for i := 0; i < m; i++ {
+		bi := b[i*ldb : i*ldb+n]
+		for k := 0; k < n; k++ {
+			j := k
+			if !opUpper {
+				j = n - 1 - k
+			}
+			// Column j of op(A) has its non-zero elements in rows
+			// l <= j if op(A) is upper triangular and l >= j otherwise.
+			lmin, lmax := 0, j
+			if !opUpper {
+				lmin, lmax = j+1, n
+			}
+			sum := alpha * bi[j]
+			for l := lmin; l < lmax; l++ {
+				sum -= bi[l] * opA(l, j)
+			}
+			if nonUnit {
+				sum /= opA(j, j)
+			}
+			bi[j] = sum
+		}
+	}
+}
@@ -71,6 +71,26 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	if s == blas.Left {
+		if bs, nw, ok := parallelize(m, n); ok {
+			// The columns of B are independent, so blocks of
+			// columns are computed concurrently.
+			parallelFor(blocks(n, bs), nw, func(j int) {
+				j *= bs
+				Implementation{}.Dtrsm(s, ul, tA, d, m, min(bs, n-j), alpha, a, lda, b[j:], ldb)
+			})
+			return
+		}
+	} else if bs, nw, ok := parallelize(n, m); ok {
+		// The rows of B are independent, so blocks of rows are
+		// computed concurrently.
+		parallelFor(blocks(m, bs), nw, func(i int) {
+			i *= bs
+			Implementation{}.Dtrsm(s, ul, tA, d, min(bs, m-i), n, alpha, a, lda, b[i*ldb:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -190,7 +210,7 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 			return
 		}
 		for i := 0; i < m; i++ {
-			btmp := b[i*lda : i*lda+n]
+			btmp := b[i*ldb : i*ldb+n]
 			if alpha != 1 {
 				for j := 0; j < n; j++ {
 					btmp[j] *= alpha
@@ -210,7 +230,7 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	// Cases where a is transposed.
 	if ul == blas.Upper {
 		for i := 0; i < m; i++ {
-			btmp := b[i*lda : i*lda+n]
+			btmp := b[i*ldb : i*ldb+n]
 			for j := n - 1; j >= 0; j-- {
 				tmp := alpha*btmp[j] - f64.DotUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:])
 				if nonUnit {
@@ -222,7 +242,7 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 	for i := 0; i < m; i++ {
-		btmp := b[i*lda : i*lda+n]
+		btmp := b[i*ldb : i*ldb+n]
 		for j := 0; j < n; j++ {
 			tmp := alpha*btmp[j] - f64.DotUnitary(a[j*lda:j*lda+j], btmp)
 			if nonUnit {
@@ -430,6 +450,36 @@ func (Implementation) Dsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	if bs, nw, ok := parallelize(n, n); ok {
+		// Partition C into blocks. The blocks on the diagonal are
+		// rank-k updates of smaller symmetric matrices and the blocks
+		// off the diagonal are general matrix multiplications.
+		nb := blocks(n, bs)
+		parallelFor(nb*nb, nw, func(ij int) {
+			i := (ij / nb) * bs
+			j := (ij % nb) * bs
+			if (ul == blas.Upper && j < i) || (ul == blas.Lower && i < j) {
+				return
+			}
+			li := min(bs, n-i)
+			cij := c[i*ldc+j:]
+			if i == j {
+				if tA == blas.NoTrans {
+					Implementation{}.Dsyrk(ul, tA, li, k, alpha, a[i*lda:], lda, beta, cij, ldc)
+				} else {
+					Implementation{}.Dsyrk(ul, tA, li, k, alpha, a[i:], lda, beta, cij, ldc)
+				}
+				return
+			}
+			lj := min(bs, n-j)
+			if tA == blas.NoTrans {
+				Implementation{}.Dgemm(blas.NoTrans, blas.Trans, li, lj, k, alpha, a[i*lda:], lda, a[j*lda:], lda, beta, cij, ldc)
+			} else {
+				Implementation{}.Dgemm(blas.Trans, blas.NoTrans, li, lj, k, alpha, a[i:], lda, a[j:], lda, beta, cij, ldc)
+			}
+		})
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -680,6 +730,26 @@ func (Implementation) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if s == blas.Left {
+		if bs, nw, ok := parallelize(m, n); ok {
+			// The columns of B are independent, so blocks of
+			// columns are computed concurrently.
+			parallelFor(blocks(n, bs), nw, func(j int) {
+				j *= bs
+				Implementation{}.Dtrmm(s, ul, tA, d, m, min(bs, n-j), alpha, a, lda, b[j:], ldb)
+			})
+			return
+		}
+	} else if bs, nw, ok := parallelize(n, m); ok {
+		// The rows of B are independent, so blocks of rows are
+		// computed concurrently.
+		parallelFor(blocks(m, bs), nw, func(i int) {
+			i *= bs
+			Implementation{}.Dtrmm(s, ul, tA, d, min(bs, m-i), n, alpha, a, lda, b[i*ldb:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
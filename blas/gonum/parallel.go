@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultBlockSize is the default size of the square blocks into which the
+// level 3 routines partition their matrices.
+const defaultBlockSize = 64
+
+var (
+	// numWorkers is the maximum number of goroutines used by the level 3
+	// routines. If numWorkers is not positive, runtime.GOMAXPROCS(0) is used.
+	numWorkers int64
+
+	// parBlockSize is the size of the blocks used by the level 3 routines.
+	parBlockSize int64 = defaultBlockSize
+)
+
+// SetWorkers sets the maximum number of goroutines used by the level 3
+// routines Dgemm, Dsyrk, Dtrmm and Dtrsm, and their single precision
+// counterparts, and returns the previous setting. If n is zero or negative,
+// the value of runtime.GOMAXPROCS(0) at the time of each call is used, which
+// is the default. A value of one makes the routines run serially.
+//
+// SetWorkers is safe to call concurrently with the BLAS routines. Calls that
+// are already running are not affected.
+func SetWorkers(n int) (prev int) {
+	return int(atomic.SwapInt64(&numWorkers, int64(n)))
+}
+
+// SetBlockSize sets the size of the square blocks into which the level 3
+// routines Dgemm, Dsyrk, Dtrmm and Dtrsm, and their single precision
+// counterparts, partition their matrices for concurrent computation, and
+// returns the previous setting. The default block size is 64. SetBlockSize
+// panics if b is less than one.
+//
+// SetBlockSize is safe to call concurrently with the BLAS routines. Calls that
+// are already running are not affected.
+func SetBlockSize(b int) (prev int) {
+	if b < 1 {
+		panic("blas: block size < 1")
+	}
+	return int(atomic.SwapInt64(&parBlockSize, int64(b)))
+}
+
+// parallelParams returns the current block size and number of workers.
+func parallelParams() (blockSize, nWorkers int) {
+	blockSize = int(atomic.LoadInt64(&parBlockSize))
+	nWorkers = int(atomic.LoadInt64(&numWorkers))
+	if nWorkers <= 0 {
+		nWorkers = runtime.GOMAXPROCS(0)
+	}
+	return blockSize, nWorkers
+}
+
+// parallelize returns the block size and number of workers to use for an
+// operation on an m×n matrix that is split into independent blocks of
+// columns, or rows, along the dimension n. ok is false if the operation
+// should be computed serially.
+func parallelize(m, n int) (blockSize, nWorkers int, ok bool) {
+	blockSize, nWorkers = parallelParams()
+	nb := blocks(n, blockSize)
+	if nWorkers < 2 || nb < 2 || blocks(m, blockSize)*nb < minParBlock {
+		return blockSize, nWorkers, false
+	}
+	return blockSize, min(nWorkers, nb), true
+}
+
+// parallelFor calls fn(i) for all i in [0, n) using nWorkers goroutines and
+// returns when all the calls have returned. The calls must be independent.
+func parallelFor(n, nWorkers int, fn func(i int)) {
+	nWorkers = min(nWorkers, n)
+	// There is a tradeoff between the workers having to wait for work
+	// and a large buffer making operations slow.
+	work := make(chan int, min(buffMul*nWorkers, n))
+	var wg sync.WaitGroup
+	for w := 0; w < nWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
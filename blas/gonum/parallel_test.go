@@ -0,0 +1,135 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// withParallel calls fn serially and then concurrently with the given block
+// size, restoring the package settings on return.
+func withParallel(blockSize int, fn func()) {
+	prevWorkers := SetWorkers(1)
+	prevBlockSize := SetBlockSize(blockSize)
+	defer func() {
+		SetWorkers(prevWorkers)
+		SetBlockSize(prevBlockSize)
+	}()
+	fn()
+	SetWorkers(4)
+	fn()
+}
+
+func TestParallelLevel3(t *testing.T) {
+	const blockSize = 7
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{5, 30},
+		{30, 5},
+		{blockSize, 4 * blockSize},
+		{2*blockSize + 3, 3*blockSize - 1},
+		{50, 50},
+	} {
+		m, n := test.m, test.n
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := m
+			if s == blas.Right {
+				k = n
+			}
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						name := fmt.Sprintf("m=%d,n=%d,s=%c,ul=%c,tA=%c,d=%c", m, n, s, ul, tA, d)
+						// Make A diagonally dominant so that the
+						// triangular solves are well-conditioned
+						// also for a unit diagonal.
+						a := randmat(k, k, k+3)
+						for i := range a.data {
+							a.data[i] /= float64(k)
+						}
+						for i := 0; i < k; i++ {
+							a.data[i*a.stride+i]++
+						}
+						b := randmat(m, n, n+2)
+
+						var want, got general64
+						withParallel(blockSize, func() {
+							bb := b.clone()
+							Implementation{}.Dtrmm(s, ul, tA, d, m, n, 1.5, a.data, a.stride, bb.data, bb.stride)
+							Implementation{}.Dtrsm(s, ul, tA, d, m, n, 0.5, a.data, a.stride, bb.data, bb.stride)
+							if want.data == nil {
+								want = bb
+							} else {
+								got = bb
+							}
+						})
+						if !got.equalWithinAbs(want, 1e-12) {
+							t.Errorf("%s: Dtrmm and Dtrsm mismatch between parallel and serial", name)
+						}
+					}
+				}
+			}
+		}
+
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				name := fmt.Sprintf("n=%d,k=%d,ul=%c,tA=%c", n, m, ul, tA)
+				a := randmat(n, m, m+1)
+				if tA == blas.Trans {
+					a = randmat(m, n, n+1)
+				}
+				c := randmat(n, n, n+2)
+
+				var want, got general64
+				withParallel(blockSize, func() {
+					cc := c.clone()
+					Implementation{}.Dsyrk(ul, tA, n, m, 1.5, a.data, a.stride, 0.5, cc.data, cc.stride)
+					if want.data == nil {
+						want = cc
+					} else {
+						got = cc
+					}
+				})
+				if !got.equalWithinAbs(want, 1e-12) {
+					t.Errorf("%s: Dsyrk mismatch between parallel and serial", name)
+				}
+				// The other triangle of C must not be referenced.
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						if (ul == blas.Upper && j < i) || (ul == blas.Lower && i < j) {
+							if got.data[i*got.stride+j] != c.data[i*c.stride+j] {
+								t.Errorf("%s: Dsyrk modified the wrong triangle", name)
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSetBlockSize(t *testing.T) {
+	prev := SetBlockSize(13)
+	if prev != defaultBlockSize {
+		t.Errorf("unexpected default block size: got %d want %d", prev, defaultBlockSize)
+	}
+	if got := SetBlockSize(prev); got != 13 {
+		t.Errorf("unexpected block size: got %d want 13", got)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic for zero block size")
+			}
+		}()
+		SetBlockSize(0)
+	}()
+}
@@ -29,7 +29,7 @@ func TestDgemmParallel(t *testing.T) {
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize*2 + 5,
+			m:     defaultBlockSize*2 + 5,
 			n:     3,
 			k:     2,
 			alpha: 2.5,
@@ -38,7 +38,7 @@ func TestDgemmParallel(t *testing.T) {
 		},
 		{
 			m:     3,
-			n:     blockSize * 2,
+			n:     defaultBlockSize * 2,
 			k:     2,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
@@ -47,13 +47,13 @@ func TestDgemmParallel(t *testing.T) {
 		{
 			m:     2,
 			n:     3,
-			k:     blockSize*3 - 2,
+			k:     defaultBlockSize*3 - 2,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize * minParBlock,
+			m:     defaultBlockSize * minParBlock,
 			n:     3,
 			k:     2,
 			alpha: 2.5,
@@ -62,7 +62,7 @@ func TestDgemmParallel(t *testing.T) {
 		},
 		{
 			m:     3,
-			n:     blockSize * minParBlock,
+			n:     defaultBlockSize * minParBlock,
 			k:     2,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
@@ -71,14 +71,14 @@ func TestDgemmParallel(t *testing.T) {
 		{
 			m:     2,
 			n:     3,
-			k:     blockSize * minParBlock,
+			k:     defaultBlockSize * minParBlock,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize*minParBlock + 1,
-			n:     blockSize * minParBlock,
+			m:     defaultBlockSize*minParBlock + 1,
+			n:     defaultBlockSize * minParBlock,
 			k:     3,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
@@ -86,32 +86,32 @@ func TestDgemmParallel(t *testing.T) {
 		},
 		{
 			m:     3,
-			n:     blockSize*minParBlock + 2,
-			k:     blockSize * 3,
+			n:     defaultBlockSize*minParBlock + 2,
+			k:     defaultBlockSize * 3,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize * minParBlock,
+			m:     defaultBlockSize * minParBlock,
 			n:     3,
-			k:     blockSize * minParBlock,
+			k:     defaultBlockSize * minParBlock,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize * minParBlock,
-			n:     blockSize * minParBlock,
-			k:     blockSize * 3,
+			m:     defaultBlockSize * minParBlock,
+			n:     defaultBlockSize * minParBlock,
+			k:     defaultBlockSize * 3,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
 		{
-			m:     blockSize + blockSize/2,
-			n:     blockSize + blockSize/2,
-			k:     blockSize + blockSize/2,
+			m:     defaultBlockSize + defaultBlockSize/2,
+			n:     defaultBlockSize + defaultBlockSize/2,
+			k:     defaultBlockSize + defaultBlockSize/2,
 			alpha: 2.5,
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
@@ -11,7 +11,7 @@ WARNING='//\
 # Level1 routines.
 
 echo Generating level1single.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level1single.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level1single.go
 cat level1double.go \
 | gofmt -r 'blas.Float64Level1 -> blas.Float32Level1' \
 \
@@ -35,7 +35,7 @@ cat level1double.go \
 >> level1single.go
 
 echo Generating level1single_sdot.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level1single_sdot.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level1single_sdot.go
 cat level1double_ddot.go \
 | gofmt -r 'float64 -> float32' \
 \
@@ -48,7 +48,7 @@ cat level1double_ddot.go \
 >> level1single_sdot.go
 
 echo Generating level1single_dsdot.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level1single_dsdot.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level1single_dsdot.go
 cat level1double_ddot.go \
 | gofmt -r '[]float64 -> []float32' \
 \
@@ -61,7 +61,7 @@ cat level1double_ddot.go \
 >> level1single_dsdot.go
 
 echo Generating level1single_sdsdot.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level1single_sdsdot.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level1single_sdsdot.go
 cat level1double_ddot.go \
 | gofmt -r 'float64 -> float32' \
 \
@@ -79,7 +79,7 @@ cat level1double_ddot.go \
 # Level2 routines.
 
 echo Generating level2single.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level2single.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level2single.go
 cat level2double.go \
 | gofmt -r 'blas.Float64Level2 -> blas.Float32Level2' \
 \
@@ -103,7 +103,7 @@ cat level2double.go \
 # Level3 routines.
 
 echo Generating level3single.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level3single.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level3single.go
 cat level3double.go \
 | gofmt -r 'blas.Float64Level3 -> blas.Float32Level3' \
 \
@@ -114,11 +114,12 @@ cat level3double.go \
 \
 | sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
       -e 's_^// D_// S_' \
+      -e 's_Implementation{}\.D_Implementation{}.S_' \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> level3single.go
 
 echo Generating general_single.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > general_single.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > general_single.go
 cat general_double.go \
 | gofmt -r 'float64 -> float32' \
 \
@@ -131,7 +132,7 @@ cat general_double.go \
 >> general_single.go
 
 echo Generating sgemm.go
-echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > sgemm.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > sgemm.go
 cat dgemm.go \
 | gofmt -r 'float64 -> float32' \
 | gofmt -r 'general64 -> general32' \
@@ -156,3 +157,49 @@ cat dgemm.go \
       -e 's_^// d_// s_' \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> sgemm.go
+
+
+# Complex64 routines.
+
+CWARNING='//\
+// Complex64 implementations are autogenerated and not directly tested.\
+'
+
+for level in 1 2 3; do
+	echo Generating level${level}cmplx64.go
+	echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum"; DO NOT EDIT.\n' > level${level}cmplx64.go
+	cat level${level}cmplx128.go \
+	| gofmt -r 'complex128 -> complex64' \
+	| gofmt -r 'float64 -> float32' \
+	\
+	| gofmt -r 'checkZMatrix -> checkCMatrix' \
+	| gofmt -r 'checkZVector -> checkCVector' \
+	| gofmt -r 'checkZBand -> checkCBand' \
+	| gofmt -r 'dcabs1 -> scabs1' \
+	| gofmt -r 'zhemv -> chemv' \
+	| gofmt -r 'ztrmv -> ctrmv' \
+	| gofmt -r 'ztrsv -> ctrsv' \
+	| gofmt -r 'zsymm -> csymm' \
+	\
+	| gofmt -r 'c128.AxpyInc -> c64.AxpyInc' \
+	| gofmt -r 'c128.AxpyUnitary -> c64.AxpyUnitary' \
+	| gofmt -r 'c128.DotcInc -> c64.DotcInc' \
+	| gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
+	| gofmt -r 'c128.DotuInc -> c64.DotuInc' \
+	| gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+	| gofmt -r 'c128.DscalUnitary -> c64.SscalUnitary' \
+	| gofmt -r 'c128.ScalInc -> c64.ScalInc' \
+	| gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
+	\
+	| sed -e 's_Dzasum_Scasum_g' \
+	      -e 's_Dznrm2_Scnrm2_g' \
+	      -e 's_Izamax_Icamax_g' \
+	      -e 's_Zdscal_Csscal_g' \
+	      -e "s_^\(func (Implementation) \)Z\(.*\)\$_$CWARNING\1C\2_" \
+	      -e "s_^\(func (Implementation) \)\(Scasum\|Scnrm2\|Icamax\|Csscal\)\(.*\)\$_$CWARNING\1\2\3_" \
+	      -e 's_^// Z_// C_' \
+	      -e 's_"math/cmplx"_cmplx "gonum.org/v1/gonum/internal/cmplx64"_' \
+	      -e 's_"math"_math "gonum.org/v1/gonum/internal/math32"_' \
+	      -e 's_"gonum.org/v1/gonum/internal/asm/c128"_"gonum.org/v1/gonum/internal/asm/c64"_' \
+	>> level${level}cmplx64.go
+done
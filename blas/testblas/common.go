@@ -7,6 +7,7 @@ package testblas
 import (
 	"math"
 	"math/cmplx"
+	"math/rand"
 	"testing"
 
 	"gonum.org/v1/gonum/blas"
@@ -314,3 +315,195 @@ func max(a, b int) int {
 	}
 	return a
 }
+
+// randZ returns a slice of n random complex numbers whose real and imaginary
+// parts are uniformly distributed in [-1, 1).
+func randZ(n int, rnd *rand.Rand) []complex128 {
+	z := make([]complex128, n)
+	for i := range z {
+		z[i] = complex(2*rnd.Float64()-1, 2*rnd.Float64()-1)
+	}
+	return z
+}
+
+// zStrided returns the n element vector data stored with the increment inc
+// as expected by the BLAS routines. The elements not in the vector are NaN.
+func zStrided(data []complex128, inc int) []complex128 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	x := make([]complex128, (n-1)*abs(inc)+1)
+	for i := range x {
+		x[i] = cmplx.NaN()
+	}
+	for i, v := range data {
+		if inc > 0 {
+			x[i*inc] = v
+		} else {
+			x[(n-1-i)*(-inc)] = v
+		}
+	}
+	return x
+}
+
+// zUnstrided returns the n elements of the vector x with the increment inc.
+func zUnstrided(x []complex128, n, inc int) []complex128 {
+	data := make([]complex128, n)
+	for i := range data {
+		if inc > 0 {
+			data[i] = x[i*inc]
+		} else {
+			data[i] = x[(n-1-i)*(-inc)]
+		}
+	}
+	return data
+}
+
+// zEqualApprox returns whether the slices a and b have the same length and
+// their elements are equal within tol, treating NaN elements as equal.
+func zEqualApprox(a, b []complex128, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		w := b[i]
+		if cmplx.IsNaN(v) || cmplx.IsNaN(w) {
+			if cmplx.IsNaN(v) && cmplx.IsNaN(w) {
+				continue
+			}
+			return false
+		}
+		if cmplx.Abs(v-w) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// zOp returns the element op(A)[i][j] of the matrix A stored in a with the
+// stride lda.
+func zOp(trans blas.Transpose, a []complex128, lda, i, j int) complex128 {
+	switch trans {
+	case blas.NoTrans:
+		return a[i*lda+j]
+	case blas.Trans:
+		return a[j*lda+i]
+	case blas.ConjTrans:
+		return cmplx.Conj(a[j*lda+i])
+	}
+	panic("bad trans")
+}
+
+// zMul returns the m×n product op(A)*op(B), with op(A) m×k, as a dense matrix
+// with the stride n.
+func zMul(tA, tB blas.Transpose, m, n, k int, a []complex128, lda int, b []complex128, ldb int) []complex128 {
+	c := make([]complex128, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var sum complex128
+			for l := 0; l < k; l++ {
+				sum += zOp(tA, a, lda, i, l) * zOp(tB, b, ldb, l, j)
+			}
+			c[i*n+j] = sum
+		}
+	}
+	return c
+}
+
+// zTriangular returns the n×n dense triangular matrix with the stride n
+// formed from the uplo triangle of a with unit or non-unit diagonal.
+func zTriangular(uplo blas.Uplo, diag blas.Diag, n int, a []complex128, lda int) []complex128 {
+	t := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				continue
+			}
+			t[i*n+j] = a[i*lda+j]
+		}
+		if diag == blas.Unit {
+			t[i*n+i] = 1
+		}
+	}
+	return t
+}
+
+// zHermitian returns the n×n dense Hermitian matrix, or symmetric matrix if
+// herm is false, with the stride n formed from the uplo triangle of a.
+func zHermitian(uplo blas.Uplo, herm bool, n int, a []complex128, lda int) []complex128 {
+	h := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var v complex128
+			if uplo == blas.Upper {
+				v = a[i*lda+j]
+			} else {
+				v = a[j*lda+i]
+				if herm {
+					v = cmplx.Conj(v)
+				}
+			}
+			if herm && i == j {
+				v = complex(real(v), 0)
+			}
+			h[i*n+j] = v
+			if herm {
+				h[j*n+i] = cmplx.Conj(v)
+			} else {
+				h[j*n+i] = v
+			}
+		}
+	}
+	return h
+}
+
+// zPack returns the uplo triangle of the n×n matrix a in row-major packed
+// form.
+func zPack(uplo blas.Uplo, n int, a []complex128, lda int) []complex128 {
+	var ap []complex128
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			ap = append(ap, a[i*lda+i:i*lda+n]...)
+		} else {
+			ap = append(ap, a[i*lda:i*lda+i+1]...)
+		}
+	}
+	return ap
+}
+
+// zBand returns the band of the m×n matrix a with kL sub-diagonals and kU
+// super-diagonals in row-major band form with the stride ldab, and a copy of
+// a with the elements outside the band set to zero. The unused elements of
+// the band form are NaN.
+func zBand(m, n, kL, kU int, a []complex128, lda, ldab int) (ab, dense []complex128) {
+	ab = make([]complex128, m*ldab)
+	for i := range ab {
+		ab[i] = cmplx.NaN()
+	}
+	dense = make([]complex128, m*n)
+	for i := 0; i < m; i++ {
+		for j := max(0, i-kL); j < min(n, i+kU+1); j++ {
+			ab[i*ldab+j-i+kL] = a[i*lda+j]
+			dense[i*n+j] = a[i*lda+j]
+		}
+	}
+	return ab, dense
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// zDense returns a copy of the m×n matrix a as a dense matrix with the
+// stride n.
+func zDense(m, n int, a []complex128, lda int) []complex128 {
+	d := make([]complex128, m*n)
+	for i := 0; i < m; i++ {
+		copy(d[i*n:i*n+n], a[i*lda:i*lda+n])
+	}
+	return d
+}
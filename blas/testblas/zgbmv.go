@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgbmver interface {
+	Zgbmv(trans blas.Transpose, m, n, kL, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZgbmvTest(t *testing.T, impl Zgbmver) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, kL, kU int
+	}{
+		{0, 0, 0, 0},
+		{1, 1, 0, 0},
+		{3, 3, 1, 1},
+		{5, 3, 2, 0},
+		{3, 5, 0, 2},
+		{7, 6, 3, 2},
+		{6, 7, 1, 4},
+		{10, 10, 9, 9},
+	} {
+		m, n, kL, kU := test.m, test.n, test.kL, test.kU
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, inc := range []int{-3, 1, 2} {
+				for _, beta := range []complex128{0, 1, 0.5 - 2i} {
+					name := fmt.Sprintf("m=%v,n=%v,kL=%v,kU=%v,trans=%v,inc=%v,beta=%v", m, n, kL, kU, trans, inc, beta)
+					lenX, lenY := n, m
+					if trans != blas.NoTrans {
+						lenX, lenY = m, n
+					}
+					alpha := complex(1.5, -0.5)
+					ldab := kL + kU + 1 + 2
+					ab, dense := zBand(m, n, kL, kU, randZ(m*n, rnd), max(1, n), ldab)
+					xd := randZ(lenX, rnd)
+					yd := randZ(lenY, rnd)
+					x := zStrided(xd, inc)
+					y := zStrided(yd, -inc)
+
+					want := make([]complex128, lenY)
+					ax := zMul(trans, blas.NoTrans, lenY, 1, lenX, dense, max(1, n), xd, 1)
+					for i := range want {
+						want[i] = alpha*ax[i] + beta*yd[i]
+					}
+
+					impl.Zgbmv(trans, m, n, kL, kU, alpha, ab, ldab, x, inc, beta, y, -inc)
+					if !zEqualApprox(zUnstrided(y, lenY, -inc), want, tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
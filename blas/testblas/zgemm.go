@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgemmer interface {
+	Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZgemmTest(t *testing.T, impl Zgemmer) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{0, 0, 0},
+		{0, 3, 2},
+		{1, 1, 1},
+		{3, 1, 2},
+		{2, 5, 0},
+		{4, 3, 5},
+		{7, 8, 6},
+	} {
+		m, n, k := test.m, test.n, test.k
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				for _, beta := range []complex128{0, 1, 0.5 - 2i} {
+					name := fmt.Sprintf("m=%v,n=%v,k=%v,tA=%v,tB=%v,beta=%v", m, n, k, tA, tB, beta)
+					alpha := complex(1.5, -0.5)
+					ra, ca := m, k
+					if tA != blas.NoTrans {
+						ra, ca = k, m
+					}
+					rb, cb := k, n
+					if tB != blas.NoTrans {
+						rb, cb = n, k
+					}
+					lda, ldb, ldc := ca+2, cb+3, n+4
+					a := randZ(ra*lda, rnd)
+					b := randZ(rb*ldb, rnd)
+					c := randZ(m*ldc, rnd)
+
+					want := zMul(tA, tB, m, n, k, a, lda, b, ldb)
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							want[i*n+j] = alpha*want[i*n+j] + beta*c[i*ldc+j]
+						}
+					}
+
+					impl.Zgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+					if !zEqualApprox(zDense(m, n, c, ldc), want, tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
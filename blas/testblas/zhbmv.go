@@ -0,0 +1,29 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhbmver interface {
+	Zhbmv(uplo blas.Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhbmvTest(t *testing.T, impl Zhbmver) {
+	for _, k := range []int{0, 1, 2, 5} {
+		testZhemv(t, "Zhbmv", k, func(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+			// Store the uplo triangle of A in band form.
+			kL, kU := 0, k
+			if uplo == blas.Lower {
+				kL, kU = k, 0
+			}
+			ab, _ := zBand(n, n, kL, kU, a, lda, k+1)
+			impl.Zhbmv(uplo, n, k, alpha, ab, k+1, x, incX, beta, y, incY)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhemmer interface {
+	Zhemm(side blas.Side, uplo blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZhemmTest(t *testing.T, impl Zhemmer) {
+	testZsymm(t, "Zhemm", true, impl.Zhemm)
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhemver interface {
+	Zhemv(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhemvTest(t *testing.T, impl Zhemver) {
+	testZhemv(t, "Zhemv", -1, func(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+		impl.Zhemv(uplo, n, alpha, a, lda, x, incX, beta, y, incY)
+	})
+}
+
+// testZhemv tests a Hermitian matrix-vector multiplication. The function
+// hemv is called with a dense n×n matrix a of which only the uplo triangle
+// may be referenced. If k is not negative, the elements of A outside the k
+// off-diagonals are zero.
+func testZhemv(t *testing.T, routine string, k int, hemv func(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 10} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, inc := range []int{-3, 1, 2} {
+				for _, beta := range []complex128{0, 1, 0.5 - 2i} {
+					name := fmt.Sprintf("%v: n=%v,k=%v,uplo=%v,inc=%v,beta=%v", routine, n, k, uplo, inc, beta)
+					alpha := complex(1.5, -0.5)
+					lda := n + 3
+					a := randZ(n*lda, rnd)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if k >= 0 && (i-j > k || j-i > k) {
+								a[i*lda+j] = 0
+							}
+						}
+					}
+					h := zHermitian(uplo, true, n, a, lda)
+					// The other triangle and the imaginary parts of
+					// the diagonal must not be referenced.
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+								a[i*lda+j] = cmplx.NaN()
+							}
+						}
+						a[i*lda+i] = complex(real(a[i*lda+i]), -7)
+					}
+					xd := randZ(n, rnd)
+					yd := randZ(n, rnd)
+					x := zStrided(xd, inc)
+					y := zStrided(yd, -inc)
+
+					want := zMul(blas.NoTrans, blas.NoTrans, n, 1, n, h, n, xd, 1)
+					for i := range want {
+						want[i] = alpha*want[i] + beta*yd[i]
+					}
+
+					hemv(uplo, n, alpha, a, lda, x, inc, beta, y, -inc)
+					if !zEqualApprox(zUnstrided(y, n, -inc), want, tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zher2ker interface {
+	Zher2k(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int)
+}
+
+func Zher2kTest(t *testing.T, impl Zher2ker) {
+	testZsyrk(t, "Zher2k", true, true, func(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+		impl.Zher2k(uplo, trans, n, k, alpha, a, lda, b, ldb, real(beta), c, ldc)
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zherker interface {
+	Zherk(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int)
+}
+
+func ZherkTest(t *testing.T, impl Zherker) {
+	testZsyrk(t, "Zherk", true, false, func(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, _ []complex128, _ int, beta complex128, c []complex128, ldc int) {
+		impl.Zherk(uplo, trans, n, k, real(alpha), a, lda, real(beta), c, ldc)
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhpmver interface {
+	Zhpmv(uplo blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhpmvTest(t *testing.T, impl Zhpmver) {
+	testZhemv(t, "Zhpmv", -1, func(uplo blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+		impl.Zhpmv(uplo, n, alpha, zPack(uplo, n, a, lda), x, incX, beta, y, incY)
+	})
+}
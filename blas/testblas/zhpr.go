@@ -0,0 +1,51 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhprer interface {
+	Zhpr(uplo blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128)
+}
+
+func ZhprTest(t *testing.T, impl Zhprer) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 10} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, inc := range []int{-3, 1, 2} {
+				for _, alpha := range []float64{0, 1, -2.5} {
+					name := fmt.Sprintf("n=%v,uplo=%v,inc=%v,alpha=%v", n, uplo, inc, alpha)
+					a := randZ(n*n, rnd)
+					xd := randZ(n, rnd)
+					x := zStrided(xd, inc)
+
+					want := zHermitian(uplo, true, n, a, n)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							want[i*n+j] += complex(alpha, 0) * xd[i] * cmplx.Conj(xd[j])
+						}
+					}
+
+					ap := zPack(uplo, n, a, n)
+					impl.Zhpr(uplo, n, alpha, x, inc, ap)
+					if alpha != 0 && !zEqualApprox(ap, zPack(uplo, n, want, n), tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+					if alpha == 0 && !zsame(ap, zPack(uplo, n, a, n)) {
+						t.Errorf("%v: unexpected modification of A", name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zhpr2er interface {
+	Zhpr2(uplo blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128)
+}
+
+func Zhpr2Test(t *testing.T, impl Zhpr2er) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 10} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, inc := range []int{-3, 1, 2} {
+				for _, alpha := range []complex128{0, 1, 1.5 - 2i} {
+					name := fmt.Sprintf("n=%v,uplo=%v,inc=%v,alpha=%v", n, uplo, inc, alpha)
+					a := randZ(n*n, rnd)
+					xd := randZ(n, rnd)
+					yd := randZ(n, rnd)
+					x := zStrided(xd, inc)
+					y := zStrided(yd, -inc)
+
+					want := zHermitian(uplo, true, n, a, n)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							want[i*n+j] += alpha*xd[i]*cmplx.Conj(yd[j]) + cmplx.Conj(alpha)*yd[i]*cmplx.Conj(xd[j])
+						}
+					}
+
+					ap := zPack(uplo, n, a, n)
+					impl.Zhpr2(uplo, n, alpha, x, inc, y, -inc, ap)
+					if alpha != 0 && !zEqualApprox(ap, zPack(uplo, n, want, n), tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+					if alpha == 0 && !zsame(ap, zPack(uplo, n, a, n)) {
+						t.Errorf("%v: unexpected modification of A", name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zsymmer interface {
+	Zsymm(side blas.Side, uplo blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZsymmTest(t *testing.T, impl Zsymmer) {
+	testZsymm(t, "Zsymm", false, impl.Zsymm)
+}
+
+// testZsymm tests a symmetric, or if herm is true, a Hermitian matrix-matrix
+// multiplication.
+func testZsymm(t *testing.T, routine string, herm bool, symm func(side blas.Side, uplo blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0},
+		{0, 3},
+		{1, 1},
+		{3, 1},
+		{2, 5},
+		{7, 6},
+	} {
+		m, n := test.m, test.n
+		for _, side := range []blas.Side{blas.Left, blas.Right} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, beta := range []complex128{0, 1, 0.5 - 2i} {
+					name := fmt.Sprintf("%v: m=%v,n=%v,side=%v,uplo=%v,beta=%v", routine, m, n, side, uplo, beta)
+					alpha := complex(1.5, -0.5)
+					na := m
+					if side == blas.Right {
+						na = n
+					}
+					lda, ldb, ldc := na+2, n+3, n+4
+					a := randZ(na*lda, rnd)
+					b := randZ(m*ldb, rnd)
+					c := randZ(m*ldc, rnd)
+					s := zHermitian(uplo, herm, na, a, lda)
+					// The other triangle must not be referenced.
+					for i := 0; i < na; i++ {
+						for j := 0; j < na; j++ {
+							if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+								a[i*lda+j] = cmplx.NaN()
+							}
+						}
+					}
+
+					var want []complex128
+					if side == blas.Left {
+						want = zMul(blas.NoTrans, blas.NoTrans, m, n, m, s, na, b, ldb)
+					} else {
+						want = zMul(blas.NoTrans, blas.NoTrans, m, n, n, b, ldb, s, na)
+					}
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							want[i*n+j] = alpha*want[i*n+j] + beta*c[i*ldc+j]
+						}
+					}
+
+					symm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+					if !zEqualApprox(zDense(m, n, c, ldc), want, tol) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zsyr2ker interface {
+	Zsyr2k(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func Zsyr2kTest(t *testing.T, impl Zsyr2ker) {
+	testZsyrk(t, "Zsyr2k", false, true, impl.Zsyr2k)
+}
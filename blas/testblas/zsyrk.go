@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zsyrker interface {
+	Zsyrk(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int)
+}
+
+func ZsyrkTest(t *testing.T, impl Zsyrker) {
+	testZsyrk(t, "Zsyrk", false, false, func(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, _ []complex128, _ int, beta complex128, c []complex128, ldc int) {
+		impl.Zsyrk(uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+	})
+}
+
+// testZsyrk tests a symmetric, or if herm is true, a Hermitian rank-k update,
+// or if two is true, a rank-2k update. For Hermitian updates beta is real, and
+// alpha is real if two is false.
+func testZsyrk(t *testing.T, routine string, herm, two bool, syrk func(uplo blas.Uplo, trans blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	tr := blas.Trans
+	if herm {
+		tr = blas.ConjTrans
+	}
+	for _, test := range []struct {
+		n, k int
+	}{
+		{0, 0},
+		{0, 3},
+		{1, 1},
+		{3, 0},
+		{3, 1},
+		{2, 5},
+		{7, 6},
+	} {
+		n, k := test.n, test.k
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, tr} {
+				for _, beta := range []complex128{0, 1, 0.5 - 2i} {
+					for _, alpha := range []complex128{0, 1.5 - 0.5i} {
+						if herm {
+							beta = complex(real(beta), 0)
+							if !two {
+								alpha = complex(real(alpha), 0)
+							}
+						}
+						name := fmt.Sprintf("%v: n=%v,k=%v,uplo=%v,trans=%v,alpha=%v,beta=%v", routine, n, k, uplo, trans, alpha, beta)
+						ra, ca := n, k
+						if trans != blas.NoTrans {
+							ra, ca = k, n
+						}
+						lda, ldb, ldc := ca+2, ca+3, n+4
+						a := randZ(ra*lda, rnd)
+						b := randZ(ra*ldb, rnd)
+						c := randZ(n*ldc, rnd)
+						// The other triangle must not be referenced.
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+									c[i*ldc+j] = cmplx.NaN()
+								}
+							}
+						}
+
+						// Form the products with A in the
+						// position of op(A) * op(A)^T.
+						tA, tB := blas.NoTrans, tr
+						if trans != blas.NoTrans {
+							tA, tB = tr, blas.NoTrans
+						}
+						var want []complex128
+						if two {
+							ab := zMul(tA, tB, n, n, k, a, lda, b, ldb)
+							ba := zMul(tA, tB, n, n, k, b, ldb, a, lda)
+							want = make([]complex128, n*n)
+							for i := range want {
+								if herm {
+									want[i] = alpha*ab[i] + cmplx.Conj(alpha)*ba[i]
+								} else {
+									want[i] = alpha * (ab[i] + ba[i])
+								}
+							}
+						} else {
+							want = zMul(tA, tB, n, n, k, a, lda, a, lda)
+							for i := range want {
+								want[i] *= alpha
+							}
+						}
+						// The imaginary parts of the diagonal of C
+						// are zeroed unless the update is a no-op.
+						zeroImag := herm && !((alpha == 0 || k == 0) && beta == 1)
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								cij := c[i*ldc+j]
+								switch {
+								case cmplx.IsNaN(cij):
+									want[i*n+j] = cij
+									continue
+								case zeroImag && i == j:
+									cij = complex(real(cij), 0)
+								}
+								want[i*n+j] += beta * cij
+							}
+							if zeroImag {
+								want[i*n+i] = complex(real(want[i*n+i]), 0)
+							}
+						}
+
+						syrk(uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+						if !zEqualApprox(zDense(n, n, c, ldc), want, tol) {
+							t.Errorf("%v: unexpected result", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztbmver interface {
+	Ztbmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtbmvTest(t *testing.T, impl Ztbmver) {
+	for _, k := range []int{0, 1, 2, 5} {
+		testZtrmv(t, "Ztbmv", k, false, func(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+			// Store the uplo triangle of A in band form.
+			kL, kU := 0, k
+			if uplo == blas.Lower {
+				kL, kU = k, 0
+			}
+			ab, _ := zBand(n, n, kL, kU, a, lda, k+1)
+			impl.Ztbmv(uplo, trans, diag, n, k, ab, k+1, x, incX)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztpmver interface {
+	Ztpmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, ap []complex128, x []complex128, incX int)
+}
+
+func ZtpmvTest(t *testing.T, impl Ztpmver) {
+	testZtrmv(t, "Ztpmv", -1, false, func(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+		impl.Ztpmv(uplo, trans, diag, n, zPack(uplo, n, a, lda), x, incX)
+	})
+}
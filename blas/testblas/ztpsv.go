@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztpsver interface {
+	Ztpsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, ap []complex128, x []complex128, incX int)
+}
+
+func ZtpsvTest(t *testing.T, impl Ztpsver) {
+	testZtrmv(t, "Ztpsv", -1, true, func(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+		impl.Ztpsv(uplo, trans, diag, n, zPack(uplo, n, a, lda), x, incX)
+	})
+}
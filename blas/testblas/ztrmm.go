@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztrmmer interface {
+	Ztrmm(side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int)
+}
+
+func ZtrmmTest(t *testing.T, impl Ztrmmer) {
+	testZtrmm(t, "Ztrmm", false, impl.Ztrmm)
+}
+
+// testZtrmm tests a triangular matrix-matrix multiplication, or if solve is
+// true, a triangular solve with multiple right-hand sides.
+func testZtrmm(t *testing.T, routine string, solve bool, trmm func(side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int)) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0},
+		{0, 3},
+		{1, 1},
+		{3, 1},
+		{2, 5},
+		{7, 6},
+	} {
+		m, n := test.m, test.n
+		for _, side := range []blas.Side{blas.Left, blas.Right} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+					for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						for _, alpha := range []complex128{0, 1, 1.5 - 0.5i} {
+							name := fmt.Sprintf("%v: m=%v,n=%v,side=%v,uplo=%v,trans=%v,diag=%v,alpha=%v", routine, m, n, side, uplo, trans, diag, alpha)
+							na := m
+							if side == blas.Right {
+								na = n
+							}
+							lda, ldb := na+2, n+3
+							a := randZ(na*lda, rnd)
+							for i := 0; i < na; i++ {
+								// Keep the triangular solves
+								// well-conditioned.
+								for j := 0; j < na; j++ {
+									a[i*lda+j] /= complex(float64(na), 0)
+								}
+								a[i*lda+i] += 2
+							}
+							tri := zTriangular(uplo, diag, na, a, lda)
+							// The other triangle must not be referenced,
+							// nor the diagonal if it is unit.
+							for i := 0; i < na; i++ {
+								for j := 0; j < na; j++ {
+									if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) || (i == j && diag == blas.Unit) {
+										a[i*lda+j] = cmplx.NaN()
+									}
+								}
+							}
+							b := randZ(m*ldb, rnd)
+							bd := zDense(m, n, b, ldb)
+
+							trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+
+							got := zDense(m, n, b, ldb)
+							var want []complex128
+							if solve {
+								// Check that op(A) * X = alpha * B
+								// or X * op(A) = alpha * B.
+								if side == blas.Left {
+									got = zMul(trans, blas.NoTrans, m, n, m, tri, na, got, n)
+								} else {
+									got = zMul(blas.NoTrans, trans, m, n, n, got, n, tri, na)
+								}
+								want = bd
+							} else if side == blas.Left {
+								want = zMul(trans, blas.NoTrans, m, n, m, tri, na, bd, n)
+							} else {
+								want = zMul(blas.NoTrans, trans, m, n, n, bd, n, tri, na)
+							}
+							for i := range want {
+								want[i] *= alpha
+							}
+							if !zEqualApprox(got, want, tol) {
+								t.Errorf("%v: unexpected result", name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
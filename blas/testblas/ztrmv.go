@@ -0,0 +1,82 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztrmver interface {
+	Ztrmv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtrmvTest(t *testing.T, impl Ztrmver) {
+	testZtrmv(t, "Ztrmv", -1, false, impl.Ztrmv)
+}
+
+// testZtrmv tests a triangular matrix-vector multiplication, or if solve is
+// true, a triangular solve. The function trmv is called with a dense n×n
+// matrix a of which only the uplo triangle may be referenced. If k is not
+// negative, the elements of A outside the k off-diagonals are zero.
+func testZtrmv(t *testing.T, routine string, k int, solve bool, trmv func(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int)) {
+	const tol = 1e-13
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 10} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, inc := range []int{-3, 1, 2} {
+						name := fmt.Sprintf("%v: n=%v,k=%v,uplo=%v,trans=%v,diag=%v,inc=%v", routine, n, k, uplo, trans, diag, inc)
+						lda := n + 3
+						a := randZ(n*lda, rnd)
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								switch {
+								case k >= 0 && (i-j > k || j-i > k):
+									a[i*lda+j] = 0
+								case i != j:
+									// Keep the triangular solves
+									// well-conditioned.
+									a[i*lda+j] /= complex(float64(n), 0)
+								}
+							}
+							a[i*lda+i] += 2
+						}
+						tri := zTriangular(uplo, diag, n, a, lda)
+						// The other triangle must not be referenced,
+						// nor the diagonal if it is unit.
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) || (i == j && diag == blas.Unit) {
+									a[i*lda+j] = cmplx.NaN()
+								}
+							}
+						}
+						xd := randZ(n, rnd)
+						x := zStrided(xd, inc)
+
+						trmv(uplo, trans, diag, n, a, lda, x, inc)
+
+						got := zUnstrided(x, n, inc)
+						if solve {
+							// Check that op(A) * x = b.
+							got = zMul(trans, blas.NoTrans, n, 1, n, tri, n, got, 1)
+						} else {
+							xd = zMul(trans, blas.NoTrans, n, 1, n, tri, n, xd, 1)
+						}
+						if !zEqualApprox(got, xd, tol) {
+							t.Errorf("%v: unexpected result", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
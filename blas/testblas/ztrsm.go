@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztrsmer interface {
+	Ztrsm(side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int)
+}
+
+func ZtrsmTest(t *testing.T, impl Ztrsmer) {
+	testZtrmm(t, "Ztrsm", true, impl.Ztrsm)
+}
@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Ztrsver interface {
+	Ztrsv(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtrsvTest(t *testing.T, impl Ztrsver) {
+	testZtrmv(t, "Ztrsv", -1, true, impl.Ztrsv)
+}
@@ -12,6 +12,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // qDirected returns the modularity Q score of the graph g subdivided into the
@@ -23,7 +24,7 @@ import (
 //  Q = 1/m \sum_{ij} [ A_{ij} - (\gamma k_i^in k_j^out)/m ] \delta(c_i,c_j)
 //
 func qDirected(g graph.Directed, communities [][]graph.Node, resolution float64) float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	weight := positiveWeightFuncFor(g)
 
 	// Calculate the total edge weight of the graph
@@ -33,12 +34,14 @@ func qDirected(g graph.Directed, communities [][]graph.Node, resolution float64)
 	for _, n := range nodes {
 		var wOut float64
 		u := n
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			wOut += weight(u, v)
 		}
 		var wIn float64
 		v := n
-		for _, u := range g.To(v) {
+		for from := g.To(v); from.Next(); {
+			u := from.Node()
 			wIn += weight(u, v)
 		}
 		w := weight(n, n)
@@ -170,7 +173,7 @@ func reduceDirected(g graph.Directed, communities [][]graph.Node) *ReducedDirect
 			return r
 		}
 
-		nodes := g.Nodes()
+		nodes := graph.NodesOf(g.Nodes())
 		// TODO(kortschak) This sort is necessary really only
 		// for testing. In practice we would not be using the
 		// community provided by the user for a Q calculation.
@@ -202,7 +205,8 @@ func reduceDirected(g graph.Directed, communities [][]graph.Node) *ReducedDirect
 
 			var out []int
 			u := n
-			for _, v := range g.From(u) {
+			for to := g.From(u); to.Next(); {
+				v := to.Node()
 				vid := communityOf[v.ID()]
 				if vid != id {
 					out = append(out, vid)
@@ -213,7 +217,8 @@ func reduceDirected(g graph.Directed, communities [][]graph.Node) *ReducedDirect
 
 			var in []int
 			v := n
-			for _, u := range g.To(v) {
+			for from := g.To(v); from.Next(); {
+				u := from.Node()
 				uid := communityOf[u.ID()]
 				if uid != id {
 					in = append(in, uid)
@@ -273,7 +278,8 @@ func reduceDirected(g graph.Directed, communities [][]graph.Node) *ReducedDirect
 				r.nodes[id].weight += weight(u, v)
 			}
 
-			for _, v := range g.From(u) {
+			for to := g.From(u); to.Next(); {
+				v := to.Node()
 				vid := communityOf[v.ID()]
 				found := false
 				for _, e := range out {
@@ -291,7 +297,8 @@ func reduceDirected(g graph.Directed, communities [][]graph.Node) *ReducedDirect
 			}
 
 			v := n
-			for _, u := range g.To(v) {
+			for from := g.To(v); from.Next(); {
+				u := from.Node()
 				uid := communityOf[u.ID()]
 				found := false
 				for _, e := range in {
@@ -321,32 +328,28 @@ func (g *ReducedDirected) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *ReducedDirected) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	for i := range g.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g *ReducedDirected) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g *ReducedDirected) From(u graph.Node) []graph.Node {
+func (g *ReducedDirected) From(u graph.Node) graph.Nodes {
 	out := g.edgesFrom[u.ID()]
 	nodes := make([]graph.Node, len(out))
 	for i, vid := range out {
 		nodes[i] = g.nodes[vid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // To returns all nodes in g that can reach directly to v.
-func (g *ReducedDirected) To(v graph.Node) []graph.Node {
+func (g *ReducedDirected) To(v graph.Node) graph.Nodes {
 	in := g.edgesTo[v.ID()]
 	nodes := make([]graph.Node, len(in))
 	for i, uid := range in {
 		nodes[i] = g.nodes[uid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -467,7 +470,7 @@ type directedWeights struct {
 // nodes.
 // If g has a zero edge weight sum, nil is returned.
 func newDirectedLocalMover(g *ReducedDirected, communities [][]graph.Node, resolution float64) *directedLocalMover {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	l := directedLocalMover{
 		g:             g,
 		nodes:         nodes,
@@ -483,13 +486,15 @@ func newDirectedLocalMover(g *ReducedDirected, communities [][]graph.Node, resol
 	for _, n := range l.nodes {
 		u := n
 		var wOut float64
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			wOut += l.weight(u, v)
 		}
 
 		v := n
 		var wIn float64
-		for _, u := range g.To(v) {
+		for from := g.To(v); from.Next(); {
+			u := from.Node()
 			wIn += l.weight(u, v)
 		}
 
@@ -13,6 +13,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // DirectedMultiplex is a directed multiplex graph.
@@ -42,7 +43,7 @@ type DirectedMultiplex interface {
 // Note that Q values for multiplex graphs are not scaled by the total layer edge weight.
 func qDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, weights, resolutions []float64) []float64 {
 	q := make([]float64, g.Depth())
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	layerWeight := 1.0
 	layerResolution := 1.0
 	if len(resolutions) == 1 {
@@ -76,12 +77,14 @@ func qDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, weights
 		for _, n := range nodes {
 			var wOut float64
 			u := n
-			for _, v := range layer.From(u) {
+			for to := layer.From(u); to.Next(); {
+				v := to.Node()
 				wOut += weight(u, v)
 			}
 			var wIn float64
 			v := n
-			for _, u := range layer.To(v) {
+			for from := layer.To(v); from.Next(); {
+				u := from.Node()
 				wIn += weight(u, v)
 			}
 			w := weight(n, n)
@@ -125,12 +128,14 @@ func NewDirectedLayers(layers ...graph.Directed) (DirectedLayers, error) {
 		return nil, nil
 	}
 	base := make(set.Int64s)
-	for _, n := range layers[0].Nodes() {
+	for nodes := layers[0].Nodes(); nodes.Next(); {
+		n := nodes.Node()
 		base.Add(n.ID())
 	}
 	for i, l := range layers[1:] {
 		next := make(set.Int64s)
-		for _, n := range l.Nodes() {
+		for nodes := l.Nodes(); nodes.Next(); {
+			n := nodes.Node()
 			next.Add(n.ID())
 		}
 		if !set.Int64sEqual(base, next) {
@@ -141,9 +146,9 @@ func NewDirectedLayers(layers ...graph.Directed) (DirectedLayers, error) {
 }
 
 // Nodes returns the nodes of the receiver.
-func (g DirectedLayers) Nodes() []graph.Node {
+func (g DirectedLayers) Nodes() graph.Nodes {
 	if len(g) == 0 {
-		return nil
+		return graph.Empty
 	}
 	return g[0].Nodes()
 }
@@ -212,12 +217,8 @@ var (
 )
 
 // Nodes returns all the nodes in the graph.
-func (g *ReducedDirectedMultiplex) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	for i := range g.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g *ReducedDirectedMultiplex) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // Depth returns the number of layers in the multiplex graph.
@@ -281,7 +282,7 @@ func reduceDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, we
 			return r
 		}
 
-		nodes := g.Nodes()
+		nodes := graph.NodesOf(g.Nodes())
 		// TODO(kortschak) This sort is necessary really only
 		// for testing. In practice we would not be using the
 		// community provided by the user for a Q calculation.
@@ -331,7 +332,8 @@ func reduceDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, we
 
 				var out []int
 				u := n
-				for _, v := range layer.From(u) {
+				for to := layer.From(u); to.Next(); {
+					v := to.Node()
 					vid := communityOf[v.ID()]
 					if vid != id {
 						out = append(out, vid)
@@ -342,7 +344,8 @@ func reduceDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, we
 
 				var in []int
 				v := n
-				for _, u := range layer.To(v) {
+				for from := layer.To(v); from.Next(); {
+					u := from.Node()
 					uid := communityOf[u.ID()]
 					if uid != id {
 						in = append(in, uid)
@@ -421,7 +424,8 @@ func reduceDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, we
 					r.nodes[id].weights[l] += sign * weight(u, v)
 				}
 
-				for _, v := range layer.From(u) {
+				for to := layer.From(u); to.Next(); {
+					v := to.Node()
 					vid := communityOf[v.ID()]
 					found := false
 					for _, e := range out {
@@ -439,7 +443,8 @@ func reduceDirectedMultiplex(g DirectedMultiplex, communities [][]graph.Node, we
 				}
 
 				v := n
-				for _, u := range layer.To(v) {
+				for from := layer.To(v); from.Next(); {
+					u := from.Node()
 					uid := communityOf[u.ID()]
 					found := false
 					for _, e := range in {
@@ -483,32 +488,28 @@ func (g directedLayerHandle) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g directedLayerHandle) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.multiplex.nodes))
-	for i := range g.multiplex.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g directedLayerHandle) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.multiplex.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g directedLayerHandle) From(u graph.Node) []graph.Node {
+func (g directedLayerHandle) From(u graph.Node) graph.Nodes {
 	out := g.multiplex.layers[g.layer].edgesFrom[u.ID()]
 	nodes := make([]graph.Node, len(out))
 	for i, vid := range out {
 		nodes[i] = g.multiplex.nodes[vid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // To returns all nodes in g that can reach directly to v.
-func (g directedLayerHandle) To(v graph.Node) []graph.Node {
+func (g directedLayerHandle) To(v graph.Node) graph.Nodes {
 	in := g.multiplex.layers[g.layer].edgesTo[v.ID()]
 	nodes := make([]graph.Node, len(in))
 	for i, uid := range in {
 		nodes[i] = g.multiplex.nodes[uid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -637,7 +638,7 @@ type directedMultiplexLocalMover struct {
 // node IDs of g must be contiguous in [0,n) where n is the number of nodes.
 // If g has a zero edge weight sum, nil is returned.
 func newDirectedMultiplexLocalMover(g *ReducedDirectedMultiplex, communities [][]graph.Node, weights, resolutions []float64, all bool) *directedMultiplexLocalMover {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	l := directedMultiplexLocalMover{
 		g:             g,
 		nodes:         nodes,
@@ -677,13 +678,15 @@ func newDirectedMultiplexLocalMover(g *ReducedDirectedMultiplex, communities [][
 		for _, n := range l.nodes {
 			u := n
 			var wOut float64
-			for _, v := range layer.From(u) {
+			for to := layer.From(u); to.Next(); {
+				v := to.Node()
 				wOut += weight(u, v)
 			}
 
 			v := n
 			var wIn float64
-			for _, u := range layer.To(v) {
+			for from := layer.To(v); from.Next(); {
+				u := from.Node()
 				wIn += weight(u, v)
 			}
 
@@ -12,6 +12,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // qUndirected returns the modularity Q score of the graph g subdivided into the
@@ -25,7 +26,7 @@ import (
 // graph.Undirect may be used as a shim to allow calculation of Q for
 // directed graphs.
 func qUndirected(g graph.Undirected, communities [][]graph.Node, resolution float64) float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	weight := positiveWeightFuncFor(g)
 
 	// Calculate the total edge weight of the graph
@@ -34,7 +35,8 @@ func qUndirected(g graph.Undirected, communities [][]graph.Node, resolution floa
 	k := make(map[int64]float64, len(nodes))
 	for _, u := range nodes {
 		w := weight(u, u)
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			w += weight(u, v)
 		}
 		m2 += w
@@ -170,7 +172,7 @@ func reduceUndirected(g graph.Undirected, communities [][]graph.Node) *ReducedUn
 			return r
 		}
 
-		nodes := g.Nodes()
+		nodes := graph.NodesOf(g.Nodes())
 		// TODO(kortschak) This sort is necessary really only
 		// for testing. In practice we would not be using the
 		// community provided by the user for a Q calculation.
@@ -199,7 +201,8 @@ func reduceUndirected(g graph.Undirected, communities [][]graph.Node) *ReducedUn
 		for _, u := range nodes {
 			var out []int
 			uid := communityOf[u.ID()]
-			for _, v := range g.From(u) {
+			for to := g.From(u); to.Next(); {
+				v := to.Node()
 				vid := communityOf[v.ID()]
 				if vid != uid {
 					out = append(out, vid)
@@ -260,7 +263,8 @@ func reduceUndirected(g graph.Undirected, communities [][]graph.Node) *ReducedUn
 			for _, v := range comm[i+1:] {
 				r.nodes[uid].weight += 2 * weight(u, v)
 			}
-			for _, v := range g.From(u) {
+			for to := g.From(u); to.Next(); {
+				v := to.Node()
 				vid := communityOf[v.ID()]
 				found := false
 				for _, e := range out {
@@ -290,22 +294,18 @@ func (g *ReducedUndirected) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *ReducedUndirected) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	for i := range g.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g *ReducedUndirected) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g *ReducedUndirected) From(u graph.Node) []graph.Node {
+func (g *ReducedUndirected) From(u graph.Node) graph.Nodes {
 	out := g.edges[u.ID()]
 	nodes := make([]graph.Node, len(out))
 	for i, vid := range out {
 		nodes[i] = g.nodes[vid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -425,7 +425,7 @@ type undirectedLocalMover struct {
 // node IDs of g must be contiguous in [0,n) where n is the number of nodes.
 // If g has a zero edge weight sum, nil is returned.
 func newUndirectedLocalMover(g *ReducedUndirected, communities [][]graph.Node, resolution float64) *undirectedLocalMover {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	l := undirectedLocalMover{
 		g:            g,
 		nodes:        nodes,
@@ -440,7 +440,8 @@ func newUndirectedLocalMover(g *ReducedUndirected, communities [][]graph.Node, r
 	// and degree weights for each node.
 	for _, u := range l.nodes {
 		w := l.weight(u, u)
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			w += l.weight(u, v)
 		}
 		l.edgeWeightOf[u.ID()] = w
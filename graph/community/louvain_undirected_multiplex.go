@@ -13,6 +13,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // UndirectedMultiplex is an undirected multiplex graph.
@@ -45,7 +46,7 @@ type UndirectedMultiplex interface {
 // directed graphs.
 func qUndirectedMultiplex(g UndirectedMultiplex, communities [][]graph.Node, weights, resolutions []float64) []float64 {
 	q := make([]float64, g.Depth())
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	layerWeight := 1.0
 	layerResolution := 1.0
 	if len(resolutions) == 1 {
@@ -78,7 +79,8 @@ func qUndirectedMultiplex(g UndirectedMultiplex, communities [][]graph.Node, wei
 		k := make(map[int64]float64, len(nodes))
 		for _, u := range nodes {
 			w := weight(u, u)
-			for _, v := range layer.From(u) {
+			for to := layer.From(u); to.Next(); {
+				v := to.Node()
 				w += weight(u, v)
 			}
 			m2 += w
@@ -124,12 +126,14 @@ func NewUndirectedLayers(layers ...graph.Undirected) (UndirectedLayers, error) {
 		return nil, nil
 	}
 	base := make(set.Int64s)
-	for _, n := range layers[0].Nodes() {
+	for nodes := layers[0].Nodes(); nodes.Next(); {
+		n := nodes.Node()
 		base.Add(n.ID())
 	}
 	for i, l := range layers[1:] {
 		next := make(set.Int64s)
-		for _, n := range l.Nodes() {
+		for nodes := l.Nodes(); nodes.Next(); {
+			n := nodes.Node()
 			next.Add(n.ID())
 		}
 		if !set.Int64sEqual(next, base) {
@@ -140,9 +144,9 @@ func NewUndirectedLayers(layers ...graph.Undirected) (UndirectedLayers, error) {
 }
 
 // Nodes returns the nodes of the receiver.
-func (g UndirectedLayers) Nodes() []graph.Node {
+func (g UndirectedLayers) Nodes() graph.Nodes {
 	if len(g) == 0 {
-		return nil
+		return graph.Empty
 	}
 	return g[0].Nodes()
 }
@@ -211,12 +215,8 @@ var (
 )
 
 // Nodes returns all the nodes in the graph.
-func (g *ReducedUndirectedMultiplex) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	for i := range g.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g *ReducedUndirectedMultiplex) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // Depth returns the number of layers in the multiplex graph.
@@ -280,7 +280,7 @@ func reduceUndirectedMultiplex(g UndirectedMultiplex, communities [][]graph.Node
 			return r
 		}
 
-		nodes := g.Nodes()
+		nodes := graph.NodesOf(g.Nodes())
 		// TODO(kortschak) This sort is necessary really only
 		// for testing. In practice we would not be using the
 		// community provided by the user for a Q calculation.
@@ -327,7 +327,8 @@ func reduceUndirectedMultiplex(g UndirectedMultiplex, communities [][]graph.Node
 			for _, u := range nodes {
 				var out []int
 				uid := communityOf[u.ID()]
-				for _, v := range layer.From(u) {
+				for to := layer.From(u); to.Next(); {
+					v := to.Node()
 					vid := communityOf[v.ID()]
 					if vid != uid {
 						out = append(out, vid)
@@ -407,7 +408,8 @@ func reduceUndirectedMultiplex(g UndirectedMultiplex, communities [][]graph.Node
 				for _, v := range comm[i+1:] {
 					r.nodes[uid].weights[l] += 2 * sign * weight(u, v)
 				}
-				for _, v := range layer.From(u) {
+				for to := layer.From(u); to.Next(); {
+					v := to.Node()
 					vid := communityOf[v.ID()]
 					found := false
 					for _, e := range out {
@@ -450,22 +452,18 @@ func (g undirectedLayerHandle) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g undirectedLayerHandle) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.multiplex.nodes))
-	for i := range g.multiplex.nodes {
-		nodes[i] = node(i)
-	}
-	return nodes
+func (g undirectedLayerHandle) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.multiplex.nodes), func(id int) graph.Node { return node(id) })
 }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g undirectedLayerHandle) From(u graph.Node) []graph.Node {
+func (g undirectedLayerHandle) From(u graph.Node) graph.Nodes {
 	out := g.multiplex.layers[g.layer].edges[u.ID()]
 	nodes := make([]graph.Node, len(out))
 	for i, vid := range out {
 		nodes[i] = g.multiplex.nodes[vid]
 	}
-	return nodes
+	return iterator.NewOrderedNodes(nodes)
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -595,7 +593,7 @@ type undirectedMultiplexLocalMover struct {
 // node IDs of g must be contiguous in [0,n) where n is the number of nodes.
 // If g has a zero edge weight sum, nil is returned.
 func newUndirectedMultiplexLocalMover(g *ReducedUndirectedMultiplex, communities [][]graph.Node, weights, resolutions []float64, all bool) *undirectedMultiplexLocalMover {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	l := undirectedMultiplexLocalMover{
 		g:            g,
 		nodes:        nodes,
@@ -634,7 +632,8 @@ func newUndirectedMultiplexLocalMover(g *ReducedUndirectedMultiplex, communities
 		layer := g.Layer(i)
 		for _, u := range l.nodes {
 			w := weight(u, u)
-			for _, v := range layer.From(u) {
+			for to := layer.From(u); to.Next(); {
+				v := to.Node()
 				w += weight(u, v)
 			}
 			l.edgeWeightOf[i][u.ID()] = w
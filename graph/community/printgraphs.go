@@ -101,13 +101,13 @@ func main() {
 			}
 		}
 
-		nodes := g.Nodes()
+		nodes := graph.NodesOf(g.Nodes())
 		sort.Sort(ordered.ByID(nodes))
 
 		fmt.Printf("%s = []set{\n", raw.name)
 		rank := network.PageRank(asDirected{g}, 0.85, 1e-8)
 		for _, u := range nodes {
-			to := g.From(nodes[u.ID()])
+			to := graph.NodesOf(g.From(nodes[u.ID()]))
 			sort.Sort(ordered.ByID(to))
 			var links []int
 			for _, v := range to {
@@ -139,4 +139,4 @@ type asDirected struct{ *simple.UndirectedGraph }
 func (g asDirected) HasEdgeFromTo(u, v graph.Node) bool {
 	return g.UndirectedGraph.HasEdgeBetween(u, v)
 }
-func (g asDirected) To(v graph.Node) []graph.Node { return g.From(v) }
+func (g asDirected) To(v graph.Node) graph.Nodes { return g.From(v) }
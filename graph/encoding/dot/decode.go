@@ -38,9 +38,25 @@ func Unmarshal(data []byte, dst encoding.Builder) error {
 	return copyGraph(dst, file.Graphs[0])
 }
 
+// UnmarshalMulti parses the Graphviz DOT-encoded data as a multigraph and
+// stores the result in dst. Each edge of a non-strict DOT graph is added to
+// dst as a distinct line, so parallel edges are retained. Repeated edges of
+// a strict DOT graph are merged into a single line.
+func UnmarshalMulti(data []byte, dst encoding.MultiBuilder) error {
+	file, err := dot.ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	if len(file.Graphs) != 1 {
+		return fmt.Errorf("invalid number of graphs; expected 1, got %d", len(file.Graphs))
+	}
+	return copyGraph(dst, file.Graphs[0])
+}
+
 // copyGraph copies the nodes and edges from the Graphviz AST source graph to
-// the destination graph. Edge direction is maintained if present.
-func copyGraph(dst encoding.Builder, src *ast.Graph) (err error) {
+// the destination graph. Edge direction is maintained if present. The
+// destination must be an encoding.Builder or an encoding.MultiBuilder.
+func copyGraph(dst graph.NodeAdder, src *ast.Graph) (err error) {
 	defer func() {
 		switch e := recover().(type) {
 		case nil:
@@ -52,6 +68,7 @@ func copyGraph(dst encoding.Builder, src *ast.Graph) (err error) {
 	}()
 	gen := &generator{
 		directed: src.Directed,
+		strict:   src.Strict,
 		ids:      make(map[string]graph.Node),
 	}
 	if dst, ok := dst.(DOTIDSetter); ok {
@@ -71,6 +88,8 @@ func copyGraph(dst encoding.Builder, src *ast.Graph) (err error) {
 type generator struct {
 	// Directed graph.
 	directed bool
+	// Strict graph; repeated edges are merged.
+	strict bool
 	// Map from dot AST node ID to gonum node.
 	ids map[string]graph.Node
 	// Nodes processed within the context of a subgraph, that is to be used as a
@@ -85,7 +104,7 @@ type generator struct {
 
 // node returns the gonum node corresponding to the given dot AST node ID,
 // generating a new such node if none exist.
-func (gen *generator) node(dst encoding.Builder, id string) graph.Node {
+func (gen *generator) node(dst graph.NodeAdder, id string) graph.Node {
 	if n, ok := gen.ids[id]; ok {
 		return n
 	}
@@ -106,7 +125,7 @@ func (gen *generator) node(dst encoding.Builder, id string) graph.Node {
 }
 
 // addStmt adds the given statement to the graph.
-func (gen *generator) addStmt(dst encoding.Builder, stmt ast.Stmt) {
+func (gen *generator) addStmt(dst graph.NodeAdder, stmt ast.Stmt) {
 	switch stmt := stmt.(type) {
 	case *ast.NodeStmt:
 		n, ok := gen.node(dst, stmt.Node.ID).(encoding.AttributeSetter)
@@ -170,14 +189,12 @@ func (gen *generator) addStmt(dst encoding.Builder, stmt ast.Stmt) {
 }
 
 // addEdgeStmt adds the given edge statement to the graph.
-func (gen *generator) addEdgeStmt(dst encoding.Builder, stmt *ast.EdgeStmt) {
+func (gen *generator) addEdgeStmt(dst graph.NodeAdder, stmt *ast.EdgeStmt) {
 	fs := gen.addVertex(dst, stmt.From)
 	ts := gen.addEdge(dst, stmt.To)
 	for _, f := range fs {
 		for _, t := range ts {
-			edge := dst.NewEdge(f, t)
-			dst.SetEdge(edge)
-			e, ok := edge.(encoding.AttributeSetter)
+			e, ok := gen.setEdge(dst, f, t).(encoding.AttributeSetter)
 			if !ok {
 				continue
 			}
@@ -195,7 +212,7 @@ func (gen *generator) addEdgeStmt(dst encoding.Builder, stmt *ast.EdgeStmt) {
 }
 
 // addVertex adds the given vertex to the graph, and returns its set of nodes.
-func (gen *generator) addVertex(dst encoding.Builder, v ast.Vertex) []graph.Node {
+func (gen *generator) addVertex(dst graph.NodeAdder, v ast.Vertex) []graph.Node {
 	switch v := v.(type) {
 	case *ast.Node:
 		n := gen.node(dst, v.ID)
@@ -212,7 +229,7 @@ func (gen *generator) addVertex(dst encoding.Builder, v ast.Vertex) []graph.Node
 }
 
 // addEdge adds the given edge to the graph, and returns its set of nodes.
-func (gen *generator) addEdge(dst encoding.Builder, to *ast.Edge) []graph.Node {
+func (gen *generator) addEdge(dst graph.NodeAdder, to *ast.Edge) []graph.Node {
 	if !gen.directed && to.Directed {
 		panic(fmt.Errorf("directed edge to %v in undirected graph", to.Vertex))
 	}
@@ -221,14 +238,37 @@ func (gen *generator) addEdge(dst encoding.Builder, to *ast.Edge) []graph.Node {
 		ts := gen.addEdge(dst, to.To)
 		for _, f := range fs {
 			for _, t := range ts {
-				edge := dst.NewEdge(f, t)
-				dst.SetEdge(edge)
+				gen.setEdge(dst, f, t)
 			}
 		}
 	}
 	return fs
 }
 
+// setEdge adds an edge from f to t to the graph and returns it. If the
+// graph is a multigraph the edge is added as a new line unless the DOT
+// graph is strict and a line already exists from f to t, in which case
+// the existing line is returned.
+func (gen *generator) setEdge(dst graph.NodeAdder, f, t graph.Node) interface{} {
+	switch dst := dst.(type) {
+	case encoding.Builder:
+		e := dst.NewEdge(f, t)
+		dst.SetEdge(e)
+		return e
+	case encoding.MultiBuilder:
+		if gen.strict {
+			if lines := dst.Lines(f, t); lines != nil {
+				return lines[0]
+			}
+		}
+		l := dst.NewLine(f, t)
+		dst.SetLine(l)
+		return l
+	default:
+		panic(fmt.Sprintf("unsupported destination graph type %T", dst))
+	}
+}
+
 // pushSubgraph pushes the node start index of the active subgraph onto the
 // stack.
 func (gen *generator) pushSubgraph() {
@@ -6,10 +6,13 @@ package dot
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/multi"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -60,6 +63,115 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestUnmarshalMulti(t *testing.T) {
+	for i, test := range []struct {
+		dot      string
+		directed bool
+
+		// want holds the expected number of lines
+		// from the first to the second node and the
+		// labels of those lines.
+		want []struct {
+			from, to string
+			labels   []string
+		}
+	}{
+		{
+			dot: `digraph {
+	A -> B;
+	A -> B [label=b];
+	B -> A -> B;
+	A -> A;
+	A -> A;
+}`,
+			directed: true,
+			want: []struct {
+				from, to string
+				labels   []string
+			}{
+				{from: "A", to: "B", labels: []string{"", "", "b"}},
+				{from: "B", to: "A", labels: []string{""}},
+				{from: "A", to: "A", labels: []string{"", ""}},
+			},
+		},
+		{
+			dot: `strict digraph {
+	A -> B;
+	A -> B [label=b];
+	B -> A -> B;
+}`,
+			directed: true,
+			want: []struct {
+				from, to string
+				labels   []string
+			}{
+				{from: "A", to: "B", labels: []string{"b"}},
+				{from: "B", to: "A", labels: []string{""}},
+			},
+		},
+		{
+			dot: `graph {
+	A -- B [label=a];
+	B -- A [label=b];
+	C -- A;
+	B -- C;
+}`,
+			directed: false,
+			want: []struct {
+				from, to string
+				labels   []string
+			}{
+				{from: "A", to: "B", labels: []string{"a", "b"}},
+				{from: "C", to: "A", labels: []string{""}},
+				{from: "B", to: "C", labels: []string{""}},
+			},
+		},
+		{
+			dot: `strict graph {
+	A -- B [label=a];
+	B -- A [label=b];
+}`,
+			directed: false,
+			want: []struct {
+				from, to string
+				labels   []string
+			}{
+				{from: "A", to: "B", labels: []string{"b"}},
+			},
+		},
+	} {
+		var dst interface {
+			encoding.MultiBuilder
+			Node(int64) graph.Node
+		}
+		if test.directed {
+			dst = newDotDirectedMultigraph()
+		} else {
+			dst = newDotUndirectedMultigraph()
+		}
+		if err := UnmarshalMulti([]byte(test.dot), dst); err != nil {
+			t.Errorf("i=%d: unable to unmarshal DOT multigraph; %v", i, err)
+			continue
+		}
+		ids := make(map[string]graph.Node)
+		for _, n := range graph.NodesOf(dst.Nodes()) {
+			ids[n.(*dotNode).dotID] = n
+		}
+		for _, w := range test.want {
+			lines := dst.Lines(ids[w.from], ids[w.to])
+			var labels []string
+			for _, l := range lines {
+				labels = append(labels, l.(*dotLine).Label)
+			}
+			sort.Strings(labels)
+			if !reflect.DeepEqual(labels, w.labels) {
+				t.Errorf("i=%d: unexpected lines from %s to %s: got labels %q want %q",
+					i, w.from, w.to, labels, w.labels)
+			}
+		}
+	}
+}
+
 const directed = `digraph {
 	graph [
 		outputorder=edgesfirst
@@ -218,6 +330,50 @@ func (g *dotUndirectedGraph) DOTID() string {
 	return g.id
 }
 
+// dotDirectedMultigraph extends multi.DirectedGraph to add NewNode and
+// NewLine methods for creating user-defined nodes and lines.
+type dotDirectedMultigraph struct {
+	*multi.DirectedGraph
+}
+
+// newDotDirectedMultigraph returns a new directed multigraph capable of
+// creating user-defined nodes and lines.
+func newDotDirectedMultigraph() *dotDirectedMultigraph {
+	return &dotDirectedMultigraph{DirectedGraph: multi.NewDirectedGraph()}
+}
+
+// NewNode returns a new node with a unique node ID for the graph.
+func (g *dotDirectedMultigraph) NewNode() graph.Node {
+	return &dotNode{Node: g.DirectedGraph.NewNode()}
+}
+
+// NewLine returns a new Line from the source to the destination node.
+func (g *dotDirectedMultigraph) NewLine(from, to graph.Node) graph.Line {
+	return &dotLine{Line: g.DirectedGraph.NewLine(from, to)}
+}
+
+// dotUndirectedMultigraph extends multi.UndirectedGraph to add NewNode and
+// NewLine methods for creating user-defined nodes and lines.
+type dotUndirectedMultigraph struct {
+	*multi.UndirectedGraph
+}
+
+// newDotUndirectedMultigraph returns a new undirected multigraph capable of
+// creating user-defined nodes and lines.
+func newDotUndirectedMultigraph() *dotUndirectedMultigraph {
+	return &dotUndirectedMultigraph{UndirectedGraph: multi.NewUndirectedGraph()}
+}
+
+// NewNode returns a new node with a unique node ID for the graph.
+func (g *dotUndirectedMultigraph) NewNode() graph.Node {
+	return &dotNode{Node: g.UndirectedGraph.NewNode()}
+}
+
+// NewLine returns a new Line from the source to the destination node.
+func (g *dotUndirectedMultigraph) NewLine(from, to graph.Node) graph.Line {
+	return &dotLine{Line: g.UndirectedGraph.NewLine(from, to)}
+}
+
 // dotNode extends simple.Node with a label field to test round-trip encoding
 // and decoding of node DOT label attributes.
 type dotNode struct {
@@ -285,6 +441,23 @@ func (e *dotEdge) Attributes() []encoding.Attribute {
 	}}
 }
 
+// dotLine extends multi.Line with a label field to test decoding of line
+// DOT label attributes.
+type dotLine struct {
+	graph.Line
+	// Line label.
+	Label string
+}
+
+// SetAttribute sets a DOT attribute.
+func (l *dotLine) SetAttribute(attr encoding.Attribute) error {
+	if attr.Key != "label" {
+		return fmt.Errorf("unable to unmarshal line DOT attribute with key %q", attr.Key)
+	}
+	l.Label = attr.Value
+	return nil
+}
+
 // attributes is a helper for global attributes.
 type attributes []encoding.Attribute
 
@@ -104,7 +104,7 @@ type edge struct {
 }
 
 func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool) error {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	sort.Sort(ordered.ByID(nodes))
 
 	p.buf.WriteString(p.prefix)
@@ -151,7 +151,7 @@ func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool
 		if s, ok := n.(Subgrapher); ok {
 			// If the node is not linked to any other node
 			// the graph needs to be written now.
-			if len(g.From(n)) == 0 {
+			if g.From(n).Len() == 0 {
 				g := s.Subgraph()
 				_, subIsDirected := g.(graph.Directed)
 				if subIsDirected != isDirected {
@@ -182,7 +182,7 @@ func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool
 
 	havePrintedEdgeHeader := false
 	for _, n := range nodes {
-		to := g.From(n)
+		to := graph.NodesOf(g.From(n))
 		sort.Sort(ordered.ByID(to))
 		for _, t := range to {
 			if isDirected {
@@ -12,6 +12,12 @@ type Builder interface {
 	graph.Builder
 }
 
+// MultiBuilder is a multigraph that can have user-defined nodes and lines added.
+type MultiBuilder interface {
+	graph.Multigraph
+	graph.MultigraphBuilder
+}
+
 // AttributeSetter is implemented by types that can set an encoded graph
 // attribute.
 type AttributeSetter interface {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -80,7 +81,7 @@ func (g *GraphNode) has(n graph.Node, visited map[int64]struct{}) bool {
 	return false
 }
 
-func (g *GraphNode) Nodes() []graph.Node {
+func (g *GraphNode) Nodes() graph.Nodes {
 	toReturn := []graph.Node{g}
 	visited := map[int64]struct{}{g.id: {}}
 
@@ -100,7 +101,7 @@ func (g *GraphNode) Nodes() []graph.Node {
 		}
 	}
 
-	return toReturn
+	return iterator.NewOrderedNodes(toReturn)
 }
 
 func (g *GraphNode) nodes(list []graph.Node, visited map[int64]struct{}) []graph.Node {
@@ -128,9 +129,9 @@ func (g *GraphNode) nodes(list []graph.Node, visited map[int64]struct{}) []graph
 	return list
 }
 
-func (g *GraphNode) From(n graph.Node) []graph.Node {
+func (g *GraphNode) From(n graph.Node) graph.Nodes {
 	if n.ID() == g.ID() {
-		return g.neighbors
+		return iterator.NewOrderedNodes(g.neighbors)
 	}
 
 	visited := map[int64]struct{}{g.id: {}}
@@ -138,7 +139,7 @@ func (g *GraphNode) From(n graph.Node) []graph.Node {
 		visited[root.ID()] = struct{}{}
 
 		if result := root.findNeighbors(n, visited); result != nil {
-			return result
+			return iterator.NewOrderedNodes(result)
 		}
 	}
 
@@ -147,12 +148,12 @@ func (g *GraphNode) From(n graph.Node) []graph.Node {
 
 		if gn, ok := neigh.(*GraphNode); ok {
 			if result := gn.findNeighbors(n, visited); result != nil {
-				return result
+				return iterator.NewOrderedNodes(result)
 			}
 		}
 	}
 
-	return nil
+	return graph.Empty
 }
 
 func (g *GraphNode) findNeighbors(n graph.Node, visited map[int64]struct{}) []graph.Node {
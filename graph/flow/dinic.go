@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Dinic returns a maximum flow from s to t in the flow network g
+// using Dinic's blocking flow algorithm. The capacity of each edge
+// is given by its weight. If s or t is not in g, the returned flow
+// is empty. Dinic will panic if s and t are the same node or if g
+// has a negative or non-finite capacity.
+//
+// The time complexity of Dinic is O(|V|^2.|E|).
+func Dinic(g graph.WeightedDirected, s, t graph.Node) Flow {
+	n, ok := newNetwork(g, s, t, nil)
+	if !ok {
+		return Flow{source: s, sink: t}
+	}
+	si := n.indexOf[s.ID()]
+	ti := n.indexOf[t.ID()]
+
+	level := make([]int, len(n.nodes))
+	next := make([]int, len(n.nodes))
+	for n.levels(si, ti, level) {
+		for i := range next {
+			next[i] = 0
+		}
+		for n.augment(si, ti, math.Inf(1), level, next) > 0 {
+		}
+	}
+	return n.result(s, t)
+}
+
+// levels labels each node with its breadth first distance from s
+// in the residual network. Unreachable nodes are labeled -1. It
+// returns whether t is reachable from s.
+func (n *network) levels(s, t int, level []int) bool {
+	for i := range level {
+		level[i] = -1
+	}
+	level[s] = 0
+	queue := []int{s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range n.adj[u] {
+			a := n.arcs[i]
+			if a.r > 0 && level[a.to] < 0 {
+				level[a.to] = level[u] + 1
+				queue = append(queue, a.to)
+			}
+		}
+	}
+	return level[t] >= 0
+}
+
+// augment pushes at most limit units of flow from u to t along a
+// single path of the level graph and returns the amount pushed.
+// next holds the index of the first arc of each node's adjacency
+// that has not yet been found to be blocked.
+func (n *network) augment(u, t int, limit float64, level, next []int) float64 {
+	if u == t {
+		return limit
+	}
+	for ; next[u] < len(n.adj[u]); next[u]++ {
+		i := n.adj[u][next[u]]
+		a := &n.arcs[i]
+		if a.r <= 0 || level[a.to] != level[u]+1 {
+			continue
+		}
+		d := n.augment(a.to, t, math.Min(limit, a.r), level, next)
+		if d > 0 {
+			a.r -= d
+			n.arcs[i^1].r += d
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,9 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flow provides network flow functions.
+//
+// The capacity of an edge in a flow network is given by the weight
+// of the edge in a graph.WeightedDirected.
+package flow // import "gonum.org/v1/gonum/graph/flow"
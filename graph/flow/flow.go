@@ -0,0 +1,224 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Flow is a flow from a source to a sink in a flow network.
+type Flow struct {
+	source, sink graph.Node
+
+	value float64
+
+	// flow holds the positive edge flows
+	// indexed by from and to node IDs.
+	flow  map[int64]map[int64]float64
+	edges []graph.WeightedEdge
+
+	cut Cut
+}
+
+// Source returns the source of the flow.
+func (f Flow) Source() graph.Node { return f.source }
+
+// Sink returns the sink of the flow.
+func (f Flow) Sink() graph.Node { return f.sink }
+
+// Value returns the net amount of flow leaving the source.
+func (f Flow) Value() float64 { return f.value }
+
+// Of returns the amount of flow along the edge from u to v.
+func (f Flow) Of(u, v graph.Node) float64 {
+	return f.flow[u.ID()][v.ID()]
+}
+
+// Edges returns the edges of the flow network that carry a
+// positive flow, with the weight of each edge holding the
+// amount of flow. The edges are ordered by from and then
+// to node ID.
+func (f Flow) Edges() []graph.WeightedEdge {
+	return append([]graph.WeightedEdge(nil), f.edges...)
+}
+
+// MinCut returns the source-sink cut saturated by the flow. If
+// the flow is a maximum flow the returned cut is a minimum cut.
+func (f Flow) MinCut() Cut {
+	c := f.cut
+	c.Source = append([]graph.Node(nil), c.Source...)
+	c.Sink = append([]graph.Node(nil), c.Sink...)
+	c.Edges = append([]graph.WeightedEdge(nil), c.Edges...)
+	return c
+}
+
+// Cut is a partition of the nodes of a flow network into a
+// set holding the source and a set holding the sink.
+type Cut struct {
+	// Source and Sink are the nodes on the source
+	// and sink sides of the cut, ordered by ID.
+	Source, Sink []graph.Node
+
+	// Edges are the edges from the source side to the
+	// sink side of the cut weighted by their capacity.
+	Edges []graph.WeightedEdge
+
+	// Capacity is the sum of the capacities of Edges.
+	Capacity float64
+}
+
+// MinCut returns a minimum s-t cut of the flow network g.
+func MinCut(g graph.WeightedDirected, s, t graph.Node) Cut {
+	return Dinic(g, s, t).MinCut()
+}
+
+// network is a residual network. Each edge of the input graph
+// is held as a pair of arcs at indices 2i and 2i+1, the first
+// in the direction of the edge and the second its reverse.
+type network struct {
+	nodes   []graph.Node
+	indexOf map[int64]int
+
+	// adj holds the indices of the arcs leaving each node.
+	adj  [][]int
+	arcs []arc
+}
+
+// arc is an arc in a residual network.
+type arc struct {
+	from, to int
+
+	// cap is the capacity of the arc and r is its residual
+	// capacity. The flow along an edge is held as the residual
+	// capacity of the edge's reverse arc.
+	cap, r float64
+
+	cost float64
+}
+
+// newNetwork returns the residual network for g with no flow.
+// If cost is not nil, it is used to assign a cost per unit of
+// flow to each edge. newNetwork returns false if either s or t
+// is not in g, and panics if s and t are the same node.
+func newNetwork(g graph.WeightedDirected, s, t graph.Node, cost func(u, v graph.Node) float64) (*network, bool) {
+	if s.ID() == t.ID() {
+		panic("flow: source and sink are the same node")
+	}
+	if !g.Has(s) || !g.Has(t) {
+		return nil, false
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := network{
+		nodes:   nodes,
+		indexOf: make(map[int64]int, len(nodes)),
+		adj:     make([][]int, len(nodes)),
+	}
+	for i, u := range nodes {
+		n.indexOf[u.ID()] = i
+	}
+	for i, u := range nodes {
+		to := graph.NodesOf(g.From(u))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if v.ID() == u.ID() {
+				continue
+			}
+			w, ok := g.Weight(u, v)
+			if !ok {
+				panic("flow: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("flow: negative capacity")
+			}
+			if math.IsInf(w, 1) || math.IsNaN(w) {
+				panic("flow: capacity not finite")
+			}
+			var c float64
+			if cost != nil {
+				c = cost(u, v)
+				if math.IsInf(c, 0) || math.IsNaN(c) {
+					panic("flow: cost not finite")
+				}
+			}
+			j := n.indexOf[v.ID()]
+			n.adj[i] = append(n.adj[i], len(n.arcs))
+			n.arcs = append(n.arcs, arc{from: i, to: j, cap: w, r: w, cost: c})
+			n.adj[j] = append(n.adj[j], len(n.arcs))
+			n.arcs = append(n.arcs, arc{from: j, to: i, cost: -c})
+		}
+	}
+	return &n, true
+}
+
+// result returns the Flow corresponding to the current state
+// of the residual network.
+func (n *network) result(s, t graph.Node) Flow {
+	f := Flow{
+		source: s,
+		sink:   t,
+		flow:   make(map[int64]map[int64]float64),
+	}
+	sid := s.ID()
+	for i := 0; i < len(n.arcs); i += 2 {
+		a := n.arcs[i]
+		x := n.arcs[i+1].r
+		if x <= 0 {
+			continue
+		}
+		u := n.nodes[a.from]
+		v := n.nodes[a.to]
+		if f.flow[u.ID()] == nil {
+			f.flow[u.ID()] = make(map[int64]float64)
+		}
+		f.flow[u.ID()][v.ID()] = x
+		f.edges = append(f.edges, simple.WeightedEdge{F: u, T: v, W: x})
+		switch {
+		case u.ID() == sid:
+			f.value += x
+		case v.ID() == sid:
+			f.value -= x
+		}
+	}
+
+	// The source side of the cut is the set of nodes
+	// reachable from the source in the residual network.
+	reached := make([]bool, len(n.nodes))
+	si := n.indexOf[sid]
+	reached[si] = true
+	queue := []int{si}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range n.adj[u] {
+			a := n.arcs[i]
+			if a.r > 0 && !reached[a.to] {
+				reached[a.to] = true
+				queue = append(queue, a.to)
+			}
+		}
+	}
+	for i, u := range n.nodes {
+		if reached[i] {
+			f.cut.Source = append(f.cut.Source, u)
+		} else {
+			f.cut.Sink = append(f.cut.Sink, u)
+		}
+	}
+	for i := 0; i < len(n.arcs); i += 2 {
+		a := n.arcs[i]
+		if reached[a.from] && !reached[a.to] {
+			f.cut.Edges = append(f.cut.Edges, simple.WeightedEdge{F: n.nodes[a.from], T: n.nodes[a.to], W: a.cap})
+			f.cut.Capacity += a.cap
+		}
+	}
+	return f
+}
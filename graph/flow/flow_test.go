@@ -0,0 +1,331 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var maxFlowTests = []struct {
+	name  string
+	edges []simple.WeightedEdge
+	s, t  int64
+
+	want    float64
+	wantCut []int64
+}{
+	{
+		name: "clrs",
+		// Figure 26.6 from Introduction to Algorithms 3rd Ed.
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 16},
+			{F: simple.Node(0), T: simple.Node(2), W: 13},
+			{F: simple.Node(1), T: simple.Node(3), W: 12},
+			{F: simple.Node(2), T: simple.Node(1), W: 4},
+			{F: simple.Node(2), T: simple.Node(4), W: 14},
+			{F: simple.Node(3), T: simple.Node(2), W: 9},
+			{F: simple.Node(3), T: simple.Node(5), W: 20},
+			{F: simple.Node(4), T: simple.Node(3), W: 7},
+			{F: simple.Node(4), T: simple.Node(5), W: 4},
+		},
+		s: 0, t: 5,
+
+		want:    23,
+		wantCut: []int64{0, 1, 2, 4},
+	},
+	{
+		name: "antiparallel",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(1), T: simple.Node(0), W: 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(1), W: 5},
+			{F: simple.Node(1), T: simple.Node(3), W: 4},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		s: 0, t: 3,
+
+		want:    5,
+		wantCut: []int64{0},
+	},
+	{
+		name: "disconnected",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(2), T: simple.Node(3), W: 2},
+			{F: simple.Node(3), T: simple.Node(1), W: 2},
+		},
+		s: 0, t: 3,
+
+		want:    0,
+		wantCut: []int64{0, 1},
+	},
+	{
+		name: "zero capacity",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2.5},
+			{F: simple.Node(1), T: simple.Node(2), W: 0},
+			{F: simple.Node(1), T: simple.Node(3), W: 1.5},
+			{F: simple.Node(2), T: simple.Node(3), W: 7},
+		},
+		s: 0, t: 3,
+
+		want:    1.5,
+		wantCut: []int64{0, 1},
+	},
+}
+
+var maxFlowFuncs = []struct {
+	name string
+	fn   func(g graph.WeightedDirected, s, t graph.Node) Flow
+}{
+	{name: "Dinic", fn: Dinic},
+	{name: "PushRelabel", fn: PushRelabel},
+}
+
+func TestMaxFlow(t *testing.T) {
+	for _, test := range maxFlowTests {
+		g := simple.NewWeightedDirectedGraph(0, 0)
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		s := simple.Node(test.s)
+		sink := simple.Node(test.t)
+		for _, f := range maxFlowFuncs {
+			flow := f.fn(g, s, sink)
+			if flow.Value() != test.want {
+				t.Errorf("unexpected flow value for %q with %s: got:%v want:%v",
+					test.name, f.name, flow.Value(), test.want)
+			}
+			checkFlow(t, test.name+" "+f.name, g, flow)
+
+			cut := flow.MinCut()
+			var got []int64
+			for _, n := range cut.Source {
+				got = append(got, n.ID())
+			}
+			if !reflect.DeepEqual(got, test.wantCut) {
+				t.Errorf("unexpected source side of cut for %q with %s: got:%v want:%v",
+					test.name, f.name, got, test.wantCut)
+			}
+			if cut.Capacity != test.want {
+				t.Errorf("unexpected cut capacity for %q with %s: got:%v want:%v",
+					test.name, f.name, cut.Capacity, test.want)
+			}
+		}
+	}
+}
+
+func TestMaxFlowRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(30)
+		p := rnd.Float64()
+		g := simple.NewWeightedDirectedGraph(0, 0)
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < p {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(20))})
+				}
+			}
+		}
+		s := simple.Node(0)
+		sink := simple.Node(n - 1)
+
+		dinic := Dinic(g, s, sink)
+		checkFlow(t, "Dinic", g, dinic)
+		pr := PushRelabel(g, s, sink)
+		checkFlow(t, "PushRelabel", g, pr)
+		if dinic.Value() != pr.Value() {
+			t.Errorf("flow values disagree for test %d: Dinic:%v PushRelabel:%v", i, dinic.Value(), pr.Value())
+		}
+		if c := dinic.MinCut().Capacity; c != dinic.Value() {
+			t.Errorf("cut capacity does not match flow value for test %d: got:%v want:%v", i, c, dinic.Value())
+		}
+		if c := pr.MinCut().Capacity; c != pr.Value() {
+			t.Errorf("cut capacity does not match flow value for test %d: got:%v want:%v", i, c, pr.Value())
+		}
+	}
+}
+
+func TestMaxFlowMissingNode(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	for _, f := range maxFlowFuncs {
+		flow := f.fn(g, simple.Node(0), simple.Node(2))
+		if flow.Value() != 0 || len(flow.Edges()) != 0 {
+			t.Errorf("unexpected flow for missing sink with %s", f.name)
+		}
+	}
+}
+
+// checkFlow checks that flow is a feasible flow in g.
+func checkFlow(t *testing.T, name string, g graph.WeightedDirected, flow Flow) {
+	net := make(map[int64]float64)
+	for _, e := range flow.Edges() {
+		u, v := e.From(), e.To()
+		if e.Weight() <= 0 {
+			t.Errorf("%s: non-positive flow on edge %d->%d", name, u.ID(), v.ID())
+		}
+		if flow.Of(u, v) != e.Weight() {
+			t.Errorf("%s: flow mismatch on edge %d->%d", name, u.ID(), v.ID())
+		}
+		c, ok := g.Weight(u, v)
+		if !ok || u.ID() == v.ID() {
+			t.Errorf("%s: flow on non-edge %d->%d", name, u.ID(), v.ID())
+			continue
+		}
+		if e.Weight() > c {
+			t.Errorf("%s: flow exceeds capacity on edge %d->%d: %v > %v", name, u.ID(), v.ID(), e.Weight(), c)
+		}
+		net[u.ID()] -= e.Weight()
+		net[v.ID()] += e.Weight()
+	}
+	for id, x := range net {
+		switch id {
+		case flow.Source().ID():
+			if x != -flow.Value() {
+				t.Errorf("%s: unexpected net flow from source: got:%v want:%v", name, -x, flow.Value())
+			}
+		case flow.Sink().ID():
+			if x != flow.Value() {
+				t.Errorf("%s: unexpected net flow into sink: got:%v want:%v", name, x, flow.Value())
+			}
+		default:
+			if x != 0 {
+				t.Errorf("%s: flow not conserved at node %d: %v", name, id, x)
+			}
+		}
+	}
+}
+
+func TestMinCostFlowAssignment(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5} {
+		for k := 0; k < 10; k++ {
+			cost := make([][]float64, n)
+			for i := range cost {
+				cost[i] = make([]float64, n)
+				for j := range cost[i] {
+					cost[i][j] = float64(rnd.Intn(21) - 5)
+				}
+			}
+
+			// Workers are nodes 1 to n and jobs are
+			// nodes n+1 to 2n. The source is node 0
+			// and the sink is node 2n+1.
+			g := simple.NewWeightedDirectedGraph(0, 0)
+			s := simple.Node(0)
+			sink := simple.Node(2*n + 1)
+			for i := 0; i < n; i++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: s, T: simple.Node(i + 1), W: 1})
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(n + i + 1), T: sink, W: 1})
+				for j := 0; j < n; j++ {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i + 1), T: simple.Node(n + j + 1), W: 1})
+				}
+			}
+			costOf := func(u, v graph.Node) float64 {
+				if u.ID() == s.ID() || v.ID() == sink.ID() {
+					return 0
+				}
+				return cost[u.ID()-1][v.ID()-int64(n)-1]
+			}
+
+			flow, total := MinCostFlow(g, s, sink, costOf)
+			checkFlow(t, "MinCostFlow", g, flow)
+			if flow.Value() != float64(n) {
+				t.Errorf("unexpected flow value for n=%d: got:%v want:%v", n, flow.Value(), n)
+			}
+			var got float64
+			for _, e := range flow.Edges() {
+				got += e.Weight() * costOf(e.From(), e.To())
+			}
+			if got != total {
+				t.Errorf("returned cost does not match flow cost for n=%d: got:%v want:%v", n, total, got)
+			}
+			if want := bestAssignment(cost); total != want {
+				t.Errorf("unexpected minimum cost for n=%d: got:%v want:%v", n, total, want)
+			}
+		}
+	}
+}
+
+// bestAssignment returns the minimum cost of assigning each row of
+// cost to a distinct column by exhaustive search.
+func bestAssignment(cost [][]float64) float64 {
+	n := len(cost)
+	used := make([]bool, n)
+	best := math.Inf(1)
+	var search func(i int, sum float64)
+	search = func(i int, sum float64) {
+		if i == n {
+			best = math.Min(best, sum)
+			return
+		}
+		for j := 0; j < n; j++ {
+			if !used[j] {
+				used[j] = true
+				search(i+1, sum+cost[i][j])
+				used[j] = false
+			}
+		}
+	}
+	search(0, 0)
+	return best
+}
+
+func TestMinCostFlowMaximal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + rnd.Intn(20)
+		p := rnd.Float64()
+		g := simple.NewWeightedDirectedGraph(0, 0)
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		cost := make(map[[2]int64]float64)
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < p {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(20))})
+					cost[[2]int64{int64(u), int64(v)}] = float64(rnd.Intn(10))
+				}
+			}
+		}
+		s := simple.Node(0)
+		sink := simple.Node(n - 1)
+		flow, _ := MinCostFlow(g, s, sink, func(u, v graph.Node) float64 {
+			return cost[[2]int64{u.ID(), v.ID()}]
+		})
+		checkFlow(t, "MinCostFlow", g, flow)
+		if want := Dinic(g, s, sink).Value(); flow.Value() != want {
+			t.Errorf("min cost flow is not maximal for test %d: got:%v want:%v", i, flow.Value(), want)
+		}
+	}
+}
+
+func TestMinCostFlowNegativeCycle(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 1})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for negative cost cycle")
+		}
+	}()
+	MinCostFlow(g, simple.Node(0), simple.Node(3), func(u, v graph.Node) float64 { return -1 })
+}
@@ -0,0 +1,148 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// MinCostFlow returns a maximum flow from s to t in the flow network g
+// that has the minimum total cost among all maximum flows, and that cost.
+// The capacity of each edge is given by its weight and the cost per unit
+// of flow along the edge from u to v is given by cost(u, v). Costs may be
+// negative, but MinCostFlow will panic if g has a negative cost cycle
+// reachable from s. It will also panic if s and t are the same node or if
+// g has a negative or non-finite capacity or a non-finite cost. If s or
+// t is not in g, the returned flow is empty.
+//
+// MinCostFlow uses the successive shortest path algorithm with node
+// potentials. The time complexity of MinCostFlow is O(|V|.|E| + F.|E|.log|V|)
+// where F is the number of augmenting paths, which is bounded by the value
+// of the flow for integral capacities.
+func MinCostFlow(g graph.WeightedDirected, s, t graph.Node, cost func(u, v graph.Node) float64) (flow Flow, total float64) {
+	n, ok := newNetwork(g, s, t, cost)
+	if !ok {
+		return Flow{source: s, sink: t}, 0
+	}
+	si := n.indexOf[s.ID()]
+	ti := n.indexOf[t.ID()]
+
+	// Only nodes reachable from s in the residual network can
+	// be on an augmenting path, and augmenting never makes an
+	// unreachable node reachable, so the potentials of the
+	// remaining nodes are never used.
+	pot := n.potentials(si)
+	if math.IsInf(pot[ti], 1) {
+		return n.result(s, t), 0
+	}
+
+	dist := make([]float64, len(n.nodes))
+	via := make([]int, len(n.nodes))
+	for {
+		for i := range dist {
+			dist[i] = math.Inf(1)
+			via[i] = -1
+		}
+		dist[si] = 0
+		q := priorityQueue{{node: si}}
+		for q.Len() != 0 {
+			mid := heap.Pop(&q).(distance)
+			u := mid.node
+			if mid.dist > dist[u] {
+				continue
+			}
+			for _, i := range n.adj[u] {
+				a := n.arcs[i]
+				if a.r <= 0 || math.IsInf(pot[a.to], 1) {
+					continue
+				}
+				// Reduced costs are non-negative up to
+				// rounding error.
+				joint := dist[u] + math.Max(0, a.cost+pot[u]-pot[a.to])
+				if joint < dist[a.to] {
+					dist[a.to] = joint
+					via[a.to] = i
+					heap.Push(&q, distance{node: a.to, dist: joint})
+				}
+			}
+		}
+		if via[ti] < 0 {
+			break
+		}
+		for i, d := range dist {
+			if !math.IsInf(d, 1) {
+				pot[i] += d
+			}
+		}
+
+		// Augment along the shortest path by its bottleneck.
+		d := math.Inf(1)
+		for v := ti; v != si; v = n.arcs[via[v]].from {
+			d = math.Min(d, n.arcs[via[v]].r)
+		}
+		for v := ti; v != si; v = n.arcs[via[v]].from {
+			i := via[v]
+			n.arcs[i].r -= d
+			n.arcs[i^1].r += d
+		}
+	}
+
+	for i := 0; i < len(n.arcs); i += 2 {
+		total += n.arcs[i+1].r * n.arcs[i].cost
+	}
+	return n.result(s, t), total
+}
+
+// potentials returns the shortest path distances from s with respect
+// to the arc costs of the residual network using the Bellman-Ford-Moore
+// algorithm. Nodes that are not reachable from s have an infinite
+// distance. potentials will panic if there is a negative cost cycle
+// reachable from s.
+func (n *network) potentials(s int) []float64 {
+	dist := make([]float64, len(n.nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	dist[s] = 0
+	for k := 0; k < len(n.nodes); k++ {
+		var changed bool
+		for _, a := range n.arcs {
+			if a.r <= 0 || math.IsInf(dist[a.from], 1) {
+				continue
+			}
+			if joint := dist[a.from] + a.cost; joint < dist[a.to] {
+				dist[a.to] = joint
+				changed = true
+			}
+		}
+		if !changed {
+			return dist
+		}
+	}
+	panic("flow: negative cost cycle")
+}
+
+// distance is a node and its tentative distance from the source.
+type distance struct {
+	node int
+	dist float64
+}
+
+// priorityQueue implements a no-dec priority queue.
+type priorityQueue []distance
+
+func (q priorityQueue) Len() int            { return len(q) }
+func (q priorityQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q priorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(n interface{}) { *q = append(*q, n.(distance)) }
+func (q *priorityQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
@@ -0,0 +1,118 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PushRelabel returns a maximum flow from s to t in the flow network g
+// using the FIFO push-relabel algorithm. The capacity of each edge is
+// given by its weight. If s or t is not in g, the returned flow is
+// empty. PushRelabel will panic if s and t are the same node or if g
+// has a negative or non-finite capacity.
+//
+// The time complexity of PushRelabel is O(|V|^3).
+func PushRelabel(g graph.WeightedDirected, s, t graph.Node) Flow {
+	n, ok := newNetwork(g, s, t, nil)
+	if !ok {
+		return Flow{source: s, sink: t}
+	}
+	si := n.indexOf[s.ID()]
+	ti := n.indexOf[t.ID()]
+
+	nn := len(n.nodes)
+	height := n.initialHeights(si, ti)
+	excess := make([]float64, nn)
+	next := make([]int, nn)
+	active := make([]bool, nn)
+	var queue []int
+
+	// Saturate all arcs leaving the source.
+	for _, i := range n.adj[si] {
+		a := &n.arcs[i]
+		if a.r <= 0 {
+			continue
+		}
+		d := a.r
+		a.r = 0
+		n.arcs[i^1].r += d
+		excess[a.to] += d
+		if a.to != ti && !active[a.to] {
+			active[a.to] = true
+			queue = append(queue, a.to)
+		}
+	}
+
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		active[u] = false
+
+		// Discharge u.
+		for excess[u] > 0 {
+			if next[u] == len(n.adj[u]) {
+				// Relabel u to allow a push along
+				// its lowest residual arc.
+				h := math.MaxInt32
+				for _, i := range n.adj[u] {
+					a := n.arcs[i]
+					if a.r > 0 && height[a.to] < h {
+						h = height[a.to]
+					}
+				}
+				height[u] = h + 1
+				next[u] = 0
+				continue
+			}
+			i := n.adj[u][next[u]]
+			a := &n.arcs[i]
+			if a.r <= 0 || height[u] != height[a.to]+1 {
+				next[u]++
+				continue
+			}
+			d := math.Min(excess[u], a.r)
+			a.r -= d
+			n.arcs[i^1].r += d
+			excess[u] -= d
+			excess[a.to] += d
+			if a.to != si && a.to != ti && !active[a.to] {
+				active[a.to] = true
+				queue = append(queue, a.to)
+			}
+		}
+	}
+	return n.result(s, t)
+}
+
+// initialHeights returns a valid labeling of the nodes of the residual
+// network for the start of the push-relabel algorithm. Nodes are labeled
+// with their distance to t, except for s and the nodes that cannot reach
+// t which are labeled with the number of nodes.
+func (n *network) initialHeights(s, t int) []int {
+	nn := len(n.nodes)
+	height := make([]int, nn)
+	for i := range height {
+		height[i] = nn
+	}
+	height[t] = 0
+	queue := []int{t}
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, i := range n.adj[v] {
+			// The arc into v paired with i.
+			a := n.arcs[i^1]
+			if a.r > 0 && a.from != s && height[a.from] == nn {
+				height[a.from] = height[v] + 1
+				queue = append(queue, a.from)
+			}
+		}
+	}
+	height[s] = nn
+	return height
+}
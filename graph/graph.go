@@ -31,11 +31,11 @@ type Graph interface {
 	Has(Node) bool
 
 	// Nodes returns all the nodes in the graph.
-	Nodes() []Node
+	Nodes() Nodes
 
 	// From returns all nodes that can be reached directly
 	// from the given node.
-	From(Node) []Node
+	From(Node) Nodes
 
 	// HasEdgeBetween returns whether an edge exists between
 	// nodes x and y without considering direction.
@@ -95,7 +95,7 @@ type Directed interface {
 
 	// To returns all nodes that can reach directly
 	// to the given node.
-	To(Node) []Node
+	To(Node) Nodes
 }
 
 // WeightedDirected is a weighted directed graph.
@@ -108,7 +108,7 @@ type WeightedDirected interface {
 
 	// To returns all nodes that can reach directly
 	// to the given node.
-	To(Node) []Node
+	To(Node) Nodes
 }
 
 // NodeAdder is an interface for adding arbitrary nodes to a graph.
@@ -213,11 +213,15 @@ type DirectedWeightedBuilder interface {
 // be present in the destination after the copy is complete.
 func Copy(dst Builder, src Graph) {
 	nodes := src.Nodes()
-	for _, n := range nodes {
-		dst.AddNode(n)
+	for nodes.Next() {
+		dst.AddNode(nodes.Node())
 	}
-	for _, u := range nodes {
-		for _, v := range src.From(u) {
+	nodes.Reset()
+	for nodes.Next() {
+		u := nodes.Node()
+		to := src.From(u)
+		for to.Next() {
+			v := to.Node()
 			dst.SetEdge(src.Edge(u, v))
 		}
 	}
@@ -236,11 +240,15 @@ func Copy(dst Builder, src Graph) {
 // to resolve such conflicts, an UndirectWeighted may be used to do this.
 func CopyWeighted(dst WeightedBuilder, src Weighted) {
 	nodes := src.Nodes()
-	for _, n := range nodes {
-		dst.AddNode(n)
+	for nodes.Next() {
+		dst.AddNode(nodes.Node())
 	}
-	for _, u := range nodes {
-		for _, v := range src.From(u) {
+	nodes.Reset()
+	for nodes.Next() {
+		u := nodes.Node()
+		to := src.From(u)
+		for to.Next() {
+			v := to.Node()
 			dst.SetWeightedEdge(src.WeightedEdge(u, v))
 		}
 	}
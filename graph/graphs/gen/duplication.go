@@ -54,7 +54,7 @@ func Duplication(dst UndirectedMutator, n int, delta, alpha, sigma float64, src
 		rndN = src.Intn
 	}
 
-	nodes := dst.Nodes()
+	nodes := graph.NodesOf(dst.Nodes())
 	sort.Sort(ordered.ByID(nodes))
 	if len(nodes) == 0 {
 		n--
@@ -73,7 +73,7 @@ func Duplication(dst UndirectedMutator, n int, delta, alpha, sigma float64, src
 		// into the rest of the graph.
 		for {
 			// Add edges to parent's neighbours.
-			to := dst.From(u)
+			to := graph.NodesOf(dst.From(u))
 			sort.Sort(ordered.ByID(to))
 			for _, v := range to {
 				if rnd() < delta || dst.HasEdgeBetween(v, d) {
@@ -113,7 +113,7 @@ func Duplication(dst UndirectedMutator, n int, delta, alpha, sigma float64, src
 				}
 			}
 
-			if len(dst.From(d)) != 0 {
+			if dst.From(d).Len() != 0 {
 				break
 			}
 		}
@@ -67,7 +67,7 @@ func TunableClusteringScaleFree(dst graph.UndirectedBuilder, n, m int, p float64
 		for i := 0; i < m; i++ {
 			// Triad formation.
 			if i != 0 && rnd() < p {
-				for _, w := range permute(dst.From(simple.Node(u)), rndN) {
+				for _, w := range permute(graph.NodesOf(dst.From(simple.Node(u))), rndN) {
 					wid := w.ID()
 					if wid == int64(v) || dst.HasEdgeBetween(w, simple.Node(v)) {
 						continue
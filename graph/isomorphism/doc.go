@@ -0,0 +1,9 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package isomorphism provides graph and subgraph isomorphism functions.
+//
+// Mappings between graphs are returned as maps from the IDs of nodes in the
+// first graph to the IDs of the corresponding nodes in the second graph.
+package isomorphism // import "gonum.org/v1/gonum/graph/isomorphism"
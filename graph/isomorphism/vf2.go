@@ -0,0 +1,411 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package isomorphism
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// NodeMatch is a node compatibility function. It returns whether the node a
+// of the first graph may be mapped to the node b of the second graph.
+type NodeMatch func(a, b graph.Node) bool
+
+// EdgeMatch is an edge compatibility function. It returns whether the edge a
+// of the first graph may be mapped to the edge b of the second graph.
+type EdgeMatch func(a, b graph.Edge) bool
+
+// Isomorphism returns a mapping from the nodes of a to the nodes of b under
+// which a and b are isomorphic, using the VF2 algorithm. If nodeMatch or
+// edgeMatch are non-nil, only mappings for which every mapped pair of nodes
+// and edges is compatible are considered. If a and b are not isomorphic,
+// Isomorphism returns nil and false.
+//
+// Graphs are treated as directed if they implement graph.Directed, and as
+// undirected otherwise. Isomorphism will panic if only one of a and b is
+// directed.
+func Isomorphism(a, b graph.Graph, nodeMatch NodeMatch, edgeMatch EdgeMatch) (mapping map[int64]int64, ok bool) {
+	ga := newVF2Graph(a)
+	gb := newVF2Graph(b)
+	if ga.directed != gb.directed {
+		panic("isomorphism: mismatched graph directedness")
+	}
+	if len(ga.nodes) != len(gb.nodes) || ga.size != gb.size {
+		return nil, false
+	}
+	s := newState(ga, gb, nodeMatch, edgeMatch, true)
+	s.match(func(m map[int64]int64) bool {
+		mapping = m
+		return true
+	})
+	return mapping, mapping != nil
+}
+
+// SubgraphIsomorphisms calls fn with each mapping from the nodes of pattern
+// to the nodes of g under which pattern is isomorphic to a node-induced
+// subgraph of g, using the VF2 algorithm. If nodeMatch or edgeMatch are
+// non-nil, only mappings for which every mapped pair of nodes and edges is
+// compatible are considered. The search is terminated if fn returns true.
+// Each mapping passed to fn is newly allocated and may be retained.
+//
+// Graphs are treated as directed if they implement graph.Directed, and as
+// undirected otherwise. SubgraphIsomorphisms will panic if only one of
+// pattern and g is directed.
+func SubgraphIsomorphisms(pattern, g graph.Graph, nodeMatch NodeMatch, edgeMatch EdgeMatch, fn func(mapping map[int64]int64) (stop bool)) {
+	gp := newVF2Graph(pattern)
+	gg := newVF2Graph(g)
+	if gp.directed != gg.directed {
+		panic("isomorphism: mismatched graph directedness")
+	}
+	if len(gp.nodes) > len(gg.nodes) || gp.size > gg.size {
+		return
+	}
+	newState(gp, gg, nodeMatch, edgeMatch, false).match(fn)
+}
+
+// vf2Graph is an indexed representation of a graph used
+// during a VF2 search.
+type vf2Graph struct {
+	g        graph.Graph
+	directed bool
+
+	nodes []graph.Node
+
+	// succ and pred hold the indices of the successors
+	// and predecessors of each node. For undirected
+	// graphs both hold the neighbours of the node.
+	succ, pred [][]int
+	adj        []set.Ints
+
+	// size is the total length of the succ lists.
+	size int
+}
+
+func newVF2Graph(g graph.Graph) *vf2Graph {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	d, directed := g.(graph.Directed)
+	vg := &vf2Graph{
+		g:        g,
+		directed: directed,
+		nodes:    nodes,
+		succ:     make([][]int, len(nodes)),
+		adj:      make([]set.Ints, len(nodes)),
+	}
+	for i, u := range nodes {
+		vg.adj[i] = make(set.Ints)
+		for to := g.From(u); to.Next(); {
+			j := indexOf[to.Node().ID()]
+			vg.succ[i] = append(vg.succ[i], j)
+			vg.adj[i].Add(j)
+		}
+		sort.Ints(vg.succ[i])
+		vg.size += len(vg.succ[i])
+	}
+	if !directed {
+		vg.pred = vg.succ
+		return vg
+	}
+	vg.pred = make([][]int, len(nodes))
+	for i, v := range nodes {
+		for from := d.To(v); from.Next(); {
+			vg.pred[i] = append(vg.pred[i], indexOf[from.Node().ID()])
+		}
+		sort.Ints(vg.pred[i])
+	}
+	return vg
+}
+
+// hasEdge returns whether there is an edge from the node with index u
+// to the node with index v.
+func (g *vf2Graph) hasEdge(u, v int) bool {
+	return g.adj[u].Has(v)
+}
+
+// edge returns the edge from the node with index u to the node with
+// index v.
+func (g *vf2Graph) edge(u, v int) graph.Edge {
+	return g.g.Edge(g.nodes[u], g.nodes[v])
+}
+
+// state is the state of a VF2 search mapping the nodes of p to the
+// nodes of g.
+type state struct {
+	p, g *vf2Graph
+
+	nodeMatch NodeMatch
+	edgeMatch EdgeMatch
+
+	// exact specifies that a graph isomorphism rather
+	// than a subgraph isomorphism is being searched for.
+	exact bool
+
+	// coreP and coreG hold the index of the node mapped
+	// to each node, or -1 if the node is not mapped.
+	coreP, coreG []int
+
+	// inP, outP, inG and outG hold the search depth at which
+	// each node entered the in- and out-terminal sets of the
+	// partial mapping, or zero if the node is not in the set.
+	// For undirected graphs the in and out sets are shared.
+	inP, outP []int
+	inG, outG []int
+
+	depth int
+}
+
+func newState(p, g *vf2Graph, nodeMatch NodeMatch, edgeMatch EdgeMatch, exact bool) *state {
+	s := &state{
+		p: p,
+		g: g,
+
+		nodeMatch: nodeMatch,
+		edgeMatch: edgeMatch,
+		exact:     exact,
+
+		coreP: make([]int, len(p.nodes)),
+		coreG: make([]int, len(g.nodes)),
+		outP:  make([]int, len(p.nodes)),
+		outG:  make([]int, len(g.nodes)),
+	}
+	for i := range s.coreP {
+		s.coreP[i] = -1
+	}
+	for i := range s.coreG {
+		s.coreG[i] = -1
+	}
+	if p.directed {
+		s.inP = make([]int, len(p.nodes))
+		s.inG = make([]int, len(g.nodes))
+	} else {
+		s.inP = s.outP
+		s.inG = s.outG
+	}
+	return s
+}
+
+// match extends the current partial mapping, calling fn with each complete
+// mapping that is found. It returns whether fn has terminated the search.
+func (s *state) match(fn func(map[int64]int64) bool) (stop bool) {
+	if s.depth == len(s.p.nodes) {
+		m := make(map[int64]int64, len(s.coreP))
+		for n, m2 := range s.coreP {
+			m[s.p.nodes[n].ID()] = s.g.nodes[m2].ID()
+		}
+		return fn(m)
+	}
+
+	n, term := s.nextCandidates()
+	for m := range s.g.nodes {
+		if s.coreG[m] != -1 || (term != nil && term[m] == 0) {
+			continue
+		}
+		if !s.feasible(n, m) {
+			continue
+		}
+		s.add(n, m)
+		stop = s.match(fn)
+		s.remove(n, m)
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+// nextCandidates returns the index of the next node of p to map and
+// the terminal set of g that candidate nodes must be drawn from. If
+// term is nil, any unmapped node of g is a candidate.
+func (s *state) nextCandidates() (n int, term []int) {
+	if n := firstTerminal(s.outP, s.coreP); n != -1 && firstTerminal(s.outG, s.coreG) != -1 {
+		return n, s.outG
+	}
+	if n := firstTerminal(s.inP, s.coreP); n != -1 && firstTerminal(s.inG, s.coreG) != -1 {
+		return n, s.inG
+	}
+	for n, m := range s.coreP {
+		if m == -1 {
+			return n, nil
+		}
+	}
+	panic("isomorphism: no unmapped node")
+}
+
+// firstTerminal returns the lowest index of an unmapped node in the
+// terminal set term, or -1 if there is none.
+func firstTerminal(term, core []int) int {
+	for i, d := range term {
+		if d != 0 && core[i] == -1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// feasible returns whether the node n of p can be mapped to the node m
+// of g, extending the current partial mapping.
+func (s *state) feasible(n, m int) bool {
+	if s.nodeMatch != nil && !s.nodeMatch(s.p.nodes[n], s.g.nodes[m]) {
+		return false
+	}
+	if s.p.hasEdge(n, n) != s.g.hasEdge(m, m) {
+		return false
+	}
+	if s.p.hasEdge(n, n) && !s.edgesMatch(n, n, m, m) {
+		return false
+	}
+
+	if !s.consistent(n, m, s.p.succ[n], s.g.succ[m], false) {
+		return false
+	}
+	if s.p.directed && !s.consistent(n, m, s.p.pred[n], s.g.pred[m], true) {
+		return false
+	}
+
+	if !s.lookAhead(n, m, s.p.succ[n], s.g.succ[m]) {
+		return false
+	}
+	return !s.p.directed || s.lookAhead(n, m, s.p.pred[n], s.g.pred[m])
+}
+
+// consistent returns whether mapping n to m preserves the edges between
+// already mapped nodes. The nbrP and nbrG parameters hold the successors
+// of n and m, or their predecessors if reverse is true.
+func (s *state) consistent(n, m int, nbrP, nbrG []int, reverse bool) bool {
+	for _, np := range nbrP {
+		mp := s.coreP[np]
+		if np == n || mp == -1 {
+			continue
+		}
+		u, v, x, y := n, np, m, mp
+		if reverse {
+			u, v, x, y = np, n, mp, m
+		}
+		if !s.g.hasEdge(x, y) || !s.edgesMatch(u, v, x, y) {
+			return false
+		}
+	}
+	for _, mg := range nbrG {
+		ng := s.coreG[mg]
+		if mg == m || ng == -1 {
+			continue
+		}
+		u, v := n, ng
+		if reverse {
+			u, v = ng, n
+		}
+		if !s.p.hasEdge(u, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// edgesMatch returns whether the edge between the nodes of p with
+// indices u and v is compatible with the edge between the nodes of
+// g with indices x and y.
+func (s *state) edgesMatch(u, v, x, y int) bool {
+	return s.edgeMatch == nil || s.edgeMatch(s.p.edge(u, v), s.g.edge(x, y))
+}
+
+// lookAhead returns whether the unmapped neighbours of n in p, nbrP,
+// can be matched by the unmapped neighbours of m in g, nbrG, based on
+// their membership of the terminal sets.
+func (s *state) lookAhead(n, m int, nbrP, nbrG []int) bool {
+	inP, outP, newP := terminalCounts(n, nbrP, s.coreP, s.inP, s.outP)
+	inG, outG, newG := terminalCounts(m, nbrG, s.coreG, s.inG, s.outG)
+	if s.exact {
+		return inP == inG && outP == outG && newP == newG
+	}
+	return inP <= inG && outP <= outG && newP <= newG
+}
+
+// terminalCounts returns the number of unmapped nodes in nbr, excluding
+// self, that are in the in- and out-terminal sets, and in neither.
+func terminalCounts(self int, nbr, core, in, out []int) (nIn, nOut, nNew int) {
+	for _, v := range nbr {
+		if v == self || core[v] != -1 {
+			continue
+		}
+		if in[v] != 0 {
+			nIn++
+		}
+		if out[v] != 0 {
+			nOut++
+		}
+		if in[v] == 0 && out[v] == 0 {
+			nNew++
+		}
+	}
+	return nIn, nOut, nNew
+}
+
+// add adds the pair n and m to the partial mapping.
+func (s *state) add(n, m int) {
+	s.depth++
+	s.coreP[n] = m
+	s.coreG[m] = n
+	enter(s.depth, n, s.p.succ[n], s.p.pred[n], s.inP, s.outP)
+	enter(s.depth, m, s.g.succ[m], s.g.pred[m], s.inG, s.outG)
+}
+
+// remove removes the pair n and m from the partial mapping. It must
+// be the most recently added pair.
+func (s *state) remove(n, m int) {
+	leave(s.depth, n, s.p.succ[n], s.p.pred[n], s.inP, s.outP)
+	leave(s.depth, m, s.g.succ[m], s.g.pred[m], s.inG, s.outG)
+	s.coreP[n] = -1
+	s.coreG[m] = -1
+	s.depth--
+}
+
+// enter adds the newly mapped node u and its successors and predecessors
+// to the terminal sets at the given depth.
+func enter(depth, u int, succ, pred, in, out []int) {
+	if in[u] == 0 {
+		in[u] = depth
+	}
+	if out[u] == 0 {
+		out[u] = depth
+	}
+	for _, v := range succ {
+		if out[v] == 0 {
+			out[v] = depth
+		}
+	}
+	for _, v := range pred {
+		if in[v] == 0 {
+			in[v] = depth
+		}
+	}
+}
+
+// leave reverses the effect of enter at the given depth.
+func leave(depth, u int, succ, pred, in, out []int) {
+	if in[u] == depth {
+		in[u] = 0
+	}
+	if out[u] == depth {
+		out[u] = 0
+	}
+	for _, v := range succ {
+		if out[v] == depth {
+			out[v] = 0
+		}
+	}
+	for _, v := range pred {
+		if in[v] == depth {
+			in[v] = 0
+		}
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package isomorphism
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// cycle and petersen are the edges of a five node cycle and
+// of the Petersen graph.
+var (
+	cycle = []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(2), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(4)},
+		{F: simple.Node(4), T: simple.Node(0)},
+	}
+	petersen = []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(2), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(4)},
+		{F: simple.Node(4), T: simple.Node(0)},
+		{F: simple.Node(0), T: simple.Node(5)},
+		{F: simple.Node(1), T: simple.Node(6)},
+		{F: simple.Node(2), T: simple.Node(7)},
+		{F: simple.Node(3), T: simple.Node(8)},
+		{F: simple.Node(4), T: simple.Node(9)},
+		{F: simple.Node(5), T: simple.Node(7)},
+		{F: simple.Node(7), T: simple.Node(9)},
+		{F: simple.Node(9), T: simple.Node(6)},
+		{F: simple.Node(6), T: simple.Node(8)},
+		{F: simple.Node(8), T: simple.Node(5)},
+	}
+)
+
+// prism is the edges of the pentagonal prism, which has the same
+// number of nodes and edges as the Petersen graph and is also
+// 3-regular.
+var prism = append(append(append([]simple.Edge(nil), cycle...),
+	relabel(cycle, []int{5, 6, 7, 8, 9})...),
+	simple.Edge{F: simple.Node(0), T: simple.Node(5)},
+	simple.Edge{F: simple.Node(1), T: simple.Node(6)},
+	simple.Edge{F: simple.Node(2), T: simple.Node(7)},
+	simple.Edge{F: simple.Node(3), T: simple.Node(8)},
+	simple.Edge{F: simple.Node(4), T: simple.Node(9)},
+)
+
+var isomorphismTests = []struct {
+	name     string
+	directed bool
+	a, b     []simple.Edge
+
+	want bool
+}{
+	{
+		name: "empty",
+		want: true,
+	},
+	{
+		name: "relabelled cycle",
+		a:    cycle,
+		b:    relabel(cycle, []int{3, 0, 4, 1, 2}),
+		want: true,
+	},
+	{
+		name:     "reversed directed cycle",
+		directed: true,
+		a:        cycle,
+		b:        reverse(cycle),
+		want:     true,
+	},
+	{
+		name: "path and star",
+		a: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+		},
+		b: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+		},
+		want: false,
+	},
+	{
+		name:     "directed path and converging path",
+		directed: true,
+		a: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+		},
+		b: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(2), T: simple.Node(1)},
+		},
+		want: false,
+	},
+	{
+		name: "relabelled petersen",
+		a:    petersen,
+		b:    relabel(petersen, []int{7, 2, 9, 0, 4, 1, 8, 3, 6, 5}),
+		want: true,
+	},
+	{
+		name: "petersen and pentagonal prism",
+		a:    petersen,
+		b:    prism,
+		want: false,
+	},
+}
+
+func TestIsomorphism(t *testing.T) {
+	for _, test := range isomorphismTests {
+		a := newGraph(test.directed, test.a)
+		b := newGraph(test.directed, test.b)
+		got, ok := Isomorphism(a, b, nil, nil)
+		if ok != test.want {
+			t.Errorf("unexpected isomorphism result for %q: got:%t want:%t", test.name, ok, test.want)
+			continue
+		}
+		if !ok {
+			if got != nil {
+				t.Errorf("unexpected mapping for non-isomorphic graphs %q: %v", test.name, got)
+			}
+			continue
+		}
+		if !isEmbedding(a, b, got) || len(graph.NodesOf(b.Nodes())) != len(got) {
+			t.Errorf("invalid isomorphism for %q: %v", test.name, got)
+		}
+	}
+}
+
+func TestIsomorphismRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		for i := 0; i < 200; i++ {
+			n := 1 + rnd.Intn(6)
+			p := rnd.Float64()
+			a := randomEdges(rnd, n, p, directed)
+			b := relabel(a, rnd.Perm(n))
+			if rnd.Intn(2) == 0 && len(b) != 0 {
+				// Move one edge so that the graphs
+				// are usually not isomorphic.
+				e := &b[rnd.Intn(len(b))]
+				u, v := rnd.Intn(n), rnd.Intn(n)
+				if u != v && !hasEdgeIn(b, u, v, directed) {
+					*e = simple.Edge{F: simple.Node(u), T: simple.Node(v)}
+				}
+			}
+			ga := newGraph(directed, a)
+			gb := newGraph(directed, b)
+			addNodes(ga, n)
+			addNodes(gb, n)
+
+			got, ok := Isomorphism(ga, gb, nil, nil)
+			want := len(embeddings(ga, gb)) != 0
+			if ok != want {
+				t.Errorf("unexpected isomorphism result for random graphs %d directed=%t: got:%t want:%t", i, directed, ok, want)
+				continue
+			}
+			if ok && !isEmbedding(ga, gb, got) {
+				t.Errorf("invalid isomorphism for random graphs %d directed=%t: %v", i, directed, got)
+			}
+		}
+	}
+}
+
+func TestIsomorphismMatch(t *testing.T) {
+	// Two labelled paths, A-B-A and A-A-B, that are
+	// isomorphic only when labels are ignored.
+	labels := map[int64]string{0: "A", 1: "B", 2: "A", 10: "A", 11: "A", 12: "B"}
+	a := newGraph(false, []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+	})
+	b := newGraph(false, []simple.Edge{
+		{F: simple.Node(10), T: simple.Node(11)},
+		{F: simple.Node(11), T: simple.Node(12)},
+	})
+	nodeMatch := func(a, b graph.Node) bool { return labels[a.ID()] == labels[b.ID()] }
+	if _, ok := Isomorphism(a, b, nil, nil); !ok {
+		t.Error("expected unlabelled paths to be isomorphic")
+	}
+	if _, ok := Isomorphism(a, b, nodeMatch, nil); ok {
+		t.Error("unexpected isomorphism between differently labelled paths")
+	}
+
+	wa := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	wb := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+	} {
+		wa.SetWeightedEdge(e)
+	}
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(5), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(3), W: 1},
+	} {
+		wb.SetWeightedEdge(e)
+	}
+	edgeMatch := func(a, b graph.Edge) bool {
+		return a.(graph.WeightedEdge).Weight() == b.(graph.WeightedEdge).Weight()
+	}
+	got, ok := Isomorphism(wa, wb, nil, edgeMatch)
+	want := map[int64]int64{0: 3, 1: 4, 2: 5}
+	if !ok || len(got) != len(want) {
+		t.Fatalf("unexpected weighted isomorphism result: got:%v want:%v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("unexpected weighted isomorphism: got:%v want:%v", got, want)
+			break
+		}
+	}
+
+	wb.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(4), T: simple.Node(3), W: 3})
+	if _, ok := Isomorphism(wa, wb, nil, edgeMatch); ok {
+		t.Error("unexpected isomorphism between differently weighted paths")
+	}
+}
+
+func TestIsomorphismMismatchedDirection(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for mismatched graph directedness")
+		}
+	}()
+	Isomorphism(simple.NewDirectedGraph(), simple.NewUndirectedGraph(), nil, nil)
+}
+
+var subgraphIsomorphismsTests = []struct {
+	name     string
+	directed bool
+	pattern  []simple.Edge
+	g        []simple.Edge
+
+	want int
+}{
+	{
+		name: "triangle in complete graph",
+		pattern: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+		},
+		g:    complete(4),
+		want: 24,
+	},
+	{
+		name: "path in complete graph",
+		pattern: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+		},
+		g:    complete(4),
+		want: 0,
+	},
+	{
+		name: "path in cycle",
+		pattern: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+		},
+		g:    cycle,
+		want: 10,
+	},
+	{
+		name:     "directed path in directed cycle",
+		directed: true,
+		pattern: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+		},
+		g:    cycle,
+		want: 5,
+	},
+	{
+		name: "cycle in petersen",
+		pattern: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(0)},
+		},
+		g: petersen,
+		// The Petersen graph has 12 five-cycles,
+		// each with 10 automorphisms.
+		want: 120,
+	},
+}
+
+func TestSubgraphIsomorphisms(t *testing.T) {
+	for _, test := range subgraphIsomorphismsTests {
+		p := newGraph(test.directed, test.pattern)
+		g := newGraph(test.directed, test.g)
+		var n int
+		SubgraphIsomorphisms(p, g, nil, nil, func(m map[int64]int64) bool {
+			if !isEmbedding(p, g, m) {
+				t.Errorf("invalid embedding for %q: %v", test.name, m)
+			}
+			n++
+			return false
+		})
+		if n != test.want {
+			t.Errorf("unexpected number of embeddings for %q: got:%d want:%d", test.name, n, test.want)
+		}
+
+		n = 0
+		SubgraphIsomorphisms(p, g, nil, nil, func(map[int64]int64) bool {
+			n++
+			return true
+		})
+		if test.want != 0 && n != 1 {
+			t.Errorf("search not terminated for %q: got %d calls", test.name, n)
+		}
+	}
+}
+
+func TestSubgraphIsomorphismsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			np := 1 + rnd.Intn(4)
+			ng := np + rnd.Intn(3)
+			p := newGraph(directed, randomEdges(rnd, np, rnd.Float64(), directed))
+			g := newGraph(directed, randomEdges(rnd, ng, rnd.Float64(), directed))
+			addNodes(p, np)
+			addNodes(g, ng)
+
+			var n int
+			SubgraphIsomorphisms(p, g, nil, nil, func(m map[int64]int64) bool {
+				if !isEmbedding(p, g, m) {
+					t.Errorf("invalid embedding for random graphs %d directed=%t: %v", i, directed, m)
+				}
+				n++
+				return false
+			})
+			if want := len(embeddings(p, g)); n != want {
+				t.Errorf("unexpected number of embeddings for random graphs %d directed=%t: got:%d want:%d", i, directed, n, want)
+			}
+		}
+	}
+}
+
+type builder interface {
+	graph.Graph
+	graph.Builder
+}
+
+func newGraph(directed bool, edges []simple.Edge) builder {
+	var g builder
+	if directed {
+		g = simple.NewDirectedGraph()
+	} else {
+		g = simple.NewUndirectedGraph()
+	}
+	for _, e := range edges {
+		g.SetEdge(e)
+	}
+	return g
+}
+
+// addNodes adds any of the nodes 0 to n-1 that are not already in g.
+func addNodes(g builder, n int) {
+	for i := 0; i < n; i++ {
+		if !g.Has(simple.Node(i)) {
+			g.AddNode(simple.Node(i))
+		}
+	}
+}
+
+// relabel returns a copy of edges with node i replaced by node perm[i].
+func relabel(edges []simple.Edge, perm []int) []simple.Edge {
+	r := make([]simple.Edge, len(edges))
+	for i, e := range edges {
+		r[i] = simple.Edge{F: simple.Node(perm[e.F.ID()]), T: simple.Node(perm[e.T.ID()])}
+	}
+	return r
+}
+
+// reverse returns a copy of edges with the direction of each edge reversed.
+func reverse(edges []simple.Edge) []simple.Edge {
+	r := make([]simple.Edge, len(edges))
+	for i, e := range edges {
+		r[i] = simple.Edge{F: e.T, T: e.F}
+	}
+	return r
+}
+
+// complete returns the edges of a complete graph with n nodes.
+func complete(n int) []simple.Edge {
+	var edges []simple.Edge
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			edges = append(edges, simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return edges
+}
+
+func randomEdges(rnd *rand.Rand, n int, p float64, directed bool) []simple.Edge {
+	var edges []simple.Edge
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u == v || (!directed && v < u) || rnd.Float64() >= p {
+				continue
+			}
+			edges = append(edges, simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return edges
+}
+
+func hasEdgeIn(edges []simple.Edge, u, v int, directed bool) bool {
+	for _, e := range edges {
+		f, t := int(e.F.ID()), int(e.T.ID())
+		if (f == u && t == v) || (!directed && f == v && t == u) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEdge(g graph.Graph, u, v graph.Node) bool {
+	if d, ok := g.(graph.Directed); ok {
+		return d.HasEdgeFromTo(u, v)
+	}
+	return g.HasEdgeBetween(u, v)
+}
+
+// isEmbedding returns whether m is an injective mapping from the nodes
+// of p to the nodes of g under which p is isomorphic to the subgraph of
+// g induced by the mapped nodes.
+func isEmbedding(p, g graph.Graph, m map[int64]int64) bool {
+	nodes := graph.NodesOf(p.Nodes())
+	if len(m) != len(nodes) {
+		return false
+	}
+	used := make(map[int64]bool)
+	for _, n := range nodes {
+		id, ok := m[n.ID()]
+		if !ok || used[id] || !g.Has(simple.Node(id)) {
+			return false
+		}
+		used[id] = true
+	}
+	for _, u := range nodes {
+		for _, v := range nodes {
+			if hasEdge(p, u, v) != hasEdge(g, simple.Node(m[u.ID()]), simple.Node(m[v.ID()])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// embeddings returns all the mappings from the nodes of p to the nodes
+// of g under which p is isomorphic to an induced subgraph of g by
+// exhaustive search.
+func embeddings(p, g graph.Graph) []map[int64]int64 {
+	pNodes := graph.NodesOf(p.Nodes())
+	gNodes := graph.NodesOf(g.Nodes())
+	var found []map[int64]int64
+	m := make(map[int64]int64)
+	used := make(map[int64]bool)
+	var extend func(i int)
+	extend = func(i int) {
+		if i == len(pNodes) {
+			if isEmbedding(p, g, m) {
+				c := make(map[int64]int64, len(m))
+				for k, v := range m {
+					c[k] = v
+				}
+				found = append(found, c)
+			}
+			return
+		}
+		for _, v := range gNodes {
+			if used[v.ID()] {
+				continue
+			}
+			used[v.ID()] = true
+			m[pNodes[i].ID()] = v.ID()
+			extend(i + 1)
+			delete(m, pNodes[i].ID())
+			used[v.ID()] = false
+		}
+	}
+	extend(0)
+	return found
+}
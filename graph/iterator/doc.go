@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package iterator provides node and edge iterators for graphs.
+//
+// The Ordered iterators iterate over a slice in order. The map-backed
+// iterators iterate over the values of a map in an arbitrary order.
+// They walk the map directly, without copying its contents into a
+// slice, when built with Go 1.18 or later.
+package iterator // import "gonum.org/v1/gonum/graph/iterator"
@@ -0,0 +1,219 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iterator_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var (
+	_ graph.Nodes              = (*iterator.OrderedNodes)(nil)
+	_ graph.NodeSlicer         = (*iterator.OrderedNodes)(nil)
+	_ graph.Nodes              = (*iterator.ImplicitNodes)(nil)
+	_ graph.NodeSlicer         = (*iterator.ImplicitNodes)(nil)
+	_ graph.Nodes              = (*iterator.Nodes)(nil)
+	_ graph.NodeSlicer         = (*iterator.Nodes)(nil)
+	_ graph.Nodes              = (*iterator.NodesByEdge)(nil)
+	_ graph.Nodes              = (*iterator.NodesByWeightedEdge)(nil)
+	_ graph.Nodes              = (*iterator.NodesByLines)(nil)
+	_ graph.Edges              = (*iterator.OrderedEdges)(nil)
+	_ graph.EdgeSlicer         = (*iterator.OrderedEdges)(nil)
+	_ graph.WeightedEdges      = (*iterator.OrderedWeightedEdges)(nil)
+	_ graph.WeightedEdgeSlicer = (*iterator.OrderedWeightedEdges)(nil)
+)
+
+var nodesTests = [][]graph.Node{
+	nil,
+	{simple.Node(1)},
+	{simple.Node(1), simple.Node(2), simple.Node(3), simple.Node(5)},
+}
+
+func TestOrderedNodes(t *testing.T) {
+	for _, want := range nodesTests {
+		it := iterator.NewOrderedNodes(want)
+		for i := 0; i < 2; i++ {
+			if it.Len() != len(want) {
+				t.Errorf("unexpected iterator length for round %d: got:%d want:%d", i, it.Len(), len(want))
+			}
+			var got []graph.Node
+			for it.Next() {
+				got = append(got, it.Node())
+				if it.Len() != len(want)-len(got) {
+					t.Errorf("unexpected remaining length: got:%d want:%d", it.Len(), len(want)-len(got))
+				}
+			}
+			if it.Node() != nil {
+				t.Errorf("unexpected non-nil node after iteration: %v", it.Node())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected iterator output for round %d: got:%v want:%v", i, got, want)
+			}
+			it.Reset()
+		}
+	}
+}
+
+func TestOrderedNodesSlice(t *testing.T) {
+	for _, want := range nodesTests {
+		it := iterator.NewOrderedNodes(want)
+		for i := 0; i < 2; i++ {
+			got := it.NodeSlice()
+			if !reflect.DeepEqual(got, want) && !(len(got) == 0 && len(want) == 0) {
+				t.Errorf("unexpected iterator output for round %d: got:%v want:%v", i, got, want)
+			}
+			if it.Len() != 0 || it.Next() {
+				t.Errorf("unexpected remaining nodes after NodeSlice for round %d", i)
+			}
+			it.Reset()
+		}
+		if len(want) > 1 {
+			it.Next()
+			got := it.NodeSlice()
+			if !reflect.DeepEqual(got, want[1:]) {
+				t.Errorf("unexpected partial iterator output: got:%v want:%v", got, want[1:])
+			}
+		}
+	}
+}
+
+func TestImplicitNodes(t *testing.T) {
+	for _, test := range []struct{ beg, end int }{
+		{beg: 0, end: 0},
+		{beg: 0, end: 1},
+		{beg: 2, end: 6},
+	} {
+		var want []graph.Node
+		for id := test.beg; id < test.end; id++ {
+			want = append(want, simple.Node(id))
+		}
+		it := iterator.NewImplicitNodes(test.beg, test.end, func(id int) graph.Node { return simple.Node(id) })
+		for i := 0; i < 2; i++ {
+			if it.Len() != len(want) {
+				t.Errorf("unexpected iterator length for round %d: got:%d want:%d", i, it.Len(), len(want))
+			}
+			var got []graph.Node
+			for it.Next() {
+				got = append(got, it.Node())
+			}
+			if it.Node() != nil {
+				t.Errorf("unexpected non-nil node after iteration: %v", it.Node())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected iterator output for round %d: got:%v want:%v", i, got, want)
+			}
+			it.Reset()
+		}
+		got := it.NodeSlice()
+		if len(got) != len(want) || (len(want) != 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("unexpected node slice: got:%v want:%v", got, want)
+		}
+		if it.Len() != 0 {
+			t.Errorf("unexpected iterator length after slice: got:%d want:0", it.Len())
+		}
+	}
+}
+
+func TestNodes(t *testing.T) {
+	for _, want := range nodesTests {
+		nodes := make(map[int64]graph.Node)
+		edges := make(map[int64]graph.Edge)
+		weighted := make(map[int64]graph.WeightedEdge)
+		lines := make(map[int64]map[int64]graph.Line)
+		for _, n := range want {
+			nodes[n.ID()] = n
+			edges[n.ID()] = simple.Edge{F: simple.Node(-1), T: n}
+			weighted[n.ID()] = simple.WeightedEdge{F: simple.Node(-1), T: n}
+			lines[n.ID()] = nil
+		}
+		for _, it := range []graph.Nodes{
+			iterator.NewNodes(nodes),
+			iterator.NewNodesByEdge(nodes, edges),
+			iterator.NewNodesByWeightedEdge(nodes, weighted),
+			iterator.NewNodesByLines(nodes, lines),
+		} {
+			for i := 0; i < 2; i++ {
+				if it.Len() != len(want) {
+					t.Errorf("unexpected iterator length for %T round %d: got:%d want:%d", it, i, it.Len(), len(want))
+				}
+				var got []graph.Node
+				for it.Next() {
+					got = append(got, it.Node())
+					if it.Len() != len(want)-len(got) {
+						t.Errorf("unexpected remaining length for %T: got:%d want:%d", it, it.Len(), len(want)-len(got))
+					}
+				}
+				sort.Sort(ordered.ByID(got))
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("unexpected iterator output for %T round %d: got:%v want:%v", it, i, got, want)
+				}
+				it.Reset()
+			}
+			got := graph.NodesOf(it)
+			sort.Sort(ordered.ByID(got))
+			if !reflect.DeepEqual(got, want) && !(len(got) == 0 && len(want) == 0) {
+				t.Errorf("unexpected NodesOf output for %T: got:%v want:%v", it, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderedEdges(t *testing.T) {
+	for _, want := range [][]graph.Edge{
+		nil,
+		{simple.Edge{F: simple.Node(1), T: simple.Node(2)}},
+		{simple.Edge{F: simple.Node(1), T: simple.Node(2)}, simple.Edge{F: simple.Node(2), T: simple.Node(3)}},
+	} {
+		it := iterator.NewOrderedEdges(want)
+		for i := 0; i < 2; i++ {
+			if it.Len() != len(want) {
+				t.Errorf("unexpected iterator length for round %d: got:%d want:%d", i, it.Len(), len(want))
+			}
+			var got []graph.Edge
+			for it.Next() {
+				got = append(got, it.Edge())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected iterator output for round %d: got:%v want:%v", i, got, want)
+			}
+			it.Reset()
+		}
+		if got := graph.EdgesOf(it); len(got) != len(want) {
+			t.Errorf("unexpected EdgesOf output: got:%v want:%v", got, want)
+		}
+	}
+}
+
+func TestOrderedWeightedEdges(t *testing.T) {
+	for _, want := range [][]graph.WeightedEdge{
+		nil,
+		{simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 1}},
+		{simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 1}, simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 2}},
+	} {
+		it := iterator.NewOrderedWeightedEdges(want)
+		for i := 0; i < 2; i++ {
+			if it.Len() != len(want) {
+				t.Errorf("unexpected iterator length for round %d: got:%d want:%d", i, it.Len(), len(want))
+			}
+			var got []graph.WeightedEdge
+			for it.Next() {
+				got = append(got, it.WeightedEdge())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected iterator output for round %d: got:%v want:%v", i, got, want)
+			}
+			it.Reset()
+		}
+		if got := graph.WeightedEdgesOf(it); len(got) != len(want) {
+			t.Errorf("unexpected WeightedEdgesOf output: got:%v want:%v", got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package iterator
+
+import "reflect"
+
+// mapIter walks the entries of a map, storing the key and value of the
+// current entry in the variables it was initialized with. mapIter does
+// not allocate during iteration.
+type mapIter struct {
+	m    reflect.Value
+	iter reflect.MapIter
+	done bool
+
+	key, value reflect.Value
+}
+
+// init initializes the iterator to walk the map m. If key or value is
+// not nil it must be a pointer to a variable of the map's key or value
+// type respectively, and the variable is set to the current key or value
+// by each successful call to next.
+func (it *mapIter) init(m, key, value interface{}) {
+	it.m = reflect.ValueOf(m)
+	if key != nil {
+		it.key = reflect.ValueOf(key).Elem()
+	}
+	if value != nil {
+		it.value = reflect.ValueOf(value).Elem()
+	}
+	it.reset()
+}
+
+// next advances the iterator and returns whether an entry was found.
+func (it *mapIter) next() bool {
+	if it.done || !it.iter.Next() {
+		it.done = true
+		return false
+	}
+	if it.key.IsValid() {
+		it.key.SetIterKey(&it.iter)
+	}
+	if it.value.IsValid() {
+		it.value.SetIterValue(&it.iter)
+	}
+	return true
+}
+
+// reset returns the iterator to the start of the map.
+func (it *mapIter) reset() {
+	it.iter.Reset(it.m)
+	it.done = false
+}
@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !go1.18
+// +build !go1.18
+
+package iterator
+
+import "reflect"
+
+// mapIter walks the entries of a map, storing the key and value of the
+// current entry in the variables it was initialized with. Without
+// reflect.MapIter, the keys of the map are gathered on the first call
+// to next.
+type mapIter struct {
+	m    reflect.Value
+	keys []reflect.Value
+	idx  int
+
+	key, value reflect.Value
+}
+
+// init initializes the iterator to walk the map m. If key or value is
+// not nil it must be a pointer to a variable of the map's key or value
+// type respectively, and the variable is set to the current key or value
+// by each successful call to next.
+func (it *mapIter) init(m, key, value interface{}) {
+	it.m = reflect.ValueOf(m)
+	if key != nil {
+		it.key = reflect.ValueOf(key).Elem()
+	}
+	if value != nil {
+		it.value = reflect.ValueOf(value).Elem()
+	}
+	it.reset()
+}
+
+// next advances the iterator and returns whether an entry was found.
+func (it *mapIter) next() bool {
+	if it.keys == nil {
+		it.keys = it.m.MapKeys()
+	}
+	if it.idx+1 >= len(it.keys) {
+		it.idx = len(it.keys)
+		return false
+	}
+	it.idx++
+	k := it.keys[it.idx]
+	if it.key.IsValid() {
+		it.key.Set(k)
+	}
+	if it.value.IsValid() {
+		it.value.Set(it.m.MapIndex(k))
+	}
+	return true
+}
+
+// reset returns the iterator to the start of the map.
+func (it *mapIter) reset() {
+	it.idx = -1
+}
@@ -0,0 +1,404 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iterator
+
+import "gonum.org/v1/gonum/graph"
+
+// OrderedNodes implements the graph.Nodes and graph.NodeSlicer interfaces.
+// The iteration order of OrderedNodes is the order of nodes passed to
+// NewOrderedNodes.
+type OrderedNodes struct {
+	idx   int
+	nodes []graph.Node
+}
+
+// NewOrderedNodes returns an OrderedNodes initialized with the provided nodes.
+func NewOrderedNodes(nodes []graph.Node) *OrderedNodes {
+	return &OrderedNodes{idx: -1, nodes: nodes}
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *OrderedNodes) Len() int {
+	if n.idx >= len(n.nodes) {
+		return 0
+	}
+	return len(n.nodes) - n.idx - 1
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *OrderedNodes) Next() bool {
+	if n.idx+1 < len(n.nodes) {
+		n.idx++
+		return true
+	}
+	n.idx = len(n.nodes)
+	return false
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *OrderedNodes) Node() graph.Node {
+	if n.idx < 0 || n.idx >= len(n.nodes) {
+		return nil
+	}
+	return n.nodes[n.idx]
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *OrderedNodes) NodeSlice() []graph.Node {
+	if n.idx >= len(n.nodes) {
+		return nil
+	}
+	idx := n.idx + 1
+	n.idx = len(n.nodes)
+	return n.nodes[idx:]
+}
+
+// Reset returns the iterator to its initial state.
+func (n *OrderedNodes) Reset() {
+	n.idx = -1
+}
+
+// ImplicitNodes implements the graph.Nodes interface for a set of nodes over
+// a contiguous ID range.
+type ImplicitNodes struct {
+	beg, end int
+	curr     int
+	newNode  func(id int) graph.Node
+}
+
+// NewImplicitNodes returns a new implicit node iterator spanning nodes in [beg,end).
+// The provided new func maps the id to a graph.Node. NewImplicitNodes will panic
+// if beg is greater than end.
+func NewImplicitNodes(beg, end int, new func(id int) graph.Node) *ImplicitNodes {
+	if beg > end {
+		panic("iterator: invalid range")
+	}
+	return &ImplicitNodes{beg: beg, end: end, curr: beg - 1, newNode: new}
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *ImplicitNodes) Len() int {
+	if n.curr >= n.end {
+		return 0
+	}
+	return n.end - n.curr - 1
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *ImplicitNodes) Next() bool {
+	if n.curr == n.end {
+		return false
+	}
+	n.curr++
+	return n.curr < n.end
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *ImplicitNodes) Node() graph.Node {
+	if n.curr < n.beg || n.curr >= n.end {
+		return nil
+	}
+	return n.newNode(n.curr)
+}
+
+// Reset returns the iterator to its initial state.
+func (n *ImplicitNodes) Reset() {
+	n.curr = n.beg - 1
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *ImplicitNodes) NodeSlice() []graph.Node {
+	if n.Len() == 0 {
+		n.curr = n.end
+		return nil
+	}
+	nodes := make([]graph.Node, 0, n.Len())
+	for n.curr++; n.curr < n.end; n.curr++ {
+		nodes = append(nodes, n.newNode(n.curr))
+	}
+	return nodes
+}
+
+// Nodes implements the graph.Nodes and graph.NodeSlicer interfaces.
+// The iteration order of Nodes is randomized.
+type Nodes struct {
+	nodes map[int64]graph.Node
+
+	pos  int
+	curr graph.Node
+	iter mapIter
+}
+
+// NewNodes returns a Nodes initialized with the provided nodes, a
+// map of node IDs to graph.Nodes. No check is made that the keys
+// match the graph.Node IDs, and the map keys are not used.
+//
+// Behavior of the Nodes is unspecified if nodes is mutated after
+// the call to NewNodes.
+func NewNodes(nodes map[int64]graph.Node) *Nodes {
+	n := &Nodes{nodes: nodes}
+	n.iter.init(nodes, nil, &n.curr)
+	return n
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *Nodes) Len() int {
+	return len(n.nodes) - n.pos
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *Nodes) Next() bool {
+	if n.pos >= len(n.nodes) || !n.iter.next() {
+		n.pos = len(n.nodes)
+		n.curr = nil
+		return false
+	}
+	n.pos++
+	return true
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *Nodes) Node() graph.Node {
+	return n.curr
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *Nodes) NodeSlice() []graph.Node {
+	if n.Len() == 0 {
+		n.curr = nil
+		return nil
+	}
+	nodes := make([]graph.Node, 0, n.Len())
+	for n.Next() {
+		nodes = append(nodes, n.curr)
+	}
+	return nodes
+}
+
+// Reset returns the iterator to its initial state.
+func (n *Nodes) Reset() {
+	n.pos = 0
+	n.curr = nil
+	n.iter.reset()
+}
+
+// NodesByEdge implements the graph.Nodes and graph.NodeSlicer interfaces.
+// The iteration order of NodesByEdge is randomized.
+type NodesByEdge struct {
+	nodes map[int64]graph.Node
+	edges map[int64]graph.Edge
+
+	pos  int
+	id   int64
+	curr graph.Node
+	iter mapIter
+}
+
+// NewNodesByEdge returns a NodesByEdge initialized with the
+// provided nodes, a map of node IDs to graph.Nodes, and the set
+// of edges, a map of to-node IDs to graph.Edge, that can be
+// traversed to reach the nodes that the NodesByEdge will iterate
+// over. No check is made that the keys match the graph.Node IDs,
+// and the map keys are not used.
+//
+// Behavior of the NodesByEdge is unspecified if nodes or edges
+// is mutated after the call to NewNodesByEdge.
+func NewNodesByEdge(nodes map[int64]graph.Node, edges map[int64]graph.Edge) *NodesByEdge {
+	n := &NodesByEdge{nodes: nodes, edges: edges}
+	n.iter.init(edges, &n.id, nil)
+	return n
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *NodesByEdge) Len() int {
+	return len(n.edges) - n.pos
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *NodesByEdge) Next() bool {
+	if n.pos >= len(n.edges) || !n.iter.next() {
+		n.pos = len(n.edges)
+		n.curr = nil
+		return false
+	}
+	n.pos++
+	n.curr = n.nodes[n.id]
+	return true
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *NodesByEdge) Node() graph.Node {
+	return n.curr
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *NodesByEdge) NodeSlice() []graph.Node {
+	if n.Len() == 0 {
+		n.curr = nil
+		return nil
+	}
+	nodes := make([]graph.Node, 0, n.Len())
+	for n.Next() {
+		nodes = append(nodes, n.curr)
+	}
+	return nodes
+}
+
+// Reset returns the iterator to its initial state.
+func (n *NodesByEdge) Reset() {
+	n.pos = 0
+	n.curr = nil
+	n.iter.reset()
+}
+
+// NodesByWeightedEdge implements the graph.Nodes and graph.NodeSlicer
+// interfaces. The iteration order of NodesByWeightedEdge is randomized.
+type NodesByWeightedEdge struct {
+	nodes map[int64]graph.Node
+	edges map[int64]graph.WeightedEdge
+
+	pos  int
+	id   int64
+	curr graph.Node
+	iter mapIter
+}
+
+// NewNodesByWeightedEdge returns a NodesByWeightedEdge initialized
+// with the provided nodes, a map of node IDs to graph.Nodes, and
+// the set of edges, a map of to-node IDs to graph.WeightedEdge,
+// that can be traversed to reach the nodes that the
+// NodesByWeightedEdge will iterate over. No check is made that the
+// keys match the graph.Node IDs, and the map keys are not used.
+//
+// Behavior of the NodesByWeightedEdge is unspecified if nodes or
+// edges is mutated after the call to NewNodesByWeightedEdge.
+func NewNodesByWeightedEdge(nodes map[int64]graph.Node, edges map[int64]graph.WeightedEdge) *NodesByWeightedEdge {
+	n := &NodesByWeightedEdge{nodes: nodes, edges: edges}
+	n.iter.init(edges, &n.id, nil)
+	return n
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *NodesByWeightedEdge) Len() int {
+	return len(n.edges) - n.pos
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *NodesByWeightedEdge) Next() bool {
+	if n.pos >= len(n.edges) || !n.iter.next() {
+		n.pos = len(n.edges)
+		n.curr = nil
+		return false
+	}
+	n.pos++
+	n.curr = n.nodes[n.id]
+	return true
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *NodesByWeightedEdge) Node() graph.Node {
+	return n.curr
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *NodesByWeightedEdge) NodeSlice() []graph.Node {
+	if n.Len() == 0 {
+		n.curr = nil
+		return nil
+	}
+	nodes := make([]graph.Node, 0, n.Len())
+	for n.Next() {
+		nodes = append(nodes, n.curr)
+	}
+	return nodes
+}
+
+// Reset returns the iterator to its initial state.
+func (n *NodesByWeightedEdge) Reset() {
+	n.pos = 0
+	n.curr = nil
+	n.iter.reset()
+}
+
+// NodesByLines implements the graph.Nodes and graph.NodeSlicer interfaces.
+// The iteration order of NodesByLines is randomized.
+type NodesByLines struct {
+	nodes map[int64]graph.Node
+	lines map[int64]map[int64]graph.Line
+
+	pos  int
+	id   int64
+	curr graph.Node
+	iter mapIter
+}
+
+// NewNodesByLines returns a NodesByLines initialized with the
+// provided nodes, a map of node IDs to graph.Nodes, and the set
+// of lines, a map of to-node IDs to sets of graph.Line, that can
+// be traversed to reach the nodes that the NodesByLines will
+// iterate over. No check is made that the keys match the
+// graph.Node IDs, and the map keys are not used.
+//
+// Behavior of the NodesByLines is unspecified if nodes or lines
+// is mutated after the call to NewNodesByLines.
+func NewNodesByLines(nodes map[int64]graph.Node, lines map[int64]map[int64]graph.Line) *NodesByLines {
+	n := &NodesByLines{nodes: nodes, lines: lines}
+	n.iter.init(lines, &n.id, nil)
+	return n
+}
+
+// Len returns the remaining number of nodes to be iterated over.
+func (n *NodesByLines) Len() int {
+	return len(n.lines) - n.pos
+}
+
+// Next returns whether the next call of Node will return a valid node.
+func (n *NodesByLines) Next() bool {
+	if n.pos >= len(n.lines) || !n.iter.next() {
+		n.pos = len(n.lines)
+		n.curr = nil
+		return false
+	}
+	n.pos++
+	n.curr = n.nodes[n.id]
+	return true
+}
+
+// Node returns the current node of the iterator. Next must have been
+// called prior to a call to Node.
+func (n *NodesByLines) Node() graph.Node {
+	return n.curr
+}
+
+// NodeSlice returns all the remaining nodes in the iterator and advances
+// the iterator.
+func (n *NodesByLines) NodeSlice() []graph.Node {
+	if n.Len() == 0 {
+		n.curr = nil
+		return nil
+	}
+	nodes := make([]graph.Node, 0, n.Len())
+	for n.Next() {
+		nodes = append(nodes, n.curr)
+	}
+	return nodes
+}
+
+// Reset returns the iterator to its initial state.
+func (n *NodesByLines) Reset() {
+	n.pos = 0
+	n.curr = nil
+	n.iter.reset()
+}
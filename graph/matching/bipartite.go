@@ -0,0 +1,216 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// bipartition returns the indices of the nodes in the two parts of a
+// bipartition of the graph with the given adjacency lists. If the graph
+// is not bipartite, ok is returned false.
+func bipartition(adj [][]int) (left, right []int, ok bool) {
+	const unseen = -1
+	side := make([]int, len(adj))
+	for i := range side {
+		side[i] = unseen
+	}
+	var queue []int
+	for s := range adj {
+		if side[s] != unseen {
+			continue
+		}
+		side[s] = 0
+		queue = append(queue[:0], s)
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adj[u] {
+				switch side[v] {
+				case unseen:
+					side[v] = 1 - side[u]
+					queue = append(queue, v)
+				case side[u]:
+					return nil, nil, false
+				}
+			}
+		}
+	}
+	for i, s := range side {
+		if s == 0 {
+			left = append(left, i)
+		} else {
+			right = append(right, i)
+		}
+	}
+	return left, right, true
+}
+
+// HopcroftKarp returns a maximum cardinality matching of the bipartite
+// graph g using the Hopcroft-Karp algorithm. The bipartition of g is
+// determined from the graph's structure. If g is not bipartite, HopcroftKarp
+// returns nil and false. Self loops in g cause it to be treated as not
+// bipartite.
+//
+// The time complexity of HopcroftKarp is O(|E|.sqrt(|V|)).
+func HopcroftKarp(g graph.Undirected) (matching []graph.Edge, ok bool) {
+	idx := indexNodes(g)
+	for _, u := range idx.nodes {
+		if g.HasEdgeBetween(u, u) {
+			return nil, false
+		}
+	}
+	adj := adjacency(g, idx)
+	left, _, ok := bipartition(adj)
+	if !ok {
+		return nil, false
+	}
+
+	hk := hopcroftKarp{
+		adj:  adj,
+		left: left,
+		mate: make([]int, len(adj)),
+		dist: make([]int, len(adj)),
+	}
+	for i := range hk.mate {
+		hk.mate[i] = -1
+	}
+	for hk.bfs() {
+		for _, u := range hk.left {
+			if hk.mate[u] == -1 {
+				hk.dfs(u)
+			}
+		}
+	}
+
+	return matchedEdges(g, idx, hk.mate), true
+}
+
+// hopcroftKarp holds the state of a Hopcroft-Karp search.
+type hopcroftKarp struct {
+	adj  [][]int
+	left []int
+
+	// mate holds the index of the node matched
+	// with each node, or -1 if it is unmatched.
+	mate []int
+
+	// dist holds the layer of each left node in
+	// the alternating level graph, or -1 if the
+	// node is not in the current level graph.
+	dist []int
+}
+
+// bfs builds the alternating level graph from the free left nodes
+// and returns whether an augmenting path exists.
+func (hk *hopcroftKarp) bfs() bool {
+	var queue []int
+	for _, u := range hk.left {
+		if hk.mate[u] == -1 {
+			hk.dist[u] = 0
+			queue = append(queue, u)
+		} else {
+			hk.dist[u] = -1
+		}
+	}
+	found := false
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range hk.adj[u] {
+			w := hk.mate[v]
+			if w == -1 {
+				found = true
+				continue
+			}
+			if hk.dist[w] == -1 {
+				hk.dist[w] = hk.dist[u] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return found
+}
+
+// dfs attempts to find an augmenting path from the left node u
+// along the level graph, augmenting the matching if one is found.
+func (hk *hopcroftKarp) dfs(u int) bool {
+	for _, v := range hk.adj[u] {
+		w := hk.mate[v]
+		if w == -1 || (hk.dist[w] == hk.dist[u]+1 && hk.dfs(w)) {
+			hk.mate[u] = v
+			hk.mate[v] = u
+			return true
+		}
+	}
+	// Remove u from the level graph so that it
+	// is not searched again in this phase.
+	hk.dist[u] = -1
+	return false
+}
+
+// MaxWeightBipartite returns a maximum weight matching of the bipartite
+// graph g and the sum of the weights of the matched edges. Edge weights
+// are obtained from the Weight method of g, and edges with non-positive
+// weight are never included in the matching. The bipartition of g is
+// determined from the graph's structure. If g is not bipartite,
+// MaxWeightBipartite returns nil, zero and false.
+//
+// MaxWeightBipartite uses the Hungarian algorithm and has a time complexity
+// of O(|V|^3).
+func MaxWeightBipartite(g graph.WeightedUndirected) (matching []graph.WeightedEdge, weight float64, ok bool) {
+	idx := indexNodes(g)
+	for _, u := range idx.nodes {
+		if g.HasEdgeBetween(u, u) {
+			return nil, 0, false
+		}
+	}
+	adj := adjacency(g, idx)
+	left, right, ok := bipartition(adj)
+	if !ok {
+		return nil, 0, false
+	}
+	if len(left) == 0 || len(right) == 0 {
+		return nil, 0, true
+	}
+
+	// Non-edges and edges with non-positive weight
+	// are given zero cost so that they can take part
+	// in a complete assignment without contributing
+	// to the matching.
+	col := make(map[int]int, len(right))
+	for j, v := range right {
+		col[v] = j
+	}
+	cost := mat.NewDense(len(left), len(right), nil)
+	for i, u := range left {
+		for _, v := range adj[u] {
+			w, ok := g.Weight(idx.nodes[u], idx.nodes[v])
+			if !ok {
+				panic("matching: unexpected invalid weight")
+			}
+			if math.IsNaN(w) || math.IsInf(w, 0) {
+				panic("matching: non-finite edge weight")
+			}
+			if w > 0 {
+				cost.Set(i, col[v], -w)
+			}
+		}
+	}
+
+	assign, _ := Hungarian(cost)
+	for i, j := range assign {
+		if j < 0 || cost.At(i, j) == 0 {
+			continue
+		}
+		e := g.WeightedEdgeBetween(idx.nodes[left[i]], idx.nodes[right[j]])
+		matching = append(matching, e)
+		weight -= cost.At(i, j)
+	}
+	return matching, weight, true
+}
@@ -0,0 +1,177 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import "gonum.org/v1/gonum/graph"
+
+// Edmonds returns a maximum cardinality matching of the undirected graph g
+// using Edmonds' blossom algorithm. Self loops in g are ignored.
+//
+// The time complexity of Edmonds is O(|V|^3).
+func Edmonds(g graph.Undirected) []graph.Edge {
+	idx := indexNodes(g)
+	b := newBlossom(adjacency(g, idx))
+
+	// Initialise with a greedy matching to
+	// reduce the number of augmentations.
+	for u, adj := range b.adj {
+		if b.mate[u] != -1 {
+			continue
+		}
+		for _, v := range adj {
+			if b.mate[v] == -1 {
+				b.mate[u] = v
+				b.mate[v] = u
+				break
+			}
+		}
+	}
+
+	for root := range b.adj {
+		if b.mate[root] != -1 {
+			continue
+		}
+		v := b.augmentingPath(root)
+		for v != -1 {
+			pv := b.parent[v]
+			ppv := b.mate[pv]
+			b.mate[v] = pv
+			b.mate[pv] = v
+			v = ppv
+		}
+	}
+
+	return matchedEdges(g, idx, b.mate)
+}
+
+// blossom holds the state of a blossom algorithm search.
+type blossom struct {
+	adj [][]int
+
+	// mate holds the index of the node matched
+	// with each node, or -1 if it is unmatched.
+	mate []int
+
+	// parent holds the parent of each odd node
+	// in the alternating tree, or -1.
+	parent []int
+
+	// base holds the base of the blossom that
+	// contains each node.
+	base []int
+
+	used      []bool
+	inBlossom []bool
+	onPath    []bool
+
+	queue []int
+}
+
+func newBlossom(adj [][]int) *blossom {
+	n := len(adj)
+	b := &blossom{
+		adj:       adj,
+		mate:      make([]int, n),
+		parent:    make([]int, n),
+		base:      make([]int, n),
+		used:      make([]bool, n),
+		inBlossom: make([]bool, n),
+		onPath:    make([]bool, n),
+	}
+	for i := range b.mate {
+		b.mate[i] = -1
+	}
+	return b
+}
+
+// augmentingPath searches for an augmenting path from the unmatched
+// node root, contracting blossoms as they are found. It returns the
+// unmatched end of the path, or -1 if no augmenting path exists. The
+// path can be followed by alternating between parent and mate.
+func (b *blossom) augmentingPath(root int) int {
+	for i := range b.adj {
+		b.used[i] = false
+		b.parent[i] = -1
+		b.base[i] = i
+	}
+	b.used[root] = true
+	b.queue = append(b.queue[:0], root)
+
+	for len(b.queue) != 0 {
+		v := b.queue[0]
+		b.queue = b.queue[1:]
+		for _, to := range b.adj[v] {
+			if b.base[v] == b.base[to] || b.mate[v] == to {
+				continue
+			}
+			if to == root || (b.mate[to] != -1 && b.parent[b.mate[to]] != -1) {
+				// We have found an odd cycle, so
+				// contract the blossom it forms.
+				base := b.lowestCommonAncestor(v, to)
+				for i := range b.inBlossom {
+					b.inBlossom[i] = false
+				}
+				b.markPath(v, base, to)
+				b.markPath(to, base, v)
+				for i := range b.adj {
+					if !b.inBlossom[b.base[i]] {
+						continue
+					}
+					b.base[i] = base
+					if !b.used[i] {
+						b.used[i] = true
+						b.queue = append(b.queue, i)
+					}
+				}
+				continue
+			}
+			if b.parent[to] == -1 {
+				b.parent[to] = v
+				if b.mate[to] == -1 {
+					return to
+				}
+				b.used[b.mate[to]] = true
+				b.queue = append(b.queue, b.mate[to])
+			}
+		}
+	}
+	return -1
+}
+
+// lowestCommonAncestor returns the base of the lowest common ancestor
+// of u and v in the alternating tree.
+func (b *blossom) lowestCommonAncestor(u, v int) int {
+	for i := range b.onPath {
+		b.onPath[i] = false
+	}
+	for {
+		u = b.base[u]
+		b.onPath[u] = true
+		if b.mate[u] == -1 {
+			break
+		}
+		u = b.parent[b.mate[u]]
+	}
+	for {
+		v = b.base[v]
+		if b.onPath[v] {
+			return v
+		}
+		v = b.parent[b.mate[v]]
+	}
+}
+
+// markPath marks the blossoms on the path from v to the blossom base,
+// setting the parents of the path's nodes so that the path can be
+// followed through the blossom in the reverse direction.
+func (b *blossom) markPath(v, base, child int) {
+	for b.base[v] != base {
+		b.inBlossom[b.base[v]] = true
+		b.inBlossom[b.base[b.mate[v]]] = true
+		b.parent[v] = child
+		child = b.mate[v]
+		v = b.parent[b.mate[v]]
+	}
+}
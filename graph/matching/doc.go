@@ -0,0 +1,10 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package matching provides graph matching and assignment functions.
+//
+// A matching in a graph is a set of edges without common nodes.
+// Functions in the package return matchings as the set of matched
+// edges taken from the input graph.
+package matching // import "gonum.org/v1/gonum/graph/matching"
@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Hungarian returns a minimum cost assignment of the rows of cost to its
+// columns using the Hungarian algorithm. The element at (i, j) of cost is
+// the cost of assigning row i to column j. The returned assign slice has
+// an element for each row holding the column assigned to that row, or -1
+// if the row is unassigned, and total is the sum of the costs of the
+// assignment. If cost has more rows than columns, only as many rows as
+// there are columns are assigned, otherwise every row is assigned.
+//
+// A maximum weight assignment can be found by negating the weights.
+// Hungarian will panic if cost has a NaN or infinite element.
+//
+// The time complexity of Hungarian is O(n^2.m) where n and m are the smaller
+// and larger dimensions of cost respectively.
+func Hungarian(cost mat.Matrix) (assign []int, total float64) {
+	r, c := cost.Dims()
+	at := cost.At
+	n, m := r, c
+	transposed := r > c
+	if transposed {
+		at = func(i, j int) float64 { return cost.At(j, i) }
+		n, m = c, r
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			v := at(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				panic("matching: non-finite cost")
+			}
+		}
+	}
+
+	// The implementation here uses the shortest augmenting path
+	// formulation of the algorithm with row and column potentials
+	// u and v. Rows and columns are indexed from 1 with the zero
+	// column being a sentinel holding the row being augmented.
+	var (
+		u   = make([]float64, n+1)
+		v   = make([]float64, m+1)
+		p   = make([]int, m+1)
+		way = make([]int, m+1)
+
+		minv = make([]float64, m+1)
+		used = make([]bool, m+1)
+	)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				cur := at(i0-1, j-1) - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assign = make([]int, r)
+	for i := range assign {
+		assign[i] = -1
+	}
+	for j := 1; j <= m; j++ {
+		if p[j] == 0 {
+			continue
+		}
+		row, col := p[j]-1, j-1
+		if transposed {
+			row, col = col, row
+		}
+		assign[row] = col
+		total += cost.At(row, col)
+	}
+	return assign, total
+}
@@ -0,0 +1,169 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var hungarianTests = []struct {
+	name string
+	cost *mat.Dense
+
+	want      []int
+	wantTotal float64
+}{
+	{
+		name: "square",
+		cost: mat.NewDense(3, 3, []float64{
+			4, 1, 3,
+			2, 0, 5,
+			3, 2, 2,
+		}),
+		want:      []int{1, 0, 2},
+		wantTotal: 5,
+	},
+	{
+		name: "negative",
+		cost: mat.NewDense(3, 3, []float64{
+			-4, -1, -3,
+			-2, 0, -5,
+			-3, -2, -2,
+		}),
+		want:      []int{0, 2, 1},
+		wantTotal: -11,
+	},
+	{
+		name: "wide",
+		cost: mat.NewDense(2, 4, []float64{
+			9, 2, 7, 1,
+			6, 4, 3, 8,
+		}),
+		want:      []int{3, 2},
+		wantTotal: 4,
+	},
+	{
+		name: "tall",
+		cost: mat.NewDense(4, 2, []float64{
+			9, 6,
+			2, 4,
+			7, 3,
+			1, 8,
+		}),
+		want:      []int{-1, -1, 1, 0},
+		wantTotal: 4,
+	},
+	{
+		name:      "single",
+		cost:      mat.NewDense(1, 1, []float64{7}),
+		want:      []int{0},
+		wantTotal: 7,
+	},
+}
+
+func TestHungarian(t *testing.T) {
+	for _, test := range hungarianTests {
+		got, total := Hungarian(test.cost)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected assignment for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		if total != test.wantTotal {
+			t.Errorf("unexpected total cost for %q: got:%v want:%v", test.name, total, test.wantTotal)
+		}
+	}
+}
+
+func TestHungarianRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		r := 1 + rnd.Intn(6)
+		c := 1 + rnd.Intn(6)
+		cost := mat.NewDense(r, c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				cost.Set(i, j, float64(rnd.Intn(40)-10))
+			}
+		}
+
+		got, total := Hungarian(cost)
+		n := r
+		if c < r {
+			n = c
+		}
+		used := make(map[int]bool)
+		var assigned int
+		var sum float64
+		for i, j := range got {
+			if j < 0 {
+				continue
+			}
+			if used[j] {
+				t.Errorf("column %d assigned more than once in test %d", j, i)
+			}
+			used[j] = true
+			assigned++
+			sum += cost.At(i, j)
+		}
+		if assigned != n {
+			t.Errorf("unexpected number of assignments in test %d: got:%d want:%d", i, assigned, n)
+		}
+		if sum != total {
+			t.Errorf("unexpected total cost in test %d: got:%v want:%v", i, total, sum)
+		}
+		if want := minAssignmentCost(cost); total != want {
+			t.Errorf("unexpected minimum cost in test %d: got:%v want:%v", i, total, want)
+		}
+	}
+}
+
+func TestHungarianNonFinite(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			Hungarian(mat.NewDense(2, 2, []float64{1, 2, v, 4}))
+			return false
+		}()
+		if !panicked {
+			t.Errorf("expected panic for cost element %v", v)
+		}
+	}
+}
+
+// minAssignmentCost returns the minimum cost of a complete
+// assignment of the smaller dimension of cost by exhaustive
+// search.
+func minAssignmentCost(cost mat.Matrix) float64 {
+	r, c := cost.Dims()
+	at := cost.At
+	if r > c {
+		r, c = c, r
+		at = func(i, j int) float64 { return cost.At(j, i) }
+	}
+	used := make([]bool, c)
+	var best func(i int) float64
+	best = func(i int) float64 {
+		if i == r {
+			return 0
+		}
+		min := math.Inf(1)
+		for j := 0; j < c; j++ {
+			if used[j] {
+				continue
+			}
+			used[j] = true
+			min = math.Min(min, at(i, j)+best(i+1))
+			used[j] = false
+		}
+		return min
+	}
+	return best(0)
+}
@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// indexed holds the nodes of a graph ordered by ID and
+// a mapping from node IDs to their index.
+type indexed struct {
+	nodes   []graph.Node
+	indexOf map[int64]int
+}
+
+// indexNodes returns the nodes of g sorted by ID and indexed.
+func indexNodes(g graph.Graph) indexed {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	return indexed{nodes: nodes, indexOf: indexOf}
+}
+
+// adjacency returns the adjacency lists of g indexed according to idx.
+// Self loops are not included.
+func adjacency(g graph.Graph, idx indexed) [][]int {
+	adj := make([][]int, len(idx.nodes))
+	for i, u := range idx.nodes {
+		to := g.From(u)
+		adj[i] = make([]int, 0, to.Len())
+		for to.Next() {
+			j := idx.indexOf[to.Node().ID()]
+			if j == i {
+				continue
+			}
+			adj[i] = append(adj[i], j)
+		}
+		sort.Ints(adj[i])
+	}
+	return adj
+}
+
+// matchedEdges returns the edges of g between the nodes paired in mate.
+// A negative value in mate indicates an unmatched node. The edges are
+// ordered by the lower index of their end points.
+func matchedEdges(g graph.Undirected, idx indexed, mate []int) []graph.Edge {
+	var edges []graph.Edge
+	for i, j := range mate {
+		if j <= i {
+			continue
+		}
+		edges = append(edges, g.EdgeBetween(idx.nodes[i], idx.nodes[j]))
+	}
+	return edges
+}
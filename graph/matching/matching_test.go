@@ -0,0 +1,353 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matching
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var matchingTests = []struct {
+	name  string
+	edges []simple.Edge
+	nodes int
+
+	bipartite bool
+	want      int
+}{
+	{
+		name:  "empty",
+		nodes: 3,
+
+		bipartite: true,
+		want:      0,
+	},
+	{
+		name: "path",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+		},
+
+		bipartite: true,
+		want:      2,
+	},
+	{
+		name: "star",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+		},
+
+		bipartite: true,
+		want:      1,
+	},
+	{
+		name: "augmenting",
+		// A greedy matching of 1-2 blocks both
+		// 0 and 3 unless the path is augmented.
+		edges: []simple.Edge{
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(1), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(5)},
+		},
+
+		bipartite: true,
+		want:      3,
+	},
+	{
+		name: "triangle",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+		},
+
+		bipartite: false,
+		want:      1,
+	},
+	{
+		name: "blossom",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(0), T: simple.Node(5)},
+		},
+
+		bipartite: false,
+		want:      3,
+	},
+	{
+		name: "nested blossom",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(0)},
+			{F: simple.Node(2), T: simple.Node(5)},
+			{F: simple.Node(5), T: simple.Node(6)},
+			{F: simple.Node(6), T: simple.Node(2)},
+			{F: simple.Node(6), T: simple.Node(7)},
+			{F: simple.Node(4), T: simple.Node(8)},
+		},
+
+		bipartite: false,
+		want:      4,
+	},
+	{
+		name: "petersen",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(0)},
+			{F: simple.Node(0), T: simple.Node(5)},
+			{F: simple.Node(1), T: simple.Node(6)},
+			{F: simple.Node(2), T: simple.Node(7)},
+			{F: simple.Node(3), T: simple.Node(8)},
+			{F: simple.Node(4), T: simple.Node(9)},
+			{F: simple.Node(5), T: simple.Node(7)},
+			{F: simple.Node(7), T: simple.Node(9)},
+			{F: simple.Node(9), T: simple.Node(6)},
+			{F: simple.Node(6), T: simple.Node(8)},
+			{F: simple.Node(8), T: simple.Node(5)},
+		},
+
+		bipartite: false,
+		want:      5,
+	},
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	for _, test := range matchingTests {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < test.nodes; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		got, ok := HopcroftKarp(g)
+		if ok != test.bipartite {
+			t.Errorf("unexpected bipartite result for %q: got:%t want:%t", test.name, ok, test.bipartite)
+			continue
+		}
+		if !ok {
+			if got != nil {
+				t.Errorf("unexpected matching for non-bipartite graph %q: %v", test.name, got)
+			}
+			continue
+		}
+		checkMatching(t, test.name, g, got)
+		if len(got) != test.want {
+			t.Errorf("unexpected matching size for %q: got:%d want:%d", test.name, len(got), test.want)
+		}
+	}
+}
+
+func TestEdmonds(t *testing.T) {
+	for _, test := range matchingTests {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < test.nodes; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		got := Edmonds(g)
+		checkMatching(t, test.name, g, got)
+		if len(got) != test.want {
+			t.Errorf("unexpected matching size for %q: got:%d want:%d", test.name, len(got), test.want)
+		}
+	}
+}
+
+func TestMatchingRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(9)
+		p := rnd.Float64()
+
+		g := simple.NewUndirectedGraph()
+		bg := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+			bg.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() >= p {
+					continue
+				}
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				if u%2 != v%2 {
+					bg.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		got := Edmonds(g)
+		checkMatching(t, "random", g, got)
+		if want := maxMatchingSize(g); len(got) != want {
+			t.Errorf("unexpected Edmonds matching size for random graph %d: got:%d want:%d", i, len(got), want)
+		}
+
+		bgot, ok := HopcroftKarp(bg)
+		if !ok {
+			t.Errorf("unexpected non-bipartite result for random bipartite graph %d", i)
+			continue
+		}
+		checkMatching(t, "random bipartite", bg, bgot)
+		if want := maxMatchingSize(bg); len(bgot) != want {
+			t.Errorf("unexpected Hopcroft-Karp matching size for random graph %d: got:%d want:%d", i, len(bgot), want)
+		}
+	}
+}
+
+func TestMaxWeightBipartite(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		// The heaviest edge, 1-2, is not in the
+		// maximum weight matching.
+		{F: simple.Node(0), T: simple.Node(1), W: 3},
+		{F: simple.Node(1), T: simple.Node(2), W: 4},
+		{F: simple.Node(2), T: simple.Node(3), W: 3},
+		{F: simple.Node(3), T: simple.Node(4), W: -1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	got, weight, ok := MaxWeightBipartite(g)
+	if !ok {
+		t.Fatal("unexpected non-bipartite result")
+	}
+	checkWeightedMatching(t, "path", g, got, weight)
+	if weight != 6 || len(got) != 2 {
+		t.Errorf("unexpected matching: got:%v weight:%v want weight:6", got, weight)
+	}
+
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 1})
+	_, _, ok = MaxWeightBipartite(g)
+	if ok {
+		t.Error("unexpected bipartite result for graph with odd cycle")
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(9)
+		p := rnd.Float64()
+
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if u%2 == v%2 || rnd.Float64() >= p {
+					continue
+				}
+				w := float64(rnd.Intn(20) - 5)
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+			}
+		}
+
+		got, weight, ok := MaxWeightBipartite(g)
+		if !ok {
+			t.Errorf("unexpected non-bipartite result for random bipartite graph %d", i)
+			continue
+		}
+		checkWeightedMatching(t, "random", g, got, weight)
+		if want := maxMatchingWeight(g); weight != want {
+			t.Errorf("unexpected matching weight for random graph %d: got:%v want:%v", i, weight, want)
+		}
+	}
+}
+
+// checkMatching checks that m is a valid matching in g.
+func checkMatching(t *testing.T, name string, g graph.Graph, m []graph.Edge) {
+	seen := make(map[int64]bool)
+	for _, e := range m {
+		u, v := e.From(), e.To()
+		if !g.HasEdgeBetween(u, v) {
+			t.Errorf("matched edge not in graph for %q: %d--%d", name, u.ID(), v.ID())
+		}
+		if seen[u.ID()] || seen[v.ID()] {
+			t.Errorf("node matched more than once for %q: %d--%d", name, u.ID(), v.ID())
+		}
+		seen[u.ID()] = true
+		seen[v.ID()] = true
+	}
+}
+
+// checkWeightedMatching checks that m is a valid matching in g
+// with the given weight.
+func checkWeightedMatching(t *testing.T, name string, g graph.WeightedUndirected, m []graph.WeightedEdge, weight float64) {
+	edges := make([]graph.Edge, len(m))
+	var sum float64
+	for i, e := range m {
+		edges[i] = e
+		sum += e.Weight()
+	}
+	checkMatching(t, name, g, edges)
+	if sum != weight {
+		t.Errorf("unexpected matching weight sum for %q: got:%v want:%v", name, weight, sum)
+	}
+}
+
+// maxMatchingSize returns the size of a maximum cardinality matching
+// of g by exhaustive search.
+func maxMatchingSize(g graph.Undirected) int {
+	return int(bestMatching(g, func(u, v graph.Node) float64 { return 1 }))
+}
+
+// maxMatchingWeight returns the weight of a maximum weight matching
+// of g by exhaustive search.
+func maxMatchingWeight(g graph.WeightedUndirected) float64 {
+	return bestMatching(g, func(u, v graph.Node) float64 {
+		return g.WeightedEdgeBetween(u, v).Weight()
+	})
+}
+
+func bestMatching(g graph.Graph, weight func(u, v graph.Node) float64) float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	matched := make(map[int64]bool)
+	var best func(i int) float64
+	best = func(i int) float64 {
+		for i < len(nodes) && matched[nodes[i].ID()] {
+			i++
+		}
+		if i == len(nodes) {
+			return 0
+		}
+		u := nodes[i]
+		matched[u.ID()] = true
+		max := best(i + 1)
+		for _, v := range graph.NodesOf(g.From(u)) {
+			if matched[v.ID()] {
+				continue
+			}
+			matched[v.ID()] = true
+			if w := weight(u, v) + best(i+1); w > max {
+				max = w
+			}
+			matched[v.ID()] = false
+		}
+		matched[u.ID()] = false
+		return max
+	}
+	return best(0)
+}
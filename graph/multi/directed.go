@@ -0,0 +1,247 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// DirectedGraph implements a generalized directed multigraph.
+type DirectedGraph struct {
+	nodes map[int64]graph.Node
+	from  map[int64]map[int64]map[int64]graph.Line
+	to    map[int64]map[int64]map[int64]graph.Line
+
+	nodeIDs uid.Set
+	lineIDs uid.Set
+}
+
+// NewDirectedGraph returns a DirectedGraph.
+func NewDirectedGraph() *DirectedGraph {
+	return &DirectedGraph{
+		nodes: make(map[int64]graph.Node),
+		from:  make(map[int64]map[int64]map[int64]graph.Line),
+		to:    make(map[int64]map[int64]map[int64]graph.Line),
+
+		nodeIDs: uid.NewSet(),
+		lineIDs: uid.NewSet(),
+	}
+}
+
+// NewNode returns a new unique Node to be added to g. The Node's ID does
+// not become valid in g until the Node is added to g.
+func (g *DirectedGraph) NewNode() graph.Node {
+	if len(g.nodes) == 0 {
+		return Node(0)
+	}
+	if int64(len(g.nodes)) == uid.Max {
+		panic("multi: cannot allocate node: no slot")
+	}
+	return Node(g.nodeIDs.NewID())
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *DirectedGraph) AddNode(n graph.Node) {
+	if _, exists := g.nodes[n.ID()]; exists {
+		panic(fmt.Sprintf("multi: node ID collision: %d", n.ID()))
+	}
+	g.nodes[n.ID()] = n
+	g.from[n.ID()] = make(map[int64]map[int64]graph.Line)
+	g.to[n.ID()] = make(map[int64]map[int64]graph.Line)
+	g.nodeIDs.Use(n.ID())
+}
+
+// RemoveNode removes n from the graph, as well as any lines attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *DirectedGraph) RemoveNode(n graph.Node) {
+	if _, ok := g.nodes[n.ID()]; !ok {
+		return
+	}
+	delete(g.nodes, n.ID())
+
+	for to, lines := range g.from[n.ID()] {
+		for id := range lines {
+			g.lineIDs.Release(id)
+		}
+		delete(g.to[to], n.ID())
+	}
+	delete(g.from, n.ID())
+
+	for from, lines := range g.to[n.ID()] {
+		for id := range lines {
+			g.lineIDs.Release(id)
+		}
+		delete(g.from[from], n.ID())
+	}
+	delete(g.to, n.ID())
+
+	g.nodeIDs.Release(n.ID())
+}
+
+// NewLine returns a new Line from the source to the destination node.
+// The returned Line will have a graph-unique ID.
+// The Line's ID does not become valid in g until the Line is added to g.
+func (g *DirectedGraph) NewLine(from, to graph.Node) graph.Line {
+	return &Line{F: from, T: to, UID: g.lineIDs.NewID()}
+}
+
+// SetLine adds l, a line from one node to another. If the nodes do not exist, they are added.
+// If a line with the same ID already exists between the nodes, it is replaced.
+func (g *DirectedGraph) SetLine(l graph.Line) {
+	var (
+		from = l.From()
+		fid  = from.ID()
+		to   = l.To()
+		tid  = to.ID()
+		lid  = l.ID()
+	)
+
+	if !g.Has(from) {
+		g.AddNode(from)
+	}
+	if g.from[fid][tid] == nil {
+		g.from[fid][tid] = make(map[int64]graph.Line)
+	}
+	if !g.Has(to) {
+		g.AddNode(to)
+	}
+	if g.to[tid][fid] == nil {
+		g.to[tid][fid] = make(map[int64]graph.Line)
+	}
+
+	g.from[fid][tid][lid] = l
+	g.to[tid][fid][lid] = l
+	g.lineIDs.Use(lid)
+}
+
+// RemoveLine removes l from the graph, leaving the terminal nodes. If the line does not exist
+// it is a no-op.
+func (g *DirectedGraph) RemoveLine(l graph.Line) {
+	fid, tid, lid := l.From().ID(), l.To().ID(), l.ID()
+	lines, ok := g.from[fid][tid]
+	if !ok {
+		return
+	}
+	if _, ok := lines[lid]; !ok {
+		return
+	}
+
+	delete(lines, lid)
+	if len(lines) == 0 {
+		delete(g.from[fid], tid)
+	}
+	lines = g.to[tid][fid]
+	delete(lines, lid)
+	if len(lines) == 0 {
+		delete(g.to[tid], fid)
+	}
+	g.lineIDs.Release(lid)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *DirectedGraph) Node(id int64) graph.Node {
+	return g.nodes[id]
+}
+
+// Has returns whether the node exists within the graph.
+func (g *DirectedGraph) Has(n graph.Node) bool {
+	_, ok := g.nodes[n.ID()]
+
+	return ok
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *DirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewNodes(g.nodes)
+}
+
+// Edges returns all the edges in the graph. Each edge returned by the
+// iterator is a multi.Edge.
+func (g *DirectedGraph) Edges() graph.Edges {
+	var edges []graph.Edge
+	for _, u := range g.nodes {
+		for vid, lines := range g.from[u.ID()] {
+			edges = append(edges, Edge{F: u, T: g.nodes[vid], Lines: linesOf(lines)})
+		}
+	}
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *DirectedGraph) From(n graph.Node) graph.Nodes {
+	if len(g.from[n.ID()]) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewNodesByLines(g.nodes, g.from[n.ID()])
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *DirectedGraph) To(n graph.Node) graph.Nodes {
+	if len(g.to[n.ID()]) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewNodesByLines(g.nodes, g.to[n.ID()])
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *DirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	xid := x.ID()
+	yid := y.ID()
+	if _, ok := g.from[xid][yid]; ok {
+		return true
+	}
+	_, ok := g.from[yid][xid]
+	return ok
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+// The returned graph.Edge is a multi.Edge holding all the lines from u to v.
+func (g *DirectedGraph) Edge(u, v graph.Node) graph.Edge {
+	lines := g.Lines(u, v)
+	if lines == nil {
+		return nil
+	}
+	return Edge{F: g.nodes[u.ID()], T: g.nodes[v.ID()], Lines: lines}
+}
+
+// Lines returns the lines from u to v if such lines exist and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *DirectedGraph) Lines(u, v graph.Node) []graph.Line {
+	return linesOf(g.from[u.ID()][v.ID()])
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *DirectedGraph) HasEdgeFromTo(u, v graph.Node) bool {
+	_, ok := g.from[u.ID()][v.ID()]
+	return ok
+}
+
+// Degree returns the in+out degree of n in g, counting each line.
+func (g *DirectedGraph) Degree(n graph.Node) int {
+	if _, ok := g.nodes[n.ID()]; !ok {
+		return 0
+	}
+
+	var deg int
+	for _, lines := range g.from[n.ID()] {
+		deg += len(lines)
+	}
+	for _, lines := range g.to[n.ID()] {
+		deg += len(lines)
+	}
+	return deg
+}
@@ -0,0 +1,157 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+var (
+	directedGraph = (*DirectedGraph)(nil)
+
+	_ graph.Graph                     = directedGraph
+	_ graph.Directed                  = directedGraph
+	_ graph.Multigraph                = directedGraph
+	_ graph.DirectedMultigraph        = directedGraph
+	_ graph.DirectedMultigraphBuilder = directedGraph
+	_ graph.NodeRemover               = directedGraph
+	_ graph.LineRemover               = directedGraph
+)
+
+func TestDirectedParallelLines(t *testing.T) {
+	g := NewDirectedGraph()
+	var lines []graph.Line
+	for _, e := range []struct{ from, to int64 }{
+		{0, 1}, {0, 1}, {0, 1}, {1, 0}, {1, 2}, {2, 2}, {2, 2},
+	} {
+		l := g.NewLine(Node(e.from), Node(e.to))
+		g.SetLine(l)
+		lines = append(lines, l)
+	}
+
+	if got := lineIDs(g.Lines(Node(0), Node(1))); !equalIDs(got, []int64{0, 1, 2}) {
+		t.Errorf("unexpected lines from 0 to 1: got:%v want:[0 1 2]", got)
+	}
+	if got := lineIDs(g.Lines(Node(1), Node(0))); !equalIDs(got, []int64{3}) {
+		t.Errorf("unexpected lines from 1 to 0: got:%v want:[3]", got)
+	}
+	if got := g.Lines(Node(2), Node(1)); got != nil {
+		t.Errorf("unexpected lines from 2 to 1: %v", got)
+	}
+	e, ok := g.Edge(Node(0), Node(1)).(Edge)
+	if !ok || len(e.Lines) != 3 {
+		t.Errorf("unexpected edge from 0 to 1: %v", g.Edge(Node(0), Node(1)))
+	}
+	if g.Edge(Node(2), Node(1)) != nil {
+		t.Error("unexpected edge from 2 to 1")
+	}
+	if got := nodeIDs(g.From(Node(2))); !equalIDs(got, []int64{2}) {
+		t.Errorf("unexpected nodes from 2: got:%v want:[2]", got)
+	}
+	if got := nodeIDs(g.To(Node(2))); !equalIDs(got, []int64{1, 2}) {
+		t.Errorf("unexpected nodes to 2: got:%v want:[1 2]", got)
+	}
+	if !g.HasEdgeBetween(Node(2), Node(1)) || g.HasEdgeFromTo(Node(2), Node(1)) {
+		t.Error("unexpected edge existence between 1 and 2")
+	}
+	for _, test := range []struct {
+		id   int64
+		want int
+	}{
+		{id: 0, want: 4},
+		{id: 1, want: 5},
+		{id: 2, want: 5},
+		{id: 3, want: 0},
+	} {
+		if got := g.Degree(Node(test.id)); got != test.want {
+			t.Errorf("unexpected degree for node %d: got:%d want:%d", test.id, got, test.want)
+		}
+	}
+	if n := g.Edges().Len(); n != 4 {
+		t.Errorf("unexpected number of edges: got:%d want:4", n)
+	}
+
+	g.RemoveLine(lines[1])
+	if got := lineIDs(g.Lines(Node(0), Node(1))); !equalIDs(got, []int64{0, 2}) {
+		t.Errorf("unexpected lines from 0 to 1 after removal: got:%v want:[0 2]", got)
+	}
+	if id := g.NewLine(Node(0), Node(1)).ID(); id != 1 {
+		t.Errorf("unexpected reuse of line ID: got:%d want:1", id)
+	}
+	g.RemoveLine(lines[4])
+	if g.HasEdgeFromTo(Node(1), Node(2)) || g.HasEdgeBetween(Node(1), Node(2)) {
+		t.Error("unexpected edge from 1 to 2 after removal")
+	}
+	if got := nodeIDs(g.To(Node(2))); !equalIDs(got, []int64{2}) {
+		t.Errorf("unexpected nodes to 2 after removal: got:%v want:[2]", got)
+	}
+
+	g.RemoveNode(Node(2))
+	if g.Has(Node(2)) || g.Nodes().Len() != 2 {
+		t.Error("unexpected nodes after node removal")
+	}
+	g.RemoveNode(Node(0))
+	if got := g.To(Node(1)); got.Len() != 0 {
+		t.Errorf("unexpected nodes to 1 after node removal: %v", got)
+	}
+	if got := g.Degree(Node(1)); got != 0 {
+		t.Errorf("unexpected degree for node 1 after node removal: got:%d want:0", got)
+	}
+}
+
+// Test for issue #123 https://github.com/gonum/graph/issues/123
+func TestIssue123DirectedGraph(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("unexpected panic: %v", r)
+		}
+	}()
+	g := NewDirectedGraph()
+
+	n0 := g.NewNode()
+	g.AddNode(n0)
+
+	n1 := g.NewNode()
+	g.AddNode(n1)
+
+	g.RemoveNode(n0)
+
+	n2 := g.NewNode()
+	g.AddNode(n2)
+}
+
+func lineIDs(lines []graph.Line) []int64 {
+	ids := make([]int64, len(lines))
+	for i, l := range lines {
+		ids[i] = l.ID()
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
+
+func nodeIDs(nodes graph.Nodes) []int64 {
+	ids := make([]int64, 0, nodes.Len())
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package multi provides a suite of multigraph implementations satisfying
+// the gonum/graph interfaces.
+//
+// The multigraphs in this package also satisfy the graph.Graph interfaces,
+// presenting all the lines between a pair of nodes as a single Edge, so
+// they can be used with algorithms that do not distinguish parallel edges.
+package multi // import "gonum.org/v1/gonum/graph/multi"
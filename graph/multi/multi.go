@@ -0,0 +1,58 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import "gonum.org/v1/gonum/graph"
+
+// Node is a multigraph node.
+type Node int64
+
+// ID returns the ID number of the node.
+func (n Node) ID() int64 {
+	return int64(n)
+}
+
+// Line is a multigraph edge.
+type Line struct {
+	F, T graph.Node
+
+	UID int64
+}
+
+// From returns the from-node of the line.
+func (l Line) From() graph.Node { return l.F }
+
+// To returns the to-node of the line.
+func (l Line) To() graph.Node { return l.T }
+
+// ID returns the ID of the line.
+func (l Line) ID() int64 { return l.UID }
+
+// Edge is a collection of multigraph lines sharing end points.
+type Edge struct {
+	F, T graph.Node
+
+	// Lines are the lines
+	// making up the edge.
+	Lines []graph.Line
+}
+
+// From returns the from-node of the edge.
+func (e Edge) From() graph.Node { return e.F }
+
+// To returns the to-node of the edge.
+func (e Edge) To() graph.Node { return e.T }
+
+// linesOf returns the lines held in m.
+func linesOf(m map[int64]graph.Line) []graph.Line {
+	if len(m) == 0 {
+		return nil
+	}
+	lines := make([]graph.Line, 0, len(m))
+	for _, l := range m {
+		lines = append(lines, l)
+	}
+	return lines
+}
@@ -0,0 +1,232 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// UndirectedGraph implements a generalized undirected multigraph.
+type UndirectedGraph struct {
+	nodes map[int64]graph.Node
+
+	// lines holds the lines between nodes. The
+	// lines between x and y are held in a single
+	// map shared by lines[x][y] and lines[y][x].
+	lines map[int64]map[int64]map[int64]graph.Line
+
+	nodeIDs uid.Set
+	lineIDs uid.Set
+}
+
+// NewUndirectedGraph returns an UndirectedGraph.
+func NewUndirectedGraph() *UndirectedGraph {
+	return &UndirectedGraph{
+		nodes: make(map[int64]graph.Node),
+		lines: make(map[int64]map[int64]map[int64]graph.Line),
+
+		nodeIDs: uid.NewSet(),
+		lineIDs: uid.NewSet(),
+	}
+}
+
+// NewNode returns a new unique Node to be added to g. The Node's ID does
+// not become valid in g until the Node is added to g.
+func (g *UndirectedGraph) NewNode() graph.Node {
+	if len(g.nodes) == 0 {
+		return Node(0)
+	}
+	if int64(len(g.nodes)) == uid.Max {
+		panic("multi: cannot allocate node: no slot")
+	}
+	return Node(g.nodeIDs.NewID())
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *UndirectedGraph) AddNode(n graph.Node) {
+	if _, exists := g.nodes[n.ID()]; exists {
+		panic(fmt.Sprintf("multi: node ID collision: %d", n.ID()))
+	}
+	g.nodes[n.ID()] = n
+	g.lines[n.ID()] = make(map[int64]map[int64]graph.Line)
+	g.nodeIDs.Use(n.ID())
+}
+
+// RemoveNode removes n from the graph, as well as any lines attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *UndirectedGraph) RemoveNode(n graph.Node) {
+	if _, ok := g.nodes[n.ID()]; !ok {
+		return
+	}
+	delete(g.nodes, n.ID())
+
+	for to, lines := range g.lines[n.ID()] {
+		for id := range lines {
+			g.lineIDs.Release(id)
+		}
+		delete(g.lines[to], n.ID())
+	}
+	delete(g.lines, n.ID())
+
+	g.nodeIDs.Release(n.ID())
+}
+
+// NewLine returns a new Line from the source to the destination node.
+// The returned Line will have a graph-unique ID.
+// The Line's ID does not become valid in g until the Line is added to g.
+func (g *UndirectedGraph) NewLine(from, to graph.Node) graph.Line {
+	return &Line{F: from, T: to, UID: g.lineIDs.NewID()}
+}
+
+// SetLine adds l, a line from one node to another. If the nodes do not exist, they are added.
+// If a line with the same ID already exists between the nodes, it is replaced.
+func (g *UndirectedGraph) SetLine(l graph.Line) {
+	var (
+		from = l.From()
+		fid  = from.ID()
+		to   = l.To()
+		tid  = to.ID()
+		lid  = l.ID()
+	)
+
+	if !g.Has(from) {
+		g.AddNode(from)
+	}
+	if !g.Has(to) {
+		g.AddNode(to)
+	}
+	lines := g.lines[fid][tid]
+	if lines == nil {
+		lines = make(map[int64]graph.Line)
+		g.lines[fid][tid] = lines
+		g.lines[tid][fid] = lines
+	}
+
+	lines[lid] = l
+	g.lineIDs.Use(lid)
+}
+
+// RemoveLine removes l from the graph, leaving the terminal nodes. If the line does not exist
+// it is a no-op.
+func (g *UndirectedGraph) RemoveLine(l graph.Line) {
+	fid, tid, lid := l.From().ID(), l.To().ID(), l.ID()
+	lines, ok := g.lines[fid][tid]
+	if !ok {
+		return
+	}
+	if _, ok := lines[lid]; !ok {
+		return
+	}
+
+	delete(lines, lid)
+	if len(lines) == 0 {
+		delete(g.lines[fid], tid)
+		delete(g.lines[tid], fid)
+	}
+	g.lineIDs.Release(lid)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *UndirectedGraph) Node(id int64) graph.Node {
+	return g.nodes[id]
+}
+
+// Has returns whether the node exists within the graph.
+func (g *UndirectedGraph) Has(n graph.Node) bool {
+	_, ok := g.nodes[n.ID()]
+	return ok
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *UndirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewNodes(g.nodes)
+}
+
+// Edges returns all the edges in the graph. Each edge returned by the
+// iterator is a multi.Edge.
+func (g *UndirectedGraph) Edges() graph.Edges {
+	var edges []graph.Edge
+	seen := make(map[[2]int64]struct{})
+	for xid, u := range g.lines {
+		for yid, lines := range u {
+			if _, ok := seen[[2]int64{yid, xid}]; ok {
+				continue
+			}
+			seen[[2]int64{xid, yid}] = struct{}{}
+			edges = append(edges, Edge{F: g.nodes[xid], T: g.nodes[yid], Lines: linesOf(lines)})
+		}
+	}
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *UndirectedGraph) From(n graph.Node) graph.Nodes {
+	if len(g.lines[n.ID()]) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewNodesByLines(g.nodes, g.lines[n.ID()])
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *UndirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	_, ok := g.lines[x.ID()][y.ID()]
+	return ok
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+// The returned graph.Edge is a multi.Edge holding all the lines between u and v.
+func (g *UndirectedGraph) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y. The returned graph.Edge
+// is a multi.Edge holding all the lines between x and y.
+func (g *UndirectedGraph) EdgeBetween(x, y graph.Node) graph.Edge {
+	lines := g.LinesBetween(x, y)
+	if lines == nil {
+		return nil
+	}
+	return Edge{F: g.nodes[x.ID()], T: g.nodes[y.ID()], Lines: lines}
+}
+
+// Lines returns the lines from u to v if such lines exist and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *UndirectedGraph) Lines(u, v graph.Node) []graph.Line {
+	return g.LinesBetween(u, v)
+}
+
+// LinesBetween returns the lines between nodes x and y. The lines are
+// returned with the orientation with which they were added to g.
+func (g *UndirectedGraph) LinesBetween(x, y graph.Node) []graph.Line {
+	return linesOf(g.lines[x.ID()][y.ID()])
+}
+
+// Degree returns the degree of n in g, counting each line. A line
+// from n to itself contributes two to the degree.
+func (g *UndirectedGraph) Degree(n graph.Node) int {
+	if _, ok := g.nodes[n.ID()]; !ok {
+		return 0
+	}
+
+	var deg int
+	for id, lines := range g.lines[n.ID()] {
+		deg += len(lines)
+		if id == n.ID() {
+			deg += len(lines)
+		}
+	}
+	return deg
+}
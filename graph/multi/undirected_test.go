@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+var (
+	undirectedGraph = (*UndirectedGraph)(nil)
+
+	_ graph.Graph                       = undirectedGraph
+	_ graph.Undirected                  = undirectedGraph
+	_ graph.Multigraph                  = undirectedGraph
+	_ graph.UndirectedMultigraph        = undirectedGraph
+	_ graph.UndirectedMultigraphBuilder = undirectedGraph
+	_ graph.NodeRemover                 = undirectedGraph
+	_ graph.LineRemover                 = undirectedGraph
+)
+
+func TestAssertMultigraphNotDirected(t *testing.T) {
+	var g graph.UndirectedMultigraphBuilder = NewUndirectedGraph()
+	if _, ok := g.(graph.DirectedMultigraph); ok {
+		t.Fatal("Graph is directed, but an undirected multigraph cannot be directed!")
+	}
+}
+
+func TestUndirectedParallelLines(t *testing.T) {
+	g := NewUndirectedGraph()
+	var lines []graph.Line
+	for _, e := range []struct{ from, to int64 }{
+		{0, 1}, {1, 0}, {0, 1}, {1, 2}, {2, 2}, {2, 2},
+	} {
+		l := g.NewLine(Node(e.from), Node(e.to))
+		g.SetLine(l)
+		lines = append(lines, l)
+	}
+
+	for _, pair := range [][2]int64{{0, 1}, {1, 0}} {
+		if got := lineIDs(g.LinesBetween(Node(pair[0]), Node(pair[1]))); !equalIDs(got, []int64{0, 1, 2}) {
+			t.Errorf("unexpected lines between %d and %d: got:%v want:[0 1 2]", pair[0], pair[1], got)
+		}
+	}
+	if got := lineIDs(g.Lines(Node(2), Node(2))); !equalIDs(got, []int64{4, 5}) {
+		t.Errorf("unexpected lines between 2 and 2: got:%v want:[4 5]", got)
+	}
+	if g.EdgeBetween(Node(0), Node(2)) != nil {
+		t.Error("unexpected edge between 0 and 2")
+	}
+	e, ok := g.Edge(Node(1), Node(0)).(Edge)
+	if !ok || len(e.Lines) != 3 || e.From().ID() != 1 || e.To().ID() != 0 {
+		t.Errorf("unexpected edge between 1 and 0: %v", g.Edge(Node(1), Node(0)))
+	}
+	if got := nodeIDs(g.From(Node(1))); !equalIDs(got, []int64{0, 2}) {
+		t.Errorf("unexpected nodes from 1: got:%v want:[0 2]", got)
+	}
+	for _, test := range []struct {
+		id   int64
+		want int
+	}{
+		{id: 0, want: 3},
+		{id: 1, want: 4},
+		{id: 2, want: 5},
+	} {
+		if got := g.Degree(Node(test.id)); got != test.want {
+			t.Errorf("unexpected degree for node %d: got:%d want:%d", test.id, got, test.want)
+		}
+	}
+	if n := g.Edges().Len(); n != 3 {
+		t.Errorf("unexpected number of edges: got:%d want:3", n)
+	}
+
+	g.RemoveLine(lines[0])
+	g.RemoveLine(lines[1])
+	if got := lineIDs(g.LinesBetween(Node(1), Node(0))); !equalIDs(got, []int64{2}) {
+		t.Errorf("unexpected lines between 1 and 0 after removal: got:%v want:[2]", got)
+	}
+	g.RemoveLine(Line{F: Node(1), T: Node(0), UID: 2})
+	if g.HasEdgeBetween(Node(0), Node(1)) || g.HasEdgeBetween(Node(1), Node(0)) {
+		t.Error("unexpected edge between 0 and 1 after removal")
+	}
+
+	g.RemoveNode(Node(2))
+	if got := g.From(Node(1)); got.Len() != 0 {
+		t.Errorf("unexpected nodes from 1 after node removal: %v", got)
+	}
+	if n := g.Edges().Len(); n != 0 {
+		t.Errorf("unexpected number of edges after node removal: got:%d want:0", n)
+	}
+}
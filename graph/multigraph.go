@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+// Line is an edge in a multigraph. A Line returns an ID that must
+// distinguish Lines sharing Node end points.
+type Line interface {
+	From() Node
+	To() Node
+	ID() int64
+}
+
+// Multigraph is a generalized multigraph.
+type Multigraph interface {
+	// Has returns whether the node exists within the multigraph.
+	Has(Node) bool
+
+	// Nodes returns all the nodes in the multigraph.
+	Nodes() Nodes
+
+	// From returns all nodes that can be reached directly
+	// from the given node.
+	From(Node) Nodes
+
+	// HasEdgeBetween returns whether an edge exists between
+	// nodes x and y without considering direction.
+	HasEdgeBetween(x, y Node) bool
+
+	// Lines returns the lines from u to v if any such lines
+	// exist and nil otherwise. The node v must be directly
+	// reachable from u as defined by the From method.
+	Lines(u, v Node) []Line
+}
+
+// UndirectedMultigraph is an undirected multigraph.
+type UndirectedMultigraph interface {
+	Multigraph
+
+	// LinesBetween returns the lines between nodes x and y.
+	LinesBetween(x, y Node) []Line
+}
+
+// DirectedMultigraph is a directed multigraph.
+type DirectedMultigraph interface {
+	Multigraph
+
+	// HasEdgeFromTo returns whether an edge exists
+	// in the multigraph from u to v.
+	HasEdgeFromTo(u, v Node) bool
+
+	// To returns all nodes that can reach directly
+	// to the given node.
+	To(Node) Nodes
+}
+
+// LineAdder is an interface for adding lines to a multigraph.
+type LineAdder interface {
+	// NewLine returns a new Line from the source to the destination node.
+	NewLine(from, to Node) Line
+
+	// SetLine adds a Line from one node to another.
+	// If the multigraph supports node addition the nodes
+	// will be added if they do not exist, otherwise
+	// SetLine will panic.
+	// Whether l, l.From() and l.To() are stored
+	// within the graph is implementation dependent.
+	SetLine(l Line)
+}
+
+// LineRemover is an interface for removing lines from a multigraph.
+type LineRemover interface {
+	// RemoveLine removes the given line, leaving the
+	// terminal nodes. If the line does not exist it
+	// is a no-op.
+	RemoveLine(Line)
+}
+
+// MultigraphBuilder is a multigraph that can have nodes and lines added.
+type MultigraphBuilder interface {
+	NodeAdder
+	LineAdder
+}
+
+// UndirectedMultigraphBuilder is an undirected multigraph builder.
+type UndirectedMultigraphBuilder interface {
+	UndirectedMultigraph
+	MultigraphBuilder
+}
+
+// DirectedMultigraphBuilder is a directed multigraph builder.
+type DirectedMultigraphBuilder interface {
+	DirectedMultigraph
+	MultigraphBuilder
+}
@@ -90,7 +90,7 @@ func EdgeBetweenness(g graph.Graph) map[[2]int64]float64 {
 // the accumulation loop provided by the accumulate closure.
 func brandes(g graph.Graph, accumulate func(s graph.Node, stack linear.NodeStack, p map[int64][]graph.Node, delta, sigma map[int64]float64)) {
 	var (
-		nodes = g.Nodes()
+		nodes = graph.NodesOf(g.Nodes())
 		stack linear.NodeStack
 		p     = make(map[int64][]graph.Node, len(nodes))
 		sigma = make(map[int64]float64, len(nodes))
@@ -116,7 +116,8 @@ func brandes(g graph.Graph, accumulate func(s graph.Node, stack linear.NodeStack
 		for queue.Len() != 0 {
 			v := queue.Dequeue()
 			stack.Push(v)
-			for _, w := range g.From(v) {
+			for to := g.From(v); to.Next(); {
+				w := to.Node()
 				// w found for the first time?
 				if d[w.ID()] < 0 {
 					queue.Enqueue(w)
@@ -149,7 +150,7 @@ func brandes(g graph.Graph, accumulate func(s graph.Node, stack linear.NodeStack
 func BetweennessWeighted(g graph.Weighted, p path.AllShortest) map[int64]float64 {
 	cb := make(map[int64]float64)
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	for i, s := range nodes {
 		for j, t := range nodes {
 			if i == j {
@@ -201,7 +202,7 @@ func EdgeBetweennessWeighted(g graph.Weighted, p path.AllShortest) map[[2]int64]
 	cb := make(map[[2]int64]float64)
 
 	_, isUndirected := g.(graph.Undirected)
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	for i, s := range nodes {
 		for j, t := range nodes {
 			if i == j {
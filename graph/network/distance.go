@@ -19,7 +19,7 @@ import (
 // For directed graphs the incoming paths are used. Infinite distances are
 // not considered.
 func Closeness(g graph.Graph, p path.AllShortest) map[int64]float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	c := make(map[int64]float64, len(nodes))
 	for _, u := range nodes {
 		var sum float64
@@ -46,7 +46,7 @@ func Closeness(g graph.Graph, p path.AllShortest) map[int64]float64 {
 // For directed graphs the incoming paths are used. Infinite distances are
 // not considered.
 func Farness(g graph.Graph, p path.AllShortest) map[int64]float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	f := make(map[int64]float64, len(nodes))
 	for _, u := range nodes {
 		var sum float64
@@ -73,7 +73,7 @@ func Farness(g graph.Graph, p path.AllShortest) map[int64]float64 {
 // For directed graphs the incoming paths are used. Infinite distances are
 // not considered.
 func Harmonic(g graph.Graph, p path.AllShortest) map[int64]float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	h := make(map[int64]float64, len(nodes))
 	for i, u := range nodes {
 		var sum float64
@@ -102,7 +102,7 @@ func Harmonic(g graph.Graph, p path.AllShortest) map[int64]float64 {
 // For directed graphs the incoming paths are used. Infinite distances are
 // not considered.
 func Residual(g graph.Graph, p path.AllShortest) map[int64]float64 {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	r := make(map[int64]float64, len(nodes))
 	for i, u := range nodes {
 		var sum float64
@@ -22,7 +22,7 @@ type HubAuthority struct {
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
 func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 
 	// Make a topological copy of g with dense node IDs.
 	indexOf := make(map[int64]int, len(nodes))
@@ -32,10 +32,12 @@ func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
 	nodesLinkingTo := make([][]int, len(nodes))
 	nodesLinkedFrom := make([][]int, len(nodes))
 	for i, n := range nodes {
-		for _, u := range g.To(n) {
+		for from := g.To(n); from.Next(); {
+			u := from.Node()
 			nodesLinkingTo[i] = append(nodesLinkingTo[i], indexOf[u.ID()])
 		}
-		for _, v := range g.From(n) {
+		for to := g.From(n); to.Next(); {
+			v := to.Node()
 			nodesLinkedFrom[i] = append(nodesLinkedFrom[i], indexOf[v.ID()])
 		}
 	}
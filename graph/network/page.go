@@ -25,7 +25,7 @@ func PageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	//
 	// http://www.ams.org/samplings/feature-column/fcarc-pagerank
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	indexOf := make(map[int64]int, len(nodes))
 	for i, n := range nodes {
 		indexOf[n.ID()] = i
@@ -35,14 +35,15 @@ func PageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	dangling := damp / float64(len(nodes))
 	for j, u := range nodes {
 		to := g.From(u)
-		f := damp / float64(len(to))
-		for _, v := range to {
-			m.Set(indexOf[v.ID()], j, f)
-		}
-		if len(to) == 0 {
+		if to.Len() == 0 {
 			for i := range nodes {
 				m.Set(i, j, dangling)
 			}
+			continue
+		}
+		f := damp / float64(to.Len())
+		for to.Next() {
+			m.Set(indexOf[to.Node().ID()], j, f)
 		}
 	}
 	matrix := m.RawMatrix().Data
@@ -98,7 +99,7 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	//
 	// http://www.ams.org/samplings/feature-column/fcarc-pagerank
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	indexOf := make(map[int64]int, len(nodes))
 	for i, n := range nodes {
 		indexOf[n.ID()] = i
@@ -109,12 +110,13 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	df := damp / float64(len(nodes))
 	for j, u := range nodes {
 		to := g.From(u)
-		f := damp / float64(len(to))
-		for _, v := range to {
-			m.addTo(indexOf[v.ID()], j, f)
-		}
-		if len(to) == 0 {
+		if to.Len() == 0 {
 			dangling.addTo(j, df)
+			continue
+		}
+		f := damp / float64(to.Len())
+		for to.Next() {
+			m.addTo(indexOf[to.Node().ID()], j, f)
 		}
 	}
 
@@ -41,7 +41,7 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 		}
 	}
 
-	path = newShortestFrom(s, g.Nodes())
+	path = newShortestFrom(s, graph.NodesOf(g.Nodes()))
 	tid := t.ID()
 
 	visited := make(set.Int64s)
@@ -59,7 +59,8 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 		}
 
 		visited.Add(uid)
-		for _, v := range g.From(u.node) {
+		for to := g.From(u.node); to.Next(); {
+			v := to.Node()
 			vid := v.ID()
 			if visited.Has(vid) {
 				continue
@@ -22,7 +22,7 @@ func BellmanFordFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
 		weight = UniformCost(g)
 	}
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 
 	path = newShortestFrom(u, nodes)
 	path.dist[path.indexOf[u.ID()]] = 0
@@ -32,7 +32,8 @@ func BellmanFordFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
 	for i := 1; i < len(nodes); i++ {
 		changed := false
 		for j, u := range nodes {
-			for _, v := range g.From(u) {
+			for to := g.From(u); to.Next(); {
+				v := to.Node()
 				k := path.indexOf[v.ID()]
 				w, ok := weight(u, v)
 				if !ok {
@@ -51,7 +52,8 @@ func BellmanFordFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
 	}
 
 	for j, u := range nodes {
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			k := path.indexOf[v.ID()]
 			w, ok := weight(u, v)
 			if !ok {
@@ -139,3 +139,47 @@ func BenchmarkAStarUndirectedmallWorld_100_5_20_2_Heur(b *testing.B) {
 	}
 	benchmarkAStarHeuristic(b, nswUndirected_100_5_20_2, h)
 }
+
+var (
+	gnpDirected_10_tenth   = gnpDirected(10, 0.1)
+	gnpDirected_100_tenth  = gnpDirected(100, 0.1)
+	gnpDirected_1000_tenth = gnpDirected(1000, 0.1)
+	gnpDirected_10_half    = gnpDirected(10, 0.5)
+	gnpDirected_100_half   = gnpDirected(100, 0.5)
+	gnpDirected_1000_half  = gnpDirected(1000, 0.5)
+)
+
+func gnpDirected(n int, p float64) graph.Directed {
+	g := simple.NewDirectedGraph()
+	gen.Gnp(g, n, p, nil)
+	return g
+}
+
+func benchmarkDijkstraFrom(b *testing.B, g graph.Graph) {
+	var pt Shortest
+	for i := 0; i < b.N; i++ {
+		pt = DijkstraFrom(simple.Node(0), g)
+	}
+	if pt.From() == nil {
+		b.Fatal("unexpected nil path tree root")
+	}
+}
+
+func BenchmarkDijkstraFromDirectedGnp_10_tenth(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_10_tenth)
+}
+func BenchmarkDijkstraFromDirectedGnp_100_tenth(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_100_tenth)
+}
+func BenchmarkDijkstraFromDirectedGnp_1000_tenth(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_1000_tenth)
+}
+func BenchmarkDijkstraFromDirectedGnp_10_half(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_10_half)
+}
+func BenchmarkDijkstraFromDirectedGnp_100_half(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_100_half)
+}
+func BenchmarkDijkstraFromDirectedGnp_1000_half(b *testing.B) {
+	benchmarkDijkstraFrom(b, gnpDirected_1000_half)
+}
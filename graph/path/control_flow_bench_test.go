@@ -20,6 +20,7 @@ import (
 	"gonum.org/v1/gonum/graph/encoding"
 	"gonum.org/v1/gonum/graph/encoding/dot"
 	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
 )
@@ -241,7 +242,7 @@ func duplication(n int, delta, alpha, sigma float64) func() *simple.DirectedGrap
 		if err != nil {
 			panic(err)
 		}
-		for _, e := range g.Edges() {
+		for _, e := range graph.EdgesOf(g.Edges()) {
 			if rnd.Intn(2) == 0 {
 				g.RemoveEdge(e)
 			}
@@ -254,8 +255,10 @@ type undirected struct {
 	*simple.DirectedGraph
 }
 
-func (g undirected) From(n graph.Node) []graph.Node {
-	return append(g.DirectedGraph.From(n), g.DirectedGraph.To(n)...)
+func (g undirected) From(n graph.Node) graph.Nodes {
+	return iterator.NewOrderedNodes(append(
+		graph.NodesOf(g.DirectedGraph.From(n)),
+		graph.NodesOf(g.DirectedGraph.To(n))...))
 }
 
 func (g undirected) HasEdgeBetween(x, y graph.Node) bool {
@@ -140,7 +140,8 @@ func (lt *lengauerTarjan) dfs(g graph.Directed, v graph.Node) {
 	ltv.label = ltv
 	lt.nodes = append(lt.nodes, ltv)
 
-	for _, w := range g.From(v) {
+	for to := g.From(v); to.Next(); {
+		w := to.Node()
 		wid := w.ID()
 
 		idx, ok := lt.indexOf[wid]
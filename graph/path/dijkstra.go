@@ -26,7 +26,7 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 		weight = UniformCost(g)
 	}
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	path := newShortestFrom(u, nodes)
 
 	// Dijkstra's algorithm here is implemented essentially as
@@ -46,7 +46,8 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 		if mid.dist > path.dist[k] {
 			continue
 		}
-		for _, v := range g.From(mid.node) {
+		for to := g.From(mid.node); to.Next(); {
+			v := to.Node()
 			j := path.indexOf[v.ID()]
 			w, ok := weight(mid.node, v)
 			if !ok {
@@ -72,7 +73,7 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 //
 // The time complexity of DijkstrAllPaths is O(|V|.|E|+|V|^2.log|V|).
 func DijkstraAllPaths(g graph.Graph) (paths AllShortest) {
-	paths = newAllShortest(g.Nodes(), false)
+	paths = newAllShortest(graph.NodesOf(g.Nodes()), false)
 	dijkstraAllPaths(g, paths)
 	return paths
 }
@@ -106,7 +107,8 @@ func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 			if mid.dist < paths.dist.At(i, k) {
 				paths.dist.Set(i, k, mid.dist)
 			}
-			for _, v := range g.From(mid.node) {
+			for to := g.From(mid.node); to.Next(); {
+				v := to.Node()
 				j := paths.indexOf[v.ID()]
 				w, ok := weight(mid.node, v)
 				if !ok {
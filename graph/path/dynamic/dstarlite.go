@@ -88,7 +88,8 @@ func NewDStarLite(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel
 
 	d.queue.insert(d.t, key{d.heuristic(s, t), 0})
 
-	for _, n := range g.Nodes() {
+	for nodes := g.Nodes(); nodes.Next(); {
+		n := nodes.Node()
 		switch n.ID() {
 		case d.s.ID():
 			d.model.AddNode(d.s)
@@ -98,8 +99,10 @@ func NewDStarLite(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel
 			d.model.AddNode(newDStarLiteNode(n))
 		}
 	}
-	for _, u := range d.model.Nodes() {
-		for _, v := range g.From(u) {
+	for nodes := d.model.Nodes(); nodes.Next(); {
+		u := nodes.Node()
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			w := edgeWeight(d.weight, u, v)
 			if w < 0 {
 				panic("D* Lite: negative edge weight")
@@ -193,8 +196,8 @@ func (d *DStarLite) findShortestPath() {
 		case u.g > u.rhs:
 			u.g = u.rhs
 			d.queue.remove(u)
-			for _, _s := range d.model.To(u) {
-				s := _s.(*dStarLiteNode)
+			for from := d.model.To(u); from.Next(); {
+				s := from.Node().(*dStarLiteNode)
 				if s.ID() != d.t.ID() {
 					s.rhs = math.Min(s.rhs, edgeWeight(d.model.Weight, s, u)+u.g)
 				}
@@ -203,12 +206,13 @@ func (d *DStarLite) findShortestPath() {
 		default:
 			gOld := u.g
 			u.g = math.Inf(1)
-			for _, _s := range append(d.model.To(u), u) {
+			for _, _s := range append(graph.NodesOf(d.model.To(u)), u) {
 				s := _s.(*dStarLiteNode)
 				if s.rhs == edgeWeight(d.model.Weight, s, u)+gOld {
 					if s.ID() != d.t.ID() {
 						s.rhs = math.Inf(1)
-						for _, t := range d.model.From(s) {
+						for to := d.model.From(s); to.Next(); {
+							t := to.Node()
 							s.rhs = math.Min(s.rhs, edgeWeight(d.model.Weight, s, t)+t.(*dStarLiteNode).g)
 						}
 					}
@@ -243,8 +247,8 @@ func (d *DStarLite) Step() bool {
 	min := math.Inf(1)
 
 	var next *dStarLiteNode
-	for _, _s := range d.model.From(d.s) {
-		s := _s.(*dStarLiteNode)
+	for to := d.model.From(d.s); to.Next(); {
+		s := to.Node().(*dStarLiteNode)
 		w := edgeWeight(d.model.Weight, d.s, s) + s.g
 		if w < min || (w == min && s.rhs < rhs) {
 			next = s
@@ -310,7 +314,8 @@ func (d *DStarLite) UpdateWorld(changes []graph.Edge) {
 		} else if u.rhs == cOld+v.g {
 			if u.ID() != d.t.ID() {
 				u.rhs = math.Inf(1)
-				for _, t := range d.model.From(u) {
+				for to := d.model.From(u); to.Next(); {
+					t := to.Node()
 					u.rhs = math.Min(u.rhs, edgeWeight(d.model.Weight, u, t)+t.(*dStarLiteNode).g)
 				}
 			}
@@ -354,8 +359,8 @@ func (d *DStarLite) Path() (p []graph.Node, weight float64) {
 			next *dStarLiteNode
 			cost float64
 		)
-		for _, _v := range d.model.From(u) {
-			v := _v.(*dStarLiteNode)
+		for to := d.model.From(u); to.Next(); {
+			v := to.Node().(*dStarLiteNode)
 			w := edgeWeight(d.model.Weight, u, v)
 			if rhs := w + v.g; rhs < min || (rhs == min && v.rhs < rhsMin) {
 				next = v
@@ -388,7 +388,7 @@ var dynamicDStarLiteTests = []struct {
 		modify: func(l *internal.LimitedVisionGrid) {
 			all := l.Grid.AllVisible
 			l.Grid.AllVisible = false
-			for _, n := range l.Nodes() {
+			for _, n := range graph.NodesOf(l.Nodes()) {
 				l.Known[n.ID()] = !l.Grid.Has(n)
 			}
 			l.Grid.AllVisible = all
@@ -400,8 +400,8 @@ var dynamicDStarLiteTests = []struct {
 			l.Known[l.NodeAt(wallRow, wallCol).ID()] = false
 
 			// Check we have a correctly modified representation.
-			for _, u := range l.Nodes() {
-				for _, v := range l.Nodes() {
+			for _, u := range graph.NodesOf(l.Nodes()) {
+				for _, v := range graph.NodesOf(l.Nodes()) {
 					if l.HasEdgeBetween(u, v) != l.Grid.HasEdgeBetween(u, v) {
 						ur, uc := l.RowCol(u.ID())
 						vr, vc := l.RowCol(v.ID())
@@ -596,7 +596,7 @@ func TestDStarLiteDynamic(t *testing.T) {
 			}
 
 			dp.dump(true)
-			dp.printEdges("Initial world knowledge: %s\n\n", simpleWeightedEdgesOf(l, world.Edges()))
+			dp.printEdges("Initial world knowledge: %s\n\n", simpleWeightedEdgesOf(l, graph.EdgesOf(world.Edges())))
 			for d.Step() {
 				changes, _ := l.MoveTo(d.Here())
 				got = append(got, l.Location)
@@ -19,11 +19,12 @@ func FloydWarshall(g graph.Graph) (paths AllShortest, ok bool) {
 		weight = UniformCost(g)
 	}
 
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	paths = newAllShortest(nodes, true)
 	for i, u := range nodes {
 		paths.dist.Set(i, i, 0)
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			j := paths.indexOf[v.ID()]
 			w, ok := weight(u, v)
 			if !ok {
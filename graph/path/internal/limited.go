@@ -9,6 +9,7 @@ import (
 	"math"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -143,12 +144,8 @@ func (l *LimitedVisionGrid) XY(n graph.Node) (x, y float64) {
 }
 
 // Nodes returns all the nodes in the grid.
-func (l *LimitedVisionGrid) Nodes() []graph.Node {
-	nodes := make([]graph.Node, 0, len(l.Grid.open))
-	for id := range l.Grid.open {
-		nodes = append(nodes, simple.Node(id))
-	}
-	return nodes
+func (l *LimitedVisionGrid) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(l.Grid.open), func(id int) graph.Node { return simple.Node(id) })
 }
 
 // NodeAt returns the node at (r, c). The returned node may be open or closed.
@@ -166,9 +163,9 @@ func (l *LimitedVisionGrid) has(id int64) bool {
 }
 
 // From returns nodes that are optimistically reachable from u.
-func (l *LimitedVisionGrid) From(u graph.Node) []graph.Node {
+func (l *LimitedVisionGrid) From(u graph.Node) graph.Nodes {
 	if !l.Has(u) {
-		return nil
+		return graph.Empty
 	}
 
 	nr, nc := l.RowCol(u.ID())
@@ -180,7 +177,7 @@ func (l *LimitedVisionGrid) From(u graph.Node) []graph.Node {
 			}
 		}
 	}
-	return to
+	return iterator.NewOrderedNodes(to)
 }
 
 // HasEdgeBetween optimistically returns whether an edge is exists between u and v.
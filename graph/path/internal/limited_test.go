@@ -1166,10 +1166,10 @@ func TestLimitedVisionGrid(t *testing.T) {
 		l.Grid.AllowDiagonal = test.diag
 
 		x, y := l.XY(test.path[0])
-		for _, u := range l.Nodes() {
+		for _, u := range graph.NodesOf(l.Nodes()) {
 			ux, uy := l.XY(u)
 			uNear := math.Hypot(x-ux, y-uy) <= test.radius
-			for _, v := range l.Nodes() {
+			for _, v := range graph.NodesOf(l.Nodes()) {
 				vx, vy := l.XY(v)
 				vNear := math.Hypot(x-vx, y-vy) <= test.radius
 				if u.ID() == v.ID() && l.HasEdgeBetween(u, v) {
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -28,7 +29,7 @@ func JohnsonAllPaths(g graph.Graph) (paths AllShortest, ok bool) {
 		jg.weight = UniformCost(g)
 	}
 
-	paths = newAllShortest(g.Nodes(), false)
+	paths = newAllShortest(graph.NodesOf(g.Nodes()), false)
 
 	sign := int64(-1)
 	for {
@@ -68,7 +69,7 @@ type johnsonWeightAdjuster struct {
 	q int64
 	g graph.Graph
 
-	from   func(graph.Node) []graph.Node
+	from   func(graph.Node) graph.Nodes
 	edgeTo func(graph.Node, graph.Node) graph.Edge
 	weight Weighting
 
@@ -93,14 +94,19 @@ func (g johnsonWeightAdjuster) Has(n graph.Node) bool {
 
 }
 
-func (g johnsonWeightAdjuster) Nodes() []graph.Node {
+func (g johnsonWeightAdjuster) Nodes() graph.Nodes {
 	if g.bellmanFord {
-		return append(g.g.Nodes(), johnsonGraphNode(g.q))
+		nodes := g.g.Nodes()
+		all := make([]graph.Node, 0, nodes.Len()+1)
+		for nodes.Next() {
+			all = append(all, nodes.Node())
+		}
+		return iterator.NewOrderedNodes(append(all, johnsonGraphNode(g.q)))
 	}
 	return g.g.Nodes()
 }
 
-func (g johnsonWeightAdjuster) From(n graph.Node) []graph.Node {
+func (g johnsonWeightAdjuster) From(n graph.Node) graph.Nodes {
 	if g.bellmanFord && n.ID() == g.q {
 		return g.g.Nodes()
 	}
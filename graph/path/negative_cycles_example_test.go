@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 
+	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/path"
 	"gonum.org/v1/gonum/graph/simple"
 )
@@ -36,7 +37,7 @@ func ExampleBellmanFordFrom_negativecycles() {
 	}
 
 	// Add a zero-cost path to all nodes from a new node Q.
-	for _, n := range g.Nodes() {
+	for _, n := range graph.NodesOf(g.Nodes()) {
 		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node('Q'), T: n})
 	}
 
@@ -33,22 +33,25 @@ type WeightedBuilder interface {
 // If dst has nodes that exist in g, Prim will panic.
 func Prim(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
 	nodes := g.Nodes()
-	if len(nodes) == 0 {
+	if nodes.Len() == 0 {
 		return 0
 	}
 
 	q := &primQueue{
-		indexOf: make(map[int64]int, len(nodes)-1),
-		nodes:   make([]simple.WeightedEdge, 0, len(nodes)-1),
+		indexOf: make(map[int64]int, nodes.Len()-1),
+		nodes:   make([]simple.WeightedEdge, 0, nodes.Len()-1),
 	}
-	dst.AddNode(nodes[0])
-	for _, u := range nodes[1:] {
-		dst.AddNode(u)
-		heap.Push(q, simple.WeightedEdge{F: u, W: math.Inf(1)})
+	nodes.Next()
+	u := nodes.Node()
+	dst.AddNode(u)
+	for nodes.Next() {
+		n := nodes.Node()
+		dst.AddNode(n)
+		heap.Push(q, simple.WeightedEdge{F: n, W: math.Inf(1)})
 	}
 
-	u := nodes[0]
-	for _, v := range g.From(u) {
+	for to := g.From(u); to.Next(); {
+		v := to.Node()
 		w, ok := g.Weight(u, v)
 		if !ok {
 			panic("prim: unexpected invalid weight")
@@ -65,7 +68,8 @@ func Prim(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
 		}
 
 		u = e.From()
-		for _, n := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			n := to.Node()
 			if key, ok := q.key(n); ok {
 				w, ok := g.Weight(u, n)
 				if !ok {
@@ -144,7 +148,7 @@ func (q *primQueue) update(u, v graph.Node, key float64) {
 // the set of edges in the graph.
 type UndirectedWeightLister interface {
 	graph.WeightedUndirected
-	WeightedEdges() []graph.WeightedEdge
+	WeightedEdges() graph.WeightedEdges
 }
 
 // Kruskal generates a minimum spanning tree of g by greedy tree coalescence, placing
@@ -160,11 +164,12 @@ type UndirectedWeightLister interface {
 //
 // If dst has nodes that exist in g, Kruskal will panic.
 func Kruskal(dst WeightedBuilder, g UndirectedWeightLister) float64 {
-	edges := g.WeightedEdges()
+	edges := graph.WeightedEdgesOf(g.WeightedEdges())
 	sort.Sort(byWeight(edges))
 
 	ds := newDisjointSet()
-	for _, node := range g.Nodes() {
+	for nodes := g.Nodes(); nodes.Next(); {
+		node := nodes.Node()
 		dst.AddNode(node)
 		ds.makeSet(node.ID())
 	}
@@ -9,6 +9,7 @@ import (
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -85,22 +86,18 @@ func (g *DirectedMatrix) has(id int64) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *DirectedMatrix) Nodes() []graph.Node {
+func (g *DirectedMatrix) Nodes() graph.Nodes {
 	if g.nodes != nil {
 		nodes := make([]graph.Node, len(g.nodes))
 		copy(nodes, g.nodes)
-		return nodes
+		return iterator.NewOrderedNodes(nodes)
 	}
 	r, _ := g.mat.Dims()
-	nodes := make([]graph.Node, r)
-	for i := 0; i < r; i++ {
-		nodes[i] = Node(i)
-	}
-	return nodes
+	return iterator.NewImplicitNodes(0, r, newSimpleNode)
 }
 
 // Edges returns all the edges in the graph.
-func (g *DirectedMatrix) Edges() []graph.Edge {
+func (g *DirectedMatrix) Edges() graph.Edges {
 	var edges []graph.Edge
 	r, _ := g.mat.Dims()
 	for i := 0; i < r; i++ {
@@ -113,14 +110,17 @@ func (g *DirectedMatrix) Edges() []graph.Edge {
 			}
 		}
 	}
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
 }
 
 // From returns all nodes in g that can be reached directly from n.
-func (g *DirectedMatrix) From(n graph.Node) []graph.Node {
+func (g *DirectedMatrix) From(n graph.Node) graph.Nodes {
 	id := n.ID()
 	if !g.has(id) {
-		return nil
+		return graph.Empty
 	}
 	var neighbors []graph.Node
 	_, c := g.mat.Dims()
@@ -133,14 +133,17 @@ func (g *DirectedMatrix) From(n graph.Node) []graph.Node {
 			neighbors = append(neighbors, g.Node(int64(j)))
 		}
 	}
-	return neighbors
+	if len(neighbors) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(neighbors)
 }
 
 // To returns all nodes in g that can reach directly to n.
-func (g *DirectedMatrix) To(n graph.Node) []graph.Node {
+func (g *DirectedMatrix) To(n graph.Node) graph.Nodes {
 	id := n.ID()
 	if !g.has(id) {
-		return nil
+		return graph.Empty
 	}
 	var neighbors []graph.Node
 	r, _ := g.mat.Dims()
@@ -153,7 +156,10 @@ func (g *DirectedMatrix) To(n graph.Node) []graph.Node {
 			neighbors = append(neighbors, g.Node(int64(i)))
 		}
 	}
-	return neighbors
+	if len(neighbors) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(neighbors)
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
@@ -77,18 +77,18 @@ func TestDirectedDenseAddRemove(t *testing.T) {
 	dg := NewDirectedMatrix(10, math.Inf(1), 0, math.Inf(1))
 	dg.SetWeightedEdge(WeightedEdge{F: Node(0), T: Node(2), W: 1})
 
-	if neighbors := dg.From(Node(0)); len(neighbors) != 1 || neighbors[0].ID() != 2 ||
+	if neighbors := graph.NodesOf(dg.From(Node(0))); len(neighbors) != 1 || neighbors[0].ID() != 2 ||
 		dg.Edge(Node(0), Node(2)) == nil {
 		t.Errorf("Adding edge didn't create successor")
 	}
 
 	dg.RemoveEdge(Edge{F: Node(0), T: Node(2)})
 
-	if neighbors := dg.From(Node(0)); len(neighbors) != 0 || dg.Edge(Node(0), Node(2)) != nil {
+	if neighbors := graph.NodesOf(dg.From(Node(0))); len(neighbors) != 0 || dg.Edge(Node(0), Node(2)) != nil {
 		t.Errorf("Removing edge didn't properly remove successor")
 	}
 
-	if neighbors := dg.To(Node(2)); len(neighbors) != 0 || dg.Edge(Node(0), Node(2)) != nil {
+	if neighbors := graph.NodesOf(dg.To(Node(2))); len(neighbors) != 0 || dg.Edge(Node(0), Node(2)) != nil {
 		t.Errorf("Removing directed edge wrongly kept predecessor")
 	}
 
@@ -109,12 +109,12 @@ func TestUndirectedDenseAddRemove(t *testing.T) {
 	dg := NewUndirectedMatrix(10, math.Inf(1), 0, math.Inf(1))
 	dg.SetEdge(Edge{F: Node(0), T: Node(2)})
 
-	if neighbors := dg.From(Node(0)); len(neighbors) != 1 || neighbors[0].ID() != 2 ||
+	if neighbors := graph.NodesOf(dg.From(Node(0))); len(neighbors) != 1 || neighbors[0].ID() != 2 ||
 		dg.EdgeBetween(Node(0), Node(2)) == nil {
 		t.Errorf("Couldn't add neighbor")
 	}
 
-	if neighbors := dg.From(Node(2)); len(neighbors) != 1 || neighbors[0].ID() != 0 ||
+	if neighbors := graph.NodesOf(dg.From(Node(2))); len(neighbors) != 1 || neighbors[0].ID() != 0 ||
 		dg.EdgeBetween(Node(2), Node(0)) == nil {
 		t.Errorf("Adding an undirected neighbor didn't add it reciprocally")
 	}
@@ -122,7 +122,7 @@ func TestUndirectedDenseAddRemove(t *testing.T) {
 
 func TestDenseLists(t *testing.T) {
 	dg := NewDirectedMatrix(15, 1, 0, math.Inf(1))
-	nodes := dg.Nodes()
+	nodes := graph.NodesOf(dg.Nodes())
 
 	if len(nodes) != 15 {
 		t.Fatalf("Wrong number of nodes")
@@ -130,19 +130,19 @@ func TestDenseLists(t *testing.T) {
 
 	sort.Sort(ordered.ByID(nodes))
 
-	for i, node := range dg.Nodes() {
+	for i, node := range graph.NodesOf(dg.Nodes()) {
 		if int64(i) != node.ID() {
 			t.Errorf("Node list doesn't return properly id'd nodes")
 		}
 	}
 
-	edges := dg.Edges()
+	edges := graph.EdgesOf(dg.Edges())
 	if len(edges) != 15*14 {
 		t.Errorf("Improper number of edges for passable dense graph")
 	}
 
 	dg.RemoveEdge(Edge{F: Node(12), T: Node(11)})
-	edges = dg.Edges()
+	edges = graph.EdgesOf(dg.Edges())
 	if len(edges) != (15*14)-1 {
 		t.Errorf("Removing edge didn't affect edge listing properly")
 	}
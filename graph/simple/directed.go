@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // DirectedGraph implements a generalized directed graph.
@@ -16,7 +18,7 @@ type DirectedGraph struct {
 	from  map[int64]map[int64]graph.Edge
 	to    map[int64]map[int64]graph.Edge
 
-	nodeIDs idSet
+	nodeIDs uid.Set
 }
 
 // NewDirectedGraph returns a DirectedGraph with the specified self and absent
@@ -27,7 +29,7 @@ func NewDirectedGraph() *DirectedGraph {
 		from:  make(map[int64]map[int64]graph.Edge),
 		to:    make(map[int64]map[int64]graph.Edge),
 
-		nodeIDs: newIDSet(),
+		nodeIDs: uid.NewSet(),
 	}
 }
 
@@ -37,10 +39,10 @@ func (g *DirectedGraph) NewNode() graph.Node {
 	if len(g.nodes) == 0 {
 		return Node(0)
 	}
-	if int64(len(g.nodes)) == maxInt {
+	if int64(len(g.nodes)) == uid.Max {
 		panic("simple: cannot allocate node: no slot")
 	}
-	return Node(g.nodeIDs.newID())
+	return Node(g.nodeIDs.NewID())
 }
 
 // AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
@@ -51,7 +53,7 @@ func (g *DirectedGraph) AddNode(n graph.Node) {
 	g.nodes[n.ID()] = n
 	g.from[n.ID()] = make(map[int64]graph.Edge)
 	g.to[n.ID()] = make(map[int64]graph.Edge)
-	g.nodeIDs.use(n.ID())
+	g.nodeIDs.Use(n.ID())
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
@@ -72,7 +74,7 @@ func (g *DirectedGraph) RemoveNode(n graph.Node) {
 	}
 	delete(g.to, n.ID())
 
-	g.nodeIDs.release(n.ID())
+	g.nodeIDs.Release(n.ID())
 }
 
 // NewEdge returns a new Edge from the source to the destination node.
@@ -133,58 +135,41 @@ func (g *DirectedGraph) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *DirectedGraph) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.from))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
+func (g *DirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
 	}
-
-	return nodes
+	return iterator.NewNodes(g.nodes)
 }
 
 // Edges returns all the edges in the graph.
-func (g *DirectedGraph) Edges() []graph.Edge {
+func (g *DirectedGraph) Edges() graph.Edges {
 	var edges []graph.Edge
 	for _, u := range g.nodes {
 		for _, e := range g.from[u.ID()] {
 			edges = append(edges, e)
 		}
 	}
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
 }
 
 // From returns all nodes in g that can be reached directly from n.
-func (g *DirectedGraph) From(n graph.Node) []graph.Node {
+func (g *DirectedGraph) From(n graph.Node) graph.Nodes {
 	if _, ok := g.from[n.ID()]; !ok {
-		return nil
-	}
-
-	from := make([]graph.Node, len(g.from[n.ID()]))
-	i := 0
-	for id := range g.from[n.ID()] {
-		from[i] = g.nodes[id]
-		i++
+		return graph.Empty
 	}
-
-	return from
+	return iterator.NewNodesByEdge(g.nodes, g.from[n.ID()])
 }
 
 // To returns all nodes in g that can reach directly to n.
-func (g *DirectedGraph) To(n graph.Node) []graph.Node {
-	if _, ok := g.from[n.ID()]; !ok {
-		return nil
+func (g *DirectedGraph) To(n graph.Node) graph.Nodes {
+	if _, ok := g.to[n.ID()]; !ok {
+		return graph.Empty
 	}
-
-	to := make([]graph.Node, len(g.to[n.ID()]))
-	i := 0
-	for id := range g.to[n.ID()] {
-		to[i] = g.nodes[id]
-		i++
-	}
-
-	return to
+	return iterator.NewNodesByEdge(g.nodes, g.to[n.ID()])
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // UndirectedGraph implements a generalized undirected graph.
@@ -15,7 +17,7 @@ type UndirectedGraph struct {
 	nodes map[int64]graph.Node
 	edges map[int64]map[int64]graph.Edge
 
-	nodeIDs idSet
+	nodeIDs uid.Set
 }
 
 // NewUndirectedGraph returns an UndirectedGraph with the specified self and absent
@@ -25,7 +27,7 @@ func NewUndirectedGraph() *UndirectedGraph {
 		nodes: make(map[int64]graph.Node),
 		edges: make(map[int64]map[int64]graph.Edge),
 
-		nodeIDs: newIDSet(),
+		nodeIDs: uid.NewSet(),
 	}
 }
 
@@ -35,10 +37,10 @@ func (g *UndirectedGraph) NewNode() graph.Node {
 	if len(g.nodes) == 0 {
 		return Node(0)
 	}
-	if int64(len(g.nodes)) == maxInt {
+	if int64(len(g.nodes)) == uid.Max {
 		panic("simple: cannot allocate node: no slot")
 	}
-	return Node(g.nodeIDs.newID())
+	return Node(g.nodeIDs.NewID())
 }
 
 // AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
@@ -48,7 +50,7 @@ func (g *UndirectedGraph) AddNode(n graph.Node) {
 	}
 	g.nodes[n.ID()] = n
 	g.edges[n.ID()] = make(map[int64]graph.Edge)
-	g.nodeIDs.use(n.ID())
+	g.nodeIDs.Use(n.ID())
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
@@ -64,7 +66,7 @@ func (g *UndirectedGraph) RemoveNode(n graph.Node) {
 	}
 	delete(g.edges, n.ID())
 
-	g.nodeIDs.release(n.ID())
+	g.nodeIDs.Release(n.ID())
 }
 
 // NewEdge returns a new Edge from the source to the destination node.
@@ -124,19 +126,15 @@ func (g *UndirectedGraph) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *UndirectedGraph) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
+func (g *UndirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
 	}
-
-	return nodes
+	return iterator.NewNodes(g.nodes)
 }
 
 // Edges returns all the edges in the graph.
-func (g *UndirectedGraph) Edges() []graph.Edge {
+func (g *UndirectedGraph) Edges() graph.Edges {
 	var edges []graph.Edge
 
 	seen := make(map[[2]int64]struct{})
@@ -152,24 +150,18 @@ func (g *UndirectedGraph) Edges() []graph.Edge {
 			edges = append(edges, e)
 		}
 	}
-
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
 }
 
 // From returns all nodes in g that can be reached directly from n.
-func (g *UndirectedGraph) From(n graph.Node) []graph.Node {
-	if !g.Has(n) {
-		return nil
+func (g *UndirectedGraph) From(n graph.Node) graph.Nodes {
+	if _, ok := g.edges[n.ID()]; !ok {
+		return graph.Empty
 	}
-
-	nodes := make([]graph.Node, len(g.edges[n.ID()]))
-	i := 0
-	for from := range g.edges[n.ID()] {
-		nodes[i] = g.nodes[from]
-		i++
-	}
-
-	return nodes
+	return iterator.NewNodesByEdge(g.nodes, g.edges[n.ID()])
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
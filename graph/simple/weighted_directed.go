@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // WeightedDirectedGraph implements a generalized weighted directed graph.
@@ -18,7 +20,7 @@ type WeightedDirectedGraph struct {
 
 	self, absent float64
 
-	nodeIDs idSet
+	nodeIDs uid.Set
 }
 
 // NewWeightedDirectedGraph returns a WeightedDirectedGraph with the specified self and absent
@@ -32,7 +34,7 @@ func NewWeightedDirectedGraph(self, absent float64) *WeightedDirectedGraph {
 		self:   self,
 		absent: absent,
 
-		nodeIDs: newIDSet(),
+		nodeIDs: uid.NewSet(),
 	}
 }
 
@@ -42,10 +44,10 @@ func (g *WeightedDirectedGraph) NewNode() graph.Node {
 	if len(g.nodes) == 0 {
 		return Node(0)
 	}
-	if int64(len(g.nodes)) == maxInt {
+	if int64(len(g.nodes)) == uid.Max {
 		panic("simple: cannot allocate node: no slot")
 	}
-	return Node(g.nodeIDs.newID())
+	return Node(g.nodeIDs.NewID())
 }
 
 // AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
@@ -56,7 +58,7 @@ func (g *WeightedDirectedGraph) AddNode(n graph.Node) {
 	g.nodes[n.ID()] = n
 	g.from[n.ID()] = make(map[int64]graph.WeightedEdge)
 	g.to[n.ID()] = make(map[int64]graph.WeightedEdge)
-	g.nodeIDs.use(n.ID())
+	g.nodeIDs.Use(n.ID())
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
@@ -77,7 +79,7 @@ func (g *WeightedDirectedGraph) RemoveNode(n graph.Node) {
 	}
 	delete(g.to, n.ID())
 
-	g.nodeIDs.release(n.ID())
+	g.nodeIDs.Release(n.ID())
 }
 
 // NewWeightedEdge returns a new weighted edge from the source to the destination node.
@@ -138,69 +140,55 @@ func (g *WeightedDirectedGraph) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *WeightedDirectedGraph) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.from))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
+func (g *WeightedDirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
 	}
-
-	return nodes
+	return iterator.NewNodes(g.nodes)
 }
 
 // Edges returns all the edges in the graph.
-func (g *WeightedDirectedGraph) Edges() []graph.Edge {
+func (g *WeightedDirectedGraph) Edges() graph.Edges {
 	var edges []graph.Edge
 	for _, u := range g.nodes {
 		for _, e := range g.from[u.ID()] {
 			edges = append(edges, e)
 		}
 	}
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
 }
 
 // WeightedEdges returns all the weighted edges in the graph.
-func (g *WeightedDirectedGraph) WeightedEdges() []graph.WeightedEdge {
+func (g *WeightedDirectedGraph) WeightedEdges() graph.WeightedEdges {
 	var edges []graph.WeightedEdge
 	for _, u := range g.nodes {
 		for _, e := range g.from[u.ID()] {
 			edges = append(edges, e)
 		}
 	}
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedWeightedEdges(edges)
 }
 
 // From returns all nodes in g that can be reached directly from n.
-func (g *WeightedDirectedGraph) From(n graph.Node) []graph.Node {
+func (g *WeightedDirectedGraph) From(n graph.Node) graph.Nodes {
 	if _, ok := g.from[n.ID()]; !ok {
-		return nil
-	}
-
-	from := make([]graph.Node, len(g.from[n.ID()]))
-	i := 0
-	for id := range g.from[n.ID()] {
-		from[i] = g.nodes[id]
-		i++
+		return graph.Empty
 	}
-
-	return from
+	return iterator.NewNodesByWeightedEdge(g.nodes, g.from[n.ID()])
 }
 
 // To returns all nodes in g that can reach directly to n.
-func (g *WeightedDirectedGraph) To(n graph.Node) []graph.Node {
-	if _, ok := g.from[n.ID()]; !ok {
-		return nil
+func (g *WeightedDirectedGraph) To(n graph.Node) graph.Nodes {
+	if _, ok := g.to[n.ID()]; !ok {
+		return graph.Empty
 	}
-
-	to := make([]graph.Node, len(g.to[n.ID()]))
-	i := 0
-	for id := range g.to[n.ID()] {
-		to[i] = g.nodes[id]
-		i++
-	}
-
-	return to
+	return iterator.NewNodesByWeightedEdge(g.nodes, g.to[n.ID()])
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/uid"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // WeightedUndirectedGraph implements a generalized weighted undirected graph.
@@ -17,7 +19,7 @@ type WeightedUndirectedGraph struct {
 
 	self, absent float64
 
-	nodeIDs idSet
+	nodeIDs uid.Set
 }
 
 // NewWeightedUndirectedGraph returns an WeightedUndirectedGraph with the specified self and absent
@@ -30,7 +32,7 @@ func NewWeightedUndirectedGraph(self, absent float64) *WeightedUndirectedGraph {
 		self:   self,
 		absent: absent,
 
-		nodeIDs: newIDSet(),
+		nodeIDs: uid.NewSet(),
 	}
 }
 
@@ -40,10 +42,10 @@ func (g *WeightedUndirectedGraph) NewNode() graph.Node {
 	if len(g.nodes) == 0 {
 		return Node(0)
 	}
-	if int64(len(g.nodes)) == maxInt {
+	if int64(len(g.nodes)) == uid.Max {
 		panic("simple: cannot allocate node: no slot")
 	}
-	return Node(g.nodeIDs.newID())
+	return Node(g.nodeIDs.NewID())
 }
 
 // AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
@@ -53,7 +55,7 @@ func (g *WeightedUndirectedGraph) AddNode(n graph.Node) {
 	}
 	g.nodes[n.ID()] = n
 	g.edges[n.ID()] = make(map[int64]graph.WeightedEdge)
-	g.nodeIDs.use(n.ID())
+	g.nodeIDs.Use(n.ID())
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
@@ -69,7 +71,7 @@ func (g *WeightedUndirectedGraph) RemoveNode(n graph.Node) {
 	}
 	delete(g.edges, n.ID())
 
-	g.nodeIDs.release(n.ID())
+	g.nodeIDs.Release(n.ID())
 }
 
 // NewWeightedEdge returns a new weighted edge from the source to the destination node.
@@ -129,19 +131,15 @@ func (g *WeightedUndirectedGraph) Has(n graph.Node) bool {
 }
 
 // Nodes returns all the nodes in the graph.
-func (g *WeightedUndirectedGraph) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.nodes))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
+func (g *WeightedUndirectedGraph) Nodes() graph.Nodes {
+	if len(g.nodes) == 0 {
+		return graph.Empty
 	}
-
-	return nodes
+	return iterator.NewNodes(g.nodes)
 }
 
 // Edges returns all the edges in the graph.
-func (g *WeightedUndirectedGraph) Edges() []graph.Edge {
+func (g *WeightedUndirectedGraph) Edges() graph.Edges {
 	var edges []graph.Edge
 
 	seen := make(map[[2]int64]struct{})
@@ -157,15 +155,18 @@ func (g *WeightedUndirectedGraph) Edges() []graph.Edge {
 			edges = append(edges, e)
 		}
 	}
-
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedEdges(edges)
 }
 
 // WeightedEdges returns all the weighted edges in the graph.
-func (g *WeightedUndirectedGraph) WeightedEdges() []graph.WeightedEdge {
+func (g *WeightedUndirectedGraph) WeightedEdges() graph.WeightedEdges {
 	var edges []graph.WeightedEdge
 
 	seen := make(map[[2]int64]struct{})
+
 	for _, u := range g.edges {
 		for _, e := range u {
 			uid := e.From().ID()
@@ -178,24 +179,18 @@ func (g *WeightedUndirectedGraph) WeightedEdges() []graph.WeightedEdge {
 			edges = append(edges, e)
 		}
 	}
-
-	return edges
+	if len(edges) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedWeightedEdges(edges)
 }
 
 // From returns all nodes in g that can be reached directly from n.
-func (g *WeightedUndirectedGraph) From(n graph.Node) []graph.Node {
-	if !g.Has(n) {
-		return nil
-	}
-
-	nodes := make([]graph.Node, len(g.edges[n.ID()]))
-	i := 0
-	for from := range g.edges[n.ID()] {
-		nodes[i] = g.nodes[from]
-		i++
+func (g *WeightedUndirectedGraph) From(n graph.Node) graph.Nodes {
+	if _, ok := g.edges[n.ID()]; !ok {
+		return graph.Empty
 	}
-
-	return nodes
+	return iterator.NewNodesByWeightedEdge(g.nodes, g.edges[n.ID()])
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
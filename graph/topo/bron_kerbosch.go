@@ -44,7 +44,7 @@ func KCore(k int, g graph.Undirected) []graph.Node {
 // s, a set of relative offsets into l for each k-core, where k is an index
 // into s.
 func degeneracyOrdering(g graph.Undirected) (l []graph.Node, s []int) {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 
 	// The algorithm used here is essentially as described at
 	// http://en.wikipedia.org/w/index.php?title=Degeneracy_%28graph_theory%29&oldid=640308710
@@ -60,7 +60,7 @@ func degeneracyOrdering(g graph.Undirected) (l []graph.Node, s []int) {
 		neighbours = make(map[int64][]graph.Node)
 	)
 	for _, n := range nodes {
-		adj := g.From(n)
+		adj := graph.NodesOf(g.From(n))
 		neighbours[n.ID()] = adj
 		dv[n.ID()] = len(adj)
 		if len(adj) > maxDegree {
@@ -132,7 +132,7 @@ func degeneracyOrdering(g graph.Undirected) (l []graph.Node, s []int) {
 
 // BronKerbosch returns the set of maximal cliques of the undirected graph g.
 func BronKerbosch(g graph.Undirected) [][]graph.Node {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 
 	// The algorithm used here is essentially BronKerbosch3 as described at
 	// http://en.wikipedia.org/w/index.php?title=Bron%E2%80%93Kerbosch_algorithm&oldid=656805858
@@ -146,7 +146,7 @@ func BronKerbosch(g graph.Undirected) [][]graph.Node {
 	order, _ := degeneracyOrdering(g)
 	ordered.Reverse(order)
 	for _, v := range order {
-		neighbours := g.From(v)
+		neighbours := graph.NodesOf(g.From(v))
 		nv := make(set.Nodes, len(neighbours))
 		for _, n := range neighbours {
 			nv.Add(n)
@@ -175,7 +175,7 @@ func (bk *bronKerbosch) maximalCliquePivot(g graph.Undirected, r []graph.Node, p
 		if nu.Has(v) {
 			continue
 		}
-		neighbours := g.From(v)
+		neighbours := graph.NodesOf(g.From(v))
 		nv := make(set.Nodes, len(neighbours))
 		for _, n := range neighbours {
 			nv.Add(n)
@@ -205,10 +205,10 @@ func (*bronKerbosch) choosePivotFrom(g graph.Undirected, p, x set.Nodes) (neighb
 	// compile time option.
 	if !tomitaTanakaTakahashi {
 		for _, n := range p {
-			return g.From(n)
+			return graph.NodesOf(g.From(n))
 		}
 		for _, n := range x {
-			return g.From(n)
+			return graph.NodesOf(g.From(n))
 		}
 		panic("bronKerbosch: empty set")
 	}
@@ -220,7 +220,7 @@ func (*bronKerbosch) choosePivotFrom(g graph.Undirected, p, x set.Nodes) (neighb
 	maxNeighbors := func(s set.Nodes) {
 	outer:
 		for _, u := range s {
-			nb := g.From(u)
+			nb := graph.NodesOf(g.From(u))
 			c := len(nb)
 			if c <= max {
 				continue
@@ -10,6 +10,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 // johnson implements Johnson's "Finding all the elementary
@@ -132,7 +133,7 @@ type johnsonGraph struct {
 
 // johnsonGraphFrom returns a deep copy of the graph g.
 func johnsonGraphFrom(g graph.Directed) johnsonGraph {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	sort.Sort(ordered.ByID(nodes))
 	c := johnsonGraph{
 		orig:  nodes,
@@ -143,7 +144,8 @@ func johnsonGraphFrom(g graph.Directed) johnsonGraph {
 	}
 	for i, u := range nodes {
 		c.index[u.ID()] = i
-		for _, v := range g.From(u) {
+		for to := g.From(u); to.Next(); {
+			v := to.Node()
 			if c.succ[u.ID()] == nil {
 				c.succ[u.ID()] = make(set.Int64s)
 				c.nodes.Add(u.ID())
@@ -238,25 +240,25 @@ func (g johnsonGraph) sccSubGraph(sccs [][]graph.Node, min int) johnsonGraph {
 }
 
 // Nodes is required to satisfy Tarjan.
-func (g johnsonGraph) Nodes() []graph.Node {
+func (g johnsonGraph) Nodes() graph.Nodes {
 	n := make([]graph.Node, 0, len(g.nodes))
 	for id := range g.nodes {
 		n = append(n, johnsonGraphNode(id))
 	}
-	return n
+	return iterator.NewOrderedNodes(n)
 }
 
 // Successors is required to satisfy Tarjan.
-func (g johnsonGraph) From(n graph.Node) []graph.Node {
+func (g johnsonGraph) From(n graph.Node) graph.Nodes {
 	adj := g.succ[n.ID()]
 	if len(adj) == 0 {
-		return nil
+		return graph.Empty
 	}
 	succ := make([]graph.Node, 0, len(adj))
 	for n := range adj {
 		succ = append(succ, johnsonGraphNode(n))
 	}
-	return succ
+	return iterator.NewOrderedNodes(succ)
 }
 
 func (johnsonGraph) Has(graph.Node) bool {
@@ -271,7 +273,7 @@ func (johnsonGraph) Edge(_, _ graph.Node) graph.Edge {
 func (johnsonGraph) HasEdgeFromTo(_, _ graph.Node) bool {
 	panic("topo: unintended use of johnsonGraph")
 }
-func (johnsonGraph) To(graph.Node) []graph.Node {
+func (johnsonGraph) To(graph.Node) graph.Nodes {
 	panic("topo: unintended use of johnsonGraph")
 }
 
@@ -19,7 +19,8 @@ func UndirectedCyclesIn(g graph.Undirected) [][]graph.Node {
 	var cycles [][]graph.Node
 	done := make(set.Int64s)
 	var tree linear.NodeStack
-	for _, n := range g.Nodes() {
+	for nodes := g.Nodes(); nodes.Next(); {
+		n := nodes.Node()
 		id := n.ID()
 		if done.Has(id) {
 			continue
@@ -35,7 +36,8 @@ func UndirectedCyclesIn(g graph.Undirected) [][]graph.Node {
 			u := tree.Pop()
 			uid := u.ID()
 			adj := from[uid]
-			for _, v := range g.From(u) {
+			for it := g.From(u); it.Next(); {
+				v := it.Node()
 				vid := v.ID()
 				switch {
 				case uid == vid:
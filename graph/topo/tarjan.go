@@ -94,16 +94,18 @@ func TarjanSCC(g graph.Directed) [][]graph.Node {
 }
 
 func tarjanSCCstabilized(g graph.Directed, order func([]graph.Node)) [][]graph.Node {
-	nodes := g.Nodes()
+	nodes := graph.NodesOf(g.Nodes())
 	var succ func(graph.Node) []graph.Node
 	if order == nil {
-		succ = g.From
+		succ = func(n graph.Node) []graph.Node {
+			return graph.NodesOf(g.From(n))
+		}
 	} else {
 		order(nodes)
 		ordered.Reverse(nodes)
 
 		succ = func(n graph.Node) []graph.Node {
-			to := g.From(n)
+			to := graph.NodesOf(g.From(n))
 			order(to)
 			ordered.Reverse(to)
 			return to
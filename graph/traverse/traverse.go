@@ -40,7 +40,8 @@ func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.N
 		if until != nil && until(t, depth) {
 			return t
 		}
-		for _, n := range g.From(t) {
+		for to := g.From(t); to.Next(); {
+			n := to.Node()
 			if b.EdgeFilter != nil && !b.EdgeFilter(g.Edge(t, n)) {
 				continue
 			}
@@ -70,7 +71,8 @@ func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.N
 // during is called on each node as it is traversed.
 func (b *BreadthFirst) WalkAll(g graph.Undirected, before, after func(), during func(graph.Node)) {
 	b.Reset()
-	for _, from := range g.Nodes() {
+	for nodes := g.Nodes(); nodes.Next(); {
+		from := nodes.Node()
 		if b.Visited(from) {
 			continue
 		}
@@ -125,7 +127,8 @@ func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node)
 		if until != nil && until(t) {
 			return t
 		}
-		for _, n := range g.From(t) {
+		for to := g.From(t); to.Next(); {
+			n := to.Node()
 			if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(t, n)) {
 				continue
 			}
@@ -149,7 +152,8 @@ func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node)
 // during is called on each node as it is traversed.
 func (d *DepthFirst) WalkAll(g graph.Undirected, before, after func(), during func(graph.Node)) {
 	d.Reset()
-	for _, from := range g.Nodes() {
+	for nodes := g.Nodes(); nodes.Next(); {
+		from := nodes.Node()
 		if d.Visited(from) {
 			continue
 		}
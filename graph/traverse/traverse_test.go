@@ -371,7 +371,7 @@ func gnpUndirected(n int, p float64) graph.Undirected {
 }
 
 func benchmarkWalkAllBreadthFirst(b *testing.B, g graph.Undirected) {
-	n := len(g.Nodes())
+	n := g.Nodes().Len()
 	b.ResetTimer()
 	var bft BreadthFirst
 	for i := 0; i < b.N; i++ {
@@ -402,7 +402,7 @@ func BenchmarkWalkAllBreadthFirstGnp_1000_half(b *testing.B) {
 }
 
 func benchmarkWalkAllDepthFirst(b *testing.B, g graph.Undirected) {
-	n := len(g.Nodes())
+	n := g.Nodes().Len()
 	b.ResetTimer()
 	var dft DepthFirst
 	for i := 0; i < b.N; i++ {
@@ -431,3 +431,78 @@ func BenchmarkWalkAllDepthFirstGnp_100_half(b *testing.B) {
 func BenchmarkWalkAllDepthFirstGnp_1000_half(b *testing.B) {
 	benchmarkWalkAllDepthFirst(b, gnpUndirected_1000_half)
 }
+
+var (
+	gnpDirected_10_tenth   = gnpDirected(10, 0.1)
+	gnpDirected_100_tenth  = gnpDirected(100, 0.1)
+	gnpDirected_1000_tenth = gnpDirected(1000, 0.1)
+	gnpDirected_10_half    = gnpDirected(10, 0.5)
+	gnpDirected_100_half   = gnpDirected(100, 0.5)
+	gnpDirected_1000_half  = gnpDirected(1000, 0.5)
+)
+
+func gnpDirected(n int, p float64) graph.Directed {
+	g := simple.NewDirectedGraph()
+	gen.Gnp(g, n, p, nil)
+	return g
+}
+
+func benchmarkWalkBreadthFirst(b *testing.B, g graph.Directed) {
+	var bft BreadthFirst
+	for i := 0; i < b.N; i++ {
+		bft.Reset()
+		bft.Walk(g, simple.Node(0), nil)
+	}
+	if len(bft.visited) == 0 {
+		b.Fatal("unexpected empty walk")
+	}
+}
+
+func BenchmarkWalkBreadthFirstDirectedGnp_10_tenth(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_10_tenth)
+}
+func BenchmarkWalkBreadthFirstDirectedGnp_100_tenth(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_100_tenth)
+}
+func BenchmarkWalkBreadthFirstDirectedGnp_1000_tenth(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_1000_tenth)
+}
+func BenchmarkWalkBreadthFirstDirectedGnp_10_half(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_10_half)
+}
+func BenchmarkWalkBreadthFirstDirectedGnp_100_half(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_100_half)
+}
+func BenchmarkWalkBreadthFirstDirectedGnp_1000_half(b *testing.B) {
+	benchmarkWalkBreadthFirst(b, gnpDirected_1000_half)
+}
+
+func benchmarkWalkDepthFirst(b *testing.B, g graph.Directed) {
+	var dft DepthFirst
+	for i := 0; i < b.N; i++ {
+		dft.Reset()
+		dft.Walk(g, simple.Node(0), nil)
+	}
+	if len(dft.visited) == 0 {
+		b.Fatal("unexpected empty walk")
+	}
+}
+
+func BenchmarkWalkDepthFirstDirectedGnp_10_tenth(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_10_tenth)
+}
+func BenchmarkWalkDepthFirstDirectedGnp_100_tenth(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_100_tenth)
+}
+func BenchmarkWalkDepthFirstDirectedGnp_1000_tenth(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_1000_tenth)
+}
+func BenchmarkWalkDepthFirstDirectedGnp_10_half(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_10_half)
+}
+func BenchmarkWalkDepthFirstDirectedGnp_100_half(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_100_half)
+}
+func BenchmarkWalkDepthFirstDirectedGnp_1000_half(b *testing.B) {
+	benchmarkWalkDepthFirst(b, gnpDirected_1000_half)
+}
@@ -15,25 +15,11 @@ var _ Undirected = Undirect{}
 func (g Undirect) Has(n Node) bool { return g.G.Has(n) }
 
 // Nodes returns all the nodes in the graph.
-func (g Undirect) Nodes() []Node { return g.G.Nodes() }
+func (g Undirect) Nodes() Nodes { return g.G.Nodes() }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g Undirect) From(u Node) []Node {
-	var nodes []Node
-	seen := make(map[int64]struct{})
-	for _, n := range g.G.From(u) {
-		seen[n.ID()] = struct{}{}
-		nodes = append(nodes, n)
-	}
-	for _, n := range g.G.To(u) {
-		id := n.ID()
-		if _, ok := seen[id]; ok {
-			continue
-		}
-		seen[n.ID()] = struct{}{}
-		nodes = append(nodes, n)
-	}
-	return nodes
+func (g Undirect) From(u Node) Nodes {
+	return newNodeUnion(u, g.G, g.G.From(u), g.G.To(u))
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -94,25 +80,11 @@ var (
 func (g UndirectWeighted) Has(n Node) bool { return g.G.Has(n) }
 
 // Nodes returns all the nodes in the graph.
-func (g UndirectWeighted) Nodes() []Node { return g.G.Nodes() }
+func (g UndirectWeighted) Nodes() Nodes { return g.G.Nodes() }
 
 // From returns all nodes in g that can be reached directly from u.
-func (g UndirectWeighted) From(u Node) []Node {
-	var nodes []Node
-	seen := make(map[int64]struct{})
-	for _, n := range g.G.From(u) {
-		seen[n.ID()] = struct{}{}
-		nodes = append(nodes, n)
-	}
-	for _, n := range g.G.To(u) {
-		id := n.ID()
-		if _, ok := seen[id]; ok {
-			continue
-		}
-		seen[n.ID()] = struct{}{}
-		nodes = append(nodes, n)
-	}
-	return nodes
+func (g UndirectWeighted) From(u Node) Nodes {
+	return newNodeUnion(u, g.G, g.G.From(u), g.G.To(u))
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
@@ -222,3 +194,67 @@ type WeightedEdgePair struct {
 
 // Weight returns the merged edge weights of the two edges.
 func (e WeightedEdgePair) Weight() float64 { return e.W }
+
+// nodeUnion is a Nodes iterator over the union of the nodes
+// reachable from and reaching a node u in a directed graph.
+type nodeUnion struct {
+	u Node
+	g interface {
+		HasEdgeFromTo(u, v Node) bool
+	}
+
+	from, to Nodes
+	inTo     bool
+	len, pos int
+	curr     Node
+}
+
+// newNodeUnion returns a nodeUnion for the nodes adjacent to u in g,
+// where from and to are the nodes reachable from u and reaching u.
+func newNodeUnion(u Node, g interface {
+	HasEdgeFromTo(u, v Node) bool
+}, from, to Nodes) *nodeUnion {
+	n := &nodeUnion{u: u, g: g, from: from, to: to, len: from.Len()}
+	for to.Next() {
+		if !g.HasEdgeFromTo(u, to.Node()) {
+			n.len++
+		}
+	}
+	to.Reset()
+	return n
+}
+
+func (n *nodeUnion) Len() int { return n.len - n.pos }
+
+func (n *nodeUnion) Next() bool {
+	if !n.inTo {
+		if n.from.Next() {
+			n.curr = n.from.Node()
+			n.pos++
+			return true
+		}
+		n.inTo = true
+	}
+	for n.to.Next() {
+		v := n.to.Node()
+		if n.g.HasEdgeFromTo(n.u, v) {
+			// v has already been returned from n.from.
+			continue
+		}
+		n.curr = v
+		n.pos++
+		return true
+	}
+	n.curr = nil
+	return false
+}
+
+func (n *nodeUnion) Node() Node { return n.curr }
+
+func (n *nodeUnion) Reset() {
+	n.from.Reset()
+	n.to.Reset()
+	n.inTo = false
+	n.pos = 0
+	n.curr = nil
+}
@@ -121,9 +121,9 @@ func TestUndirect(t *testing.T) {
 		}
 
 		src := graph.Undirect{G: g}
-		dst := simple.NewUndirectedMatrixFrom(src.Nodes(), 0, 0, 0)
-		for _, u := range src.Nodes() {
-			for _, v := range src.From(u) {
+		dst := simple.NewUndirectedMatrixFrom(graph.NodesOf(src.Nodes()), 0, 0, 0)
+		for _, u := range graph.NodesOf(src.Nodes()) {
+			for _, v := range graph.NodesOf(src.From(u)) {
 				dst.SetEdge(src.Edge(u, v))
 			}
 		}
@@ -146,9 +146,9 @@ func TestUndirectWeighted(t *testing.T) {
 		}
 
 		src := graph.UndirectWeighted{G: g, Absent: test.absent, Merge: test.merge}
-		dst := simple.NewUndirectedMatrixFrom(src.Nodes(), 0, 0, 0)
-		for _, u := range src.Nodes() {
-			for _, v := range src.From(u) {
+		dst := simple.NewUndirectedMatrixFrom(graph.NodesOf(src.Nodes()), 0, 0, 0)
+		for _, u := range graph.NodesOf(src.Nodes()) {
+			for _, v := range graph.NodesOf(src.From(u)) {
 				dst.SetWeightedEdge(src.WeightedEdge(u, v))
 			}
 		}
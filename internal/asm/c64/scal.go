@@ -24,6 +24,30 @@ func ScalUnitaryTo(dst []complex64, alpha complex64, x []complex64) {
 	}
 }
 
+// SscalUnitary is
+//  for i, v := range x {
+//  	x[i] = complex(real(v)*alpha, imag(v)*alpha)
+//  }
+func SscalUnitary(alpha float32, x []complex64) {
+	for i, v := range x {
+		x[i] = complex(real(v)*alpha, imag(v)*alpha)
+	}
+}
+
+// SscalInc is
+//  var ix uintptr
+//  for i := 0; i < int(n); i++ {
+//  	x[ix] = complex(real(x[ix])*alpha, imag(x[ix])*alpha)
+//  	ix += inc
+//  }
+func SscalInc(alpha float32, x []complex64, n, inc uintptr) {
+	var ix uintptr
+	for i := 0; i < int(n); i++ {
+		x[ix] = complex(real(x[ix])*alpha, imag(x[ix])*alpha)
+		ix += inc
+	}
+}
+
 // ScalInc is
 //  var ix uintptr
 //  for i := 0; i < int(n); i++ {
@@ -0,0 +1,407 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dbdsdc computes the singular value decomposition of a real n×n bidiagonal
+// matrix B using a divide and conquer method,
+//  B = U * S * V^T
+// where S is a diagonal matrix of singular values, and U and V are orthogonal
+// matrices of left and right singular vectors.
+//
+// The matrix is recursively split into two smaller bidiagonal matrices and a
+// coupling row. The decompositions of the subproblems are merged by solving a
+// secular equation for the singular values, and the singular vectors are
+// computed from a corrected coupling vector so that they are numerically
+// orthogonal. Subproblems of order at most 25 are solved with Dbdsqr.
+//
+// d and e contain the elements of the bidiagonal matrix B. d must have length
+// at least n, and e must have length at least n-1. Dbdsdc will panic if there
+// is insufficient length. On exit, d contains the singular values of B in
+// decreasing order and e is overwritten.
+//
+// On exit, u contains the n×n matrix U and vt contains the n×n matrix V^T.
+//
+// work must have length at least 4*n*n+9*n, and iwork must have length at
+// least 8*n. Dbdsdc will panic if there is insufficient working memory.
+//
+// Dbdsdc returns whether the decomposition was successful.
+//
+// Dbdsdc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dbdsdc(uplo blas.Uplo, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(n, n, vt, ldvt)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(work) < 4*n*n+9*n {
+		panic(badWork)
+	}
+	if len(iwork) < 8*n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+
+	// Scale the matrix so that its largest element is one.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+		return true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+	// If the matrix is lower bidiagonal, rotate it to upper bidiagonal
+	// by applying plane rotations from the left, saving the rotations
+	// to be applied to U.
+	lower := uplo == blas.Lower
+	cs := work[:n]
+	sn := work[n : 2*n]
+	if lower {
+		for i := 0; i < n-1; i++ {
+			c, s, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = s * d[i+1]
+			d[i+1] *= c
+			cs[i] = c
+			sn[i] = s
+		}
+	}
+
+	ok = impl.dbdsdcRec(n, 0, d, e, u, ldu, vt, ldvt, work[2*n:], iwork)
+
+	if lower {
+		bi := blas64.Implementation()
+		for i := n - 2; i >= 0; i-- {
+			bi.Drot(n, u[i*ldu:], 1, u[(i+1)*ldu:], 1, cs[i], -sn[i])
+		}
+	}
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	return ok
+}
+
+// dbdsdcRec computes the singular value decomposition of the n×(n+sqre)
+// upper bidiagonal matrix B with diagonal d and super-diagonal e. On return
+// u contains the n×n left singular vectors, vt contains the (n+sqre)×(n+sqre)
+// right singular vectors and d contains the singular values in decreasing
+// order. If sqre == 1, the last row of vt spans the null space of B.
+func (impl Implementation) dbdsdcRec(n, sqre int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	m := n + sqre
+	smlsiz := impl.Ilaenv(9, "DBDSDC", " ", 0, 0, 0, 0)
+	if n <= smlsiz {
+		bi := blas64.Implementation()
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, m, m, 0, 1, vt, ldvt)
+		if sqre == 1 {
+			// Remove the extra column with rotations from the right,
+			// chasing the fill-in up the last column.
+			f := e[n-1]
+			for i := n - 1; i >= 0; i-- {
+				c, s, r := impl.Dlartg(d[i], f)
+				d[i] = r
+				if i > 0 {
+					f = -s * e[i-1]
+					e[i-1] *= c
+				}
+				bi.Drot(m, vt[i*ldvt:], 1, vt[n*ldvt:], 1, c, s)
+			}
+		}
+		return impl.Dbdsqr(blas.Upper, n, m, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+
+	// Split B at row nl into an nl×(nl+1) upper block, the coupling
+	// row and an nr×(nr+sqre) lower block.
+	nl := n / 2
+	nr := n - nl - 1
+	alpha := d[nl]
+	beta := e[nl]
+	impl.Dlaset(blas.All, n, n, 0, 0, u, ldu)
+	impl.Dlaset(blas.All, m, m, 0, 0, vt, ldvt)
+	if !impl.dbdsdcRec(nl, 1, d, e, u, ldu, vt, ldvt, work, iwork) {
+		return false
+	}
+	if !impl.dbdsdcRec(nr, sqre, d[nl+1:], e[nl+1:], u[(nl+1)*ldu+nl+1:], ldu, vt[(nl+1)*ldvt+nl+1:], ldvt, work, iwork) {
+		return false
+	}
+	u[nl*ldu+nl] = 1
+	impl.dbdsdcMerge(n, sqre, nl, alpha, beta, d, u, ldu, vt, ldvt, work, iwork)
+	return true
+}
+
+// dbdsdcMerge merges the singular value decompositions of the two
+// subproblems of dbdsdcRec. On entry, u and vt contain the block diagonal
+// singular vectors of the subproblems with a one in u at the coupling
+// position, and d contains the subproblem singular values. On exit u, vt and
+// d contain the singular value decomposition of the n×(n+sqre) matrix.
+//
+// In the basis of the subproblem singular vectors, B is an arrow matrix
+//  M = [z_0 z_1 z_2 ... ]
+//      [     d_1        ]
+//      [         d_2    ]
+//      [            ... ]
+// whose singular values are the roots of the secular equation
+//  1 + \sum_j z_j^2 / (d_j^2 - σ^2) = 0,
+// where d_0 = 0.
+func (impl Implementation) dbdsdcMerge(n, sqre, nl int, alpha, beta float64, d, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) {
+	m := n + sqre
+	bi := blas64.Implementation()
+
+	dk := work[:n]
+	zk := work[n : 2*n]
+	pd := work[2*n : 3*n]
+	pz := work[3*n : 4*n]
+	zh := work[4*n : 5*n]
+	tau := work[5*n : 6*n]
+	sig := work[6*n : 7*n]
+	uk := work[7*n : 7*n+n*n]
+	vk := work[7*n+n*n : 7*n+2*n*n]
+	buf1 := work[7*n+2*n*n : 7*n+2*n*n+n*m]
+	buf2 := work[7*n+2*n*n+n*m : 7*n+2*n*n+2*n*m]
+
+	ucol := iwork[:n]
+	vrow := iwork[n : 2*n]
+	keep := iwork[2*n : 3*n]
+	defl := iwork[3*n : 4*n]
+	org := iwork[4*n : 5*n]
+	src := iwork[5*n : 6*n]
+
+	// Scale the merged problem so that its largest element is one.
+	orgnrm := math.Max(math.Abs(alpha), math.Abs(beta))
+	orgnrm = math.Max(orgnrm, math.Max(d[0], d[nl+1]))
+	if orgnrm == 0 {
+		return
+	}
+	alpha /= orgnrm
+	beta /= orgnrm
+	tol := 8 * dlamchE
+
+	// Collect the poles and the coupling vector in increasing order of the
+	// poles. The subproblem singular values are in decreasing order.
+	dk[0] = 0
+	ucol[0] = nl
+	vrow[0] = nl
+	i, j := nl-1, n-1
+	for k := 1; k < n; k++ {
+		if j < nl+1 || (i >= 0 && d[i] <= d[j]) {
+			dk[k] = d[i] / orgnrm
+			zk[k] = alpha * vt[i*ldvt+nl]
+			ucol[k] = i
+			vrow[k] = i
+			i--
+		} else {
+			dk[k] = d[j] / orgnrm
+			zk[k] = beta * vt[j*ldvt+nl+1]
+			ucol[k] = j
+			vrow[k] = j
+			j--
+		}
+	}
+	// Combine the null vectors of the subproblems into a single column.
+	z1 := alpha * vt[nl*ldvt+nl]
+	if sqre == 1 {
+		z2 := beta * vt[(m-1)*ldvt+nl+1]
+		r := impl.Dlapy2(z1, z2)
+		c, s := 1.0, 0.0
+		if r != 0 {
+			c = z1 / r
+			s = z2 / r
+		}
+		bi.Drot(m, vt[nl*ldvt:], 1, vt[(m-1)*ldvt:], 1, c, s)
+		zk[0] = r
+	} else {
+		zk[0] = z1
+	}
+
+	// Deflate. A small coupling entry gives a singular value equal to
+	// the pole, and two close poles are combined by a rotation so that
+	// one of them can be removed from the secular equation.
+	if math.Abs(zk[0]) <= tol {
+		zk[0] = tol
+	}
+	for k := 1; k < n; k++ {
+		if dk[k] < tol {
+			dk[k] = tol
+		}
+	}
+	keep[0] = 0
+	nk := 1
+	var nd int
+	prev := -1
+	for k := 1; k < n; k++ {
+		if math.Abs(zk[k]) <= tol {
+			defl[nd] = k
+			nd++
+			continue
+		}
+		if prev >= 0 && dk[k]-dk[prev] <= tol {
+			r := impl.Dlapy2(zk[prev], zk[k])
+			c := zk[k] / r
+			s := zk[prev] / r
+			zk[k] = r
+			zk[prev] = 0
+			bi.Drot(n, u[ucol[k]:], ldu, u[ucol[prev]:], ldu, c, s)
+			bi.Drot(m, vt[vrow[k]*ldvt:], 1, vt[vrow[prev]*ldvt:], 1, c, s)
+			defl[nd] = prev
+			nd++
+			nk--
+		}
+		keep[nk] = k
+		nk++
+		prev = k
+	}
+	// Sort the deflated poles in increasing order.
+	for k := 1; k < nd; k++ {
+		for l := k; l > 0 && dk[defl[l]] < dk[defl[l-1]]; l-- {
+			defl[l], defl[l-1] = defl[l-1], defl[l]
+		}
+	}
+
+	// Solve the secular equation by bisection. Each root is represented
+	// as an offset tau from its closest pole to retain the accuracy of
+	// the differences between the roots and the poles.
+	for k := 0; k < nk; k++ {
+		pd[k] = dk[keep[k]]
+		pz[k] = zk[keep[k]]
+	}
+	zz := bi.Ddot(nk, pz, 1, pz, 1)
+	for k := 0; k < nk; k++ {
+		o := k
+		var lo, hi float64
+		if k < nk-1 {
+			h := (pd[k+1] - pd[k]) / 2
+			if secular(nk, pd, pz, k, h) >= 0 {
+				hi = h
+			} else {
+				o = k + 1
+				lo = -h
+			}
+		} else {
+			hi = zz / (pd[k] + math.Sqrt(pd[k]*pd[k]+zz))
+		}
+		for {
+			mid := lo + (hi-lo)/2
+			if mid <= lo || mid >= hi {
+				break
+			}
+			if secular(nk, pd, pz, o, mid) < 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		org[k] = o
+		if o == k {
+			tau[k] = hi
+		} else {
+			tau[k] = lo
+		}
+		sig[k] = pd[o] + tau[k]
+	}
+
+	// Recompute the coupling vector from the computed roots so that the
+	// singular vectors are orthogonal to working precision.
+	for j := 0; j < nk; j++ {
+		p := -secularDiff(pd, org, tau, nk-1, j) * secularSum(pd, org, tau, nk-1, j)
+		for k := 0; k < j; k++ {
+			p *= -secularDiff(pd, org, tau, k, j) * secularSum(pd, org, tau, k, j) / ((pd[k] - pd[j]) * (pd[k] + pd[j]))
+		}
+		for k := j; k < nk-1; k++ {
+			p *= -secularDiff(pd, org, tau, k, j) * secularSum(pd, org, tau, k, j) / ((pd[k+1] - pd[j]) * (pd[k+1] + pd[j]))
+		}
+		zh[j] = math.Copysign(math.Sqrt(math.Abs(p)), pz[j])
+	}
+
+	// Compute the singular vectors of the arrow matrix.
+	for k := 0; k < nk; k++ {
+		for j := 0; j < nk; j++ {
+			v := zh[j] / (secularDiff(pd, org, tau, k, j) * secularSum(pd, org, tau, k, j))
+			vk[j*nk+k] = v
+			uk[j*nk+k] = pd[j] * v
+		}
+		uk[k] = -1
+		bi.Dscal(nk, 1/bi.Dnrm2(nk, uk[k:], nk), uk[k:], nk)
+		bi.Dscal(nk, 1/bi.Dnrm2(nk, vk[k:], nk), vk[k:], nk)
+	}
+
+	// Order the singular values decreasingly. src holds the index of the
+	// root if non-negative, and otherwise the deflated pole as -1-k.
+	ir, id := nk-1, nd-1
+	for f := 0; f < n; f++ {
+		if id < 0 || (ir >= 0 && sig[ir] >= dk[defl[id]]) {
+			src[f] = ir
+			d[f] = orgnrm * sig[ir]
+			ir--
+		} else {
+			src[f] = -1 - defl[id]
+			d[f] = orgnrm * dk[defl[id]]
+			id--
+		}
+	}
+
+	// Update the left singular vectors.
+	for k := 0; k < nk; k++ {
+		bi.Dcopy(n, u[ucol[keep[k]]:], ldu, buf1[k:], nk)
+	}
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nk, nk, 1, buf1, nk, uk, nk, 0, buf2, nk)
+	for f := 0; f < n; f++ {
+		if src[f] >= 0 {
+			bi.Dcopy(n, buf2[src[f]:], nk, buf1[f:], n)
+		} else {
+			bi.Dcopy(n, u[ucol[-1-src[f]]:], ldu, buf1[f:], n)
+		}
+	}
+	impl.Dlacpy(blas.All, n, n, buf1, n, u, ldu)
+
+	// Update the right singular vectors.
+	for k := 0; k < nk; k++ {
+		bi.Dcopy(m, vt[vrow[keep[k]]*ldvt:], 1, buf1[k*m:], 1)
+	}
+	bi.Dgemm(blas.Trans, blas.NoTrans, nk, m, nk, 1, vk, nk, buf1, m, 0, buf2, m)
+	for f := 0; f < n; f++ {
+		if src[f] >= 0 {
+			bi.Dcopy(m, buf2[src[f]*m:], 1, buf1[f*m:], 1)
+		} else {
+			bi.Dcopy(m, vt[vrow[-1-src[f]]*ldvt:], 1, buf1[f*m:], 1)
+		}
+	}
+	impl.Dlacpy(blas.All, n, m, buf1, m, vt, ldvt)
+}
+
+// secular evaluates the secular equation
+//  1 + \sum_j z_j^2 / (d_j^2 - σ^2)
+// at σ = d[o] + tau.
+func secular(n int, d, z []float64, o int, tau float64) float64 {
+	f := 1.0
+	for j := 0; j < n; j++ {
+		f += z[j] * z[j] / (((d[j] - d[o]) - tau) * (d[j] + d[o] + tau))
+	}
+	return f
+}
+
+// secularDiff returns d_j - σ_k where σ_k = d[org[k]] + tau[k].
+func secularDiff(d []float64, org []int, tau []float64, k, j int) float64 {
+	return (d[j] - d[org[k]]) - tau[k]
+}
+
+// secularSum returns d_j + σ_k where σ_k = d[org[k]] + tau[k].
+func secularSum(d []float64, org []int, tau []float64, k, j int) float64 {
+	return d[j] + d[org[k]] + tau[k]
+}
@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas/blas64"
+
+// Dgbtf2 computes an LU factorization of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U,
+// where P is a permutation matrix, L is lower triangular with unit diagonal
+// elements and at most kl non-zero elements below the diagonal in each
+// column, and U is upper triangular with kl+ku super-diagonals.
+//
+// The band matrix A is stored in ab in row-major order with the element
+// A[i,j] stored in ab[i*ldab+kl+j-i] for max(0,i-kl) <= j <= min(n-1,i+ku).
+// The trailing kl elements of each row are used as workspace for the fill-in
+// and are overwritten. ldab must be at least 2*kl+ku+1, otherwise Dgbtf2 will
+// panic. The band storage scheme is illustrated below when m = n = 6, kl = 2
+// and ku = 1 (ldab = 6).
+//  On entry:                    On exit:
+//   *   *  a11 a12  +   +        *   *  u11 u12 u13 u14
+//   *  a21 a22 a23  +   +        *  l21 u22 u23 u24 u25
+//  a31 a32 a33 a34  +   +       l31 l32 u33 u34 u35 u36
+//  a42 a43 a44 a45  +   *       l42 l43 u44 u45 u46  *
+//  a53 a54 a55 a56  *   *       l53 l54 u55 u56  *   *
+//  a64 a65 a66  *   *   *       l64 l65 u66  *   *   *
+// Elements marked * are not referenced and elements marked + need not be set
+// on entry, but are required for the fill-in. On exit, the element
+// ab[i*ldab+kl+j-i] for i > j contains the multiplier applied to row i in
+// step j of the elimination.
+//
+// ipiv must have length at least min(m,n), otherwise Dgbtf2 will panic. On
+// return, row i of the matrix was interchanged with row ipiv[i].
+//
+// Dgbtf2 returns whether U is non-singular. The factorization is completed
+// even if U is exactly singular, but division by zero will occur if it is
+// used to solve a system of equations.
+//
+// Dgbtf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Dgbtf2(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	checkGeneralBanded(m, n, kl, ku, ab, ldab)
+	if len(ipiv) < min(m, n) {
+		panic(badIpiv)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	// Zero the fill-in elements.
+	kv := kl + ku
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := kv + 1; j < min(kv+kl+1, kl+n-i); j++ {
+			ab[i*ldab+j] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+	ok = true
+	ju := 0 // Index of the last column affected by the current pivot.
+	for j := 0; j < min(m, n); j++ {
+		km := min(kl, m-j-1)
+
+		// Find the pivot in column j. Elements of a column
+		// of A are ldab-1 elements apart in ab.
+		var jp int
+		if km > 0 {
+			jp = bi.Idamax(km+1, ab[j*ldab+kl:], ldab-1)
+		}
+		ipiv[j] = j + jp
+		if ab[(j+jp)*ldab+kl-jp] == 0 {
+			// U[j,j] is zero so the matrix is singular.
+			ok = false
+			continue
+		}
+		ju = max(ju, min(j+ku+jp, n-1))
+
+		// Apply the interchange to columns j through ju.
+		if jp != 0 {
+			bi.Dswap(ju-j+1, ab[(j+jp)*ldab+kl-jp:], 1, ab[j*ldab+kl:], 1)
+		}
+		if km > 0 {
+			// Compute the multipliers.
+			bi.Dscal(km, 1/ab[j*ldab+kl], ab[(j+1)*ldab+kl-1:], ldab-1)
+
+			// Update the trailing submatrix within the band.
+			if ju > j {
+				bi.Dger(km, ju-j, -1, ab[(j+1)*ldab+kl-1:], ldab-1, ab[j*ldab+kl+1:], 1, ab[(j+1)*ldab+kl:], ldab-1)
+			}
+		}
+	}
+	return ok
+}
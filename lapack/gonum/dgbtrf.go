@@ -0,0 +1,249 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgbtrf computes an LU factorization of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U,
+// where P is a permutation matrix, L is lower triangular with unit diagonal
+// elements and at most kl non-zero elements below the diagonal in each
+// column, and U is upper triangular with kl+ku super-diagonals.
+//
+// The storage of A in ab and of the factors on return is described in the
+// documentation of Dgbtf2. ldab must be at least 2*kl+ku+1 and ipiv must have
+// length at least min(m,n), otherwise Dgbtrf will panic.
+//
+// Dgbtrf returns whether U is non-singular. The factorization is completed
+// even if U is exactly singular, but division by zero will occur if it is
+// used to solve a system of equations.
+//
+// Dgbtrf is the blocked version of the algorithm, see Dgbtf2 for the unblocked
+// version.
+func (impl Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	checkGeneralBanded(m, n, kl, ku, ab, ldab)
+	if len(ipiv) < min(m, n) {
+		panic(badIpiv)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	// Determine the block size and use the unblocked code if the
+	// block is wider than the number of sub-diagonals.
+	const nbmax = 64
+	nb := min(impl.Ilaenv(1, "DGBTRF", " ", m, n, kl, ku), nbmax)
+	if nb <= 1 || nb > kl {
+		return impl.Dgbtf2(m, n, kl, ku, ab, ldab, ipiv)
+	}
+
+	// Zero the fill-in elements.
+	kv := kl + ku
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := kv + 1; j < min(kv+kl+1, kl+n-i); j++ {
+			ab[i*ldab+j] = 0
+		}
+	}
+
+	// The element A[i,j] is stored in ab[i*ldab+kl+j-i] so any
+	// submatrix of A within the band is a general row-major matrix
+	// with stride ldab-1.
+	bi := blas64.Implementation()
+	kld := ldab - 1
+
+	// The parts of the blocks A13 and A31 below that lie outside the
+	// band are held in the work arrays work13 and work31. Only the
+	// lower triangle of A13 and the upper triangle of A31 are in the
+	// band, so the remaining elements of the work arrays are zero.
+	ldwork := nb
+	work13 := make([]float64, nb*ldwork)
+	work31 := make([]float64, nb*ldwork)
+
+	ok = true
+	ju := 0 // Index of the last column affected by the current stage.
+	for j := 0; j < min(m, n); j += nb {
+		jb := min(nb, min(m, n)-j)
+
+		// The active part of the matrix is partitioned as
+		//  A11 A12 A13
+		//  A21 A22 A23
+		//  A31 A32 A33
+		// where A11, A21 and A31 are the current block of jb columns
+		// to be factorized. The numbers of rows in the partitioning
+		// are jb, i2 and i3, and the numbers of columns are jb, j2
+		// and j3. The superdiagonal elements of A13 and the
+		// subdiagonal elements of A31 lie outside the band.
+		i2 := min(kl-jb, m-j-jb)
+		i3 := min(jb, m-j-kl)
+
+		// Factorize the current block of jb columns.
+		for jj := j; jj < j+jb; jj++ {
+			km := min(kl, m-jj-1)
+
+			// Find the pivot in column jj, relative to the
+			// first row of the block.
+			jp := bi.Idamax(km+1, ab[jj*ldab+kl:], kld)
+			ipiv[jj] = jp + jj - j
+			if ab[(jj+jp)*ldab+kl-jp] != 0 {
+				ju = max(ju, min(jj+ku+jp, n-1))
+				if jp != 0 {
+					// Apply the interchange to columns j to j+jb-1.
+					if jp+jj < j+kl {
+						bi.Dswap(jb, ab[jj*ldab+kl+j-jj:], 1, ab[(jj+jp)*ldab+kl+j-jj-jp:], 1)
+					} else {
+						// The interchange affects columns j to jj-1
+						// of A31 which are held in work31.
+						bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, work31[(jp+jj-j-kl)*ldwork:], 1)
+						bi.Dswap(j+jb-jj, ab[jj*ldab+kl:], 1, ab[(jj+jp)*ldab+kl-jp:], 1)
+					}
+				}
+
+				if km > 0 {
+					// Compute the multipliers.
+					bi.Dscal(km, 1/ab[jj*ldab+kl], ab[(jj+1)*ldab+kl-1:], kld)
+
+					// Update the trailing submatrix within the band
+					// and within the current block. jm is the index
+					// of the last column which needs to be updated.
+					jm := min(ju, j+jb-1)
+					if jm > jj {
+						bi.Dger(km, jm-jj, -1, ab[(jj+1)*ldab+kl-1:], kld,
+							ab[jj*ldab+kl+1:], 1,
+							ab[(jj+1)*ldab+kl:], kld)
+					}
+				}
+			} else {
+				// U[jj,jj] is zero so the matrix is singular.
+				ok = false
+			}
+
+			// Copy the current column of A31 into work31.
+			nw := min(jj-j+1, i3)
+			if nw > 0 {
+				bi.Dcopy(nw, ab[(j+kl)*ldab+jj-j:], kld, work31[jj-j:], ldwork)
+			}
+		}
+
+		if j+jb < n {
+			// Apply the row interchanges to the other blocks.
+			j2 := min(ju-j+1, kv) - jb
+			j3 := max(0, ju-j-kv+1)
+
+			// Apply the row interchanges to A12, A22 and A32.
+			if j2 > 0 {
+				impl.Dlaswp(j2, ab[j*ldab+kl+jb:], kld, 0, jb-1, ipiv[j:j+jb], 1)
+			}
+
+			// Adjust the pivot indices.
+			for i := j; i < j+jb; i++ {
+				ipiv[i] += j
+			}
+
+			// Apply the row interchanges to A13, A23 and A33
+			// columnwise.
+			k2 := j + jb + j2
+			for i := 0; i < j3; i++ {
+				jj := k2 + i
+				for ii := j + i; ii < j+jb; ii++ {
+					ip := ipiv[ii]
+					if ip != ii {
+						ab[ii*ldab+kl+jj-ii], ab[ip*ldab+kl+jj-ip] = ab[ip*ldab+kl+jj-ip], ab[ii*ldab+kl+jj-ii]
+					}
+				}
+			}
+
+			// Update the relevant part of the trailing submatrix.
+			if j2 > 0 {
+				// Update A12.
+				bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit, jb, j2,
+					1, ab[j*ldab+kl:], kld,
+					ab[j*ldab+kl+jb:], kld)
+				if i2 > 0 {
+					// Update A22.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i2, j2, jb,
+						-1, ab[(j+jb)*ldab+kl-jb:], kld,
+						ab[j*ldab+kl+jb:], kld,
+						1, ab[(j+jb)*ldab+kl:], kld)
+				}
+				if i3 > 0 {
+					// Update A32.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i3, j2, jb,
+						-1, work31, ldwork,
+						ab[j*ldab+kl+jb:], kld,
+						1, ab[(j+kl)*ldab+jb:], kld)
+				}
+			}
+
+			if j3 > 0 {
+				// Copy the lower triangle of A13 into work13.
+				for ii := 0; ii < jb; ii++ {
+					for jj := 0; jj <= min(ii, j3-1); jj++ {
+						work13[ii*ldwork+jj] = ab[(j+ii)*ldab+kl+kv+jj-ii]
+					}
+				}
+
+				// Update A13 in the work array.
+				bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit, jb, j3,
+					1, ab[j*ldab+kl:], kld,
+					work13, ldwork)
+				if i2 > 0 {
+					// Update A23.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i2, j3, jb,
+						-1, ab[(j+jb)*ldab+kl-jb:], kld,
+						work13, ldwork,
+						1, ab[(j+jb)*ldab+kl+kv-jb:], kld)
+				}
+				if i3 > 0 {
+					// Update A33.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i3, j3, jb,
+						-1, work31, ldwork,
+						work13, ldwork,
+						1, ab[(j+kl)*ldab+kv:], kld)
+				}
+
+				// Copy the lower triangle of A13 back into place.
+				for ii := 0; ii < jb; ii++ {
+					for jj := 0; jj <= min(ii, j3-1); jj++ {
+						ab[(j+ii)*ldab+kl+kv+jj-ii] = work13[ii*ldwork+jj]
+					}
+				}
+			}
+		} else {
+			// Adjust the pivot indices.
+			for i := j; i < j+jb; i++ {
+				ipiv[i] += j
+			}
+		}
+
+		// Partially undo the interchanges in the current block to
+		// restore the upper triangular form of A31 and copy the
+		// upper triangle of A31 back into place.
+		for jj := j + jb - 1; jj >= j; jj-- {
+			jp := ipiv[jj] - jj
+			if jp != 0 {
+				// Apply the interchange to columns j to jj-1.
+				if jp+jj < j+kl {
+					// The interchange does not affect A31.
+					bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, ab[(jj+jp)*ldab+kl+j-jj-jp:], 1)
+				} else {
+					// The interchange does affect A31.
+					bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, work31[(jp+jj-j-kl)*ldwork:], 1)
+				}
+			}
+
+			// Copy the current column of A31 back into place.
+			nw := min(i3, jj-j+1)
+			if nw > 0 {
+				bi.Dcopy(nw, work31[jj-j:], ldwork, ab[(j+kl)*ldab+jj-j:], kld)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,70 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgbtrs solves a system of linear equations
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans
+// with an n×n band matrix A with kl sub-diagonals and ku super-diagonals,
+// using the LU factorization computed by Dgbtrf.
+//
+// ab and ipiv contain the factorization and the details of the interchanges
+// as returned by Dgbtrf. ldab must be at least 2*kl+ku+1.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, and on return it
+// is overwritten by the solution matrix X.
+func (impl Implementation) Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) {
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTrans)
+	}
+	checkGeneralBanded(n, n, kl, ku, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	kd := kl + ku // Number of super-diagonals of U.
+	if trans == blas.NoTrans {
+		// Solve L * X = B, where L = P_0 * L_0 * ... * P_{n-2} * L_{n-2}.
+		if kl > 0 {
+			for j := 0; j < n-1; j++ {
+				lm := min(kl, n-j-1)
+				if l := ipiv[j]; l != j {
+					bi.Dswap(nrhs, b[l*ldb:], 1, b[j*ldb:], 1)
+				}
+				bi.Dger(lm, nrhs, -1, ab[(j+1)*ldab+kl-1:], ldab-1, b[j*ldb:], 1, b[(j+1)*ldb:], ldb)
+			}
+		}
+		// Solve U * X = B.
+		for k := 0; k < nrhs; k++ {
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kd, ab[kl:], ldab, b[k:], ldb)
+		}
+		return
+	}
+
+	// Solve U^T * X = B.
+	for k := 0; k < nrhs; k++ {
+		bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kd, ab[kl:], ldab, b[k:], ldb)
+	}
+	// Solve L^T * X = B.
+	if kl > 0 {
+		for j := n - 2; j >= 0; j-- {
+			lm := min(kl, n-j-1)
+			bi.Dgemv(blas.Trans, lm, nrhs, -1, b[(j+1)*ldb:], ldb, ab[(j+1)*ldab+kl-1:], ldab-1, 1, b[j*ldb:], 1)
+			if l := ipiv[j]; l != j {
+				bi.Dswap(nrhs, b[l*ldb:], 1, b[j*ldb:], 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgerfs improves the computed solution to a system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// by iterative refinement and provides error bounds and backward error
+// estimates for the solution.
+//
+// a contains the original n×n matrix A, and af and ipiv contain its LU
+// factorization as computed by Dgetrf. b contains the n×nrhs right hand side
+// matrix B. On entry, x contains the solution matrix X as computed by Dgetrs,
+// on return it contains the improved solution.
+//
+// On return, ferr[j] contains an estimated bound on the relative error
+//  max_i |X_ij - Xtrue_ij| / max_i |X_ij|
+// of the j-th column of the solution and berr[j] contains the componentwise
+// relative backward error, the smallest relative change in any element of A
+// or B that makes the j-th column of X an exact solution. ferr and berr must
+// have length at least nrhs.
+//
+// work must have length at least 3*n and iwork must have length at least n,
+// otherwise Dgerfs will panic.
+func (impl Implementation) Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTrans)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(ferr) < nrhs || len(berr) < nrhs {
+		panic(badSlice)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 || nrhs == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+
+	const itmax = 5
+	transt := blas.Trans
+	if trans == blas.Trans {
+		transt = blas.NoTrans
+	}
+	notran := trans == blas.NoTrans
+
+	// nz is the maximum number of nonzero elements in each row of A,
+	// plus one.
+	nz := float64(n + 1)
+	eps := dlamchE
+	safmin := dlamchS
+	safe1 := nz * safmin
+	safe2 := safe1 / eps
+
+	bi := blas64.Implementation()
+	abs := work[:n]
+	res := work[n : 2*n]
+	v := work[2*n : 3*n]
+	isave := new([3]int)
+	for j := 0; j < nrhs; j++ {
+		lstres := 3.0
+		for count := 1; ; count++ {
+			// Compute the residual R = B - op(A) * X.
+			bi.Dcopy(n, b[j:], ldb, res, 1)
+			bi.Dgemv(trans, n, n, -1, a, lda, x[j:], ldx, 1, res, 1)
+
+			// Compute |B| + |op(A)|*|X| for the componentwise
+			// relative backward error.
+			for i := 0; i < n; i++ {
+				abs[i] = math.Abs(b[i*ldb+j])
+			}
+			if notran {
+				for i := 0; i < n; i++ {
+					var s float64
+					for k := 0; k < n; k++ {
+						s += math.Abs(a[i*lda+k]) * math.Abs(x[k*ldx+j])
+					}
+					abs[i] += s
+				}
+			} else {
+				for k := 0; k < n; k++ {
+					xk := math.Abs(x[k*ldx+j])
+					for i := 0; i < n; i++ {
+						abs[i] += math.Abs(a[k*lda+i]) * xk
+					}
+				}
+			}
+			var s float64
+			for i := 0; i < n; i++ {
+				if abs[i] > safe2 {
+					s = math.Max(s, math.Abs(res[i])/abs[i])
+				} else {
+					s = math.Max(s, (math.Abs(res[i])+safe1)/(abs[i]+safe1))
+				}
+			}
+			berr[j] = s
+
+			// Stop if the backward error is at the level of machine
+			// precision, if it did not decrease by at least a factor
+			// of two or if the maximum number of iterations has been
+			// reached.
+			if berr[j] <= eps || 2*berr[j] > lstres || count > itmax {
+				break
+			}
+			// Update the solution and try again.
+			impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, res, 1)
+			bi.Daxpy(n, 1, res, 1, x[j:], ldx)
+			lstres = berr[j]
+		}
+
+		// Bound the error using
+		//  |X - Xtrue| / |X| <= || |inv(op(A))| * (|R| + nz*eps*(|op(A)|*|X|+|B|)) || / |X|
+		// where the norm is estimated by Dlacn2.
+		for i := 0; i < n; i++ {
+			if abs[i] > safe2 {
+				abs[i] = math.Abs(res[i]) + nz*eps*abs[i]
+			} else {
+				abs[i] = math.Abs(res[i]) + nz*eps*abs[i] + safe1
+			}
+		}
+		var est float64
+		var kase int
+		*isave = [3]int{}
+		for {
+			est, kase = impl.Dlacn2(n, v, res, iwork, est, kase, isave)
+			if kase == 0 {
+				break
+			}
+			if kase == 1 {
+				// Multiply by diag(abs)*inv(op(A))^T.
+				impl.Dgetrs(transt, n, 1, af, ldaf, ipiv, res, 1)
+				for i := 0; i < n; i++ {
+					res[i] *= abs[i]
+				}
+			} else {
+				// Multiply by inv(op(A))*diag(abs).
+				for i := 0; i < n; i++ {
+					res[i] *= abs[i]
+				}
+				impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, res, 1)
+			}
+		}
+		ferr[j] = est
+
+		// Normalize the error.
+		var xnrm float64
+		for i := 0; i < n; i++ {
+			xnrm = math.Max(xnrm, math.Abs(x[i*ldx+j]))
+		}
+		if xnrm != 0 {
+			ferr[j] /= xnrm
+		}
+	}
+}
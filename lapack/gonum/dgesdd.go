@@ -0,0 +1,214 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesdd computes the singular value decomposition of the input matrix A using
+// a divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// A is first reduced to bidiagonal form by Dgebrd. If singular vectors are
+// requested, the bidiagonal matrix is decomposed by Dbdsdc, which is
+// significantly faster than the QR iteration used by Dgesvd for large
+// matrices. If only singular values are requested, Dbdsqr is used.
+//
+// jobz specifies the singular vectors to compute:
+//  jobz == lapack.SVDAll       All m columns of U are returned in u and all
+//                              n rows of V^T are returned in vt.
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U are returned in
+//                              u and the first min(m,n) rows of V^T are
+//                              returned in vt.
+//  jobz == lapack.SVDOverwrite If m >= n, the first n columns of U are written
+//                              into a and all rows of V^T are returned in vt.
+//                              Otherwise, all columns of U are returned in u
+//                              and the first m rows of V^T are written into a.
+//  jobz == lapack.SVDNone      No singular vectors are computed.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Dgesdd
+// the data is overwritten. On exit, A contains the appropriate singular vectors
+// if jobz is lapack.SVDOverwrite.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll, or jobz == lapack.SVDOverwrite and m < n, u is of
+// size m×m. If jobz == lapack.SVDInPlace, u is of size m×min(m,n). Otherwise
+// u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll, or jobz == lapack.SVDOverwrite and m >= n, vt is of
+// size n×n. If jobz == lapack.SVDInPlace, vt is of size min(m,n)×n. Otherwise
+// vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. Let mn = min(m,n) and mx = max(m,n). If jobz == lapack.SVDNone,
+// lwork must be at least 3*mn+max(mx,4*mn). Otherwise lwork must be at least
+// 3*mn+2*mn*mn+max(mx,4*mn*mn+9*mn), with an additional m*n if
+// jobz == lapack.SVDOverwrite. If lwork == -1, instead of
+// performing Dgesdd, the optimal work length will be stored into work[0].
+// iwork must have length at least 8*min(m,n). Dgesdd will panic if the working
+// memory has insufficient storage.
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	checkMatrix(m, n, a, lda)
+	minmn := min(m, n)
+	mx := max(m, n)
+	wntqa := jobz == lapack.SVDAll
+	wntqs := jobz == lapack.SVDInPlace
+	wntqo := jobz == lapack.SVDOverwrite
+	wntqn := jobz == lapack.SVDNone
+	if !wntqa && !wntqs && !wntqo && !wntqn {
+		panic(badSVDJob)
+	}
+
+	// The number of columns of U and rows of V^T to compute, and the
+	// matrices holding them.
+	ncu, nrvt := minmn, minmn
+	if wntqa {
+		ncu, nrvt = m, n
+	}
+	cu, ldcu := u, ldu
+	cvt, ldcvt := vt, ldvt
+	switch {
+	case wntqa, wntqs:
+		checkMatrix(m, ncu, u, ldu)
+		checkMatrix(nrvt, n, vt, ldvt)
+	case wntqo && m >= n:
+		cu, ldcu = a, lda
+		checkMatrix(n, n, vt, ldvt)
+	case wntqo:
+		cvt, ldcvt = a, lda
+		checkMatrix(m, m, u, ldu)
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+
+	// Compute the workspace.
+	ie := 0
+	itauq := ie + minmn
+	itaup := itauq + minmn
+	nwork := itaup + minmn
+	iu := nwork
+	ivt := iu + minmn*minmn
+	iov := ivt + minmn*minmn
+	nwork2 := iov
+	if wntqo {
+		nwork2 += m * n
+	}
+	var minwrk, maxwrk int
+	if wntqn {
+		minwrk = nwork + max(mx, 4*minmn)
+	} else {
+		minwrk = nwork2 + max(mx, 4*minmn*minmn+9*minmn)
+	}
+	maxwrk = max(1, minwrk)
+	if m > 0 && n > 0 {
+		impl.Dgebrd(m, n, a, lda, nil, nil, nil, nil, work, -1)
+		maxwrk = max(maxwrk, nwork+int(work[0]))
+		if !wntqn {
+			impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncu, n, a, lda, s, cu, ldcu, work, -1)
+			maxwrk = max(maxwrk, nwork2+int(work[0]))
+			impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, nrvt, n, m, a, lda, s, cvt, ldcvt, work, -1)
+			maxwrk = max(maxwrk, nwork2+int(work[0]))
+		}
+	}
+	work[0] = float64(maxwrk)
+	if lwork == -1 {
+		return true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwrk {
+		panic(badWork)
+	}
+	if len(iwork) < 8*minmn {
+		panic(badWork)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	eps := dlamchE
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+	}
+
+	// Reduce A to bidiagonal form. B is upper bidiagonal if m >= n and
+	// lower bidiagonal otherwise.
+	impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+	uplo := blas.Upper
+	if m < n {
+		uplo = blas.Lower
+	}
+
+	if wntqn {
+		ok = impl.Dbdsqr(uplo, minmn, 0, 0, 0, s, work[ie:], nil, 1, nil, 1, nil, 1, work[nwork:])
+	} else {
+		// Compute the singular value decomposition of B.
+		ok = impl.Dbdsdc(uplo, minmn, s, work[ie:], work[iu:], minmn, work[ivt:], minmn, work[nwork2:], iwork)
+
+		// Overwritten singular vectors are formed in work before being
+		// copied into a, since a holds the Householder reflectors.
+		if wntqo {
+			if m >= n {
+				cu, ldcu = work[iov:], n
+			} else {
+				cvt, ldcvt = work[iov:], n
+			}
+		}
+
+		// Form U = Q * [U_B 0; 0 I].
+		impl.Dlaset(blas.All, m, ncu, 0, 1, cu, ldcu)
+		impl.Dlacpy(blas.All, minmn, minmn, work[iu:], minmn, cu, ldcu)
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncu, n, a, lda, work[itauq:itauq+minmn], cu, ldcu, work[nwork2:], lwork-nwork2)
+
+		// Form V^T = [V_B^T 0; 0 I] * P^T.
+		impl.Dlaset(blas.All, nrvt, n, 0, 1, cvt, ldcvt)
+		impl.Dlacpy(blas.All, minmn, minmn, work[ivt:], minmn, cvt, ldcvt)
+		impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, nrvt, n, m, a, lda, work[itaup:itaup+minmn], cvt, ldcvt, work[nwork2:], lwork-nwork2)
+
+		if wntqo {
+			impl.Dlacpy(blas.All, m, n, work[iov:], n, a, lda)
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, 1)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, 1)
+		}
+	}
+	work[0] = float64(maxwrk)
+	return ok
+}
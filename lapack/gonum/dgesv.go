@@ -0,0 +1,40 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dgesv computes the solution to the system of linear equations
+//  A * X = B
+// where A is an n×n matrix and X and B are n×nrhs matrices.
+//
+// The LU decomposition with partial pivoting and row interchanges is used to
+// factor A as
+//  A = P * L * U
+// where P is a permutation matrix, L is unit lower triangular, and U is upper
+// triangular. On return, the factors L and U are stored in a; the unit
+// diagonal elements of L are not stored. The row pivot indices that define
+// the permutation matrix P are stored in ipiv.
+//
+// On entry, b contains the right hand side matrix B. On return, if ok is true,
+// b contains the solution matrix X.
+//
+// Dgesv returns whether A is nonsingular. If ok is false, U is exactly
+// singular and the solution has not been computed.
+func (impl Implementation) Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool) {
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ok = impl.Dgetrf(n, n, a, lda, ipiv)
+	if ok {
+		impl.Dgetrs(blas.NoTrans, n, nrhs, a, lda, ipiv, b, ldb)
+	}
+	return ok
+}
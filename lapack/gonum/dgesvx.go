@@ -0,0 +1,221 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesvx uses the LU factorization to compute the solution to the system of
+// linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// where A is an n×n matrix and X and B are n×nrhs matrices. Error bounds on
+// the solution and a condition estimate are also provided.
+//
+// fact specifies how the factorization of A is obtained:
+//  fact == lapack.Factored          af and ipiv contain the LU factorization
+//                                   of A as computed by Dgetrf. If equed is
+//                                   not lapack.NoEquilibration, A has been
+//                                   equilibrated with the scale factors in r
+//                                   and c.
+//  fact == lapack.FactorNew         A is copied into af and factorized.
+//  fact == lapack.FactorEquilibrate A is equilibrated if necessary, then copied
+//                                   into af and factorized.
+// When A is equilibrated it is overwritten by diag(r)*A*diag(c) and B is
+// scaled accordingly, so that the equilibrated system is solved. equed
+// is only used on entry if fact == lapack.Factored. The equilibration that was
+// applied is returned in equedOut.
+//
+// r and c must have length at least n. ipiv must have length at least n. On
+// entry, b contains the right hand side matrix B, which is overwritten if
+// equilibration is applied. On return, x contains the solution matrix X of the
+// original system.
+//
+// On return, ferr and berr contain the forward error bounds and backward
+// errors of each column of the solution as described in Dgerfs. They must have
+// length at least nrhs. rcond is the estimate of the reciprocal condition
+// number of the equilibrated A and rpvgrw is the reciprocal pivot growth
+// factor max|A|/max|U|. A small value of rpvgrw indicates that the LU
+// factorization, and so the solution, may be unstable.
+//
+// work must have length at least 4*n and iwork must have length at least n,
+// otherwise Dgesvx will panic.
+//
+// Dgesvx returns whether A is nonsingular. If ok is false, U is exactly
+// singular, rcond is zero and the solution has not been computed. If ok is
+// true but rcond is less than machine precision, the matrix is singular to
+// working precision and the solution may be inaccurate.
+func (impl Implementation) Dgesvx(fact lapack.FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	nofact := fact == lapack.FactorNew
+	equil := fact == lapack.FactorEquilibrate
+	if !nofact && !equil && fact != lapack.Factored {
+		panic(badFact)
+	}
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTrans)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(r) < n || len(c) < n {
+		panic(badSlice)
+	}
+	if len(ferr) < nrhs || len(berr) < nrhs {
+		panic(badSlice)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	var rowequ, colequ bool
+	if nofact || equil {
+		equed = lapack.NoEquilibration
+	} else {
+		switch equed {
+		default:
+			panic(badEquilType)
+		case lapack.NoEquilibration:
+		case lapack.RowEquilibration:
+			rowequ = true
+		case lapack.ColEquilibration:
+			colequ = true
+		case lapack.BothEquilibration:
+			rowequ = true
+			colequ = true
+		}
+	}
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return equed, 1, 1, true
+	}
+
+	// Compute the condition of the scale factors supplied with a
+	// factorization.
+	rowcnd, colcnd := 1.0, 1.0
+	if rowequ {
+		rowcnd = scaleCondition(r[:n])
+	}
+	if colequ {
+		colcnd = scaleCondition(c[:n])
+	}
+
+	if equil {
+		// Compute the row and column scalings to equilibrate A and
+		// apply them if they are worthwhile.
+		var amax float64
+		var eqok bool
+		rowcnd, colcnd, amax, eqok = impl.Dgeequ(n, n, a, lda, r, c)
+		if eqok {
+			equed = impl.Dlaqge(n, n, a, lda, r, c, rowcnd, colcnd, amax)
+			rowequ = equed == lapack.RowEquilibration || equed == lapack.BothEquilibration
+			colequ = equed == lapack.ColEquilibration || equed == lapack.BothEquilibration
+		}
+	}
+
+	// Scale the right hand side.
+	if trans == blas.NoTrans && rowequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= r[i]
+			}
+		}
+	} else if trans == blas.Trans && colequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= c[i]
+			}
+		}
+	}
+
+	if nofact || equil {
+		// Compute the LU factorization of A.
+		impl.Dlacpy(blas.All, n, n, a, lda, af, ldaf)
+		ok = impl.Dgetrf(n, n, af, ldaf, ipiv)
+	} else {
+		ok = true
+		for i := 0; i < n; i++ {
+			if af[i*ldaf+i] == 0 {
+				ok = false
+				break
+			}
+		}
+	}
+
+	// Compute the reciprocal pivot growth factor.
+	rpvgrw = impl.Dlantr(lapack.MaxAbs, blas.Upper, blas.NonUnit, n, n, af, ldaf, nil)
+	if rpvgrw == 0 {
+		rpvgrw = 1
+	} else {
+		rpvgrw = impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil) / rpvgrw
+	}
+	if !ok {
+		return equed, 0, rpvgrw, false
+	}
+
+	// Estimate the reciprocal of the condition number of A.
+	norm := lapack.MaxColumnSum
+	if trans == blas.Trans {
+		norm = lapack.MaxRowSum
+	}
+	anorm := impl.Dlange(norm, n, n, a, lda, work)
+	rcond = impl.Dgecon(norm, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution and improve it by iterative refinement.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dgetrs(trans, n, nrhs, af, ldaf, ipiv, x, ldx)
+	impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution to that of the original system.
+	if trans == blas.NoTrans && colequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= c[i]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= colcnd
+		}
+	} else if trans == blas.Trans && rowequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= r[i]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= rowcnd
+		}
+	}
+	return equed, rcond, rpvgrw, true
+}
+
+// scaleCondition returns the ratio of the smallest to the largest of the
+// scale factors in s.
+func scaleCondition(s []float64) float64 {
+	smin := math.Inf(1)
+	var smax float64
+	for _, v := range s {
+		smin = math.Min(smin, v)
+		smax = math.Max(smax, v)
+	}
+	if smin <= 0 {
+		panic(badScaleFactor)
+	}
+	smlnum := dlamchS
+	return math.Max(smin, smlnum) / math.Min(smax, 1/smlnum)
+}
@@ -0,0 +1,278 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// A generalized eigenvalue of (A,B) is a scalar λ or a ratio α/β = λ, such
+// that A - λ*B is singular. It is usually represented as the pair (α,β), as
+// there is a reasonable interpretation for β == 0, and even for both being
+// zero.
+//
+// The right generalized eigenvector v_j of (A,B) corresponding to the
+// generalized eigenvalue λ_j is defined by
+//  A v_j = λ_j B v_j,
+// and the left generalized eigenvector u_j corresponding to λ_j is defined by
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR
+// in the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that its
+// largest component has |real part| + |imag part| = 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Dggev will panic.
+//
+// On return, the generalized eigenvalues are
+//  λ_j = (alphar[j] + i*alphai[j]) / beta[j].
+// If alphai[j] is zero, then the j-th eigenvalue is real; if positive, then
+// the j-th and (j+1)-st eigenvalues are a complex conjugate pair, with
+// alphai[j+1] negative. beta[j] will be non-negative and is zero for infinite
+// eigenvalues. The quotients alphar[j]/beta[j] and alphai[j]/beta[j] may
+// easily over- or underflow, and beta[j] may even be zero, so the ratio
+// should not be computed naively. alphar, alphai and beta must have length n,
+// otherwise Dggev will panic.
+//
+// Unlike the reference implementation, Dggev does not balance the matrix
+// pair before reducing it to generalized Hessenberg form.
+//
+// work must have length at least lwork and lwork must be at least
+// max(1,8*n), otherwise Dggev will panic. For good performance, lwork must
+// generally be larger. On return, optimal value of lwork will be stored in
+// work[0].
+//
+// If lwork == -1, instead of performing Dggev, the function only calculates
+// the optimal value of lwork and stores it into work[0].
+//
+// Dggev returns whether the computation was successful. If ok is false, the
+// QZ iteration failed or the eigenvectors could not be computed and the
+// contents of alphar, alphai, beta, VL and VR are unspecified.
+func (impl Implementation) Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	minwrk := max(1, 8*n)
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		checkMatrix(n, n, b, ldb)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(alphar) != n:
+			panic("lapack: bad length of alphar")
+		case len(alphai) != n:
+			panic("lapack: bad length of alphai")
+		case len(beta) != n:
+			panic(badBeta)
+		case lwork < minwrk:
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	impl.Dgeqrf(n, n, nil, max(1, n), nil, work, -1)
+	maxwrk := max(minwrk, n+int(work[0]))
+	impl.Dormqr(blas.Left, blas.Trans, n, n, n, nil, max(1, n), nil, nil, max(1, n), work, -1)
+	maxwrk = max(maxwrk, n+int(work[0]))
+	if wantvl {
+		impl.Dorgqr(n, n, n, nil, max(1, n), nil, work, -1)
+		maxwrk = max(maxwrk, n+int(work[0]))
+	}
+
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+
+	// Get machine constants.
+	eps := dlamchP
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+
+	// Scale A if its max element is outside [smlnum,bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil)
+	var anrmto float64
+	scalea := false
+	if 0 < anrm && anrm < smlnum {
+		anrmto = smlnum
+		scalea = true
+	} else if anrm > bignum {
+		anrmto = bignum
+		scalea = true
+	}
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrm, anrmto, n, n, a, lda)
+	}
+
+	// Scale B if its max element is outside [smlnum,bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, n, n, b, ldb, nil)
+	var bnrmto float64
+	scaleb := false
+	if 0 < bnrm && bnrm < smlnum {
+		bnrmto = smlnum
+		scaleb = true
+	} else if bnrm > bignum {
+		bnrmto = bignum
+		scaleb = true
+	}
+	if scaleb {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bnrmto, n, n, b, ldb)
+	}
+
+	// Reduce B to triangular form and apply the orthogonal transformation
+	// to A.
+	tau := work[:n]
+	impl.Dgeqrf(n, n, b, ldb, tau, work[n:], lwork-n)
+	impl.Dormqr(blas.Left, blas.Trans, n, n, n, b, ldb, tau, a, lda, work[n:], lwork-n)
+
+	// Initialize VL and VR.
+	var compq lapack.EVComp = lapack.None
+	if wantvl {
+		compq = lapack.OriginalEV
+		impl.Dlaset(blas.All, n, n, 0, 1, vl, ldvl)
+		if n > 1 {
+			impl.Dlacpy(blas.Lower, n-1, n-1, b[ldb:], ldb, vl[ldvl:], ldvl)
+		}
+		impl.Dorgqr(n, n, n, vl, ldvl, tau, work[n:], lwork-n)
+	}
+	var compz lapack.EVComp = lapack.None
+	if wantvr {
+		compz = lapack.OriginalEV
+		impl.Dlaset(blas.All, n, n, 0, 1, vr, ldvr)
+	}
+
+	// Reduce to generalized Hessenberg form.
+	impl.Dgghrd(compq, compz, n, 0, n-1, a, lda, b, ldb, vl, ldvl, vr, ldvr)
+
+	// Perform the QZ algorithm, computing the Schur vectors if desired.
+	job := lapack.EigenvaluesOnly
+	if wantvl || wantvr {
+		job = lapack.EigenvaluesAndSchur
+	}
+	unconverged := impl.Dhgeqz(job, compq, compz, n, 0, n-1, a, lda, b, ldb,
+		alphar, alphai, beta, vl, ldvl, vr, ldvr, work, lwork)
+	ok = unconverged == 0
+
+	// Compute the eigenvectors.
+	if ok && (wantvl || wantvr) {
+		var side lapack.EVSide
+		switch {
+		case wantvl && wantvr:
+			side = lapack.RightLeftEV
+		case wantvl:
+			side = lapack.LeftEV
+		default:
+			side = lapack.RightEV
+		}
+		_, ok = impl.Dtgevc(side, lapack.AllEVMulQ, nil, n, a, lda, b, ldb,
+			vl, ldvl, vr, ldvr, n, work[:6*n])
+		if ok {
+			if wantvl {
+				dggevNormalize(n, vl, ldvl, alphai, smlnum)
+			}
+			if wantvr {
+				dggevNormalize(n, vr, ldvr, alphai, smlnum)
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphar, 1)
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphai, 1)
+	}
+	if scaleb {
+		impl.Dlascl(lapack.General, 0, 0, bnrmto, bnrm, n, 1, beta, 1)
+	}
+
+	work[0] = float64(maxwrk)
+	return ok
+}
+
+// dggevNormalize scales the eigenvectors stored in the columns of the n×n
+// matrix v so that the largest component of each has |real part| +
+// |imag part| = 1. Complex eigenvectors are stored in two consecutive
+// columns as indicated by alphai. Eigenvectors whose largest component is
+// smaller than smlnum are left unchanged.
+func dggevNormalize(n int, v []float64, ldv int, alphai []float64, smlnum float64) {
+	for j := 0; j < n; j++ {
+		if alphai[j] < 0 {
+			continue
+		}
+		var temp float64
+		if alphai[j] == 0 {
+			for i := 0; i < n; i++ {
+				temp = math.Max(temp, math.Abs(v[i*ldv+j]))
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				temp = math.Max(temp, math.Abs(v[i*ldv+j])+math.Abs(v[i*ldv+j+1]))
+			}
+		}
+		if temp < smlnum {
+			continue
+		}
+		temp = 1 / temp
+		if alphai[j] == 0 {
+			for i := 0; i < n; i++ {
+				v[i*ldv+j] *= temp
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				v[i*ldv+j] *= temp
+				v[i*ldv+j+1] *= temp
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgghrd reduces a pair of real matrices (A, B) to generalized upper
+// Hessenberg form using orthogonal transformations, where A is a general
+// matrix and B is upper triangular.
+//
+// The form of the generalized eigenvalue problem is
+//  A*x = λ*B*x,
+// and B is typically made upper triangular by computing its QR factorization
+// and moving the orthogonal matrix Q to the left side of the equation.
+//
+// This subroutine simultaneously reduces A to a Hessenberg matrix H
+//  Q^T*A*Z = H,
+// and transforms B to another upper triangular matrix T
+//  Q^T*B*Z = T.
+// The orthogonal matrices Q and Z are determined as products of Givens
+// rotations. They may either be formed explicitly, or they may be
+// postmultiplied into input matrices Q1 and Z1, so that
+//  Q1 * A * Z1^T = (Q1*Q) * H * (Z1*Z)^T,
+//  Q1 * B * Z1^T = (Q1*Q) * T * (Z1*Z)^T.
+// If Q1 is the orthogonal matrix from the QR factorization of B in the
+// original equation A*x = λ*B*x, then Dgghrd reduces the original problem to
+// generalized Hessenberg form.
+//
+// If compq == lapack.None, Q will not be referenced. If compq ==
+// lapack.HessEV, q will be initialized to the identity and on return it will
+// contain the orthogonal matrix Q. If compq == lapack.OriginalEV, q must
+// contain an orthogonal matrix Q1 on entry and on return it will contain the
+// product Q1*Q. compz and z are treated in the same way for Z. For other
+// values of compq and compz Dgghrd will panic.
+//
+// ilo and ihi determine the block of (A, B) that is reduced. It is assumed
+// that A is already upper triangular in rows and columns [0:ilo] and
+// [ihi+1:n]. ilo and ihi are normally set by a previous call to a balancing
+// routine, otherwise they should be set to 0 and n-1, respectively. It must
+// hold that 0 <= ilo <= ihi < n if n > 0, and ilo == 0 and ihi == -1 if
+// n == 0, otherwise Dgghrd will panic.
+//
+// On return, A will contain the upper Hessenberg matrix H and B will contain
+// the upper triangular matrix T. The elements of B below the diagonal are set
+// to zero.
+//
+// Dgghrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int) {
+	var wantq, initq bool
+	switch compq {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		wantq = true
+		initq = true
+	case lapack.OriginalEV:
+		wantq = true
+	}
+	var wantz, initz bool
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		wantz = true
+		initz = true
+	case lapack.OriginalEV:
+		wantz = true
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	if initq {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+	if initz {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	// Quick return if possible.
+	if n <= 1 {
+		return
+	}
+
+	// Zero out the lower triangle of B.
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b[i*ldb+j] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+	// Reduce A and B.
+	for jcol := ilo; jcol <= ihi-2; jcol++ {
+		for jrow := ihi; jrow >= jcol+2; jrow-- {
+			// Rotate rows jrow-1 and jrow to zero A[jrow,jcol].
+			var c, s float64
+			c, s, a[(jrow-1)*lda+jcol] = impl.Dlartg(a[(jrow-1)*lda+jcol], a[jrow*lda+jcol])
+			a[jrow*lda+jcol] = 0
+			bi.Drot(n-jcol-1, a[(jrow-1)*lda+jcol+1:], 1, a[jrow*lda+jcol+1:], 1, c, s)
+			bi.Drot(n-jrow+1, b[(jrow-1)*ldb+jrow-1:], 1, b[jrow*ldb+jrow-1:], 1, c, s)
+			if wantq {
+				bi.Drot(n, q[jrow-1:], ldq, q[jrow:], ldq, c, s)
+			}
+
+			// Rotate columns jrow and jrow-1 to zero B[jrow,jrow-1].
+			c, s, b[jrow*ldb+jrow] = impl.Dlartg(b[jrow*ldb+jrow], b[jrow*ldb+jrow-1])
+			b[jrow*ldb+jrow-1] = 0
+			bi.Drot(ihi+1, a[jrow:], lda, a[jrow-1:], lda, c, s)
+			bi.Drot(jrow, b[jrow:], ldb, b[jrow-1:], ldb, c, s)
+			if wantz {
+				bi.Drot(n, z[jrow:], ldz, z[jrow-1:], ldz, c, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// Dgtsv solves one of the equations
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans
+// where A is an n×n tridiagonal matrix and X and B are n×nrhs matrices, using
+// Gaussian elimination with partial pivoting.
+//
+// On entry, dl, d and du contain the sub-diagonal, the diagonal and the
+// super-diagonal of A. dl and du must have length at least n-1 and d must have
+// length at least n, otherwise Dgtsv will panic. On return, dl, d and du are
+// overwritten by the factorization of A: d contains the diagonal of the upper
+// triangular matrix U, du contains its first super-diagonal and dl contains
+// its second super-diagonal in the first n-2 elements.
+//
+// On entry, b contains the right-hand side matrix B, and on return, if ok is
+// true, b contains the solution matrix X.
+//
+// Dgtsv returns whether the solution was computed successfully. If ok is
+// false, the matrix U has an exactly zero diagonal element and A is singular,
+// in which case the solution has not been computed.
+func (impl Implementation) Dgtsv(trans blas.Transpose, n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool) {
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTrans)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return true
+	}
+	if len(dl) < n-1 || len(du) < n-1 {
+		panic(badSlice)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if nrhs == 0 {
+		return true
+	}
+
+	if trans != blas.NoTrans {
+		// The sub-diagonal of A^T is the super-diagonal of A.
+		dl, du = du, dl
+	}
+
+	for i := 0; i < n-1; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required.
+			if d[i] == 0 {
+				return false
+			}
+			fact := dl[i] / d[i]
+			d[i+1] -= fact * du[i]
+			bi := b[i*ldb : i*ldb+nrhs]
+			bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+			for j, v := range bi {
+				bi1[j] -= fact * v
+			}
+			dl[i] = 0
+			continue
+		}
+
+		// Interchange rows i and i+1.
+		fact := d[i] / dl[i]
+		d[i] = dl[i]
+		tmp := d[i+1]
+		d[i+1] = du[i] - fact*tmp
+		if i < n-2 {
+			dl[i] = du[i+1]
+			du[i+1] = -fact * dl[i]
+		}
+		du[i] = tmp
+		bi := b[i*ldb : i*ldb+nrhs]
+		bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+		for j, v := range bi {
+			bi[j] = bi1[j]
+			bi1[j] = v - fact*bi1[j]
+		}
+	}
+	if d[n-1] == 0 {
+		return false
+	}
+
+	// Back solve with the matrix U from the factorization.
+	for j := 0; j < nrhs; j++ {
+		b[(n-1)*ldb+j] /= d[n-1]
+		if n > 1 {
+			b[(n-2)*ldb+j] = (b[(n-2)*ldb+j] - du[n-2]*b[(n-1)*ldb+j]) / d[n-2]
+		}
+		for i := n - 3; i >= 0; i-- {
+			b[i*ldb+j] = (b[i*ldb+j] - du[i]*b[(i+1)*ldb+j] - dl[i]*b[(i+2)*ldb+j]) / d[i]
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlaneg returns the Sturm count, the number of negative pivots encountered
+// while factoring the tridiagonal L*D*L^T - sigma*I = N*G*N^T, where N is a
+// twisted factor with twist index r. The count is the number of eigenvalues
+// of L*D*L^T less than sigma.
+//
+// d contains the n diagonal elements of D and lld contains the n-1 elements
+// l_i*l_i*d_i. r must satisfy 0 <= r < n.
+//
+// The pivots are computed in blocks, and a block in which a NaN is produced
+// by a zero pivot following an infinite one is recomputed with the NaN
+// replaced by its correct limit.
+func dlaneg(n int, d, lld []float64, sigma float64, r int) int {
+	const blklen = 128
+
+	var negcnt int
+
+	// Upper part: L*D*L^T - sigma*I = L+*D+*L+^T.
+	t := -sigma
+	for bj := 0; bj < r; bj += blklen {
+		var neg1 int
+		bsav := t
+		jmax := min(bj+blklen, r)
+		for j := bj; j < jmax; j++ {
+			dplus := d[j] + t
+			if dplus < 0 {
+				neg1++
+			}
+			t = t/dplus*lld[j] - sigma
+		}
+		if math.IsNaN(t) {
+			neg1 = 0
+			t = bsav
+			for j := bj; j < jmax; j++ {
+				dplus := d[j] + t
+				if dplus < 0 {
+					neg1++
+				}
+				tmp := t / dplus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				t = tmp*lld[j] - sigma
+			}
+		}
+		negcnt += neg1
+	}
+
+	// Lower part: L*D*L^T - sigma*I = U-*D-*U-^T.
+	p := d[n-1] - sigma
+	for bj := n - 2; bj >= r; bj -= blklen {
+		var neg2 int
+		bsav := p
+		jmin := max(bj-blklen+1, r)
+		for j := bj; j >= jmin; j-- {
+			dminus := lld[j] + p
+			if dminus < 0 {
+				neg2++
+			}
+			p = p/dminus*d[j] - sigma
+		}
+		if math.IsNaN(p) {
+			neg2 = 0
+			p = bsav
+			for j := bj; j >= jmin; j-- {
+				dminus := lld[j] + p
+				if dminus < 0 {
+					neg2++
+				}
+				tmp := p / dminus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				p = tmp*d[j] - sigma
+			}
+		}
+		negcnt += neg2
+	}
+
+	// Twist index. t was shifted by sigma initially.
+	if gamma := (t + sigma) + p; gamma < 0 {
+		negcnt++
+	}
+	return negcnt
+}
@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/lapack"
+
+// Dlaqge equilibrates the m×n matrix A using the row and column scale factors
+// in r and c as computed by Dgeequ. rowcnd, colcnd and amax are the values
+// returned by Dgeequ. Row scaling is applied if rowcnd < 0.1 or amax is close
+// to underflow or overflow, and column scaling is applied if colcnd < 0.1.
+//
+// On return, a contains the equilibrated matrix and equed specifies the form
+// of equilibration that was applied.
+//
+// Dlaqge is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) (equed lapack.EquilibrationType) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+	if m == 0 || n == 0 {
+		return lapack.NoEquilibration
+	}
+
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	rowScale := rowcnd < thresh || amax < small || amax > large
+	colScale := colcnd < thresh
+	switch {
+	case !rowScale && !colScale:
+		return lapack.NoEquilibration
+	case !rowScale:
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] *= c[j]
+			}
+		}
+		return lapack.ColEquilibration
+	case !colScale:
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] *= r[i]
+			}
+		}
+		return lapack.RowEquilibration
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			a[i*lda+j] *= r[i] * c[j]
+		}
+	}
+	return lapack.BothEquilibration
+}
@@ -0,0 +1,197 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlar1v computes the scaled r-th column of the inverse of the submatrix in
+// rows and columns b1 through bn of the tridiagonal L*D*L^T - lambda*I, where
+// lambda is an approximation to an eigenvalue. The vector is an approximate
+// eigenvector of L*D*L^T corresponding to lambda.
+//
+// d contains the n diagonal elements of D, l the n-1 subdiagonal elements of
+// L, ld the n-1 elements l_i*d_i and lld the n-1 elements l_i*l_i*d_i.
+//
+// If r is in [b1,bn], it is used as the twist index. Otherwise the twist
+// index is chosen as the index in [b1,bn] of the largest diagonal element
+// of the inverse, and it is returned in rOut.
+//
+// On return, z contains the unnormalized vector with its support, the indices
+// of its first and last non-zero elements, stored in isuppz[0] and isuppz[1].
+// Elements smaller than gaptol relative to their neighbors are set to zero.
+// negcnt is the number of pivots less than zero if wantnc is true, and -1
+// otherwise. ztz is the square of the 2-norm of z, mingma the reciprocal of
+// the largest diagonal element of the inverse, nrminv is 1/sqrt(ztz),
+// resid the residual of the FP vector and rqcorr the Rayleigh quotient
+// correction to lambda.
+//
+// work must have length at least 4*n.
+func dlar1v(n, b1, bn int, lambda float64, d, l, ld, lld []float64, pivmin, gaptol float64, z []float64, wantnc bool, r int, isuppz []int, work []float64) (negcnt int, ztz, mingma float64, rOut int, nrminv, resid, rqcorr float64) {
+	eps := dlamchP
+
+	r1, r2 := b1, bn
+	if b1 <= r && r <= bn {
+		r1, r2 = r, r
+	}
+
+	// Storage for L+, U-, S and P.
+	lplus := work[:n]
+	uminus := work[n : 2*n]
+	s := work[2*n : 3*n]
+	p := work[3*n : 4*n]
+
+	if b1 == 0 {
+		s[0] = 0
+	} else {
+		s[b1] = lld[b1-1]
+	}
+
+	// Compute the stationary transform (using the differential form)
+	// until the index r2.
+	var neg1 int
+	t := s[b1] - lambda
+	for i := b1; i < r1; i++ {
+		dplus := d[i] + t
+		lplus[i] = ld[i] / dplus
+		if dplus < 0 {
+			neg1++
+		}
+		s[i+1] = t * lplus[i] * l[i]
+		t = s[i+1] - lambda
+	}
+	sawnan1 := math.IsNaN(t)
+	if !sawnan1 {
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + t
+			lplus[i] = ld[i] / dplus
+			s[i+1] = t * lplus[i] * l[i]
+			t = s[i+1] - lambda
+		}
+		sawnan1 = math.IsNaN(t)
+	}
+	if sawnan1 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg1 = 0
+		t = s[b1] - lambda
+		for i := b1; i < r2; i++ {
+			dplus := d[i] + t
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			lplus[i] = ld[i] / dplus
+			if i < r1 && dplus < 0 {
+				neg1++
+			}
+			s[i+1] = t * lplus[i] * l[i]
+			if lplus[i] == 0 {
+				s[i+1] = lld[i]
+			}
+			t = s[i+1] - lambda
+		}
+	}
+
+	// Compute the progressive transform (using the differential form)
+	// until the index r1.
+	var neg2 int
+	p[bn] = d[bn] - lambda
+	for i := bn - 1; i >= r1; i-- {
+		dminus := lld[i] + p[i+1]
+		tmp := d[i] / dminus
+		if dminus < 0 {
+			neg2++
+		}
+		uminus[i] = l[i] * tmp
+		p[i] = p[i+1]*tmp - lambda
+	}
+	sawnan2 := math.IsNaN(p[r1])
+	if sawnan2 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg2 = 0
+		for i := bn - 1; i >= r1; i-- {
+			dminus := lld[i] + p[i+1]
+			if math.Abs(dminus) < pivmin {
+				dminus = -pivmin
+			}
+			tmp := d[i] / dminus
+			if dminus < 0 {
+				neg2++
+			}
+			uminus[i] = l[i] * tmp
+			p[i] = p[i+1]*tmp - lambda
+			if tmp == 0 {
+				p[i] = d[i] - lambda
+			}
+		}
+	}
+
+	// Find the index, from r1 to r2, of the largest (in magnitude)
+	// diagonal element of the inverse.
+	mingma = s[r1] + p[r1]
+	if mingma < 0 {
+		neg1++
+	}
+	if wantnc {
+		negcnt = neg1 + neg2
+	} else {
+		negcnt = -1
+	}
+	if mingma == 0 {
+		mingma = eps * s[r1]
+	}
+	rOut = r1
+	for i := r1 + 1; i <= r2; i++ {
+		tmp := s[i] + p[i]
+		if tmp == 0 {
+			tmp = eps * s[i]
+		}
+		if math.Abs(tmp) <= math.Abs(mingma) {
+			mingma = tmp
+			rOut = i
+		}
+	}
+
+	// Compute the FP vector: solve N^T*v = e_r.
+	isuppz[0] = b1
+	isuppz[1] = bn
+	z[rOut] = 1
+	ztz = 1
+
+	// Compute the FP vector upwards from r.
+	for i := rOut - 1; i >= b1; i-- {
+		if (sawnan1 || sawnan2) && z[i+1] == 0 {
+			z[i] = -(ld[i+1] / ld[i]) * z[i+2]
+		} else {
+			z[i] = -(lplus[i] * z[i+1])
+		}
+		if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+			z[i] = 0
+			isuppz[0] = i + 1
+			break
+		}
+		ztz += z[i] * z[i]
+	}
+
+	// Compute the FP vector downwards from r.
+	for i := rOut; i < bn; i++ {
+		if (sawnan1 || sawnan2) && z[i] == 0 {
+			z[i+1] = -(ld[i-1] / ld[i]) * z[i-1]
+		} else {
+			z[i+1] = -(uminus[i] * z[i])
+		}
+		if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+			z[i+1] = 0
+			isuppz[1] = i
+			break
+		}
+		ztz += z[i+1] * z[i+1]
+	}
+
+	// Compute quantities for the convergence test.
+	tmp := 1 / ztz
+	nrminv = math.Sqrt(tmp)
+	resid = math.Abs(mingma) * nrminv
+	rqcorr = mingma * tmp
+	return negcnt, ztz, mingma, rOut, nrminv, resid, rqcorr
+}
@@ -0,0 +1,39 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarra computes the splitting points of the n×n symmetric tridiagonal
+// matrix T with diagonal d and off-diagonal e. The off-diagonal elements
+// that are negligible are set to zero in e and e2, where e2 contains the
+// squares of the elements of e.
+//
+// If spltol < 0, the absolute criterion |e[i]| <= |spltol|*tnrm is used,
+// where tnrm is a norm of T. Otherwise the criterion
+//  |e[i]| <= spltol*sqrt(|d[i]|)*sqrt(|d[i+1]|)
+// that preserves relative accuracy is used.
+//
+// On return, the first nsplit elements of isplit contain the index of the
+// last row of each unreduced block. isplit must have length at least n.
+func dlarra(n int, d, e, e2 []float64, spltol, tnrm float64, isplit []int) (nsplit int) {
+	for i := 0; i < n-1; i++ {
+		eabs := math.Abs(e[i])
+		var split bool
+		if spltol < 0 {
+			split = eabs <= math.Abs(spltol)*tnrm
+		} else {
+			split = eabs <= spltol*math.Sqrt(math.Abs(d[i]))*math.Sqrt(math.Abs(d[i+1]))
+		}
+		if split {
+			e[i] = 0
+			e2[i] = 0
+			isplit[nsplit] = i
+			nsplit++
+		}
+	}
+	isplit[nsplit] = n - 1
+	return nsplit + 1
+}
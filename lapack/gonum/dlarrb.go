@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrb refines, by bisection, the eigenvalue approximations of the n×n
+// tridiagonal L*D*L^T with indices ifirst through ilast, indexed from zero.
+// d contains the n diagonal elements of D and lld contains the n-1 elements
+// l_i*l_i*d_i.
+//
+// The approximation of the i-th eigenvalue is stored in w[i-offset] with the
+// semi-width of its uncertainty interval in werr[i-offset] and the gap to the
+// next eigenvalue in wgap[i-offset]. An interval is considered converged if
+// its semi-width is at most max(rtol1*gap, rtol2*max(|left|,|right|)), where
+// gap is the smaller of the gaps to its neighbors. On return w, werr and wgap
+// are updated.
+//
+// twist is the twist index used for the Sturm counts, see dlaneg. If it is
+// out of the range [0,n), n-1 is used. work must have length at least 2*n and
+// iwork at least 2*n.
+func dlarrb(n int, d, lld []float64, ifirst, ilast int, rtol1, rtol2 float64, offset int, w, wgap, werr []float64, work []float64, iwork []int, pivmin, spdiam float64, twist int) {
+	if n <= 0 {
+		return
+	}
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+	mnwdth := 2 * pivmin
+	r := twist
+	if r < 0 || r >= n {
+		r = n - 1
+	}
+
+	// The interval of the i-th eigenvalue is [work[2*i], work[2*i+1]].
+	// iwork[2*i] is 1 for unconverged intervals, -1 for intervals that
+	// converged initially and 0 for intervals that converged during the
+	// bisection.
+	var nint int
+	rgap := wgap[ifirst-offset]
+	for i := ifirst; i <= ilast; i++ {
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		lgap := rgap
+		rgap = wgap[ii]
+		gap := math.Min(lgap, rgap)
+
+		// Make sure that [left,right] contains the desired eigenvalue.
+		// The step is bounded below so that the interval grows even
+		// if werr is zero.
+		back := math.Max(werr[ii], pivmin)
+		for dlaneg(n, d, lld, left, r) > i {
+			left -= back
+			back *= 2
+		}
+		back = math.Max(werr[ii], pivmin)
+		for dlaneg(n, d, lld, right, r) <= i {
+			right += back
+			back *= 2
+		}
+
+		width := 0.5 * math.Abs(left-right)
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+		if width <= cvrgd || width <= mnwdth {
+			// The interval has already converged and does not
+			// need refinement.
+			iwork[2*i] = -1
+		} else {
+			nint++
+			iwork[2*i] = 1
+		}
+		work[2*i] = left
+		work[2*i+1] = right
+	}
+
+	// Bisect the unconverged intervals. In the last iteration all
+	// intervals are accepted since this is the best that can be done.
+	for iter := 0; nint > 0 && iter <= maxitr; iter++ {
+		for i := ifirst; i <= ilast; i++ {
+			if iwork[2*i] != 1 {
+				continue
+			}
+			ii := i - offset
+			rgap := wgap[ii]
+			lgap := rgap
+			if ii > 0 {
+				lgap = wgap[ii-1]
+			}
+			gap := math.Min(lgap, rgap)
+			left := work[2*i]
+			right := work[2*i+1]
+			mid := 0.5 * (left + right)
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+			if width <= cvrgd || width <= mnwdth || iter == maxitr {
+				nint--
+				iwork[2*i] = 0
+				continue
+			}
+			if dlaneg(n, d, lld, mid, r) <= i {
+				work[2*i] = mid
+			} else {
+				work[2*i+1] = mid
+			}
+		}
+	}
+
+	// All intervals marked by 0 have been refined.
+	for i := ifirst; i <= ilast; i++ {
+		if iwork[2*i] == 0 {
+			ii := i - offset
+			w[ii] = 0.5 * (work[2*i] + work[2*i+1])
+			werr[ii] = work[2*i+1] - w[ii]
+		}
+	}
+	for i := ifirst + 1; i <= ilast; i++ {
+		ii := i - offset
+		wgap[ii-1] = math.Max(0, w[ii]-werr[ii]-w[ii-1]-werr[ii-1])
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// dlarrd computes approximations to the selected eigenvalues of the n×n
+// symmetric tridiagonal matrix T, with diagonal d and off-diagonal e, by
+// bisection. T is split into nsplit unreduced blocks and the index of the
+// last row of each block is stored in isplit, see dlarra. gers contains the
+// Gerschgorin intervals of the rows of T, with the i-th interval stored in
+// [gers[2*i], gers[2*i+1]].
+//
+// rng, vl, vu, il and iu select the eigenvalues as in Dstebz. The eigenvalues
+// are located to a relative accuracy of reltol.
+//
+// On return, the first m elements of w contain the approximations ordered by
+// block and, within each block, from smallest to largest. werr contains the
+// semi-widths of the uncertainty intervals, iblock the block number of each
+// eigenvalue and indexw its index, from zero, within its block. All wanted
+// eigenvalues lie in the half-open interval (wl,wu]. ok is false if the
+// bisection did not converge or the wrong number of eigenvalues was found.
+func dlarrd(rng lapack.EVRange, n int, vl, vu float64, il, iu int, gers []float64, reltol float64, d, e []float64, pivmin float64, nsplit int, isplit []int, w, werr []float64, iblock, indexw []int) (m int, wl, wu float64, ok bool) {
+	const fudge = 2
+
+	eps := dlamchP
+
+	// Compute the Gerschgorin interval of the whole matrix.
+	gl := gers[0]
+	gu := gers[1]
+	for i := 1; i < n; i++ {
+		gl = math.Min(gl, gers[2*i])
+		gu = math.Max(gu, gers[2*i+1])
+	}
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl -= fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+	gu += fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	// Compute the interval (wl,wu] that contains the wanted eigenvalues.
+	// In the index case, the end points are the ends of the bisection
+	// intervals of the il-th and iu-th eigenvalue of T so that the
+	// interval may contain a few unwanted eigenvalues that are discarded
+	// below.
+	switch rng {
+	case lapack.AllEVs:
+		wl, wu = gl, gu
+	case lapack.ValueEVs:
+		wl, wu = vl, vu
+	case lapack.IndexEVs:
+		lw, lerr, lok := dlarrk(n, il, gl, gu, d, e, pivmin, reltol)
+		uw, uerr, uok := dlarrk(n, iu, gl, gu, d, e, pivmin, reltol)
+		if !lok || !uok {
+			return 0, wl, wu, false
+		}
+		wl = lw - lerr
+		wu = uw + uerr
+	}
+
+	var ibegin int
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+		if in == 1 {
+			// A 1×1 block.
+			if rng == lapack.AllEVs || (wl < d[ibegin]-pivmin && d[ibegin]-pivmin <= wu) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+			}
+			ibegin = iend + 1
+			continue
+		}
+
+		// Compute the Gerschgorin interval of the block.
+		bgl := gers[2*ibegin]
+		bgu := gers[2*ibegin+1]
+		for i := ibegin + 1; i <= iend; i++ {
+			bgl = math.Min(bgl, gers[2*i])
+			bgu = math.Max(bgu, gers[2*i+1])
+		}
+		bnorm := math.Max(math.Abs(bgl), math.Abs(bgu))
+		bgl -= fudge*bnorm*eps*float64(in) + fudge*2*pivmin
+		bgu += fudge*bnorm*eps*float64(in) + fudge*2*pivmin
+
+		// Compute the local indices of the eigenvalues of the block in
+		// (wl,wu] and locate each of them by bisection.
+		ilo, ihi := 0, in-1
+		if rng != lapack.AllEVs {
+			if bgu < wl || wu < bgl {
+				ibegin = iend + 1
+				continue
+			}
+			ilo = dsturm(in, d[ibegin:], e[ibegin:], wl, pivmin)
+			ihi = dsturm(in, d[ibegin:], e[ibegin:], wu, pivmin) - 1
+		}
+		for j := ilo; j <= ihi; j++ {
+			x, xerr, xok := dlarrk(in, j, bgl, bgu, d[ibegin:], e[ibegin:], pivmin, reltol)
+			if !xok {
+				return 0, wl, wu, false
+			}
+			w[m] = x
+			werr[m] = xerr
+			iblock[m] = jblk
+			indexw[m] = j
+			m++
+		}
+		ibegin = iend + 1
+	}
+
+	if rng == lapack.IndexEVs {
+		// Discard the unwanted eigenvalues at both ends of (wl,wu].
+		nwl := dsturm(n, d, e, wl, pivmin)
+		nwu := dsturm(n, d, e, wu, pivmin)
+		idiscl := il - nwl
+		idiscu := nwu - 1 - iu
+		for ; idiscl > 0; idiscl-- {
+			k := -1
+			for j := 0; j < m; j++ {
+				if iblock[j] >= 0 && (k < 0 || w[j] < w[k]) {
+					k = j
+				}
+			}
+			if k < 0 {
+				break
+			}
+			iblock[k] = -1
+		}
+		for ; idiscu > 0; idiscu-- {
+			k := -1
+			for j := 0; j < m; j++ {
+				if iblock[j] >= 0 && (k < 0 || w[j] >= w[k]) {
+					k = j
+				}
+			}
+			if k < 0 {
+				break
+			}
+			iblock[k] = -1
+		}
+		var im int
+		for j := 0; j < m; j++ {
+			if iblock[j] < 0 {
+				continue
+			}
+			w[im] = w[j]
+			werr[im] = werr[j]
+			iblock[im] = iblock[j]
+			indexw[im] = indexw[j]
+			im++
+		}
+		m = im
+		if m != iu-il+1 {
+			return m, wl, wu, false
+		}
+	}
+	return m, wl, wu, true
+}
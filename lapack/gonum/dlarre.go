@@ -0,0 +1,462 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// dlarre splits the n×n symmetric tridiagonal matrix T, with diagonal d and
+// off-diagonal e, into unreduced blocks T_i and computes for each block a
+// relatively robust representation
+//  L_i*D_i*L_i^T = T_i - sigma_i*I
+// together with approximations to the selected eigenvalues of L_i*D_i*L_i^T.
+// rng, vl, vu, il and iu select the eigenvalues as in Dstebz.
+//
+// e must have length at least n and e2 must contain the squares of the n-1
+// off-diagonal elements. spltol is the splitting criterion, see dlarra. The
+// eigenvalue approximations computed by bisection are refined to the
+// relative accuracy given by rtol1 and rtol2, see dlarrb.
+//
+// On return, d contains the diagonals of the D_i and e contains the
+// subdiagonals of the L_i, with the shift sigma_i stored in e at the index
+// of the last row of the block. The first nsplit elements of isplit contain
+// the index of the last row of each block. The first m elements of w contain
+// the eigenvalue approximations of the shifted representations ordered by
+// block, werr their uncertainties and wgap the gaps between them. iblock
+// contains the block number and indexw the index, from zero, within the
+// block of each eigenvalue. gers contains the Gerschgorin intervals of the
+// rows of T. All wanted eigenvalues of T lie in the half-open interval
+// (wl,wu]. pivmin is the minimum pivot in the Sturm sequences of T.
+//
+// work must have length at least 6*n and iwork at least 5*n. ok is false if
+// no eigenvalues or no base representation could be computed.
+func (impl Implementation) dlarre(rng lapack.EVRange, n int, vl, vu float64, il, iu int, d, e, e2 []float64, rtol1, rtol2, spltol float64, isplit []int, w, werr, wgap []float64, iblock, indexw []int, gers []float64, work []float64, iwork []int) (nsplit, m int, wl, wu, pivmin float64, ok bool) {
+	const (
+		fac       = 0.5
+		maxgrowth = 64
+		fudge     = 2
+		maxtry    = 6
+		pert      = 8
+		hndrd     = 100
+	)
+
+	safmin := dlamchS
+	eps := dlamchP
+	rtl := math.Sqrt(eps)
+	bsrtol := math.Sqrt(eps)
+
+	if n == 1 {
+		if rng == lapack.AllEVs ||
+			(rng == lapack.ValueEVs && vl < d[0] && d[0] <= vu) ||
+			(rng == lapack.IndexEVs && il == 0 && iu == 0) {
+			m = 1
+			w[0] = d[0]
+			werr[0] = 0
+			wgap[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+		}
+		gers[0] = d[0]
+		gers[1] = d[0]
+		// Store the shift for the initial representation, which is
+		// zero in this case.
+		e[0] = 0
+		isplit[0] = 0
+		return 1, m, vl, vu, safmin, true
+	}
+
+	// Compute the Gerschgorin intervals and the spectral diameter, and
+	// the maximum off-diagonal element and pivmin.
+	gl := d[0]
+	gu := d[0]
+	var eold, emax float64
+	e[n-1] = 0
+	for i := 0; i < n; i++ {
+		werr[i] = 0
+		wgap[i] = 0
+		eabs := math.Abs(e[i])
+		emax = math.Max(emax, eabs)
+		tmp := eabs + eold
+		gers[2*i] = d[i] - tmp
+		gl = math.Min(gl, gers[2*i])
+		gers[2*i+1] = d[i] + tmp
+		gu = math.Max(gu, gers[2*i+1])
+		eold = eabs
+	}
+	pivmin = safmin * math.Max(1, emax*emax)
+	spdiam := gu - gl
+
+	// Compute the splitting points.
+	nsplit = dlarra(n, d, e, e2, spltol, spdiam, isplit)
+
+	var mm int
+	if rng == lapack.AllEVs {
+		// The interval [gl,gu] contains all eigenvalues. They are
+		// computed by dqds.
+		vl = gl
+		vu = gu
+	} else {
+		// Find crude approximations to the eigenvalues in the desired
+		// range and, in the index case, the interval (vl,vu] that
+		// contains them.
+		mm, vl, vu, ok = dlarrd(rng, n, vl, vu, il, iu, gers, bsrtol, d, e, pivmin, nsplit, isplit, w, werr, iblock, indexw)
+		if !ok {
+			return nsplit, 0, vl, vu, pivmin, false
+		}
+		for i := mm; i < n; i++ {
+			w[i] = 0
+			werr[i] = 0
+			iblock[i] = -1
+			indexw[i] = -1
+		}
+	}
+
+	// Loop over the unreduced blocks.
+	var ibegin, wbegin int
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		if in == 1 {
+			// A 1×1 block.
+			if rng == lapack.AllEVs || (wbegin < mm && iblock[wbegin] == jblk) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				// The gap for a single block doesn't matter for
+				// the later algorithm.
+				wgap[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+				wbegin++
+			}
+			// e[iend] holds the shift for the initial representation.
+			e[iend] = 0
+			ibegin = iend + 1
+			continue
+		}
+
+		// Blocks of size larger than 1×1. e[iend] will hold the shift
+		// for the initial representation.
+		e[iend] = 0
+
+		// Find the local outer bounds gl, gu for the block.
+		gl = d[ibegin]
+		gu = d[ibegin]
+		for i := ibegin; i <= iend; i++ {
+			gl = math.Min(gl, gers[2*i])
+			gu = math.Max(gu, gers[2*i+1])
+		}
+		spdiam = gu - gl
+
+		var (
+			mb, wend   int
+			indl, indu int
+			usedqd     bool
+		)
+		if rng != lapack.AllEVs {
+			// Count the number of eigenvalues in the current block.
+			for i := wbegin; i < mm && iblock[i] == jblk; i++ {
+				mb++
+			}
+			if mb == 0 {
+				// No eigenvalue of the current block lies in
+				// the desired range.
+				ibegin = iend + 1
+				continue
+			}
+			// Decide whether dqds or bisection is more efficient.
+			usedqd = float64(mb) > fac*float64(in)
+			wend = wbegin + mb - 1
+			// Calculate the gaps for the current block.
+			for i := wbegin; i < wend; i++ {
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[wend] = math.Max(0, vu-(w[wend]+werr[wend]))
+			// Find the local indices of the first and last desired
+			// eigenvalue.
+			indl = indexw[wbegin]
+			indu = indexw[wend]
+		}
+
+		var isleft, isrght float64
+		if rng == lapack.AllEVs || usedqd {
+			// Find approximations to the extremal eigenvalues of the
+			// block.
+			tmp, tmp1, xok := dlarrk(in, 0, gl, gu, d[ibegin:], e[ibegin:], pivmin, rtl)
+			if !xok {
+				return nsplit, m, vl, vu, pivmin, false
+			}
+			isleft = math.Max(gl, tmp-tmp1-hndrd*eps*math.Abs(tmp-tmp1))
+			tmp, tmp1, xok = dlarrk(in, in-1, gl, gu, d[ibegin:], e[ibegin:], pivmin, rtl)
+			if !xok {
+				return nsplit, m, vl, vu, pivmin, false
+			}
+			isrght = math.Min(gu, tmp+tmp1+hndrd*eps*math.Abs(tmp+tmp1))
+			// Improve the estimate of the spectral diameter.
+			spdiam = isrght - isleft
+		} else {
+			// Find approximations to the wanted extremal eigenvalues.
+			isleft = math.Max(gl, w[wbegin]-werr[wbegin]-hndrd*eps*math.Abs(w[wbegin]-werr[wbegin]))
+			isrght = math.Min(gu, w[wend]+werr[wend]+hndrd*eps*math.Abs(w[wend]+werr[wend]))
+		}
+
+		// Decide whether the base representation for the current block
+		// should be on the left or the right end of the block. The
+		// strategy is to shift to the end which is "more populated".
+		var s1, s2 float64
+		switch {
+		case rng == lapack.AllEVs:
+			// All the eigenvalues are computed by dqds.
+			usedqd = true
+			indl = 0
+			indu = in - 1
+			mb = in
+			wend = wbegin + mb - 1
+			s1 = isleft + 0.25*spdiam
+			s2 = isrght - 0.25*spdiam
+		case usedqd:
+			s1 = isleft + 0.25*spdiam
+			s2 = isrght - 0.25*spdiam
+		default:
+			tmp := math.Min(isrght, vu) - math.Max(isleft, vl)
+			s1 = math.Max(isleft, vl) + 0.25*tmp
+			s2 = math.Min(isrght, vu) - 0.25*tmp
+		}
+
+		// Compute the Sturm counts at the 1/4 and 3/4 points.
+		var cnt1, cnt2 int
+		if mb > 1 {
+			cnt1 = dsturm(in, d[ibegin:], e[ibegin:], s1, pivmin)
+			cnt2 = dsturm(in, d[ibegin:], e[ibegin:], s2, pivmin)
+		}
+
+		var sigma, sgndef float64
+		switch {
+		case mb == 1:
+			sigma = gl
+			sgndef = 1
+		case cnt1-indl >= indu-cnt2+2:
+			switch {
+			case rng == lapack.AllEVs:
+				sigma = math.Max(isleft, gl)
+			case usedqd:
+				// Use the Gerschgorin bound as the shift to get a
+				// positive definite matrix for dqds.
+				sigma = isleft
+			default:
+				// Use an approximation of the first desired
+				// eigenvalue of the block as the shift.
+				sigma = math.Max(isleft, vl)
+			}
+			sgndef = 1
+		default:
+			switch {
+			case rng == lapack.AllEVs:
+				sigma = math.Min(isrght, gu)
+			case usedqd:
+				// Use the Gerschgorin bound as the shift to get a
+				// negative definite matrix for dqds.
+				sigma = isrght
+			default:
+				// Use an approximation of the last desired
+				// eigenvalue of the block as the shift.
+				sigma = math.Min(isrght, vu)
+			}
+			sgndef = -1
+		}
+
+		// An initial sigma has been chosen that will be used for
+		// computing T - sigma*I = L*D*L^T. Define the increment tau of
+		// the shift in case the initial shift needs to be refined to
+		// obtain a factorization with not too much element growth.
+		var tau float64
+		if usedqd {
+			// The initial sigma was to the outer end of the spectrum
+			// and the matrix is definite, so there is no need to
+			// retreat.
+			tau = spdiam*eps*float64(n) + 2*pivmin
+			tau = math.Max(tau, 2*eps*math.Abs(sigma))
+		} else {
+			if mb > 1 {
+				clwdth := w[wend] + werr[wend] - w[wbegin] - werr[wbegin]
+				avgap := math.Abs(clwdth / float64(wend-wbegin))
+				if sgndef == 1 {
+					tau = 0.5 * math.Max(wgap[wbegin], avgap)
+					tau = math.Max(tau, werr[wbegin])
+				} else {
+					tau = 0.5 * math.Max(wgap[wend-1], avgap)
+					tau = math.Max(tau, werr[wend])
+				}
+			} else {
+				tau = werr[wbegin]
+			}
+		}
+
+		var found bool
+		for idum := 0; idum < maxtry; idum++ {
+			// Compute the L*D*L^T factorization of T - sigma*I.
+			// D is stored in work[:in], L in work[in:2*in] and the
+			// reciprocals of the pivots in work[2*in:3*in].
+			dpivot := d[ibegin] - sigma
+			work[0] = dpivot
+			dmax := math.Abs(work[0])
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				work[2*in+i] = 1 / work[i]
+				tmp := e[j] * work[2*in+i]
+				work[in+i] = tmp
+				dpivot = (d[j+1] - sigma) - tmp*e[j]
+				work[i+1] = dpivot
+				dmax = math.Max(dmax, math.Abs(dpivot))
+				j++
+			}
+			// Check for element growth.
+			norep := dmax > maxgrowth*spdiam || math.IsNaN(dmax)
+			if usedqd && !norep {
+				// Ensure the definiteness of the representation.
+				// All entries of D must have the same sign.
+				for i := 0; i < in; i++ {
+					if sgndef*work[i] < 0 {
+						norep = true
+						break
+					}
+				}
+			}
+			if !norep {
+				found = true
+				break
+			}
+			// In the case of all eigenvalues the Gerschgorin shift
+			// makes the matrix definite, so this should really only
+			// happen for subsets of eigenvalues.
+			if idum == maxtry-2 {
+				// The fudged Gerschgorin shift should succeed.
+				if sgndef == 1 {
+					sigma = gl - fudge*spdiam*eps*float64(n) - fudge*2*pivmin
+				} else {
+					sigma = gu + fudge*spdiam*eps*float64(n) + fudge*2*pivmin
+				}
+			} else {
+				sigma -= sgndef * tau
+				tau *= 2
+			}
+		}
+		if !found {
+			// No base representation could be found in maxtry
+			// iterations.
+			return nsplit, m, vl, vu, pivmin, false
+		}
+
+		// An initial base representation
+		//  T - sigma*I = L*D*L^T
+		// with not too much element growth has been found. Store the
+		// shift, D and L.
+		e[iend] = sigma
+		copy(d[ibegin:iend+1], work[:in])
+		copy(e[ibegin:iend], work[in:2*in-1])
+
+		if mb > 1 {
+			// Perturb each entry of the base representation by a
+			// small multiple of its own size. This has the effect of
+			// making the eigenvalues computed by dlarrb relatively
+			// robust with respect to the positions of the small
+			// entries which can otherwise cause stagnation in
+			// bisection.
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 2*in-1; i++ {
+				work[i] = 2*rnd.Float64() - 1
+			}
+			for i := 0; i < in-1; i++ {
+				d[ibegin+i] *= 1 + eps*pert*work[i]
+				e[ibegin+i] *= 1 + eps*pert*work[in+i]
+			}
+			d[iend] *= 1 + eps*4*work[in-1]
+		}
+
+		// Compute the required eigenvalues of L*D*L^T by bisection or
+		// dqds.
+		if !usedqd {
+			// Shift the eigenvalue approximations from dlarrd
+			// according to their representation. This is necessary
+			// for a uniform dlarrv since dqds computes eigenvalues of
+			// the shifted representation.
+			for j := wbegin; j <= wend; j++ {
+				w[j] -= sigma
+				werr[j] += math.Abs(w[j]) * eps
+			}
+			// Use bisection to refine the eigenvalues from indl to
+			// indu.
+			for i := ibegin; i < iend; i++ {
+				work[i] = d[i] * e[i] * e[i]
+			}
+			dlarrb(in, d[ibegin:], work[ibegin:], indl, indu, rtol1, rtol2, indl,
+				w[wbegin:], wgap[wbegin:], werr[wbegin:], work[2*n:], iwork, pivmin, spdiam, in-1)
+			// dlarrb computes all gaps correctly except for the last
+			// one. Record the distance to vu.
+			wgap[wend] = math.Max(0, (vu-sigma)-(w[wend]+werr[wend]))
+			for i := indl; i <= indu; i++ {
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+		} else {
+			// Call dqds to get all eigenvalues of the block and
+			// then possibly delete the unwanted ones. dqds finds the
+			// eigenvalues of L*D*L^T to high relative accuracy.
+			//
+			// rtol is of the order of the tolerance used by dqds.
+			// This is an estimated error, the worst case bound is
+			// 4*n*eps which is usually too large and requires
+			// unnecessary work to be done by bisection when computing
+			// the eigenvectors.
+			rtol := math.Log(float64(in)) * 4 * eps
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				work[2*i] = math.Abs(d[j])
+				work[2*i+1] = e[j] * e[j] * work[2*i]
+				j++
+			}
+			work[2*(in-1)] = math.Abs(d[iend])
+			work[2*in-1] = 0
+			if impl.Dlasq2(in, work) != 0 {
+				return nsplit, m, vl, vu, pivmin, false
+			}
+			// Test that all eigenvalues are positive as expected.
+			for i := 0; i < in; i++ {
+				if work[i] < 0 {
+					return nsplit, m, vl, vu, pivmin, false
+				}
+			}
+			// The eigenvalues are returned by dqds in decreasing
+			// order.
+			for i := indl; i <= indu; i++ {
+				if sgndef > 0 {
+					w[m] = work[in-1-i]
+				} else {
+					w[m] = -work[i]
+				}
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+			for i := m - mb; i < m; i++ {
+				werr[i] = rtol * math.Abs(w[i])
+			}
+			for i := m - mb; i < m-1; i++ {
+				// Compute the right gap between the intervals.
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[m-1] = math.Max(0, (vu-sigma)-(w[m-1]+werr[m-1]))
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return nsplit, m, vl, vu, pivmin, true
+}
@@ -0,0 +1,219 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrf finds a new relatively robust representation
+//  L(+)*D(+)*L(+)^T = L*D*L^T - sigma*I
+// such that at least one of the eigenvalues of L*D*L^T with indices clstrt
+// through clend, indexed from zero, is relatively isolated. The eigenvalues
+// form a cluster with approximations in w, semi-widths of the uncertainty
+// intervals in werr and gaps in wgap.
+//
+// d contains the n diagonal elements of D, l the n-1 subdiagonal elements of
+// L and ld the n-1 elements l_i*d_i. spdiam is an estimate of the spectral
+// diameter, clgapl and clgapr are the gaps to the left and right of the
+// cluster.
+//
+// On return, dplus and lplus contain the n diagonal elements of D(+) and
+// the n-1 subdiagonal elements of L(+). ok is false if no representation
+// with acceptable element growth could be found.
+//
+// work must have length at least 2*n.
+func dlarrf(n int, d, l, ld []float64, clstrt, clend int, w, wgap, werr []float64, spdiam, clgapl, clgapr, pivmin float64, dplus, lplus, work []float64) (sigma float64, ok bool) {
+	const (
+		maxgrowth1 = 8
+		maxgrowth2 = 8
+		ktrymax    = 1
+		fact       = 1 << ktrymax
+	)
+
+	if n <= 0 {
+		return 0, true
+	}
+	eps := dlamchP
+	var forcer bool
+
+	// Compute the average gap length of the cluster.
+	clwdth := math.Abs(w[clend]-w[clstrt]) + werr[clend] + werr[clstrt]
+	avgap := clwdth / float64(clend-clstrt)
+	mingap := math.Min(clgapl, clgapr)
+
+	// Initial values for shifts to both ends of the cluster. A small
+	// fudge makes sure that we really shift to the outside.
+	lsigma := math.Min(w[clstrt], w[clend]) - werr[clstrt]
+	rsigma := math.Max(w[clstrt], w[clend]) + werr[clend]
+	lsigma -= math.Abs(lsigma) * 4 * eps
+	rsigma += math.Abs(rsigma) * 4 * eps
+
+	// Upper bounds for how much to back off the initial shifts.
+	ldmax := 0.25*mingap + 2*pivmin
+	rdmax := 0.25*mingap + 2*pivmin
+
+	ldelta := math.Max(avgap, wgap[clstrt]) / fact
+	rdelta := math.Max(avgap, wgap[clend-1]) / fact
+
+	// Initialize the record of the best representation found.
+	smlgrowth := 1 / dlamchS
+	fail := float64(n-1) * mingap / (spdiam * eps)
+	fail2 := float64(n-1) * mingap / (spdiam * math.Sqrt(eps))
+	bestshift := lsigma
+
+	// The factorization for the right end of the cluster is stored in
+	// work, with D(+) in work[:n] and L(+) in work[n:2*n-1].
+	rdplus := work[:n]
+	rlplus := work[n : 2*n]
+
+	const (
+		sleft = iota + 1
+		sright
+	)
+	var shift int
+	growthbound := maxgrowth1 * spdiam
+	for ktry := 0; ; {
+		// Ensure that we do not back off too much of the initial
+		// shifts.
+		ldelta = math.Min(ldmax, ldelta)
+		rdelta = math.Min(rdmax, rdelta)
+
+		// Compute the element growth when shifting to both ends of the
+		// cluster. Accept the shift if there is no element growth at
+		// one of the two ends.
+
+		// Left end.
+		max1, sawnan1 := dlarrfShift(n, d, l, ld, lsigma, pivmin, dplus, lplus)
+		if forcer || (max1 <= growthbound && !sawnan1) {
+			sigma = lsigma
+			shift = sleft
+			break
+		}
+
+		// Right end.
+		max2, sawnan2 := dlarrfShift(n, d, l, ld, rsigma, pivmin, rdplus, rlplus)
+		if forcer || (max2 <= growthbound && !sawnan2) {
+			sigma = rsigma
+			shift = sright
+			break
+		}
+
+		// Both shifts led to too much element growth. Record the
+		// better of the two shifts, provided it didn't lead to NaN.
+		if !sawnan1 || !sawnan2 {
+			var indx int
+			if !sawnan1 {
+				indx = 1
+				if max1 <= smlgrowth {
+					smlgrowth = max1
+					bestshift = lsigma
+				}
+			}
+			if !sawnan2 {
+				if sawnan1 || max2 <= max1 {
+					indx = 2
+				}
+				if max2 <= smlgrowth {
+					smlgrowth = max2
+					bestshift = rsigma
+				}
+			}
+
+			// If the element growth is moderate, the representation
+			// may still be accepted if it passes a refined test for
+			// RRR. The test is only used for isolated clusters
+			// without NaNs.
+			if clwdth < mingap/128 && math.Min(max1, max2) < fail2 && !sawnan1 && !sawnan2 {
+				if indx == 1 {
+					if dlarrfRRR(n, dplus, lplus, spdiam) <= maxgrowth2 {
+						sigma = lsigma
+						shift = sleft
+						break
+					}
+				} else {
+					if dlarrfRRR(n, rdplus, rlplus, spdiam) <= maxgrowth2 {
+						sigma = rsigma
+						shift = sright
+						break
+					}
+				}
+			}
+		}
+
+		if ktry < ktrymax {
+			// Both shifts failed also the RRR test. Back off to the
+			// outside.
+			lsigma = math.Max(lsigma-ldelta, lsigma-ldmax)
+			rsigma = math.Min(rsigma+rdelta, rsigma+rdmax)
+			ldelta *= 2
+			rdelta *= 2
+			ktry++
+			continue
+		}
+		// None of the representations investigated satisfied the
+		// criteria. Take the best one found.
+		if smlgrowth < fail {
+			lsigma = bestshift
+			rsigma = bestshift
+			forcer = true
+			continue
+		}
+		return 0, false
+	}
+
+	if shift == sright {
+		// Store the new L and D.
+		copy(dplus[:n], rdplus)
+		copy(lplus[:n-1], rlplus[:n-1])
+	}
+	return sigma, true
+}
+
+// dlarrfShift computes the factorization
+//  L(+)*D(+)*L(+)^T = L*D*L^T - sigma*I
+// storing D(+) in dplus and L(+) in lplus. Pivots smaller than pivmin are
+// replaced by -pivmin. It returns the element growth max|D(+)| and whether
+// a small pivot or NaN was encountered.
+func dlarrfShift(n int, d, l, ld []float64, sigma, pivmin float64, dplus, lplus []float64) (growth float64, sawnan bool) {
+	s := -sigma
+	dplus[0] = d[0] + s
+	if math.Abs(dplus[0]) < pivmin {
+		dplus[0] = -pivmin
+		// The refined RRR test should not be used in this case.
+		sawnan = true
+	}
+	growth = math.Abs(dplus[0])
+	for i := 0; i < n-1; i++ {
+		lplus[i] = ld[i] / dplus[i]
+		s = s*lplus[i]*l[i] - sigma
+		dplus[i+1] = d[i+1] + s
+		if math.Abs(dplus[i+1]) < pivmin {
+			dplus[i+1] = -pivmin
+			sawnan = true
+		}
+		growth = math.Max(growth, math.Abs(dplus[i+1]))
+	}
+	return growth, sawnan || math.IsNaN(growth)
+}
+
+// dlarrfRRR returns the refined measure of the element growth of the
+// factorization L(+)*D(+)*L(+)^T used by dlarrf.
+func dlarrfRRR(n int, dplus, lplus []float64, spdiam float64) float64 {
+	eps := dlamchP
+	tmp := math.Abs(dplus[n-1])
+	znm2 := 1.0
+	prod := 1.0
+	oldp := 1.0
+	for i := n - 2; i >= 0; i-- {
+		if prod <= eps {
+			prod = dplus[i+1] * lplus[i+1] / (dplus[i] * lplus[i]) * oldp
+		} else {
+			prod *= math.Abs(lplus[i])
+		}
+		oldp = prod
+		znm2 += prod * prod
+		tmp = math.Max(tmp, math.Abs(dplus[i]*prod))
+	}
+	return tmp / (spdiam * math.Sqrt(znm2))
+}
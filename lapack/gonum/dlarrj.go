@@ -0,0 +1,110 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrj refines, by bisection, the eigenvalue approximations of the n×n
+// symmetric tridiagonal matrix T with diagonal d and squared off-diagonal
+// elements e2 so that they have relative accuracy rtol with respect to T.
+//
+// The eigenvalues to refine are those with the indices ifirst through ilast,
+// indexed from zero. The approximation of the i-th eigenvalue is stored in
+// w[i-offset] with an error bound werr[i-offset], and both are updated on
+// return.
+//
+// work must have length at least 2*n and iwork at least 2*n.
+func dlarrj(n int, d, e2 []float64, ifirst, ilast int, rtol float64, offset int, w, werr []float64, work []float64, iwork []int, pivmin, spdiam float64) {
+	if n <= 0 {
+		return
+	}
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	// count returns the number of eigenvalues of T less than s.
+	count := func(s float64) int {
+		var cnt int
+		dplus := d[0] - s
+		if dplus < 0 {
+			cnt++
+		}
+		for j := 1; j < n; j++ {
+			dplus = d[j] - s - e2[j-1]/dplus
+			if dplus < 0 {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	// The interval of the i-th eigenvalue is [work[2*i], work[2*i+1]].
+	// iwork[2*i] is 1 for unconverged intervals, -1 for intervals that
+	// converged initially and 0 for intervals that converged during the
+	// bisection.
+	var nint int
+	for i := ifirst; i <= ilast; i++ {
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		width := right - w[ii]
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		if width < rtol*tmp {
+			// The interval has already converged and does not
+			// need refinement.
+			iwork[2*i] = -1
+		} else {
+			// Make sure that [left,right] contains the desired
+			// eigenvalue.
+			back := math.Max(werr[ii], pivmin)
+			fac := 1.0
+			for count(left) > i {
+				left -= back * fac
+				fac *= 2
+			}
+			fac = 1
+			for count(right) <= i {
+				right += back * fac
+				fac *= 2
+			}
+			nint++
+			iwork[2*i] = 1
+		}
+		work[2*i] = left
+		work[2*i+1] = right
+	}
+
+	// Bisect the unconverged intervals. In the last iteration all
+	// intervals are accepted since this is the best that can be done.
+	for iter := 0; nint > 0 && iter <= maxitr; iter++ {
+		for i := ifirst; i <= ilast; i++ {
+			if iwork[2*i] != 1 {
+				continue
+			}
+			left := work[2*i]
+			right := work[2*i+1]
+			mid := 0.5 * (left + right)
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			if width < rtol*tmp || iter == maxitr {
+				nint--
+				iwork[2*i] = 0
+				continue
+			}
+			if count(mid) <= i {
+				work[2*i] = mid
+			} else {
+				work[2*i+1] = mid
+			}
+		}
+	}
+
+	// All intervals marked by 0 have been refined.
+	for i := ifirst; i <= ilast; i++ {
+		if iwork[2*i] == 0 {
+			ii := i - offset
+			w[ii] = 0.5 * (work[2*i] + work[2*i+1])
+			werr[ii] = work[2*i+1] - w[ii]
+		}
+	}
+}
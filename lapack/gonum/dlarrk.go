@@ -0,0 +1,44 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrk computes the iw-th eigenvalue, indexed from zero in ascending order,
+// of the n×n symmetric tridiagonal matrix T by bisection. d contains the
+// diagonal and e the off-diagonal elements of T, and [gl,gu] is an interval
+// that contains all eigenvalues of T, for example the Gerschgorin interval.
+//
+// The eigenvalue is located to a relative accuracy of reltol, and on return
+// it lies in [w-werr, w+werr]. ok is false if the bisection did not converge
+// in the maximum number of iterations.
+func dlarrk(n, iw int, gl, gu float64, d, e []float64, pivmin, reltol float64) (w, werr float64, ok bool) {
+	const fudge = 2
+
+	eps := dlamchP
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	atoli := fudge * 2 * pivmin
+	itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	left := gl - fudge*tnorm*eps*float64(n) - fudge*2*pivmin
+	right := gu + fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+	for it := 0; ; it++ {
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		if math.Abs(right-left) < math.Max(math.Max(atoli, pivmin), reltol*tmp) {
+			ok = true
+			break
+		}
+		if it > itmax {
+			break
+		}
+		mid := 0.5 * (left + right)
+		if dsturm(n, d, e, mid, pivmin) > iw {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+	return 0.5 * (left + right), 0.5 * math.Abs(right-left), ok
+}
@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrr reports whether the n×n symmetric tridiagonal matrix T with diagonal
+// d and off-diagonal e warrants the more expensive computations which
+// guarantee high relative accuracy of its eigenvalues.
+//
+// The test is for scaled diagonal dominance with a relative condition number
+// of at most 1000, that is, when the diagonal of T is scaled to one the sum
+// of any two adjacent off-diagonal elements is less than 0.999.
+func dlarrr(n int, d, e []float64) bool {
+	const relcond = 0.999
+
+	if n <= 0 {
+		return true
+	}
+	rmin := math.Sqrt(dlamchS / dlamchP)
+	var offdig float64
+	tmp := math.Sqrt(math.Abs(d[0]))
+	if tmp < rmin {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		tmp2 := math.Sqrt(math.Abs(d[i]))
+		if tmp2 < rmin {
+			return false
+		}
+		offdig2 := math.Abs(e[i-1]) / (tmp * tmp2)
+		if offdig+offdig2 >= relcond {
+			return false
+		}
+		tmp = tmp2
+		offdig = offdig2
+	}
+	return true
+}
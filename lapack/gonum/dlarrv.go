@@ -0,0 +1,465 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// dlarrv computes the eigenvectors of the n×n symmetric tridiagonal matrix T
+// corresponding to the m eigenvalues approximated by dlarre. The eigenvectors
+// of each unreduced block of T are computed from the relatively robust
+// representation L*D*L^T of the block found by dlarre, using further
+// representations of clusters of close eigenvalues as necessary.
+//
+// d and l contain the diagonals of D and the subdiagonals of L of the root
+// representations with the shifts stored in l at the index of the last row
+// of each block, as returned by dlarre. pivmin, isplit, w, werr, wgap,
+// iblock, indexw and gers are as returned by dlarre and all desired
+// eigenvalues lie in (vl,vu]. minrgp is the minimum relative gap between
+// eigenvalues that are computed as singletons. rtol1 and rtol2 are the
+// tolerances for the refinement of the eigenvalues by bisection, see dlarrb.
+//
+// On return, w contains the refined eigenvalues of T, and werr and wgap are
+// updated accordingly. The first m columns of the n×m matrix Z contain the
+// orthonormal eigenvectors. The support of the i-th eigenvector, the indices
+// of its first and last non-zero elements, is stored in isuppz[2*i] and
+// isuppz[2*i+1]. d and l are overwritten.
+//
+// work must have length at least 10*n and iwork at least 5*n. dlarrv returns
+// whether all eigenvectors were computed successfully.
+func (impl Implementation) dlarrv(n int, vl, vu float64, d, l []float64, pivmin float64, isplit []int, m int, minrgp, rtol1, rtol2 float64, w, werr, wgap []float64, iblock, indexw []int, gers, z []float64, ldz int, isuppz []int, work []float64, iwork []int) (ok bool) {
+	const maxitr = 10
+
+	if n <= 0 || m <= 0 {
+		return true
+	}
+
+	// The first n elements of work hold the eigenvalues of the current
+	// representation. ld and lld hold the elements l_i*d_i and l_i*l_i*d_i
+	// of the current representation. The remainder is scratch space for
+	// the auxiliary routines, the new representations found by dlarrf
+	// and the eigenvector being computed.
+	ld := work[n : 2*n]
+	lld := work[2*n : 3*n]
+	wrk := work[3*n : 7*n]
+	dplus := work[7*n : 8*n]
+	lplus := work[8*n : 9*n]
+	zvec := work[9*n : 10*n]
+
+	// iwork[:n] holds the twist indices of the factorizations used to
+	// compute the eigenvectors. The clusters of the current level of the
+	// representation tree and of the next level are stored alternately
+	// in iwork[n:2*n] and iwork[2*n:3*n], each as a pair of indices of
+	// its first and last eigenvalue.
+	twist := iwork[:n]
+	for i := range twist {
+		twist[i] = -1
+	}
+	iwrk := iwork[3*n : 5*n]
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			z[i*ldz+j] = 0
+		}
+	}
+
+	eps := dlamchP
+	rqtol := 2 * eps
+
+	// The eigenvalues with indices wbegin through wend in w, werr and
+	// wgap belong to the current block, which spans rows ibegin through
+	// iend of T.
+	var ibegin, wbegin int
+	for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+		iend := isplit[jblk]
+		sigma := l[iend]
+
+		// Find the eigenvalues of the current block.
+		wend := wbegin - 1
+		for wend < m-1 && iblock[wend+1] == jblk {
+			wend++
+		}
+		if wend < wbegin {
+			ibegin = iend + 1
+			continue
+		}
+
+		// Find the local spectral diameter of the block.
+		gl := gers[2*ibegin]
+		gu := gers[2*ibegin+1]
+		for i := ibegin + 1; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam := gu - gl
+
+		// oldien is the row offset of the current block.
+		oldien := ibegin
+		in := iend - ibegin + 1
+		im := wend - wbegin + 1
+
+		if in == 1 {
+			// A 1×1 block.
+			z[ibegin*ldz+wbegin] = 1
+			isuppz[2*wbegin] = ibegin
+			isuppz[2*wbegin+1] = ibegin
+			w[wbegin] += sigma
+			work[wbegin] = w[wbegin]
+			ibegin = iend + 1
+			wbegin++
+			continue
+		}
+
+		// Store the eigenvalues of the shifted representation in work
+		// and those of T in w. The eigenvalues in work are refined when
+		// necessary as high relative accuracy is required for the
+		// computation of the eigenvectors.
+		copy(work[wbegin:wend+1], w[wbegin:wend+1])
+		for i := wbegin; i <= wend; i++ {
+			w[i] += sigma
+		}
+
+		// Generate the representation tree for the current block
+		// breadth first, starting with a single cluster at the root, and
+		// compute the eigenvectors.
+		var (
+			ndepth int
+			parity = 1
+			nclus  = 1
+			idone  int
+		)
+		iwork[n] = 0
+		iwork[n+1] = im - 1
+		for idone < im {
+			// This is a crude protection against infinitely deep trees.
+			if ndepth > m {
+				return false
+			}
+			oldncl := nclus
+			nclus = 0
+			parity = 1 - parity
+			oldcls, newcls := n, 2*n
+			if parity != 0 {
+				oldcls, newcls = 2*n, n
+			}
+
+			// Process the clusters on the current level.
+			for i := 0; i < oldncl; i++ {
+				// oldfst and oldlst are the indices, relative to
+				// wbegin, of the first and last eigenvalue of the
+				// current cluster.
+				oldfst := iwork[oldcls+2*i]
+				oldlst := iwork[oldcls+2*i+1]
+				if ndepth > 0 {
+					// Retrieve the representation of the cluster
+					// that was computed at the previous level and
+					// stored in the columns of Z at the location of
+					// its leftmost eigenvalue.
+					j := wbegin + oldfst
+					for k := 0; k < in; k++ {
+						d[ibegin+k] = z[(ibegin+k)*ldz+j]
+						z[(ibegin+k)*ldz+j] = 0
+					}
+					for k := 0; k < in-1; k++ {
+						l[ibegin+k] = z[(ibegin+k)*ldz+j+1]
+					}
+					sigma = z[iend*ldz+j+1]
+					for k := 0; k < in; k++ {
+						z[(ibegin+k)*ldz+j+1] = 0
+					}
+				}
+
+				// Compute ld and lld of the current representation.
+				for j := ibegin; j < iend; j++ {
+					tmp := d[j] * l[j]
+					ld[j] = tmp
+					lld[j] = tmp * l[j]
+				}
+
+				if ndepth > 0 {
+					// Perform limited bisection, if necessary, to get
+					// the eigenvalue approximations to the precision
+					// needed.
+					p := indexw[wbegin+oldfst]
+					q := indexw[wbegin+oldlst]
+					offset := indexw[wbegin]
+					dlarrb(in, d[ibegin:], lld[ibegin:], p, q, rtol1, rtol2, offset,
+						work[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, in-1)
+					// Recompute the extremal gaps. w holds all
+					// eigenvalues of the unshifted matrix and must be
+					// used for the gaps since the entries of work may
+					// stem from representations with different shifts.
+					// The gaps are only allowed to grow since this is
+					// what should happen when werr decreases.
+					if oldfst > 0 {
+						k := wbegin + oldfst
+						wgap[k-1] = math.Max(wgap[k-1], w[k]-werr[k]-w[k-1]-werr[k-1])
+					}
+					if wbegin+oldlst < wend {
+						k := wbegin + oldlst
+						wgap[k] = math.Max(wgap[k], w[k+1]-werr[k+1]-w[k]-werr[k])
+					}
+					// Store the refined approximations with all shifts
+					// applied in w.
+					for j := oldfst; j <= oldlst; j++ {
+						w[wbegin+j] = work[wbegin+j] + sigma
+					}
+				}
+
+				// Process the current node.
+				newfst := oldfst
+				for j := oldfst; j <= oldlst; j++ {
+					if j < oldlst && wgap[wbegin+j] < minrgp*math.Abs(work[wbegin+j]) {
+						// Inside a child cluster the relative gap is
+						// not big enough.
+						continue
+					}
+					// The child cluster newfst through newlst is well
+					// separated from the following one.
+					newlst := j
+
+					// newftt is the column of Z where the new
+					// representation or the computed eigenvector is
+					// stored.
+					newftt := wbegin + newfst
+
+					if newlst > newfst {
+						// The child is a cluster. Compute and store its
+						// new representation.
+						//
+						// The left and right gaps of the cluster are
+						// computed from w since it holds the eigenvalues
+						// of the unshifted matrix.
+						var lgap float64
+						if newfst == 0 {
+							lgap = math.Max(0, w[wbegin]-werr[wbegin]-vl)
+						} else {
+							lgap = wgap[wbegin+newfst-1]
+						}
+						rgap := wgap[wbegin+newlst]
+
+						// Compute the leftmost and rightmost eigenvalue
+						// of the child to high precision in order to
+						// shift as close as possible and obtain as large
+						// relative gaps as possible.
+						offset := indexw[wbegin]
+						for _, p := range []int{indexw[wbegin+newfst], indexw[wbegin+newlst]} {
+							dlarrb(in, d[ibegin:], lld[ibegin:], p, p, rqtol, rqtol, offset,
+								work[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, in-1)
+						}
+
+						tau, rok := dlarrf(in, d[ibegin:], l[ibegin:], ld[ibegin:], newfst, newlst,
+							work[wbegin:], wgap[wbegin:], werr[wbegin:], spdiam, lgap, rgap, pivmin, dplus, lplus, wrk)
+						if !rok {
+							return false
+						}
+						// Store the new representation and its shift
+						// in Z.
+						for k := 0; k < in; k++ {
+							z[(ibegin+k)*ldz+newftt] = dplus[k]
+						}
+						for k := 0; k < in-1; k++ {
+							z[(ibegin+k)*ldz+newftt+1] = lplus[k]
+						}
+						z[iend*ldz+newftt+1] = sigma + tau
+						// Shift the eigenvalue approximations and fudge
+						// their errors. The gaps are not fudged: a zero
+						// gap indicates that a new representation is
+						// needed to resolve the cluster.
+						for k := newfst; k <= newlst; k++ {
+							kk := wbegin + k
+							fudge := 3 * eps * math.Abs(work[kk])
+							work[kk] -= tau
+							fudge += 4 * eps * math.Abs(work[kk])
+							werr[kk] += fudge
+						}
+						iwork[newcls+2*nclus] = newfst
+						iwork[newcls+2*nclus+1] = newlst
+						nclus++
+						newfst = j + 1
+						continue
+					}
+
+					// Compute the eigenvector of a singleton.
+					tol := 4 * math.Log(float64(in)) * eps
+					k := newfst
+					windex := wbegin + k
+					windmn := max(windex-1, 0)
+					windpl := min(windex+1, m-1)
+					lambda := work[windex]
+					left := work[windex] - werr[windex]
+					right := work[windex] + werr[windex]
+					indeig := indexw[windex]
+
+					// All eigenvalue approximations of the child are
+					// with respect to the same shift, so the gaps are
+					// computed from work. For the extremal eigenvalues
+					// a small gap is forced to prevent an early
+					// convergence of the Rayleigh quotient iteration
+					// caused by an overestimation of the gap.
+					var lgap, rgap float64
+					if k == 0 {
+						lgap = eps * math.Max(math.Abs(left), math.Abs(right))
+					} else {
+						lgap = wgap[windmn]
+					}
+					if k == im-1 {
+						rgap = eps * math.Max(math.Abs(left), math.Abs(right))
+					} else {
+						rgap = wgap[windex]
+					}
+					gap := math.Min(lgap, rgap)
+					var gaptol float64
+					if k != 0 && k != im-1 {
+						// The support of the eigenvector of an extremal
+						// eigenvalue could otherwise become wrong when
+						// significant entries are cut off.
+						gaptol = gap * eps
+					}
+					isupmn := in - 1
+					isupmx := 0
+
+					// Update wgap so that it holds the minimum gap to
+					// the left or the right. This ensures that bisection
+					// refines the eigenvalue to the required precision.
+					// The correct value is restored afterwards.
+					savgap := wgap[windex]
+					wgap[windex] = gap
+
+					// The Rayleigh quotient correction is used as often
+					// as possible since it converges quadratically close
+					// to the eigenvalue. However, it can have the wrong
+					// sign and lead away from the desired eigenvalue, in
+					// which case bisection is used.
+					for ii := 0; ii < in; ii++ {
+						zvec[ii] = 0
+					}
+					var (
+						usedbs, usedrq, needbs bool
+						bstres, bstw           float64
+						nrminv                 float64
+					)
+					supp := isuppz[2*windex : 2*windex+2]
+					for iter := 0; ; {
+						if needbs {
+							// Take the bisection as the new iterate.
+							usedbs = true
+							offset := indexw[wbegin]
+							dlarrb(in, d[ibegin:], lld[ibegin:], indeig, indeig, 0, 2*eps, offset,
+								work[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, twist[windex])
+							lambda = work[windex]
+							// Reset the twist index to force the
+							// computation of the true mingma.
+							twist[windex] = -1
+						}
+						// Given lambda, compute the eigenvector.
+						negcnt, _, _, r, nrm, resid, rqcorr := dlar1v(in, 0, in-1, lambda, d[ibegin:], l[ibegin:], ld[ibegin:], lld[ibegin:],
+							pivmin, gaptol, zvec, !usedbs, twist[windex], supp, wrk)
+						twist[windex] = r
+						nrminv = nrm
+						if iter == 0 || resid < bstres {
+							bstres = resid
+							bstw = lambda
+						}
+						isupmn = min(isupmn, supp[0])
+						isupmx = max(isupmx, supp[1])
+						iter++
+
+						// Convergence test for the Rayleigh quotient
+						// iteration, omitted if bisection has been used.
+						if resid > tol*gap && math.Abs(rqcorr) > rqtol*math.Abs(lambda) && !usedbs {
+							// Check that the correction doesn't move the
+							// eigenvalue away from the desired one and
+							// towards a neighbor.
+							var sgndef float64
+							if indeig < negcnt {
+								// The wanted eigenvalue lies to the left.
+								sgndef = -1
+							} else {
+								// The wanted eigenvalue lies to the right.
+								sgndef = 1
+							}
+							// Only use the correction if it improves the
+							// iterate reasonably.
+							if rqcorr*sgndef >= 0 && lambda+rqcorr <= right && lambda+rqcorr >= left {
+								usedrq = true
+								// Store the new midpoint of the bisection
+								// interval in work.
+								if sgndef == 1 {
+									left = lambda
+								} else {
+									right = lambda
+								}
+								work[windex] = 0.5 * (right + left)
+								lambda += rqcorr
+								werr[windex] = 0.5 * (right - left)
+							} else {
+								needbs = true
+							}
+							switch {
+							case right-left < rqtol*math.Abs(lambda):
+								// The eigenvalue is computed to bisection
+								// accuracy. Compute the eigenvector and
+								// stop.
+								usedbs = true
+							case iter < maxitr:
+							case iter == maxitr:
+								needbs = true
+							default:
+								return false
+							}
+							continue
+						}
+						if usedrq && usedbs && bstres <= resid {
+							// Improve the error angle by a second step.
+							lambda = bstw
+							_, _, _, r, nrminv, _, _ = dlar1v(in, 0, in-1, lambda, d[ibegin:], l[ibegin:], ld[ibegin:], lld[ibegin:],
+								pivmin, gaptol, zvec, !usedbs, twist[windex], supp, wrk)
+							twist[windex] = r
+						}
+						work[windex] = lambda
+						break
+					}
+
+					// Scale the eigenvector and clear any entries
+					// outside its support left by earlier iterations,
+					// then store it in Z.
+					zfrom, zto := supp[0], supp[1]
+					for ii := isupmn; ii < zfrom; ii++ {
+						zvec[ii] = 0
+					}
+					for ii := zto + 1; ii <= isupmx; ii++ {
+						zvec[ii] = 0
+					}
+					for ii := zfrom; ii <= zto; ii++ {
+						zvec[ii] *= nrminv
+					}
+					for ii := 0; ii < in; ii++ {
+						z[(ibegin+ii)*ldz+windex] = zvec[ii]
+					}
+					// Compute the support with respect to the whole
+					// matrix.
+					supp[0] += oldien
+					supp[1] += oldien
+
+					// Update w and recompute the gaps on the left and
+					// right. They are only allowed to become larger.
+					w[windex] = lambda + sigma
+					if k > 0 {
+						wgap[windmn] = math.Max(wgap[windmn], w[windex]-werr[windex]-w[windmn]-werr[windmn])
+					}
+					if windex < wend {
+						wgap[windex] = math.Max(savgap, w[windpl]-werr[windpl]-w[windex]-werr[windex])
+					}
+					idone++
+					newfst = j + 1
+				}
+			}
+			ndepth++
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return true
+}
@@ -0,0 +1,390 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dlasyf computes a partial factorization of a real symmetric n×n matrix A
+// using the Bunch-Kaufman diagonal pivoting method. It factorizes nb-1 or nb
+// columns of A and updates the remaining part of A with a blocked update.
+//
+// If uplo == blas.Upper, the last kb columns of A are factorized and the
+// leading submatrix A[:n-kb,:n-kb] is updated as
+//  A11 := A11 - U12*D*U12^T,
+// and if uplo == blas.Lower, the first kb columns of A are factorized and the
+// trailing submatrix A[kb:,kb:] is updated as
+//  A22 := A22 - L21*D*L21^T.
+// kb is either nb or nb-1, or n if n <= nb.
+//
+// On entry, a contains the symmetric matrix A in the triangle specified by
+// uplo. On return, the factorized columns of a contain the corresponding
+// block diagonal elements of D and the multipliers used to obtain the factor
+// U or L, and the rest of the triangle contains the updated submatrix.
+//
+// ipiv must have length n and on return contains details of the interchanges
+// and the block structure of D in the format described in the documentation
+// of Dsytf2. Only the elements of ipiv corresponding to the factorized
+// columns are set.
+//
+// w is an n×nb workspace with stride ldw. nb must be at least 2.
+//
+// Dlasyf returns the number of factorized columns kb and whether the factorized
+// part of D is non-singular.
+//
+// Dlasyf is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlasyf(uplo blas.Uplo, n, nb int, a []float64, lda int, ipiv []int, w []float64, ldw int) (kb int, ok bool) {
+	checkMatrix(n, n, a, lda)
+	if nb < 2 {
+		panic(badNb)
+	}
+	checkMatrix(n, nb, w, ldw)
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return 0, true
+	}
+
+	// alpha is used for the choice of pivot block size.
+	alpha := (1 + math.Sqrt(17)) / 8
+
+	bi := blas64.Implementation()
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize the trailing columns of A using the upper triangle of A
+		// and working backwards, and compute the matrix W = U12*D for use
+		// in updating A11.
+		// k is the main loop index, decreasing from n-1 in steps of 1 or 2.
+		// kw is the column of W which corresponds to column k of A.
+		k := n - 1
+		for k >= 0 {
+			if k <= n-nb && nb < n {
+				break
+			}
+			kw := nb + k - n
+
+			// Copy column k of A to column kw of W and update it.
+			bi.Dcopy(k+1, a[k:], lda, w[kw:], ldw)
+			if k < n-1 {
+				bi.Dgemv(blas.NoTrans, k+1, n-k-1, -1, a[k+1:], lda, w[k*ldw+kw+1:], 1, 1, w[kw:], ldw)
+			}
+
+			kstep := 1
+			kp := k
+
+			// Determine the rows and columns to be interchanged
+			// and whether a 1×1 or 2×2 pivot block will be used.
+			absakk := math.Abs(w[k*ldw+kw])
+			var imax int
+			var colmax float64
+			if k > 0 {
+				imax = bi.Idamax(k, w[kw:], ldw)
+				colmax = math.Abs(w[imax*ldw+kw])
+			}
+			if math.Max(absakk, colmax) == 0 || math.IsNaN(absakk) {
+				// The column is zero: set ok and continue.
+				ok = false
+				bi.Dcopy(k+1, w[kw:], ldw, a[k:], lda)
+			} else {
+				if absakk < alpha*colmax {
+					// Copy column imax to column kw-1 of W and update it.
+					bi.Dcopy(imax+1, a[imax:], lda, w[kw-1:], ldw)
+					bi.Dcopy(k-imax, a[imax*lda+imax+1:], 1, w[(imax+1)*ldw+kw-1:], ldw)
+					if k < n-1 {
+						bi.Dgemv(blas.NoTrans, k+1, n-k-1, -1, a[k+1:], lda, w[imax*ldw+kw+1:], 1, 1, w[kw-1:], ldw)
+					}
+
+					// jmax is the column index of the largest
+					// off-diagonal element in row imax.
+					jmax := imax + 1 + bi.Idamax(k-imax, w[(imax+1)*ldw+kw-1:], ldw)
+					rowmax := math.Abs(w[jmax*ldw+kw-1])
+					if imax > 0 {
+						jmax = bi.Idamax(imax, w[kw-1:], ldw)
+						rowmax = math.Max(rowmax, math.Abs(w[jmax*ldw+kw-1]))
+					}
+					switch {
+					case absakk >= alpha*colmax*(colmax/rowmax):
+						// No interchange, use 1×1 pivot block.
+					case math.Abs(w[imax*ldw+kw-1]) >= alpha*rowmax:
+						// Interchange rows and columns k and imax,
+						// use 1×1 pivot block.
+						kp = imax
+						// Copy column kw-1 of W to column kw.
+						bi.Dcopy(k+1, w[kw-1:], ldw, w[kw:], ldw)
+					default:
+						// Interchange rows and columns k-1 and imax,
+						// use 2×2 pivot block.
+						kp = imax
+						kstep = 2
+					}
+				}
+
+				// kk is the column of A where pivoting step stopped.
+				kk := k - kstep + 1
+				// kkw is the column of W which corresponds to column kk of A.
+				kkw := nb + kk - n
+
+				// Interchange rows and columns kp and kk. The updated
+				// column kp is already stored in column kkw of W.
+				if kp != kk {
+					// Copy the non-updated column kk to column kp of A.
+					// Columns k (and k-1 for a 2×2 pivot) of A are
+					// overwritten below.
+					a[kp*lda+kp] = a[kk*lda+kk]
+					bi.Dcopy(kk-1-kp, a[(kp+1)*lda+kk:], lda, a[kp*lda+kp+1:], 1)
+					if kp > 0 {
+						bi.Dcopy(kp, a[kk:], lda, a[kp:], lda)
+					}
+					// Interchange rows kk and kp in the last n-k-1
+					// columns of A and in the last nb-kkw columns of W.
+					if k < n-1 {
+						bi.Dswap(n-k-1, a[kk*lda+k+1:], 1, a[kp*lda+k+1:], 1)
+					}
+					bi.Dswap(n-kk, w[kk*ldw+kkw:], 1, w[kp*ldw+kkw:], 1)
+				}
+
+				if kstep == 1 {
+					// Column kw of W now holds W_k = U_k*D_k. Store
+					// U_k and the 1×1 block D_k in column k of A.
+					bi.Dcopy(k+1, w[kw:], ldw, a[k:], lda)
+					r1 := 1 / a[k*lda+k]
+					bi.Dscal(k, r1, a[k:], lda)
+				} else {
+					// Columns kw-1 and kw of W now hold
+					//  (W_{k-1} W_k) = (U_{k-1} U_k)*D_k.
+					// Store U_{k-1} and U_k in columns k-1 and k of A.
+					if k > 1 {
+						d21 := w[(k-1)*ldw+kw]
+						d11 := w[k*ldw+kw] / d21
+						d22 := w[(k-1)*ldw+kw-1] / d21
+						t := 1 / (d11*d22 - 1)
+						d21 = t / d21
+						for j := 0; j < k-1; j++ {
+							a[j*lda+k-1] = d21 * (d11*w[j*ldw+kw-1] - w[j*ldw+kw])
+							a[j*lda+k] = d21 * (d22*w[j*ldw+kw] - w[j*ldw+kw-1])
+						}
+					}
+					// Copy the 2×2 block D_k to A.
+					a[(k-1)*lda+k-1] = w[(k-1)*ldw+kw-1]
+					a[(k-1)*lda+k] = w[(k-1)*ldw+kw]
+					a[k*lda+k] = w[k*ldw+kw]
+				}
+			}
+
+			// Store details of the interchanges in ipiv.
+			if kstep == 1 {
+				ipiv[k] = kp
+			} else {
+				ipiv[k] = -kp - 1
+				ipiv[k-1] = -kp - 1
+			}
+			k -= kstep
+		}
+
+		kw := nb + k - n
+
+		// Update the upper triangle of A11 = A[:k+1,:k+1] as
+		//  A11 := A11 - U12*D*U12^T = A11 - U12*W^T
+		// computing blocks of nb columns at a time.
+		for j := (k / nb) * nb; j >= 0; j -= nb {
+			jb := min(nb, k-j+1)
+			// Update the upper triangle of the diagonal block.
+			for jj := j; jj < j+jb; jj++ {
+				bi.Dgemv(blas.NoTrans, jj-j+1, n-k-1, -1, a[j*lda+k+1:], lda, w[jj*ldw+kw+1:], 1,
+					1, a[j*lda+jj:], lda)
+			}
+			// Update the rectangular superdiagonal block.
+			if j > 0 {
+				bi.Dgemm(blas.NoTrans, blas.Trans, j, jb, n-k-1, -1, a[k+1:], lda, w[j*ldw+kw+1:], ldw,
+					1, a[j:], lda)
+			}
+		}
+
+		// Put U12 in standard form by partially undoing the interchanges
+		// in columns k+1:n.
+		for j := k + 1; j < n-1; {
+			jj := j
+			jp := ipiv[j]
+			if jp < 0 {
+				jp = -jp - 1
+				j++
+			}
+			j++
+			if jp != jj && j < n {
+				bi.Dswap(n-j, a[jp*lda+j:], 1, a[jj*lda+j:], 1)
+			}
+		}
+		return n - k - 1, ok
+	}
+
+	// Factorize the leading columns of A using the lower triangle of A and
+	// working forwards, and compute the matrix W = L21*D for use in updating
+	// A22.
+	// k is the main loop index, increasing from 0 in steps of 1 or 2.
+	k := 0
+	for k < n {
+		if k >= nb-1 && nb < n {
+			break
+		}
+
+		// Copy column k of A to column k of W and update it.
+		bi.Dcopy(n-k, a[k*lda+k:], lda, w[k*ldw+k:], ldw)
+		if k > 0 {
+			bi.Dgemv(blas.NoTrans, n-k, k, -1, a[k*lda:], lda, w[k*ldw:], 1, 1, w[k*ldw+k:], ldw)
+		}
+
+		kstep := 1
+		kp := k
+
+		// Determine the rows and columns to be interchanged
+		// and whether a 1×1 or 2×2 pivot block will be used.
+		absakk := math.Abs(w[k*ldw+k])
+		var imax int
+		var colmax float64
+		if k < n-1 {
+			imax = k + 1 + bi.Idamax(n-k-1, w[(k+1)*ldw+k:], ldw)
+			colmax = math.Abs(w[imax*ldw+k])
+		}
+		if math.Max(absakk, colmax) == 0 || math.IsNaN(absakk) {
+			// The column is zero: set ok and continue.
+			ok = false
+			bi.Dcopy(n-k, w[k*ldw+k:], ldw, a[k*lda+k:], lda)
+		} else {
+			if absakk < alpha*colmax {
+				// Copy column imax to column k+1 of W and update it.
+				bi.Dcopy(imax-k, a[imax*lda+k:], 1, w[k*ldw+k+1:], ldw)
+				bi.Dcopy(n-imax, a[imax*lda+imax:], lda, w[imax*ldw+k+1:], ldw)
+				if k > 0 {
+					bi.Dgemv(blas.NoTrans, n-k, k, -1, a[k*lda:], lda, w[imax*ldw:], 1, 1, w[k*ldw+k+1:], ldw)
+				}
+
+				// jmax is the column index of the largest
+				// off-diagonal element in row imax.
+				jmax := k + bi.Idamax(imax-k, w[k*ldw+k+1:], ldw)
+				rowmax := math.Abs(w[jmax*ldw+k+1])
+				if imax < n-1 {
+					jmax = imax + 1 + bi.Idamax(n-imax-1, w[(imax+1)*ldw+k+1:], ldw)
+					rowmax = math.Max(rowmax, math.Abs(w[jmax*ldw+k+1]))
+				}
+				switch {
+				case absakk >= alpha*colmax*(colmax/rowmax):
+					// No interchange, use 1×1 pivot block.
+				case math.Abs(w[imax*ldw+k+1]) >= alpha*rowmax:
+					// Interchange rows and columns k and imax,
+					// use 1×1 pivot block.
+					kp = imax
+					// Copy column k+1 of W to column k.
+					bi.Dcopy(n-k, w[k*ldw+k+1:], ldw, w[k*ldw+k:], ldw)
+				default:
+					// Interchange rows and columns k+1 and imax,
+					// use 2×2 pivot block.
+					kp = imax
+					kstep = 2
+				}
+			}
+
+			// kk is the column of A where pivoting step stopped.
+			kk := k + kstep - 1
+
+			// Interchange rows and columns kp and kk. The updated
+			// column kp is already stored in column kk of W.
+			if kp != kk {
+				// Copy the non-updated column kk to column kp of A.
+				// Columns k (and k+1 for a 2×2 pivot) of A are
+				// overwritten below.
+				a[kp*lda+kp] = a[kk*lda+kk]
+				bi.Dcopy(kp-kk-1, a[(kk+1)*lda+kk:], lda, a[kp*lda+kk+1:], 1)
+				if kp < n-1 {
+					bi.Dcopy(n-kp-1, a[(kp+1)*lda+kk:], lda, a[(kp+1)*lda+kp:], lda)
+				}
+				// Interchange rows kk and kp in the first k columns
+				// of A and in the first kk+1 columns of W.
+				if k > 0 {
+					bi.Dswap(k, a[kk*lda:], 1, a[kp*lda:], 1)
+				}
+				bi.Dswap(kk+1, w[kk*ldw:], 1, w[kp*ldw:], 1)
+			}
+
+			if kstep == 1 {
+				// Column k of W now holds W_k = L_k*D_k. Store
+				// L_k and the 1×1 block D_k in column k of A.
+				bi.Dcopy(n-k, w[k*ldw+k:], ldw, a[k*lda+k:], lda)
+				if k < n-1 {
+					r1 := 1 / a[k*lda+k]
+					bi.Dscal(n-k-1, r1, a[(k+1)*lda+k:], lda)
+				}
+			} else {
+				// Columns k and k+1 of W now hold
+				//  (W_k W_{k+1}) = (L_k L_{k+1})*D_k.
+				// Store L_k and L_{k+1} in columns k and k+1 of A.
+				if k < n-2 {
+					d21 := w[(k+1)*ldw+k]
+					d11 := w[(k+1)*ldw+k+1] / d21
+					d22 := w[k*ldw+k] / d21
+					t := 1 / (d11*d22 - 1)
+					d21 = t / d21
+					for j := k + 2; j < n; j++ {
+						a[j*lda+k] = d21 * (d11*w[j*ldw+k] - w[j*ldw+k+1])
+						a[j*lda+k+1] = d21 * (d22*w[j*ldw+k+1] - w[j*ldw+k])
+					}
+				}
+				// Copy the 2×2 block D_k to A.
+				a[k*lda+k] = w[k*ldw+k]
+				a[(k+1)*lda+k] = w[(k+1)*ldw+k]
+				a[(k+1)*lda+k+1] = w[(k+1)*ldw+k+1]
+			}
+		}
+
+		// Store details of the interchanges in ipiv.
+		if kstep == 1 {
+			ipiv[k] = kp
+		} else {
+			ipiv[k] = -kp - 1
+			ipiv[k+1] = -kp - 1
+		}
+		k += kstep
+	}
+
+	// Update the lower triangle of A22 = A[k:,k:] as
+	//  A22 := A22 - L21*D*L21^T = A22 - L21*W^T
+	// computing blocks of nb columns at a time.
+	for j := k; j < n; j += nb {
+		jb := min(nb, n-j)
+		// Update the lower triangle of the diagonal block.
+		for jj := j; jj < j+jb; jj++ {
+			bi.Dgemv(blas.NoTrans, j+jb-jj, k, -1, a[jj*lda:], lda, w[jj*ldw:], 1,
+				1, a[jj*lda+jj:], lda)
+		}
+		// Update the rectangular subdiagonal block.
+		if j+jb < n {
+			bi.Dgemm(blas.NoTrans, blas.Trans, n-j-jb, jb, k, -1, a[(j+jb)*lda:], lda, w[j*ldw:], ldw,
+				1, a[(j+jb)*lda+j:], lda)
+		}
+	}
+
+	// Put L21 in standard form by partially undoing the interchanges
+	// in columns 0:k.
+	for j := k - 1; j > 0; {
+		jj := j
+		jp := ipiv[j]
+		if jp < 0 {
+			jp = -jp - 1
+			j--
+		}
+		j--
+		if jp != jj && j >= 0 {
+			bi.Dswap(j+1, a[jp*lda:], 1, a[jj*lda:], 1)
+		}
+	}
+	return k, ok
+}
@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dpbcon estimates the reciprocal of the condition number of an n×n
+// symmetric positive definite band matrix A with kd super-diagonals (or
+// sub-diagonals) in the 1-norm, using the Cholesky factorization computed by
+// Dpbtrf. The condition number computed is
+//  1 / (|A|_1 * |A^-1|_1),
+// where an estimate of |A^-1|_1 is obtained with Dlacn2.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dpbcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dpbcon will panic
+// otherwise.
+func (impl Implementation) Dpbcon(ul blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64 {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBanded(ab, n, kd, ldab)
+	if anorm < 0 {
+		panic("lapack: anorm < 0")
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 1
+	}
+	if anorm == 0 {
+		return 0
+	}
+
+	// Estimate the 1-norm of the inverse.
+	var ainvnm float64
+	var kase int
+	isave := new([3]int)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, isave)
+		if kase == 0 {
+			break
+		}
+		// Multiply by inv(A) which is symmetric.
+		impl.Dpbtrs(ul, n, kd, 1, ab, ldab, work, 1)
+	}
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dpbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A with kd super-diagonals (or sub-diagonals). The
+// factorization has the form
+//  A = U^T * U if ul == blas.Upper
+//  A = L * L^T if ul == blas.Lower
+// The storage of A in ab and of the factor on return is described in the
+// documentation of Dpbtf2.
+//
+// Dpbtrf returns whether the factorization was successfully completed. If ok
+// is false, A is not positive definite.
+//
+// Dpbtrf is the blocked version of the algorithm, see Dpbtf2 for the unblocked
+// version.
+func (impl Implementation) Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBanded(ab, n, kd, ldab)
+	if n == 0 {
+		return true
+	}
+
+	const nbmax = 32
+	nb := min(impl.Ilaenv(1, "DPBTRF", " ", n, kd, -1, -1), nbmax)
+	if nb <= 1 || kd < nb {
+		// Use unblocked code.
+		return impl.Dpbtf2(ul, n, kd, ab, ldab)
+	}
+
+	// Any submatrix of A that lies within the band is stored in ab
+	// as a general matrix with stride ldab-1. The part of A outside
+	// the band that is updated by a block step is copied into work.
+	bi := blas64.Implementation()
+	kld := ldab - 1
+	ldwork := nb
+	work := make([]float64, nb*ldwork)
+
+	if ul == blas.Upper {
+		// A[r,c] is stored in ab[r*ldab+c-r].
+		for i := 0; i < n; i += nb {
+			ib := min(nb, n-i)
+
+			// Factorize the diagonal block.
+			if !impl.Dpotf2(ul, ib, ab[i*ldab:], kld) {
+				return false
+			}
+			if i+ib >= n {
+				break
+			}
+
+			// Update the relevant part of the trailing submatrix.
+			// The off-diagonal block is partitioned as
+			//  [ A12 A13 ]
+			// where A12 has i2 columns and lies within the band and
+			// A13 has i3 columns and only its lower triangle lies
+			// within the band.
+			i2 := min(kd-ib, n-i-ib)
+			i3 := min(ib, n-i-kd)
+
+			if i2 > 0 {
+				// Compute U12 = U11^{-T} * A12.
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, ib, i2,
+					1, ab[i*ldab:], kld, ab[i*ldab+ib:], kld)
+				// Update A22 -= U12^T * U12.
+				bi.Dsyrk(blas.Upper, blas.Trans, i2, ib,
+					-1, ab[i*ldab+ib:], kld, 1, ab[(i+ib)*ldab:], kld)
+			}
+
+			if i3 > 0 {
+				// Copy the lower triangle of A13 into work.
+				for ii := 0; ii < ib; ii++ {
+					for jj := 0; jj <= min(ii, i3-1); jj++ {
+						work[ii*ldwork+jj] = ab[i*ldab+kd+ii*kld+jj]
+					}
+				}
+
+				// Compute U13 = U11^{-T} * A13.
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, ib, i3,
+					1, ab[i*ldab:], kld, work, ldwork)
+				if i2 > 0 {
+					// Update A23 -= U12^T * U13.
+					bi.Dgemm(blas.Trans, blas.NoTrans, i2, i3, ib,
+						-1, ab[i*ldab+ib:], kld, work, ldwork, 1, ab[(i+ib)*ldab+kd-ib:], kld)
+				}
+				// Update A33 -= U13^T * U13.
+				bi.Dsyrk(blas.Upper, blas.Trans, i3, ib,
+					-1, work, ldwork, 1, ab[(i+kd)*ldab:], kld)
+
+				// Copy the lower triangle of U13 back into the band.
+				for ii := 0; ii < ib; ii++ {
+					for jj := 0; jj <= min(ii, i3-1); jj++ {
+						ab[i*ldab+kd+ii*kld+jj] = work[ii*ldwork+jj]
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	// A[r,c] is stored in ab[r*ldab+kd+c-r].
+	for i := 0; i < n; i += nb {
+		ib := min(nb, n-i)
+
+		// Factorize the diagonal block.
+		if !impl.Dpotf2(ul, ib, ab[i*ldab+kd:], kld) {
+			return false
+		}
+		if i+ib >= n {
+			break
+		}
+
+		// Update the relevant part of the trailing submatrix.
+		// The off-diagonal block is partitioned as
+		//  [ A21 ]
+		//  [ A31 ]
+		// where A21 has i2 rows and lies within the band and
+		// A31 has i3 rows and only its upper triangle lies
+		// within the band.
+		i2 := min(kd-ib, n-i-ib)
+		i3 := min(ib, n-i-kd)
+
+		if i2 > 0 {
+			// Compute L21 = A21 * L11^{-T}.
+			bi.Dtrsm(blas.Right, blas.Lower, blas.Trans, blas.NonUnit, i2, ib,
+				1, ab[i*ldab+kd:], kld, ab[(i+ib)*ldab+kd-ib:], kld)
+			// Update A22 -= L21 * L21^T.
+			bi.Dsyrk(blas.Lower, blas.NoTrans, i2, ib,
+				-1, ab[(i+ib)*ldab+kd-ib:], kld, 1, ab[(i+ib)*ldab+kd:], kld)
+		}
+
+		if i3 > 0 {
+			// Copy the upper triangle of A31 into work.
+			for ii := 0; ii < i3; ii++ {
+				for jj := ii; jj < ib; jj++ {
+					work[ii*ldwork+jj] = ab[(i+kd)*ldab+ii*kld+jj]
+				}
+			}
+
+			// Compute L31 = A31 * L11^{-T}.
+			bi.Dtrsm(blas.Right, blas.Lower, blas.Trans, blas.NonUnit, i3, ib,
+				1, ab[i*ldab+kd:], kld, work, ldwork)
+			if i2 > 0 {
+				// Update A32 -= L31 * L21^T.
+				bi.Dgemm(blas.NoTrans, blas.Trans, i3, i2, ib,
+					-1, work, ldwork, ab[(i+ib)*ldab+kd-ib:], kld, 1, ab[(i+kd)*ldab+ib:], kld)
+			}
+			// Update A33 -= L31 * L31^T.
+			bi.Dsyrk(blas.Lower, blas.NoTrans, i3, ib,
+				-1, work, ldwork, 1, ab[(i+kd)*ldab+kd:], kld)
+
+			// Copy the upper triangle of L31 back into the band.
+			for ii := 0; ii < i3; ii++ {
+				for jj := ii; jj < ib; jj++ {
+					ab[(i+kd)*ldab+ii*kld+jj] = work[ii*ldwork+jj]
+				}
+			}
+		}
+	}
+	return true
+}
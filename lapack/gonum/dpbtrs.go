@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dpbtrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite band matrix A with kd super-diagonals (or sub-diagonals)
+// using the Cholesky factorization
+//  A = U^T * U if ul == blas.Upper
+//  A = L * L^T if ul == blas.Lower
+// computed by Dpbtrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, and on return it
+// is overwritten by the solution matrix X.
+func (Implementation) Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBanded(ab, n, kd, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	if ul == blas.Upper {
+		for k := 0; k < nrhs; k++ {
+			// Solve U^T * Y = B and U * X = Y.
+			bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kd, ab, ldab, b[k:], ldb)
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, b[k:], ldb)
+		}
+		return
+	}
+	for k := 0; k < nrhs; k++ {
+		// Solve L * Y = B and L^T * X = Y.
+		bi.Dtbsv(blas.Lower, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, b[k:], ldb)
+		bi.Dtbsv(blas.Lower, blas.Trans, blas.NonUnit, n, kd, ab, ldab, b[k:], ldb)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dposv computes the solution to the system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U if uplo == blas.Upper
+//  A = L * L^T if uplo == blas.Lower
+// where U is upper triangular and L is lower triangular. On entry, a contains
+// the triangle of A specified by uplo. On return, the factor U or L is stored
+// in the same triangle of a.
+//
+// On entry, b contains the right hand side matrix B. On return, if ok is true,
+// b contains the solution matrix X.
+//
+// Dposv returns whether A is positive definite. If ok is false, the
+// factorization could not be completed and the solution has not been computed.
+func (impl Implementation) Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	ok = impl.Dpotrf(uplo, n, a, lda)
+	if ok {
+		impl.Dpotrs(uplo, n, nrhs, a, lda, b, ldb)
+	}
+	return ok
+}
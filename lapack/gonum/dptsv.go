@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dptsv computes the solution to a system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix and X and
+// B are n×nrhs matrices. A is factored as A = L*D*L^T and the factored form
+// of A is then used to solve the system.
+//
+// On entry, d contains the n diagonal elements of A and e contains the n-1
+// sub-diagonal elements of A. On return, d and e contain the diagonal of D and
+// the sub-diagonal of the unit bidiagonal factor L.
+//
+// On entry, b contains the right-hand side matrix B, and on return, if ok is
+// true, b contains the solution matrix X.
+//
+// Dptsv returns whether the solution was computed successfully. If ok is
+// false, A is not positive definite and the solution has not been computed.
+func (impl Implementation) Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	ok = impl.Dpttrf(n, d, e)
+	if !ok {
+		return false
+	}
+	impl.Dpttrs(n, nrhs, d, e, b, ldb)
+	return true
+}
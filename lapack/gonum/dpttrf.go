@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A. L is a unit lower bidiagonal matrix and D is
+// a diagonal matrix.
+//
+// On entry, d contains the n diagonal elements of A and e contains the n-1
+// sub-diagonal elements of A. On return, d contains the n diagonal elements
+// of D and e contains the n-1 sub-diagonal elements of L.
+//
+// Dpttrf returns whether the factorization was successfully completed. If ok
+// is false, A is not positive definite and the factorization could not be
+// completed.
+//
+// Dpttrf is an internal routine. It is exported for testing purposes.
+func (Implementation) Dpttrf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	for i := 0; i < n-1; i++ {
+		if d[i] <= 0 {
+			return false
+		}
+		ei := e[i]
+		e[i] = ei / d[i]
+		d[i+1] -= e[i] * ei
+	}
+	return d[n-1] > 0
+}
@@ -0,0 +1,44 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite tridiagonal matrix A using the L*D*L^T factorization of A
+// computed by Dpttrf.
+//
+// d contains the n diagonal elements of D and e contains the n-1 sub-diagonal
+// elements of the unit bidiagonal factor L.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, and on return it
+// is overwritten by the solution matrix X.
+//
+// Dpttrs is an internal routine. It is exported for testing purposes.
+func (Implementation) Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	for j := 0; j < nrhs; j++ {
+		// Solve L * X = B.
+		for i := 1; i < n; i++ {
+			b[i*ldb+j] -= b[(i-1)*ldb+j] * e[i-1]
+		}
+		// Solve D * L^T * X = B.
+		b[(n-1)*ldb+j] /= d[n-1]
+		for i := n - 2; i >= 0; i-- {
+			b[i*ldb+j] = b[i*ldb+j]/d[i] - b[(i+1)*ldb+j]*e[i]
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstebz computes selected eigenvalues of the n×n symmetric tridiagonal matrix
+// T by bisection. The eigenvalues are located using Sturm sequence counts, so
+// any subset of them can be computed without computing the others.
+//
+// d contains the n diagonal elements of T and e contains the n-1 off-diagonal
+// elements. Dstebz will panic if either is too short.
+//
+// rng specifies the eigenvalues to compute:
+//  rng == lapack.AllEVs   All eigenvalues are computed.
+//  rng == lapack.ValueEVs The eigenvalues in the half-open interval (vl, vu]
+//                         are computed. vl must be less than vu.
+//  rng == lapack.IndexEVs The il-th through iu-th eigenvalues are computed,
+//                         where the eigenvalues are indexed from zero in
+//                         ascending order. 0 <= il <= iu < n must hold.
+//
+// abstol is the absolute tolerance for the eigenvalues. An eigenvalue is
+// considered located if it lies in an interval of width at most
+// max(abstol, 2*eps*max(|a|,|b|)), where [a,b] is the enclosing interval.
+// If abstol <= 0, eps*|T| is used instead.
+//
+// On return, the first m elements of w contain the computed eigenvalues in
+// ascending order. w must have length at least n, and Dstebz will panic
+// otherwise.
+//
+// Dstebz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstebz(rng lapack.EVRange, n int, vl, vu float64, il, iu int, abstol float64, d, e, w []float64) (m int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	switch rng {
+	default:
+		panic(badEVRange)
+	case lapack.AllEVs:
+	case lapack.ValueEVs:
+		if vl >= vu {
+			panic(badVlVu)
+		}
+	case lapack.IndexEVs:
+		if n > 0 && (il < 0 || il >= n) {
+			panic(badIl)
+		}
+		if n > 0 && (iu < il || iu >= n) {
+			panic(badIu)
+		}
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	const fudge = 2.1
+	ulp := dlamchP
+	rtol := 2 * ulp
+
+	// Compute the minimum pivot for the Sturm sequence and the
+	// Gershgorin interval containing all eigenvalues.
+	pivmin := 1.0
+	for i := 0; i < n-1; i++ {
+		pivmin = math.Max(pivmin, e[i]*e[i])
+	}
+	pivmin *= dlamchS
+	gl := d[0]
+	gu := d[0]
+	for i := 0; i < n; i++ {
+		var r float64
+		if i > 0 {
+			r += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			r += math.Abs(e[i])
+		}
+		gl = math.Min(gl, d[i]-r)
+		gu = math.Max(gu, d[i]+r)
+	}
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl -= fudge*tnorm*ulp*float64(n) + 2*pivmin
+	gu += fudge*tnorm*ulp*float64(n) + 2*pivmin
+	atol := abstol
+	if atol <= 0 {
+		atol = ulp * tnorm
+	}
+
+	// Convert the requested eigenvalues to a range of indices.
+	switch rng {
+	case lapack.AllEVs:
+		il, iu = 0, n-1
+	case lapack.ValueEVs:
+		il = dsturm(n, d, e, vl, pivmin)
+		iu = dsturm(n, d, e, vu, pivmin) - 1
+		if iu < il {
+			return 0
+		}
+	}
+
+	// Locate each eigenvalue by bisection. The lower bound of an
+	// eigenvalue is also a lower bound for the following ones.
+	lo := gl
+	for k := il; k <= iu; k++ {
+		hi := gu
+		for {
+			mid := lo + (hi-lo)/2
+			if hi-lo <= math.Max(atol, rtol*math.Max(math.Abs(lo), math.Abs(hi))) || mid <= lo || mid >= hi {
+				break
+			}
+			if dsturm(n, d, e, mid, pivmin) > k {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		w[m] = lo + (hi-lo)/2
+		m++
+	}
+	return m
+}
+
+// dsturm returns the number of eigenvalues of the symmetric tridiagonal
+// matrix T that are less than or equal to x. Zero pivots in the Sturm
+// sequence are perturbed to -pivmin.
+func dsturm(n int, d, e []float64, x, pivmin float64) int {
+	var count int
+	q := d[0] - x
+	if math.Abs(q) <= pivmin {
+		q = -pivmin
+	}
+	if q < 0 {
+		count++
+	}
+	for i := 1; i < n; i++ {
+		q = d[i] - x - e[i-1]*e[i-1]/q
+		if math.Abs(q) <= pivmin {
+			q = -pivmin
+		}
+		if q < 0 {
+			count++
+		}
+	}
+	return count
+}
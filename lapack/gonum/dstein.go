@@ -0,0 +1,233 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dstein computes the eigenvectors of the n×n symmetric tridiagonal matrix T
+// corresponding to the m eigenvalues in w using inverse iteration. The
+// eigenvalues will typically have been computed by Dstebz.
+//
+// d contains the n diagonal elements of T and e contains the n-1 off-diagonal
+// elements. w contains the m eigenvalues in ascending order. Eigenvectors of
+// eigenvalues that are closer than 1e-3*|T| to each other are
+// reorthogonalized against each other.
+//
+// On return, the columns of the n×m matrix Z contain the eigenvectors, each
+// normalized to unit length with its largest element positive.
+//
+// work must have length at least 5*n and iwork must have length at least n.
+// Dstein will panic if there is insufficient working memory.
+//
+// Dstein returns whether all eigenvectors converged in the maximum number of
+// iterations.
+//
+// Dstein is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstein(n int, d, e []float64, m int, w, z []float64, ldz int, work []float64, iwork []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if m < 0 || m > n {
+		panic(badDims)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(w) < m {
+		panic(badSlice)
+	}
+	checkMatrix(n, m, z, ldz)
+	if len(work) < 5*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if m == 0 {
+		return true
+	}
+	if n == 1 {
+		z[0] = 1
+		return true
+	}
+
+	const (
+		maxits = 5
+		extra  = 2
+	)
+	bi := blas64.Implementation()
+	eps := dlamchP
+	rnd := rand.New(rand.NewSource(1))
+
+	a := work[:n]
+	b := work[n : 2*n]
+	c := work[2*n : 3*n]
+	d2 := work[3*n : 4*n]
+	x := work[4*n : 5*n]
+	piv := iwork[:n]
+
+	var onenrm float64
+	for i := 0; i < n; i++ {
+		r := math.Abs(d[i])
+		if i > 0 {
+			r += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			r += math.Abs(e[i])
+		}
+		onenrm = math.Max(onenrm, r)
+	}
+	ortol := 1e-3 * onenrm
+	dtpcrt := math.Sqrt(0.1 / float64(n))
+
+	ok = true
+	var xjm float64
+	var gpind int
+	for j := 0; j < m; j++ {
+		xj := w[j]
+		if j > 0 {
+			// Separate close eigenvalues so that the iterations
+			// converge to different vectors.
+			pertol := 10 * math.Abs(eps*xj)
+			if xj-xjm < pertol {
+				xj = xjm + pertol
+			}
+			if xj-xjm > ortol {
+				gpind = j
+			}
+		}
+
+		// Factor T - xj*I with partial pivoting.
+		for i := 0; i < n; i++ {
+			a[i] = d[i] - xj
+		}
+		copy(b, e[:n-1])
+		copy(c, e[:n-1])
+		dlagtf(n, a, b, c, d2, piv)
+		tol := math.Abs(a[0])
+		for i := 1; i < n; i++ {
+			tol = math.Max(tol, math.Max(math.Abs(a[i]), math.Abs(b[i-1])))
+			if i > 1 {
+				tol = math.Max(tol, math.Abs(d2[i-2]))
+			}
+		}
+		tol *= eps
+		if tol == 0 {
+			tol = eps
+		}
+
+		for i := range x {
+			x[i] = 2*rnd.Float64() - 1
+		}
+		var nrmchk int
+		converged := false
+		for its := 0; its < maxits; its++ {
+			// Scale the iterate so that the solve does not overflow.
+			jmax := bi.Idamax(n, x, 1)
+			scl := float64(n) * onenrm * math.Max(eps, math.Abs(a[n-1])) / math.Abs(x[jmax])
+			bi.Dscal(n, scl, x, 1)
+
+			dlagts(n, a, b, c, d2, piv, x, tol)
+
+			// Reorthogonalize against the vectors of the close
+			// eigenvalues.
+			for i := gpind; i < j; i++ {
+				ztr := -bi.Ddot(n, x, 1, z[i:], ldz)
+				bi.Daxpy(n, ztr, z[i:], ldz, x, 1)
+			}
+
+			jmax = bi.Idamax(n, x, 1)
+			if math.Abs(x[jmax]) < dtpcrt {
+				continue
+			}
+			nrmchk++
+			if nrmchk > extra {
+				converged = true
+				break
+			}
+		}
+		if !converged {
+			ok = false
+		}
+
+		scl := 1 / bi.Dnrm2(n, x, 1)
+		if x[bi.Idamax(n, x, 1)] < 0 {
+			scl = -scl
+		}
+		bi.Dscal(n, scl, x, 1)
+		bi.Dcopy(n, x, 1, z[j:], ldz)
+		xjm = xj
+	}
+	return ok
+}
+
+// dlagtf computes the LU factorization with partial pivoting of the n×n
+// tridiagonal matrix with diagonal a, super-diagonal b and sub-diagonal c.
+// On return a contains the diagonal of U, b and d2 contain the first and
+// second super-diagonals of U, c contains the multipliers of L and piv[k] is
+// 1 if rows k and k+1 were interchanged and 0 otherwise.
+func dlagtf(n int, a, b, c, d2 []float64, piv []int) {
+	for k := 0; k < n-1; k++ {
+		if math.Abs(a[k]) >= math.Abs(c[k]) {
+			piv[k] = 0
+			if a[k] != 0 {
+				c[k] /= a[k]
+				a[k+1] -= c[k] * b[k]
+			}
+			if k < n-2 {
+				d2[k] = 0
+			}
+			continue
+		}
+		piv[k] = 1
+		mult := a[k] / c[k]
+		a[k] = c[k]
+		tmp := a[k+1]
+		a[k+1] = b[k] - mult*tmp
+		if k < n-2 {
+			d2[k] = b[k+1]
+			b[k+1] = -mult * d2[k]
+		}
+		b[k] = tmp
+		c[k] = mult
+	}
+}
+
+// dlagts solves the system T*x = y where T has been factorized by dlagtf.
+// Pivots of U smaller than tol in magnitude are perturbed to tol, as is
+// appropriate for inverse iteration. On return y contains the solution.
+func dlagts(n int, a, b, c, d2 []float64, piv []int, y []float64, tol float64) {
+	for k := 0; k < n-1; k++ {
+		if piv[k] == 0 {
+			y[k+1] -= c[k] * y[k]
+		} else {
+			tmp := y[k]
+			y[k] = y[k+1]
+			y[k+1] = tmp - c[k]*y[k]
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		tmp := y[k]
+		if k < n-1 {
+			tmp -= b[k] * y[k+1]
+		}
+		if k < n-2 {
+			tmp -= d2[k] * y[k+2]
+		}
+		ak := a[k]
+		if math.Abs(ak) < tol {
+			ak = math.Copysign(tol, ak)
+		}
+		y[k] = tmp / ak
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstemr computes selected eigenvalues and, optionally, eigenvectors of the
+// n×n symmetric tridiagonal matrix T using the algorithm of Multiple
+// Relatively Robust Representations (MRRR). Eigenvalues can be selected by a
+// range of values or a range of indices.
+//
+// For each unreduced block of T a factorization L*D*L^T = T - sigma*I is
+// computed that determines its eigenvalues to high relative accuracy. The
+// eigenvalues of clusters of close eigenvalues are resolved by further
+// shifted factorizations so that each eigenvector can be computed
+// independently to high accuracy, without reorthogonalization, in O(n) time.
+//
+// d contains the n diagonal elements of T. e contains the n-1 off-diagonal
+// elements of T and must have length at least n. On return, d and e are
+// overwritten.
+//
+// rng specifies the eigenvalues to compute:
+//  rng == lapack.AllEVs   All eigenvalues are computed.
+//  rng == lapack.ValueEVs The eigenvalues in the half-open interval (vl, vu]
+//                         are computed. vl must be less than vu.
+//  rng == lapack.IndexEVs The il-th through iu-th eigenvalues are computed,
+//                         where the eigenvalues are indexed from zero in
+//                         ascending order. 0 <= il <= iu < n must hold.
+//
+// If tryrac is true, Dstemr checks whether T determines its eigenvalues to
+// high relative accuracy and, if it does, computes them to that accuracy.
+//
+// On return, m is the number of eigenvalues found and the first m elements of
+// w contain them in ascending order. w must have length at least n. If
+// jobz == lapack.ComputeEV, the first m columns of the n×n matrix Z contain
+// the orthonormal eigenvectors, with the i-th column corresponding to w[i],
+// and the indices of the first and last non-zero element of the i-th
+// eigenvector are stored in isuppz[2*i] and isuppz[2*i+1]. isuppz must have
+// length at least 2*n. Otherwise z and isuppz are not referenced.
+//
+// If jobz == lapack.ComputeEV, work must have length at least 18*n and iwork
+// at least 10*n, otherwise work must have length at least 12*n and iwork at
+// least 8*n. Dstemr will panic if there is insufficient working memory.
+//
+// Dstemr returns whether the computation was successful.
+//
+// Dstemr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, iwork []int) (m int, ok bool) {
+	const minrgp = 1e-3
+
+	wantz := jobz == lapack.ComputeEV
+	if jobz != lapack.None && !wantz {
+		panic(badEVJob)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	switch rng {
+	default:
+		panic(badEVRange)
+	case lapack.AllEVs:
+	case lapack.ValueEVs:
+		if vl >= vu {
+			panic(badVlVu)
+		}
+	case lapack.IndexEVs:
+		if n > 0 && (il < 0 || il >= n) {
+			panic(badIl)
+		}
+		if n > 0 && (iu < il || iu >= n) {
+			panic(badIu)
+		}
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n {
+		panic(badE)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	lwmin := 12 * n
+	liwmin := 8 * n
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+		if len(isuppz) < 2*n {
+			panic(badIsuppz)
+		}
+		lwmin = 18 * n
+		liwmin = 10 * n
+	}
+	if len(work) < lwmin {
+		panic(badWork)
+	}
+	if len(iwork) < liwmin {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if rng != lapack.ValueEVs || (vl < d[0] && d[0] <= vu) {
+			m = 1
+			w[0] = d[0]
+		}
+		if wantz {
+			z[0] = 1
+			isuppz[0] = 0
+			isuppz[1] = 0
+		}
+		return m, true
+	}
+
+	var nsplit int
+	if n == 2 {
+		var r1, r2, cs, sn float64
+		if wantz {
+			r1, r2, cs, sn = impl.Dlaev2(d[0], e[0], d[1])
+		} else {
+			r1, r2 = impl.Dlae2(d[0], e[0], d[1])
+		}
+		// Dlae2 and Dlaev2 return |r1| >= |r2|, order them so that
+		// r1 >= r2.
+		swapped := r1 < r2
+		if swapped {
+			r1, r2 = r2, r1
+		}
+		if rng == lapack.AllEVs || (rng == lapack.ValueEVs && vl < r2 && r2 <= vu) ||
+			(rng == lapack.IndexEVs && il == 0) {
+			w[m] = r2
+			if wantz {
+				if swapped {
+					z[m] = cs
+					z[ldz+m] = sn
+				} else {
+					z[m] = -sn
+					z[ldz+m] = cs
+				}
+			}
+			m++
+		}
+		if rng == lapack.AllEVs || (rng == lapack.ValueEVs && vl < r1 && r1 <= vu) ||
+			(rng == lapack.IndexEVs && iu == 1) {
+			w[m] = r1
+			if wantz {
+				if swapped {
+					z[m] = -sn
+					z[ldz+m] = cs
+				} else {
+					z[m] = cs
+					z[ldz+m] = sn
+				}
+			}
+			m++
+		}
+		if wantz {
+			// At most one of cs and sn can be zero.
+			for j := 0; j < m; j++ {
+				isuppz[2*j] = 0
+				if z[j] == 0 {
+					isuppz[2*j] = 1
+				}
+				isuppz[2*j+1] = 1
+				if z[ldz+j] == 0 {
+					isuppz[2*j+1] = 0
+				}
+			}
+		}
+	} else {
+		safmin := dlamchS
+		eps := dlamchP
+		smlnum := safmin / eps
+		bignum := 1 / smlnum
+		rmin := math.Sqrt(smlnum)
+		rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+		gers := work[:2*n]
+		werr := work[2*n : 3*n]
+		wgap := work[3*n : 4*n]
+		dorig := work[4*n : 5*n]
+		e2 := work[5*n : 6*n]
+		wrk := work[6*n:]
+
+		isplit := iwork[:n]
+		iblock := iwork[n : 2*n]
+		indexw := iwork[2*n : 3*n]
+		iwrk := iwork[3*n:]
+
+		// Scale the matrix to the allowable range, if necessary. The
+		// allowable range is related to the pivmin parameter, see
+		// dlarrd.
+		scale := 1.0
+		tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+		if tnrm > 0 && tnrm < rmin {
+			scale = rmin / tnrm
+		} else if tnrm > rmax {
+			scale = rmax / tnrm
+		}
+		bi := blas64.Implementation()
+		if scale != 1 {
+			bi.Dscal(n, scale, d, 1)
+			bi.Dscal(n-1, scale, e, 1)
+			tnrm *= scale
+			if rng == lapack.ValueEVs {
+				vl *= scale
+				vu *= scale
+			}
+		}
+
+		// Compute the desired eigenvalues of the tridiagonal after
+		// splitting into smaller blocks if the corresponding off-diagonal
+		// elements are small. A negative splitting threshold selects the
+		// absolute splitting criterion, a positive threshold the
+		// criterion that preserves relative accuracy.
+		tryrac = tryrac && dlarrr(n, d, e)
+		thresh := -eps
+		if tryrac {
+			thresh = eps
+			// Copy the original diagonal, needed to guarantee
+			// relative accuracy.
+			copy(dorig, d[:n])
+		}
+		for j := 0; j < n-1; j++ {
+			e2[j] = e[j] * e[j]
+		}
+
+		// Set the tolerances for bisection. If the eigenvectors are
+		// wanted, dlarrv refines the eigenvalue approximations so dlarre
+		// needs less accurate initial bisection.
+		rtol1 := 4 * eps
+		rtol2 := 4 * eps
+		if wantz {
+			rtol1 = math.Sqrt(eps)
+			rtol2 = math.Max(math.Sqrt(eps)*5e-3, 4*eps)
+		}
+		var wl, wu, pivmin float64
+		nsplit, m, wl, wu, pivmin, ok = impl.dlarre(rng, n, vl, vu, il, iu, d, e, e2, rtol1, rtol2, thresh, isplit,
+			w, werr, wgap, iblock, indexw, gers, wrk, iwrk)
+		if !ok {
+			return 0, false
+		}
+
+		if wantz {
+			// Compute the eigenvectors corresponding to the computed
+			// eigenvalues. All desired eigenvalues lie in (wl,wu].
+			ok = impl.dlarrv(n, wl, wu, d, e, pivmin, isplit, m, minrgp, rtol1, rtol2,
+				w, werr, wgap, iblock, indexw, gers, z, ldz, isuppz, wrk, iwrk)
+			if !ok {
+				return 0, false
+			}
+		} else {
+			// dlarre computes the eigenvalues of the shifted root
+			// representations, apply the shifts to obtain the
+			// eigenvalues of T.
+			for j := 0; j < m; j++ {
+				w[j] += e[isplit[iblock[j]]]
+			}
+		}
+
+		if tryrac {
+			// Refine the computed eigenvalues so that they are
+			// relatively accurate with respect to the original matrix.
+			var ibegin, wbegin int
+			for jblk := 0; m > 0 && jblk <= iblock[m-1]; jblk++ {
+				iend := isplit[jblk]
+				in := iend - ibegin + 1
+				wend := wbegin - 1
+				for wend < m-1 && iblock[wend+1] == jblk {
+					wend++
+				}
+				if wend < wbegin {
+					ibegin = iend + 1
+					continue
+				}
+				offset := indexw[wbegin]
+				ifirst := indexw[wbegin]
+				ilast := indexw[wend]
+				dlarrj(in, dorig[ibegin:], e2[ibegin:], ifirst, ilast, 4*eps, offset,
+					w[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, tnrm)
+				ibegin = iend + 1
+				wbegin = wend + 1
+			}
+		}
+
+		// If the matrix was scaled, rescale the eigenvalues.
+		if scale != 1 {
+			bi.Dscal(m, 1/scale, w, 1)
+		}
+	}
+
+	// If the eigenvalues are not in ascending order, sort them along with
+	// the eigenvectors.
+	if nsplit > 1 || n == 2 {
+		if !wantz {
+			impl.Dlasrt(lapack.SortIncreasing, m, w)
+			return m, true
+		}
+		bi := blas64.Implementation()
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp {
+					i = jj
+					tmp = w[jj]
+				}
+			}
+			if i >= 0 {
+				w[i] = w[j]
+				w[j] = tmp
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	return m, true
+}
@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dsycon estimates the reciprocal of the condition number of a real symmetric
+// matrix A in the 1-norm using the factorization
+//  A = U * D * U^T,  if uplo == blas.Upper,
+//  A = L * D * L^T,  if uplo == blas.Lower,
+// computed by Dsytrf. The condition number computed is
+//  1 / (|A|_1 * |A^-1|_1),
+// where an estimate of |A^-1|_1 is obtained with Dlacn2.
+//
+// a and ipiv must contain the factorization and the details of the
+// interchanges as returned by Dsytrf.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dsycon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dsycon will panic
+// otherwise.
+func (impl Implementation) Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	checkMatrix(n, n, a, lda)
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if anorm < 0 {
+		panic("lapack: anorm < 0")
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 1
+	}
+	if anorm == 0 {
+		return 0
+	}
+
+	// Check that the diagonal matrix D is non-singular.
+	for i := 0; i < n; i++ {
+		if ipiv[i] >= 0 && a[i*lda+i] == 0 {
+			return 0
+		}
+	}
+
+	// Estimate the 1-norm of the inverse.
+	var ainvnm float64
+	var kase int
+	isave := new([3]int)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, isave)
+		if kase == 0 {
+			break
+		}
+		// Multiply by inv(L*D*L^T) or inv(U*D*U^T).
+		impl.Dsytrs(uplo, n, 1, a, lda, ipiv, work, 1)
+	}
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,221 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevr computes selected eigenvalues and, optionally, eigenvectors of the
+// n×n symmetric matrix A using the algorithm of Multiple Relatively Robust
+// Representations (MRRR). Eigenvalues can be selected by a range of values or
+// a range of indices.
+//
+// A is reduced to tridiagonal form T by Dsytrd and the selected eigenvalues
+// and eigenvectors of T are computed by Dstemr. Each eigenvector is computed
+// independently in O(n) time without reorthogonalization, so the cost of
+// computing k eigenpairs of T is O(n*k). If only eigenvalues are wanted and
+// all of them are requested, they are computed by Dsterf. If Dstemr fails,
+// the eigenpairs of T are computed by bisection with Dstebz and inverse
+// iteration with Dstein as in Dsyevx.
+//
+// rng specifies the eigenvalues to compute:
+//  rng == lapack.AllEVs   All eigenvalues are computed.
+//  rng == lapack.ValueEVs The eigenvalues in the half-open interval (vl, vu]
+//                         are computed. vl must be less than vu.
+//  rng == lapack.IndexEVs The il-th through iu-th eigenvalues are computed,
+//                         where the eigenvalues are indexed from zero in
+//                         ascending order. 0 <= il <= iu < n must hold.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit the specified triangular region is
+// overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues. If abstol is at
+// most 2*n*eps, Dstemr computes the eigenvalues to high relative accuracy when
+// T warrants it. abstol is also used by Dstebz if Dstemr fails, see Dsyevx.
+//
+// On return, m is the number of eigenvalues found and the first m elements of
+// w contain them in ascending order. w must have length at least n. If
+// jobz == lapack.ComputeEV, the first m columns of the n×m matrix Z contain the
+// orthonormal eigenvectors, with the i-th column corresponding to w[i].
+// Otherwise z is not referenced.
+//
+// work is temporary storage, and lwork specifies the usable memory length. If
+// jobz == lapack.ComputeEV, lwork must be at least n*n+26*n, otherwise lwork
+// must be at least 26*n. If lwork == -1, instead of computing Dsyevr the
+// optimal work length is stored into work[0]. iwork must have length at least
+// 12*n. Dsyevr will panic if the working memory has insufficient storage.
+//
+// Dsyevr returns whether the computation of the eigenvalues and eigenvectors
+// was successful.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork []int) (m int, ok bool) {
+	checkMatrix(n, n, a, lda)
+	wantz := jobz == lapack.ComputeEV
+	if jobz != lapack.None && !wantz {
+		panic(badEVJob)
+	}
+	switch rng {
+	default:
+		panic(badEVRange)
+	case lapack.AllEVs:
+	case lapack.ValueEVs:
+		if vl >= vu {
+			panic(badVlVu)
+		}
+	case lapack.IndexEVs:
+		if n > 0 && (il < 0 || il >= n) {
+			panic(badIl)
+		}
+		if n > 0 && (iu < il || iu >= n) {
+			panic(badIu)
+		}
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	minwrk := 26 * n
+	if wantz {
+		minwrk += n * n
+	}
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+	lworkopt := max(1, max(26, nb+5)*n)
+	if wantz {
+		lworkopt += n * n
+	}
+	work[0] = float64(lworkopt)
+	if lwork == -1 {
+		return 0, true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < max(1, minwrk) {
+		panic(badWork)
+	}
+	if len(iwork) < 12*n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if rng == lapack.ValueEVs && (a[0] <= vl || vu < a[0]) {
+			return 0, true
+		}
+		w[0] = a[0]
+		if wantz {
+			z[0] = 1
+		}
+		return 1, true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if abstol > 0 {
+			abstol *= sigma
+		}
+		if rng == lapack.ValueEVs {
+			vl *= sigma
+			vu *= sigma
+		}
+	}
+
+	// The diagonal and off-diagonal elements of T are kept in work[indd:]
+	// and work[inde:] so that Dstebz and Dstein can be used if Dstemr
+	// fails. Dstemr overwrites the copies in work[inddd:] and work[indee:].
+	var indd int
+	inde := indd + n
+	indtau := inde + n
+	inddd := indtau + n
+	indee := inddd + n
+	indwork := indee + n
+	llwork := lwork - indwork
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwork:], llwork)
+
+	bi := blas64.Implementation()
+	alleig := rng == lapack.AllEVs || (rng == lapack.IndexEVs && il == 0 && iu == n-1)
+	if !wantz && alleig {
+		// For all eigenvalues without eigenvectors, call Dsterf.
+		copy(w, work[indd:indd+n])
+		copy(work[indee:indee+n-1], work[inde:inde+n-1])
+		if impl.Dsterf(n, w, work[indee:]) {
+			m = n
+			ok = true
+		}
+	} else {
+		// Compute the selected eigenpairs of T by Dstemr. The
+		// eigenvectors of T are stored in the n×n matrix Zt in
+		// work[indzt:].
+		indzt := indwork
+		indwrk := indwork
+		if wantz {
+			indwrk += n * n
+		}
+		copy(work[inddd:inddd+n], work[indd:indd+n])
+		copy(work[indee:indee+n-1], work[inde:inde+n-1])
+		tryrac := abstol <= 2*float64(n)*eps
+		m, ok = impl.Dstemr(jobz, rng, n, work[inddd:], work[indee:], vl, vu, il, iu, w, work[indzt:], n,
+			iwork[:2*n], tryrac, work[indwrk:], iwork[2*n:])
+		if !ok {
+			// Dstemr failed, compute the selected eigenvalues of T by
+			// bisection and their eigenvectors by inverse iteration.
+			ok = true
+			m = impl.Dstebz(rng, n, vl, vu, il, iu, abstol, work[indd:], work[inde:], w)
+			if wantz && m > 0 {
+				ok = impl.Dstein(n, work[indd:], work[inde:], m, w, work[indzt:], n, work[indwrk:], iwork)
+			}
+		}
+		if wantz && m > 0 {
+			// Transform the eigenvectors of T back to those of A.
+			impl.Dorgtr(uplo, n, a, lda, work[indtau:indtau+n-1], work[indwrk:], lwork-indwrk)
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, n, m, n, 1, a, lda, work[indzt:], n, 0, z, ldz)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+	work[0] = float64(lworkopt)
+	return m, ok
+}
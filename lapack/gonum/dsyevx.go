@@ -0,0 +1,206 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevx computes selected eigenvalues and, optionally, eigenvectors of the
+// n×n symmetric matrix A. Eigenvalues can be selected by a range of values or
+// a range of indices.
+//
+// A is reduced to tridiagonal form T by Dsytrd. If all eigenvalues are
+// requested they are computed by Dsterf, or by Dsteqr if eigenvectors are
+// also wanted. Otherwise the selected eigenvalues of T are computed by
+// bisection with Dstebz and their eigenvectors by inverse iteration with
+// Dstein, so the cost of computing a small subset of the eigenpairs is much
+// lower than that of the full decomposition. Dsyevr computes the same
+// eigenpairs with the MRRR algorithm, which is usually faster.
+//
+// rng specifies the eigenvalues to compute:
+//  rng == lapack.AllEVs   All eigenvalues are computed.
+//  rng == lapack.ValueEVs The eigenvalues in the half-open interval (vl, vu]
+//                         are computed. vl must be less than vu.
+//  rng == lapack.IndexEVs The il-th through iu-th eigenvalues are computed,
+//                         where the eigenvalues are indexed from zero in
+//                         ascending order. 0 <= il <= iu < n must hold.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit the specified triangular region is
+// overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues computed by
+// bisection. If abstol <= 0, eps*|T| is used.
+//
+// On return, m is the number of eigenvalues found and the first m elements of
+// w contain them in ascending order. w must have length at least n. If
+// jobz == lapack.ComputeEV, the first m columns of the n×m matrix Z contain the
+// orthonormal eigenvectors, with the i-th column corresponding to w[i].
+// Otherwise z is not referenced.
+//
+// work is temporary storage, and lwork specifies the usable memory length. If
+// jobz == lapack.ComputeEV, lwork must be at least n*n+8*n, otherwise lwork
+// must be at least 4*n. If lwork == -1, instead of computing Dsyevx the
+// optimal work length is stored into work[0]. iwork must have length at least
+// n. Dsyevx will panic if the working memory has insufficient storage.
+//
+// Dsyevx returns whether the computation of the eigenvalues and eigenvectors
+// was successful.
+func (impl Implementation) Dsyevx(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork []int) (m int, ok bool) {
+	checkMatrix(n, n, a, lda)
+	wantz := jobz == lapack.ComputeEV
+	if jobz != lapack.None && !wantz {
+		panic(badEVJob)
+	}
+	switch rng {
+	default:
+		panic(badEVRange)
+	case lapack.AllEVs:
+	case lapack.ValueEVs:
+		if vl >= vu {
+			panic(badVlVu)
+		}
+	case lapack.IndexEVs:
+		if n > 0 && (il < 0 || il >= n) {
+			panic(badIl)
+		}
+		if n > 0 && (iu < il || iu >= n) {
+			panic(badIu)
+		}
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	minwrk := 4 * n
+	if wantz {
+		minwrk = n*n + 8*n
+	}
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+	lworkopt := max(1, max(minwrk, (nb+3)*n))
+	if wantz {
+		lworkopt += n * n
+	}
+	work[0] = float64(lworkopt)
+	if lwork == -1 {
+		return 0, true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < max(1, minwrk) {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if rng == lapack.ValueEVs && (a[0] <= vl || vu < a[0]) {
+			return 0, true
+		}
+		w[0] = a[0]
+		if wantz {
+			z[0] = 1
+		}
+		return 1, true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if abstol > 0 {
+			abstol *= sigma
+		}
+		if rng == lapack.ValueEVs {
+			vl *= sigma
+			vu *= sigma
+		}
+	}
+
+	var indd int
+	inde := indd + n
+	indtau := inde + n
+	indwork := indtau + n
+	llwork := lwork - indwork
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwork:], llwork)
+
+	bi := blas64.Implementation()
+	if rng == lapack.AllEVs {
+		// For all eigenvalues, call Dsterf. For eigenvectors, first
+		// call Dorgtr to generate the orthogonal matrix, then call
+		// Dsteqr.
+		m = n
+		copy(w, work[indd:indd+n])
+		if !wantz {
+			ok = impl.Dsterf(n, w, work[inde:])
+		} else {
+			impl.Dorgtr(uplo, n, a, lda, work[indtau:indtau+n-1], work[indwork:], llwork)
+			impl.Dlacpy(blas.All, n, n, a, lda, z, ldz)
+			ok = impl.Dsteqr(lapack.OriginalEV, n, w, work[inde:], z, ldz, work[indwork:])
+		}
+		if !ok {
+			return 0, false
+		}
+	} else {
+		// Compute the selected eigenvalues of T by bisection and their
+		// eigenvectors by inverse iteration, then transform the
+		// eigenvectors back to those of A.
+		ok = true
+		m = impl.Dstebz(rng, n, vl, vu, il, iu, abstol, work[indd:], work[inde:], w)
+		if wantz && m > 0 {
+			indzt := indwork
+			indwrk := indzt + n*m
+			ok = impl.Dstein(n, work[indd:], work[inde:], m, w, work[indzt:], m, work[indwrk:], iwork)
+			impl.Dorgtr(uplo, n, a, lda, work[indtau:indtau+n-1], work[indwrk:], lwork-indwrk)
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, n, m, n, 1, a, lda, work[indzt:], m, 0, z, ldz)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+	work[0] = float64(lworkopt)
+	return m, ok
+}
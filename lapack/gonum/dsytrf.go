@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dsytrf computes the factorization of a real symmetric matrix A using the
+// Bunch-Kaufman diagonal pivoting method. The form of the factorization is
+//  A = U * D * U^T,  if uplo == blas.Upper,
+//  A = L * D * L^T,  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks.
+//
+// On entry, a contains the symmetric matrix A in the triangle specified by
+// uplo. On return, a contains the block diagonal matrix D and the multipliers
+// used to obtain the factor U or L.
+//
+// ipiv contains details of the interchanges and the block structure of D as
+// described in the documentation of Dsytf2. ipiv must have length n, otherwise
+// Dsytrf will panic.
+//
+// work must have length at least lwork and lwork must be at least 1, otherwise
+// Dsytrf will panic. On return, work[0] contains the optimal value of lwork.
+// For optimal performance lwork should be at least n*nb, where nb is the
+// block size returned by Ilaenv. If lwork == -1, instead of performing Dsytrf,
+// only the optimal value of lwork will be stored in work[0].
+//
+// Dsytrf returns whether D is non-singular. The factorization is completed
+// even if D is exactly singular, but division by zero will occur if it is
+// used to solve a system of equations.
+//
+// Dsytrf is the blocked version of the algorithm, see Dsytf2 for the unblocked
+// version.
+func (impl Implementation) Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	nb := impl.Ilaenv(1, "DSYTRF", string(uplo), n, -1, -1, -1)
+	lworkopt := max(1, n*nb)
+	if lwork == -1 {
+		work[0] = float64(lworkopt)
+		return true
+	}
+	checkMatrix(n, n, a, lda)
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if lwork < 1 {
+		panic(badWork)
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	nbmin := 2
+	if nb > 1 && nb < n && lwork < n*nb {
+		// Not enough workspace for the optimal block size,
+		// so reduce it.
+		nb = max(lwork/n, 1)
+		nbmin = max(2, impl.Ilaenv(2, "DSYTRF", string(uplo), n, -1, -1, -1))
+	}
+	if nb < nbmin || nb >= n {
+		// Use unblocked code.
+		ok = impl.Dsytf2(uplo, n, a, lda, ipiv)
+		work[0] = float64(lworkopt)
+		return ok
+	}
+
+	// The n×nb matrix W used by Dlasyf is stored in work.
+	ldwork := nb
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize A as U*D*U^T using the upper triangle of A.
+		// k is the number of leading columns that remain to be
+		// factorized. It decreases from n to 0 in steps of kb, the
+		// number of columns factorized by Dlasyf or Dsytf2.
+		for k := n; k > 0; {
+			var kb int
+			var iok bool
+			if k > nb {
+				// Factorize columns k-kb:k of A and use blocked
+				// code to update columns 0:k-kb.
+				kb, iok = impl.Dlasyf(uplo, k, nb, a, lda, ipiv, work, ldwork)
+			} else {
+				// Use unblocked code to factorize columns 0:k of A.
+				iok = impl.Dsytf2(uplo, k, a, lda, ipiv)
+				kb = k
+			}
+			ok = ok && iok
+			k -= kb
+		}
+	} else {
+		// Factorize A as L*D*L^T using the lower triangle of A.
+		// k increases from 0 to n in steps of kb, the number of
+		// columns factorized by Dlasyf or Dsytf2.
+		for k := 0; k < n; {
+			var kb int
+			var iok bool
+			if k < n-nb {
+				// Factorize columns k:k+kb of A and use blocked
+				// code to update columns k+kb:n.
+				kb, iok = impl.Dlasyf(uplo, n-k, nb, a[k*lda+k:], lda, ipiv[k:], work, ldwork)
+			} else {
+				// Use unblocked code to factorize columns k:n of A.
+				iok = impl.Dsytf2(uplo, n-k, a[k*lda+k:], lda, ipiv[k:])
+				kb = n - k
+			}
+			ok = ok && iok
+			// Adjust ipiv to refer to rows and columns of A.
+			for j := k; j < k+kb; j++ {
+				if ipiv[j] >= 0 {
+					ipiv[j] += k
+				} else {
+					ipiv[j] -= k
+				}
+			}
+			k += kb
+		}
+	}
+	work[0] = float64(lworkopt)
+	return ok
+}
@@ -13,7 +13,11 @@ import (
 // this code is in pure Go, the underlying BLAS implementation may not be.
 type Implementation struct{}
 
-var _ lapack.Float64 = Implementation{}
+var (
+	_ lapack.Float32    = Implementation{}
+	_ lapack.Float64    = Implementation{}
+	_ lapack.Complex128 = Implementation{}
+)
 
 // This list is duplicated in lapack/cgo. Keep in sync.
 const (
@@ -27,14 +31,20 @@ const (
 	badDims         = "lapack: bad input dimensions"
 	badDirect       = "lapack: bad direct"
 	badE            = "lapack: e has insufficient length"
+	badEquilType    = "lapack: bad equilibration type"
 	badEVComp       = "lapack: bad EVComp"
 	badEVJob        = "lapack: bad EVJob"
+	badEVRange      = "lapack: bad EVRange"
 	badEVSide       = "lapack: bad EVSide"
+	badFact         = "lapack: bad factorization type"
 	badGSVDJob      = "lapack: bad GSVDJob"
 	badHowMany      = "lapack: bad HowMany"
 	badIlo          = "lapack: ilo out of range"
 	badIhi          = "lapack: ihi out of range"
+	badIl           = "lapack: il out of range"
 	badIpiv         = "lapack: bad permutation length"
+	badIsuppz       = "lapack: isuppz has insufficient length"
+	badIu           = "lapack: iu out of range"
 	badJob          = "lapack: bad Job"
 	badK1           = "lapack: k1 out of range"
 	badK2           = "lapack: k2 out of range"
@@ -44,17 +54,20 @@ const (
 	badNorm         = "lapack: bad norm"
 	badPivot        = "lapack: bad pivot"
 	badS            = "lapack: s has insufficient length"
+	badScaleFactor  = "lapack: scale factor not positive"
 	badShifts       = "lapack: bad shifts"
 	badSide         = "lapack: bad side"
 	badSlice        = "lapack: bad input slice length"
 	badSort         = "lapack: bad Sort"
 	badStore        = "lapack: bad store"
+	badSVDJob       = "lapack: bad SVDJob"
 	badTau          = "lapack: tau has insufficient length"
 	badTauQ         = "lapack: tauQ has insufficient length"
 	badTauP         = "lapack: tauP has insufficient length"
 	badTrans        = "lapack: bad trans"
 	badVn1          = "lapack: vn1 has insufficient length"
 	badVn2          = "lapack: vn2 has insufficient length"
+	badVlVu         = "lapack: vl >= vu"
 	badUplo         = "lapack: illegal triangle"
 	badWork         = "lapack: insufficient working memory"
 	badZ            = "lapack: insufficient z length"
@@ -97,6 +110,56 @@ func checkVector(n int, v []float64, inc int) {
 	}
 }
 
+// checkSMatrix verifies the parameters of a float32 matrix input.
+func checkSMatrix(m, n int, a []float32, lda int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < n {
+		panic("lapack: stride less than number of columns")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+func checkSVector(n int, v []float32, inc int) {
+	if n < 0 {
+		panic("lapack: negative vector length")
+	}
+	if (inc > 0 && (n-1)*inc >= len(v)) || (inc < 0 && (1-n)*inc >= len(v)) {
+		panic("lapack: insufficient vector slice length")
+	}
+}
+
+// checkZMatrix verifies the parameters of a complex matrix input.
+func checkZMatrix(m, n int, a []complex128, lda int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < n {
+		panic("lapack: stride less than number of columns")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+func checkZVector(n int, v []complex128, inc int) {
+	if n < 0 {
+		panic("lapack: negative vector length")
+	}
+	if (inc > 0 && (n-1)*inc >= len(v)) || (inc < 0 && (1-n)*inc >= len(v)) {
+		panic("lapack: insufficient vector slice length")
+	}
+}
+
 func checkSymBanded(ab []float64, n, kd, lda int) {
 	if n < 0 {
 		panic("lapack: negative banded length")
@@ -112,6 +175,27 @@ func checkSymBanded(ab []float64, n, kd, lda int) {
 	}
 }
 
+// checkGeneralBanded verifies the parameters of a general band matrix input
+// stored with kl additional super-diagonals for the fill-in of an LU
+// factorization.
+func checkGeneralBanded(m, n, kl, ku int, ab []float64, ldab int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if kl < 0 || ku < 0 {
+		panic("lapack: negative bandwidth value")
+	}
+	if ldab < 2*kl+ku+1 {
+		panic("lapack: stride less than number of bands")
+	}
+	if r := min(m, n+kl); r > 0 && len(ab) < (r-1)*ldab+2*kl+ku+1 {
+		panic("lapack: insufficient banded vector length")
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -141,3 +225,12 @@ const (
 	// For IEEE this is 2^{-1022}.
 	dlamchS = 1.0 / (1 << 256) / (1 << 256) / (1 << 256) / (1 << 254)
 )
+
+const (
+	// slamchE is the machine epsilon for float32. For IEEE this is 2^{-24}.
+	slamchE = 1.0 / (1 << 24)
+
+	// slamchS is the "safe minimum" for float32, the smallest normal
+	// number. For IEEE this is 2^{-126}.
+	slamchS = 1.0 / (1 << 126)
+)
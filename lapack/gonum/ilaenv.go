@@ -165,12 +165,12 @@ func (impl Implementation) Ilaenv(ispec int, s string, opts string, n1, n2, n3,
 				panic("lapack: bad function name")
 			case "TRF":
 				if sname {
-					if n4 <= 64 {
+					if n2 <= 64 {
 						return 1
 					}
 					return 32
 				}
-				if n4 <= 64 {
+				if n2 <= 64 {
 					return 1
 				}
 				return 32
@@ -14,10 +14,54 @@ var appengine bool
 
 var impl = Implementation{}
 
+func TestDbdsdc(t *testing.T) {
+	testlapack.DbdsdcTest(t, impl)
+}
+
 func TestDbdsqr(t *testing.T) {
 	testlapack.DbdsqrTest(t, impl)
 }
 
+func TestDgbcon(t *testing.T) {
+	testlapack.DgbconTest(t, impl)
+}
+
+func TestDgbtf2(t *testing.T) {
+	testlapack.Dgbtf2Test(t, impl)
+}
+
+func TestDgbtrf(t *testing.T) {
+	testlapack.DgbtrfTest(t, impl)
+}
+
+func TestDgbtrs(t *testing.T) {
+	testlapack.DgbtrsTest(t, impl)
+}
+
+func TestDgeequ(t *testing.T) {
+	testlapack.DgeequTest(t, impl)
+}
+
+func TestDgerfs(t *testing.T) {
+	testlapack.DgerfsTest(t, impl)
+}
+
+func TestDgesdd(t *testing.T) {
+	testlapack.DgesddTest(t, impl)
+}
+
+func TestDgesv(t *testing.T) {
+	testlapack.DgesvTest(t, impl)
+}
+
+func TestDgesvx(t *testing.T) {
+	testlapack.DgesvxTest(t, impl)
+}
+
+func TestDhgeqz(t *testing.T) {
+	testlapack.DhgeqzTest(t, impl)
+}
+
 func TestDhseqr(t *testing.T) {
 	testlapack.DhseqrTest(t, impl)
 }
@@ -116,6 +160,14 @@ func TestDgetrs(t *testing.T) {
 	testlapack.DgetrsTest(t, impl)
 }
 
+func TestDggev(t *testing.T) {
+	testlapack.DggevTest(t, impl)
+}
+
+func TestDgghrd(t *testing.T) {
+	testlapack.DgghrdTest(t, impl)
+}
+
 func TestDggsvd3(t *testing.T) {
 	testlapack.Dggsvd3Test(t, impl)
 }
@@ -124,6 +176,10 @@ func TestDggsvp3(t *testing.T) {
 	testlapack.Dggsvp3Test(t, impl)
 }
 
+func TestDgtsv(t *testing.T) {
+	testlapack.DgtsvTest(t, impl)
+}
+
 func TestDlabrd(t *testing.T) {
 	testlapack.DlabrdTest(t, impl)
 }
@@ -148,6 +204,10 @@ func TestDlaexc(t *testing.T) {
 	testlapack.DlaexcTest(t, impl)
 }
 
+func TestDlag2(t *testing.T) {
+	testlapack.Dlag2Test(t, impl)
+}
+
 func TestDlags2(t *testing.T) {
 	testlapack.Dlags2Test(t, impl)
 }
@@ -368,14 +428,30 @@ func TestDorm2r(t *testing.T) {
 	testlapack.Dorm2rTest(t, impl)
 }
 
+func TestDpbcon(t *testing.T) {
+	testlapack.DpbconTest(t, impl)
+}
+
 func TestDpbtf2(t *testing.T) {
 	testlapack.Dpbtf2Test(t, impl)
 }
 
+func TestDpbtrf(t *testing.T) {
+	testlapack.DpbtrfTest(t, impl)
+}
+
+func TestDpbtrs(t *testing.T) {
+	testlapack.DpbtrsTest(t, impl)
+}
+
 func TestDpocon(t *testing.T) {
 	testlapack.DpoconTest(t, impl)
 }
 
+func TestDposv(t *testing.T) {
+	testlapack.DposvTest(t, impl)
+}
+
 func TestDpotf2(t *testing.T) {
 	testlapack.Dpotf2Test(t, impl)
 }
@@ -384,10 +460,38 @@ func TestDpotrf(t *testing.T) {
 	testlapack.DpotrfTest(t, impl)
 }
 
+func TestDpstf2(t *testing.T) {
+	testlapack.Dpstf2Test(t, impl)
+}
+
+func TestDpstrf(t *testing.T) {
+	testlapack.DpstrfTest(t, impl)
+}
+
+func TestDptsv(t *testing.T) {
+	testlapack.DptsvTest(t, impl)
+}
+
+func TestDpttrf(t *testing.T) {
+	testlapack.DpttrfTest(t, impl)
+}
+
 func TestDrscl(t *testing.T) {
 	testlapack.DrsclTest(t, impl)
 }
 
+func TestDstebz(t *testing.T) {
+	testlapack.DstebzTest(t, impl)
+}
+
+func TestDstein(t *testing.T) {
+	testlapack.DsteinTest(t, impl)
+}
+
+func TestDstemr(t *testing.T) {
+	testlapack.DstemrTest(t, impl)
+}
+
 func TestDsteqr(t *testing.T) {
 	testlapack.DsteqrTest(t, impl)
 }
@@ -396,18 +500,46 @@ func TestDsterf(t *testing.T) {
 	testlapack.DsterfTest(t, impl)
 }
 
+func TestDsycon(t *testing.T) {
+	testlapack.DsyconTest(t, impl)
+}
+
 func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
 
+func TestDsyevx(t *testing.T) {
+	testlapack.DsyevxTest(t, impl)
+}
+
+func TestDsyevr(t *testing.T) {
+	testlapack.DsyevrTest(t, impl)
+}
+
 func TestDsytd2(t *testing.T) {
 	testlapack.Dsytd2Test(t, impl)
 }
 
+func TestDsytf2(t *testing.T) {
+	testlapack.Dsytf2Test(t, impl)
+}
+
 func TestDsytrd(t *testing.T) {
 	testlapack.DsytrdTest(t, impl)
 }
 
+func TestDsytrf(t *testing.T) {
+	testlapack.DsytrfTest(t, impl)
+}
+
+func TestDsytrs(t *testing.T) {
+	testlapack.DsytrsTest(t, impl)
+}
+
+func TestDtgevc(t *testing.T) {
+	testlapack.DtgevcTest(t, impl)
+}
+
 func TestDtgsja(t *testing.T) {
 	testlapack.DtgsjaTest(t, impl)
 }
@@ -439,3 +571,39 @@ func TestIladlc(t *testing.T) {
 func TestIladlr(t *testing.T) {
 	testlapack.IladlrTest(t, impl)
 }
+
+func TestSgeqrf(t *testing.T) {
+	testlapack.SgeqrfTest(t, impl)
+}
+
+func TestSgetrf(t *testing.T) {
+	testlapack.SgetrfTest(t, impl)
+}
+
+func TestSgetrs(t *testing.T) {
+	testlapack.SgetrsTest(t, impl)
+}
+
+func TestSpotrf(t *testing.T) {
+	testlapack.SpotrfTest(t, impl)
+}
+
+func TestZgeqrf(t *testing.T) {
+	testlapack.ZgeqrfTest(t, impl)
+}
+
+func TestZgesvd(t *testing.T) {
+	testlapack.ZgesvdTest(t, impl)
+}
+
+func TestZgetrf(t *testing.T) {
+	testlapack.ZgetrfTest(t, impl)
+}
+
+func TestZgetrs(t *testing.T) {
+	testlapack.ZgetrsTest(t, impl)
+}
+
+func TestZheev(t *testing.T) {
+	testlapack.ZheevTest(t, impl)
+}
@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// Slarf applies an elementary reflector to a general rectangular matrix c.
+// This computes
+//  c = h * c if side == Left
+//  c = c * h if side == right
+// where
+//  h = 1 - tau * v * v^T
+// and c is an m * n matrix.
+//
+// work is temporary storage of length at least n if side == Left and at least
+// m if side == Right. This function will panic if this length requirement is not met.
+//
+// Slarf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Slarf(side blas.Side, m, n int, v []float32, incv int, tau float32, c []float32, ldc int, work []float32) {
+	applyleft := side == blas.Left
+	if (applyleft && len(work) < n) || (!applyleft && len(work) < m) {
+		panic(badWork)
+	}
+	checkSMatrix(m, n, c, ldc)
+
+	// v has length m if applyleft and n otherwise.
+	lenV := n
+	if applyleft {
+		lenV = m
+	}
+	checkSVector(lenV, v, incv)
+
+	if tau == 0 || m == 0 || n == 0 {
+		return
+	}
+	bi := blas32.Implementation()
+	if applyleft {
+		// Form H * C
+		// w = C^T * v
+		bi.Sgemv(blas.Trans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+		// C = C - tau * v * w^T
+		bi.Sger(m, n, -tau, v, incv, work, 1, c, ldc)
+		return
+	}
+	// Form C * H
+	// w = C * v
+	bi.Sgemv(blas.NoTrans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+	// C = C - tau * w * v^T
+	bi.Sger(m, n, -tau, work, 1, v, incv, c, ldc)
+}
@@ -0,0 +1,61 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/internal/math32"
+)
+
+// Slarfg generates an elementary reflector for a Householder matrix. It creates
+// a real elementary reflector of order n such that
+//  H * (alpha) = (beta)
+//      (    x)   (   0)
+//  H^T * H = I
+// H is represented in the form
+//  H = 1 - tau * (1; v) * (1 v^T)
+// where tau is a real scalar.
+//
+// On entry, x contains the vector x, on exit it contains v.
+//
+// Slarfg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Slarfg(n int, alpha float32, x []float32, incX int) (beta, tau float32) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n <= 1 {
+		return alpha, 0
+	}
+	checkSVector(n-1, x, incX)
+	bi := blas32.Implementation()
+	xnorm := bi.Snrm2(n-1, x, incX)
+	if xnorm == 0 {
+		return alpha, 0
+	}
+	beta = -math32.Copysign(math32.Hypot(alpha, xnorm), alpha)
+	safmin := float32(slamchS / slamchE)
+	knt := 0
+	if math32.Abs(beta) < safmin {
+		// xnorm and beta may be inaccurate, scale x and recompute.
+		rsafmn := 1 / safmin
+		for {
+			knt++
+			bi.Sscal(n-1, rsafmn, x, incX)
+			beta *= rsafmn
+			alpha *= rsafmn
+			if math32.Abs(beta) >= safmin {
+				break
+			}
+		}
+		xnorm = bi.Snrm2(n-1, x, incX)
+		beta = -math32.Copysign(math32.Hypot(alpha, xnorm), alpha)
+	}
+	tau = (beta - alpha) / beta
+	bi.Sscal(n-1, 1/(alpha-beta), x, incX)
+	for j := 0; j < knt; j++ {
+		beta *= safmin
+	}
+	return beta, tau
+}
@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Sormqr multiplies an m×n matrix C by an orthogonal matrix Q as
+//  C = Q * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Q,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^T,  if side == blas.Right and trans == blas.Trans,
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}.
+//
+// If side == blas.Left, A is an m×k matrix and 0 <= k <= m.
+// If side == blas.Right, A is an n×k matrix and 0 <= k <= n.
+// The ith column of A contains the vector which defines the elementary
+// reflector H_i and tau[i] contains its scalar factor. tau must have length k
+// and Sormqr will panic otherwise. Sgeqrf returns A and tau in the required
+// form.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Sormqr will
+// panic.
+//
+// If lwork is -1, instead of performing Sormqr, the optimal workspace size will
+// be stored into work[0].
+//
+// Sormqr is the unblocked version of the algorithm.
+func (impl Implementation) Sormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case k < 0 || nq < k:
+		panic("lapack: invalid value of k")
+	case len(work) < lwork:
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	lworkopt := max(1, nw)
+	if lwork == -1 {
+		work[0] = float32(lworkopt)
+		return
+	}
+	checkSMatrix(nq, k, a, lda)
+	checkSMatrix(m, n, c, ldc)
+	if len(tau) != k {
+		panic(badTau)
+	}
+
+	if m == 0 || n == 0 || k == 0 {
+		work[0] = 1
+		return
+	}
+	impl.Sorm2r(side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	work[0] = float32(lworkopt)
+}
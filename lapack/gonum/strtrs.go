@@ -0,0 +1,30 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// Strtrs solves a triangular system of the form A * X = B or A^T * X = B. Strtrs
+// returns whether the solve completed successfully. If A is singular, no solve is performed.
+func (impl Implementation) Strtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float32, lda int, b []float32, ldb int) (ok bool) {
+	nounit := diag == blas.NonUnit
+	if n == 0 {
+		return false
+	}
+	// Check for singularity.
+	if nounit {
+		for i := 0; i < n; i++ {
+			if a[i*lda+i] == 0 {
+				return false
+			}
+		}
+	}
+	bi := blas32.Implementation()
+	bi.Strsm(blas.Left, uplo, trans, diag, n, nrhs, 1, a, lda, b, ldb)
+	return true
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// Zgebd2 reduces a complex m×n matrix A to a real upper or lower bidiagonal
+// matrix B by a unitary transformation.
+//  Q^H * A * P = B
+// if m >= n, B is upper diagonal, otherwise B is lower bidiagonal.
+// d is the diagonal, len = min(m,n)
+// e is the off-diagonal len = min(m,n)-1
+//
+// Q and P are represented as products of elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+//  P = G_0 * G_1 * ... * G_{k-1}
+// where
+//  H_i = I - tauQ[i] * v * v^H
+//  G_i = I - tauP[i] * u * u^H
+// If m >= n, v[i] = 1, v[i+1:m] is stored in A[i+1:m, i], u[i+1] = 1 and the
+// conjugate of u[i+2:n] is stored in A[i, i+2:n]. If m < n, v[i+1] = 1,
+// v[i+2:m] is stored in A[i+2:m, i], u[i] = 1 and the conjugate of u[i+1:n]
+// is stored in A[i, i+1:n].
+//
+// work must have length at least max(m,n), and Zgebd2 will panic otherwise.
+//
+// Zgebd2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zgebd2(m, n int, a []complex128, lda int, d, e []float64, tauQ, tauP, work []complex128) {
+	checkZMatrix(m, n, a, lda)
+	if len(d) < min(m, n) {
+		panic(badD)
+	}
+	if len(e) < min(m, n)-1 {
+		panic(badE)
+	}
+	if len(tauQ) < min(m, n) {
+		panic(badTauQ)
+	}
+	if len(tauP) < min(m, n) {
+		panic(badTauP)
+	}
+	if len(work) < max(m, n) {
+		panic(badWork)
+	}
+	if m >= n {
+		for i := 0; i < n; i++ {
+			// Generate H_i to annihilate A[i+1:m, i].
+			a[i*lda+i], tauQ[i] = impl.Zlarfg(m-i, a[i*lda+i], a[min(i+1, m-1)*lda+i:], lda)
+			d[i] = real(a[i*lda+i])
+			// Apply H_i^H to A[i:m, i+1:n] from the left.
+			if i < n-1 {
+				a[i*lda+i] = 1
+				impl.Zlarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, cmplx.Conj(tauQ[i]), a[i*lda+i+1:], lda, work)
+			}
+			a[i*lda+i] = complex(d[i], 0)
+
+			if i < n-1 {
+				// Generate G_i to annihilate A[i, i+2:n].
+				impl.Zlacgv(n-i-1, a[i*lda+i+1:], 1)
+				a[i*lda+i+1], tauP[i] = impl.Zlarfg(n-i-1, a[i*lda+i+1], a[i*lda+min(i+2, n-1):], 1)
+				e[i] = real(a[i*lda+i+1])
+				// Apply G_i to A[i+1:m, i+1:n] from the right.
+				a[i*lda+i+1] = 1
+				impl.Zlarf(blas.Right, m-i-1, n-i-1, a[i*lda+i+1:], 1, tauP[i], a[(i+1)*lda+i+1:], lda, work)
+				impl.Zlacgv(n-i-1, a[i*lda+i+1:], 1)
+				a[i*lda+i+1] = complex(e[i], 0)
+			} else {
+				tauP[i] = 0
+			}
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		// Generate G_i to annihilate A[i, i+1:n].
+		impl.Zlacgv(n-i, a[i*lda+i:], 1)
+		a[i*lda+i], tauP[i] = impl.Zlarfg(n-i, a[i*lda+i], a[i*lda+min(i+1, n-1):], 1)
+		d[i] = real(a[i*lda+i])
+		// Apply G_i to A[i+1:m, i:n] from the right.
+		if i < m-1 {
+			a[i*lda+i] = 1
+			impl.Zlarf(blas.Right, m-i-1, n-i, a[i*lda+i:], 1, tauP[i], a[(i+1)*lda+i:], lda, work)
+		}
+		impl.Zlacgv(n-i, a[i*lda+i:], 1)
+		a[i*lda+i] = complex(d[i], 0)
+
+		if i < m-1 {
+			// Generate H_i to annihilate A[i+2:m, i].
+			a[(i+1)*lda+i], tauQ[i] = impl.Zlarfg(m-i-1, a[(i+1)*lda+i], a[min(i+2, m-1)*lda+i:], lda)
+			e[i] = real(a[(i+1)*lda+i])
+			// Apply H_i^H to A[i+1:m, i+1:n] from the left.
+			a[(i+1)*lda+i] = 1
+			impl.Zlarf(blas.Left, m-i-1, n-i-1, a[(i+1)*lda+i:], lda, cmplx.Conj(tauQ[i]), a[(i+1)*lda+i+1:], lda, work)
+			a[(i+1)*lda+i] = complex(e[i], 0)
+		} else {
+			tauQ[i] = 0
+		}
+	}
+}
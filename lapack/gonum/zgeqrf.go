@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Zgeqrf computes the QR factorization of the complex m×n matrix A. See the
+// documentation for Zgeqr2 for a description of the parameters at entry and
+// exit.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// The length of work must be at least max(1, lwork) and lwork must be -1
+// or at least n, otherwise this function will panic.
+// If lwork == -1, instead of performing Zgeqrf, the optimal work length will
+// be stored into work[0].
+//
+// tau must have length at least min(m,n), and this function will panic otherwise.
+func (impl Implementation) Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) {
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	lworkopt := max(1, n)
+	if lwork == -1 {
+		work[0] = complex(float64(lworkopt), 0)
+		return
+	}
+	checkZMatrix(m, n, a, lda)
+	if lwork < n {
+		panic(badWork)
+	}
+	k := min(m, n)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if k == 0 {
+		work[0] = complex(float64(lworkopt), 0)
+		return
+	}
+	impl.Zgeqr2(m, n, a, lda, tau, work)
+	work[0] = complex(float64(lworkopt), 0)
+}
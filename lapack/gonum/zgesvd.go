@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+const noZSVDO = "zgesvd: not coded for overwrite"
+
+// Zgesvd computes the singular value decomposition of the complex input
+// matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^H
+// where Sigma is a real m×n diagonal matrix containing the singular values of A,
+// U is an m×m unitary matrix and V is an n×n unitary matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDInPlace   The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^H. Zgesvd does not
+// support lapack.SVDOverwrite and will panic if either job is
+// lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Zgesvd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored columnwise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDInPlace u is
+// of size m×min(m,n). If jobU == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored rowwise. If
+// jobVT == lapack.SVDAll, vt is of size n×n. If jobVT == lapack.SVDInPlace vt
+// is of size min(m,n)×n. If jobVT == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. lwork must be at least 2*min(m,n)+max(m,n). If lwork == -1,
+// instead of performing Zgesvd, the optimal work length will be stored into
+// work[0]. Zgesvd will panic if the working memory has insufficient storage.
+//
+// rwork is temporary storage and must have length at least
+// 5*min(m,n) + 2*min(m,n)*min(m,n), and Zgesvd will panic otherwise.
+//
+// Zgesvd returns whether the decomposition successfully completed.
+func (impl Implementation) Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool) {
+	minmn := min(m, n)
+	checkZMatrix(m, n, a, lda)
+	if jobU == lapack.SVDOverwrite || jobVT == lapack.SVDOverwrite {
+		panic(noZSVDO)
+	}
+	if jobU != lapack.SVDAll && jobU != lapack.SVDInPlace && jobU != lapack.SVDNone {
+		panic(badSVDJob)
+	}
+	if jobVT != lapack.SVDAll && jobVT != lapack.SVDInPlace && jobVT != lapack.SVDNone {
+		panic(badSVDJob)
+	}
+	ucol := m
+	if jobU == lapack.SVDAll {
+		checkZMatrix(m, m, u, ldu)
+	} else if jobU == lapack.SVDInPlace {
+		checkZMatrix(m, minmn, u, ldu)
+		ucol = minmn
+	}
+	vrow := n
+	if jobVT == lapack.SVDAll {
+		checkZMatrix(n, n, vt, ldvt)
+	} else if jobVT == lapack.SVDInPlace {
+		checkZMatrix(minmn, n, vt, ldvt)
+		vrow = minmn
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+	lworkopt := max(1, 2*minmn+max(m, n))
+	if lwork == -1 {
+		work[0] = complex(float64(lworkopt), 0)
+		return true
+	}
+	if lwork < lworkopt || len(work) < lwork {
+		panic(badWork)
+	}
+	if len(rwork) < 5*minmn+2*minmn*minmn {
+		panic(badWork)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+	wantu := jobU != lapack.SVDNone
+	wantvt := jobVT != lapack.SVDNone
+
+	// Reduce A to real bidiagonal form B = Q^H * A * P.
+	tauQ := work[:minmn]
+	tauP := work[minmn : 2*minmn]
+	wrk := work[2*minmn:]
+	e := rwork[:minmn]
+	impl.Zgebd2(m, n, a, lda, s, e, tauQ, tauP, wrk)
+
+	// Compute the singular value decomposition of the bidiagonal matrix,
+	//  B = U_1 * Sigma * VT_1,
+	// accumulating the real singular vectors into identity matrices.
+	uplo := blas.Upper
+	if m < n {
+		uplo = blas.Lower
+	}
+	var nru, ncvt int
+	rw := rwork[minmn:]
+	var u1, vt1 []float64
+	if wantu {
+		nru = minmn
+		u1 = rw[:minmn*minmn]
+		rw = rw[minmn*minmn:]
+		impl.Dlaset(blas.All, minmn, minmn, 0, 1, u1, minmn)
+	}
+	if wantvt {
+		ncvt = minmn
+		vt1 = rw[:minmn*minmn]
+		rw = rw[minmn*minmn:]
+		impl.Dlaset(blas.All, minmn, minmn, 0, 1, vt1, minmn)
+	}
+	ok = impl.Dbdsqr(uplo, minmn, ncvt, nru, 0, s, e, vt1, minmn, u1, minmn, nil, 1, rw)
+	if !ok {
+		return false
+	}
+
+	if wantu {
+		// Form U = Q * [U_1 0; 0 I].
+		for i := 0; i < m; i++ {
+			for j := 0; j < ucol; j++ {
+				switch {
+				case i < minmn && j < minmn:
+					u[i*ldu+j] = complex(u1[i*minmn+j], 0)
+				case i == j:
+					u[i*ldu+j] = 1
+				default:
+					u[i*ldu+j] = 0
+				}
+			}
+		}
+		if m >= n {
+			for i := n - 1; i >= 0; i-- {
+				a[i*lda+i] = 1
+				impl.Zlarf(blas.Left, m-i, ucol, a[i*lda+i:], lda, tauQ[i], u[i*ldu:], ldu, wrk)
+			}
+		} else {
+			for i := m - 2; i >= 0; i-- {
+				a[(i+1)*lda+i] = 1
+				impl.Zlarf(blas.Left, m-i-1, ucol, a[(i+1)*lda+i:], lda, tauQ[i], u[(i+1)*ldu:], ldu, wrk)
+			}
+		}
+	}
+
+	if wantvt {
+		// Form V^H = [VT_1 0; 0 I] * P^H.
+		for i := 0; i < vrow; i++ {
+			for j := 0; j < n; j++ {
+				switch {
+				case i < minmn && j < minmn:
+					vt[i*ldvt+j] = complex(vt1[i*minmn+j], 0)
+				case i == j:
+					vt[i*ldvt+j] = 1
+				default:
+					vt[i*ldvt+j] = 0
+				}
+			}
+		}
+		if m >= n {
+			for i := n - 2; i >= 0; i-- {
+				a[i*lda+i+1] = 1
+				impl.Zlacgv(n-i-1, a[i*lda+i+1:], 1)
+				impl.Zlarf(blas.Right, vrow, n-i-1, a[i*lda+i+1:], 1, cmplx.Conj(tauP[i]), vt[i+1:], ldvt, wrk)
+			}
+		} else {
+			for i := m - 1; i >= 0; i-- {
+				a[i*lda+i] = 1
+				impl.Zlacgv(n-i, a[i*lda+i:], 1)
+				impl.Zlarf(blas.Right, vrow, n-i, a[i*lda+i:], 1, cmplx.Conj(tauP[i]), vt[i:], ldvt, wrk)
+			}
+		}
+	}
+	work[0] = complex(float64(lworkopt), 0)
+	return true
+}
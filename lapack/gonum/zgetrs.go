@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zgetrs solves a system of equations using an LU factorization.
+// The system of equations solved is
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+//  A^H * X = B  if trans == blas.ConjTrans
+// A is a general complex n×n matrix with stride lda. B is a general complex
+// matrix of size n×nrhs.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+//
+// a and ipiv contain the LU factorization of A and the permutation indices as
+// computed by Zgetrf. ipiv is zero-indexed.
+func (impl Implementation) Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int) {
+	checkZMatrix(n, n, a, lda)
+	checkZMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTrans)
+	}
+	bi := cblas128.Implementation()
+	if trans == blas.NoTrans {
+		// Solve A * X = B.
+		impl.Zlaswp(nrhs, b, ldb, 0, n-1, ipiv, 1)
+		// Solve L * X = B, updating b.
+		bi.Ztrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit,
+			n, nrhs, 1, a, lda, b, ldb)
+		// Solve U * X = B, updating b.
+		bi.Ztrsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit,
+			n, nrhs, 1, a, lda, b, ldb)
+		return
+	}
+	// Solve A^T * X = B or A^H * X = B.
+	// Solve U^T * X = B or U^H * X = B, updating b.
+	bi.Ztrsm(blas.Left, blas.Upper, trans, blas.NonUnit,
+		n, nrhs, 1, a, lda, b, ldb)
+	// Solve L^T * X = B or L^H * X = B, updating b.
+	bi.Ztrsm(blas.Left, blas.Lower, trans, blas.Unit,
+		n, nrhs, 1, a, lda, b, ldb)
+	impl.Zlaswp(nrhs, b, ldb, 0, n-1, ipiv, -1)
+}
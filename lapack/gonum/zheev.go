@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Zheev computes all eigenvalues and, optionally, the eigenvectors of a complex
+// Hermitian matrix A.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Zheev will panic otherwise.
+//
+// On entry, a contains the elements of the Hermitian matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= max(1, 2*n-1), and Zheev will panic otherwise. If
+// lwork == -1, instead of computing Zheev the optimal work length is stored
+// into work[0].
+//
+// rwork is temporary storage and must have length at least max(1, 3*n-2) if
+// only the eigenvalues are computed and at least n*n+3*n-2 if the eigenvectors
+// are computed. Zheev will panic otherwise.
+//
+// Zheev returns whether the decomposition successfully completed.
+func (impl Implementation) Zheev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) (ok bool) {
+	checkZMatrix(n, n, a, lda)
+	upper := uplo == blas.Upper
+	if !upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	wantz := jobz == lapack.ComputeEV
+	lworkopt := max(1, 2*n-1)
+	work[0] = complex(float64(lworkopt), 0)
+	if lwork == -1 {
+		return
+	}
+	if len(work) < lwork {
+		panic(badWork)
+	}
+	if lwork < lworkopt {
+		panic(badWork)
+	}
+	lrwork := max(1, 3*n-2)
+	if wantz {
+		lrwork += n * n
+	}
+	if len(rwork) < lrwork {
+		panic(badWork)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		w[0] = real(a[0])
+		if wantz {
+			a[0] = 1
+		}
+		return true
+	}
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	var anrm float64
+	for i := 0; i < n; i++ {
+		jmin, jmax := 0, i+1
+		if upper {
+			jmin, jmax = i, n
+		}
+		for _, v := range a[i*lda+jmin : i*lda+jmax] {
+			anrm = math.Max(anrm, cmplx.Abs(v))
+		}
+	}
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		for i := 0; i < n; i++ {
+			jmin, jmax := 0, i+1
+			if upper {
+				jmin, jmax = i, n
+			}
+			for j := jmin; j < jmax; j++ {
+				a[i*lda+j] *= complex(sigma, 0)
+			}
+		}
+	}
+
+	// Reduce to real tridiagonal form.
+	e := rwork[:n-1]
+	tau := work[:n-1]
+	impl.Zhetd2(uplo, n, a, lda, w, e, tau)
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, compute the
+	// eigenvectors of the tridiagonal matrix with Dsteqr and transform
+	// them with the unitary matrix formed by Zungtr.
+	if !wantz {
+		ok = impl.Dsterf(n, w, e)
+	} else {
+		z := rwork[n-1 : n-1+n*n]
+		ok = impl.Dsteqr(lapack.TridiagEV, n, w, e, z, n, rwork[n-1+n*n:])
+		if ok {
+			impl.Zungtr(uplo, n, a, lda, tau, work[n-1:])
+			// Form Q * Z one row at a time.
+			row := work[n-1 : 2*n-1]
+			for i := 0; i < n; i++ {
+				for j := range row {
+					row[j] = 0
+				}
+				for k := 0; k < n; k++ {
+					qik := a[i*lda+k]
+					if qik == 0 {
+						continue
+					}
+					for j, zkj := range z[k*n : k*n+n] {
+						row[j] += qik * complex(zkj, 0)
+					}
+				}
+				copy(a[i*lda:i*lda+n], row)
+			}
+		}
+	}
+	if !ok {
+		return false
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi := blas64.Implementation()
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	work[0] = complex(float64(lworkopt), 0)
+	return true
+}
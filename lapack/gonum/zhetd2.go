@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zhetd2 reduces a Hermitian n×n matrix A to real symmetric tridiagonal form T
+// by a unitary similarity transformation
+//  Q^H * A * Q = T
+// On entry, the matrix is contained in the specified triangle of a. On exit,
+// if uplo == blas.Upper, the diagonal and first super-diagonal of a are
+// overwritten with the elements of T. The elements above the first super-diagonal
+// are overwritten with the the elementary reflectors that are used with the
+// elements written to tau in order to construct Q. If uplo == blas.Lower, the
+// elements are written in the lower triangular region.
+//
+// d must have length at least n. e and tau must have length at least n-1. Zhetd2
+// will panic if these sizes are not met.
+//
+// Q is represented as a product of elementary reflectors.
+// If uplo == blas.Upper
+//  Q = H_{n-2} * ... * H_1 * H_0
+// and if uplo == blas.Lower
+//  Q = H_0 * H_1 * ... * H_{n-2}
+// where
+//  H_i = I - tau * v * v^H
+// where tau is stored in tau[i], and v is stored in a.
+//
+// If uplo == blas.Upper, v[0:i-1] is stored in A[0:i-1,i+1], v[i] = 1, and
+// v[i+1:] = 0. If uplo == blas.Lower, v[0:i+1] = 0, v[i+1] = 1, and v[i+2:]
+// is stored in A[i+2:n,i]. See Dsytd2 for the layout of the elements of a.
+//
+// Zhetd2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zhetd2(uplo blas.Uplo, n int, a []complex128, lda int, d, e []float64, tau []complex128) {
+	checkZMatrix(n, n, a, lda)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(tau) < n-1 {
+		panic(badTau)
+	}
+	if n <= 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	if uplo == blas.Upper {
+		// Reduce the upper triangle of A.
+		a[(n-1)*lda+n-1] = complex(real(a[(n-1)*lda+n-1]), 0)
+		for i := n - 2; i >= 0; i-- {
+			// Generate elementary reflector H_i = I - tau * v * v^H
+			// to annihilate A[0:i, i+1].
+			var taui complex128
+			var alpha complex128
+			alpha, taui = impl.Zlarfg(i+1, a[i*lda+i+1], a[i+1:], lda)
+			e[i] = real(alpha)
+			if taui != 0 {
+				// Apply H_i from both sides to A[0:i+1, 0:i+1].
+				a[i*lda+i+1] = 1
+
+				// Compute x := tau * A * v, storing x in tau[0:i+1].
+				bi.Zhemv(blas.Upper, i+1, taui, a, lda, a[i+1:], lda, 0, tau, 1)
+
+				// Compute w := x - 1/2 * tau * (x^H * v) * v.
+				alpha = -0.5 * taui * bi.Zdotc(i+1, tau, 1, a[i+1:], lda)
+				bi.Zaxpy(i+1, alpha, a[i+1:], lda, tau, 1)
+
+				// Apply the transformation as a rank-2 update
+				// A = A - v * w^H - w * v^H.
+				bi.Zher2(blas.Upper, i+1, -1, a[i+1:], lda, tau, 1, a, lda)
+			} else {
+				a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+			}
+			a[i*lda+i+1] = complex(e[i], 0)
+			d[i+1] = real(a[(i+1)*lda+i+1])
+			tau[i] = taui
+		}
+		d[0] = real(a[0])
+		return
+	}
+	// Reduce the lower triangle of A.
+	a[0] = complex(real(a[0]), 0)
+	for i := 0; i < n-1; i++ {
+		// Generate elementary reflector H_i = I - tau * v * v^H
+		// to annihilate A[i+2:n, i].
+		var taui complex128
+		var alpha complex128
+		alpha, taui = impl.Zlarfg(n-i-1, a[(i+1)*lda+i], a[min(i+2, n-1)*lda+i:], lda)
+		e[i] = real(alpha)
+		if taui != 0 {
+			// Apply H_i from both sides to A[i+1:n, i+1:n].
+			a[(i+1)*lda+i] = 1
+
+			// Compute x := tau * A * v, storing x in tau[i:n-1].
+			bi.Zhemv(blas.Lower, n-i-1, taui, a[(i+1)*lda+i+1:], lda, a[(i+1)*lda+i:], lda, 0, tau[i:], 1)
+
+			// Compute w := x - 1/2 * tau * (x^H * v) * v.
+			alpha = -0.5 * taui * bi.Zdotc(n-i-1, tau[i:], 1, a[(i+1)*lda+i:], lda)
+			bi.Zaxpy(n-i-1, alpha, a[(i+1)*lda+i:], lda, tau[i:], 1)
+
+			// Apply the transformation as a rank-2 update
+			// A = A - v * w^H - w * v^H.
+			bi.Zher2(blas.Lower, n-i-1, -1, a[(i+1)*lda+i:], lda, tau[i:], 1, a[(i+1)*lda+i+1:], lda)
+		} else {
+			a[(i+1)*lda+i+1] = complex(real(a[(i+1)*lda+i+1]), 0)
+		}
+		a[(i+1)*lda+i] = complex(e[i], 0)
+		d[i] = real(a[i*lda+i])
+		tau[i] = taui
+	}
+	d[n-1] = real(a[(n-1)*lda+n-1])
+}
@@ -0,0 +1,25 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math/cmplx"
+
+// Zlacgv conjugates the n elements of the complex vector x in place.
+//
+// Zlacgv is an internal routine. It is exported for testing purposes.
+func (Implementation) Zlacgv(n int, x []complex128, incX int) {
+	checkZVector(n, x, incX)
+	if n == 0 {
+		return
+	}
+	var ix int
+	if incX < 0 {
+		ix = (1 - n) * incX
+	}
+	for i := 0; i < n; i++ {
+		x[ix] = cmplx.Conj(x[ix])
+		ix += incX
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zung2r generates an m×n complex matrix Q with orthonormal columns defined
+// by the product of elementary reflectors as computed by Zgeqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// len(tau) >= k, 0 <= k <= n, 0 <= n <= m, len(work) >= n.
+// Zung2r will panic if these conditions are not met.
+//
+// Zung2r is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zung2r(m, n, k int, a []complex128, lda int, tau []complex128, work []complex128) {
+	checkZMatrix(m, n, a, lda)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+	if k > n {
+		panic(kGTN)
+	}
+	if n > m {
+		panic(mLTN)
+	}
+	if n == 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	// Initialize columns k+1:n to columns of the unit matrix.
+	for l := 0; l < m; l++ {
+		for j := k; j < n; j++ {
+			a[l*lda+j] = 0
+		}
+	}
+	for j := k; j < n; j++ {
+		a[j*lda+j] = 1
+	}
+	for i := k - 1; i >= 0; i-- {
+		if i < n-1 {
+			// Apply H_i to A[i:m, i+1:n] from the left.
+			a[i*lda+i] = 1
+			impl.Zlarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, tau[i], a[i*lda+i+1:], lda, work)
+		}
+		if i < m-1 {
+			bi.Zscal(m-i-1, -tau[i], a[(i+1)*lda+i:], lda)
+		}
+		a[i*lda+i] = 1 - tau[i]
+		for l := 0; l < i; l++ {
+			a[l*lda+i] = 0
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Zungqr generates an m×n complex matrix Q with orthonormal columns defined
+// by the product of elementary reflectors as computed by Zgeqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// The length of tau must be at least k, and the length of work must be at
+// least n. It also must be that 0 <= k <= n and 0 <= n <= m.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= n, and the amount of blocking is limited by the usable
+// length. If lwork == -1, instead of computing Zungqr the optimal work length
+// is stored into work[0].
+//
+// Zungqr will panic if the conditions on input values are not met.
+func (impl Implementation) Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) {
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	lworkopt := max(1, n)
+	if lwork == -1 {
+		work[0] = complex(float64(lworkopt), 0)
+		return
+	}
+	checkZMatrix(m, n, a, lda)
+	if k < 0 {
+		panic(kLT0)
+	}
+	if k > n {
+		panic(kGTN)
+	}
+	if n > m {
+		panic(mLTN)
+	}
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if lwork < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		work[0] = 1
+		return
+	}
+	impl.Zung2r(m, n, k, a, lda, tau, work)
+	work[0] = complex(float64(lworkopt), 0)
+}
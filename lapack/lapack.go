@@ -13,30 +13,75 @@ type Job byte
 type Comp byte
 
 // Complex128 defines the public complex128 LAPACK API supported by gonum/lapack.
-type Complex128 interface{}
+type Complex128 interface {
+	Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zgesvd(jobU, jobVT SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool)
+	Zgetrf(m, n int, a []complex128, lda int, ipiv []int) (ok bool)
+	Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int)
+	Zheev(jobz EVJob, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) (ok bool)
+	Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int)
+}
+
+// Float32 defines the public float32 LAPACK API supported by gonum/lapack.
+type Float32 interface {
+	Sgeqrf(m, n int, a []float32, lda int, tau, work []float32, lwork int)
+	Sgetrf(m, n int, a []float32, lda int, ipiv []int) (ok bool)
+	Sgetrs(trans blas.Transpose, n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int)
+	Sormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int)
+	Spotrf(ul blas.Uplo, n int, a []float32, lda int) (ok bool)
+	Strtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float32, lda int, b []float32, ldb int) (ok bool)
+}
 
 // Float64 defines the public float64 LAPACK API supported by gonum/lapack.
 type Float64 interface {
+	Dgbcon(norm MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
 	Dgecon(norm MatrixNorm, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dgehrd(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dgeev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (first int)
 	Dgels(trans blas.Transpose, m, n, nrhs int, a []float64, lda int, b []float64, ldb int, work []float64, lwork int) bool
 	Dgelqf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgeqp3(m, n int, a []float64, lda int, jpvt []int, tau, work []float64, lwork int)
 	Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgesdd(jobz SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+	Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool)
 	Dgesvd(jobU, jobVT SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) (ok bool)
+	Dgesvx(fact FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut EquilibrationType, rcond, rpvgrw float64, ok bool)
 	Dgetrf(m, n int, a []float64, lda int, ipiv []int) (ok bool)
 	Dgetri(n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
 	Dgetrs(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dggev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+	Dgghrd(compq, compz EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int)
 	Dggsvd3(jobU, jobV, jobQ GSVDJob, m, n, p int, a []float64, lda int, b []float64, ldb int, alpha, beta, u []float64, ldu int, v []float64, ldv int, q []float64, ldq int, work []float64, lwork int, iwork []int) (k, l int, ok bool)
+	Dgtsv(trans blas.Transpose, n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+	Dhgeqz(job EVJob, compq, compz EVComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int, alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int) (unconverged int)
+	Dhseqr(job EVJob, compz EVComp, n, ilo, ihi int, h []float64, ldh int, wr, wi []float64, z []float64, ldz int, work []float64, lwork int) (unconverged int)
 	Dlantr(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, m, n int, a []float64, lda int, work []float64) float64
 	Dlange(norm MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
 	Dlansy(norm MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
 	Dlapmt(forward bool, m, n int, x []float64, ldx int, k []int)
+	Dlartg(f, g float64) (cs, sn, r float64)
+	Dorghr(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
 	Dormlq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+	Dpbcon(ul blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+	Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
 	Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
 	Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool)
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
 	Dsyev(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
+	Dsyevr(jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork []int) (m int, ok bool)
+	Dsyevx(jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork []int) (m int, ok bool)
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dtgevc(side EVSide, howmny HowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool)
 	Dtrcon(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64
+	Dtrexc(compq EVComp, n int, t []float64, ldt int, q []float64, ldq int, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool)
 	Dtrtri(uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int) (ok bool)
 	Dtrtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
 }
@@ -154,6 +199,36 @@ const (
 	ComputeRightEV RightEVJob = 'V' // Compute right eigenvectors.
 )
 
+// EVRange specifies the eigenvalues to compute.
+type EVRange byte
+
+// EVRange constants for Dsyevr, Dsyevx and Dstebz.
+const (
+	AllEVs   EVRange = 'A' // Compute all eigenvalues.
+	ValueEVs EVRange = 'V' // Compute the eigenvalues in the half-open interval (vl, vu].
+	IndexEVs EVRange = 'I' // Compute the eigenvalues with indices il through iu.
+)
+
+// EquilibrationType specifies the scaling applied to a matrix by equilibration.
+type EquilibrationType byte
+
+const (
+	NoEquilibration   EquilibrationType = 'N' // No equilibration.
+	RowEquilibration  EquilibrationType = 'R' // Row equilibration, A is replaced by diag(R)*A.
+	ColEquilibration  EquilibrationType = 'C' // Column equilibration, A is replaced by A*diag(C).
+	BothEquilibration EquilibrationType = 'B' // Both, A is replaced by diag(R)*A*diag(C).
+)
+
+// FactorizationType specifies how the factorization of the matrix is obtained
+// by the expert linear solvers.
+type FactorizationType byte
+
+const (
+	Factored          FactorizationType = 'F' // The factorization is supplied on entry.
+	FactorNew         FactorizationType = 'N' // The matrix is factorized.
+	FactorEquilibrate FactorizationType = 'E' // The matrix is equilibrated if necessary and then factorized.
+)
+
 // Jobs for Dgebal.
 const (
 	Permute      Job = 'P'
@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack128
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/gonum"
+)
+
+var lapack128 lapack.Complex128 = gonum.Implementation{}
+
+// Use sets the LAPACK complex128 implementation to be used by subsequent lapack128 calls.
+// The default implementation is gonum.Implementation.
+func Use(l lapack.Complex128) {
+	lapack128 = l
+}
+
+// Geqrf computes the QR factorization of the m×n matrix A. A is modified to
+// contain the information to construct Q and R. The upper triangle of a
+// contains the matrix R. The lower triangular elements (not including the
+// diagonal) contain the elementary reflectors. tau is modified to contain the
+// reflector scales. tau must have length at least min(m,n), and this function
+// will panic otherwise.
+//
+// The ith elementary reflector can be explicitly constructed by first extracting
+// the
+//  v[j] = 0           j < i
+//  v[j] = 1           j == i
+//  v[j] = a[j*lda+i]  j > i
+// and computing H_i = I - tau[i] * v * v^H.
+//
+// The unitary matrix Q can be constucted from a product of these elementary
+// reflectors, Q = H_0 * H_1 * ... * H_{k-1}, where k = min(m,n).
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n and this function will panic otherwise.
+// If lwork == -1, instead of performing Geqrf, the optimal work length will
+// be stored into work[0].
+func Geqrf(a cblas128.General, tau, work []complex128, lwork int) {
+	lapack128.Zgeqrf(a.Rows, a.Cols, a.Data, a.Stride, tau, work, lwork)
+}
+
+// Gesvd computes the singular value decomposition of the input matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^H
+// where Sigma is a real m×n diagonal matrix containing the singular values of
+// A, U is an m×m unitary matrix and V is an n×n unitary matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDInPlace   The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^H.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesvd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored columnwise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDInPlace u is
+// of size m×min(m,n). If jobU == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored rowwise. If
+// jobVT == lapack.SVDAll, vt is of size n×n. If jobVT == lapack.SVDInPlace vt
+// is of size min(m,n)×n. If jobVT == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. lwork must be at least 2*min(m,n)+max(m,n). If lwork == -1,
+// instead of performing Gesvd, the optimal work length will be stored into
+// work[0]. rwork is temporary storage and must have length at least
+// 5*min(m,n) + 2*min(m,n)*min(m,n). Gesvd will panic if the working memory has
+// insufficient storage.
+//
+// Gesvd returns whether the decomposition successfully completed.
+func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt cblas128.General, s []float64, work []complex128, lwork int, rwork []float64) (ok bool) {
+	return lapack128.Zgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork, rwork)
+}
+
+// Getrf computes the LU decomposition of the m×n matrix A.
+// The LU decomposition is a factorization of A into
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is a (usually) non-unit upper triangular matrix. On exit, L and U are stored
+// in place into a.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
+// otherwise. ipiv is zero-indexed.
+//
+// Getrf returns whether the matrix A is singular. The LU decomposition will
+// be computed regardless of the singularity of A, but division by zero
+// will occur if the false is returned and the result is used to solve a
+// system of equations.
+func Getrf(a cblas128.General, ipiv []int) bool {
+	return lapack128.Zgetrf(a.Rows, a.Cols, a.Data, a.Stride, ipiv)
+}
+
+// Getrs solves a system of equations using an LU factorization.
+// The system of equations solved is
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+//  A^H * X = B  if trans == blas.ConjTrans
+// A is a general n×n matrix. B is a general matrix of size n×nrhs.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+//
+// a and ipiv contain the LU factorization of A and the permutation indices as
+// computed by Getrf. ipiv is zero-indexed.
+func Getrs(trans blas.Transpose, a cblas128.General, b cblas128.General, ipiv []int) {
+	lapack128.Zgetrs(trans, a.Cols, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Heev computes all eigenvalues and, optionally, the eigenvectors of a
+// Hermitian matrix A.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Heev will panic otherwise.
+//
+// On entry, a contains the elements of the Hermitian matrix A in the triangular
+// portion specified by a.Uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// Work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= max(1, 2*n-1), and Heev will panic otherwise. If
+// lwork == -1, instead of computing Heev the optimal work length is stored
+// into work[0]. rwork is temporary storage and must have length at least
+// max(1, 3*n-2) if only the eigenvalues are computed and at least n*n+3*n-2
+// if the eigenvectors are computed.
+func Heev(jobz lapack.EVJob, a cblas128.Hermitian, w []float64, work []complex128, lwork int, rwork []float64) (ok bool) {
+	return lapack128.Zheev(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork, rwork)
+}
+
+// Ungqr generates an m×n matrix Q with orthonormal columns defined by the
+// product of elementary reflectors as computed by Geqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// The length of tau must be at least k = len(tau), and it must be that
+// k <= n <= m.
+//
+// On entry, a contains the elementary reflectors in the first k columns as
+// returned by Geqrf. On exit, a contains Q.
+//
+// Work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= n, and Ungqr will panic otherwise. If lwork == -1,
+// instead of computing Ungqr the optimal work length is stored into work[0].
+func Ungqr(a cblas128.General, tau, work []complex128, lwork int) {
+	lapack128.Zungqr(a.Rows, a.Cols, len(tau), a.Data, a.Stride, tau, work, lwork)
+}
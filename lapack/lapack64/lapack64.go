@@ -37,6 +37,73 @@ func Potrf(a blas64.Symmetric) (t blas64.Triangular, ok bool) {
 	return
 }
 
+// Pstrf computes the Cholesky factorization with complete pivoting of the
+// symmetric positive semidefinite matrix a.
+// The factorization has the form
+//  P^T * A * P = U^T * U if a.Uplo == blas.Upper, or
+//  P^T * A * P = L * L^T if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix, L is lower triangular and P is a
+// permutation matrix with ones at the elements (piv[k], k).
+// The triangular matrix is returned in t, and the underlying data between
+// a and t is shared. The factorization stops when the largest remaining
+// diagonal element is less than or equal to tol, and only the leading rank
+// rows (or columns) of t hold the factor. If tol is negative, n*eps*max(A[k,k])
+// is used instead. The returned bool indicates whether a has full rank.
+//
+// The length of piv must be a.N and the length of work must be at least 2*a.N,
+// otherwise Pstrf will panic.
+func Pstrf(a blas64.Symmetric, piv []int, tol float64, work []float64) (t blas64.Triangular, rank int, ok bool) {
+	rank, ok = lapack64.Dpstrf(a.Uplo, a.N, a.Data, a.Stride, piv, tol, work)
+	t.Uplo = a.Uplo
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	t.Diag = blas.NonUnit
+	return
+}
+
+// Gbtrf computes an LU factorization of the m×n band matrix A with a.KL
+// sub-diagonals and a.KU super-diagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U,
+// where P is a permutation matrix, L is lower triangular with unit diagonal
+// elements and U is upper triangular with a.KL+a.KU super-diagonals.
+//
+// The element A[i,j] is stored in a.Data[i*a.Stride+a.KL+j-i], which is the
+// storage used by blas64.Band, and a.Stride must be at least 2*a.KL+a.KU+1
+// to hold the fill-in of the factorization. On return, a contains the
+// factors L and U as described in the documentation of Dgbtf2 in
+// gonum.org/v1/gonum/lapack/gonum.
+//
+// ipiv must have length at least min(m,n). Gbtrf returns whether U is
+// non-singular.
+func Gbtrf(a blas64.Band, ipiv []int) (ok bool) {
+	return lapack64.Dgbtrf(a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv)
+}
+
+// Gbtrs solves a system of linear equations
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans
+// with an n×n band matrix A using the LU factorization computed by Gbtrf. On
+// entry, b contains the right hand side matrix B, and on return it contains
+// the solution matrix X.
+func Gbtrs(trans blas.Transpose, a blas64.Band, b blas64.General, ipiv []int) {
+	lapack64.Dgbtrs(trans, a.Cols, a.KL, a.KU, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Gbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A given the LU factorization computed by Gbtrf. The condition number
+// computed may be based on the 1-norm or the ∞-norm.
+//
+// anorm is the 1-norm or the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Gbcon will panic
+// otherwise. iwork is a temporary data slice of length at least n and Gbcon
+// will panic otherwise.
+func Gbcon(norm lapack.MatrixNorm, a blas64.Band, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgbcon(norm, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
 // Gecon estimates the reciprocal of the condition number of the n×n matrix A
 // given the LU decomposition of the matrix. The condition number computed may
 // be based on the 1-norm or the ∞-norm.
@@ -85,6 +152,27 @@ func Gels(trans blas.Transpose, a blas64.General, b blas64.General, work []float
 	return lapack64.Dgels(trans, a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, work, lwork)
 }
 
+// Geqp3 computes a QR factorization with column pivoting of the m×n matrix A:
+//  A*P = Q*R.
+// On return, the upper triangle of a contains the matrix R and the elements
+// below the diagonal, with tau, represent Q as a product of elementary
+// reflectors as described in Geqrf. The magnitudes of the diagonal elements
+// of R are non-increasing.
+//
+// jpvt specifies a column pivot to be applied to A. If jpvt[j] is at least
+// zero, the jth column of A is permuted to the front of A*P, if jpvt[j] is -1
+// the jth column of A is a free column. On return, jpvt holds the permutation
+// that was applied; the jth column of A*P was the jpvt[j] column of A. jpvt
+// must have length n and tau must have length min(m,n), otherwise Geqp3 will
+// panic.
+//
+// work must have length at least max(1,lwork), and lwork must be at least
+// 3*n+1, otherwise Geqp3 will panic. If lwork == -1, instead of performing
+// Geqp3, only the optimal value of lwork will be stored in work[0].
+func Geqp3(a blas64.General, jpvt []int, tau, work []float64, lwork int) {
+	lapack64.Dgeqp3(a.Rows, a.Cols, a.Data, a.Stride, jpvt, tau, work, lwork)
+}
+
 // Geqrf computes the QR factorization of the m×n matrix A using a blocked
 // algorithm. A is modified to contain the information to construct Q and R.
 // The upper triangle of a contains the matrix R. The lower triangular elements
@@ -111,6 +199,30 @@ func Geqrf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgeqrf(a.Rows, a.Cols, a.Data, a.Stride, tau, work, lwork)
 }
 
+// Gehrd reduces a block of a real n×n general matrix A to upper Hessenberg
+// form H by an orthogonal similarity transformation Q^T * A * Q = H.
+//
+// The matrix Q is represented as a product of (ihi-ilo) elementary
+// reflectors
+//  Q = H_{ilo} H_{ilo+1} ... H_{ihi-1}.
+// On return, the upper triangle and the first subdiagonal of A will be
+// overwritten with the upper Hessenberg matrix H, and the elements below the
+// first subdiagonal, with the slice tau, represent the orthogonal matrix Q.
+//
+// ilo and ihi determine the block of A that will be reduced to upper Hessenberg
+// form. They are typically 0 and n-1, respectively. tau must have length n-1
+// if n > 0, otherwise Gehrd will panic.
+//
+// work must have length at least lwork and lwork must be at least max(1,n),
+// otherwise Gehrd will panic. If lwork == -1, instead of performing Gehrd, the
+// optimal work length will be stored into work[0].
+func Gehrd(a blas64.General, ilo, ihi int, tau, work []float64, lwork int) {
+	if a.Rows != a.Cols {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dgehrd(a.Rows, ilo, ihi, a.Data, a.Stride, tau, work, lwork)
+}
+
 // Gelqf computes the LQ factorization of the m×n matrix A using a blocked
 // algorithm. A is modified to contain the information to construct L and Q. The
 // lower triangle of a contains the matrix L. The elements above the diagonal
@@ -177,6 +289,61 @@ func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt blas64.General, s, work []float64
 	return lapack64.Dgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork)
 }
 
+// Gesdd computes the singular value decomposition of the input matrix A using
+// a divide and conquer method. It is significantly faster than Gesvd for
+// large matrices when singular vectors are requested.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies the singular vectors to compute. See the documentation of
+// Dgesdd for the details of the supported jobs and of the sizes of u and vt.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesdd
+// the data is overwritten. s is a slice of length at least min(m,n) and on exit
+// contains the singular values in decreasing order.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If lwork == -1, instead of performing Gesdd, the optimal work
+// length will be stored into work[0]. iwork must have length at least
+// 8*min(m,n). Gesdd will panic if the working memory has insufficient storage.
+//
+// Gesdd returns whether the decomposition successfully completed.
+func Gesdd(jobz lapack.SVDJob, a, u, vt blas64.General, s, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgesdd(jobz, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork, iwork)
+}
+
+// Gesv computes the solution to the system of linear equations
+//  A * X = B
+// where A is an n×n matrix and X and B are n×nrhs matrices, using the LU
+// decomposition of A. On return, a contains the factors L and U, ipiv contains
+// the row pivots and b contains the solution X.
+//
+// Gesv returns whether A is nonsingular. If false is returned the solution
+// has not been computed.
+func Gesv(a blas64.General, ipiv []int, b blas64.General) (ok bool) {
+	return lapack64.Dgesv(a.Rows, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Gesvx solves the system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// using the LU factorization of A, optionally after equilibrating A, and
+// refines the solution iteratively. The forward error bounds and backward
+// errors of each column of the solution are returned in ferr and berr, and the
+// reciprocal condition number and pivot growth are returned in rcond and
+// rpvgrw.
+//
+// See the documentation of Dgesvx for the details of the factorization and
+// equilibration options and of the workspace requirements.
+func Gesvx(fact lapack.FactorizationType, trans blas.Transpose, a, af blas64.General, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	return lapack64.Dgesvx(fact, trans, a.Rows, b.Cols, a.Data, a.Stride, af.Data, af.Stride, ipiv, equed, r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
 // Getrf computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of A into
 //  A = P * L * U
@@ -329,6 +496,59 @@ func Ggsvd3(jobU, jobV, jobQ lapack.GSVDJob, a, b blas64.General, alpha, beta []
 	return lapack64.Dggsvd3(jobU, jobV, jobQ, a.Rows, a.Cols, b.Rows, a.Data, a.Stride, b.Data, b.Stride, alpha, beta, u.Data, u.Stride, v.Data, v.Stride, q.Data, q.Stride, work, lwork, iwork)
 }
 
+// Gtsv solves one of the equations
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans
+// where A is an n×n tridiagonal matrix with sub-diagonal dl, diagonal d and
+// super-diagonal du, using Gaussian elimination with partial pivoting. On
+// return dl, d and du are overwritten by the factorization of A, and, if ok
+// is true, b contains the solution matrix X. Gtsv returns false if A is
+// exactly singular.
+func Gtsv(trans blas.Transpose, dl, d, du []float64, b blas64.General) (ok bool) {
+	return lapack64.Dgtsv(trans, len(d), b.Cols, dl, d, du, b.Data, b.Stride)
+}
+
+// Hseqr computes the eigenvalues of an n×n Hessenberg matrix H and,
+// optionally, the matrices T and Z from the Schur decomposition
+//  H = Z T Z^T,
+// where T is an n×n upper quasi-triangular matrix (the Schur form), and Z is
+// the n×n orthogonal matrix of Schur vectors.
+//
+// If job == lapack.EigenvaluesAndSchur, on return H will contain the Schur
+// form T with 2×2 diagonal blocks in standard form. If job ==
+// lapack.EigenvaluesOnly, only the eigenvalues will be computed.
+//
+// If compz == lapack.None, Z will not be referenced. If compz == lapack.HessEV,
+// on return Z will contain the matrix of Schur vectors of H. If compz ==
+// lapack.OriginalEV, on entry Z is assumed to contain the orthogonal matrix Q
+// that reduced a matrix A to the Hessenberg form H, and on return Z will be
+// updated to the product Q*Z, giving the Schur vectors of A.
+//
+// ilo and ihi determine the block of H on which Hseqr operates and are
+// typically 0 and n-1, respectively.
+//
+// On return, wr and wi will contain the real and imaginary parts,
+// respectively, of the computed eigenvalues in the order they appear on the
+// diagonal of T. wr and wi must have length n.
+//
+// work must have length at least lwork and lwork must be at least max(1,n),
+// otherwise Hseqr will panic. If lwork == -1, instead of performing Hseqr, the
+// optimal work length will be stored into work[0].
+//
+// unconverged is the number of eigenvalues that Hseqr failed to compute. See
+// the documentation of Dhseqr for the state of the output when unconverged is
+// positive.
+func Hseqr(job lapack.EVJob, compz lapack.EVComp, h blas64.General, ilo, ihi int, wr, wi []float64, z blas64.General, work []float64, lwork int) (unconverged int) {
+	n := h.Rows
+	if h.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if compz != lapack.None && (z.Rows != n || z.Cols != n) {
+		panic("lapack64: bad size of Z")
+	}
+	return lapack64.Dhseqr(job, compz, n, ilo, ihi, h.Data, h.Stride, wr, wi, z.Data, z.Stride, work, lwork)
+}
+
 // Lange computes the matrix norm of the general m×n matrix A. The input norm
 // specifies the norm computed.
 //  lapack.MaxAbs: the maximum absolute value of an element.
@@ -372,6 +592,32 @@ func Lapmt(forward bool, x blas64.General, k []int) {
 	lapack64.Dlapmt(forward, x.Rows, x.Cols, x.Data, x.Stride, k)
 }
 
+// Lartg generates a plane rotation so that
+//  [ cs sn] * [f] = [r]
+//  [-sn cs]   [g] = [0]
+// If g = 0, then cs = 1 and sn = 0, and if f = 0 and g != 0, then cs = 0 and
+// sn = 1. If abs(f) > abs(g), cs will be positive.
+func Lartg(f, g float64) (cs, sn, r float64) {
+	return lapack64.Dlartg(f, g)
+}
+
+// Orghr generates an n×n orthogonal matrix Q which is defined as the product
+// of ihi-ilo elementary reflectors as returned by Gehrd. On entry, A must
+// contain the vectors which define the elementary reflectors, as returned by
+// Gehrd, and on return A will contain the matrix Q. ilo and ihi must have the
+// same values as in the previous call of Gehrd, and tau must contain the
+// scalar factors of the elementary reflectors.
+//
+// work must have length at least lwork and lwork must be at least ihi-ilo,
+// otherwise Orghr will panic. If lwork == -1, instead of performing Orghr, the
+// optimal work length will be stored into work[0].
+func Orghr(ilo, ihi int, a blas64.General, tau, work []float64, lwork int) {
+	if a.Rows != a.Cols {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dorghr(a.Rows, ilo, ihi, a.Data, a.Stride, tau, work, lwork)
+}
+
 // Ormlq multiplies the matrix C by the othogonal matrix Q defined by
 // A and tau. A and tau are as returned from Gelqf.
 //  C = Q * C    if side == blas.Left and trans == blas.NoTrans
@@ -425,6 +671,45 @@ func Ormqr(side blas.Side, trans blas.Transpose, a blas64.General, tau []float64
 	lapack64.Dormqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, a.Stride, tau, c.Data, c.Stride, work, lwork)
 }
 
+// Pbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A with k super-diagonals (or sub-diagonals). The
+// factorization has the form
+//  A = U^T * U if a.Uplo == blas.Upper
+//  A = L * L^T if a.Uplo == blas.Lower
+// The factor is stored in-place into a and returned as a triangular band
+// matrix sharing the data of a. Pbtrf returns whether the factorization was
+// successfully completed.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, a.Stride)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
+// Pbtrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization computed
+// by Pbtrf. On entry, b contains the right hand side matrix B, and on return
+// it contains the solution matrix X.
+func Pbtrs(t blas64.TriangularBand, b blas64.General) {
+	lapack64.Dpbtrs(t.Uplo, t.N, t.K, b.Cols, t.Data, t.Stride, b.Data, b.Stride)
+}
+
+// Pbcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite band matrix A in the 1-norm given the Cholesky
+// factorization of A computed by Pbtrf. anorm is the 1-norm of the original
+// matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Pbcon will panic
+// otherwise. iwork is a temporary data slice of length at least n and Pbcon
+// will panic otherwise.
+func Pbcon(t blas64.TriangularBand, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dpbcon(t.Uplo, t.N, t.K, t.Data, t.Stride, anorm, work, iwork)
+}
+
 // Pocon estimates the reciprocal of the condition number of a positive-definite
 // matrix A given the Cholesky decmposition of A. The condition number computed
 // is based on the 1-norm and the ∞-norm.
@@ -438,6 +723,39 @@ func Pocon(a blas64.Symmetric, anorm float64, work []float64, iwork []int) float
 	return lapack64.Dpocon(a.Uplo, a.N, a.Data, a.Stride, anorm, work, iwork)
 }
 
+// Posv computes the solution to the system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices, using the Cholesky factorization of A. On return, a contains
+// the Cholesky factor in the triangle specified by a.Uplo and b contains the
+// solution X.
+//
+// Posv returns whether A is positive definite. If false is returned the
+// solution has not been computed.
+func Posv(a blas64.Symmetric, b blas64.General) (ok bool) {
+	return lapack64.Dposv(a.Uplo, a.N, b.Cols, a.Data, a.Stride, b.Data, b.Stride)
+}
+
+// Ptsv solves the equation A * X = B where A is an n×n symmetric positive
+// definite tridiagonal matrix with diagonal d and sub-diagonal e, using the
+// L*D*L^T factorization of A. On return d and e are overwritten by the
+// factorization of A, and, if ok is true, b contains the solution matrix X.
+// Ptsv returns false if A is not positive definite.
+func Ptsv(d, e []float64, b blas64.General) (ok bool) {
+	return lapack64.Dptsv(len(d), b.Cols, d, e, b.Data, b.Stride)
+}
+
+// Sycon estimates the reciprocal of the condition number of the symmetric
+// matrix A in the 1-norm given the factorization computed by Sytrf. anorm is
+// the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Sycon will panic
+// otherwise. iwork is a temporary data slice of length at least n and Sycon
+// will panic otherwise.
+func Sycon(a blas64.Symmetric, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dsycon(a.Uplo, a.N, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
 // Syev computes all eigenvalues and, optionally, the eigenvectors of a real
 // symmetric matrix A.
 //
@@ -457,6 +775,67 @@ func Syev(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int) (
 	return lapack64.Dsyev(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork)
 }
 
+// Syevr computes selected eigenvalues and, optionally, eigenvectors of the
+// symmetric matrix A using the MRRR algorithm. Eigenvalues can be selected by
+// a range of values or a range of indices as specified by rng. See the
+// documentation of Dsyevr for the details.
+//
+// On return, m is the number of eigenvalues found and the first m elements of
+// w contain them in ascending order. If jobz == lapack.ComputeEV, the first m
+// columns of z contain the corresponding orthonormal eigenvectors. On exit the
+// triangle of a specified by a.Uplo is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length. If
+// lwork == -1, instead of computing Syevr the optimal work length is stored into
+// work[0]. iwork must have length at least 12*n.
+func Syevr(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, abstol float64, w []float64, z blas64.General, work []float64, lwork int, iwork []int) (m int, ok bool) {
+	return lapack64.Dsyevr(jobz, rng, a.Uplo, a.N, a.Data, a.Stride, vl, vu, il, iu, abstol, w, z.Data, z.Stride, work, lwork, iwork)
+}
+
+// Syevx computes selected eigenvalues and, optionally, eigenvectors of the
+// symmetric matrix A. Eigenvalues can be selected by a range of values or a
+// range of indices as specified by rng. See the documentation of Dsyevx for
+// the details.
+//
+// On return, m is the number of eigenvalues found and the first m elements of
+// w contain them in ascending order. If jobz == lapack.ComputeEV, the first m
+// columns of z contain the corresponding orthonormal eigenvectors. On exit the
+// triangle of a specified by a.Uplo is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length. If
+// lwork == -1, instead of computing Syevx the optimal work length is stored into
+// work[0]. iwork must have length at least n.
+func Syevx(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, abstol float64, w []float64, z blas64.General, work []float64, lwork int, iwork []int) (m int, ok bool) {
+	return lapack64.Dsyevx(jobz, rng, a.Uplo, a.N, a.Data, a.Stride, vl, vu, il, iu, abstol, w, z.Data, z.Stride, work, lwork, iwork)
+}
+
+// Sytrf computes the factorization of the symmetric matrix A using the
+// Bunch-Kaufman diagonal pivoting method. The form of the factorization is
+//  A = U * D * U^T,  if a.Uplo == blas.Upper,
+//  A = L * D * L^T,  if a.Uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks. On return, a contains D and the multipliers used to obtain the
+// factor U or L, and ipiv contains details of the interchanges and the block
+// structure of D. See the documentation of Dsytf2 for the format of ipiv.
+// ipiv must have length n, otherwise Sytrf will panic.
+//
+// work must have length at least lwork and lwork must be at least 1,
+// otherwise Sytrf will panic. If lwork == -1, instead of performing Sytrf,
+// the optimal work length will be stored into work[0].
+//
+// Sytrf returns whether D is non-singular.
+func Sytrf(a blas64.Symmetric, ipiv []int, work []float64, lwork int) (ok bool) {
+	return lapack64.Dsytrf(a.Uplo, a.N, a.Data, a.Stride, ipiv, work, lwork)
+}
+
+// Sytrs solves a system of linear equations A * X = B with a symmetric matrix
+// A using the factorization computed by Sytrf. On entry, b contains the right
+// hand side matrix B, and on return it contains the solution matrix X.
+func Sytrs(a blas64.Symmetric, ipiv []int, b blas64.General) {
+	lapack64.Dsytrs(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
 // Trcon estimates the reciprocal of the condition number of a triangular matrix A.
 // The condition number computed may be based on the 1-norm or the ∞-norm.
 //
@@ -467,6 +846,36 @@ func Trcon(norm lapack.MatrixNorm, a blas64.Triangular, work []float64, iwork []
 	return lapack64.Dtrcon(norm, a.Uplo, a.Diag, a.N, a.Data, a.Stride, work, iwork)
 }
 
+// Trexc reorders the real Schur factorization of a n×n real matrix
+//  A = Q*T*Q^T
+// so that the diagonal block of T with row index ifst is moved to row ilst.
+//
+// On entry, T must be in Schur canonical form, that is, block upper triangular
+// with 1×1 and 2×2 diagonal blocks; each 2×2 diagonal block has its diagonal
+// elements equal and its off-diagonal elements of opposite sign. On return, T
+// will be reordered by an orthogonal similarity transformation Z as Z^T*T*Z,
+// and will be again in Schur canonical form.
+//
+// If compq is lapack.UpdateSchur, on return the matrix Q of Schur vectors will
+// be updated by postmultiplying it with Z. If compq is lapack.None, Q is not
+// referenced.
+//
+// ilstOut will point to the first row of the block in its final position. If
+// ok is false, two adjacent blocks were too close to swap and T may have been
+// partially reordered.
+//
+// work must have length at least n, otherwise Trexc will panic.
+func Trexc(compq lapack.EVComp, t, q blas64.General, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
+	n := t.Rows
+	if t.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if compq == lapack.UpdateSchur && (q.Rows != n || q.Cols != n) {
+		panic("lapack64: bad size of Q")
+	}
+	return lapack64.Dtrexc(compq, n, t.Data, t.Stride, q.Data, q.Stride, ifst, ilst, work)
+}
+
 // Trtri computes the inverse of a triangular matrix, storing the result in place
 // into a.
 //
@@ -543,3 +952,66 @@ func Geev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a blas64.General, wr,
 	}
 	return lapack64.Dgeev(jobvl, jobvr, n, a.Data, a.Stride, wr, wi, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
 }
+
+// Ggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// The right generalized eigenvector v_j of (A,B) corresponding to the
+// generalized eigenvalue λ_j is defined by
+//  A v_j = λ_j B v_j,
+// and the left generalized eigenvector u_j corresponding to λ_j is defined by
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR
+// in the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that its
+// largest component has |real part| + |imag part| = 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None.
+// Right eigenvectors will be computed only if jobvr == lapack.ComputeRightEV,
+// otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Ggev will panic.
+//
+// On return, the generalized eigenvalues are
+//  λ_j = (alphar[j] + i*alphai[j]) / beta[j].
+// Complex conjugate pairs of eigenvalues appear consecutively with the
+// eigenvalue having the positive imaginary part first. beta[j] will be
+// non-negative and is zero for infinite eigenvalues. alphar, alphai and beta
+// must have length n, and Ggev will panic otherwise.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n).
+// For good performance, lwork must generally be larger. On return, optimal
+// value of lwork will be stored in work[0].
+//
+// If lwork == -1, instead of performing Ggev, the function only calculates the
+// optimal value of lwork and stores it into work[0].
+//
+// Ggev returns whether the computation was successful.
+func Ggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a, b blas64.General, alphar, alphai, beta []float64, vl, vr blas64.General, work []float64, lwork int) (ok bool) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if b.Rows != n || b.Cols != n {
+		panic("lapack64: bad size of B")
+	}
+	if jobvl == lapack.ComputeLeftEV && (vl.Rows != n || vl.Cols != n) {
+		panic("lapack64: bad size of VL")
+	}
+	if jobvr == lapack.ComputeRightEV && (vr.Rows != n || vr.Cols != n) {
+		panic("lapack64: bad size of VR")
+	}
+	return lapack64.Dggev(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
+}
@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dbdsdcer interface {
+	Dbdsdc(uplo blas.Uplo, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool)
+
+	Dbdsqrer
+}
+
+func DbdsdcTest(t *testing.T, impl Dbdsdcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 10, 25, 26, 27, 51, 52, 100, 153} {
+			for _, ld := range []int{max(1, n), n + 5} {
+				for typ := 0; typ < 5; typ++ {
+					testDbdsdc(t, impl, rnd, uplo, n, ld, typ)
+				}
+			}
+		}
+	}
+}
+
+func testDbdsdc(t *testing.T, impl Dbdsdcer, rnd *rand.Rand, uplo blas.Uplo, n, ld, typ int) {
+	const tol = 1e-13
+
+	d := make([]float64, n)
+	e := make([]float64, max(0, n-1))
+	switch typ {
+	case 0:
+		// Random matrix.
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case 1:
+		// Constant diagonal and off-diagonal.
+		for i := range d {
+			d[i] = 1
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	case 2:
+		// Nearly diagonal with repeated values.
+		for i := range d {
+			d[i] = float64(1 + rnd.Intn(3))
+		}
+		for i := range e {
+			e[i] = 1e-20 * rnd.NormFloat64()
+		}
+	case 3:
+		// Graded matrix.
+		for i := range d {
+			d[i] = math.Pow(2, -float64(i))
+		}
+		for i := range e {
+			e[i] = math.Pow(2, -float64(i)-0.5)
+		}
+	case 4:
+		// Matrix with zeros on the diagonal.
+		for i := range d {
+			if rnd.Intn(3) != 0 {
+				d[i] = rnd.NormFloat64()
+			}
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	}
+	b := constructBidiagonal(uplo, n, d, e)
+
+	// Compute the singular values with Dbdsqr for comparison.
+	want := make([]float64, n)
+	copy(want, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dbdsqr(uplo, n, 0, 0, 0, want, eCopy, nil, 1, nil, 1, nil, 1, make([]float64, 4*n))
+
+	u := nanSlice(max(0, (n-1)*ld+n))
+	vt := nanSlice(max(0, (n-1)*ld+n))
+	work := nanSlice(4*n*n + 9*n)
+	iwork := make([]int, 8*n)
+	ok := impl.Dbdsdc(uplo, n, d, e, u, ld, vt, ld, work, iwork)
+
+	prefix := fmt.Sprintf("uplo=%c,n=%v,ld=%v,type=%v", uplo, n, ld, typ)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(d))) {
+		t.Errorf("%v: singular values not sorted", prefix)
+	}
+	if d[n-1] < 0 {
+		t.Errorf("%v: negative singular value", prefix)
+	}
+	if !floats.EqualApprox(d, want, tol*math.Max(1, want[0])) {
+		t.Errorf("%v: singular values differ from Dbdsqr", prefix)
+	}
+
+	uMat := blas64.General{Rows: n, Cols: n, Stride: ld, Data: u}
+	vtMat := blas64.General{Rows: n, Cols: n, Stride: ld, Data: vt}
+	if !isOrthonormal(uMat) {
+		t.Errorf("%v: U is not orthogonal", prefix)
+	}
+	if !isOrthonormal(vtMat) {
+		t.Errorf("%v: V^T is not orthogonal", prefix)
+	}
+
+	// Check that U * S * V^T = B.
+	us := cloneGeneral(uMat)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			us.Data[i*us.Stride+j] *= d[j]
+		}
+	}
+	usvt := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vtMat, 0, usvt)
+	if !equalApproxGeneral(usvt, b, tol*math.Max(1, d[0])*float64(n)) {
+		t.Errorf("%v: U*S*V^T does not equal B", prefix)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgbconer interface {
+	Dgbtrfer
+	Dlanger
+	Dgecon(norm lapack.MatrixNorm, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+	Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DgbconTest(t *testing.T, impl Dgbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, n := range []int{1, 2, 5, 10, 25} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 3} {
+					name := fmt.Sprintf("norm=%c,n=%d,kl=%d,ku=%d", norm, n, kl, ku)
+					a, ab, ldab := randomGeneralBand(n, n, kl, ku, 0, rnd)
+					work := make([]float64, 4*n)
+					iwork := make([]int, n)
+					anorm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+
+					ipiv := make([]int, n)
+					if !impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv) {
+						t.Errorf("%v: unexpected singular matrix", name)
+						continue
+					}
+					got := impl.Dgbcon(norm, n, kl, ku, ab, ldab, ipiv, anorm, work, iwork)
+
+					// Dgbtrf and Dgetrf compute the same factors, so the
+					// estimates should agree closely.
+					luIpiv := make([]int, n)
+					impl.Dgetrf(n, n, a.Data, a.Stride, luIpiv)
+					want := impl.Dgecon(norm, n, a.Data, a.Stride, anorm, work, iwork)
+					if !floats.EqualWithinAbsOrRel(want, got, 1e-14, 1e-8) {
+						t.Errorf("%v: Dgbcon and Dgecon mismatch: Dgbcon %v, Dgecon %v", name, got, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgbtf2er interface {
+	Dgbtf2(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgetrfer
+}
+
+func Dgbtf2Test(t *testing.T, impl Dgbtf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 5, 11} {
+		for _, n := range []int{0, 1, 2, 5, 11} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, ldoff := range []int{0, 3} {
+						testDgbtf2(t, impl, rnd, m, n, kl, ku, ldoff)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtf2(t *testing.T, impl Dgbtf2er, rnd *rand.Rand, m, n, kl, ku, ldoff int) {
+	name := fmt.Sprintf("m=%d,n=%d,kl=%d,ku=%d,ldab=%d", m, n, kl, ku, 2*kl+ku+1+ldoff)
+
+	a, ab, ldab := randomGeneralBand(m, n, kl, ku, ldoff, rnd)
+	ipiv := make([]int, min(m, n))
+	ok := impl.Dgbtf2(m, n, kl, ku, ab, ldab, ipiv)
+	if min(m, n) == 0 {
+		if !ok {
+			t.Errorf("%v: unexpected failure for empty matrix", name)
+		}
+		return
+	}
+
+	// Compare against the dense LU factorization, which chooses the
+	// same pivots.
+	wantIpiv := make([]int, min(m, n))
+	wantOk := impl.Dgetrf(m, n, a.Data, a.Stride, wantIpiv)
+	if ok != wantOk {
+		t.Errorf("%v: unexpected ok: got %v, want %v", name, ok, wantOk)
+	}
+	for i, v := range ipiv {
+		if v != wantIpiv[i] {
+			t.Errorf("%v: unexpected ipiv: got %v, want %v", name, ipiv, wantIpiv)
+			break
+		}
+	}
+	for i := 0; i < min(m, n); i++ {
+		for j := i; j < min(n, i+kl+ku+1); j++ {
+			got := ab[i*ldab+kl+j-i]
+			want := a.Data[i*a.Stride+j]
+			if math.Abs(got-want) > 1e-13 {
+				t.Errorf("%v: unexpected U[%d,%d]: got %v, want %v", name, i, j, got, want)
+			}
+		}
+		for j := i + kl + ku + 1; j < n; j++ {
+			if a.Data[i*a.Stride+j] != 0 {
+				t.Errorf("%v: U[%d,%d] outside the band is not zero", name, i, j)
+			}
+		}
+	}
+	// Check that the elements outside the storage of the band
+	// were not modified.
+	for i := 0; i < min(m, n+kl); i++ {
+		for jb := 0; jb < ldab; jb++ {
+			j := i + jb - kl
+			if (j < 0 || n <= j || jb >= 2*kl+ku+1) && !math.IsNaN(ab[i*ldab+jb]) {
+				t.Errorf("%v: unexpected write to unreferenced element ab[%d,%d]", name, i, jb)
+			}
+		}
+	}
+}
+
+// randomGeneralBand returns a random m×n band matrix with kl sub-diagonals and
+// ku super-diagonals as a general matrix a and in the band storage used by
+// Dgbtrf. Elements of ab outside the band are set to NaN.
+func randomGeneralBand(m, n, kl, ku, ldoff int, rnd *rand.Rand) (a blas64.General, ab []float64, ldab int) {
+	a = zeros(m, n, max(1, n))
+	ldab = 2*kl + ku + 1 + ldoff
+	r := min(m, n+kl)
+	ab = make([]float64, r*ldab)
+	for i := range ab {
+		ab[i] = math.NaN()
+	}
+	for i := 0; i < m; i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			v := rnd.NormFloat64()
+			a.Data[i*a.Stride+j] = v
+			ab[i*ldab+kl+j-i] = v
+		}
+	}
+	return a, ab, ldab
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dgbtrfer interface {
+	Dgbtf2er
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+}
+
+func DgbtrfTest(t *testing.T, impl Dgbtrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 3, 8, 20} {
+		for _, n := range []int{0, 1, 3, 8, 20} {
+			for _, kl := range []int{0, 1, 3, 7} {
+				for _, ku := range []int{0, 1, 4} {
+					dgbtrfTest(t, impl, rnd, m, n, kl, ku, 2, -1, 1e-13)
+				}
+			}
+		}
+	}
+	// Band widths large enough for the blocked code to be used.
+	for _, m := range []int{40, 100, 150} {
+		for _, n := range []int{40, 100, 150} {
+			for _, kl := range []int{32, 45, 70} {
+				for _, ku := range []int{65, 90} {
+					for _, zeroCol := range []int{-1, n / 2} {
+						dgbtrfTest(t, impl, rnd, m, n, kl, ku, 3, zeroCol, 1e-10)
+					}
+				}
+			}
+		}
+	}
+}
+
+// dgbtrfTest compares the factorization computed by Dgbtrf with that computed
+// by Dgbtf2 for a random band matrix. If zeroCol is not negative, the column
+// zeroCol of the matrix is set to zero.
+func dgbtrfTest(t *testing.T, impl Dgbtrfer, rnd *rand.Rand, m, n, kl, ku, ldoff, zeroCol int, tol float64) {
+	name := fmt.Sprintf("m=%d,n=%d,kl=%d,ku=%d,zeroCol=%d", m, n, kl, ku, zeroCol)
+	_, ab, ldab := randomGeneralBand(m, n, kl, ku, ldoff, rnd)
+	if zeroCol >= 0 {
+		for i := max(0, zeroCol-ku); i <= min(m-1, zeroCol+kl); i++ {
+			ab[i*ldab+kl+zeroCol-i] = 0
+		}
+	}
+	want := make([]float64, len(ab))
+	copy(want, ab)
+
+	ipiv := make([]int, min(m, n))
+	ok := impl.Dgbtrf(m, n, kl, ku, ab, ldab, ipiv)
+	wantIpiv := make([]int, min(m, n))
+	wantOk := impl.Dgbtf2(m, n, kl, ku, want, ldab, wantIpiv)
+	if ok != wantOk {
+		t.Errorf("%v: unexpected ok: got %v, want %v", name, ok, wantOk)
+	}
+	for i, v := range ipiv {
+		if v != wantIpiv[i] {
+			t.Errorf("%v: unexpected ipiv: got %v, want %v", name, ipiv, wantIpiv)
+			break
+		}
+	}
+	for i, v := range ab {
+		if math.IsNaN(v) != math.IsNaN(want[i]) || (!math.IsNaN(v) && math.Abs(v-want[i]) > tol) {
+			t.Errorf("%v: factorization mismatch with Dgbtf2", name)
+			break
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgbtrser interface {
+	Dgbtrfer
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+}
+
+func DgbtrsTest(t *testing.T, impl Dgbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{1, 2, 5, 10, 25} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, nrhs := range []int{1, 3} {
+						for _, ldb := range []int{nrhs, nrhs + 2} {
+							testDgbtrs(t, impl, rnd, trans, n, kl, ku, nrhs, ldb)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrs(t *testing.T, impl Dgbtrser, rnd *rand.Rand, trans blas.Transpose, n, kl, ku, nrhs, ldb int) {
+	name := fmt.Sprintf("trans=%c,n=%d,kl=%d,ku=%d,nrhs=%d,ldb=%d", trans, n, kl, ku, nrhs, ldb)
+
+	a, ab, ldab := randomGeneralBand(n, n, kl, ku, 1, rnd)
+	ipiv := make([]int, n)
+	if !impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv) {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+
+	want := randomGeneral(n, nrhs, ldb, rnd)
+	b := nanGeneral(n, nrhs, ldb)
+	blas64.Gemm(trans, blas.NoTrans, 1, a, want, 0, b)
+	bCopy := cloneGeneral(b)
+
+	impl.Dgbtrs(trans, n, kl, ku, nrhs, ab, ldab, ipiv, b.Data, b.Stride)
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to b", name)
+	}
+
+	// Check the residual |op(A)*X - B| relative to |A|*|X|.
+	var anorm, xnorm float64
+	for _, v := range a.Data {
+		anorm = math.Max(anorm, math.Abs(v))
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	blas64.Gemm(trans, blas.NoTrans, 1, a, b, -1, bCopy)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			r := math.Abs(bCopy.Data[i*bCopy.Stride+j])
+			if r > 1e-13*float64(n)*anorm*xnorm {
+				t.Errorf("%v: residual too large at (%d,%d): %v", name, i, j, r)
+				return
+			}
+		}
+	}
+}
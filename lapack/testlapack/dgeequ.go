@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dgeequer interface {
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+}
+
+func DgeequTest(t *testing.T, impl Dgeequer) {
+	const tol = 1e-14
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{1, 2, 5, 10, 30} {
+		for _, n := range []int{1, 2, 5, 10, 30} {
+			for _, lda := range []int{n, n + 3} {
+				// Construct a badly scaled matrix.
+				a := randomGeneral(m, n, lda, rnd)
+				rs := make([]float64, m)
+				for i := range rs {
+					rs[i] = math.Pow(10, float64(rnd.Intn(21)-10))
+				}
+				cs := make([]float64, n)
+				for j := range cs {
+					cs[j] = math.Pow(10, float64(rnd.Intn(21)-10))
+				}
+				var wantAmax float64
+				for i := 0; i < m; i++ {
+					for j := 0; j < n; j++ {
+						a.Data[i*lda+j] *= rs[i] * cs[j]
+						wantAmax = math.Max(wantAmax, math.Abs(a.Data[i*lda+j]))
+					}
+				}
+
+				r := nanSlice(m)
+				c := nanSlice(n)
+				rowcnd, colcnd, amax, ok := impl.Dgeequ(m, n, a.Data, lda, r, c)
+				prefix := fmt.Sprintf("m=%v,n=%v,lda=%v", m, n, lda)
+				if !ok {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+				if amax != wantAmax {
+					t.Errorf("%v: unexpected amax: got %v, want %v", prefix, amax, wantAmax)
+				}
+				if rowcnd <= 0 || rowcnd > 1 || colcnd <= 0 || colcnd > 1 {
+					t.Errorf("%v: condition of scale factors out of range: rowcnd=%v, colcnd=%v", prefix, rowcnd, colcnd)
+				}
+
+				// Check that the largest element in each row of
+				// diag(r)*A*diag(c) is at most one and that the
+				// largest element in each column is one.
+				rowMax := make([]float64, m)
+				colMax := make([]float64, n)
+				for i := 0; i < m; i++ {
+					for j := 0; j < n; j++ {
+						v := math.Abs(r[i] * a.Data[i*lda+j] * c[j])
+						rowMax[i] = math.Max(rowMax[i], v)
+						colMax[j] = math.Max(colMax[j], v)
+					}
+				}
+				for i, v := range rowMax {
+					if v > 1+tol || v == 0 {
+						t.Errorf("%v: unexpected maximum of row %v: %v", prefix, i, v)
+					}
+				}
+				for j, v := range colMax {
+					if math.Abs(v-1) > tol {
+						t.Errorf("%v: unexpected maximum of column %v: %v", prefix, j, v)
+					}
+				}
+
+				// Check that a zero row and a zero column are
+				// detected.
+				if m > 1 {
+					zr := cloneGeneral(a)
+					for j := 0; j < n; j++ {
+						zr.Data[(m-1)*lda+j] = 0
+					}
+					_, _, _, ok = impl.Dgeequ(m, n, zr.Data, lda, r, c)
+					if ok {
+						t.Errorf("%v: zero row not detected", prefix)
+					}
+				}
+				if n > 1 {
+					zc := cloneGeneral(a)
+					for i := 0; i < m; i++ {
+						zc.Data[i*lda] = 0
+					}
+					_, _, _, ok = impl.Dgeequ(m, n, zc.Data, lda, r, c)
+					if ok {
+						t.Errorf("%v: zero column not detected", prefix)
+					}
+				}
+			}
+		}
+	}
+}
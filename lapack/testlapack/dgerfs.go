@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgerfser interface {
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+
+	Dgetrser
+}
+
+func DgerfsTest(t *testing.T, impl Dgerfser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, nrhs := range []int{1, 3} {
+				for _, ld := range []int{max(1, n), n + 3} {
+					testDgerfs(t, impl, rnd, trans, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func testDgerfs(t *testing.T, impl Dgerfser, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ld int) {
+	const eps = 1.0 / (1 << 53)
+
+	a := randomGeneral(n, n, max(1, ld), rnd)
+	xTrue := randomGeneral(n, nrhs, nrhs+ld-n, rnd)
+	b := zeros(n, nrhs, nrhs+ld-n)
+	if n > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, xTrue, 0, b)
+	}
+
+	af := cloneGeneral(a)
+	ipiv := make([]int, n)
+	impl.Dgetrf(n, n, af.Data, af.Stride, ipiv)
+
+	// Compute a solution and perturb it so that the refinement has work to
+	// do.
+	x := cloneGeneral(b)
+	impl.Dgetrs(trans, n, nrhs, af.Data, af.Stride, ipiv, x.Data, x.Stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			x.Data[i*x.Stride+j] *= 1 + 1e-6*rnd.NormFloat64()
+		}
+	}
+
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	impl.Dgerfs(trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride,
+		ferr, berr, nanSlice(3*n), make([]int, n))
+
+	prefix := fmt.Sprintf("trans=%v,n=%v,nrhs=%v,ld=%v", trans, n, nrhs, ld)
+	for j := 0; j < nrhs; j++ {
+		if berr[j] > 10*float64(n+1)*eps || berr[j] < 0 {
+			t.Errorf("%v: unexpected backward error for column %v: %v", prefix, j, berr[j])
+		}
+		if ferr[j] > 1e-8 || ferr[j] < 0 {
+			t.Errorf("%v: unexpected forward error bound for column %v: %v", prefix, j, ferr[j])
+		}
+		// Check that the forward error bound holds.
+		var diff, xnrm float64
+		for i := 0; i < n; i++ {
+			diff = math.Max(diff, math.Abs(x.Data[i*x.Stride+j]-xTrue.Data[i*xTrue.Stride+j]))
+			xnrm = math.Max(xnrm, math.Abs(x.Data[i*x.Stride+j]))
+		}
+		if xnrm != 0 && diff/xnrm > ferr[j] {
+			t.Errorf("%v: forward error %v larger than bound %v for column %v", prefix, diff/xnrm, ferr[j], j)
+		}
+	}
+}
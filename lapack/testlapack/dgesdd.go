@@ -0,0 +1,169 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesdder interface {
+	Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+
+	Dgesvder
+}
+
+func DgesddTest(t *testing.T, impl Dgesdder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 1},
+		{0, 5, 5},
+		{5, 0, 1},
+		{1, 1, 1},
+		{5, 5, 5},
+		{5, 9, 9},
+		{9, 5, 5},
+		{5, 5, 10},
+		{5, 9, 12},
+		{9, 5, 12},
+
+		{40, 60, 60},
+		{60, 40, 40},
+		{100, 100, 100},
+		{120, 150, 160},
+		{150, 120, 130},
+	} {
+		m := test.m
+		n := test.n
+		lda := test.lda
+		minmn := min(m, n)
+		a := randomGeneral(m, n, lda, rnd)
+
+		// Compute the singular values with Dgesvd for comparison.
+		aCopy := cloneGeneral(a)
+		want := make([]float64, minmn)
+		if minmn > 0 {
+			work := make([]float64, 1)
+			impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aCopy.Data, lda, want, nil, 1, nil, 1, work, -1)
+			work = make([]float64, int(work[0]))
+			impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aCopy.Data, lda, want, nil, 1, nil, 1, work, len(work))
+		}
+
+		for _, jobz := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace, lapack.SVDOverwrite, lapack.SVDNone} {
+			for _, wl := range []worklen{minimumWork, optimumWork} {
+				testDgesdd(t, impl, jobz, wl, a, want)
+			}
+		}
+	}
+}
+
+func testDgesdd(t *testing.T, impl Dgesdder, jobz lapack.SVDJob, wl worklen, aOrig blas64.General, want []float64) {
+	m := aOrig.Rows
+	n := aOrig.Cols
+	lda := aOrig.Stride
+	minmn := min(m, n)
+	mx := max(m, n)
+
+	a := cloneGeneral(aOrig)
+	// Sizes of the output matrices.
+	var ur, uc, vr, vc int
+	switch jobz {
+	case lapack.SVDAll:
+		ur, uc, vr, vc = m, m, n, n
+	case lapack.SVDInPlace:
+		ur, uc, vr, vc = m, minmn, minmn, n
+	case lapack.SVDOverwrite:
+		if m >= n {
+			vr, vc = n, n
+		} else {
+			ur, uc = m, m
+		}
+	}
+	ldu := max(1, uc+3)
+	ldvt := max(1, vc+2)
+	u := nanSlice(max(1, ur*ldu))
+	vt := nanSlice(max(1, vr*ldvt))
+	s := nanSlice(minmn)
+	iwork := make([]int, 8*minmn)
+
+	work := make([]float64, 1)
+	impl.Dgesdd(jobz, m, n, a.Data, lda, s, u, ldu, vt, ldvt, work, -1, iwork)
+	var lwork int
+	switch wl {
+	case minimumWork:
+		if jobz == lapack.SVDNone {
+			lwork = 3*minmn + max(mx, 4*minmn)
+		} else {
+			lwork = 3*minmn + 2*minmn*minmn + max(mx, 4*minmn*minmn+9*minmn)
+			if jobz == lapack.SVDOverwrite {
+				lwork += m * n
+			}
+		}
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(max(1, lwork))
+
+	ok := impl.Dgesdd(jobz, m, n, a.Data, lda, s, u, ldu, vt, ldvt, work, lwork, iwork)
+
+	prefix := fmt.Sprintf("jobz=%v,m=%v,n=%v,lda=%v,work=%v", string(jobz), m, n, lda, wl)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if minmn == 0 {
+		return
+	}
+	if !floats.EqualApprox(s, want, 1e-12) {
+		t.Errorf("%v: singular values differ from Dgesvd", prefix)
+	}
+	if jobz == lapack.SVDNone {
+		return
+	}
+
+	// Collect the computed singular vectors.
+	uMat := blas64.General{Rows: m, Cols: minmn, Stride: ldu, Data: u}
+	vtMat := blas64.General{Rows: minmn, Cols: n, Stride: ldvt, Data: vt}
+	if jobz == lapack.SVDOverwrite {
+		if m >= n {
+			uMat = blas64.General{Rows: m, Cols: n, Stride: lda, Data: a.Data}
+		} else {
+			vtMat = blas64.General{Rows: m, Cols: n, Stride: lda, Data: a.Data}
+		}
+	}
+	if jobz == lapack.SVDAll {
+		uMat.Cols = m
+		vtMat.Rows = n
+		if !isOrthonormal(uMat) {
+			t.Errorf("%v: U is not orthogonal", prefix)
+		}
+		if !isOrthonormal(vtMat) {
+			t.Errorf("%v: V^T is not orthogonal", prefix)
+		}
+		uMat.Cols = minmn
+		vtMat.Rows = minmn
+	}
+
+	// Check that U * Sigma * V^T = A.
+	us := zeros(m, minmn, minmn)
+	for i := 0; i < m; i++ {
+		for j := 0; j < minmn; j++ {
+			us.Data[i*us.Stride+j] = uMat.Data[i*uMat.Stride+j] * s[j]
+		}
+	}
+	usvt := zeros(m, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vtMat, 0, usvt)
+	if !equalApproxGeneral(usvt, aOrig, 1e-12*float64(mx)*s[0]) {
+		t.Errorf("%v: U*Sigma*V^T does not equal A", prefix)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgesver interface {
+	Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool)
+}
+
+func DgesvTest(t *testing.T, impl Dgesver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 5, 10, 50, 100} {
+		for _, nrhs := range []int{1, 3} {
+			for _, lda := range []int{max(1, n), n + 3} {
+				for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+					a := randomGeneral(n, n, lda, rnd)
+					b := randomGeneral(n, nrhs, ldb, rnd)
+					aCopy := cloneGeneral(a)
+					x := cloneGeneral(b)
+					ipiv := make([]int, n)
+
+					ok := impl.Dgesv(n, nrhs, aCopy.Data, lda, ipiv, x.Data, ldb)
+					prefix := fmt.Sprintf("n=%v,nrhs=%v,lda=%v,ldb=%v", n, nrhs, lda, ldb)
+					if !ok {
+						t.Errorf("%v: unexpected failure", prefix)
+						continue
+					}
+					if !isSolution(blas.NoTrans, a, x, b, 1e-10) {
+						t.Errorf("%v: A*X != B", prefix)
+					}
+				}
+			}
+		}
+	}
+
+	// Check that a singular matrix is detected.
+	a := []float64{
+		1, 2,
+		2, 4,
+	}
+	b := []float64{1, 1}
+	if impl.Dgesv(2, 1, a, 2, make([]int, 2), b, 1) {
+		t.Errorf("singular matrix not detected")
+	}
+}
+
+// isSolution returns whether x is a solution of op(A) * X = B to within a
+// relative tolerance of tol.
+func isSolution(trans blas.Transpose, a, x, b blas64.General, tol float64) bool {
+	n := a.Rows
+	nrhs := b.Cols
+	if n == 0 || nrhs == 0 {
+		return true
+	}
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(trans, blas.NoTrans, 1, a, x, 0, ax)
+	var anorm, xnorm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			anorm = math.Max(anorm, math.Abs(a.Data[i*a.Stride+j]))
+		}
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(x.Data[i*x.Stride+j]))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			diff := math.Abs(ax.Data[i*nrhs+j] - b.Data[i*b.Stride+j])
+			if diff > tol*float64(n)*math.Max(1, anorm*xnorm) || math.IsNaN(diff) {
+				return false
+			}
+		}
+	}
+	return true
+}
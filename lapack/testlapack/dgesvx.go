@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesvxer interface {
+	Dgesvx(fact lapack.FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool)
+}
+
+func DgesvxTest(t *testing.T, impl Dgesvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, nrhs := range []int{1, 3} {
+				for _, ld := range []int{max(1, n), n + 3} {
+					for _, scaled := range []bool{false, true} {
+						for _, fact := range []lapack.FactorizationType{lapack.FactorNew, lapack.FactorEquilibrate} {
+							testDgesvx(t, impl, rnd, fact, trans, n, nrhs, ld, scaled)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgesvx(t *testing.T, impl Dgesvxer, rnd *rand.Rand, fact lapack.FactorizationType, trans blas.Transpose, n, nrhs, ld int, scaled bool) {
+	prefix := fmt.Sprintf("fact=%c,trans=%v,n=%v,nrhs=%v,ld=%v,scaled=%v", fact, trans, n, nrhs, ld, scaled)
+
+	a := randomGeneral(n, n, ld, rnd)
+	if scaled {
+		// Scale the rows and columns of A over many orders of
+		// magnitude.
+		for i := 0; i < n; i++ {
+			ri := math.Pow(10, float64(6*(i%2)))
+			cj := math.Pow(10, float64(rnd.Intn(13)-6))
+			for j := 0; j < n; j++ {
+				a.Data[i*ld+j] *= ri
+				a.Data[j*ld+i] *= cj
+			}
+		}
+	}
+	ldx := nrhs + ld - max(1, n)
+	b := randomGeneral(n, nrhs, ldx, rnd)
+	aOrig := cloneGeneral(a)
+	bOrig := cloneGeneral(b)
+
+	af := nanGeneral(n, n, ld)
+	ipiv := make([]int, n)
+	r := nanSlice(n)
+	c := nanSlice(n)
+	x := nanGeneral(n, nrhs, ldx)
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(4 * n)
+	iwork := make([]int, n)
+
+	equed, rcond, rpvgrw, ok := impl.Dgesvx(fact, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv,
+		lapack.NoEquilibration, r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if fact == lapack.FactorNew && equed != lapack.NoEquilibration {
+		t.Errorf("%v: unexpected equilibration %v", prefix, string(equed))
+	}
+	if fact == lapack.FactorEquilibrate && scaled && n > 1 && equed == lapack.NoEquilibration {
+		t.Errorf("%v: badly scaled matrix not equilibrated", prefix)
+	}
+	if rcond <= 0 || rcond > 1+1e-14 {
+		t.Errorf("%v: unexpected rcond: %v", prefix, rcond)
+	}
+	if rpvgrw <= 0 {
+		t.Errorf("%v: unexpected rpvgrw: %v", prefix, rpvgrw)
+	}
+	if !isSolution(trans, aOrig, x, bOrig, 1e-12) {
+		t.Errorf("%v: op(A)*X != B", prefix)
+	}
+	checkDgesvxErrors(t, prefix, n, ferr, berr)
+
+	// Solve a new system reusing the factorization and equilibration.
+	b2 := randomGeneral(n, nrhs, ldx, rnd)
+	b2Orig := cloneGeneral(b2)
+	x2 := nanGeneral(n, nrhs, ldx)
+	_, _, _, ok = impl.Dgesvx(lapack.Factored, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv,
+		equed, r, c, b2.Data, b2.Stride, x2.Data, x2.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure with supplied factorization", prefix)
+		return
+	}
+	if !isSolution(trans, aOrig, x2, b2Orig, 1e-12) {
+		t.Errorf("%v: op(A)*X != B with supplied factorization", prefix)
+	}
+	checkDgesvxErrors(t, prefix, n, ferr, berr)
+}
+
+// checkDgesvxErrors checks that the backward errors in berr are small and that
+// the forward error bounds in ferr are valid.
+func checkDgesvxErrors(t *testing.T, prefix string, n int, ferr, berr []float64) {
+	const eps = 1.0 / (1 << 53)
+	for j := range ferr {
+		if berr[j] > 10*float64(n+1)*eps || berr[j] < 0 {
+			t.Errorf("%v: unexpected backward error for column %v: %v", prefix, j, berr[j])
+		}
+		if ferr[j] < 0 || math.IsNaN(ferr[j]) {
+			t.Errorf("%v: unexpected forward error bound for column %v: %v", prefix, j, ferr[j])
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dggever interface {
+	Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int,
+		alphar, alphai, beta []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+}
+
+func DggevTest(t *testing.T, impl Dggever) {
+	rnd := rand.New(rand.NewSource(1))
+	// The eigenvalues of random triangular pairs become badly conditioned
+	// as n grows, so keep n moderate for the comparison with the known
+	// eigenvalues.
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18} {
+		for _, extra := range []int{0, 11} {
+			for _, singular := range []bool{false, true} {
+				for cas := 0; cas < 3; cas++ {
+					// Generate (A,B) with known eigenvalues as an
+					// orthogonal equivalence of a random pair in
+					// generalized Schur form.
+					s, p, ev := randomGeneralizedSchur(n, n, singular, rnd)
+					q := randomOrthogonal(n, rnd)
+					z := randomOrthogonal(n, rnd)
+					a := nanGeneral(n, n, n+extra)
+					b := nanGeneral(n, n, n+extra)
+					if n > 0 {
+						tmp := zeros(n, n, n)
+						blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, s, 0, tmp)
+						blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, a)
+						blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, p, 0, tmp)
+						blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, b)
+					}
+					for _, jobvl := range []lapack.LeftEVJob{lapack.None, lapack.ComputeLeftEV} {
+						for _, jobvr := range []lapack.RightEVJob{lapack.None, lapack.ComputeRightEV} {
+							for _, optwork := range []bool{true, false} {
+								testDggev(t, impl, jobvl, jobvr, a, b, ev, singular, optwork)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggev(t *testing.T, impl Dggever, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, aOrig, bOrig blas64.General, evWant []generalizedEigenvalue, singular, optwork bool) {
+	const (
+		evTol  = 1e-6
+		vecTol = 1e-12
+	)
+
+	n := aOrig.Rows
+	extra := aOrig.Stride - aOrig.Cols
+	wantvl := jobvl == lapack.ComputeLeftEV
+	wantvr := jobvr == lapack.ComputeRightEV
+
+	a := cloneGeneral(aOrig)
+	b := cloneGeneral(bOrig)
+
+	vl := blas64.General{Stride: 1}
+	if wantvl {
+		vl = nanGeneral(n, n, n+extra)
+	}
+	vr := blas64.General{Stride: 1}
+	if wantvr {
+		vr = nanGeneral(n, n, n+extra)
+	}
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	var lwork int
+	if optwork {
+		work := nanSlice(1)
+		impl.Dggev(jobvl, jobvr, n, nil, a.Stride, nil, b.Stride, nil, nil, nil, nil, vl.Stride, nil, vr.Stride, work, -1)
+		lwork = int(work[0])
+	} else {
+		lwork = max(1, 8*n)
+	}
+	work := nanSlice(lwork)
+
+	ok := impl.Dggev(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
+
+	prefix := fmt.Sprintf("Case jobvl=%v,jobvr=%v,n=%v,extra=%v,singular=%v,optwk=%v",
+		string(jobvl), string(jobvr), n, extra, singular, optwork)
+
+	if !ok {
+		t.Errorf("%v: Dggev failed", prefix)
+		return
+	}
+	if wantvl && !generalOutsideAllNaN(vl) {
+		t.Errorf("%v: out-of-range write to VL", prefix)
+	}
+	if wantvr && !generalOutsideAllNaN(vr) {
+		t.Errorf("%v: out-of-range write to VR", prefix)
+	}
+	if floats.HasNaN(alphar) || floats.HasNaN(alphai) || floats.HasNaN(beta) {
+		t.Errorf("%v: alphar, alphai or beta has NaN elements", prefix)
+		return
+	}
+
+	// Check that beta is non-negative and that complex eigenvalues are
+	// stored in consecutive elements as complex conjugate pairs.
+	for i := 0; i < n; i++ {
+		if beta[i] < 0 {
+			t.Errorf("%v: beta[%v] is negative", prefix, i)
+		}
+		if alphai[i] == 0 {
+			continue
+		}
+		if alphai[i] < 0 || i == n-1 || alphai[i+1] >= 0 {
+			t.Errorf("%v: complex eigenvalues at %v are not a conjugate pair", prefix, i)
+		}
+		i++
+	}
+
+	// Check that the computed eigenvalues match the known ones using the
+	// chordal distance.
+	used := make([]bool, n)
+	for i := 0; i < n; i++ {
+		alpha := complex(alphar[i], alphai[i])
+		found := false
+		for k, ev := range evWant {
+			if used[k] {
+				continue
+			}
+			num := cmplx.Abs(alpha*complex(ev.beta, 0) - ev.alpha*complex(beta[i], 0))
+			den := math.Hypot(cmplx.Abs(alpha), beta[i]) * math.Hypot(cmplx.Abs(ev.alpha), ev.beta)
+			if num <= evTol*den {
+				used[k] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%v: unexpected eigenvalue (%v)/%v", prefix, alpha, beta[i])
+		}
+	}
+
+	// Check the eigenvectors.
+	for _, v := range []struct {
+		want bool
+		left bool
+		name string
+		mat  blas64.General
+	}{
+		{wantvl, true, "left", vl},
+		{wantvr, false, "right", vr},
+	} {
+		if !v.want {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			ev := generalizedEigenvalue{complex(alphar[j], alphai[j]), beta[j]}
+			if alphai[j] == 0 {
+				x := columnOf(v.mat, j)
+				checkGeneralizedEigenvector(t, prefix, v.name, v.left, aOrig, bOrig, x, nil, ev, vecTol)
+				continue
+			}
+			xRe := columnOf(v.mat, j)
+			xIm := columnOf(v.mat, j+1)
+			checkGeneralizedEigenvector(t, prefix, v.name, v.left, aOrig, bOrig, xRe, xIm, ev, vecTol)
+			floats.Scale(-1, xIm)
+			ev.alpha = cmplx.Conj(ev.alpha)
+			checkGeneralizedEigenvector(t, prefix, v.name, v.left, aOrig, bOrig, xRe, xIm, ev, vecTol)
+			j++
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgghrder interface {
+	Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int)
+}
+
+func DgghrdTest(t *testing.T, impl Dgghrder) {
+	rnd := rand.New(rand.NewSource(1))
+	comps := []lapack.EVComp{lapack.None, lapack.HessEV, lapack.OriginalEV}
+	for _, compq := range comps {
+		for _, compz := range comps {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 5; cas++ {
+						ilo := rnd.Intn(max(1, n))
+						ihi := ilo + rnd.Intn(max(1, n-ilo))
+						if n == 0 {
+							ihi = -1
+						}
+						testDgghrd(t, impl, rnd, compq, compz, n, ilo, ihi, extra)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgghrd(t *testing.T, impl Dgghrder, rnd *rand.Rand, compq, compz lapack.EVComp, n, ilo, ihi, extra int) {
+	const tol = 1e-13
+
+	// Generate A that is upper triangular in rows and columns [0:ilo] and
+	// [ihi+1:n], and an upper triangular B.
+	a := randomGeneral(n, n, n+extra, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if j < ilo || ihi < i {
+				a.Data[i*a.Stride+j] = 0
+			}
+		}
+	}
+	b := randomGeneral(n, n, n+extra, rnd)
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b.Data[i*b.Stride+j] = 0
+		}
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	var q, q1 blas64.General
+	switch compq {
+	case lapack.None:
+		q = blas64.General{Stride: 1}
+	case lapack.HessEV:
+		q = nanGeneral(n, n, n+extra)
+		q1 = eye(n, n)
+	case lapack.OriginalEV:
+		q = randomOrthogonal(n, rnd)
+		q1 = cloneGeneral(q)
+	}
+	var z, z1 blas64.General
+	switch compz {
+	case lapack.None:
+		z = blas64.General{Stride: 1}
+	case lapack.HessEV:
+		z = nanGeneral(n, n, n+extra)
+		z1 = eye(n, n)
+	case lapack.OriginalEV:
+		z = randomOrthogonal(n, rnd)
+		z1 = cloneGeneral(z)
+	}
+
+	impl.Dgghrd(compq, compz, n, ilo, ihi, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride)
+
+	prefix := fmt.Sprintf("Case compq=%v,compz=%v,n=%v,ilo=%v,ihi=%v,extra=%v",
+		string(compq), string(compz), n, ilo, ihi, extra)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+	if !isUpperHessenberg(a) {
+		t.Errorf("%v: A is not upper Hessenberg", prefix)
+	}
+	if !isUpperTriangular(b) {
+		t.Errorf("%v: B is not upper triangular", prefix)
+	}
+	if compq != lapack.None && !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if compz != lapack.None && !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+	if compq == lapack.None || compz == lapack.None || n == 0 {
+		return
+	}
+
+	// Check that
+	//  Q1 * A * Z1^T = Q * H * Z^T,
+	//  Q1 * B * Z1^T = Q * T * Z^T.
+	for _, m := range []struct {
+		name     string
+		orig, hr blas64.General
+	}{
+		{"A", aCopy, a},
+		{"B", bCopy, b},
+	} {
+		want := zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q1, m.orig, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z1, 0, want)
+		got := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, m.hr, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, got)
+		if !equalApproxGeneral(got, want, tol*float64(n)) {
+			t.Errorf("%v: Q*%v*Z^T does not match the original product", prefix, m.name)
+		}
+	}
+}
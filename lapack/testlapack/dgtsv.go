@@ -0,0 +1,136 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgtsver interface {
+	Dgtsv(trans blas.Transpose, n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+}
+
+func DgtsvTest(t *testing.T, impl Dgtsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+			for _, nrhs := range []int{1, 2, 5} {
+				for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+					for _, dominant := range []bool{false, true} {
+						testDgtsv(t, impl, rnd, trans, n, nrhs, ldb, dominant)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgtsv(t *testing.T, impl Dgtsver, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ldb int, dominant bool) {
+	name := fmt.Sprintf("trans=%c,n=%d,nrhs=%d,ldb=%d,dominant=%t", trans, n, nrhs, ldb, dominant)
+
+	dl, d, du := randomTridiag(n, rnd)
+	if !dominant {
+		// Zero the diagonal to force row interchanges.
+		for i := range d {
+			d[i] = 0
+		}
+		if n%2 == 1 && n > 0 {
+			// Keep A non-singular.
+			d[n-1] = 1
+		}
+	} else {
+		for i := range d {
+			d[i] += math.Copysign(4, d[i])
+		}
+	}
+	a := tridiagToGeneral(n, dl, d, du)
+
+	want := randomGeneral(n, nrhs, ldb, rnd)
+	b := nanGeneral(n, nrhs, ldb)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, want, 0, b)
+	}
+
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dgtsv(trans, n, nrhs, dl, d, du, b.Data, b.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to b", name)
+	}
+	if dominant {
+		// A is well conditioned so the solution can be
+		// checked directly.
+		if !equalApproxGeneral(b, want, 1e-10) {
+			t.Errorf("%v: unexpected solution", name)
+		}
+		return
+	}
+
+	// Check the residual |op(A)*X - B| relative to |A|*|X|.
+	var anorm, xnorm float64
+	for _, v := range a.Data {
+		anorm = math.Max(anorm, math.Abs(v))
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	blas64.Gemm(trans, blas.NoTrans, 1, a, b, -1, bCopy)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			r := math.Abs(bCopy.Data[i*bCopy.Stride+j])
+			if r > 1e-13*float64(n)*anorm*xnorm {
+				t.Errorf("%v: residual too large at (%d,%d): %v", name, i, j, r)
+				return
+			}
+		}
+	}
+}
+
+// randomTridiag returns the sub-diagonal, the diagonal and the super-diagonal
+// of a random n×n tridiagonal matrix.
+func randomTridiag(n int, rnd *rand.Rand) (dl, d, du []float64) {
+	if n == 0 {
+		return nil, nil, nil
+	}
+	dl = make([]float64, n-1)
+	d = make([]float64, n)
+	du = make([]float64, n-1)
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	for i := range dl {
+		dl[i] = rnd.NormFloat64()
+		du[i] = rnd.NormFloat64()
+	}
+	return dl, d, du
+}
+
+// tridiagToGeneral returns the n×n tridiagonal matrix with sub-diagonal dl,
+// diagonal d and super-diagonal du as a general matrix.
+func tridiagToGeneral(n int, dl, d, du []float64) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = d[i]
+		if i > 0 {
+			a.Data[i*a.Stride+i-1] = dl[i-1]
+		}
+		if i < n-1 {
+			a.Data[i*a.Stride+i+1] = du[i]
+		}
+	}
+	return a
+}
@@ -0,0 +1,242 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dhgeqzer interface {
+	Dhgeqz(job lapack.EVJob, compq, compz lapack.EVComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int,
+		alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int) (unconverged int)
+}
+
+func DhgeqzTest(t *testing.T, impl Dhgeqzer) {
+	rnd := rand.New(rand.NewSource(1))
+	comps := []lapack.EVComp{lapack.None, lapack.HessEV, lapack.OriginalEV}
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+		for _, extra := range []int{0, 11} {
+			for _, singular := range []bool{false, true} {
+				for cas := 0; cas < 3; cas++ {
+					ilo := 0
+					ihi := n - 1
+					if cas > 0 {
+						ilo = rnd.Intn(max(1, n))
+						ihi = ilo + rnd.Intn(max(1, n-ilo))
+						if n == 0 {
+							ihi = -1
+						}
+					}
+					h, tt := randomHessTriPair(n, ilo, ihi, n+extra, singular, rnd)
+					testDhgeqz(t, impl, rnd, lapack.EigenvaluesOnly, lapack.None, lapack.None, h, tt, ilo, ihi, extra, singular)
+					for _, compq := range comps {
+						for _, compz := range comps {
+							testDhgeqz(t, impl, rnd, lapack.EigenvaluesAndSchur, compq, compz, h, tt, ilo, ihi, extra, singular)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// randomHessTriPair returns a random n×n upper Hessenberg matrix H and a
+// random n×n upper triangular matrix T such that H is upper triangular in rows
+// and columns [0:ilo] and [ihi+1:n]. If singular is true, some of the diagonal
+// elements of T are set to zero.
+func randomHessTriPair(n, ilo, ihi, stride int, singular bool, rnd *rand.Rand) (h, t blas64.General) {
+	h = randomHessenberg(n, stride, rnd)
+	for i := 1; i < n; i++ {
+		if i-1 < ilo || ihi < i {
+			h.Data[i*h.Stride+i-1] = 0
+		}
+	}
+	t = randomGeneral(n, n, stride, rnd)
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			t.Data[i*t.Stride+j] = 0
+		}
+	}
+	if singular {
+		for i := 0; i < n; i++ {
+			if rnd.Float64() < 0.3 {
+				t.Data[i*t.Stride+i] = 0
+			}
+		}
+	}
+	return h, t
+}
+
+func testDhgeqz(t *testing.T, impl Dhgeqzer, rnd *rand.Rand, job lapack.EVJob, compq, compz lapack.EVComp, hOrig, tOrig blas64.General, ilo, ihi, extra int, singular bool) {
+	const tol = 1e-12
+
+	n := hOrig.Rows
+	h := cloneGeneral(hOrig)
+	tt := cloneGeneral(tOrig)
+
+	var q, q1 blas64.General
+	switch compq {
+	case lapack.None:
+		q = blas64.General{Stride: 1}
+	case lapack.HessEV:
+		q = nanGeneral(n, n, n+extra)
+		q1 = eye(n, n)
+	case lapack.OriginalEV:
+		q = randomOrthogonal(n, rnd)
+		q1 = cloneGeneral(q)
+	}
+	var z, z1 blas64.General
+	switch compz {
+	case lapack.None:
+		z = blas64.General{Stride: 1}
+	case lapack.HessEV:
+		z = nanGeneral(n, n, n+extra)
+		z1 = eye(n, n)
+	case lapack.OriginalEV:
+		z = randomOrthogonal(n, rnd)
+		z1 = cloneGeneral(z)
+	}
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	work := nanSlice(1)
+	impl.Dhgeqz(job, compq, compz, n, ilo, ihi, nil, h.Stride, nil, tt.Stride, nil, nil, nil, nil, q.Stride, nil, z.Stride, work, -1)
+	work = nanSlice(int(work[0]))
+
+	unconverged := impl.Dhgeqz(job, compq, compz, n, ilo, ihi, h.Data, h.Stride, tt.Data, tt.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, len(work))
+
+	prefix := fmt.Sprintf("Case job=%v,compq=%v,compz=%v,n=%v,ilo=%v,ihi=%v,extra=%v,singular=%v",
+		string(job), string(compq), string(compz), n, ilo, ihi, extra, singular)
+
+	if unconverged != 0 {
+		t.Errorf("%v: QZ iteration did not converge, unconverged=%v", prefix, unconverged)
+		return
+	}
+
+	if !generalOutsideAllNaN(h) {
+		t.Errorf("%v: out-of-range write to H", prefix)
+	}
+	if !generalOutsideAllNaN(tt) {
+		t.Errorf("%v: out-of-range write to T", prefix)
+	}
+	if floats.HasNaN(alphar) || floats.HasNaN(alphai) || floats.HasNaN(beta) {
+		t.Errorf("%v: alphar, alphai or beta has NaN elements", prefix)
+	}
+
+	// Check that beta is non-negative and that complex eigenvalues are
+	// stored in consecutive elements as complex conjugate pairs.
+	for i := 0; i < n; i++ {
+		if beta[i] < 0 {
+			t.Errorf("%v: beta[%v] is negative", prefix, i)
+		}
+		if alphai[i] == 0 {
+			continue
+		}
+		if alphai[i] < 0 || i == n-1 {
+			t.Errorf("%v: first in conjugate pair at %v has non-positive imaginary part", prefix, i)
+			continue
+		}
+		if alphai[i+1] >= 0 {
+			t.Errorf("%v: second in conjugate pair at %v has non-negative imaginary part", prefix, i+1)
+		}
+		i++
+	}
+
+	if compq != lapack.None && !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if compz != lapack.None && !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+
+	if job == lapack.EigenvaluesOnly {
+		return
+	}
+
+	// Check that (S,P) is in generalized Schur form and that the
+	// eigenvalues correspond to its diagonal blocks.
+	if !isUpperTriangular(tt) {
+		t.Errorf("%v: P is not upper triangular", prefix)
+	}
+	for i := 0; i < n; {
+		if alphai[i] == 0 {
+			// 1×1 block.
+			for k := i + 1; k < n; k++ {
+				if h.Data[k*h.Stride+i] != 0 {
+					t.Errorf("%v: S has non-zero element below 1×1 block at %v", prefix, i)
+					break
+				}
+			}
+			if alphar[i] != h.Data[i*h.Stride+i] || beta[i] != tt.Data[i*tt.Stride+i] {
+				t.Errorf("%v: eigenvalue at %v does not match diagonal of (S,P)", prefix, i)
+			}
+			i++
+			continue
+		}
+		// 2×2 block.
+		for k := i + 2; k < n; k++ {
+			if h.Data[k*h.Stride+i] != 0 || h.Data[k*h.Stride+i+1] != 0 {
+				t.Errorf("%v: S has non-zero element below 2×2 block at %v", prefix, i)
+				break
+			}
+		}
+		p00, p01, p10, p11 := extract2x2Block(tt.Data[i*tt.Stride+i:], tt.Stride)
+		if p01 != 0 || p10 != 0 || p00 <= 0 || p11 <= 0 {
+			t.Errorf("%v: 2×2 block of P at %v is not positive diagonal", prefix, i)
+		}
+		s00, s01, s10, s11 := extract2x2Block(h.Data[i*h.Stride+i:], h.Stride)
+		for k := i; k < i+2; k++ {
+			alpha := complex(alphar[k], alphai[k])
+			b := complex(beta[k], 0)
+			det := (b*complex(s00, 0)-alpha*complex(p00, 0))*(b*complex(s11, 0)-alpha*complex(p11, 0)) -
+				b*b*complex(s01*s10, 0)
+			snorm := math.Max(math.Abs(s00)+math.Abs(s10), math.Abs(s01)+math.Abs(s11))
+			pnorm := math.Max(p00, p11)
+			size := beta[k]*snorm + cmplx.Abs(alpha)*pnorm
+			if cmplx.Abs(det) > tol*size*size {
+				t.Errorf("%v: eigenvalue at %v does not match 2×2 block of (S,P)", prefix, k)
+			}
+		}
+		i += 2
+	}
+
+	if compq == lapack.None || compz == lapack.None || n == 0 {
+		return
+	}
+
+	// Check that
+	//  Q1 * H * Z1^T = Q * S * Z^T,
+	//  Q1 * T * Z1^T = Q * P * Z^T.
+	for _, m := range []struct {
+		name       string
+		orig, schr blas64.General
+	}{
+		{"H", hOrig, h},
+		{"T", tOrig, tt},
+	} {
+		want := zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q1, m.orig, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z1, 0, want)
+		got := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, m.schr, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, got)
+		if !equalApproxGeneral(got, want, tol*float64(n)) {
+			t.Errorf("%v: Q*%v*Z^T does not match the original product", prefix, m.name)
+		}
+	}
+}
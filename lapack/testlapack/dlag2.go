@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Dlag2er interface {
+	Dlag2(a []float64, lda int, b []float64, ldb int, safmin float64) (scale1, scale2, wr1, wr2, wi float64)
+}
+
+func Dlag2Test(t *testing.T, impl Dlag2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, lda := range []int{2, 5} {
+		for _, ldb := range []int{2, 5} {
+			for _, sa := range []float64{1, 1e-150, 1e150} {
+				for _, sb := range []float64{1, 1e-150, 1e150} {
+					for k := 0; k < 10; k++ {
+						testDlag2(t, impl, lda, ldb, sa, sb, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlag2(t *testing.T, impl Dlag2er, lda, ldb int, sa, sb float64, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	a := randomGeneral(2, 2, lda, rnd)
+	b := randomGeneral(2, 2, ldb, rnd)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			a.Data[i*lda+j] *= sa
+			b.Data[i*ldb+j] *= sb
+		}
+	}
+	// B[1,0] must not be referenced.
+	b.Data[ldb] = math.NaN()
+
+	prefix := fmt.Sprintf("Case lda=%v,ldb=%v,sa=%v,sb=%v", lda, ldb, sa, sb)
+
+	scale1, scale2, wr1, wr2, wi := impl.Dlag2(a.Data, lda, b.Data, ldb, 100*dlamchS)
+
+	if scale1 < 0 || scale2 < 0 {
+		t.Errorf("%v: negative scale factor", prefix)
+	}
+	if wi < 0 {
+		t.Errorf("%v: negative wi", prefix)
+	}
+	if wi != 0 && (wr1 != wr2 || scale1 != scale2) {
+		t.Errorf("%v: complex eigenvalues not a conjugate pair", prefix)
+	}
+
+	a00, a01, a10, a11 := extract2x2Block(a.Data, lda)
+	b00, b01, b11 := b.Data[0], b.Data[1], b.Data[ldb+1]
+	anorm := math.Max(math.Abs(a00)+math.Abs(a10), math.Abs(a01)+math.Abs(a11))
+	bnorm := math.Max(math.Abs(b00), math.Abs(b01)+math.Abs(b11))
+	for _, ev := range []struct {
+		s float64
+		w complex128
+	}{
+		{scale1, complex(wr1, wi)},
+		{scale2, complex(wr2, -wi)},
+	} {
+		// Check that the determinant of s*A - w*B is zero, relative to the
+		// size of its terms.
+		s := complex(ev.s, 0)
+		w := ev.w
+		m00 := s*complex(a00, 0) - w*complex(b00, 0)
+		m01 := s*complex(a01, 0) - w*complex(b01, 0)
+		m10 := s * complex(a10, 0)
+		m11 := s*complex(a11, 0) - w*complex(b11, 0)
+		det := m00*m11 - m01*m10
+		size := ev.s*anorm + cmplx.Abs(w)*bnorm
+		if size == 0 {
+			continue
+		}
+		if cmplx.Abs(det)/(size*size) > tol {
+			t.Errorf("%v: s*A - w*B not singular, s=%v, w=%v, |det|/size^2=%v",
+				prefix, ev.s, w, cmplx.Abs(det)/(size*size))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dpbconer interface {
+	Dpbtrfer
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+	Dpbcon(ul blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DpbconTest(t *testing.T, impl Dpbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 5, 10, 25} {
+			for _, kd := range []int{0, 1, 3, n - 1} {
+				if kd < 0 {
+					continue
+				}
+				name := fmt.Sprintf("uplo=%c,n=%d,kd=%d", ul, n, kd)
+				ldab := kd + 1
+				sym, band := randSymBand(ul, n, ldab, kd, rnd)
+				work := make([]float64, 4*n)
+				iwork := make([]int, n)
+				anorm := impl.Dlansy(lapack.MaxColumnSum, ul, n, sym.Data, sym.Stride, work)
+
+				if !impl.Dpbtrf(ul, n, kd, band.Data, band.Stride) {
+					t.Errorf("%v: unexpected failure of Cholesky factorization", name)
+					continue
+				}
+				got := impl.Dpbcon(ul, n, kd, band.Data, band.Stride, anorm, work, iwork)
+
+				if !impl.Dpotrf(ul, n, sym.Data, sym.Stride) {
+					t.Errorf("%v: unexpected failure of dense Cholesky factorization", name)
+					continue
+				}
+				want := impl.Dpocon(ul, n, sym.Data, sym.Stride, anorm, work, iwork)
+				if !floats.EqualWithinAbsOrRel(want, got, 1e-14, 1e-8) {
+					t.Errorf("%v: Dpbcon and Dpocon mismatch: Dpbcon %v, Dpocon %v", name, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Dpbtrfer interface {
+	Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpbtf2(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpotrfer
+}
+
+func DpbtrfTest(t *testing.T, impl Dpbtrfer) {
+	// Test random symmetric banded matrices against the full version.
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 5, 10, 20} {
+		for _, kb := range []int{0, 1, 3, n - 1} {
+			if kb < 0 {
+				continue
+			}
+			for _, ldoff := range []int{0, 4} {
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					ldab := kb + 1 + ldoff
+					sym, band := randSymBand(ul, n, ldab, kb, rnd)
+
+					ok := impl.Dpotrf(ul, sym.N, sym.Data, sym.Stride)
+					if !ok {
+						panic("bad test: symmetric cholesky decomp failed")
+					}
+					ok = impl.Dpbtrf(band.Uplo, band.N, band.K, band.Data, band.Stride)
+					if !ok {
+						t.Errorf("SymBand cholesky decomp failed")
+					}
+
+					sb := symBandToSym(ul, band.Data, n, kb, ldab)
+					if !equalApproxSymmetric(sym, sb, 1e-10) {
+						t.Errorf("chol mismatch banded and sym. n = %v, kb = %v, ldoff = %v", n, kb, ldoff)
+					}
+				}
+			}
+		}
+	}
+
+	// Test that the blocked algorithm, used for band widths greater
+	// than 64, agrees with the unblocked algorithm. The matrices are
+	// diagonally dominant so that they are well conditioned.
+	for _, n := range []int{65, 100, 150} {
+		for _, kd := range []int{64, 65, 80, n - 1} {
+			if n <= kd {
+				continue
+			}
+			for _, ldoff := range []int{0, 3} {
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					ldab := kd + 1 + ldoff
+					ab := make([]float64, n*ldab)
+					for i := range ab {
+						ab[i] = math.NaN()
+					}
+					diag := make([]float64, n)
+					for i := 0; i < n; i++ {
+						for j := i + 1; j <= min(i+kd, n-1); j++ {
+							v := 2*rnd.Float64() - 1
+							if ul == blas.Upper {
+								ab[i*ldab+j-i] = v
+							} else {
+								ab[j*ldab+kd+i-j] = v
+							}
+							diag[i] += math.Abs(v)
+							diag[j] += math.Abs(v)
+						}
+					}
+					for i, d := range diag {
+						if ul == blas.Upper {
+							ab[i*ldab] = d + 1
+						} else {
+							ab[i*ldab+kd] = d + 1
+						}
+					}
+					want := make([]float64, len(ab))
+					copy(want, ab)
+
+					name := fmt.Sprintf("uplo=%c,n=%d,kd=%d,ldoff=%d", ul, n, kd, ldoff)
+					ok := impl.Dpbtrf(ul, n, kd, ab, ldab)
+					wantOk := impl.Dpbtf2(ul, n, kd, want, ldab)
+					if !ok || !wantOk {
+						t.Errorf("%v: unexpected failure of Cholesky factorization: got %v, want %v", name, ok, wantOk)
+						continue
+					}
+					for i, v := range ab {
+						if math.IsNaN(v) != math.IsNaN(want[i]) || (!math.IsNaN(v) && math.Abs(v-want[i]) > 1e-12) {
+							t.Errorf("%v: factorization mismatch with Dpbtf2", name)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpbtrser interface {
+	Dpbtrfer
+	Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+}
+
+func DpbtrsTest(t *testing.T, impl Dpbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 5, 10, 25} {
+			for _, kd := range []int{0, 1, 3, n - 1} {
+				if kd < 0 {
+					continue
+				}
+				for _, nrhs := range []int{1, 3} {
+					for _, ldb := range []int{nrhs, nrhs + 2} {
+						name := fmt.Sprintf("uplo=%c,n=%d,kd=%d,nrhs=%d,ldb=%d", ul, n, kd, nrhs, ldb)
+						ldab := kd + 1
+						sym, band := randSymBand(ul, n, ldab, kd, rnd)
+						full := symToGeneral(sym)
+
+						if !impl.Dpbtrf(ul, n, kd, band.Data, band.Stride) {
+							t.Errorf("%v: unexpected failure of Cholesky factorization", name)
+							continue
+						}
+
+						want := randomGeneral(n, nrhs, ldb, rnd)
+						b := nanGeneral(n, nrhs, ldb)
+						blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, want, 0, b)
+						impl.Dpbtrs(ul, n, kd, nrhs, band.Data, band.Stride, b.Data, b.Stride)
+						if !generalOutsideAllNaN(b) {
+							t.Errorf("%v: out-of-range write to b", name)
+						}
+						if !equalApproxGeneral(b, want, 1e-8) {
+							t.Errorf("%v: unexpected solution", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// symToGeneral returns the symmetric matrix a as a general matrix with both
+// triangles set.
+func symToGeneral(a blas64.Symmetric) blas64.General {
+	n := a.N
+	g := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var v float64
+			if a.Uplo == blas.Upper {
+				v = a.Data[i*a.Stride+j]
+			} else {
+				v = a.Data[j*a.Stride+i]
+			}
+			g.Data[i*g.Stride+j] = v
+			g.Data[j*g.Stride+i] = v
+		}
+	}
+	return g
+}
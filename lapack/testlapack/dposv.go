@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dposver interface {
+	Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
+}
+
+func DposvTest(t *testing.T, impl Dposver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50, 100} {
+			for _, nrhs := range []int{1, 3} {
+				for _, lda := range []int{max(1, n), n + 3} {
+					for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+						// Construct a symmetric positive definite
+						// matrix A = G^T * G + n*I.
+						g := randomGeneral(n, n, n, rnd)
+						a := zeros(n, n, lda)
+						for i := 0; i < n; i++ {
+							a.Data[i*lda+i] = float64(n)
+						}
+						if n > 0 {
+							blas64.Gemm(blas.Trans, blas.NoTrans, 1, g, g, 1, a)
+						}
+						b := randomGeneral(n, nrhs, ldb, rnd)
+						aCopy := cloneGeneral(a)
+						x := cloneGeneral(b)
+
+						ok := impl.Dposv(uplo, n, nrhs, aCopy.Data, lda, x.Data, ldb)
+						prefix := fmt.Sprintf("uplo=%c,n=%v,nrhs=%v,lda=%v,ldb=%v", uplo, n, nrhs, lda, ldb)
+						if !ok {
+							t.Errorf("%v: unexpected failure", prefix)
+							continue
+						}
+						if !isSolution(blas.NoTrans, a, x, b, 1e-12) {
+							t.Errorf("%v: A*X != B", prefix)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Check that an indefinite matrix is detected.
+	a := []float64{
+		1, 2,
+		2, 1,
+	}
+	b := []float64{1, 1}
+	if impl.Dposv(blas.Upper, 2, 1, a, 2, b, 1) {
+		t.Errorf("indefinite matrix not detected")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpstf2er interface {
+	Dpstf2(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func Dpstf2Test(t *testing.T, impl Dpstf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, lda := range []int{max(1, n), n + 11} {
+				for _, rank := range []int{n, n - 1, n / 2, 1, 0} {
+					if rank < 0 || n < rank {
+						continue
+					}
+					dpstrfTest(t, impl.Dpstf2, rnd, uplo, n, lda, rank)
+				}
+			}
+		}
+	}
+}
+
+// dpstrfTest tests a pivoted Cholesky factorization routine on a random n×n
+// positive semidefinite matrix of the given rank.
+func dpstrfTest(t *testing.T, dpstrf func(blas.Uplo, int, []float64, int, []int, float64, []float64) (int, bool), rnd *rand.Rand, uplo blas.Uplo, n, lda, rank int) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("uplo=%c,n=%d,lda=%d,rank=%d", uplo, n, lda, rank)
+
+	// Generate a random positive semidefinite matrix A = B * B^T
+	// where B is n×rank.
+	b := randomGeneral(n, max(1, rank), max(1, rank), rnd)
+	b.Cols = rank
+	a := nanGeneral(n, n, lda)
+	if n > 0 {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.NoTrans, blas.Trans, n, n, rank, 1, b.Data, b.Stride, b.Data, b.Stride, 0, a.Data, a.Stride)
+	}
+	aCopy := cloneGeneral(a)
+	// Invalidate the part of A that must not be referenced.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				a.Data[i*lda+j] = math.NaN()
+			}
+		}
+	}
+
+	var anorm float64
+	for i := 0; i < n; i++ {
+		anorm = math.Max(anorm, math.Abs(aCopy.Data[i*lda+i]))
+	}
+	// Use the default tolerance for matrices of full rank. Rank-deficient
+	// matrices are only numerically rank-deficient, so use a tolerance
+	// that is safely larger than the rounding errors.
+	stop := -1.0
+	if rank < n {
+		stop = 1e-10 * anorm
+	}
+
+	piv := make([]int, n)
+	work := nanSlice(2 * n)
+	gotRank, ok := dpstrf(uplo, n, a.Data, lda, piv, stop, work)
+	if ok != (rank == n) {
+		t.Errorf("%s: unexpected ok: got %v, want %v", name, ok, rank == n)
+	}
+	if gotRank != rank {
+		t.Errorf("%s: unexpected rank: got %d, want %d", name, gotRank, rank)
+		return
+	}
+
+	// Check that piv is a permutation.
+	seen := make([]bool, n)
+	for _, p := range piv {
+		if p < 0 || n <= p || seen[p] {
+			t.Errorf("%s: piv is not a permutation: %v", name, piv)
+			return
+		}
+		seen[p] = true
+	}
+
+	// Extract the leading rank rows of U or columns of L as the rank×n
+	// matrix F so that P^T * A * P = F^T * F.
+	f := zeros(rank, n, max(1, n))
+	for i := 0; i < rank; i++ {
+		for j := i; j < n; j++ {
+			if uplo == blas.Upper {
+				f.Data[i*f.Stride+j] = a.Data[i*lda+j]
+			} else {
+				f.Data[i*f.Stride+j] = a.Data[j*lda+i]
+			}
+		}
+	}
+	ftf := zeros(n, n, max(1, n))
+	if n > 0 && rank > 0 {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, n, rank, 1, f.Data, f.Stride, f.Data, f.Stride, 0, ftf.Data, ftf.Stride)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			want := aCopy.Data[piv[i]*lda+piv[j]]
+			if math.Abs(ftf.Data[i*ftf.Stride+j]-want) > tol*math.Max(1, anorm) {
+				t.Errorf("%s: P^T*A*P != U^T*U at (%d,%d)", name, i, j)
+				return
+			}
+		}
+	}
+
+	// Check that the diagonal elements of the factor are positive and
+	// non-increasing.
+	for i := 0; i < rank; i++ {
+		d := a.Data[i*lda+i]
+		if d <= 0 {
+			t.Errorf("%s: non-positive diagonal element %v at %d", name, d, i)
+		}
+		if i > 0 && d > a.Data[(i-1)*lda+i-1]*(1+tol) {
+			t.Errorf("%s: diagonal elements are not non-increasing", name)
+		}
+	}
+}
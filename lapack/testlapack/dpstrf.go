@@ -0,0 +1,32 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Dpstrfer interface {
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func DpstrfTest(t *testing.T, impl Dpstrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50, 63, 64, 65, 127, 128, 129, 200} {
+			for _, lda := range []int{max(1, n), n + 11} {
+				for _, rank := range []int{n, n - 1, n / 2, 1, 0} {
+					if rank < 0 || n < rank {
+						continue
+					}
+					dpstrfTest(t, impl.Dpstrf, rnd, uplo, n, lda, rank)
+				}
+			}
+		}
+	}
+}
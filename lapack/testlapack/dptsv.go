@@ -0,0 +1,82 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dptsver interface {
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+}
+
+func DptsvTest(t *testing.T, impl Dptsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+		for _, nrhs := range []int{1, 2, 5} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+				name := fmt.Sprintf("n=%d,nrhs=%d,ldb=%d", n, nrhs, ldb)
+				d, e := randomSPDTridiag(n, rnd)
+				a := tridiagToGeneral(n, e, d, e)
+
+				want := randomGeneral(n, nrhs, ldb, rnd)
+				b := nanGeneral(n, nrhs, ldb)
+				if n > 0 && nrhs > 0 {
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+				}
+
+				ok := impl.Dptsv(n, nrhs, d, e, b.Data, b.Stride)
+				if !ok {
+					t.Errorf("%v: unexpected failure for positive definite matrix", name)
+					continue
+				}
+				if !generalOutsideAllNaN(b) {
+					t.Errorf("%v: out-of-range write to b", name)
+				}
+				if !equalApproxGeneral(b, want, 1e-10) {
+					t.Errorf("%v: unexpected solution", name)
+				}
+			}
+		}
+	}
+
+	// Check that an indefinite matrix is detected.
+	d := []float64{1, -1, 2}
+	e := []float64{0.5, 0.5}
+	b := []float64{1, 1, 1}
+	if impl.Dptsv(3, 1, d, e, b, 1) {
+		t.Errorf("unexpected success for indefinite matrix")
+	}
+}
+
+// randomSPDTridiag returns the diagonal and the sub-diagonal of a random
+// n×n symmetric positive definite tridiagonal matrix.
+func randomSPDTridiag(n int, rnd *rand.Rand) (d, e []float64) {
+	if n == 0 {
+		return nil, nil
+	}
+	d = make([]float64, n)
+	e = make([]float64, n-1)
+	for i := range e {
+		e[i] = rnd.NormFloat64()
+	}
+	// Make A strictly diagonally dominant with a positive diagonal.
+	for i := range d {
+		d[i] = 1 + rnd.Float64()
+		if i > 0 {
+			d[i] += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			d[i] += math.Abs(e[i])
+		}
+	}
+	return d, e
+}
@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpttrfer interface {
+	Dpttrf(n int, d, e []float64) (ok bool)
+}
+
+func DpttrfTest(t *testing.T, impl Dpttrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+		name := fmt.Sprintf("n=%d", n)
+		d, e := randomSPDTridiag(n, rnd)
+		a := tridiagToGeneral(n, e, d, e)
+
+		dFac := make([]float64, n)
+		copy(dFac, d)
+		eFac := make([]float64, len(e))
+		copy(eFac, e)
+		ok := impl.Dpttrf(n, dFac, eFac)
+		if !ok {
+			t.Errorf("%v: unexpected failure for positive definite matrix", name)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		// Reconstruct A = L * D * L^T.
+		l := zeros(n, n, n)
+		ld := zeros(n, n, n)
+		for i := 0; i < n; i++ {
+			l.Data[i*n+i] = 1
+			if i > 0 {
+				l.Data[i*n+i-1] = eFac[i-1]
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ld.Data[i*n+j] = l.Data[i*n+j] * dFac[j]
+			}
+		}
+		got := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, ld, l, 0, got)
+		if !equalApproxGeneral(got, a, 1e-12) {
+			t.Errorf("%v: unexpected reconstruction of A from L*D*L^T", name)
+		}
+	}
+}
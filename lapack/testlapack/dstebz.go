@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstebzer interface {
+	Dstebz(rng lapack.EVRange, n int, vl, vu float64, il, iu int, abstol float64, d, e, w []float64) (m int)
+
+	Dsterfer
+}
+
+func DstebzTest(t *testing.T, impl Dstebzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 21, 50} {
+		for typ := 0; typ < 3; typ++ {
+			d, e := randomSymTridiag(n, typ, rnd)
+
+			// Compute all eigenvalues with Dsterf for comparison.
+			want := make([]float64, n)
+			copy(want, d)
+			eCopy := make([]float64, len(e))
+			copy(eCopy, e)
+			impl.Dsterf(n, want, eCopy)
+			tol := 1e-13 * math.Max(1, math.Max(math.Abs(want[0]), math.Abs(want[n-1])))
+
+			prefix := fmt.Sprintf("n=%v,type=%v", n, typ)
+
+			w := nanSlice(n)
+			m := impl.Dstebz(lapack.AllEVs, n, 0, 0, 0, 0, 0, d, e, w)
+			if m != n {
+				t.Errorf("%v: unexpected number of eigenvalues for AllEVs: got %v, want %v", prefix, m, n)
+			} else if !floats.EqualApprox(w, want, tol) {
+				t.Errorf("%v: eigenvalue mismatch for AllEVs", prefix)
+			}
+
+			for _, r := range [][2]int{{0, 0}, {n - 1, n - 1}, {0, n - 1}, {n / 3, n / 2}} {
+				il, iu := r[0], r[1]
+				w := nanSlice(n)
+				m := impl.Dstebz(lapack.IndexEVs, n, 0, 0, il, iu, 0, d, e, w)
+				if m != iu-il+1 {
+					t.Errorf("%v: unexpected number of eigenvalues for il=%v,iu=%v: got %v, want %v", prefix, il, iu, m, iu-il+1)
+					continue
+				}
+				if !floats.EqualApprox(w[:m], want[il:iu+1], tol) {
+					t.Errorf("%v: eigenvalue mismatch for il=%v,iu=%v", prefix, il, iu)
+				}
+			}
+
+			for cas := 0; cas < 5; cas++ {
+				vl := want[0] + (want[n-1]-want[0])*(1.2*rnd.Float64()-0.1)
+				vu := vl + (want[n-1]-want[0])*rnd.Float64() + 0.1
+				var wantVal []float64
+				for _, v := range want {
+					// Skip the test case if an eigenvalue is too
+					// close to an end point of the interval.
+					if math.Abs(v-vl) < 1e-8 || math.Abs(v-vu) < 1e-8 {
+						wantVal = nil
+						break
+					}
+					if vl < v && v <= vu {
+						wantVal = append(wantVal, v)
+					}
+				}
+				w := nanSlice(n)
+				m := impl.Dstebz(lapack.ValueEVs, n, vl, vu, 0, 0, 0, d, e, w)
+				if !sort.Float64sAreSorted(w[:m]) {
+					t.Errorf("%v: eigenvalues not sorted for vl=%v,vu=%v", prefix, vl, vu)
+				}
+				if m != len(wantVal) {
+					if wantVal != nil {
+						t.Errorf("%v: unexpected number of eigenvalues for vl=%v,vu=%v: got %v, want %v", prefix, vl, vu, m, len(wantVal))
+					}
+					continue
+				}
+				if !floats.EqualApprox(w[:m], wantVal, tol) {
+					t.Errorf("%v: eigenvalue mismatch for vl=%v,vu=%v", prefix, vl, vu)
+				}
+			}
+		}
+	}
+}
+
+// randomSymTridiag returns the diagonal and off-diagonal elements of an n×n
+// symmetric tridiagonal matrix of the given type. Type 0 is a random matrix,
+// type 1 is the Wilkinson matrix with pairs of very close eigenvalues and
+// type 2 is a matrix with a repeated eigenvalue.
+func randomSymTridiag(n, typ int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	e = make([]float64, max(0, n-1))
+	switch typ {
+	case 0:
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case 1:
+		for i := range d {
+			d[i] = math.Abs(float64(i - (n-1)/2))
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	case 2:
+		for i := range d {
+			d[i] = 2
+		}
+		for i := range e {
+			if i%3 != 0 {
+				e[i] = 1
+			}
+		}
+	}
+	return d, e
+}
@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsteiner interface {
+	Dstein(n int, d, e []float64, m int, w, z []float64, ldz int, work []float64, iwork []int) (ok bool)
+
+	Dstebzer
+}
+
+func DsteinTest(t *testing.T, impl Dsteiner) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 21, 50} {
+		for typ := 0; typ < 3; typ++ {
+			for _, r := range [][2]int{{0, n - 1}, {0, 0}, {n / 3, n / 2}} {
+				for _, ldz := range []int{r[1] - r[0] + 1, r[1] - r[0] + 4} {
+					testDstein(t, impl, rnd, n, typ, r[0], r[1], ldz)
+				}
+			}
+		}
+	}
+}
+
+func testDstein(t *testing.T, impl Dsteiner, rnd *rand.Rand, n, typ, il, iu, ldz int) {
+	d, e := randomSymTridiag(n, typ, rnd)
+	w := make([]float64, n)
+	m := impl.Dstebz(lapack.IndexEVs, n, 0, 0, il, iu, 0, d, e, w)
+
+	z := nanSlice((n-1)*ldz + m)
+	ok := impl.Dstein(n, d, e, m, w, z, ldz, nanSlice(5*n), make([]int, n))
+
+	prefix := fmt.Sprintf("n=%v,type=%v,il=%v,iu=%v,ldz=%v", n, typ, il, iu, ldz)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+
+	// Check that the eigenvectors are orthonormal.
+	zMat := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+	if !hasOrthonormalColumns(n, m, z, ldz) {
+		t.Errorf("%v: eigenvectors are not orthonormal", prefix)
+	}
+
+	// Check that T*Z = Z*W.
+	tMat := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		tMat.Data[i*n+i] = d[i]
+		if i < n-1 {
+			tMat.Data[i*n+i+1] = e[i]
+			tMat.Data[(i+1)*n+i] = e[i]
+		}
+	}
+	tz := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tMat, zMat, 0, tz)
+	var tnrm float64
+	for _, v := range tMat.Data {
+		tnrm = math.Max(tnrm, math.Abs(v))
+	}
+	for j := 0; j < m; j++ {
+		for i := 0; i < n; i++ {
+			tz.Data[i*m+j] -= w[j] * z[i*ldz+j]
+			if math.Abs(tz.Data[i*m+j]) > 1e-12*math.Max(1, tnrm)*float64(n) {
+				t.Errorf("%v: T*z != w*z for eigenvalue %v", prefix, j)
+				return
+			}
+		}
+	}
+}
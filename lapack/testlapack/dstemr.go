@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstemrer interface {
+	Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, iwork []int) (m int, ok bool)
+
+	Dsterfer
+}
+
+func DstemrTest(t *testing.T, impl Dstemrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 21, 50, 101} {
+		for typ := 0; typ < 3; typ++ {
+			for _, jobz := range []lapack.EVJob{lapack.ComputeEV, lapack.None} {
+				for _, tryrac := range []bool{false, true} {
+					for _, ldz := range []int{n, n + 3} {
+						testDstemr(t, impl, rnd, n, typ, jobz, tryrac, ldz)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDstemr(t *testing.T, impl Dstemrer, rnd *rand.Rand, n, typ int, jobz lapack.EVJob, tryrac bool, ldz int) {
+	const tol = 1e-13
+
+	d, e := randomSymTridiag(n, typ, rnd)
+
+	// Compute all eigenvalues with Dsterf for comparison.
+	want := make([]float64, n)
+	copy(want, d)
+	work := make([]float64, max(0, n-1))
+	copy(work, e)
+	impl.Dsterf(n, want, work)
+	tnrm := math.Max(1, math.Max(math.Abs(want[0]), math.Abs(want[n-1])))
+
+	type evRange struct {
+		rng    lapack.EVRange
+		vl, vu float64
+		il, iu int
+	}
+	ranges := []evRange{{rng: lapack.AllEVs, il: 0, iu: n - 1}}
+	for _, r := range [][2]int{{0, 0}, {n - 1, n - 1}, {0, n - 1}, {n / 3, n / 2}} {
+		il, iu := r[0], r[1]
+		ranges = append(ranges, evRange{rng: lapack.IndexEVs, il: il, iu: iu})
+
+		// Select the same eigenvalues by a value range with end points
+		// half way between neighboring eigenvalues, unless they are too
+		// close.
+		vl := want[0] - 1
+		if il > 0 {
+			if want[il]-want[il-1] < 1e-6*tnrm {
+				continue
+			}
+			vl = 0.5 * (want[il-1] + want[il])
+		}
+		vu := want[n-1] + 1
+		if iu < n-1 {
+			if want[iu+1]-want[iu] < 1e-6*tnrm {
+				continue
+			}
+			vu = 0.5 * (want[iu] + want[iu+1])
+		}
+		ranges = append(ranges, evRange{rng: lapack.ValueEVs, vl: vl, vu: vu, il: il, iu: iu})
+	}
+
+	wantz := jobz == lapack.ComputeEV
+	for _, r := range ranges {
+		prefix := fmt.Sprintf("n=%v,type=%v,jobz=%c,tryrac=%v,ldz=%v,rng=%c,vl=%v,vu=%v,il=%v,iu=%v",
+			n, typ, jobz, tryrac, ldz, r.rng, r.vl, r.vu, r.il, r.iu)
+
+		dCopy := make([]float64, n)
+		copy(dCopy, d)
+		eCopy := make([]float64, n)
+		copy(eCopy, e)
+		w := nanSlice(n)
+		var (
+			z      []float64
+			isuppz []int
+		)
+		lwork := 12 * n
+		liwork := 8 * n
+		if wantz {
+			z = nanSlice((n-1)*ldz + n)
+			isuppz = make([]int, 2*n)
+			lwork = 18 * n
+			liwork = 10 * n
+		}
+		m, ok := impl.Dstemr(jobz, r.rng, n, dCopy, eCopy, r.vl, r.vu, r.il, r.iu, w, z, ldz, isuppz, tryrac, nanSlice(lwork), make([]int, liwork))
+		if !ok {
+			t.Errorf("%v: unexpected failure", prefix)
+			continue
+		}
+		if m != r.iu-r.il+1 {
+			t.Errorf("%v: unexpected number of eigenvalues: got %v, want %v", prefix, m, r.iu-r.il+1)
+			continue
+		}
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v: eigenvalues not sorted", prefix)
+		}
+		if !floats.EqualApprox(w[:m], want[r.il:r.iu+1], tol*tnrm) {
+			t.Errorf("%v: eigenvalue mismatch", prefix)
+		}
+		if !wantz {
+			continue
+		}
+
+		// Check that the eigenvectors are orthonormal.
+		if !hasOrthonormalColumns(n, m, z, ldz) {
+			t.Errorf("%v: eigenvectors are not orthonormal", prefix)
+		}
+
+		// Check that the eigenvectors are zero outside their support.
+		for j := 0; j < m; j++ {
+			first, last := isuppz[2*j], isuppz[2*j+1]
+			if first < 0 || first > last || last >= n {
+				t.Errorf("%v: invalid support [%v,%v] of eigenvector %v", prefix, first, last, j)
+				continue
+			}
+			for i := 0; i < n; i++ {
+				if (i < first || last < i) && z[i*ldz+j] != 0 {
+					t.Errorf("%v: non-zero element %v outside the support of eigenvector %v", prefix, i, j)
+					break
+				}
+			}
+		}
+
+		// Check that T*Z = Z*W.
+		tMat := zeros(n, n, n)
+		for i := 0; i < n; i++ {
+			tMat.Data[i*n+i] = d[i]
+			if i < n-1 {
+				tMat.Data[i*n+i+1] = e[i]
+				tMat.Data[(i+1)*n+i] = e[i]
+			}
+		}
+		zMat := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+		tz := zeros(n, m, m)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tMat, zMat, 0, tz)
+	loop:
+		for j := 0; j < m; j++ {
+			for i := 0; i < n; i++ {
+				tz.Data[i*m+j] -= w[j] * z[i*ldz+j]
+				if math.Abs(tz.Data[i*m+j]) > 1e-12*tnrm*float64(n) {
+					t.Errorf("%v: T*z != w*z for eigenvalue %v", prefix, j)
+					break loop
+				}
+			}
+		}
+	}
+}
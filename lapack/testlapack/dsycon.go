@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyconer interface {
+	Dsytf2er
+	Dgeconer
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DsyconTest(t *testing.T, impl Dsyconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 6, 15} {
+			for _, lda := range []int{n, n + 2} {
+				for trial := 0; trial < 10; trial++ {
+					name := fmt.Sprintf("uplo=%c,n=%d,lda=%d,trial=%d", uplo, n, lda, trial)
+					full := randomSymIndefinite(n, lda, "random", rnd)
+					a := cloneGeneral(full)
+					work := make([]float64, 4*n)
+					iwork := make([]int, n)
+
+					anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+					ipiv := make([]int, n)
+					if !impl.Dsytf2(uplo, n, a.Data, a.Stride, ipiv) {
+						t.Errorf("%v: unexpected singular matrix", name)
+						continue
+					}
+					got := impl.Dsycon(uplo, n, a.Data, a.Stride, ipiv, anorm, work, iwork)
+
+					// Compare against the estimate from the LU factorization.
+					lu := cloneGeneral(full)
+					luIpiv := make([]int, n)
+					impl.Dgetrf(n, n, lu.Data, lu.Stride, luIpiv)
+					want := impl.Dgecon(lapack.MaxColumnSum, n, lu.Data, lu.Stride, anorm, work, iwork)
+					// The estimates need only have the same order of magnitude.
+					if !floats.EqualWithinAbsOrRel(want, got, 1e0, 1e0) {
+						t.Errorf("%v: Dsycon and Dgecon mismatch: Dsycon %v, Dgecon %v", name, got, want)
+					}
+				}
+			}
+		}
+	}
+
+	// A singular D must have a zero condition number estimate.
+	a := []float64{0, 0, 0, 1}
+	ipiv := []int{0, 1}
+	work := make([]float64, 4)
+	iwork := make([]int, 2)
+	if rcond := impl.Dsycon(blas.Upper, 2, a, 2, ipiv, 1, work, iwork); rcond != 0 {
+		t.Errorf("unexpected condition number estimate for singular D: got %v, want 0", rcond)
+	}
+}
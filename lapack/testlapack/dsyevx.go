@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevxer interface {
+	Dsyevx(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork []int) (m int, ok bool)
+
+	Dsyever
+}
+
+func DsyevxTest(t *testing.T, impl Dsyevxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, lda := range []int{max(1, n), n + 3} {
+				a := randomSymmetricGeneral(n, lda, rnd)
+
+				// Compute all eigenvalues with Dsyev for comparison.
+				want := make([]float64, n)
+				aCopy := make([]float64, len(a.Data))
+				copy(aCopy, a.Data)
+				work := make([]float64, max(1, 3*n))
+				impl.Dsyev(lapack.None, uplo, n, aCopy, lda, want, work, len(work))
+
+				type evRange struct {
+					rng    lapack.EVRange
+					vl, vu float64
+					il, iu int
+				}
+				ranges := []evRange{{rng: lapack.AllEVs}}
+				if n > 0 {
+					ranges = append(ranges,
+						evRange{rng: lapack.IndexEVs, il: 0, iu: 0},
+						evRange{rng: lapack.IndexEVs, il: n / 3, iu: n / 2},
+						evRange{rng: lapack.IndexEVs, il: 0, iu: n - 1},
+						evRange{rng: lapack.ValueEVs, vl: want[0] - 1, vu: want[n-1] + 1},
+						evRange{rng: lapack.ValueEVs, vl: (want[0] + want[n/2]) / 2, vu: want[n-1] + 1},
+					)
+				}
+				for _, r := range ranges {
+					// Compute the indices of the wanted eigenvalues.
+					il, iu := 0, n-1
+					switch r.rng {
+					case lapack.IndexEVs:
+						il, iu = r.il, r.iu
+					case lapack.ValueEVs:
+						il = 0
+						for il < n && want[il] <= r.vl {
+							il++
+						}
+						iu = n - 1
+						for iu >= 0 && want[iu] > r.vu {
+							iu--
+						}
+					}
+					for _, jobz := range []lapack.EVJob{lapack.ComputeEV, lapack.None} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							prefix := fmt.Sprintf("uplo=%c,n=%v,lda=%v,range=%c,il=%v,iu=%v,jobz=%c,work=%v",
+								uplo, n, lda, r.rng, il, iu, jobz, wl)
+							testDsyevx(t, impl, prefix, jobz, r.rng, uplo, a, r.vl, r.vu, r.il, r.iu, want[il:iu+1], wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsyevx(t *testing.T, impl Dsyevxer, prefix string, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, aOrig blas64.General, vl, vu float64, il, iu int, want []float64, wl worklen) {
+	n := aOrig.Rows
+	a := cloneGeneral(aOrig)
+	ldz := max(1, n+2)
+	z := nanSlice(n * ldz)
+	w := nanSlice(n)
+	iwork := make([]int, n)
+
+	work := make([]float64, 1)
+	impl.Dsyevx(jobz, rng, uplo, n, a.Data, a.Stride, vl, vu, il, iu, 0, w, z, ldz, work, -1, iwork)
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 4*n)
+		if jobz == lapack.ComputeEV {
+			lwork = max(1, n*n+8*n)
+		}
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	m, ok := impl.Dsyevx(jobz, rng, uplo, n, a.Data, a.Stride, vl, vu, il, iu, 0, w, z, ldz, work, lwork, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if m != len(want) {
+		t.Errorf("%v: unexpected number of eigenvalues: got %v, want %v", prefix, m, len(want))
+		return
+	}
+	if !floats.EqualApprox(w[:m], want, 1e-12) {
+		t.Errorf("%v: eigenvalue mismatch", prefix)
+	}
+	if jobz != lapack.ComputeEV || m == 0 {
+		return
+	}
+
+	// Check that the eigenvectors are orthonormal.
+	zMat := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+	if !hasOrthonormalColumns(n, m, z, ldz) {
+		t.Errorf("%v: eigenvectors are not orthonormal", prefix)
+	}
+
+	// Check that A*Z = Z*W.
+	az := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aOrig, zMat, 0, az)
+	for j := 0; j < m; j++ {
+		for i := 0; i < n; i++ {
+			if math.Abs(az.Data[i*m+j]-w[j]*z[i*ldz+j]) > 1e-12*float64(n) {
+				t.Errorf("%v: A*z != w*z for eigenvalue %v", prefix, j)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dsytf2er interface {
+	Dsytf2(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) (ok bool)
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+}
+
+func Dsytf2Test(t *testing.T, impl Dsytf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+			for _, lda := range []int{max(1, n), n + 3} {
+				for _, kind := range []string{"random", "zero diagonal", "diagonal"} {
+					if n == 1 && kind == "zero diagonal" {
+						// A 1×1 matrix with zero diagonal is singular.
+						continue
+					}
+					name := fmt.Sprintf("uplo=%c,n=%d,lda=%d,kind=%s", uplo, n, lda, kind)
+					full := randomSymIndefinite(n, lda, kind, rnd)
+					a := symTriangleWithNaN(uplo, full)
+					ipiv := make([]int, n)
+					ok := impl.Dsytf2(uplo, n, a.Data, a.Stride, ipiv)
+					if !ok {
+						t.Errorf("%v: unexpected singular matrix", name)
+						continue
+					}
+					checkSytrf(t, name, uplo, full, a, ipiv)
+					checkSytrfSolve(t, name, impl, uplo, full, a, ipiv, 2, rnd)
+				}
+			}
+		}
+	}
+
+	// A zero matrix must be reported as singular.
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		a := make([]float64, 9)
+		ipiv := make([]int, 3)
+		if impl.Dsytf2(uplo, 3, a, 3, ipiv) {
+			t.Errorf("uplo=%c: expected singular result for zero matrix", uplo)
+		}
+	}
+}
+
+// randomSymIndefinite returns a random n×n symmetric matrix with both
+// triangles set. If kind is "zero diagonal", the diagonal of the matrix is
+// zero, forcing the use of 2×2 pivot blocks, and if kind is "diagonal", the
+// matrix is diagonal with elements of both signs.
+func randomSymIndefinite(n, lda int, kind string, rnd *rand.Rand) blas64.General {
+	a := nanGeneral(n, n, lda)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var v float64
+			switch kind {
+			case "random":
+				v = rnd.NormFloat64()
+			case "zero diagonal":
+				if i != j {
+					v = rnd.NormFloat64()
+				}
+			case "diagonal":
+				if i == j {
+					v = float64(i + 1)
+					if i%2 == 0 {
+						v = -v
+					}
+				}
+			default:
+				panic("bad kind")
+			}
+			a.Data[i*lda+j] = v
+			a.Data[j*lda+i] = v
+		}
+	}
+	return a
+}
+
+// symTriangleWithNaN returns a copy of the symmetric matrix a where the
+// triangle opposite to uplo is filled with NaN.
+func symTriangleWithNaN(uplo blas.Uplo, a blas64.General) blas64.General {
+	b := cloneGeneral(a)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				b.Data[i*b.Stride+j] = math.NaN()
+			}
+		}
+	}
+	return b
+}
+
+// checkSytrf checks that the factorization returned by Dsytf2 or Dsytrf has
+// a valid pivot vector and has not modified the triangle opposite to uplo.
+func checkSytrf(t *testing.T, name string, uplo blas.Uplo, full, a blas64.General, ipiv []int) {
+	n := a.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				if !math.IsNaN(a.Data[i*a.Stride+j]) {
+					t.Errorf("%v: opposite triangle modified at (%d,%d)", name, i, j)
+					return
+				}
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		if ipiv[k] >= 0 {
+			if ipiv[k] >= n {
+				t.Errorf("%v: ipiv[%d]=%d out of range", name, k, ipiv[k])
+			}
+			continue
+		}
+		// The first and second row of a 2×2 block.
+		if k == n-1 || ipiv[k+1] != ipiv[k] {
+			t.Errorf("%v: unpaired 2×2 pivot at %d", name, k)
+			return
+		}
+		if kp := -ipiv[k] - 1; kp >= n {
+			t.Errorf("%v: ipiv[%d]=%d out of range", name, k, ipiv[k])
+		}
+		k++
+	}
+}
+
+// checkSytrfSolve checks that the factorization of the symmetric matrix full
+// stored in a and ipiv can be used by Dsytrs to solve a system with nrhs right
+// hand sides.
+func checkSytrfSolve(t *testing.T, name string, impl Dsytf2er, uplo blas.Uplo, full, a blas64.General, ipiv []int, nrhs int, rnd *rand.Rand) {
+	n := a.Rows
+	if n == 0 {
+		return
+	}
+	want := randomGeneral(n, nrhs, nrhs, rnd)
+	b := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, want, 0, b)
+	impl.Dsytrs(uplo, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+	if !equalApproxGeneral(b, want, 1e-9) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
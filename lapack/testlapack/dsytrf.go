@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dsytrfer interface {
+	Dsytf2er
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+}
+
+func DsytrfTest(t *testing.T, impl Dsytrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	// The block size returned by Ilaenv is 64, so n > 64 exercises the
+	// blocked algorithm.
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 18, 40, 65, 100, 150} {
+			for _, lda := range []int{max(1, n), n + 5} {
+				for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+					testDsytrf(t, impl, rnd, uplo, n, lda, wl)
+				}
+			}
+		}
+	}
+}
+
+func testDsytrf(t *testing.T, impl Dsytrfer, rnd *rand.Rand, uplo blas.Uplo, n, lda int, wl worklen) {
+	name := fmt.Sprintf("uplo=%c,n=%d,lda=%d,work=%v", uplo, n, lda, wl)
+	full := randomSymIndefinite(n, lda, "random", rnd)
+	a := symTriangleWithNaN(uplo, full)
+	want := cloneGeneral(a)
+	wantIpiv := make([]int, n)
+	wantOK := impl.Dsytf2(uplo, n, want.Data, want.Stride, wantIpiv)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = 1
+	case mediumWork:
+		// Enough workspace for the blocked algorithm with block size 10.
+		lwork = max(1, 10*n)
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dsytrf(uplo, n, a.Data, a.Stride, nil, work, -1)
+		lwork = int(work[0])
+	}
+	work := make([]float64, lwork)
+	ipiv := make([]int, n)
+	ok := impl.Dsytrf(uplo, n, a.Data, a.Stride, ipiv, work, lwork)
+	if ok != wantOK {
+		t.Errorf("%v: unexpected ok: got %v, want %v", name, ok, wantOK)
+	}
+	checkSytrf(t, name, uplo, full, a, ipiv)
+	checkSytrfSolve(t, name, impl, uplo, full, a, ipiv, 3, rnd)
+
+	// The blocked and unblocked algorithms choose the same pivots and
+	// compute the same factors up to rounding errors.
+	for i := range ipiv {
+		if ipiv[i] != wantIpiv[i] {
+			t.Errorf("%v: mismatch between Dsytrf and Dsytf2 pivots", name)
+			break
+		}
+	}
+	for i, v := range a.Data {
+		w := want.Data[i]
+		if !(math.IsNaN(v) && math.IsNaN(w)) && !floats.EqualWithinAbsOrRel(v, w, 1e-12, 1e-12) {
+			t.Errorf("%v: mismatch between Dsytrf and Dsytf2 factors", name)
+			break
+		}
+	}
+}
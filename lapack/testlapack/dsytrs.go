@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dsytrser interface {
+	Dsytf2er
+}
+
+func DsytrsTest(t *testing.T, impl Dsytrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 8, 21} {
+			for _, nrhs := range []int{1, 2, 5} {
+				for _, ldb := range []int{nrhs, nrhs + 2} {
+					for _, kind := range []string{"random", "zero diagonal"} {
+						if n == 1 && kind == "zero diagonal" {
+							// A 1×1 matrix with zero diagonal is singular.
+							continue
+						}
+						name := fmt.Sprintf("uplo=%c,n=%d,nrhs=%d,ldb=%d,kind=%s", uplo, n, nrhs, ldb, kind)
+						full := randomSymIndefinite(n, n, kind, rnd)
+						a := cloneGeneral(full)
+						ipiv := make([]int, n)
+						if !impl.Dsytf2(uplo, n, a.Data, a.Stride, ipiv) {
+							t.Errorf("%v: unexpected singular matrix", name)
+							continue
+						}
+
+						want := randomGeneral(n, nrhs, ldb, rnd)
+						b := nanGeneral(n, nrhs, ldb)
+						blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, want, 0, b)
+						impl.Dsytrs(uplo, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+						if !equalApproxGeneral(b, want, 1e-9) {
+							t.Errorf("%v: unexpected solution", name)
+						}
+						if !generalOutsideAllNaN(b) {
+							t.Errorf("%v: out-of-range write to b", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
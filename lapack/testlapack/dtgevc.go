@@ -0,0 +1,254 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dtgevcer interface {
+	Dtgevc(side lapack.EVSide, howmny lapack.HowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int,
+		vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool)
+}
+
+func DtgevcTest(t *testing.T, impl Dtgevcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []lapack.EVSide{lapack.RightEV, lapack.LeftEV, lapack.RightLeftEV} {
+		for _, howmny := range []lapack.HowMany{lapack.AllEV, lapack.AllEVMulQ, lapack.SelectedEV} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 34} {
+				for _, extra := range []int{0, 11} {
+					for _, singular := range []bool{false, true} {
+						for cas := 0; cas < 5; cas++ {
+							s, p, ev := randomGeneralizedSchur(n, n+extra, singular, rnd)
+							testDtgevc(t, impl, side, howmny, s, p, ev, singular, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// generalizedEigenvalue is a generalized eigenvalue α/β of a matrix pair.
+type generalizedEigenvalue struct {
+	alpha complex128
+	beta  float64
+}
+
+// randomGeneralizedSchur returns a random n×n matrix pair (S,P) in generalized
+// Schur form as returned by Dhgeqz, that is, S is quasi-triangular with 1×1
+// and 2×2 diagonal blocks, P is upper triangular and the 2×2 diagonal blocks
+// of P corresponding to 2×2 blocks of S are diagonal with positive elements.
+// The 2×2 blocks of (S,P) have complex conjugate pairs of eigenvalues. If
+// singular is true, the diagonal element of P in one of the 1×1 blocks, if
+// there is any, is set to zero so that (S,P) has an infinite eigenvalue. The
+// eigenvalues of (S,P) are returned in ev.
+func randomGeneralizedSchur(n, stride int, singular bool, rnd *rand.Rand) (s, p blas64.General, ev []generalizedEigenvalue) {
+	s = randomGeneral(n, n, stride, rnd)
+	p = randomGeneral(n, n, stride, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			s.Data[i*s.Stride+j] = 0
+			p.Data[i*p.Stride+j] = 0
+		}
+	}
+	ev = make([]generalizedEigenvalue, n)
+	for i := 0; i < n; {
+		if i == n-1 || rnd.Float64() < 0.5 {
+			// 1×1 block.
+			if singular && (i == n-1 || rnd.Float64() < 0.3) {
+				p.Data[i*p.Stride+i] = 0
+				singular = false
+			}
+			ev[i] = generalizedEigenvalue{complex(s.Data[i*s.Stride+i], 0), p.Data[i*p.Stride+i]}
+			i++
+			continue
+		}
+		// 2×2 block.
+		p0 := 0.5 + rnd.Float64()
+		p1 := 0.5 + rnd.Float64()
+		p.Data[i*p.Stride+i] = p0
+		p.Data[i*p.Stride+i+1] = 0
+		p.Data[(i+1)*p.Stride+i+1] = p1
+		a := s.Data[i*s.Stride+i]
+		b := s.Data[i*s.Stride+i+1]
+		d := s.Data[(i+1)*s.Stride+i+1]
+		if b == 0 {
+			b = 1
+		}
+		// Choose c so that the eigenvalues of the pencil, i.e. the roots of
+		//  p0*p1*λ^2 - (a*p1 + d*p0)*λ + a*d - b*c,
+		// are complex.
+		k := p0 * p1 * (a/p0 - d/p1) * (a/p0 - d/p1) / 4
+		c := -(k + 0.5 + rnd.Float64()) / b
+		s.Data[i*s.Stride+i+1] = b
+		s.Data[(i+1)*s.Stride+i] = c
+		disc := (a*p1+d*p0)*(a*p1+d*p0) - 4*p0*p1*(a*d-b*c)
+		re := (a*p1 + d*p0) / (2 * p0 * p1)
+		im := math.Sqrt(-disc) / (2 * p0 * p1)
+		ev[i] = generalizedEigenvalue{complex(re, im), 1}
+		ev[i+1] = generalizedEigenvalue{complex(re, -im), 1}
+		i += 2
+	}
+	return s, p, ev
+}
+
+func testDtgevc(t *testing.T, impl Dtgevcer, side lapack.EVSide, howmny lapack.HowMany, s, p blas64.General, ev []generalizedEigenvalue, singular bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	n := s.Rows
+	extra := s.Stride - s.Cols
+	right := side != lapack.LeftEV
+	left := side != lapack.RightEV
+
+	var selected []bool
+	var mWant int
+	if howmny == lapack.SelectedEV {
+		selected = make([]bool, n)
+		for i := range selected {
+			selected[i] = rnd.Float64() < 0.5
+		}
+		for i := 0; i < n; {
+			if i == n-1 || s.Data[(i+1)*s.Stride+i] == 0 {
+				if selected[i] {
+					mWant++
+				}
+				i++
+			} else {
+				if selected[i] || selected[i+1] {
+					mWant += 2
+				}
+				i += 2
+			}
+		}
+	} else {
+		mWant = n
+	}
+
+	// For lapack.AllEVMulQ, VL and VR contain the orthogonal matrices Q and
+	// Z on entry and the eigenvectors are then those of the pair
+	//  (A,B) = (Q*S*Z^T, Q*P*Z^T).
+	a, b := s, p
+	var q, z blas64.General
+	if howmny == lapack.AllEVMulQ {
+		q = randomOrthogonal(n, rnd)
+		z = randomOrthogonal(n, rnd)
+		a = zeros(n, n, n)
+		b = zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		if n > 0 {
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, s, 0, tmp)
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, a)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, p, 0, tmp)
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, b)
+		}
+	}
+
+	var vl, vr blas64.General
+	if left {
+		vl = nanGeneral(n, n, n+extra)
+		if howmny == lapack.AllEVMulQ {
+			copyGeneral(vl, q)
+		}
+	}
+	if right {
+		vr = nanGeneral(n, n, n+extra)
+		if howmny == lapack.AllEVMulQ {
+			copyGeneral(vr, z)
+		}
+	}
+	sCopy := cloneGeneral(s)
+	pCopy := cloneGeneral(p)
+
+	work := nanSlice(6 * n)
+	m, ok := impl.Dtgevc(side, howmny, selected, n, s.Data, s.Stride, p.Data, p.Stride,
+		vl.Data, max(1, vl.Stride), vr.Data, max(1, vr.Stride), n, work)
+
+	prefix := fmt.Sprintf("Case side=%v,howmny=%v,n=%v,extra=%v,singular=%v",
+		string(side), string(howmny), n, extra, singular)
+
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m. Want %v, got %v", prefix, mWant, m)
+	}
+	if !floats.Same(s.Data, sCopy.Data) {
+		t.Errorf("%v: unexpected modification of S", prefix)
+	}
+	if !floats.Same(p.Data, pCopy.Data) {
+		t.Errorf("%v: unexpected modification of P", prefix)
+	}
+
+	for _, v := range []struct {
+		want bool
+		left bool
+		name string
+		mat  blas64.General
+	}{
+		{left, true, "left", vl},
+		{right, false, "right", vr},
+	} {
+		if !v.want {
+			continue
+		}
+		k := 0
+		for j := 0; j < n; {
+			cmplxPair := j < n-1 && s.Data[(j+1)*s.Stride+j] != 0
+			if howmny == lapack.SelectedEV && !selected[j] && !(cmplxPair && selected[j+1]) {
+				if cmplxPair {
+					j += 2
+				} else {
+					j++
+				}
+				continue
+			}
+			if !cmplxPair {
+				x := columnOf(v.mat, k)
+				checkGeneralizedEigenvector(t, prefix, v.name, v.left, a, b, x, nil, ev[j], tol)
+				k++
+				j++
+				continue
+			}
+			xRe := columnOf(v.mat, k)
+			xIm := columnOf(v.mat, k+1)
+			checkGeneralizedEigenvector(t, prefix, v.name, v.left, a, b, xRe, xIm, ev[j], tol)
+			floats.Scale(-1, xIm)
+			checkGeneralizedEigenvector(t, prefix, v.name, v.left, a, b, xRe, xIm, ev[j+1], tol)
+			k += 2
+			j += 2
+		}
+	}
+}
+
+// checkGeneralizedEigenvector checks that xRe+i*xIm is a generalized
+// eigenvector of the pair (A,B) corresponding to ev, and that it is normalized
+// so that its largest component has |real part| + |imag part| equal to 1.
+func checkGeneralizedEigenvector(t *testing.T, prefix, name string, left bool, a, b blas64.General, xRe, xIm []float64, ev generalizedEigenvalue, tol float64) {
+	var xmax float64
+	for i := range xRe {
+		v := math.Abs(xRe[i])
+		if xIm != nil {
+			v += math.Abs(xIm[i])
+		}
+		xmax = math.Max(xmax, v)
+	}
+	if math.Abs(xmax-1) > tol {
+		t.Errorf("%v: %v eigenvector for λ=%v/%v not normalized, max=%v", prefix, name, ev.alpha, ev.beta, xmax)
+	}
+	resid := generalizedEigenResidual(left, a, b, xRe, xIm, ev.alpha, ev.beta)
+	if resid > tol*float64(len(xRe)) || math.IsNaN(resid) {
+		t.Errorf("%v: %v eigenvector for λ=%v/%v has residual %v", prefix, name, ev.alpha, ev.beta, resid)
+	}
+}
@@ -843,6 +843,21 @@ func isOrthonormal(q blas64.General) bool {
 	return true
 }
 
+// randomSymmetricGeneral returns an n×n random symmetric matrix stored in a
+// general matrix with the given stride. Elements outside the matrix are
+// set to NaN.
+func randomSymmetricGeneral(n, stride int, rnd *rand.Rand) blas64.General {
+	a := nanGeneral(n, n, stride)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := rnd.NormFloat64()
+			a.Data[i*stride+j] = v
+			a.Data[j*stride+i] = v
+		}
+	}
+	return a
+}
+
 // copyMatrix copies an m×n matrix src of stride n into an m×n matrix dst of stride ld.
 func copyMatrix(m, n int, dst []float64, ld int, src []float64) {
 	for i := 0; i < m; i++ {
@@ -1514,3 +1529,49 @@ func constructGSVPresults(n, p, m, k, l int, a, b blas64.General) (zeroA, zeroB
 
 	return zeroA, zeroB
 }
+
+// generalizedEigenResidual returns the residual of the right or left
+// generalized eigenvector xRe+i*xIm, where i is the imaginary unit, of the
+// matrix pair (A,B) corresponding to the eigenvalue alpha/beta. The residual is
+//  |β A x - α B x| / ((|β| |A| + |α| |B|) |x|)
+// for a right eigenvector and
+//  |β x^H A - α x^H B| / ((|β| |A| + |α| |B|) |x|)
+// for a left eigenvector, where all norms are max-abs norms. If xIm is nil,
+// x is assumed to be real.
+func generalizedEigenResidual(left bool, a, b blas64.General, xRe, xIm []float64, alpha complex128, beta float64) float64 {
+	n := a.Rows
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(xRe[i], 0)
+		if xIm != nil {
+			x[i] += complex(0, xIm[i])
+		}
+		if left {
+			x[i] = cmplx.Conj(x[i])
+		}
+	}
+	var rnorm, xnorm, anorm, bnorm float64
+	for i := 0; i < n; i++ {
+		var r complex128
+		for k := 0; k < n; k++ {
+			var aik, bik float64
+			if left {
+				aik = a.Data[k*a.Stride+i]
+				bik = b.Data[k*b.Stride+i]
+			} else {
+				aik = a.Data[i*a.Stride+k]
+				bik = b.Data[i*b.Stride+k]
+			}
+			r += complex(beta*aik, 0)*x[k] - alpha*complex(bik, 0)*x[k]
+			anorm = math.Max(anorm, math.Abs(aik))
+			bnorm = math.Max(bnorm, math.Abs(bik))
+		}
+		rnorm = math.Max(rnorm, cmplx.Abs(r))
+		xnorm = math.Max(xnorm, cmplx.Abs(x[i]))
+	}
+	den := (math.Abs(beta)*anorm + cmplx.Abs(alpha)*bnorm) * xnorm
+	if den == 0 {
+		return rnorm
+	}
+	return rnorm / den
+}
@@ -0,0 +1,40 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// sgeneralFrom returns a float32 copy of the general matrix a, including the
+// elements outside of the matrix.
+func sgeneralFrom(a blas64.General) blas32.General {
+	s := blas32.General{
+		Rows:   a.Rows,
+		Cols:   a.Cols,
+		Stride: a.Stride,
+		Data:   make([]float32, len(a.Data)),
+	}
+	for i, v := range a.Data {
+		s.Data[i] = float32(v)
+	}
+	return s
+}
+
+// dgeneralFrom returns a float64 copy of the float32 general matrix a,
+// including the elements outside of the matrix.
+func dgeneralFrom(a blas32.General) blas64.General {
+	d := blas64.General{
+		Rows:   a.Rows,
+		Cols:   a.Cols,
+		Stride: a.Stride,
+		Data:   make([]float64, len(a.Data)),
+	}
+	for i, v := range a.Data {
+		d.Data[i] = float64(v)
+	}
+	return d
+}
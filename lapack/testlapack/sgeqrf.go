@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Sgeqrfer interface {
+	Sgeqrf(m, n int, a []float32, lda int, tau, work []float32, lwork int)
+	Sormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int)
+}
+
+func SgeqrfTest(t *testing.T, impl Sgeqrfer) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{1, 1, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{50, 30, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{50, 30, 60},
+	} {
+		m := test.m
+		n := test.n
+		k := min(m, n)
+		a := sgeneralFrom(randomGeneral(m, n, max(n, test.lda), rnd))
+		aCopy := dgeneralFrom(a)
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, a.Stride)
+
+		tau := make([]float32, k)
+		work := make([]float32, 1)
+		impl.Sgeqrf(m, n, a.Data, a.Stride, tau, work, -1)
+		work = make([]float32, int(work[0]))
+		impl.Sgeqrf(m, n, a.Data, a.Stride, tau, work, len(work))
+
+		// Form the full m×m matrix Q by applying it to the identity.
+		q := blas32.General{Rows: m, Cols: m, Stride: m, Data: make([]float32, m*m)}
+		for i := 0; i < m; i++ {
+			q.Data[i*m+i] = 1
+		}
+		work = make([]float32, 1)
+		impl.Sormqr(blas.Left, blas.NoTrans, m, m, k, a.Data, a.Stride, tau, q.Data, q.Stride, work, -1)
+		work = make([]float32, int(work[0]))
+		impl.Sormqr(blas.Left, blas.NoTrans, m, m, k, a.Data, a.Stride, tau, q.Data, q.Stride, work, len(work))
+
+		qd := dgeneralFrom(q)
+		qtq := zeros(m, m, m)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, qd, qd, 0, qtq)
+		if !equalApproxGeneral(qtq, eye(m, m), tol) {
+			t.Errorf("%v: Q is not orthogonal", prefix)
+		}
+
+		// Extract R.
+		r := zeros(m, n, n)
+		for i := 0; i < k; i++ {
+			for j := i; j < n; j++ {
+				r.Data[i*n+j] = float64(a.Data[i*a.Stride+j])
+			}
+		}
+		qr := zeros(m, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, qd, r, 0, qr)
+		if !equalApproxGeneral(qr, aCopy, tol) {
+			t.Errorf("%v: Q*R does not equal A", prefix)
+		}
+
+		// Check that applying Q^T from the right to A^T gives R^T.
+		c := sgeneralFrom(transposeGeneral(aCopy))
+		work = make([]float32, 1)
+		impl.Sormqr(blas.Right, blas.NoTrans, n, m, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, -1)
+		work = make([]float32, int(work[0]))
+		impl.Sormqr(blas.Right, blas.NoTrans, n, m, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, len(work))
+		if !equalApproxGeneral(dgeneralFrom(c), transposeGeneral(r), tol) {
+			t.Errorf("%v: A^T*Q does not equal R^T", prefix)
+		}
+	}
+}
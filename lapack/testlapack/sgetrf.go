@@ -0,0 +1,82 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Sgetrfer interface {
+	Sgetrf(m, n int, a []float32, lda int, ipiv []int) bool
+}
+
+func SgetrfTest(t *testing.T, impl Sgetrfer) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{1, 1, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{50, 30, 0},
+		{30, 50, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{10, 10, 20},
+		{50, 30, 60},
+	} {
+		m := test.m
+		n := test.n
+		a := sgeneralFrom(randomGeneral(m, n, max(n, test.lda), rnd))
+		aCopy := dgeneralFrom(a)
+		mn := min(m, n)
+		ipiv := make([]int, mn)
+		for i := range ipiv {
+			ipiv[i] = rnd.Int()
+		}
+		ok := impl.Sgetrf(m, n, a.Data, a.Stride, ipiv)
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, a.Stride)
+		if !ok {
+			t.Errorf("%v: unexpected singular matrix", prefix)
+			continue
+		}
+
+		// Check that P * L * U = A.
+		l := zeros(m, mn, mn)
+		u := zeros(mn, n, n)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				v := float64(a.Data[i*a.Stride+j])
+				switch {
+				case i == j:
+					l.Data[i*l.Stride+i] = 1
+					u.Data[i*u.Stride+i] = v
+				case i > j:
+					l.Data[i*l.Stride+j] = v
+				default:
+					u.Data[i*u.Stride+j] = v
+				}
+			}
+		}
+		lu := zeros(m, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, l, u, 0, lu)
+		// Undo the row interchanges.
+		for i := mn - 1; i >= 0; i-- {
+			blas64.Swap(n,
+				blas64.Vector{Inc: 1, Data: lu.Data[i*lu.Stride:]},
+				blas64.Vector{Inc: 1, Data: lu.Data[ipiv[i]*lu.Stride:]})
+		}
+		if !equalApproxGeneral(lu, aCopy, tol) {
+			t.Errorf("%v: P*L*U does not equal A", prefix)
+		}
+	}
+}
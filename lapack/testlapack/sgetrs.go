@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Sgetrser interface {
+	Sgetrfer
+	Sgetrs(trans blas.Transpose, n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int)
+}
+
+func SgetrsTest(t *testing.T, impl Sgetrser) {
+	const tol = 1e-3
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{1, 1, 0, 0},
+			{3, 3, 0, 0},
+			{3, 5, 0, 0},
+			{5, 3, 0, 0},
+			{30, 10, 0, 0},
+			{3, 3, 10, 10},
+			{3, 5, 10, 10},
+			{30, 10, 40, 20},
+		} {
+			n := test.n
+			nrhs := test.nrhs
+			// Make A diagonally dominant so that the solution
+			// can be checked in single precision.
+			ad := randomGeneral(n, n, max(n, test.lda), rnd)
+			for i := 0; i < n; i++ {
+				ad.Data[i*ad.Stride+i] += float64(n)
+			}
+			a := sgeneralFrom(ad)
+			opA := dgeneralFrom(a)
+			if trans == blas.Trans {
+				opA = transposeGeneral(opA)
+			}
+			b := sgeneralFrom(randomGeneral(n, nrhs, max(nrhs, test.ldb), rnd))
+			bCopy := dgeneralFrom(b)
+
+			ipiv := make([]int, n)
+			impl.Sgetrf(n, n, a.Data, a.Stride, ipiv)
+			impl.Sgetrs(trans, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+
+			// Check that op(A) * X = B.
+			x := dgeneralFrom(b)
+			ax := zeros(n, nrhs, nrhs)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, opA, x, 0, ax)
+			if !equalApproxGeneral(ax, bCopy, tol) {
+				t.Errorf("trans=%c,n=%d,nrhs=%d,lda=%d,ldb=%d: unexpected solution",
+					trans, n, nrhs, a.Stride, b.Stride)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Spotrfer interface {
+	Spotrf(ul blas.Uplo, n int, a []float32, lda int) (ok bool)
+}
+
+func SpotrfTest(t *testing.T, impl Spotrfer) {
+	const tol = 1e-3
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{1, 0},
+			{2, 0},
+			{3, 0},
+			{10, 0},
+			{30, 0},
+			{1, 10},
+			{3, 10},
+			{10, 20},
+			{30, 50},
+		} {
+			n := test.n
+			prefix := fmt.Sprintf("uplo=%c,n=%d,lda=%d", uplo, n, test.lda)
+
+			// Construct a positive definite matrix A as
+			//  A = B^T * B + n * I.
+			b := randomGeneral(n, n, n, rnd)
+			ad := nanGeneral(n, n, max(n, test.lda))
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					ad.Data[i*ad.Stride+j] = 0
+				}
+			}
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, b, b, 0, ad)
+			for i := 0; i < n; i++ {
+				ad.Data[i*ad.Stride+i] += float64(n)
+			}
+			a := sgeneralFrom(ad)
+			aCopy := dgeneralFrom(a)
+
+			ok := impl.Spotrf(uplo, n, a.Data, a.Stride)
+			if !ok {
+				t.Errorf("%v: unexpected failure for positive definite matrix", prefix)
+				continue
+			}
+
+			// Extract the triangular factor and check that
+			//  U^T * U = A or L * L^T = A.
+			f := dgeneralFrom(a)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+						f.Data[i*f.Stride+j] = 0
+					}
+				}
+			}
+			got := zeros(n, n, n)
+			if uplo == blas.Upper {
+				blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, f, 0, got)
+			} else {
+				blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, got)
+			}
+			if !equalApproxGeneral(got, aCopy, tol*float64(n)) {
+				t.Errorf("%v: factorization does not reconstruct A", prefix)
+			}
+		}
+	}
+
+	// Check that a matrix that is not positive definite is detected.
+	a := []float32{
+		1, 2,
+		2, 1,
+	}
+	if impl.Spotrf(blas.Upper, 2, a, 2) {
+		t.Errorf("unexpected success for indefinite matrix")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/cmplx"
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// randomZGeneral allocates a new r×c complex general matrix with the given
+// stride and fills it with random numbers, including the elements outside
+// of the matrix.
+func randomZGeneral(r, c, stride int, rnd *rand.Rand) cblas128.General {
+	if stride == 0 {
+		stride = max(1, c)
+	}
+	ans := cblas128.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: stride,
+		Data:   make([]complex128, max(0, (r-1)*stride+c)),
+	}
+	for i := range ans.Data {
+		ans.Data[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return ans
+}
+
+// cloneZGeneral allocates and returns an exact copy of the given general matrix.
+func cloneZGeneral(a cblas128.General) cblas128.General {
+	c := a
+	c.Data = make([]complex128, len(a.Data))
+	copy(c.Data, a.Data)
+	return c
+}
+
+// zeye returns an n×n complex identity matrix with the given stride.
+func zeye(n, stride int) cblas128.General {
+	ans := cblas128.General{
+		Rows:   n,
+		Cols:   n,
+		Stride: stride,
+		Data:   make([]complex128, max(0, (n-1)*stride+n)),
+	}
+	for i := 0; i < n; i++ {
+		ans.Data[i*stride+i] = 1
+	}
+	return ans
+}
+
+// zmul returns op(a) * op(b) where op is the identity or the conjugate
+// transpose.
+func zmul(tA, tB blas.Transpose, a, b cblas128.General) cblas128.General {
+	at := func(m cblas128.General, t blas.Transpose, i, j int) complex128 {
+		if t == blas.ConjTrans {
+			return cmplx.Conj(m.Data[j*m.Stride+i])
+		}
+		return m.Data[i*m.Stride+j]
+	}
+	r, k := a.Rows, a.Cols
+	if tA == blas.ConjTrans {
+		r, k = k, r
+	}
+	c := b.Cols
+	if tB == blas.ConjTrans {
+		c = b.Rows
+	}
+	ans := cblas128.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: max(1, c),
+		Data:   make([]complex128, r*c),
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			var v complex128
+			for l := 0; l < k; l++ {
+				v += at(a, tA, i, l) * at(b, tB, l, j)
+			}
+			ans.Data[i*ans.Stride+j] = v
+		}
+	}
+	return ans
+}
+
+// zequalApproxGeneral returns whether the general matrices a and b are
+// element-wise equal to within tol.
+func zequalApproxGeneral(a, b cblas128.General, tol float64) bool {
+	if a.Rows != b.Rows || a.Cols != b.Cols {
+		panic("bad sizes")
+	}
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			if cmplx.Abs(a.Data[i*a.Stride+j]-b.Data[i*b.Stride+j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasOrthonormalColumnsZ returns whether the columns of the m×n matrix q,
+// m >= n, are orthonormal to within tol.
+func hasOrthonormalColumnsZ(q cblas128.General, tol float64) bool {
+	qhq := zmul(blas.ConjTrans, blas.NoTrans, q, q)
+	return zequalApproxGeneral(qhq, zeye(q.Cols, q.Cols), tol)
+}
@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zgeqrfer interface {
+	Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int)
+}
+
+func ZgeqrfTest(t *testing.T, impl Zgeqrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{1, 1, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{100, 50, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{100, 50, 120},
+	} {
+		m := test.m
+		n := test.n
+		k := min(m, n)
+		a := randomZGeneral(m, n, test.lda, rnd)
+		aCopy := cloneZGeneral(a)
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, a.Stride)
+
+		tau := make([]complex128, k)
+		work := make([]complex128, 1)
+		impl.Zgeqrf(m, n, a.Data, a.Stride, tau, work, -1)
+		work = make([]complex128, int(real(work[0])))
+		impl.Zgeqrf(m, n, a.Data, a.Stride, tau, work, len(work))
+
+		// Extract R.
+		r := cblas128.General{Rows: k, Cols: n, Stride: n, Data: make([]complex128, k*n)}
+		for i := 0; i < k; i++ {
+			copy(r.Data[i*n+i:i*n+n], a.Data[i*a.Stride+i:i*a.Stride+n])
+		}
+
+		// Generate the first k columns of Q.
+		q := cblas128.General{Rows: m, Cols: k, Stride: k, Data: make([]complex128, m*k)}
+		for i := 0; i < m; i++ {
+			copy(q.Data[i*k:i*k+k], a.Data[i*a.Stride:i*a.Stride+k])
+		}
+		work = make([]complex128, 1)
+		impl.Zungqr(m, k, k, q.Data, q.Stride, tau, work, -1)
+		work = make([]complex128, int(real(work[0])))
+		impl.Zungqr(m, k, k, q.Data, q.Stride, tau, work, len(work))
+
+		if !hasOrthonormalColumnsZ(q, 1e-13) {
+			t.Errorf("%v: Q does not have orthonormal columns", prefix)
+		}
+		qr := zmul(blas.NoTrans, blas.NoTrans, q, r)
+		if !zequalApproxGeneral(qr, aCopy, 1e-12) {
+			t.Errorf("%v: Q*R does not equal A", prefix)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zgesvder interface {
+	Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool)
+}
+
+func ZgesvdTest(t *testing.T, impl Zgesvder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace} {
+		for _, test := range []struct {
+			m, n, lda int
+		}{
+			{1, 1, 0},
+			{1, 5, 0},
+			{5, 1, 0},
+			{5, 5, 0},
+			{10, 4, 0},
+			{4, 10, 0},
+			{30, 20, 0},
+			{20, 30, 0},
+			{10, 4, 12},
+			{4, 10, 12},
+		} {
+			m := test.m
+			n := test.n
+			minmn := min(m, n)
+			a := randomZGeneral(m, n, test.lda, rnd)
+			aCopy := cloneZGeneral(a)
+			prefix := fmt.Sprintf("job=%c,m=%d,n=%d,lda=%d", job, m, n, a.Stride)
+
+			ucol, vrow := m, n
+			if job == lapack.SVDInPlace {
+				ucol, vrow = minmn, minmn
+			}
+			u := cblas128.General{Rows: m, Cols: ucol, Stride: ucol, Data: make([]complex128, m*ucol)}
+			vt := cblas128.General{Rows: vrow, Cols: n, Stride: n, Data: make([]complex128, vrow*n)}
+			s := make([]float64, minmn)
+			work := make([]complex128, 1)
+			rwork := make([]float64, 5*minmn+2*minmn*minmn)
+			impl.Zgesvd(job, job, m, n, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, -1, rwork)
+			work = make([]complex128, int(real(work[0])))
+			ok := impl.Zgesvd(job, job, m, n, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, len(work), rwork)
+			if !ok {
+				t.Errorf("%v: decomposition failed", prefix)
+				continue
+			}
+
+			for i := 1; i < minmn; i++ {
+				if s[i] > s[i-1] {
+					t.Errorf("%v: singular values not in decreasing order", prefix)
+					break
+				}
+			}
+			if !hasOrthonormalColumnsZ(u, 1e-12) {
+				t.Errorf("%v: U does not have orthonormal columns", prefix)
+			}
+			v := zmul(blas.ConjTrans, blas.NoTrans, vt, zeye(vrow, vrow))
+			if !hasOrthonormalColumnsZ(v, 1e-12) {
+				t.Errorf("%v: V^H does not have orthonormal rows", prefix)
+			}
+
+			// Check that A = U * Σ * V^H using the leading singular vectors.
+			us := cblas128.General{Rows: m, Cols: minmn, Stride: minmn, Data: make([]complex128, m*minmn)}
+			for i := 0; i < m; i++ {
+				for j := 0; j < minmn; j++ {
+					us.Data[i*minmn+j] = u.Data[i*u.Stride+j] * complex(s[j], 0)
+				}
+			}
+			vtk := cblas128.General{Rows: minmn, Cols: n, Stride: vt.Stride, Data: vt.Data}
+			usvt := zmul(blas.NoTrans, blas.NoTrans, us, vtk)
+			if !zequalApproxGeneral(usvt, aCopy, 1e-12) {
+				t.Errorf("%v: U*Σ*V^H does not equal A", prefix)
+			}
+
+			// Check that the singular values agree when no vectors
+			// are computed.
+			a = cloneZGeneral(aCopy)
+			sOnly := make([]float64, minmn)
+			ok = impl.Zgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a.Data, a.Stride, sOnly, nil, 1, nil, 1, work, len(work), rwork)
+			if !ok {
+				t.Errorf("%v: singular value computation failed", prefix)
+				continue
+			}
+			if !floats.EqualApprox(s, sOnly, 1e-12) {
+				t.Errorf("%v: singular values differ when computing singular vectors", prefix)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zgetrfer interface {
+	Zgetrf(m, n int, a []complex128, lda int, ipiv []int) bool
+}
+
+func ZgetrfTest(t *testing.T, impl Zgetrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{1, 1, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{100, 50, 0},
+		{50, 100, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{10, 10, 20},
+		{100, 50, 120},
+	} {
+		m := test.m
+		n := test.n
+		a := randomZGeneral(m, n, test.lda, rnd)
+		aCopy := cloneZGeneral(a)
+		mn := min(m, n)
+		ipiv := make([]int, mn)
+		for i := range ipiv {
+			ipiv[i] = rnd.Int()
+		}
+		ok := impl.Zgetrf(m, n, a.Data, a.Stride, ipiv)
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, a.Stride)
+		if !ok {
+			t.Errorf("%v: unexpected singular matrix", prefix)
+			continue
+		}
+
+		// Check that P * L * U = A.
+		l := cblas128.General{Rows: m, Cols: mn, Stride: mn, Data: make([]complex128, m*mn)}
+		u := cblas128.General{Rows: mn, Cols: n, Stride: n, Data: make([]complex128, mn*n)}
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				v := a.Data[i*a.Stride+j]
+				switch {
+				case i == j:
+					l.Data[i*l.Stride+i] = 1
+					u.Data[i*u.Stride+i] = v
+				case i > j:
+					l.Data[i*l.Stride+j] = v
+				default:
+					u.Data[i*u.Stride+j] = v
+				}
+			}
+		}
+		lu := zmul(blas.NoTrans, blas.NoTrans, l, u)
+		// Undo the row interchanges.
+		for i := mn - 1; i >= 0; i-- {
+			cblas128.Swap(n,
+				cblas128.Vector{Inc: 1, Data: lu.Data[i*lu.Stride:]},
+				cblas128.Vector{Inc: 1, Data: lu.Data[ipiv[i]*lu.Stride:]})
+		}
+		if !zequalApproxGeneral(lu, aCopy, 1e-12) {
+			t.Errorf("%v: P*L*U does not equal A", prefix)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgetrser interface {
+	Zgetrfer
+	Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int)
+}
+
+func ZgetrsTest(t *testing.T, impl Zgetrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{1, 1, 0, 0},
+			{3, 3, 0, 0},
+			{3, 5, 0, 0},
+			{5, 3, 0, 0},
+			{50, 10, 0, 0},
+			{3, 3, 10, 10},
+			{3, 5, 10, 10},
+			{50, 10, 60, 20},
+		} {
+			n := test.n
+			nrhs := test.nrhs
+			a := randomZGeneral(n, n, test.lda, rnd)
+			b := randomZGeneral(n, nrhs, test.ldb, rnd)
+			bCopy := cloneZGeneral(b)
+
+			// Construct op(A) before A is overwritten.
+			opA := cloneZGeneral(a)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					switch trans {
+					case blas.Trans:
+						opA.Data[i*opA.Stride+j] = a.Data[j*a.Stride+i]
+					case blas.ConjTrans:
+						opA.Data[i*opA.Stride+j] = cmplx.Conj(a.Data[j*a.Stride+i])
+					}
+				}
+			}
+
+			ipiv := make([]int, n)
+			impl.Zgetrf(n, n, a.Data, a.Stride, ipiv)
+			impl.Zgetrs(trans, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+
+			// Check that op(A) * X = B.
+			ax := zmul(blas.NoTrans, blas.NoTrans, opA, b)
+			if !zequalApproxGeneral(ax, bCopy, 1e-10) {
+				t.Errorf("trans=%v,n=%d,nrhs=%d,lda=%d,ldb=%d: unexpected solution",
+					trans, n, nrhs, a.Stride, b.Stride)
+			}
+		}
+	}
+}
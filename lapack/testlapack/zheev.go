@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zheever interface {
+	Zheev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) (ok bool)
+}
+
+func ZheevTest(t *testing.T, impl Zheever) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{1, 0},
+			{2, 0},
+			{5, 0},
+			{10, 0},
+			{50, 0},
+
+			{1, 5},
+			{2, 5},
+			{5, 10},
+			{10, 20},
+			{50, 60},
+		} {
+			n := test.n
+			a := randomZGeneral(n, n, test.lda, rnd)
+			// Construct the full Hermitian matrix from the uplo triangle.
+			orig := cblas128.General{Rows: n, Cols: n, Stride: n, Data: make([]complex128, n*n)}
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					var v complex128
+					switch {
+					case i == j:
+						v = complex(real(a.Data[i*a.Stride+i]), 0)
+					case (uplo == blas.Upper) == (j > i):
+						v = a.Data[i*a.Stride+j]
+					default:
+						v = cmplx.Conj(a.Data[j*a.Stride+i])
+					}
+					orig.Data[i*n+j] = v
+				}
+			}
+			prefix := fmt.Sprintf("uplo=%v,n=%d,lda=%d", uplo, n, a.Stride)
+
+			// Compute the eigenvalues only.
+			aCopy := cloneZGeneral(a)
+			wOnly := make([]float64, n)
+			work := make([]complex128, 1)
+			impl.Zheev(lapack.None, uplo, n, aCopy.Data, aCopy.Stride, wOnly, work, -1, nil)
+			work = make([]complex128, int(real(work[0])))
+			rwork := make([]float64, max(1, 3*n-2))
+			ok := impl.Zheev(lapack.None, uplo, n, aCopy.Data, aCopy.Stride, wOnly, work, len(work), rwork)
+			if !ok {
+				t.Errorf("%v: eigenvalue computation failed", prefix)
+				continue
+			}
+
+			w := make([]float64, n)
+			rwork = make([]float64, n*n+3*n-2)
+			ok = impl.Zheev(lapack.ComputeEV, uplo, n, a.Data, a.Stride, w, work, len(work), rwork)
+			if !ok {
+				t.Errorf("%v: eigendecomposition failed", prefix)
+				continue
+			}
+			if !floats.EqualApprox(w, wOnly, 1e-12) {
+				t.Errorf("%v: eigenvalues differ when computing eigenvectors", prefix)
+			}
+			for i := 1; i < n; i++ {
+				if w[i] < w[i-1] {
+					t.Errorf("%v: eigenvalues not in ascending order", prefix)
+					break
+				}
+			}
+
+			// Check that the eigenvectors are orthonormal and that
+			// A * Z = Z * Λ.
+			z := cblas128.General{Rows: n, Cols: n, Stride: a.Stride, Data: a.Data}
+			if !hasOrthonormalColumnsZ(z, 1e-12) {
+				t.Errorf("%v: eigenvectors are not orthonormal", prefix)
+			}
+			az := zmul(blas.NoTrans, blas.NoTrans, orig, z)
+			zl := cloneZGeneral(z)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					zl.Data[i*zl.Stride+j] *= complex(w[j], 0)
+				}
+			}
+			if !zequalApproxGeneral(az, zl, 1e-10) {
+				t.Errorf("%v: A*Z does not equal Z*Λ", prefix)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// BiCGStab implements the Bi-Conjugate Gradient Stabilized method with
+// right preconditioning for solving general systems.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.8 BiConjugate Gradient Stabilized
+//    (Bi-CGSTAB). In Templates for the Solution of Linear Systems: Building
+//    Blocks for Iterative Methods (2nd ed.) (pp. 24-25). Philadelphia, PA: SIAM.
+//    Retrieved from http://www.netlib.org/templates/templates.pdf
+type BiCGStab struct {
+	first             bool
+	rho, alpha, omega float64
+
+	r, rt, p, v, t, phat, shat *mat.VecDense
+}
+
+// Init initializes the method for solving the system described by ctx.
+func (b *BiCGStab) Init(ctx *Context) {
+	n := ctx.X.Len()
+	b.first = true
+	b.r = ctx.Residual
+	b.rt = reuseVec(b.rt, n)
+	b.rt.CopyVec(b.r)
+	b.p = reuseVec(b.p, n)
+	b.v = reuseVec(b.v, n)
+	b.t = reuseVec(b.t, n)
+	b.phat = reuseVec(b.phat, n)
+	b.shat = reuseVec(b.shat, n)
+}
+
+// Iterate performs a single iteration of BiCGStab.
+func (b *BiCGStab) Iterate(ctx *Context) error {
+	rho := mat.Dot(b.rt, b.r)
+	if rho == 0 {
+		return ErrBreakdown
+	}
+	if b.first {
+		b.p.CopyVec(b.r)
+		b.first = false
+	} else {
+		beta := (rho / b.rho) * (b.alpha / b.omega)
+		b.p.AddScaledVec(b.p, -b.omega, b.v)
+		b.p.AddScaledVec(b.r, beta, b.p)
+	}
+	b.rho = rho
+
+	err := ctx.PreconSolve(b.phat, b.p)
+	if err != nil {
+		return err
+	}
+	ctx.MulVec(b.v, b.phat)
+	rtv := mat.Dot(b.rt, b.v)
+	if rtv == 0 {
+		return ErrBreakdown
+	}
+	b.alpha = rho / rtv
+
+	// Form s in the residual storage.
+	s := b.r
+	s.AddScaledVec(b.r, -b.alpha, b.v)
+	ctx.X.AddScaledVec(ctx.X, b.alpha, b.phat)
+	ctx.ResidualNorm = mat.Norm(s, 2)
+	if ctx.ResidualNorm <= ctx.Tolerance {
+		return nil
+	}
+
+	err = ctx.PreconSolve(b.shat, s)
+	if err != nil {
+		return err
+	}
+	ctx.MulVec(b.t, b.shat)
+	tt := mat.Dot(b.t, b.t)
+	if tt == 0 {
+		return ErrBreakdown
+	}
+	b.omega = mat.Dot(b.t, s) / tt
+	if b.omega == 0 {
+		return ErrBreakdown
+	}
+	ctx.X.AddScaledVec(ctx.X, b.omega, b.shat)
+	b.r.AddScaledVec(s, -b.omega, b.t)
+	ctx.ResidualNorm = mat.Norm(b.r, 2)
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// CG implements the preconditioned Conjugate Gradient method for solving
+// systems with a symmetric positive definite matrix. The preconditioner
+// must also be symmetric positive definite.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.1 Conjugate Gradient Method (CG).
+//    In Templates for the Solution of Linear Systems: Building Blocks for
+//    Iterative Methods (2nd ed.) (pp. 12-15). Philadelphia, PA: SIAM.
+//    Retrieved from http://www.netlib.org/templates/templates.pdf
+type CG struct {
+	first bool
+	rz    float64
+
+	r, z, p, ap *mat.VecDense
+}
+
+// Init initializes the method for solving the system described by ctx.
+func (cg *CG) Init(ctx *Context) {
+	n := ctx.X.Len()
+	cg.first = true
+	cg.r = ctx.Residual
+	cg.z = reuseVec(cg.z, n)
+	cg.p = reuseVec(cg.p, n)
+	cg.ap = reuseVec(cg.ap, n)
+}
+
+// Iterate performs a single iteration of CG.
+func (cg *CG) Iterate(ctx *Context) error {
+	err := ctx.PreconSolve(cg.z, cg.r)
+	if err != nil {
+		return err
+	}
+	rz := mat.Dot(cg.r, cg.z)
+	if cg.first {
+		cg.p.CopyVec(cg.z)
+		cg.first = false
+	} else {
+		beta := rz / cg.rz
+		cg.p.AddScaledVec(cg.z, beta, cg.p)
+	}
+	cg.rz = rz
+
+	ctx.MulVec(cg.ap, cg.p)
+	pap := mat.Dot(cg.p, cg.ap)
+	if pap <= 0 {
+		// The matrix is not positive definite.
+		return ErrBreakdown
+	}
+	alpha := rz / pap
+	ctx.X.AddScaledVec(ctx.X, alpha, cg.p)
+	cg.r.AddScaledVec(cg.r, -alpha, cg.ap)
+	ctx.ResidualNorm = mat.Norm(cg.r, 2)
+	return nil
+}
+
+// reuseVec returns a vector of length n, using v if it
+// has the correct length, otherwise allocating a new vector.
+func reuseVec(v *mat.VecDense, n int) *mat.VecDense {
+	if v == nil || v.Len() != n {
+		return mat.NewVecDense(n, nil)
+	}
+	return v
+}
@@ -0,0 +1,13 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linsolve provides iterative methods for solving linear systems
+// A*x = b, where the n×n matrix A is available only through matrix-vector
+// products.
+//
+// The methods implemented are the Krylov subspace methods CG, for symmetric
+// positive definite systems, MINRES, for symmetric indefinite systems, and
+// GMRES and BiCGStab, for general systems. Convergence may be accelerated
+// by a Preconditioner.
+package linsolve // import "gonum.org/v1/gonum/linsolve"
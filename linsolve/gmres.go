@@ -0,0 +1,179 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// defaultRestart is the GMRES restart parameter used when
+// GMRES.Restart is zero.
+const defaultRestart = 30
+
+// GMRES implements the restarted Generalized Minimum Residual method for
+// solving general systems. Preconditioning is applied on the right so
+// the residual norm estimate is the norm of the true residual.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.4 Generalized Minimal Residual
+//    (GMRES). In Templates for the Solution of Linear Systems: Building Blocks
+//    for Iterative Methods (2nd ed.) (pp. 17-19). Philadelphia, PA: SIAM.
+//    Retrieved from http://www.netlib.org/templates/templates.pdf
+//  - Saad, Y. (2003). Iterative Methods for Sparse Linear Systems (2nd ed.).
+//    Philadelphia, PA: SIAM. Section 9.3.2.
+type GMRES struct {
+	// Restart is the number of iterations between restarts. If
+	// Restart is zero, min(30, n) is used. Restart must not be
+	// negative.
+	Restart int
+
+	m int // Restart parameter in use.
+	j int // Current dimension of the Krylov subspace.
+
+	x0 *mat.VecDense   // Iterate at the start of the restart cycle.
+	v  []*mat.VecDense // Orthonormal basis of the Krylov subspace.
+	z  []*mat.VecDense // Preconditioned basis vectors.
+	w  *mat.VecDense
+
+	h      [][]float64 // Columns of the upper Hessenberg matrix.
+	cs, sn []float64   // Givens rotations applied to h.
+	g      []float64   // Rotated right-hand side of the least squares problem.
+	y      []float64
+}
+
+// Init initializes the method for solving the system described by ctx.
+func (g *GMRES) Init(ctx *Context) {
+	if g.Restart < 0 {
+		panic("linsolve: negative GMRES restart")
+	}
+	n := ctx.X.Len()
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = defaultRestart
+	}
+	g.m = min(g.m, n)
+
+	g.x0 = reuseVec(g.x0, n)
+	g.w = reuseVec(g.w, n)
+	g.v = reuseVecs(g.v, g.m+1, n)
+	g.z = reuseVecs(g.z, g.m, n)
+	if len(g.h) != g.m {
+		g.h = make([][]float64, g.m)
+		for j := range g.h {
+			g.h[j] = make([]float64, g.m+1)
+		}
+	}
+	g.cs = reuseFloats(g.cs, g.m)
+	g.sn = reuseFloats(g.sn, g.m)
+	g.g = reuseFloats(g.g, g.m+1)
+	g.y = reuseFloats(g.y, g.m)
+
+	g.start(ctx, ctx.Residual)
+}
+
+// start begins a new restart cycle from the current iterate
+// with the residual r.
+func (g *GMRES) start(ctx *Context, r *mat.VecDense) {
+	g.j = 0
+	g.x0.CopyVec(ctx.X)
+	beta := mat.Norm(r, 2)
+	g.v[0].ScaleVec(1/beta, r)
+	for i := range g.g {
+		g.g[i] = 0
+	}
+	g.g[0] = beta
+}
+
+// Iterate performs a single iteration of GMRES.
+func (g *GMRES) Iterate(ctx *Context) error {
+	if g.j == g.m {
+		// Restart from the current iterate.
+		ctx.MulVec(g.w, ctx.X)
+		g.w.SubVec(ctx.B, g.w)
+		g.start(ctx, g.w)
+	}
+	j := g.j
+
+	// Extend the Krylov subspace using the Arnoldi
+	// process with modified Gram-Schmidt.
+	err := ctx.PreconSolve(g.z[j], g.v[j])
+	if err != nil {
+		return err
+	}
+	ctx.MulVec(g.w, g.z[j])
+	h := g.h[j]
+	for i := 0; i <= j; i++ {
+		h[i] = mat.Dot(g.w, g.v[i])
+		g.w.AddScaledVec(g.w, -h[i], g.v[i])
+	}
+	h[j+1] = mat.Norm(g.w, 2)
+	if h[j+1] != 0 {
+		g.v[j+1].ScaleVec(1/h[j+1], g.w)
+	}
+
+	// Apply the previous rotations to the new column
+	// and eliminate its subdiagonal element.
+	for i := 0; i < j; i++ {
+		h[i], h[i+1] = g.cs[i]*h[i]+g.sn[i]*h[i+1], -g.sn[i]*h[i]+g.cs[i]*h[i+1]
+	}
+	r := math.Hypot(h[j], h[j+1])
+	if r == 0 {
+		return ErrBreakdown
+	}
+	g.cs[j] = h[j] / r
+	g.sn[j] = h[j+1] / r
+	h[j] = r
+	h[j+1] = 0
+	g.g[j+1] = -g.sn[j] * g.g[j]
+	g.g[j] *= g.cs[j]
+	g.j++
+
+	// Form the current iterate by solving the upper
+	// triangular least squares system.
+	y := g.y[:g.j]
+	for i := g.j - 1; i >= 0; i-- {
+		s := g.g[i]
+		for k := i + 1; k < g.j; k++ {
+			s -= g.h[k][i] * y[k]
+		}
+		y[i] = s / g.h[i][i]
+	}
+	ctx.X.CopyVec(g.x0)
+	for i, v := range y {
+		ctx.X.AddScaledVec(ctx.X, v, g.z[i])
+	}
+	ctx.ResidualNorm = math.Abs(g.g[g.j])
+	return nil
+}
+
+// reuseVecs returns a slice of k vectors of length n, using vs
+// where possible.
+func reuseVecs(vs []*mat.VecDense, k, n int) []*mat.VecDense {
+	if len(vs) != k {
+		vs = make([]*mat.VecDense, k)
+	}
+	for i := range vs {
+		vs[i] = reuseVec(vs[i], n)
+	}
+	return vs
+}
+
+// reuseFloats returns a float64 slice of length n, using f
+// if it has the correct length.
+func reuseFloats(f []float64, n int) []float64 {
+	if len(f) != n {
+		return make([]float64, n)
+	}
+	return f
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
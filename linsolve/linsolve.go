@@ -0,0 +1,239 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	// ErrIterationLimit is returned when the maximum number of
+	// iterations is reached before the tolerance is satisfied.
+	ErrIterationLimit = errors.New("linsolve: iteration limit reached")
+
+	// ErrBreakdown is returned when a method cannot continue
+	// because of a breakdown in its recurrence.
+	ErrBreakdown = errors.New("linsolve: breakdown")
+)
+
+// defaultTolerance is the relative residual tolerance used
+// when Settings.Tolerance is zero.
+const defaultTolerance = 1e-8
+
+// MulVecToer represents a square matrix A by means of a matrix-vector
+// multiplication.
+type MulVecToer interface {
+	// MulVecTo computes A*x and stores the result into dst.
+	MulVecTo(dst, x *mat.VecDense)
+}
+
+// MulVecFunc is a function that computes the product of a square matrix
+// and x, storing the result into dst.
+type MulVecFunc func(dst, x *mat.VecDense)
+
+// MulVecTo calls fn(dst, x).
+func (fn MulVecFunc) MulVecTo(dst, x *mat.VecDense) {
+	fn(dst, x)
+}
+
+// Matrix is a MulVecToer backed by a mat.Matrix.
+type Matrix struct {
+	mat.Matrix
+}
+
+// MulVecTo computes A*x and stores the result into dst.
+func (m Matrix) MulVecTo(dst, x *mat.VecDense) {
+	dst.MulVec(m.Matrix, x)
+}
+
+// Settings holds settings for solving a linear system.
+type Settings struct {
+	// InitX holds the initial guess. If it is nil,
+	// the zero vector is used. InitX is not modified.
+	InitX *mat.VecDense
+
+	// Tolerance specifies the tolerance on the residual norm
+	// relative to the norm of the right-hand side. The iteration
+	// stops when the method's estimate of |b - A*x| is at most
+	// Tolerance * |b|. If Tolerance is zero, a default value of
+	// 1e-8 is used. Tolerance must be less than one.
+	Tolerance float64
+
+	// MaxIterations is the limit on the number of iterations.
+	// If it is zero, a default value of 4 times the dimension
+	// of the system is used.
+	MaxIterations int
+
+	// Preconditioner is used to accelerate convergence.
+	// If it is nil, no preconditioning is performed.
+	Preconditioner Preconditioner
+}
+
+// Result holds the result of an iterative solve.
+type Result struct {
+	// X is the approximate solution.
+	X mat.VecDense
+
+	// ResidualNorm is the method's estimate of the norm of
+	// the residual b - A*X at the last iteration.
+	ResidualNorm float64
+
+	// History holds the residual norm estimate before the first
+	// iteration and after each iteration.
+	History []float64
+
+	Stats
+}
+
+// Stats holds statistics about an iterative solve.
+type Stats struct {
+	// Iterations is the number of iterations performed.
+	Iterations int
+	// MulVec is the number of matrix-vector products.
+	MulVec int
+	// PreconSolve is the number of preconditioner solves.
+	PreconSolve int
+}
+
+// Method is an iterative method for solving a linear system.
+//
+// Init is called once before the first iteration and Iterate is called
+// for each iteration. After each call to Iterate, the X field of the
+// Context must hold the current estimate of the solution and the
+// ResidualNorm field must hold the method's estimate of the residual
+// norm for that estimate.
+type Method interface {
+	// Init initializes the method for solving the system described
+	// by ctx. The X and Residual fields of ctx hold the initial guess
+	// and the corresponding residual.
+	Init(ctx *Context)
+
+	// Iterate performs a single iteration of the method.
+	Iterate(ctx *Context) error
+}
+
+// Context provides a Method with access to the linear system and the
+// current state of the solve.
+type Context struct {
+	// X is the current estimate of the solution.
+	X *mat.VecDense
+
+	// B is the right-hand side of the system.
+	// It must not be modified.
+	B *mat.VecDense
+
+	// Residual holds b - A*x for the initial guess when Init is
+	// called. Methods may use it as storage after initialization.
+	Residual *mat.VecDense
+
+	// ResidualNorm is the method's estimate of the norm of
+	// the residual for X.
+	ResidualNorm float64
+
+	// Tolerance is the absolute tolerance on the residual norm.
+	Tolerance float64
+
+	a     MulVecToer
+	p     Preconditioner
+	stats Stats
+}
+
+// MulVec computes A*x and stores the result into dst.
+func (ctx *Context) MulVec(dst, x *mat.VecDense) {
+	ctx.stats.MulVec++
+	ctx.a.MulVecTo(dst, x)
+}
+
+// PreconSolve solves M*dst = rhs, where M is the preconditioner.
+func (ctx *Context) PreconSolve(dst, rhs *mat.VecDense) error {
+	ctx.stats.PreconSolve++
+	return ctx.p.PreconSolve(dst, rhs)
+}
+
+// Iterative finds an approximate solution of the n×n system A*x = b using
+// the given method, where the matrix A is represented by a. If method is
+// nil, GMRES is used. If settings is nil, the default settings are used.
+//
+// Iterative returns the result and a nil error if the tolerance was met.
+// If the iteration limit is reached, ErrIterationLimit is returned with the
+// last iterate. Iterative will panic if the dimensions of the inputs do not
+// match.
+func Iterative(a MulVecToer, b *mat.VecDense, method Method, settings *Settings) (*Result, error) {
+	n := b.Len()
+	if method == nil {
+		method = &GMRES{}
+	}
+	var s Settings
+	if settings != nil {
+		s = *settings
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.Tolerance < 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * n
+	}
+	if s.MaxIterations < 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	if s.Preconditioner == nil {
+		s.Preconditioner = NoPreconditioner{}
+	}
+
+	var result Result
+	if s.InitX != nil {
+		if s.InitX.Len() != n {
+			panic("linsolve: mismatched initial guess length")
+		}
+		result.X.CloneVec(s.InitX)
+	} else {
+		result.X.CloneVec(mat.NewVecDense(n, nil))
+	}
+
+	ctx := &Context{
+		X:        &result.X,
+		B:        b,
+		Residual: mat.NewVecDense(n, nil),
+		a:        a,
+		p:        s.Preconditioner,
+	}
+	ctx.MulVec(ctx.Residual, ctx.X)
+	ctx.Residual.SubVec(b, ctx.Residual)
+	ctx.ResidualNorm = mat.Norm(ctx.Residual, 2)
+	ctx.Tolerance = s.Tolerance * mat.Norm(b, 2)
+	result.History = append(result.History, ctx.ResidualNorm)
+
+	var err error
+	if ctx.ResidualNorm > ctx.Tolerance {
+		method.Init(ctx)
+		err = ErrIterationLimit
+		for ctx.stats.Iterations < s.MaxIterations {
+			ctx.stats.Iterations++
+			iterErr := method.Iterate(ctx)
+			result.History = append(result.History, ctx.ResidualNorm)
+			if iterErr != nil {
+				err = iterErr
+				break
+			}
+			if math.IsNaN(ctx.ResidualNorm) {
+				err = ErrBreakdown
+				break
+			}
+			if ctx.ResidualNorm <= ctx.Tolerance {
+				err = nil
+				break
+			}
+		}
+	}
+	result.ResidualNorm = ctx.ResidualNorm
+	result.Stats = ctx.stats
+	return &result, err
+}
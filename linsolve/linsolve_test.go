@@ -0,0 +1,290 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplacian2D returns the matrix of the five-point finite difference
+// discretization of the negative Laplacian on a k×k grid, with convection
+// terms scaled by c making the matrix non-symmetric when c is not zero.
+func laplacian2D(k int, c float64) *mat.CSR {
+	n := k * k
+	m := mat.NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			row := i*k + j
+			m.Append(row, row, 4)
+			if i > 0 {
+				m.Append(row, row-k, -1-c)
+			}
+			if i < k-1 {
+				m.Append(row, row+k, -1+c)
+			}
+			if j > 0 {
+				m.Append(row, row-1, -1-c)
+			}
+			if j < k-1 {
+				m.Append(row, row+1, -1+c)
+			}
+		}
+	}
+	return m.ToCSR()
+}
+
+// symIndefinite returns a random symmetric indefinite n×n matrix.
+func symIndefinite(n int, rnd *rand.Rand) *mat.SymDense {
+	a := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		d := float64(i + 1)
+		if i%3 == 0 {
+			d = -d
+		}
+		a.SetSym(i, i, d)
+		for j := i + 1; j < n; j++ {
+			a.SetSym(i, j, 0.1*rnd.NormFloat64())
+		}
+	}
+	return a
+}
+
+type testSystem struct {
+	name      string
+	a         mat.Matrix
+	symmetric bool
+	spd       bool
+}
+
+func testSystems() []testSystem {
+	rnd := rand.New(rand.NewSource(1))
+	return []testSystem{
+		{name: "Laplacian 1×1", a: laplacian2D(1, 0), symmetric: true, spd: true},
+		{name: "Laplacian 10×10", a: laplacian2D(10, 0), symmetric: true, spd: true},
+		{name: "Laplacian 20×20", a: laplacian2D(20, 0), symmetric: true, spd: true},
+		{name: "convection-diffusion 10×10", a: laplacian2D(10, 0.3)},
+		{name: "convection-diffusion 15×15", a: laplacian2D(15, 0.6)},
+		{name: "symmetric indefinite 30", a: symIndefinite(30, rnd), symmetric: true},
+	}
+}
+
+func TestIterative(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, sys := range testSystems() {
+		n, _ := sys.a.Dims()
+		want := mat.NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			want.SetVec(i, rnd.NormFloat64())
+		}
+		var b mat.VecDense
+		b.MulVec(sys.a, want)
+
+		var precons []Preconditioner
+		precons = append(precons, nil)
+		jac, err := NewJacobi(sys.a)
+		if err != nil {
+			t.Fatalf("unexpected error for Jacobi preconditioner of %s: %v", sys.name, err)
+		}
+		precons = append(precons, jac)
+		ilu, err := NewIncompleteLU(sys.a)
+		if err != nil {
+			t.Fatalf("unexpected error for ILU preconditioner of %s: %v", sys.name, err)
+		}
+		if !sys.spd {
+			precons = append(precons, ilu)
+		}
+		if sys.spd {
+			ic, err := NewIncompleteCholesky(sys.a)
+			if err != nil {
+				t.Fatalf("unexpected error for IC preconditioner of %s: %v", sys.name, err)
+			}
+			precons = append(precons, ic)
+		}
+
+		for _, method := range []Method{&CG{}, &MINRES{}, &GMRES{}, &GMRES{Restart: 5}, &BiCGStab{}} {
+			switch m := method.(type) {
+			case *CG:
+				if !sys.spd {
+					continue
+				}
+			case *MINRES:
+				if !sys.symmetric {
+					continue
+				}
+			case *GMRES:
+				if m.Restart != 0 && sys.symmetric && !sys.spd {
+					// Restarted GMRES may stagnate for indefinite matrices.
+					continue
+				}
+			}
+			for _, p := range precons {
+				if _, ok := method.(*MINRES); ok && p != nil && !sys.spd {
+					// MINRES requires a positive definite preconditioner.
+					continue
+				}
+				name := fmt.Sprintf("%s %T %T", sys.name, method, p)
+				settings := &Settings{
+					Tolerance:      tol,
+					MaxIterations:  10 * n,
+					Preconditioner: p,
+				}
+				result, err := Iterative(Matrix{sys.a}, &b, method, settings)
+				if err != nil {
+					t.Errorf("%s: unexpected error: %v", name, err)
+					continue
+				}
+				if len(result.History) != result.Iterations+1 {
+					t.Errorf("%s: unexpected history length: got:%d want:%d",
+						name, len(result.History), result.Iterations+1)
+				}
+				if result.History[len(result.History)-1] != result.ResidualNorm {
+					t.Errorf("%s: last history entry does not match residual norm", name)
+				}
+				var r mat.VecDense
+				r.MulVec(sys.a, &result.X)
+				r.SubVec(&b, &r)
+				rnorm := mat.Norm(&r, 2)
+				if rnorm > 100*tol*mat.Norm(&b, 2) {
+					t.Errorf("%s: residual too large: got:%v want:<=%v",
+						name, rnorm, 100*tol*mat.Norm(&b, 2))
+				}
+				if !mat.EqualApprox(&result.X, want, 1e-6) {
+					t.Errorf("%s: unexpected solution", name)
+				}
+			}
+		}
+	}
+}
+
+func TestIterativeSettings(t *testing.T) {
+	a := laplacian2D(8, 0)
+	n, _ := a.Dims()
+	want := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		want.SetVec(i, float64(i))
+	}
+	var b mat.VecDense
+	b.MulVec(a, want)
+
+	// An exact initial guess must not require any iterations.
+	result, err := Iterative(Matrix{a}, &b, &CG{}, &Settings{InitX: want})
+	if err != nil {
+		t.Errorf("unexpected error for exact initial guess: %v", err)
+	}
+	if result.Iterations != 0 {
+		t.Errorf("unexpected number of iterations for exact initial guess: got:%d want:0", result.Iterations)
+	}
+
+	// The iteration limit must be respected.
+	result, err = Iterative(Matrix{a}, &b, &CG{}, &Settings{MaxIterations: 3})
+	if err != ErrIterationLimit {
+		t.Errorf("unexpected error for iteration limit: got:%v want:%v", err, ErrIterationLimit)
+	}
+	if result.Iterations != 3 {
+		t.Errorf("unexpected number of iterations: got:%d want:3", result.Iterations)
+	}
+
+	// A matrix-vector product function is counted.
+	var calls int
+	fn := MulVecFunc(func(dst, x *mat.VecDense) {
+		calls++
+		dst.MulVec(a, x)
+	})
+	result, err = Iterative(fn, &b, nil, nil)
+	if err != nil {
+		t.Errorf("unexpected error for default method: %v", err)
+	}
+	if calls != result.MulVec {
+		t.Errorf("mismatched matrix-vector product count: got:%d want:%d", result.MulVec, calls)
+	}
+	if !mat.EqualApprox(&result.X, want, 1e-6) {
+		t.Errorf("unexpected solution for default method")
+	}
+
+	// CG must report a breakdown for an indefinite matrix.
+	s := symIndefinite(10, rand.New(rand.NewSource(1)))
+	b.Reset()
+	b.MulVec(s, mat.NewVecDense(10, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	_, err = Iterative(Matrix{s}, &b, &CG{}, nil)
+	if err != ErrBreakdown {
+		t.Errorf("unexpected error for CG on indefinite matrix: got:%v want:%v", err, ErrBreakdown)
+	}
+}
+
+func TestPreconditioners(t *testing.T) {
+	a := laplacian2D(6, 0)
+	n, _ := a.Dims()
+	var dense mat.Dense
+	dense.Clone(a)
+
+	x := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, float64(i%7)-3)
+	}
+	// For a tridiagonal matrix, the incomplete
+	// factorizations are exact.
+	tri := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		tri.Set(i, i, 4)
+		if i > 0 {
+			tri.Set(i, i-1, -1)
+			tri.Set(i-1, i, -1)
+		}
+	}
+	var b mat.VecDense
+	b.MulVec(tri, x)
+
+	ilu, err := NewIncompleteLU(tri)
+	if err != nil {
+		t.Fatalf("unexpected error for ILU: %v", err)
+	}
+	ic, err := NewIncompleteCholesky(tri)
+	if err != nil {
+		t.Fatalf("unexpected error for IC: %v", err)
+	}
+	for _, p := range []Preconditioner{ilu, ic} {
+		got := mat.NewVecDense(n, nil)
+		err = p.PreconSolve(got, &b)
+		if err != nil {
+			t.Errorf("unexpected error for %T solve: %v", p, err)
+		}
+		if !mat.EqualApprox(got, x, 1e-12) {
+			t.Errorf("unexpected solve result for %T on tridiagonal matrix", p)
+		}
+	}
+
+	// The sparse and dense pattern paths must agree.
+	iluSparse, err := NewIncompleteLU(a)
+	if err != nil {
+		t.Fatalf("unexpected error for sparse ILU: %v", err)
+	}
+	iluDense, err := NewIncompleteLU(&dense)
+	if err != nil {
+		t.Fatalf("unexpected error for dense ILU: %v", err)
+	}
+	b.Reset()
+	b.MulVec(a, x)
+	got := mat.NewVecDense(n, nil)
+	want := mat.NewVecDense(n, nil)
+	iluSparse.PreconSolve(got, &b)
+	iluDense.PreconSolve(want, &b)
+	if !mat.EqualApprox(got, want, 1e-14) {
+		t.Errorf("mismatch between sparse and dense ILU patterns")
+	}
+
+	_, err = NewIncompleteCholesky(symIndefinite(5, rand.New(rand.NewSource(1))))
+	if err != ErrNotPositiveDefinite {
+		t.Errorf("unexpected error for IC of indefinite matrix: got:%v want:%v", err, ErrNotPositiveDefinite)
+	}
+	_, err = NewJacobi(mat.NewDense(2, 2, []float64{0, 1, 1, 0}))
+	if err != ErrZeroPivot {
+		t.Errorf("unexpected error for Jacobi with zero diagonal: got:%v want:%v", err, ErrZeroPivot)
+	}
+}
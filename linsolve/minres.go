@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MINRES implements the Minimum Residual method for solving systems with a
+// symmetric, possibly indefinite, matrix. The preconditioner must be
+// symmetric positive definite. When a preconditioner is used, the residual
+// norm estimate is the norm of the residual r in the M⁻¹-norm, sqrt(rᵀ M⁻¹ r).
+//
+// References:
+//  - Paige, C. C., & Saunders, M. A. (1975). Solution of sparse indefinite
+//    systems of linear equations. SIAM Journal on Numerical Analysis, 12(4),
+//    617-629. https://doi.org/10.1137/0712047
+//  - Choi, S.-C. T. (2006). Iterative Methods for Singular Linear Equations
+//    and Least-Squares Problems (Doctoral thesis). Stanford University.
+type MINRES struct {
+	first bool
+
+	beta, oldBeta float64
+	dbar, eps     float64
+	phibar        float64
+	cs, sn        float64
+
+	r1, r2, y, v, w, w1, w2 *mat.VecDense
+}
+
+// Init initializes the method for solving the system described by ctx.
+func (m *MINRES) Init(ctx *Context) {
+	n := ctx.X.Len()
+	m.first = true
+	m.r1 = reuseVec(m.r1, n)
+	m.r2 = reuseVec(m.r2, n)
+	m.y = reuseVec(m.y, n)
+	m.v = reuseVec(m.v, n)
+	m.w = reuseVec(m.w, n)
+	m.w1 = reuseVec(m.w1, n)
+	m.w2 = reuseVec(m.w2, n)
+	m.r1.CopyVec(ctx.Residual)
+	m.r2.CopyVec(ctx.Residual)
+	m.w.ScaleVec(0, m.w)
+	m.w2.ScaleVec(0, m.w2)
+
+	m.oldBeta = 0
+	m.dbar = 0
+	m.eps = 0
+	m.cs = -1
+	m.sn = 0
+}
+
+// Iterate performs a single iteration of MINRES.
+func (m *MINRES) Iterate(ctx *Context) error {
+	if m.first {
+		err := ctx.PreconSolve(m.y, m.r1)
+		if err != nil {
+			return err
+		}
+		ry := mat.Dot(m.r1, m.y)
+		if ry <= 0 {
+			// The preconditioner is not positive definite.
+			return ErrBreakdown
+		}
+		m.beta = math.Sqrt(ry)
+		m.phibar = m.beta
+	}
+
+	// Lanczos step.
+	m.v.ScaleVec(1/m.beta, m.y)
+	ctx.MulVec(m.y, m.v)
+	if !m.first {
+		m.y.AddScaledVec(m.y, -m.beta/m.oldBeta, m.r1)
+	}
+	m.first = false
+	alpha := mat.Dot(m.v, m.y)
+	m.y.AddScaledVec(m.y, -alpha/m.beta, m.r2)
+	m.r1.CopyVec(m.r2)
+	m.r2.CopyVec(m.y)
+	err := ctx.PreconSolve(m.y, m.r2)
+	if err != nil {
+		return err
+	}
+	m.oldBeta = m.beta
+	ry := mat.Dot(m.r2, m.y)
+	if ry < 0 {
+		return ErrBreakdown
+	}
+	m.beta = math.Sqrt(ry)
+
+	// Apply the previous rotation and compute
+	// the rotation for the new column.
+	oldEps := m.eps
+	delta := m.cs*m.dbar + m.sn*alpha
+	gbar := m.sn*m.dbar - m.cs*alpha
+	m.eps = m.sn * m.beta
+	m.dbar = -m.cs * m.beta
+	gamma := math.Hypot(gbar, m.beta)
+	if gamma == 0 {
+		return ErrBreakdown
+	}
+	m.cs = gbar / gamma
+	m.sn = m.beta / gamma
+	phi := m.cs * m.phibar
+	m.phibar *= m.sn
+
+	// Update the solution.
+	m.w1, m.w2, m.w = m.w2, m.w, m.w1
+	m.w.AddScaledVec(m.v, -oldEps, m.w1)
+	m.w.AddScaledVec(m.w, -delta, m.w2)
+	m.w.ScaleVec(1/gamma, m.w)
+	ctx.X.AddScaledVec(ctx.X, phi, m.w)
+	ctx.ResidualNorm = m.phibar
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	// ErrZeroPivot is returned when a preconditioner cannot be
+	// constructed because of a zero pivot.
+	ErrZeroPivot = errors.New("linsolve: zero pivot")
+
+	// ErrNotPositiveDefinite is returned when an incomplete Cholesky
+	// preconditioner encounters a non-positive pivot.
+	ErrNotPositiveDefinite = errors.New("linsolve: incomplete factorization not positive definite")
+)
+
+// Preconditioner represents a matrix M that approximates the system matrix A
+// and for which systems are inexpensive to solve.
+type Preconditioner interface {
+	// PreconSolve solves M*dst = rhs for dst.
+	PreconSolve(dst, rhs *mat.VecDense) error
+}
+
+// NoPreconditioner is the identity preconditioner.
+type NoPreconditioner struct{}
+
+// PreconSolve copies rhs into dst.
+func (NoPreconditioner) PreconSolve(dst, rhs *mat.VecDense) error {
+	dst.CopyVec(rhs)
+	return nil
+}
+
+// Jacobi is a preconditioner using the diagonal of the system matrix.
+type Jacobi struct {
+	diag []float64
+}
+
+// NewJacobi returns a Jacobi preconditioner for the square matrix a. If a
+// diagonal element of a is zero, ErrZeroPivot is returned.
+func NewJacobi(a mat.Matrix) (*Jacobi, error) {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrSquare)
+	}
+	diag := make([]float64, r)
+	for i := range diag {
+		diag[i] = a.At(i, i)
+		if diag[i] == 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+	return &Jacobi{diag: diag}, nil
+}
+
+// PreconSolve solves D*dst = rhs for dst, where D is the diagonal
+// of the system matrix.
+func (j *Jacobi) PreconSolve(dst, rhs *mat.VecDense) error {
+	if rhs.Len() != len(j.diag) || dst.Len() != len(j.diag) {
+		panic(mat.ErrShape)
+	}
+	for i, d := range j.diag {
+		dst.SetVec(i, rhs.At(i, 0)/d)
+	}
+	return nil
+}
+
+// IncompleteLU is a preconditioner using the incomplete LU factorization
+// with no fill-in, ILU(0), of the system matrix. The factors have the same
+// sparsity pattern as the system matrix.
+type IncompleteLU struct {
+	lu   sparseRows
+	diag []int // Position of the diagonal element in each row.
+}
+
+// NewIncompleteLU returns an ILU(0) preconditioner for the square matrix a.
+// If a implements mat.RowNonZeroDoer, the sparsity pattern is obtained from
+// DoRowNonZero, otherwise all non-zero elements returned by At are used. If
+// a zero pivot is encountered, ErrZeroPivot is returned.
+func NewIncompleteLU(a mat.Matrix) (*IncompleteLU, error) {
+	lu := newSparseRows(a, false)
+	n := len(lu.ind)
+	diag := make([]int, n)
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		ind, val := lu.ind[i], lu.val[i]
+		for p, j := range ind {
+			pos[j] = p
+		}
+		diag[i] = -1
+		for p, k := range ind {
+			if k >= i {
+				if k == i {
+					diag[i] = p
+				}
+				break
+			}
+			// Eliminate element (i, k) using row k, only
+			// updating elements within the pattern of row i.
+			val[p] /= lu.val[k][diag[k]]
+			lik := val[p]
+			for q := diag[k] + 1; q < len(lu.ind[k]); q++ {
+				if pj := pos[lu.ind[k][q]]; pj >= 0 {
+					val[pj] -= lik * lu.val[k][q]
+				}
+			}
+		}
+		for _, j := range ind {
+			pos[j] = -1
+		}
+		if diag[i] < 0 || val[diag[i]] == 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+	return &IncompleteLU{lu: lu, diag: diag}, nil
+}
+
+// PreconSolve solves L*U*dst = rhs for dst, where L and U are the
+// incomplete LU factors.
+func (ilu *IncompleteLU) PreconSolve(dst, rhs *mat.VecDense) error {
+	n := len(ilu.diag)
+	if rhs.Len() != n || dst.Len() != n {
+		panic(mat.ErrShape)
+	}
+	if dst != rhs {
+		dst.CopyVec(rhs)
+	}
+	// Solve L*y = rhs where L is unit lower triangular.
+	for i := 0; i < n; i++ {
+		s := dst.At(i, 0)
+		for p := 0; p < ilu.diag[i]; p++ {
+			s -= ilu.lu.val[i][p] * dst.At(ilu.lu.ind[i][p], 0)
+		}
+		dst.SetVec(i, s)
+	}
+	// Solve U*dst = y.
+	for i := n - 1; i >= 0; i-- {
+		s := dst.At(i, 0)
+		ind, val := ilu.lu.ind[i], ilu.lu.val[i]
+		for p := ilu.diag[i] + 1; p < len(ind); p++ {
+			s -= val[p] * dst.At(ind[p], 0)
+		}
+		dst.SetVec(i, s/val[ilu.diag[i]])
+	}
+	return nil
+}
+
+// IncompleteCholesky is a preconditioner using the incomplete Cholesky
+// factorization with no fill-in, IC(0), of a symmetric positive definite
+// system matrix. The factor has the same sparsity pattern as the lower
+// triangle of the system matrix.
+type IncompleteCholesky struct {
+	l sparseRows // Rows of the lower triangular factor with the diagonal last.
+}
+
+// NewIncompleteCholesky returns an IC(0) preconditioner for the symmetric
+// matrix a. Only the lower triangle of a is used. If a implements
+// mat.RowNonZeroDoer, the sparsity pattern is obtained from DoRowNonZero,
+// otherwise all non-zero elements returned by At are used. If a non-positive
+// pivot is encountered, ErrNotPositiveDefinite is returned.
+func NewIncompleteCholesky(a mat.Matrix) (*IncompleteCholesky, error) {
+	l := newSparseRows(a, true)
+	for i := range l.ind {
+		ind, val := l.ind[i], l.val[i]
+		last := len(ind) - 1
+		if last < 0 || ind[last] != i {
+			return nil, ErrNotPositiveDefinite
+		}
+		for p, k := range ind[:last] {
+			// The elements of row k to the left of the
+			// diagonal are the first len(l.ind[k])-1.
+			kl := len(l.ind[k]) - 1
+			s := val[p] - sparseDot(ind[:p], val[:p], l.ind[k][:kl], l.val[k][:kl])
+			val[p] = s / l.val[k][kl]
+		}
+		d := val[last] - sparseDot(ind[:last], val[:last], ind[:last], val[:last])
+		if d <= 0 {
+			return nil, ErrNotPositiveDefinite
+		}
+		val[last] = math.Sqrt(d)
+	}
+	return &IncompleteCholesky{l: l}, nil
+}
+
+// PreconSolve solves L*Lᵀ*dst = rhs for dst, where L is the incomplete
+// Cholesky factor.
+func (ic *IncompleteCholesky) PreconSolve(dst, rhs *mat.VecDense) error {
+	n := len(ic.l.ind)
+	if rhs.Len() != n || dst.Len() != n {
+		panic(mat.ErrShape)
+	}
+	if dst != rhs {
+		dst.CopyVec(rhs)
+	}
+	// Solve L*y = rhs.
+	for i := 0; i < n; i++ {
+		ind, val := ic.l.ind[i], ic.l.val[i]
+		last := len(ind) - 1
+		s := dst.At(i, 0)
+		for p := 0; p < last; p++ {
+			s -= val[p] * dst.At(ind[p], 0)
+		}
+		dst.SetVec(i, s/val[last])
+	}
+	// Solve Lᵀ*dst = y by columns of Lᵀ.
+	for i := n - 1; i >= 0; i-- {
+		ind, val := ic.l.ind[i], ic.l.val[i]
+		last := len(ind) - 1
+		xi := dst.At(i, 0) / val[last]
+		dst.SetVec(i, xi)
+		for p := 0; p < last; p++ {
+			j := ind[p]
+			dst.SetVec(j, dst.At(j, 0)-val[p]*xi)
+		}
+	}
+	return nil
+}
+
+// sparseRows is a row-wise sparse matrix with sorted column indices.
+type sparseRows struct {
+	ind [][]int
+	val [][]float64
+}
+
+// newSparseRows returns the non-zero elements of the square matrix a in
+// row-wise sparse storage. If lower is true, only the elements on and
+// below the diagonal are stored.
+func newSparseRows(a mat.Matrix, lower bool) sparseRows {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrSquare)
+	}
+	s := sparseRows{
+		ind: make([][]int, r),
+		val: make([][]float64, r),
+	}
+	add := func(i, j int, v float64) {
+		if lower && j > i {
+			return
+		}
+		s.ind[i] = append(s.ind[i], j)
+		s.val[i] = append(s.val[i], v)
+	}
+	rnz, isSparse := a.(mat.RowNonZeroDoer)
+	for i := 0; i < r; i++ {
+		if isSparse {
+			rnz.DoRowNonZero(i, add)
+		} else {
+			for j := 0; j < c; j++ {
+				if v := a.At(i, j); v != 0 {
+					add(i, j, v)
+				}
+			}
+		}
+		s.sortRow(i)
+	}
+	return s
+}
+
+// sortRow sorts row i by column index, summing duplicate elements.
+func (s sparseRows) sortRow(i int) {
+	sort.Sort(byColumn{ind: s.ind[i], val: s.val[i]})
+	ind, val := s.ind[i], s.val[i]
+	var n int
+	for k := range ind {
+		if n > 0 && ind[n-1] == ind[k] {
+			val[n-1] += val[k]
+			continue
+		}
+		ind[n] = ind[k]
+		val[n] = val[k]
+		n++
+	}
+	s.ind[i] = ind[:n]
+	s.val[i] = val[:n]
+}
+
+// byColumn sorts the elements of a sparse row by column index.
+type byColumn struct {
+	ind []int
+	val []float64
+}
+
+func (b byColumn) Len() int           { return len(b.ind) }
+func (b byColumn) Less(i, j int) bool { return b.ind[i] < b.ind[j] }
+func (b byColumn) Swap(i, j int) {
+	b.ind[i], b.ind[j] = b.ind[j], b.ind[i]
+	b.val[i], b.val[j] = b.val[j], b.val[i]
+}
+
+// sparseDot returns the dot product of two sparse vectors with sorted indices.
+func sparseDot(xi []int, x []float64, yi []int, y []float64) float64 {
+	var sum float64
+	for p, q := 0, 0; p < len(xi) && q < len(yi); {
+		switch {
+		case xi[p] < yi[q]:
+			p++
+		case xi[p] > yi[q]:
+			q++
+		default:
+			sum += x[p] * y[q]
+			p++
+			q++
+		}
+	}
+	return sum
+}
@@ -0,0 +1,209 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// BandCholesky is a type for creating and using the Cholesky factorization of
+// a symmetric positive definite band matrix. The factor is held in band
+// storage, so the memory used is proportional to n*(k+1) for an n×n matrix
+// with k super-diagonals.
+//
+// BandCholesky methods may only be called on a value that has been
+// successfully initialized by a call to Factorize that has returned true.
+// Calls to methods of an unsuccessful Cholesky factorization will panic.
+type BandCholesky struct {
+	chol blas64.TriangularBand
+	cond float64
+}
+
+// Factorize calculates the Cholesky decomposition of the symmetric band
+// matrix a and returns whether the matrix is positive definite. If Factorize
+// returns false, the factorization must not be used.
+func (ch *BandCholesky) Factorize(a SymBanded) (ok bool) {
+	n := a.Symmetric()
+	k, _ := a.Bandwidth()
+	stride := k + 1
+	ch.chol = blas64.TriangularBand{
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+		N:      n,
+		K:      k,
+		Stride: stride,
+		Data:   use(ch.chol.Data, n*stride),
+	}
+
+	// Copy the upper triangle of the band of a and compute its norm.
+	// The 1-norm of a symmetric matrix is equal to its ∞-norm.
+	rb, isRaw := a.(RawSymBander)
+	var raw blas64.SymmetricBand
+	if isRaw {
+		raw = rb.RawSymBand()
+	}
+	work := getFloats(2*n, true)
+	defer putFloats(work)
+	colSum := work[:n]
+	for i := 0; i < n; i++ {
+		row := ch.chol.Data[i*stride : (i+1)*stride]
+		zero(row)
+		for j := i; j < min(n, i+k+1); j++ {
+			var v float64
+			switch {
+			case isRaw && raw.Uplo == blas.Upper:
+				v = raw.Data[i*raw.Stride+j-i]
+			case isRaw:
+				v = raw.Data[j*raw.Stride+raw.K+i-j]
+			default:
+				v = a.At(i, j)
+			}
+			row[j-i] = v
+			colSum[j] += math.Abs(v)
+			if j != i {
+				colSum[i] += math.Abs(v)
+			}
+		}
+	}
+	var anorm float64
+	for _, v := range colSum {
+		anorm = math.Max(anorm, v)
+	}
+
+	t, ok := lapack64.Pbtrf(blas64.SymmetricBand{
+		Uplo:   blas.Upper,
+		N:      n,
+		K:      k,
+		Stride: stride,
+		Data:   ch.chol.Data,
+	})
+	if !ok {
+		ch.Reset()
+		return false
+	}
+	iwork := getInts(n, false)
+	v := lapack64.Pbcon(t, anorm, work, iwork)
+	putInts(iwork)
+	ch.cond = 1 / v
+	return true
+}
+
+// Cond returns the condition number of the factorized matrix.
+func (ch *BandCholesky) Cond() float64 {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	return ch.cond
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of
+// a dimensionally restricted operation.
+func (ch *BandCholesky) Reset() {
+	ch.chol.N = 0
+	ch.chol.K = 0
+	ch.chol.Data = ch.chol.Data[:0]
+	ch.cond = math.Inf(1)
+}
+
+func (ch *BandCholesky) valid() bool {
+	return ch.chol.N != 0
+}
+
+// Size returns the dimension of the factorized matrix.
+func (ch *BandCholesky) Size() int {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	return ch.chol.N
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (ch *BandCholesky) Det() float64 {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	return math.Exp(ch.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been
+// factorized.
+func (ch *BandCholesky) LogDet() float64 {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	var det float64
+	for i := 0; i < ch.chol.N; i++ {
+		det += 2 * math.Log(ch.chol.Data[i*ch.chol.Stride])
+	}
+	return det
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented by
+// the Cholesky decomposition, placing the result in m.
+func (ch *BandCholesky) Solve(m *Dense, b Matrix) error {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	n := ch.chol.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(bm, bn)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	lapack64.Pbtrs(ch.chol, m.mat)
+	if ch.cond > ConditionTolerance {
+		return Condition(ch.cond)
+	}
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented by
+// the Cholesky decomposition, placing the result in v.
+func (ch *BandCholesky) SolveVec(v, b *VecDense) error {
+	if !ch.valid() {
+		panic(badCholesky)
+	}
+	n := ch.chol.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+
+	v.reuseAs(n)
+	var restore func()
+	if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+	v.CopyVec(b)
+	vMat := blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+	lapack64.Pbtrs(ch.chol, vMat)
+	if ch.cond > ConditionTolerance {
+		return Condition(ch.cond)
+	}
+	return nil
+}
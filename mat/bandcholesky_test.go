@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestBandCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, k int
+	}{
+		{1, 0},
+		{3, 0},
+		{3, 1},
+		{5, 2},
+		{10, 3},
+		{10, 9},
+		{30, 5},
+	} {
+		n, k := test.n, test.k
+		// Construct a diagonally dominant symmetric band matrix.
+		a := NewSymBandDense(n, k, nil)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < min(n, i+k+1); j++ {
+				a.SetSymBand(i, j, rnd.NormFloat64())
+			}
+		}
+		for i := 0; i < n; i++ {
+			a.SetSymBand(i, i, float64(2*k+1)+rnd.Float64())
+		}
+
+		var bch BandCholesky
+		ok := bch.Factorize(a)
+		if !ok {
+			t.Errorf("n=%d,k=%d: unexpected factorization failure", n, k)
+			continue
+		}
+		if bch.Size() != n {
+			t.Errorf("n=%d,k=%d: unexpected size: got:%d", n, k, bch.Size())
+		}
+		var ch Cholesky
+		ch.Factorize(a)
+		if !floats.EqualWithinAbsOrRel(bch.Det(), ch.Det(), 1e-10, 1e-10) {
+			t.Errorf("n=%d,k=%d: determinant mismatch: got:%v want:%v", n, k, bch.Det(), ch.Det())
+		}
+		if !floats.EqualWithinAbsOrRel(bch.LogDet(), ch.LogDet(), 1e-10, 1e-10) {
+			t.Errorf("n=%d,k=%d: log determinant mismatch: got:%v want:%v", n, k, bch.LogDet(), ch.LogDet())
+		}
+		if !floats.EqualWithinAbsOrRel(bch.Cond(), ch.Cond(), 1e-8, 1e-8) {
+			t.Errorf("n=%d,k=%d: condition number mismatch: got:%v want:%v", n, k, bch.Cond(), ch.Cond())
+		}
+
+		want := NewDense(n, 3, nil)
+		for i := range want.mat.Data {
+			want.mat.Data[i] = rnd.NormFloat64()
+		}
+		var b Dense
+		b.Mul(a, want)
+		var x Dense
+		err := bch.Solve(&x, &b)
+		if err != nil {
+			t.Errorf("n=%d,k=%d: unexpected error from Solve: %v", n, k, err)
+		}
+		if !EqualApprox(&x, want, 1e-10) {
+			t.Errorf("n=%d,k=%d: unexpected Solve result", n, k)
+		}
+		// In-place solve.
+		err = bch.Solve(&b, &b)
+		if err != nil {
+			t.Errorf("n=%d,k=%d: unexpected error from in-place Solve: %v", n, k, err)
+		}
+		if !EqualApprox(&b, want, 1e-10) {
+			t.Errorf("n=%d,k=%d: unexpected in-place Solve result", n, k)
+		}
+
+		wantVec := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			wantVec.SetVec(i, rnd.NormFloat64())
+		}
+		var bv VecDense
+		bv.MulVec(a, wantVec)
+		var xv VecDense
+		err = bch.SolveVec(&xv, &bv)
+		if err != nil {
+			t.Errorf("n=%d,k=%d: unexpected error from SolveVec: %v", n, k, err)
+		}
+		if !EqualApprox(&xv, wantVec, 1e-10) {
+			t.Errorf("n=%d,k=%d: unexpected SolveVec result", n, k)
+		}
+		err = bch.SolveVec(&bv, &bv)
+		if err != nil {
+			t.Errorf("n=%d,k=%d: unexpected error from in-place SolveVec: %v", n, k, err)
+		}
+		if !EqualApprox(&bv, wantVec, 1e-10) {
+			t.Errorf("n=%d,k=%d: unexpected in-place SolveVec result", n, k)
+		}
+	}
+}
+
+func TestBandCholeskyNotPD(t *testing.T) {
+	a := NewSymBandDense(3, 1, []float64{
+		1, 2,
+		1, 1,
+		1, 0,
+	})
+	var ch BandCholesky
+	if ch.Factorize(a) {
+		t.Errorf("unexpected success factorizing indefinite matrix")
+	}
+	if panicked, _ := panics(func() { ch.Det() }); !panicked {
+		t.Errorf("expected panic for failed factorization")
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// BandLU is a type for creating and using the LU factorization of a square
+// band matrix. The factors are held in band storage, so the memory used is
+// proportional to n*(2*kl+ku+1) for an n×n matrix with kl sub-diagonals and
+// ku super-diagonals.
+type BandLU struct {
+	lu    blas64.Band
+	pivot []int
+	cond  float64
+}
+
+// Factorize computes the LU factorization of the square band matrix a and
+// stores the result. The LU decomposition will complete regardless of the
+// singularity of a.
+//
+// The LU factorization is computed with pivoting, and so really the
+// decomposition is a PLU decomposition where P is a permutation matrix. The
+// upper triangular factor U has kl+ku super-diagonals.
+//
+// Factorize will panic if a is not square.
+func (lu *BandLU) Factorize(a Banded) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	n := r
+	kl, ku := a.Bandwidth()
+	stride := 2*kl + ku + 1
+	lu.lu = blas64.Band{
+		Rows:   n,
+		Cols:   n,
+		KL:     kl,
+		KU:     ku,
+		Stride: stride,
+		Data:   use(lu.lu.Data, n*stride),
+	}
+	if cap(lu.pivot) < n {
+		lu.pivot = make([]int, n)
+	}
+	lu.pivot = lu.pivot[:n]
+
+	// Copy the band of a and compute its norm. CondNorm
+	// is the maximum absolute row sum.
+	rb, isRaw := a.(RawBander)
+	var raw blas64.Band
+	if isRaw {
+		raw = rb.RawBand()
+	}
+	var anorm float64
+	for i := 0; i < n; i++ {
+		row := lu.lu.Data[i*stride : (i+1)*stride]
+		zero(row)
+		var sum float64
+		for j := max(0, i-kl); j < min(n, i+ku+1); j++ {
+			var v float64
+			if isRaw {
+				v = raw.Data[i*raw.Stride+raw.KL+j-i]
+			} else {
+				v = a.At(i, j)
+			}
+			row[kl+j-i] = v
+			sum += math.Abs(v)
+		}
+		anorm = math.Max(anorm, sum)
+	}
+
+	ok := lapack64.Gbtrf(lu.lu, lu.pivot)
+	if !ok {
+		lu.cond = math.Inf(1)
+		return
+	}
+	work := getFloats(2*n, false)
+	iwork := getInts(n, false)
+	v := lapack64.Gbcon(CondNorm, lu.lu, lu.pivot, anorm, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	lu.cond = 1 / v
+}
+
+// Cond returns the condition number for the factorized matrix.
+// Cond will panic if the receiver does not contain a successful factorization.
+func (lu *BandLU) Cond() float64 {
+	if lu.isZero() {
+		panic(badFact)
+	}
+	return lu.cond
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *BandLU) Reset() {
+	lu.lu.Rows = 0
+	lu.lu.Cols = 0
+	lu.pivot = lu.pivot[:0]
+}
+
+func (lu *BandLU) isZero() bool {
+	return len(lu.pivot) == 0
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a successful factorization.
+func (lu *BandLU) Det() float64 {
+	det, sign := lu.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+// LogDet will panic if the receiver does not contain a successful factorization.
+func (lu *BandLU) LogDet() (det float64, sign float64) {
+	if lu.isZero() {
+		panic(badFact)
+	}
+	sign = 1.0
+	for i, p := range lu.pivot {
+		v := lu.lu.Data[i*lu.lu.Stride+lu.lu.KL]
+		if v < 0 {
+			sign *= -1
+		}
+		if p != i {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v))
+	}
+	return det, sign
+}
+
+// Solve solves a system of linear equations using the LU decomposition of a
+// band matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into m.
+//
+// If A is singular or near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+// Solve will panic if the receiver does not contain a successful factorization.
+func (lu *BandLU) Solve(m *Dense, trans bool, b Matrix) error {
+	if lu.isZero() {
+		panic(badFact)
+	}
+	n := lu.lu.Rows
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if math.IsInf(lu.cond, 1) {
+		return Condition(math.Inf(1))
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack64.Gbtrs(t, lu.lu, m.mat, lu.pivot)
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
+
+// SolveVec solves a system of linear equations using the LU decomposition of
+// a band matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the vector x is
+// stored into v.
+//
+// If A is singular or near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+// SolveVec will panic if the receiver does not contain a successful
+// factorization.
+func (lu *BandLU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	if lu.isZero() {
+		panic(badFact)
+	}
+	n := lu.lu.Rows
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	if math.IsInf(lu.cond, 1) {
+		return Condition(math.Inf(1))
+	}
+
+	v.reuseAs(n)
+	var restore func()
+	if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+	v.CopyVec(b)
+	vMat := blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack64.Gbtrs(t, lu.lu, vMat, lu.pivot)
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestBandLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, kl, ku int
+	}{
+		{1, 0, 0},
+		{3, 0, 0},
+		{3, 1, 1},
+		{5, 2, 1},
+		{5, 1, 3},
+		{10, 0, 4},
+		{10, 3, 0},
+		{10, 9, 9},
+		{30, 2, 5},
+	} {
+		n, kl, ku := test.n, test.kl, test.ku
+		a := NewBandDense(n, n, kl, ku, nil)
+		for i := 0; i < n; i++ {
+			for j := max(0, i-kl); j < min(n, i+ku+1); j++ {
+				a.SetBand(i, j, rnd.NormFloat64())
+			}
+		}
+
+		var blu BandLU
+		blu.Factorize(a)
+		var lu LU
+		lu.Factorize(a)
+		if !floats.EqualWithinAbsOrRel(blu.Det(), lu.Det(), 1e-10, 1e-10) {
+			t.Errorf("n=%d,kl=%d,ku=%d: determinant mismatch: got:%v want:%v", n, kl, ku, blu.Det(), lu.Det())
+		}
+		det, sign := blu.LogDet()
+		wantDet, wantSign := lu.LogDet()
+		if sign != wantSign || !floats.EqualWithinAbsOrRel(det, wantDet, 1e-10, 1e-10) {
+			t.Errorf("n=%d,kl=%d,ku=%d: log determinant mismatch: got:(%v,%v) want:(%v,%v)", n, kl, ku, det, sign, wantDet, wantSign)
+		}
+		if !floats.EqualWithinAbsOrRel(blu.Cond(), lu.Cond(), 1e-8, 1e-8) {
+			t.Errorf("n=%d,kl=%d,ku=%d: condition number mismatch: got:%v want:%v", n, kl, ku, blu.Cond(), lu.Cond())
+		}
+
+		for _, trans := range []bool{false, true} {
+			want := NewDense(n, 3, nil)
+			for i := range want.mat.Data {
+				want.mat.Data[i] = rnd.NormFloat64()
+			}
+			var b Dense
+			if trans {
+				b.Mul(a.T(), want)
+			} else {
+				b.Mul(a, want)
+			}
+			var x Dense
+			err := blu.Solve(&x, trans, &b)
+			if err != nil {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected error from Solve: %v", n, kl, ku, trans, err)
+			}
+			if !EqualApprox(&x, want, 1e-8) {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected Solve result", n, kl, ku, trans)
+			}
+			// In-place solve.
+			err = blu.Solve(&b, trans, &b)
+			if err != nil {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected error from in-place Solve: %v", n, kl, ku, trans, err)
+			}
+			if !EqualApprox(&b, want, 1e-8) {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected in-place Solve result", n, kl, ku, trans)
+			}
+
+			wantVec := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				wantVec.SetVec(i, rnd.NormFloat64())
+			}
+			var bv VecDense
+			if trans {
+				bv.MulVec(a.T(), wantVec)
+			} else {
+				bv.MulVec(a, wantVec)
+			}
+			var xv VecDense
+			err = blu.SolveVec(&xv, trans, &bv)
+			if err != nil {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected error from SolveVec: %v", n, kl, ku, trans, err)
+			}
+			if !EqualApprox(&xv, wantVec, 1e-8) {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected SolveVec result", n, kl, ku, trans)
+			}
+			err = blu.SolveVec(&bv, trans, &bv)
+			if err != nil {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected error from in-place SolveVec: %v", n, kl, ku, trans, err)
+			}
+			if !EqualApprox(&bv, wantVec, 1e-8) {
+				t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected in-place SolveVec result", n, kl, ku, trans)
+			}
+		}
+	}
+}
+
+func TestBandLUSingular(t *testing.T) {
+	a := NewBandDense(4, 4, 1, 1, []float64{
+		0, 1, 2,
+		3, 4, 5,
+		0, 0, 0,
+		0, 6, 7,
+	})
+	var lu BandLU
+	lu.Factorize(a)
+	if !math.IsInf(lu.Cond(), 1) {
+		t.Errorf("unexpected condition number for singular matrix: got:%v want:+Inf", lu.Cond())
+	}
+	if lu.Det() != 0 {
+		t.Errorf("unexpected determinant for singular matrix: got:%v want:0", lu.Det())
+	}
+	var x Dense
+	err := lu.Solve(&x, false, NewDense(4, 1, nil))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular matrix: got:%v want:Condition", err)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// BunchKaufman is a type for creating and using the Bunch-Kaufman
+// factorization of a symmetric, possibly indefinite, matrix.
+//
+// The factorization has the form
+//  A = U * D * U^T
+// where U is a product of permutation and unit upper triangular matrices, and
+// D is symmetric and block diagonal with 1×1 and 2×2 diagonal blocks.
+type BunchKaufman struct {
+	fact  *SymDense
+	pivot []int
+	cond  float64
+}
+
+// Factorize computes the Bunch-Kaufman factorization of the symmetric matrix a
+// and stores the result. The factorization will complete regardless of the
+// singularity of a.
+func (bk *BunchKaufman) Factorize(a Symmetric) {
+	n := a.Symmetric()
+	if bk.fact == nil || bk.fact.IsZero() {
+		bk.fact = NewSymDense(n, nil)
+	} else {
+		bk.fact = NewSymDense(n, use(bk.fact.mat.Data, n*n))
+	}
+	bk.fact.CopySym(a)
+	if cap(bk.pivot) < n {
+		bk.pivot = make([]int, n)
+	}
+	bk.pivot = bk.pivot[:n]
+
+	work := getFloats(2*n, false)
+	defer putFloats(work)
+	anorm := lapack64.Lansy(CondNorm, bk.fact.mat, work)
+	lwork := []float64{0}
+	lapack64.Sytrf(bk.fact.mat, bk.pivot, lwork, -1)
+	lwork = getFloats(int(lwork[0]), false)
+	ok := lapack64.Sytrf(bk.fact.mat, bk.pivot, lwork, len(lwork))
+	putFloats(lwork)
+	if !ok {
+		bk.cond = math.Inf(1)
+		return
+	}
+	iwork := getInts(n, false)
+	v := lapack64.Sycon(bk.fact.mat, bk.pivot, anorm, work, iwork)
+	putInts(iwork)
+	bk.cond = 1 / v
+}
+
+// Cond returns the condition number for the factorized matrix.
+// Cond will panic if the receiver does not contain a successful factorization.
+func (bk *BunchKaufman) Cond() float64 {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	return bk.cond
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (bk *BunchKaufman) Reset() {
+	if bk.fact != nil {
+		bk.fact.Reset()
+	}
+	bk.pivot = bk.pivot[:0]
+}
+
+func (bk *BunchKaufman) isZero() bool {
+	return len(bk.pivot) == 0
+}
+
+// Size returns the dimension of the factorized matrix.
+// Size will panic if the receiver does not contain a successful factorization.
+func (bk *BunchKaufman) Size() int {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	return len(bk.pivot)
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a successful factorization.
+func (bk *BunchKaufman) Det() float64 {
+	det, sign := bk.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+// LogDet will panic if the receiver does not contain a successful
+// factorization.
+func (bk *BunchKaufman) LogDet() (det float64, sign float64) {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	// The determinant of A is the determinant of D since
+	// the determinant of U is ±1 and appears twice.
+	sign = 1
+	for k := 0; k < len(bk.pivot); k++ {
+		if bk.pivot[k] >= 0 {
+			v := bk.fact.at(k, k)
+			if v < 0 {
+				sign *= -1
+			}
+			det += math.Log(math.Abs(v))
+			continue
+		}
+		// The determinant of the 2×2 block [a b; b c] is
+		//  a*c - b*b = (a/b * c/b - 1) * b*b.
+		b := bk.fact.at(k, k+1)
+		v := bk.fact.at(k, k)/b*(bk.fact.at(k+1, k+1)/b) - 1
+		if v < 0 {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v)) + 2*math.Log(math.Abs(b))
+		k++
+	}
+	return det, sign
+}
+
+// Inertia returns the inertia of the factorized matrix, the number of
+// positive, negative and zero eigenvalues. By Sylvester's law of inertia the
+// inertia of A is equal to the inertia of the block diagonal matrix D.
+// Eigenvalues of D are counted as zero only if they are exactly zero.
+// Inertia will panic if the receiver does not contain a successful
+// factorization.
+func (bk *BunchKaufman) Inertia() (pos, neg, zero int) {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	for k := 0; k < len(bk.pivot); k++ {
+		if bk.pivot[k] >= 0 {
+			switch v := bk.fact.at(k, k); {
+			case v > 0:
+				pos++
+			case v < 0:
+				neg++
+			default:
+				zero++
+			}
+			continue
+		}
+		a := bk.fact.at(k, k)
+		b := bk.fact.at(k, k+1)
+		c := bk.fact.at(k+1, k+1)
+		det := a/b*(c/b) - 1
+		tr := a + c
+		switch {
+		case det < 0:
+			pos++
+			neg++
+		case det > 0 && tr > 0:
+			pos += 2
+		case det > 0 && tr < 0:
+			neg += 2
+		default:
+			// One eigenvalue is zero and the other
+			// is equal to the trace.
+			zero++
+			switch {
+			case tr > 0:
+				pos++
+			case tr < 0:
+				neg++
+			default:
+				zero++
+			}
+		}
+		k++
+	}
+	return pos, neg, zero
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented by
+// the Bunch-Kaufman factorization, placing the result in m. If A is singular
+// or near-singular a Condition error is returned. Please see the
+// documentation for Condition for more information.
+// Solve will panic if the receiver does not contain a successful
+// factorization.
+func (bk *BunchKaufman) Solve(m *Dense, b Matrix) error {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	n := len(bk.pivot)
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if math.IsInf(bk.cond, 1) {
+		return Condition(math.Inf(1))
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	lapack64.Sytrs(bk.fact.mat, bk.pivot, m.mat)
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented by
+// the Bunch-Kaufman factorization, placing the result in v. If A is singular
+// or near-singular a Condition error is returned. Please see the
+// documentation for Condition for more information.
+// SolveVec will panic if the receiver does not contain a successful
+// factorization.
+func (bk *BunchKaufman) SolveVec(v, b *VecDense) error {
+	if bk.isZero() {
+		panic(badFact)
+	}
+	n := len(bk.pivot)
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	if math.IsInf(bk.cond, 1) {
+		return Condition(math.Inf(1))
+	}
+
+	v.reuseAs(n)
+	var restore func()
+	if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+	v.CopyVec(b)
+	vMat := blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+	lapack64.Sytrs(bk.fact.mat, bk.pivot, vMat)
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestBunchKaufman(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, rnd.NormFloat64())
+			}
+		}
+
+		var bk BunchKaufman
+		bk.Factorize(a)
+		if bk.Size() != n {
+			t.Errorf("n=%d: unexpected size: got:%d", n, bk.Size())
+		}
+
+		var lu LU
+		lu.Factorize(a)
+		if !floats.EqualWithinAbsOrRel(bk.Det(), lu.Det(), 1e-10, 1e-10) {
+			t.Errorf("n=%d: determinant mismatch: got:%v want:%v", n, bk.Det(), lu.Det())
+		}
+		det, sign := bk.LogDet()
+		wantDet, wantSign := lu.LogDet()
+		if sign != wantSign || !floats.EqualWithinAbsOrRel(det, wantDet, 1e-10, 1e-10) {
+			t.Errorf("n=%d: log determinant mismatch: got:(%v,%v) want:(%v,%v)", n, det, sign, wantDet, wantSign)
+		}
+
+		var eig EigenSym
+		eig.Factorize(a, false)
+		var wantPos, wantNeg int
+		for _, v := range eig.Values(nil) {
+			if v > 0 {
+				wantPos++
+			} else {
+				wantNeg++
+			}
+		}
+		pos, neg, zero := bk.Inertia()
+		if pos != wantPos || neg != wantNeg || zero != 0 {
+			t.Errorf("n=%d: unexpected inertia: got:(%d,%d,%d) want:(%d,%d,0)", n, pos, neg, zero, wantPos, wantNeg)
+		}
+
+		want := NewDense(n, 3, nil)
+		for i := range want.mat.Data {
+			want.mat.Data[i] = rnd.NormFloat64()
+		}
+		var b Dense
+		b.Mul(a, want)
+		var x Dense
+		err := bk.Solve(&x, &b)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error from Solve: %v", n, err)
+		}
+		if !EqualApprox(&x, want, 1e-8) {
+			t.Errorf("n=%d: unexpected Solve result", n)
+		}
+
+		wantVec := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			wantVec.SetVec(i, rnd.NormFloat64())
+		}
+		var bv VecDense
+		bv.MulVec(a, wantVec)
+		var xv VecDense
+		err = bk.SolveVec(&xv, &bv)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error from SolveVec: %v", n, err)
+		}
+		if !EqualApprox(&xv, wantVec, 1e-8) {
+			t.Errorf("n=%d: unexpected SolveVec result", n)
+		}
+		// In-place solve.
+		err = bk.SolveVec(&bv, &bv)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error from in-place SolveVec: %v", n, err)
+		}
+		if !EqualApprox(&bv, wantVec, 1e-8) {
+			t.Errorf("n=%d: unexpected in-place SolveVec result", n)
+		}
+	}
+}
+
+func TestBunchKaufmanKKT(t *testing.T) {
+	// The KKT matrix
+	//  [ H  C^T ]
+	//  [ C  0   ]
+	// of an equality constrained quadratic program with a positive
+	// definite H and full row rank C has n positive and m negative
+	// eigenvalues.
+	const n, m = 4, 2
+	a := NewSymDense(n+m, []float64{
+		4, 1, 0, 0, 1, 0,
+		1, 3, 1, 0, 1, 1,
+		0, 1, 5, 1, 0, 1,
+		0, 0, 1, 2, 1, -1,
+		1, 1, 0, 1, 0, 0,
+		0, 1, 1, -1, 0, 0,
+	})
+	var bk BunchKaufman
+	bk.Factorize(a)
+	pos, neg, zero := bk.Inertia()
+	if pos != n || neg != m || zero != 0 {
+		t.Errorf("unexpected inertia: got:(%d,%d,%d) want:(%d,%d,0)", pos, neg, zero, n, m)
+	}
+	var chol Cholesky
+	if chol.Factorize(a) {
+		t.Errorf("unexpected successful Cholesky factorization of indefinite matrix")
+	}
+
+	// A singular matrix.
+	s := NewSymDense(3, []float64{
+		1, 2, 3,
+		2, 4, 6,
+		3, 6, 9,
+	})
+	bk.Factorize(s)
+	if !math.IsInf(bk.Cond(), 1) {
+		t.Errorf("unexpected condition number for singular matrix: got:%v", bk.Cond())
+	}
+	if bk.Det() != 0 {
+		t.Errorf("unexpected determinant for singular matrix: got:%v", bk.Det())
+	}
+	pos, neg, zero = bk.Inertia()
+	if pos != 1 || neg != 0 || zero != 2 {
+		t.Errorf("unexpected inertia for singular matrix: got:(%d,%d,%d) want:(1,0,2)", pos, neg, zero)
+	}
+	var x VecDense
+	err := bk.SolveVec(&x, NewVecDense(3, []float64{1, 2, 3}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("expected Condition error for singular matrix, got:%v", err)
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+var (
+	cDense *CDense
+
+	_ CMatrix = cDense
+)
+
+// CDense is a dense matrix representation with complex data.
+type CDense struct {
+	mat cblas128.General
+
+	capRows, capCols int
+}
+
+// NewCDense creates a new complex Dense matrix with r rows and c columns.
+// If data == nil, a new slice is allocated for the backing slice.
+// If len(data) == r*c, data is used as the backing slice, and changes to the
+// elements of the returned CDense will be reflected in data.
+// If neither of these is true, NewCDense will panic.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+func NewCDense(r, c int, data []complex128) *CDense {
+	if data != nil && r*c != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, r*c)
+	}
+	return &CDense{
+		mat: cblas128.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   data,
+		},
+		capRows: r,
+		capCols: c,
+	}
+}
+
+// reuseAs resizes an empty matrix to a r×c matrix,
+// or checks that a non-empty matrix is r×c.
+func (m *CDense) reuseAs(r, c int) {
+	if m.mat.Rows > m.capRows || m.mat.Cols > m.capCols {
+		// Panic as a string, not a mat.Error.
+		panic("mat: caps not correctly set")
+	}
+	if m.IsZero() {
+		m.mat = cblas128.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   useC(m.mat.Data, r*c),
+		}
+		m.capRows = r
+		m.capCols = c
+		return
+	}
+	if r != m.mat.Rows || c != m.mat.Cols {
+		panic(ErrShape)
+	}
+}
+
+// unconjugate unconjugates a matrix if applicable. If a is an Unconjugator, then
+// unconjugate returns the underlying matrix and true. If it is not, then it returns
+// the input matrix and false.
+func unconjugate(a CMatrix) (CMatrix, bool) {
+	if ut, ok := a.(Unconjugator); ok {
+		return ut.Unconjugate(), true
+	}
+	return a, false
+}
+
+// isolatedWorkspace returns a new complex dense matrix w with the size of a and
+// returns a callback to defer which performs cleanup at the return of the call.
+// This should be used when a method receiver is the same pointer as an input argument.
+func (m *CDense) isolatedWorkspace(a CMatrix) (w *CDense, restore func()) {
+	r, c := a.Dims()
+	w = NewCDense(r, c, nil)
+	return w, func() {
+		m.Copy(w)
+	}
+}
+
+// conjAliases returns whether a is an implicit conjugate transpose of the receiver.
+func (m *CDense) conjAliases(a CMatrix) bool {
+	aU, conj := unconjugate(a)
+	return conj && aU == CMatrix(m)
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (m *CDense) Reset() {
+	// Row, Cols and Stride must be zeroed in unison.
+	m.mat.Rows, m.mat.Cols, m.mat.Stride = 0, 0, 0
+	m.capRows, m.capCols = 0, 0
+	m.mat.Data = m.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. CDense matrices can be zeroed using Reset.
+func (m *CDense) IsZero() bool {
+	// It must be the case that m.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return m.mat.Stride == 0
+}
+
+// SetRawCMatrix sets the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in b.
+func (m *CDense) SetRawCMatrix(b cblas128.General) {
+	m.capRows, m.capCols = b.Rows, b.Cols
+	m.mat = b
+}
+
+// RawCMatrix returns the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.General.
+func (m *CDense) RawCMatrix() cblas128.General { return m.mat }
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CDense) Dims() (r, c int) { return m.mat.Rows, m.mat.Cols }
+
+// Caps returns the number of rows and columns in the backing matrix.
+func (m *CDense) Caps() (r, c int) { return m.capRows, m.capCols }
+
+// H performs an implicit conjugate transpose by returning the receiver inside a
+// Conjugate.
+func (m *CDense) H() CMatrix {
+	return Conjugate{m}
+}
+
+// Clone makes a copy of a into the receiver, overwriting the previous value of
+// the receiver. The clone operation does not make any restriction on shape and
+// will not cause shadowing.
+func (m *CDense) Clone(a CMatrix) {
+	r, c := a.Dims()
+	mat := cblas128.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: c,
+		Data:   make([]complex128, r*c),
+	}
+	m.capRows, m.capCols = r, c
+	w := *m
+	w.mat = mat
+	w.Copy(a)
+	*m = w
+}
+
+// Copy makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two matrices and
+// returns the number of rows and columns it copied.
+func (m *CDense) Copy(a CMatrix) (r, c int) {
+	r, c = a.Dims()
+	if a == CMatrix(m) {
+		return r, c
+	}
+	r = min(r, m.mat.Rows)
+	c = min(c, m.mat.Cols)
+	if r == 0 || c == 0 {
+		return 0, 0
+	}
+
+	aU, conj := unconjugate(a)
+	switch aU := aU.(type) {
+	case *CDense:
+		amat := aU.mat
+		if conj {
+			if aU == m {
+				// Copy the implicit conjugate transpose
+				// of the receiver through a workspace.
+				tmp := NewCDense(r, c, nil)
+				tmp.Copy(a)
+				amat = tmp.mat
+				conj = false
+			}
+		}
+		if conj {
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					m.mat.Data[i*m.mat.Stride+j] = cmplx.Conj(amat.Data[j*amat.Stride+i])
+				}
+			}
+		} else {
+			for i := 0; i < r; i++ {
+				copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+			}
+		}
+	default:
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				m.set(i, j, a.At(i, j))
+			}
+		}
+	}
+	return r, c
+}
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Add(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	m.reuseAs(ar, ac)
+	if m.conjAliases(a) || m.conjAliases(b) {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, a.At(i, j)+b.At(i, j))
+		}
+	}
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Sub(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	m.reuseAs(ar, ac)
+	if m.conjAliases(a) || m.conjAliases(b) {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, a.At(i, j)-b.At(i, j))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *CDense) Scale(f complex128, a CMatrix) {
+	ar, ac := a.Dims()
+	m.reuseAs(ar, ac)
+	if m.conjAliases(a) {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, f*a.At(i, j))
+		}
+	}
+}
+
+// Conj places the element-wise conjugate of a in the receiver.
+func (m *CDense) Conj(a CMatrix) {
+	ar, ac := a.Dims()
+	m.reuseAs(ar, ac)
+	if m.conjAliases(a) {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, cmplx.Conj(a.At(i, j)))
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+func (m *CDense) Mul(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		panic(ErrShape)
+	}
+
+	aU, aConj := unconjugate(a)
+	bU, _ := unconjugate(b)
+	m.reuseAs(ar, bc)
+	var restore func()
+	if m == aU {
+		m, restore = m.isolatedWorkspace(aU)
+		defer restore()
+	} else if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	}
+
+	if aU, ok := aU.(*CDense); ok {
+		aT := blas.NoTrans
+		if aConj {
+			aT = blas.ConjTrans
+		}
+		// Form each column of the product as the product
+		// of a and the corresponding column of b.
+		col := make([]complex128, br)
+		for j := 0; j < bc; j++ {
+			for i := range col {
+				col[i] = b.At(i, j)
+			}
+			cblas128.Gemv(aT, 1, aU.mat,
+				cblas128.Vector{Inc: 1, Data: col},
+				0, cblas128.Vector{Inc: m.mat.Stride, Data: m.mat.Data[j:]})
+		}
+		return
+	}
+
+	row := make([]complex128, ac)
+	for r := 0; r < ar; r++ {
+		for i := range row {
+			row[i] = a.At(r, i)
+		}
+		for c := 0; c < bc; c++ {
+			var v complex128
+			for i, e := range row {
+				v += e * b.At(i, c)
+			}
+			m.mat.Data[r*m.mat.Stride+c] = v
+		}
+	}
+}
+
+// useC returns a complex128 slice with l elements, using c if it
+// has the necessary capacity, otherwise creating a new slice.
+func useC(c []complex128, l int) []complex128 {
+	if l <= cap(c) {
+		return c[:l]
+	}
+	return make([]complex128, l)
+}
@@ -0,0 +1,187 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// randCDense returns a random r×c complex matrix.
+func randCDense(r, c int, rnd *rand.Rand) *CDense {
+	m := NewCDense(r, c, nil)
+	for i := range m.mat.Data {
+		m.mat.Data[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return m
+}
+
+// basicCMatrix is a CMatrix that hides the concrete type of a CDense.
+type basicCMatrix CDense
+
+func (m *basicCMatrix) At(i, j int) complex128 { return (*CDense)(m).At(i, j) }
+func (m *basicCMatrix) Dims() (r, c int)       { return (*CDense)(m).Dims() }
+func (m *basicCMatrix) H() CMatrix             { return Conjugate{m} }
+
+func TestNewCDense(t *testing.T) {
+	m := NewCDense(2, 3, []complex128{1, 2i, 3, 4 + 1i, 5, 6 - 2i})
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("unexpected dimensions: got:%d×%d want:2×3", r, c)
+	}
+	if m.At(1, 0) != 4+1i {
+		t.Errorf("unexpected element: got:%v want:%v", m.At(1, 0), 4+1i)
+	}
+	m.Set(0, 2, -1i)
+	if m.At(0, 2) != -1i {
+		t.Errorf("unexpected element after Set: got:%v want:%v", m.At(0, 2), -1i)
+	}
+	h := m.H()
+	if r, c := h.Dims(); r != 3 || c != 2 {
+		t.Errorf("unexpected conjugate transpose dimensions: got:%d×%d want:3×2", r, c)
+	}
+	if h.At(0, 1) != 4-1i {
+		t.Errorf("unexpected conjugate transpose element: got:%v want:%v", h.At(0, 1), 4-1i)
+	}
+	if panicked, _ := panics(func() { NewCDense(2, 2, make([]complex128, 3)) }); !panicked {
+		t.Errorf("expected panic for mismatched data length")
+	}
+	if panicked, _ := panics(func() { m.At(2, 0) }); !panicked {
+		t.Errorf("expected panic for out of range row access")
+	}
+}
+
+func TestCDenseCopy(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randCDense(3, 4, rnd)
+
+	var c CDense
+	c.Clone(a.H())
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			if c.At(i, j) != cmplx.Conj(a.At(j, i)) {
+				t.Errorf("unexpected clone of conjugate transpose at (%d,%d)", i, j)
+			}
+		}
+	}
+
+	// In-place conjugate transpose of a square matrix.
+	s := randCDense(4, 4, rnd)
+	var want CDense
+	want.Clone(s.H())
+	s.Copy(s.H())
+	if !CEqual(s, &want) {
+		t.Errorf("unexpected in-place conjugate transpose copy")
+	}
+
+	// Copy from a general CMatrix.
+	d := NewCDense(3, 4, nil)
+	d.Copy((*basicCMatrix)(a))
+	if !CEqual(d, a) {
+		t.Errorf("unexpected copy from basic matrix")
+	}
+}
+
+func TestCDenseArithmetic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c int
+	}{
+		{1, 1},
+		{3, 4},
+		{5, 2},
+		{6, 6},
+	} {
+		a := randCDense(test.r, test.c, rnd)
+		b := randCDense(test.r, test.c, rnd)
+
+		var add, sub, scale, conj CDense
+		add.Add(a, b)
+		sub.Sub(a, b)
+		scale.Scale(2-3i, a)
+		conj.Conj(a)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				if add.At(i, j) != a.At(i, j)+b.At(i, j) {
+					t.Errorf("unexpected Add result at (%d,%d)", i, j)
+				}
+				if sub.At(i, j) != a.At(i, j)-b.At(i, j) {
+					t.Errorf("unexpected Sub result at (%d,%d)", i, j)
+				}
+				if scale.At(i, j) != (2-3i)*a.At(i, j) {
+					t.Errorf("unexpected Scale result at (%d,%d)", i, j)
+				}
+				if conj.At(i, j) != cmplx.Conj(a.At(i, j)) {
+					t.Errorf("unexpected Conj result at (%d,%d)", i, j)
+				}
+			}
+		}
+
+		if test.r == test.c {
+			// Aliased conjugate transpose operand.
+			var want CDense
+			want.Add(a, cloneC(a.H()))
+			a.Add(a, a.H())
+			if !CEqual(a, &want) {
+				t.Errorf("unexpected Add result with aliased conjugate transpose")
+			}
+		}
+	}
+}
+
+// cloneC returns a newly allocated copy of the elements of a.
+func cloneC(a CMatrix) *CDense {
+	var m CDense
+	m.Clone(a)
+	return &m
+}
+
+func TestCDenseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ac, bc int
+	}{
+		{1, 1, 1},
+		{3, 4, 5},
+		{5, 3, 2},
+		{4, 4, 4},
+		{1, 6, 3},
+	} {
+		a := randCDense(test.ar, test.ac, rnd)
+		b := randCDense(test.ac, test.bc, rnd)
+		want := NewCDense(test.ar, test.bc, nil)
+		for i := 0; i < test.ar; i++ {
+			for j := 0; j < test.bc; j++ {
+				var v complex128
+				for k := 0; k < test.ac; k++ {
+					v += a.At(i, k) * b.At(k, j)
+				}
+				want.Set(i, j, v)
+			}
+		}
+
+		aH := cloneC(a.H())
+		bH := cloneC(b.H())
+		for _, as := range []CMatrix{a, aH.H(), (*basicCMatrix)(a)} {
+			for _, bs := range []CMatrix{b, bH.H(), (*basicCMatrix)(b)} {
+				var got CDense
+				got.Mul(as, bs)
+				if !CEqualApprox(&got, want, 1e-14) {
+					t.Errorf("unexpected Mul result for %T×%T %d×%d×%d",
+						as, bs, test.ar, test.ac, test.bc)
+				}
+			}
+		}
+
+		if test.ar == test.ac && test.ac == test.bc {
+			// Aliased receiver.
+			c := cloneC(a)
+			c.Mul(c, b)
+			if !CEqualApprox(c, want, 1e-14) {
+				t.Errorf("unexpected Mul result for aliased receiver")
+			}
+		}
+	}
+}
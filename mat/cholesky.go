@@ -88,6 +88,87 @@ func (c *Cholesky) Factorize(a Symmetric) (ok bool) {
 	return ok
 }
 
+// FactorizeModified calculates the Cholesky decomposition of the matrix A + E,
+// where E is a non-negative diagonal matrix chosen so that A + E is
+// sufficiently positive definite, and stores the result into the receiver.
+// Unlike Factorize, FactorizeModified always succeeds, also for an indefinite
+// or singular A, making it suitable for computing descent directions in
+// Newton-type optimization methods.
+//
+// The diagonal of E is stored into e and returned. If e is nil, a new slice is
+// allocated, otherwise the length of e must be equal to the size of A. If A is
+// sufficiently positive definite, E is zero and the factorization is equal to
+// the one computed by Factorize.
+//
+// FactorizeModified implements the modified Cholesky factorization of Gill and
+// Murray without pivoting, as described in
+//  Gill, P. E., Murray, W. and Wright, M. H. Practical Optimization.
+//  Academic Press (1981), section 4.4.2.2.
+// The diagonal elements of the factor are bounded from below and the
+// off-diagonal elements from above, which bounds the size of E in terms of the
+// size of the elements of A.
+func (c *Cholesky) FactorizeModified(a Symmetric, e []float64) []float64 {
+	// dlamchE is the machine epsilon.
+	const dlamchE = 1.0 / (1 << 53)
+
+	n := a.Symmetric()
+	if e == nil {
+		e = make([]float64, n)
+	}
+	if len(e) != n {
+		panic(ErrSliceLengthMismatch)
+	}
+	if c.isZero() {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	copySymIntoTriangle(c.chol, a)
+
+	u := c.chol.mat
+	stride := u.Stride
+	// Compute the bounds on the elements of the factor from the largest
+	// diagonal and off-diagonal elements of A.
+	var gamma, xi float64
+	for i := 0; i < n; i++ {
+		gamma = math.Max(gamma, math.Abs(u.Data[i*stride+i]))
+		for _, v := range u.Data[i*stride+i+1 : i*stride+n] {
+			xi = math.Max(xi, math.Abs(v))
+		}
+	}
+	nu := math.Max(1, math.Sqrt(float64(n*n-1)))
+	beta2 := math.Max(math.Max(gamma, xi/nu), dlamchE)
+	delta := dlamchE * math.Max(gamma+xi, 1)
+
+	for j := 0; j < n; j++ {
+		// The jth row of the trailing Schur complement C holds
+		// c_jj and c_jk for k > j.
+		row := u.Data[j*stride+j+1 : j*stride+n]
+		cjj := u.Data[j*stride+j]
+		var theta float64
+		for _, v := range row {
+			theta = math.Max(theta, math.Abs(v))
+		}
+		d := math.Max(math.Max(math.Abs(cjj), theta*theta/beta2), delta)
+		e[j] = d - cjj
+		if j < n-1 {
+			blas64.Syr(-1/d, blas64.Vector{Inc: 1, Data: row}, blas64.Symmetric{
+				Uplo:   blas.Upper,
+				N:      n - j - 1,
+				Stride: stride,
+				Data:   u.Data[(j+1)*stride+j+1:],
+			})
+		}
+		d = math.Sqrt(d)
+		u.Data[j*stride+j] = d
+		for k := range row {
+			row[k] /= d
+		}
+	}
+	c.updateCond(-1)
+	return e
+}
+
 // Reset resets the factorization so that it can be reused as the receiver of a
 // dimensionally restricted operation.
 func (c *Cholesky) Reset() {
@@ -315,10 +396,12 @@ func (c *Cholesky) InverseTo(s *SymDense) error {
 // in the updated factorization
 //  U'^T * U' = A + alpha * x * x^T = A'.
 //
-// Note that when alpha is negative, the updating problem may be ill-conditioned
-// and the results may be inaccurate, or the updated matrix A' may not be
-// positive definite and not have a Cholesky factorization. SymRankOne returns
-// whether the updated matrix A' is positive definite.
+// When alpha is negative, SymRankOne performs a downdate of the factorization.
+// Note that the downdating problem may be ill-conditioned and the results may be
+// inaccurate, or the updated matrix A' may not be positive definite and not have
+// a Cholesky factorization. SymRankOne returns whether the updated matrix A' is
+// positive definite. If SymRankOne returns false, the receiver is left
+// unchanged, so orig may be the receiver even when the downdate fails.
 //
 // SymRankOne updates a Cholesky factorization in O(n²) time. The Cholesky
 // factorization computation from scratch is O(n³).
@@ -330,16 +413,15 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 	if x.Len() != n {
 		panic(ErrShape)
 	}
-	if orig != c {
-		if c.isZero() {
-			c.chol = NewTriDense(n, Upper, nil)
-		} else if c.chol.mat.N != n {
-			panic(ErrShape)
-		}
-		c.chol.Copy(orig.chol)
+	if orig != c && !c.isZero() && c.chol.mat.N != n {
+		panic(ErrShape)
 	}
 
 	if alpha == 0 {
+		if orig != c {
+			c.setFactor(orig.chol)
+			c.cond = orig.cond
+		}
 		return true
 	}
 
@@ -369,6 +451,12 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 	//   EPFL Technical Report 161468 (2004)
 	//   http://infoscience.epfl.ch/record/161468
 
+	// The factorization is modified in a workspace and copied into the
+	// receiver only if it succeeds.
+	u := getWorkspaceTri(n, Upper, false)
+	defer putWorkspaceTri(u)
+	u.Copy(orig.chol)
+
 	work := getFloats(n, false)
 	defer putFloats(work)
 	blas64.Copy(n, x.RawVector(), blas64.Vector{1, work})
@@ -378,7 +466,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 		if alpha != 1 {
 			blas64.Scal(n, math.Sqrt(alpha), blas64.Vector{1, work})
 		}
-		umat := c.chol.mat
+		umat := u.mat
 		stride := umat.Stride
 		for i := 0; i < n; i++ {
 			// Compute parameters of the Givens matrix that zeroes
@@ -402,6 +490,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 					c, s)
 			}
 		}
+		c.setFactor(u)
 		c.updateCond(-1)
 		return true
 	}
@@ -412,7 +501,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 		blas64.Scal(n, alpha, blas64.Vector{1, work})
 	}
 	// Solve U^T * p = x storing the result into work.
-	ok = lapack64.Trtrs(blas.Trans, c.chol.RawTriangular(), blas64.General{
+	ok = lapack64.Trtrs(blas.Trans, u.RawTriangular(), blas64.General{
 		Rows:   n,
 		Cols:   1,
 		Stride: 1,
@@ -443,17 +532,15 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 			sin[i] *= -1
 		}
 	}
-	umat := c.chol.mat
+	umat := u.mat
 	stride := umat.Stride
 	for i := n - 1; i >= 0; i-- {
 		// Apply Givens matrices to U.
-		// TODO(vladimir-ch): Use workspace to avoid modifying the
-		// receiver in case an invalid factorization is created.
 		blas64.Rot(n-i, blas64.Vector{1, work[i:n]}, blas64.Vector{1, umat.Data[i*stride+i : i*stride+n]}, cos[i], sin[i])
 		if umat.Data[i*stride+i] == 0 {
 			// The matrix is singular (may rarely happen due to
 			// floating-point effects?).
-			ok = false
+			return false
 		} else if umat.Data[i*stride+i] < 0 {
 			// Diagonal elements should be positive. If it happens
 			// that on the i-th row the diagonal is negative,
@@ -462,12 +549,18 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 			blas64.Scal(n-i, -1, blas64.Vector{1, umat.Data[i*stride+i : i*stride+n]})
 		}
 	}
-	if ok {
-		c.updateCond(-1)
-	} else {
-		c.Reset()
+	c.setFactor(u)
+	c.updateCond(-1)
+	return true
+}
+
+// setFactor copies the upper triangular factor u into the receiver,
+// allocating the storage if the receiver is empty.
+func (c *Cholesky) setFactor(u *TriDense) {
+	if c.isZero() {
+		c.chol = NewTriDense(u.mat.N, Upper, nil)
 	}
-	return ok
+	c.chol.Copy(u)
 }
 
 func (c *Cholesky) isZero() bool {
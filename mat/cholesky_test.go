@@ -421,6 +421,145 @@ func TestCholeskySymRankOne(t *testing.T) {
 	}
 }
 
+func TestCholeskySymRankOneDowndateFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		data := make([]float64, n*n)
+		for i := range data {
+			data[i] = rnd.NormFloat64()
+		}
+		var a SymDense
+		a.SymOuterK(1, NewDense(n, n, data))
+		x := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			x.SetVec(i, rnd.NormFloat64())
+		}
+		var orig Cholesky
+		if !orig.Factorize(&a) {
+			t.Errorf("n=%d: bad test, Cholesky factorization failed", n)
+			continue
+		}
+		// Downdating by a multiple of x that is too large
+		// makes the matrix indefinite.
+		var alpha float64
+		{
+			var z VecDense
+			orig.SolveVec(&z, x)
+			alpha = -2 / Dot(x, &z)
+		}
+		want := orig.UTo(nil)
+
+		var chol Cholesky
+		if chol.SymRankOne(&orig, alpha, x) {
+			t.Errorf("n=%d: unexpected success of downdate", n)
+		}
+		if !chol.isZero() {
+			t.Errorf("n=%d: receiver modified by failed downdate", n)
+		}
+		if orig.SymRankOne(&orig, alpha, x) {
+			t.Errorf("n=%d: unexpected success of in-place downdate", n)
+		}
+		if !Equal(orig.UTo(nil), want) {
+			t.Errorf("n=%d: factorization modified by failed in-place downdate", n)
+		}
+	}
+}
+
+func TestCholeskyFactorizeModified(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		// A positive definite matrix is not modified.
+		data := make([]float64, n*n)
+		for i := range data {
+			data[i] = rnd.NormFloat64()
+		}
+		var a SymDense
+		a.SymOuterK(1, NewDense(n, n, data))
+		for i := 0; i < n; i++ {
+			a.SetSym(i, i, a.At(i, i)+float64(n))
+		}
+		var want, chol Cholesky
+		want.Factorize(&a)
+		e := chol.FactorizeModified(&a, nil)
+		for i, v := range e {
+			if v != 0 {
+				t.Errorf("n=%d: unexpected perturbation of positive definite matrix: e[%d]=%v", n, i, v)
+			}
+		}
+		if !EqualApprox(chol.UTo(nil), want.UTo(nil), 1e-13) {
+			t.Errorf("n=%d: factorization of positive definite matrix differs from Factorize", n)
+		}
+
+		// Indefinite and singular matrices are perturbed to be
+		// positive definite.
+		for _, rank := range []int{0, n / 2, n} {
+			b := NewSymDense(n, nil)
+			for i := 0; i < n; i++ {
+				for j := i; j < n; j++ {
+					b.SetSym(i, j, rnd.NormFloat64())
+				}
+			}
+			if rank < n {
+				b = NewSymDense(n, nil)
+				if rank > 0 {
+					b.SymOuterK(1, randRankDeficient(n, rank, rank, rnd))
+				}
+			}
+			e = make([]float64, n)
+			got := chol.FactorizeModified(b, e)
+			if &got[0] != &e[0] {
+				t.Errorf("n=%d,rank=%d: e not used as the destination", n, rank)
+			}
+			for i, v := range e {
+				if v < 0 {
+					t.Errorf("n=%d,rank=%d: negative perturbation: e[%d]=%v", n, rank, i, v)
+				}
+			}
+			u := chol.UTo(nil)
+			for i := 0; i < n; i++ {
+				if u.At(i, i) <= 0 {
+					t.Errorf("n=%d,rank=%d: non-positive diagonal of factor", n, rank)
+				}
+			}
+			var got2 Dense
+			got2.Mul(u.T(), u)
+			var bpe Dense
+			bpe.Clone(b)
+			for i := 0; i < n; i++ {
+				bpe.Set(i, i, bpe.At(i, i)+e[i])
+			}
+			if !EqualApprox(&got2, &bpe, 1e-10) {
+				t.Errorf("n=%d,rank=%d: A+E != U^T*U", n, rank)
+			}
+			if rank < n {
+				// The perturbation of a semidefinite matrix
+				// may be at the level of round-off.
+				continue
+			}
+			var pd Cholesky
+			if !pd.Factorize(NewSymDense(n, bpe.RawMatrix().Data)) {
+				t.Errorf("n=%d,rank=%d: A+E is not positive definite", n, rank)
+			}
+		}
+	}
+
+	// Indefinite example from Gill, Murray and Wright.
+	a := NewSymDense(3, []float64{
+		1, 1, 2,
+		1, 1 + 1e-20, 3,
+		2, 3, 1,
+	})
+	var chol Cholesky
+	e := chol.FactorizeModified(a, nil)
+	var norm float64
+	for _, v := range e {
+		norm = math.Max(norm, v)
+	}
+	if norm == 0 || norm > 10 {
+		t.Errorf("unexpected size of perturbation for indefinite matrix: %v", e)
+	}
+}
+
 func BenchmarkCholeskySmall(b *testing.B) {
 	benchmarkCholesky(b, 2)
 }
@@ -4,6 +4,11 @@
 
 package mat
 
+import (
+	"math"
+	"math/cmplx"
+)
+
 // CMatrix is the basic matrix interface type for complex matrices.
 type CMatrix interface {
 	// Dims returns the dimensions of a Matrix.
@@ -69,3 +74,53 @@ type Unconjugator interface {
 	// conjugate transpose.
 	Unconjugate() CMatrix
 }
+
+// CEqual returns whether the matrices a and b have the same size
+// and are element-wise equal.
+func CEqual(a, b CMatrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if a.At(i, j) != b.At(i, j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CEqualApprox returns whether the matrices a and b have the same size and contain all equal
+// elements with tolerance for element-wise equality specified by epsilon. Matrices
+// with non-equal shapes are not equal.
+func CEqualApprox(a, b CMatrix, epsilon float64) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if !equalWithinAbsOrRelC(a.At(i, j), b.At(i, j), epsilon, epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalWithinAbsOrRelC returns whether a and b are equal to within
+// the absolute or relative tolerances.
+func equalWithinAbsOrRelC(a, b complex128, absTol, relTol float64) bool {
+	if a == b {
+		return true
+	}
+	delta := cmplx.Abs(a - b)
+	if delta <= absTol {
+		return true
+	}
+	return delta/math.Max(cmplx.Abs(a), cmplx.Abs(b)) <= relTol
+}
@@ -52,3 +52,17 @@ const (
 	// the decomposition.
 	GSVDNone
 )
+
+// EigenSelection specifies which eigenvalues of a symmetric matrix are
+// computed during a partial eigendecomposition.
+type EigenSelection int
+
+const (
+	// LargestEigen specifies the algebraically largest eigenvalues.
+	LargestEigen EigenSelection = iota + 1
+	// SmallestEigen specifies the algebraically smallest eigenvalues.
+	SmallestEigen
+	// LargestMagnitudeEigen specifies the eigenvalues with the largest
+	// absolute value.
+	LargestMagnitudeEigen
+)
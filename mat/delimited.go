@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadDelimited reads a matrix from delimited text in r, such as CSV or
+// tab-separated values, where each record is a row of the matrix and fields
+// are separated by comma. Leading white space in a field is ignored and lines
+// beginning with '#' are treated as comments.
+//
+// An error is returned if the input is empty, if the records do not all have
+// the same number of fields or if a field cannot be parsed as a float64. The
+// error reports the row and column of the offending field.
+func ReadDelimited(r io.Reader, comma rune) (*Dense, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	var (
+		data []float64
+		cols int
+		rows int
+	)
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if pe, ok := err.(*csv.ParseError); ok && pe.Err == csv.ErrFieldCount {
+				return nil, fmt.Errorf("mat: row %d: %v", rows, ErrRowLength)
+			}
+			return nil, err
+		}
+		if rows == 0 {
+			cols = len(rec)
+		}
+		for j, f := range rec {
+			v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+			if err != nil {
+				return nil, fmt.Errorf("mat: row %d column %d: %v", rows, j, err)
+			}
+			data = append(data, v)
+		}
+		rows++
+	}
+	if rows == 0 || cols == 0 {
+		return nil, errors.New("mat: no delimited data")
+	}
+	return NewDense(rows, cols, data), nil
+}
+
+// WriteDelimited writes the matrix m to w as delimited text, such as CSV or
+// tab-separated values, with one record per row of m and fields separated by
+// comma. Values are written with the minimal number of digits required to
+// represent them exactly, so a matrix read by ReadDelimited is equal to the
+// matrix that was written.
+func WriteDelimited(w io.Writer, m Matrix, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	r, c := m.Dims()
+	rec := make([]string, c)
+	for i := 0; i < r; i++ {
+		for j := range rec {
+			rec[j] = strconv.FormatFloat(m.At(i, j), 'g', -1, 64)
+		}
+		err := cw.Write(rec)
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
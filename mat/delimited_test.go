@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReadDelimited(t *testing.T) {
+	for _, test := range []struct {
+		in    string
+		comma rune
+		want  *Dense
+	}{
+		{
+			in:    "1,2,3\n4,5,6\n",
+			comma: ',',
+			want:  NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			in:    "# A comment.\n1, -2.5e3\n 3,  4\n",
+			comma: ',',
+			want:  NewDense(2, 2, []float64{1, -2.5e3, 3, 4}),
+		},
+		{
+			in:    "1\t2\nNaN\t+Inf",
+			comma: '\t',
+			want:  NewDense(2, 2, []float64{1, 2, math.NaN(), math.Inf(1)}),
+		},
+	} {
+		got, err := ReadDelimited(strings.NewReader(test.in), test.comma)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", test.in, err)
+			continue
+		}
+		if !equalNaN(got, test.want) {
+			t.Errorf("unexpected result for %q:\ngot:\n% v\nwant:\n% v", test.in, Formatted(got), Formatted(test.want))
+		}
+	}
+
+	for _, test := range []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: "no delimited data"},
+		{in: "1,2\n3\n", want: "row 1"},
+		{in: "1,2\n3,x\n", want: "row 1 column 1"},
+	} {
+		_, err := ReadDelimited(strings.NewReader(test.in), ',')
+		if err == nil {
+			t.Errorf("expected error for %q", test.in)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("unexpected error for %q: got:%v want containing %q", test.in, err, test.want)
+		}
+	}
+}
+
+func TestDelimitedRoundTrip(t *testing.T) {
+	for _, comma := range []rune{',', '\t', ';'} {
+		m := NewDense(3, 2, []float64{1, 0.1, -1.0 / 3, 1e-300, math.MaxFloat64, 0})
+		var buf bytes.Buffer
+		err := WriteDelimited(&buf, m, comma)
+		if err != nil {
+			t.Fatalf("unexpected error writing delimited data: %v", err)
+		}
+		got, err := ReadDelimited(&buf, comma)
+		if err != nil {
+			t.Fatalf("unexpected error reading delimited data: %v", err)
+		}
+		if !Equal(got, m) {
+			t.Errorf("unexpected round trip result with comma %q:\ngot:\n% v\nwant:\n% v", comma, Formatted(got), Formatted(m))
+		}
+	}
+}
@@ -288,6 +288,46 @@ func (m *Dense) Mul(a, b Matrix) {
 		bT = blas.Trans
 	}
 
+	// Diagonal matrices scale the rows or the columns
+	// of the other operand.
+	if aU, ok := aU.(*DiagDense); ok {
+		if bUrm, ok := bU.(RawMatrixer); ok && restore == nil {
+			m.checkOverlap(bUrm.RawMatrix())
+		}
+		m.Copy(b)
+		for i := 0; i < ar; i++ {
+			blas64.Scal(bc, aU.at(i, i), blas64.Vector{Inc: 1, Data: m.mat.Data[i*m.mat.Stride:]})
+		}
+		return
+	}
+	if bU, ok := bU.(*DiagDense); ok {
+		if aUrm, ok := aU.(RawMatrixer); ok && restore == nil {
+			m.checkOverlap(aUrm.RawMatrix())
+		}
+		m.Copy(a)
+		for j := 0; j < bc; j++ {
+			blas64.Scal(ar, bU.at(j, j), blas64.Vector{Inc: m.mat.Stride, Data: m.mat.Data[j:]})
+		}
+		return
+	}
+
+	// Sparse matrices are multiplied by iterating
+	// over their non-zero elements.
+	if aU, ok := aU.(sparse); ok {
+		if bUrm, ok := bU.(RawMatrixer); ok && restore == nil {
+			m.checkOverlap(bUrm.RawMatrix())
+		}
+		m.mulSparseLeft(aU, aTrans, b)
+		return
+	}
+	if bU, ok := bU.(sparse); ok {
+		if aUrm, ok := aU.(RawMatrixer); ok && restore == nil {
+			m.checkOverlap(aUrm.RawMatrix())
+		}
+		m.mulSparseRight(a, bU, bTrans)
+		return
+	}
+
 	// Some of the cases do not have a transpose option, so create
 	// temporary memory.
 	// C = A^T * B = (B^T * A)^T
@@ -445,6 +485,72 @@ func (m *Dense) Mul(a, b Matrix) {
 	}
 }
 
+// mulSparseLeft places the product of the sparse matrix a, transposed if
+// aTrans is true, and b into the receiver. The receiver must have the shape
+// of the product and must not alias b.
+func (m *Dense) mulSparseLeft(a sparse, aTrans bool, b Matrix) {
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		zero(m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c])
+	}
+	bU, bTrans := untranspose(b)
+	bUrm, isRaw := bU.(RawMatrixer)
+	var bmat blas64.General
+	if isRaw {
+		bmat = bUrm.RawMatrix()
+	}
+	a.DoNonZero(func(i, j int, v float64) {
+		if aTrans {
+			i, j = j, i
+		}
+		// Row i of the product accumulates v times row j of b.
+		dst := blas64.Vector{Inc: 1, Data: m.mat.Data[i*m.mat.Stride:]}
+		switch {
+		case isRaw && !bTrans:
+			blas64.Axpy(c, v, blas64.Vector{Inc: 1, Data: bmat.Data[j*bmat.Stride:]}, dst)
+		case isRaw && bTrans:
+			blas64.Axpy(c, v, blas64.Vector{Inc: bmat.Stride, Data: bmat.Data[j:]}, dst)
+		default:
+			for k := 0; k < c; k++ {
+				dst.Data[k] += v * b.At(j, k)
+			}
+		}
+	})
+}
+
+// mulSparseRight places the product of a and the sparse matrix b, transposed
+// if bTrans is true, into the receiver. The receiver must have the shape of
+// the product and must not alias a.
+func (m *Dense) mulSparseRight(a Matrix, b sparse, bTrans bool) {
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		zero(m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c])
+	}
+	aU, aTrans := untranspose(a)
+	aUrm, isRaw := aU.(RawMatrixer)
+	var amat blas64.General
+	if isRaw {
+		amat = aUrm.RawMatrix()
+	}
+	b.DoNonZero(func(i, j int, v float64) {
+		if bTrans {
+			i, j = j, i
+		}
+		// Column j of the product accumulates v times column i of a.
+		dst := blas64.Vector{Inc: m.mat.Stride, Data: m.mat.Data[j:]}
+		switch {
+		case isRaw && !aTrans:
+			blas64.Axpy(r, v, blas64.Vector{Inc: amat.Stride, Data: amat.Data[i:]}, dst)
+		case isRaw && aTrans:
+			blas64.Axpy(r, v, blas64.Vector{Inc: 1, Data: amat.Data[i*amat.Stride:]}, dst)
+		default:
+			for k := 0; k < r; k++ {
+				dst.Data[k*dst.Inc] += v * a.At(k, i)
+			}
+		}
+	})
+}
+
 // strictCopy copies a into m panicking if the shape of a and m differ.
 func strictCopy(m *Dense, a Matrix) {
 	r, c := m.Copy(a)
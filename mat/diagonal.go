@@ -0,0 +1,165 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+var (
+	diagDense *DiagDense
+	_         Matrix    = diagDense
+	_         Symmetric = diagDense
+	_         Banded    = diagDense
+
+	_ NonZeroDoer    = diagDense
+	_ RowNonZeroDoer = diagDense
+	_ ColNonZeroDoer = diagDense
+)
+
+// DiagDense represents a square diagonal matrix stored as the vector of its
+// diagonal elements.
+type DiagDense struct {
+	mat blas64.Vector
+	n   int
+}
+
+// NewDiagDense creates a new n×n diagonal matrix with the diagonal elements
+// in data. If data == nil, a new slice is allocated for the backing slice. If
+// len(data) == n, data is used as the backing slice, and changes to the
+// elements of the returned DiagDense will be reflected in data. If neither of
+// these is true, NewDiagDense will panic.
+func NewDiagDense(n int, data []float64) *DiagDense {
+	if n < 0 {
+		panic("mat: negative dimension")
+	}
+	if data != nil && len(data) != n {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float64, n)
+	}
+	return &DiagDense{
+		mat: blas64.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (d *DiagDense) Dims() (r, c int) {
+	return d.n, d.n
+}
+
+// Diag returns the number of rows and columns in the matrix.
+func (d *DiagDense) Diag() int {
+	return d.n
+}
+
+// Symmetric returns the size of the receiver.
+func (d *DiagDense) Symmetric() int {
+	return d.n
+}
+
+// Bandwidth returns the lower and upper bandwidths of the matrix.
+// These values are always zero for diagonal matrices.
+func (d *DiagDense) Bandwidth() (kl, ku int) {
+	return 0, 0
+}
+
+// T implements the Matrix interface. Diagonal matrices, by definition, are
+// equal to their transpose, and this is a no-op.
+func (d *DiagDense) T() Matrix {
+	return d
+}
+
+// TBand implements the Banded interface.
+func (d *DiagDense) TBand() Banded {
+	return d
+}
+
+// NNZ returns the number of stored elements of the matrix.
+func (d *DiagDense) NNZ() int {
+	return d.n
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of d. The function fn
+// takes a row/column index and the element value of d at (i, j).
+func (d *DiagDense) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < d.n; i++ {
+		v := d.mat.Data[i*d.mat.Inc]
+		if v != 0 {
+			fn(i, i, v)
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of d. The function fn
+// takes a row/column index and the element value of d at (i, j).
+func (d *DiagDense) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || d.n <= i {
+		panic(ErrRowAccess)
+	}
+	v := d.mat.Data[i*d.mat.Inc]
+	if v != 0 {
+		fn(i, i, v)
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of d. The function fn
+// takes a row/column index and the element value of d at (i, j).
+func (d *DiagDense) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || d.n <= j {
+		panic(ErrColAccess)
+	}
+	v := d.mat.Data[j*d.mat.Inc]
+	if v != 0 {
+		fn(j, j, v)
+	}
+}
+
+// solve solves the system A * X = B where A is the receiver, placing the
+// result in m. The condition number of A is the ratio of the largest and the
+// smallest absolute values of its diagonal elements.
+func (d *DiagDense) solve(m *Dense, b Matrix) error {
+	n := d.n
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	dmax := 0.0
+	dmin := math.Inf(1)
+	for i := 0; i < n; i++ {
+		v := math.Abs(d.mat.Data[i*d.mat.Inc])
+		dmax = math.Max(dmax, v)
+		dmin = math.Min(dmin, v)
+	}
+	if dmin == 0 {
+		return Condition(math.Inf(1))
+	}
+	for i := 0; i < n; i++ {
+		blas64.Scal(bc, 1/d.mat.Data[i*d.mat.Inc], blas64.Vector{Inc: 1, Data: m.mat.Data[i*m.mat.Stride:]})
+	}
+	if cond := dmax / dmin; cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
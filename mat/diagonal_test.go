@@ -0,0 +1,131 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewDiagDense(t *testing.T) {
+	d := NewDiagDense(3, []float64{1, 2, 3})
+	want := NewDense(3, 3, []float64{
+		1, 0, 0,
+		0, 2, 0,
+		0, 0, 3,
+	})
+	if !Equal(d, want) {
+		t.Errorf("unexpected value via mat.Equal:\ngot:\n% v\nwant:\n% v", Formatted(d), Formatted(want))
+	}
+	if d.Diag() != 3 || d.Symmetric() != 3 {
+		t.Errorf("unexpected size: got:%d want:3", d.Diag())
+	}
+	if kl, ku := d.Bandwidth(); kl != 0 || ku != 0 {
+		t.Errorf("unexpected bandwidth: got:(%d,%d) want:(0,0)", kl, ku)
+	}
+	d.SetDiag(1, -2)
+	if d.At(1, 1) != -2 {
+		t.Errorf("unexpected value after SetDiag: got:%v want:-2", d.At(1, 1))
+	}
+	if panicked, _ := panics(func() { d.At(3, 0) }); !panicked {
+		t.Errorf("expected panic for out of range access")
+	}
+	if panicked, _ := panics(func() { NewDiagDense(3, make([]float64, 2)) }); !panicked {
+		t.Errorf("expected panic for mismatched data length")
+	}
+}
+
+func TestDiagDenseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c int
+	}{
+		{1, 1},
+		{3, 5},
+		{5, 3},
+		{4, 4},
+	} {
+		d := NewDiagDense(test.r, nil)
+		for i := 0; i < test.r; i++ {
+			d.SetDiag(i, rnd.NormFloat64())
+		}
+		var dd Dense
+		dd.Clone(d)
+		b := NewDense(test.r, test.c, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		var bT Dense
+		bT.Clone(b.T())
+
+		// Diagonal left operand.
+		var got, want Dense
+		got.Mul(d, b)
+		want.Mul(&dd, b)
+		if !EqualApprox(&got, &want, 1e-15) {
+			t.Errorf("%d×%d: unexpected result for diagonal left operand", test.r, test.c)
+		}
+		got.Reset()
+		got.Mul(d, bT.T())
+		if !EqualApprox(&got, &want, 1e-15) {
+			t.Errorf("%d×%d: unexpected result for diagonal left operand with transposed right operand", test.r, test.c)
+		}
+
+		// Diagonal right operand.
+		got.Reset()
+		want.Reset()
+		got.Mul(&bT, d)
+		want.Mul(&bT, &dd)
+		if !EqualApprox(&got, &want, 1e-15) {
+			t.Errorf("%d×%d: unexpected result for diagonal right operand", test.r, test.c)
+		}
+
+		if test.r == test.c {
+			// Aliased receiver.
+			var want Dense
+			want.Mul(&dd, b)
+			b.Mul(d, b)
+			if !EqualApprox(b, &want, 1e-15) {
+				t.Errorf("%d×%d: unexpected result for aliased receiver", test.r, test.c)
+			}
+		}
+	}
+}
+
+func TestDiagDenseSolve(t *testing.T) {
+	d := NewDiagDense(3, []float64{2, -4, 0.5})
+	b := NewDense(3, 2, []float64{
+		2, 4,
+		8, -4,
+		1, 0.5,
+	})
+	want := NewDense(3, 2, []float64{
+		1, 2,
+		-2, 1,
+		2, 1,
+	})
+	var x Dense
+	if err := x.Solve(d, b); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !EqualApprox(&x, want, 1e-15) {
+		t.Errorf("unexpected solution:\ngot:\n% v\nwant:\n% v", Formatted(&x), Formatted(want))
+	}
+
+	var v VecDense
+	err := v.SolveVec(d, NewVecDense(3, []float64{2, 8, 1}))
+	if err != nil {
+		t.Errorf("unexpected error from SolveVec: %v", err)
+	}
+	if !EqualApprox(&v, want.ColView(0), 1e-15) {
+		t.Errorf("unexpected SolveVec solution")
+	}
+
+	d.SetDiag(1, 0)
+	x.Reset()
+	if _, ok := x.Solve(d, b).(Condition); !ok {
+		t.Errorf("expected Condition error for singular diagonal matrix")
+	}
+}
@@ -5,13 +5,15 @@
 package mat
 
 import (
+	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
 const (
-	badFact   = "mat: use without successful factorization"
-	badNoVect = "mat: eigenvectors not computed"
+	badFact     = "mat: use without successful factorization"
+	badNoVect   = "mat: eigenvectors not computed"
+	badInterval = "mat: empty eigenvalue interval"
 )
 
 // EigenSym is a type for creating and manipulating the Eigen decomposition of
@@ -60,9 +62,82 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	return true
 }
 
+// FactorizeIndex computes the eigenvalues of the symmetric matrix a with
+// indices il through iu inclusive, where the eigenvalues are indexed from zero
+// in ascending order, and optionally the corresponding eigenvectors. The
+// eigenpairs are computed by the MRRR algorithm, which is faster than the
+// full decomposition when only a few eigenpairs are required. FactorizeIndex
+// will panic unless 0 <= il <= iu < n.
+//
+// FactorizeIndex returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (e *EigenSym) FactorizeIndex(a Symmetric, il, iu int, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	if il < 0 || iu < il || n <= iu {
+		panic(ErrIndexOutOfRange)
+	}
+	return e.factorizeSelected(a, lapack.IndexEVs, 0, 0, il, iu, vectors)
+}
+
+// FactorizeRange computes the eigenvalues of the symmetric matrix a that lie in
+// the half-open interval (vl, vu], and optionally the corresponding
+// eigenvectors. FactorizeRange will panic if vl >= vu. The factorization
+// holds no eigenvalues if none lie in the interval.
+//
+// FactorizeRange returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeRange(a Symmetric, vl, vu float64, vectors bool) (ok bool) {
+	if vl >= vu {
+		panic(badInterval)
+	}
+	return e.factorizeSelected(a, lapack.ValueEVs, vl, vu, 0, 0, vectors)
+}
+
+func (e *EigenSym) factorizeSelected(a Symmetric, rng lapack.EVRange, vl, vu float64, il, iu int, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	sd := NewSymDense(n, nil)
+	sd.CopySym(a)
+
+	jobz := lapack.EVJob(lapack.None)
+	var z blas64.General
+	if vectors {
+		jobz = lapack.ComputeEV
+		z = blas64.General{
+			Rows:   n,
+			Cols:   n,
+			Stride: n,
+			Data:   make([]float64, n*n),
+		}
+	}
+	w := make([]float64, n)
+	iwork := getInts(12*n, false)
+	work := []float64{0}
+	lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, 0, w, z, work, -1, iwork)
+
+	work = getFloats(int(work[0]), false)
+	m, ok := lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, 0, w, z, work, len(work), iwork)
+	putFloats(work)
+	putInts(iwork)
+	if !ok {
+		e.vectorsComputed = false
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w[:m]
+	e.vectors = nil
+	if vectors && m > 0 {
+		z.Cols = m
+		e.vectors = &Dense{mat: z, capRows: n, capCols: n}
+	}
+	return true
+}
+
 // succFact returns whether the receiver contains a successful factorization.
 func (e *EigenSym) succFact() bool {
-	return len(e.values) != 0
+	return e.values != nil
 }
 
 // Values extracts the eigenvalues of the factorized matrix. If dst is
@@ -91,7 +166,8 @@ func (e *EigenSym) Values(dst []float64) []float64 {
 // respective eigenvalue returned by e.Values.
 //
 // EigenvectorsSym panics if the factorization was not successful or if the
-// decomposition did not compute the eigenvectors.
+// decomposition did not compute the eigenvectors. It panics with
+// ErrZeroLength if the factorization holds no eigenvalues.
 func (m *Dense) EigenvectorsSym(e *EigenSym) {
 	if !e.succFact() {
 		panic(badFact)
@@ -99,7 +175,11 @@ func (m *Dense) EigenvectorsSym(e *EigenSym) {
 	if !e.vectorsComputed {
 		panic(badNoVect)
 	}
-	m.reuseAs(len(e.values), len(e.values))
+	if len(e.values) == 0 {
+		panic(ErrZeroLength)
+	}
+	r, c := e.vectors.Dims()
+	m.reuseAs(r, c)
 	m.Copy(e.vectors)
 }
 
@@ -231,7 +311,7 @@ func (e *Eigen) Values(dst []complex128) []complex128 {
 // where i is the imaginary unit. The computed eigenvectors are normalized to
 // have Euclidean norm equal to 1 and largest component real.
 //
-// BUG: This signature and behavior will change when issue #308 is resolved.
+// VectorsTo returns the eigenvectors as complex vectors without this packing.
 func (e *Eigen) Vectors() *Dense {
 	if !e.succFact() {
 		panic(badFact)
@@ -248,7 +328,7 @@ func (e *Eigen) Vectors() *Dense {
 //
 // See the documentation in lapack64.Geev for the format of the vectors.
 //
-// BUG: This signature and behavior will change when issue #308 is resolved.
+// LeftVectorsTo returns the eigenvectors as complex vectors without this packing.
 func (e *Eigen) LeftVectors() *Dense {
 	if !e.succFact() {
 		panic(badFact)
@@ -258,3 +338,66 @@ func (e *Eigen) LeftVectors() *Dense {
 	}
 	return DenseCopyOf(e.lVectors)
 }
+
+// VectorsTo stores the right eigenvectors of the decomposition into the columns
+// of dst in the same order as their eigenvalues. If dst is empty, VectorsTo
+// will resize dst to be n×n. When dst is non-empty, VectorsTo will panic if dst
+// is not n×n. VectorsTo will also panic if the right eigenvectors were not
+// computed during the factorization, or if the receiver does not contain a
+// successful factorization.
+//
+// The computed eigenvectors are normalized to have Euclidean norm equal to 1
+// and largest component real.
+func (e *Eigen) VectorsTo(dst *CDense) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.right {
+		panic(badNoVect)
+	}
+	dst.reuseAs(e.n, e.n)
+	e.complexEigenTo(dst, e.rVectors)
+}
+
+// LeftVectorsTo stores the left eigenvectors of the decomposition into the
+// columns of dst in the same order as their eigenvalues. A left eigenvector
+// u_j of A satisfies
+//  u_jᴴ * A = λ_j * u_jᴴ.
+// If dst is empty, LeftVectorsTo will resize dst to be n×n. When dst is
+// non-empty, LeftVectorsTo will panic if dst is not n×n. LeftVectorsTo will
+// also panic if the left eigenvectors were not computed during the
+// factorization, or if the receiver does not contain a successful
+// factorization.
+//
+// The computed eigenvectors are normalized to have Euclidean norm equal to 1
+// and largest component real.
+func (e *Eigen) LeftVectorsTo(dst *CDense) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.left {
+		panic(badNoVect)
+	}
+	dst.reuseAs(e.n, e.n)
+	e.complexEigenTo(dst, e.lVectors)
+}
+
+// complexEigenTo extracts the complex eigenvectors from the real matrix d
+// in the format returned by lapack64.Geev and stores them into dst.
+func (e *Eigen) complexEigenTo(dst *CDense, d *Dense) {
+	for j := 0; j < e.n; j++ {
+		if imag(e.values[j]) == 0 {
+			for i := 0; i < e.n; i++ {
+				dst.set(i, j, complex(d.at(i, j), 0))
+			}
+			continue
+		}
+		for i := 0; i < e.n; i++ {
+			re := d.at(i, j)
+			im := d.at(i, j+1)
+			dst.set(i, j, complex(re, im))
+			dst.set(i, j+1, complex(re, -im))
+		}
+		j++
+	}
+}
@@ -74,9 +74,53 @@ func TestEigen(t *testing.T) {
 		if !Equal(e1.LeftVectors(), e3.LeftVectors()) {
 			t.Errorf("right eigenvector mismatch. Case %v", i)
 		}
+	}
+}
+
+func TestEigenVectorsTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		var e Eigen
+		ok := e.Factorize(a, true, true)
+		if !ok {
+			t.Fatalf("bad factorization for n = %d", n)
+		}
+		values := e.Values(nil)
+		ac := NewCDense(n, n, nil)
+		lambda := NewCDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ac.Set(i, j, complex(a.At(i, j), 0))
+			}
+			lambda.Set(i, i, values[i])
+		}
+
+		var vr, vl CDense
+		e.VectorsTo(&vr)
+		e.LeftVectorsTo(&vl)
+
+		// Check A * V = V * Λ.
+		var got, want CDense
+		got.Mul(ac, &vr)
+		want.Mul(&vr, lambda)
+		if !CEqualApprox(&got, &want, 1e-12) {
+			t.Errorf("right eigenvectors do not satisfy the eigenvalue equation for n = %d", n)
+		}
 
-		// TODO(btracey): Also add in a test for correctness when #308 is
-		// resolved and we have a CMat.Mul().
+		// Check Uᴴ * A = Λ * Uᴴ.
+		got.Reset()
+		want.Reset()
+		got.Mul(vl.H(), ac)
+		want.Mul(lambda, vl.H())
+		if !CEqualApprox(&got, &want, 1e-12) {
+			t.Errorf("left eigenvectors do not satisfy the eigenvalue equation for n = %d", n)
+		}
 	}
 }
 
@@ -162,3 +206,96 @@ func TestSymEigen(t *testing.T) {
 		}
 	}
 }
+
+func TestSymEigenSelected(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 5, 10, 70} {
+		for cas := 0; cas < 5; cas++ {
+			a := make([]float64, n*n)
+			for i := range a {
+				a[i] = rnd.NormFloat64()
+			}
+			s := NewSymDense(n, a)
+			var full EigenSym
+			ok := full.Factorize(s, false)
+			if !ok {
+				t.Fatalf("Bad test")
+			}
+			all := full.Values(nil)
+
+			il := rnd.Intn(n)
+			iu := il + rnd.Intn(n-il)
+			var byIndex EigenSym
+			ok = byIndex.FactorizeIndex(s, il, iu, true)
+			if !ok {
+				t.Errorf("n=%d: unexpected failure of FactorizeIndex", n)
+				continue
+			}
+			if !floats.EqualApprox(byIndex.Values(nil), all[il:iu+1], 1e-12) {
+				t.Errorf("n=%d: eigenvalue mismatch for indices [%d,%d]", n, il, iu)
+			}
+			checkSelectedEigen(t, s, &byIndex)
+
+			// Choose an interval that does not have an end point
+			// close to an eigenvalue.
+			vl := all[il] - 0.5
+			if il > 0 {
+				vl = (all[il-1] + all[il]) / 2
+			}
+			vu := all[iu] + 0.5
+			if iu < n-1 {
+				vu = (all[iu] + all[iu+1]) / 2
+			}
+			var byValue EigenSym
+			ok = byValue.FactorizeRange(s, vl, vu, true)
+			if !ok {
+				t.Errorf("n=%d: unexpected failure of FactorizeRange", n)
+				continue
+			}
+			if !floats.EqualApprox(byValue.Values(nil), all[il:iu+1], 1e-12) {
+				t.Errorf("n=%d: eigenvalue mismatch for interval (%v,%v]", n, vl, vu)
+			}
+			checkSelectedEigen(t, s, &byValue)
+		}
+	}
+
+	// Check an interval holding no eigenvalues.
+	var es EigenSym
+	ok := es.FactorizeRange(NewSymDense(2, []float64{1, 0, 0, 2}), 3, 4, true)
+	if !ok {
+		t.Errorf("unexpected failure for empty interval")
+	}
+	if len(es.Values(nil)) != 0 {
+		t.Errorf("unexpected eigenvalues for empty interval")
+	}
+}
+
+// checkSelectedEigen checks that the eigenvectors held by es are orthonormal
+// and that they are eigenvectors of s.
+func checkSelectedEigen(t *testing.T, s *SymDense, es *EigenSym) {
+	var vecs Dense
+	vecs.EigenvectorsSym(es)
+	n, k := vecs.Dims()
+	values := es.Values(nil)
+	if k != len(values) {
+		t.Errorf("unexpected number of eigenvectors: got %d, want %d", k, len(values))
+		return
+	}
+	var vtv Dense
+	vtv.Mul(vecs.T(), &vecs)
+	if !EqualApprox(&vtv, eye(k), 1e-10) {
+		t.Errorf("n=%d: eigenvectors not orthonormal", n)
+	}
+	for i := 0; i < k; i++ {
+		v := vecs.ColView(i).(*VecDense)
+		var m VecDense
+		m.MulVec(s, v)
+
+		var scal VecDense
+		scal.ScaleVec(values[i], v)
+
+		if !EqualApprox(&m, &scal, 1e-8) {
+			t.Errorf("n=%d: eigenvalue %d does not match", n, i)
+		}
+	}
+}
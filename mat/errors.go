@@ -133,6 +133,7 @@ var (
 	ErrSliceLengthMismatch = Error{"matrix: input slice length mismatch"}
 	ErrNotPSD              = Error{"matrix: input not positive symmetric definite"}
 	ErrFailedEigen         = Error{"matrix: eigendecomposition not successful"}
+	ErrNegativeEigenvalue  = Error{"matrix: matrix has a negative real eigenvalue"}
 )
 
 // ErrorStack represents matrix handling errors that have been recovered by Maybe wrappers.
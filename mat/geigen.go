@@ -0,0 +1,229 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// GEigen is a type for creating and using the generalized eigenvalue
+// decomposition of a pair of dense matrices.
+type GEigen struct {
+	n int // The size of the factorized matrices.
+
+	right bool // have the right eigenvectors been computed
+
+	alpha   []complex128
+	beta    []float64
+	vectors *CDense
+}
+
+// Factorize computes the generalized eigenvalues of the pair of n×n matrices
+// (A, B), and optionally the right generalized eigenvectors.
+//
+// A generalized eigenvalue/eigenvector combination is defined by
+//  A * x = λ * B * x
+// where x is the column vector called an eigenvector, and λ is the
+// corresponding eigenvalue. The eigenvalues are represented by the ratios
+//  λ = α / β
+// which are well defined even when B is singular, in which case β may be
+// zero and the corresponding eigenvalue is infinite. If β and α are both
+// zero, the pencil A - λ B is singular and its eigenvalues are not well
+// defined.
+//
+// The decomposition is computed by reducing (A, B) to generalized real Schur
+// form with the QZ algorithm as implemented by lapack64.Ggev.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+//
+// Factorize will panic if A and B are not square or not of the same size.
+func (e *GEigen) Factorize(a, b Matrix, right bool) (ok bool) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	br, bc := b.Dims()
+	if br != bc {
+		panic(ErrSquare)
+	}
+	if br != n {
+		panic(ErrShape)
+	}
+	e.alpha = nil
+	e.beta = nil
+	e.vectors = nil
+	if n == 0 {
+		return false
+	}
+
+	ad := DenseCopyOf(a)
+	bd := DenseCopyOf(b)
+
+	var vr Dense
+	var jobvr lapack.RightEVJob = lapack.None
+	if right {
+		vr = *NewDense(n, n, nil)
+		jobvr = lapack.ComputeRightEV
+	}
+
+	alphar := getFloats(n, false)
+	defer putFloats(alphar)
+	alphai := getFloats(n, false)
+	defer putFloats(alphai)
+	beta := make([]float64, n)
+
+	work := []float64{0}
+	lapack64.Ggev(lapack.None, jobvr, ad.mat, bd.mat, alphar, alphai, beta, blas64.General{}, vr.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Ggev(lapack.None, jobvr, ad.mat, bd.mat, alphar, alphai, beta, blas64.General{}, vr.mat, work, len(work))
+	putFloats(work)
+	if !ok {
+		return false
+	}
+
+	e.n = n
+	e.right = right
+	e.alpha = make([]complex128, n)
+	for i := range e.alpha {
+		e.alpha[i] = complex(alphar[i], alphai[i])
+	}
+	e.beta = beta
+	if right {
+		e.vectors = NewCDense(n, n, nil)
+		e.complexVectorsTo(e.vectors, &vr)
+	}
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *GEigen) succFact() bool {
+	return len(e.alpha) != 0
+}
+
+// Values extracts the generalized eigenvalues λ = α / β of the factorized
+// pair of matrices. Eigenvalues with β equal to zero are returned as
+// cmplx.Inf(). If dst is non-nil, the values are stored in-place into dst.
+// In this case dst must have length n, otherwise Values will panic. If dst
+// is nil, then a new slice will be allocated of the proper length and filled
+// with the eigenvalues.
+//
+// Values panics if the decomposition was not successful.
+func (e *GEigen) Values(dst []complex128) []complex128 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, e.n)
+	}
+	if len(dst) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	for i, a := range e.alpha {
+		if e.beta[i] == 0 {
+			dst[i] = cmplx.Inf()
+			continue
+		}
+		dst[i] = a / complex(e.beta[i], 0)
+	}
+	return dst
+}
+
+// ValuesAlpha extracts the numerators α of the generalized eigenvalues of the
+// factorized pair of matrices. If dst is non-nil, the values are stored
+// in-place into dst. In this case dst must have length n, otherwise
+// ValuesAlpha will panic. If dst is nil, then a new slice will be allocated of
+// the proper length.
+//
+// ValuesAlpha panics if the decomposition was not successful.
+func (e *GEigen) ValuesAlpha(dst []complex128) []complex128 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, e.n)
+	}
+	if len(dst) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.alpha)
+	return dst
+}
+
+// ValuesBeta extracts the denominators β of the generalized eigenvalues of
+// the factorized pair of matrices. The values of β are real and non-negative.
+// If dst is non-nil, the values are stored in-place into dst. In this case
+// dst must have length n, otherwise ValuesBeta will panic. If dst is nil,
+// then a new slice will be allocated of the proper length.
+//
+// ValuesBeta panics if the decomposition was not successful.
+func (e *GEigen) ValuesBeta(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]float64, e.n)
+	}
+	if len(dst) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.beta)
+	return dst
+}
+
+// VectorsTo stores the right generalized eigenvectors of the decomposition
+// into the columns of dst in the same order as their eigenvalues. Each
+// eigenvector x_j satisfies
+//  β_j * A * x_j = α_j * B * x_j.
+// If dst is empty, VectorsTo will resize dst to be n×n. When dst is
+// non-empty, VectorsTo will panic if dst is not n×n. VectorsTo will also
+// panic if the eigenvectors were not computed during the factorization, or if
+// the receiver does not contain a successful factorization.
+//
+// The computed eigenvectors are normalized to have Euclidean norm equal to 1.
+func (e *GEigen) VectorsTo(dst *CDense) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.right {
+		panic(badNoVect)
+	}
+	dst.reuseAs(e.n, e.n)
+	dst.Copy(e.vectors)
+}
+
+// complexVectorsTo extracts the complex eigenvectors from the real matrix d
+// in the format returned by lapack64.Ggev, normalizes them to unit Euclidean
+// norm and stores them into dst.
+func (e *GEigen) complexVectorsTo(dst *CDense, d *Dense) {
+	for j := 0; j < e.n; j++ {
+		if imag(e.alpha[j]) == 0 {
+			var norm float64
+			for i := 0; i < e.n; i++ {
+				norm = math.Hypot(norm, d.at(i, j))
+			}
+			for i := 0; i < e.n; i++ {
+				dst.set(i, j, complex(d.at(i, j)/norm, 0))
+			}
+			continue
+		}
+		var norm float64
+		for i := 0; i < e.n; i++ {
+			norm = math.Hypot(norm, math.Hypot(d.at(i, j), d.at(i, j+1)))
+		}
+		for i := 0; i < e.n; i++ {
+			re := d.at(i, j) / norm
+			im := d.at(i, j+1) / norm
+			dst.set(i, j, complex(re, im))
+			dst.set(i, j+1, complex(re, -im))
+		}
+		j++
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+func TestGEigen(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n        int
+		singular int // Number of zero rows in B.
+	}{
+		{1, 0},
+		{2, 0},
+		{3, 0},
+		{5, 0},
+		{10, 0},
+		{20, 0},
+		{4, 1},
+		{8, 2},
+		{3, 3},
+	} {
+		n := test.n
+		a := NewDense(n, n, nil)
+		b := NewDense(n, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		for i := 0; i < test.singular; i++ {
+			for j := 0; j < n; j++ {
+				b.Set(i, j, 0)
+			}
+		}
+
+		var ge GEigen
+		ok := ge.Factorize(a, b, true)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		alpha := ge.ValuesAlpha(nil)
+		beta := ge.ValuesBeta(nil)
+		values := ge.Values(nil)
+		anorm := Norm(a, 1)
+		bnorm := Norm(b, 1)
+		var inf int
+		for i, v := range values {
+			if beta[i] < 0 {
+				t.Errorf("n=%d: negative beta %v", n, beta[i])
+			}
+			if beta[i] == 0 && !cmplx.IsInf(v) {
+				t.Errorf("n=%d: expected infinite eigenvalue, got %v", n, v)
+			}
+			// Infinite eigenvalues may only be computed
+			// to within roundoff.
+			if beta[i] <= 1e-12*bnorm {
+				inf++
+			}
+		}
+		if inf != test.singular {
+			t.Errorf("n=%d: unexpected number of infinite eigenvalues: got:%d want:%d", n, inf, test.singular)
+		}
+
+		// Check β*A*x = α*B*x for all eigenpairs.
+		var v CDense
+		ge.VectorsTo(&v)
+		ac := realToCDense(a)
+		bc := realToCDense(b)
+		var av, bv CDense
+		av.Mul(ac, &v)
+		bv.Mul(bc, &v)
+		for j := 0; j < n; j++ {
+			var res, norm float64
+			for i := 0; i < n; i++ {
+				r := complex(beta[j], 0)*av.At(i, j) - alpha[j]*bv.At(i, j)
+				res += cmplx.Abs(r)
+				norm += cmplx.Abs(v.At(i, j))
+			}
+			scale := beta[j]*anorm + cmplx.Abs(alpha[j])*bnorm
+			if res > 1e-12*float64(n)*scale*norm {
+				t.Errorf("n=%d: eigenpair %d residual too large: %v", n, j, res/(scale*norm))
+			}
+		}
+
+		if test.singular == 0 {
+			// The generalized eigenvalues of (A, B) are the
+			// eigenvalues of inv(B)*A.
+			var binva Dense
+			err := binva.Solve(b, a)
+			if err != nil {
+				continue
+			}
+			var eig Eigen
+			eig.Factorize(&binva, false, false)
+			for _, w := range eig.Values(nil) {
+				if !containsValue(values, w, 1e-8) {
+					t.Errorf("n=%d: eigenvalue %v of inv(B)*A not found", n, w)
+				}
+			}
+		}
+	}
+}
+
+func realToCDense(a *Dense) *CDense {
+	r, c := a.Dims()
+	m := NewCDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.set(i, j, complex(a.at(i, j), 0))
+		}
+	}
+	return m
+}
@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// Hessenberg is a type for creating and using the Hessenberg decomposition of
+// a square matrix.
+type Hessenberg struct {
+	h   *Dense
+	tau []float64
+}
+
+// Factorize computes the Hessenberg decomposition of the square matrix a.
+// The Hessenberg decomposition is a factorization of the matrix A such that
+//  A = Q * H * Q^T
+// where Q is an orthogonal matrix and H is an upper Hessenberg matrix, that
+// is, H[i,j] == 0 for i > j+1. H and Q can be extracted using the HTo and QTo
+// methods.
+//
+// Factorize will panic if a is not square.
+func (h *Hessenberg) Factorize(a Matrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if h.h == nil {
+		h.h = &Dense{}
+	}
+	h.h.Clone(a)
+	h.tau = make([]float64, max(0, r-1))
+	if r == 0 {
+		return
+	}
+	work := []float64{0}
+	lapack64.Gehrd(h.h.mat, 0, r-1, h.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Gehrd(h.h.mat, 0, r-1, h.tau, work, len(work))
+	putFloats(work)
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (h *Hessenberg) succFact() bool {
+	return h.h != nil && !h.h.IsZero()
+}
+
+// HTo extracts the n×n upper Hessenberg matrix H from a Hessenberg
+// decomposition. If dst is nil, a new matrix is allocated. The resulting H
+// matrix is returned.
+//
+// HTo will panic if the receiver does not contain a successful factorization.
+func (h *Hessenberg) HTo(dst *Dense) *Dense {
+	if !h.succFact() {
+		panic(badFact)
+	}
+	n := h.h.mat.Rows
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(h.h)
+	// Zero below the first subdiagonal where the
+	// elementary reflectors are stored.
+	for i := 2; i < n; i++ {
+		zero(dst.mat.Data[i*dst.mat.Stride : i*dst.mat.Stride+i-1])
+	}
+	return dst
+}
+
+// QTo extracts the n×n orthogonal matrix Q from a Hessenberg decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+//
+// QTo will panic if the receiver does not contain a successful factorization.
+func (h *Hessenberg) QTo(dst *Dense) *Dense {
+	if !h.succFact() {
+		panic(badFact)
+	}
+	n := h.h.mat.Rows
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(h.h)
+	work := []float64{0}
+	lapack64.Orghr(0, n-1, dst.mat, h.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Orghr(0, n-1, dst.mat, h.tau, work, len(work))
+	putFloats(work)
+	return dst
+}
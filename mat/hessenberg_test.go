@@ -0,0 +1,51 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHessenberg(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		a := NewDense(n, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+
+		var hess Hessenberg
+		hess.Factorize(a)
+		h := hess.HTo(nil)
+		q := hess.QTo(nil)
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < i-1; j++ {
+				if h.At(i, j) != 0 {
+					t.Errorf("n=%d: H not upper Hessenberg at (%d,%d)", n, i, j)
+				}
+			}
+		}
+		var qtq Dense
+		qtq.Mul(q.T(), q)
+		if !EqualApprox(&qtq, eye(n), 1e-13) {
+			t.Errorf("n=%d: Q not orthogonal", n)
+		}
+		var qh, qhqt Dense
+		qh.Mul(q, h)
+		qhqt.Mul(&qh, q.T())
+		if !EqualApprox(&qhqt, a, 1e-12) {
+			t.Errorf("n=%d: A != Q*H*Q^T", n)
+		}
+
+		// Extraction into a pre-sized receiver.
+		dst := NewDense(n, n, nil)
+		hess.HTo(dst)
+		if !Equal(dst, h) {
+			t.Errorf("n=%d: mismatch between HTo results", n)
+		}
+	}
+}
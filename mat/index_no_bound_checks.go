@@ -226,3 +226,113 @@ func (s *SymBandDense) set(i, j int, v float64) {
 	}
 	s.mat.Data[i*s.mat.Stride+pj] = v
 }
+
+// At returns the element at row i, column j.
+func (t *Tridiag) At(i, j int) float64 {
+	if uint(i) >= uint(t.n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.n) {
+		panic(ErrColAccess)
+	}
+	return t.at(i, j)
+}
+
+func (t *Tridiag) at(i, j int) float64 {
+	switch j - i {
+	case -1:
+		return t.dl[j]
+	case 0:
+		return t.d[i]
+	case 1:
+		return t.du[i]
+	}
+	return 0
+}
+
+// SetBand sets the element at row i, column j to the value v.
+// It panics if the location is outside the appropriate region of the matrix.
+func (t *Tridiag) SetBand(i, j int, v float64) {
+	if uint(i) >= uint(t.n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.n) {
+		panic(ErrColAccess)
+	}
+	if j-i < -1 || 1 < j-i {
+		panic(ErrBandSet)
+	}
+	t.set(i, j, v)
+}
+
+func (t *Tridiag) set(i, j int, v float64) {
+	switch j - i {
+	case -1:
+		t.dl[j] = v
+	case 0:
+		t.d[i] = v
+	case 1:
+		t.du[i] = v
+	}
+}
+
+// At returns the element at row i, column j.
+func (d *DiagDense) At(i, j int) float64 {
+	if uint(i) >= uint(d.n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(d.n) {
+		panic(ErrColAccess)
+	}
+	return d.at(i, j)
+}
+
+func (d *DiagDense) at(i, j int) float64 {
+	if i != j {
+		return 0
+	}
+	return d.mat.Data[i*d.mat.Inc]
+}
+
+// SetDiag sets the element at row i, column i to the value v.
+// It panics if the location is outside the appropriate region of the matrix.
+func (d *DiagDense) SetDiag(i int, v float64) {
+	if uint(i) >= uint(d.n) {
+		panic(ErrRowAccess)
+	}
+	d.set(i, v)
+}
+
+func (d *DiagDense) set(i int, v float64) {
+	d.mat.Data[i*d.mat.Inc] = v
+}
+
+// At returns the element at row i, column j.
+func (m *CDense) At(i, j int) complex128 {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	return m.at(i, j)
+}
+
+func (m *CDense) at(i, j int) complex128 {
+	return m.mat.Data[i*m.mat.Stride+j]
+}
+
+// Set sets the element at row i, column j to the value v.
+func (m *CDense) Set(i, j int, v complex128) {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	m.set(i, j, v)
+}
+
+func (m *CDense) set(i, j int, v complex128) {
+	m.mat.Data[i*m.mat.Stride+j] = v
+}
@@ -0,0 +1,476 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// LanczosSettings holds parameters of the restarted Lanczos methods used by
+// PartialEigenSym.FactorizeFunc and TruncatedSVD.FactorizeLanczos.
+type LanczosSettings struct {
+	// NCV is the dimension of the Krylov subspace built between restarts.
+	// NCV must be greater than the number of requested values k and it is
+	// reduced to the size of the problem if necessary. If NCV is zero,
+	// max(2*k+1, 20) is used.
+	NCV int
+
+	// Tol is the relative tolerance for the convergence of the computed
+	// values. A value is accepted when the norm of its residual is less
+	// than Tol times the magnitude of the largest computed value. If Tol
+	// is zero, the machine epsilon is used.
+	Tol float64
+
+	// MaxIter is the maximum number of restarts. If MaxIter is zero,
+	// 300 is used.
+	MaxIter int
+
+	// Src is the source of random numbers for the starting vector. If Src
+	// is nil, the global source in math/rand is used.
+	Src *rand.Rand
+}
+
+// lanczosParams returns the Krylov subspace dimension, the convergence
+// tolerance, the maximum number of restarts and the normal random number
+// generator for computing k values of a problem of size n.
+func lanczosParams(settings *LanczosSettings, k, n int) (ncv int, tol float64, maxIter int, norm func() float64) {
+	// dlamchE is the machine epsilon.
+	const dlamchE = 1.0 / (1 << 53)
+
+	var s LanczosSettings
+	if settings != nil {
+		s = *settings
+	}
+	ncv = s.NCV
+	if ncv == 0 {
+		ncv = max(2*k+1, 20)
+	}
+	if ncv <= k && k < n {
+		panic("mat: Krylov subspace dimension too small")
+	}
+	ncv = min(ncv, n)
+	tol = s.Tol
+	if tol == 0 {
+		tol = dlamchE
+	}
+	maxIter = s.MaxIter
+	if maxIter == 0 {
+		maxIter = 300
+	}
+	norm = rand.NormFloat64
+	if s.Src != nil {
+		norm = s.Src.NormFloat64
+	}
+	return ncv, tol, maxIter, norm
+}
+
+// PartialEigenSym is a type for computing a few eigenvalues and eigenvectors
+// of a large symmetric matrix.
+type PartialEigenSym struct {
+	values  []float64
+	vectors *Dense
+}
+
+// Factorize computes k eigenvalues at the end of the spectrum given by
+// which, and the corresponding eigenvectors, of the n×n symmetric matrix a
+// using the thick-restart Lanczos method. Factorize panics if k is not
+// positive or is greater than n, or if which is not a valid EigenSelection.
+// If settings is nil, the default settings are used.
+//
+// A is only accessed through matrix-vector products, so it may be sparse.
+// Please see PartialEigenSym.FactorizeFunc for the full documentation.
+func (e *PartialEigenSym) Factorize(a Symmetric, k int, which EigenSelection, settings *LanczosSettings) (ok bool) {
+	n := a.Symmetric()
+	mulVec := func(dst, x []float64) {
+		NewVecDense(n, dst).MulVec(a, NewVecDense(n, x))
+	}
+	return e.FactorizeFunc(n, mulVec, k, which, settings)
+}
+
+// FactorizeFunc computes k eigenvalues at the end of the spectrum given by
+// which, and the corresponding eigenvectors, of the n×n symmetric linear
+// operator A represented by the function mulVec, which must store the
+// product A*x into dst. The slices passed to mulVec have length n and must
+// not be retained. FactorizeFunc panics if k is not positive or is greater
+// than n, or if which is not a valid EigenSelection. If settings is nil, the
+// default settings are used.
+//
+// The eigenpairs are computed using the thick-restart Lanczos method, which
+// is mathematically equivalent to the implicitly restarted Lanczos method,
+// with full reorthogonalization of the Krylov basis. Only settings.NCV
+// vectors of length n are stored, so the method is suitable for large sparse
+// operators, such as the Laplacian of a graph, where only a few eigenpairs
+// are needed. Convergence is fastest when the wanted eigenvalues are well
+// separated from the rest of the spectrum. Eigenvalues that are close to an
+// expected value σ are more quickly found by using a mulVec that solves
+//  (A - σ*I) * dst = x
+// and selecting LargestMagnitudeEigen. Each eigenvalue θ of the shifted and
+// inverted operator then corresponds to the eigenvalue σ + 1/θ of A.
+//
+// FactorizeFunc returns whether the decomposition succeeded, that is whether
+// all k eigenpairs converged within settings.MaxIter restarts. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *PartialEigenSym) FactorizeFunc(n int, mulVec func(dst, x []float64), k int, which EigenSelection, settings *LanczosSettings) (ok bool) {
+	if k <= 0 || n < k {
+		panic(badTruncRank)
+	}
+	switch which {
+	default:
+		panic("mat: bad eigenvalue selection")
+	case LargestEigen, SmallestEigen, LargestMagnitudeEigen:
+	}
+	e.values, e.vectors, ok = lanczosSym(mulVec, n, k, which, settings)
+	if !ok {
+		e.values = nil
+		e.vectors = nil
+	}
+	return ok
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *PartialEigenSym) succFact() bool {
+	return len(e.values) != 0
+}
+
+// Values extracts the k computed eigenvalues. The eigenvalues are ordered
+// from the largest to the smallest for LargestEigen, from the smallest to the
+// largest for SmallestEigen and by decreasing magnitude for
+// LargestMagnitudeEigen. If dst is non-nil, the values are stored in-place into dst. In
+// this case dst must have length k, otherwise Values will panic. If dst is
+// nil, then a new slice will be allocated of the proper length and filled
+// with the eigenvalues.
+//
+// Values panics if the decomposition was not successful.
+func (e *PartialEigenSym) Values(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]float64, len(e.values))
+	}
+	if len(dst) != len(e.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.values)
+	return dst
+}
+
+// VectorsTo extracts the n×k matrix of the computed eigenvectors, storing the
+// result in-place into dst. Each eigenvector is a column corresponding to the
+// respective eigenvalue returned by e.Values. If dst is nil, a new matrix is
+// allocated. The resulting matrix is returned.
+//
+// VectorsTo panics if the decomposition was not successful.
+func (e *PartialEigenSym) VectorsTo(dst *Dense) *Dense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = &Dense{}
+	}
+	dst.reuseAs(e.vectors.Dims())
+	dst.Copy(e.vectors)
+	return dst
+}
+
+// lanczosSym computes k eigenpairs of the n×n symmetric linear operator
+// represented by mulVec, which stores the product of the operator with x into
+// dst. The eigenvalues selected by which are returned ordered from the wanted
+// end of the spectrum, with the eigenvectors in the columns of the returned
+// n×k matrix.
+//
+// lanczosSym builds an orthonormal basis V of a Krylov subspace satisfying
+//  A * V = V * H + f * e^T
+// where H = V^T * A * V is symmetric, and restarts the process with the
+// Ritz vectors of the wanted eigenvalues as described in
+//  Wu, K. and Simon, H. Thick-restart Lanczos method for large symmetric
+//  eigenvalue problems. SIAM J. Matrix Anal. Appl. 22(2) (2000) 602–616.
+// Full reorthogonalization is used.
+func lanczosSym(mulVec func(dst, x []float64), n, k int, which EigenSelection, settings *LanczosSettings) (values []float64, vectors *Dense, ok bool) {
+	w, tol, maxIter, norm := lanczosParams(settings, k, n)
+
+	// The rows of v hold the basis vectors.
+	v := NewDense(w, n, nil)
+	h := NewSymDense(w, nil)
+	f := make([]float64, n)
+	coef := make([]float64, w)
+	var beta float64
+	var kept int
+	var eig EigenSym
+	var y, tmp Dense
+	for iter := 0; ; iter++ {
+		// Start from the residual of the previous restart, or
+		// from a random vector in the first iteration.
+		newBasisVector(v, kept, f, beta, norm)
+		for j := kept; j < w; j++ {
+			mulVec(f, v.RawRowView(j))
+			for i := range coef {
+				coef[i] = 0
+			}
+			basis := v.mat
+			basis.Rows = j + 1
+			norm0 := blas64.Nrm2(n, blas64.Vector{Inc: 1, Data: f})
+			beta = gramSchmidt(basis, f, coef)
+			for i := 0; i <= j; i++ {
+				h.SetSym(i, j, coef[i])
+			}
+			if isBreakdown(beta, norm0) || j+1 == n {
+				beta = 0
+			}
+			if j+1 < w {
+				newBasisVector(v, j+1, f, beta, norm)
+			}
+		}
+
+		// Compute the Ritz pairs.
+		if !eig.Factorize(h, true) {
+			return nil, nil, false
+		}
+		theta := eig.Values(nil)
+		y.EigenvectorsSym(&eig)
+		idx := selectEigen(theta, which)
+		thetaMax := math.Max(math.Abs(theta[0]), math.Abs(theta[w-1]))
+		converged := true
+		for _, i := range idx[:k] {
+			if beta*math.Abs(y.At(w-1, i)) > tol*thetaMax {
+				converged = false
+				break
+			}
+		}
+		if converged || iter == maxIter {
+			values = make([]float64, k)
+			ysel := NewDense(w, k, nil)
+			for c, i := range idx[:k] {
+				values[c] = theta[i]
+				for r := 0; r < w; r++ {
+					ysel.set(r, c, y.at(r, i))
+				}
+			}
+			vectors = NewDense(n, k, nil)
+			vectors.Mul(v.T(), ysel)
+			return values, vectors, converged
+		}
+
+		// Restart with the Ritz vectors of the wanted end of the
+		// spectrum and the residual f.
+		kept = k + (w-k)/2
+		ysel := NewDense(w, kept, nil)
+		for c, i := range idx[:kept] {
+			for r := 0; r < w; r++ {
+				ysel.set(r, c, y.at(r, i))
+			}
+		}
+		tmp.Reset()
+		tmp.Mul(ysel.T(), v)
+		v.Slice(0, kept, 0, n).(*Dense).Copy(&tmp)
+		for i := 0; i < w; i++ {
+			for j := i; j < w; j++ {
+				h.SetSym(i, j, 0)
+			}
+		}
+		for c, i := range idx[:kept] {
+			h.SetSym(c, c, theta[i])
+		}
+	}
+}
+
+// selectEigen returns the indices of the ascending eigenvalues theta ordered
+// from the end of the spectrum given by which.
+func selectEigen(theta []float64, which EigenSelection) []int {
+	n := len(theta)
+	idx := make([]int, n)
+	switch which {
+	case LargestEigen:
+		for i := range idx {
+			idx[i] = n - 1 - i
+		}
+	case SmallestEigen:
+		for i := range idx {
+			idx[i] = i
+		}
+	case LargestMagnitudeEigen:
+		// Merge the two ends of the spectrum.
+		lo, hi := 0, n-1
+		for i := range idx {
+			if math.Abs(theta[hi]) >= math.Abs(theta[lo]) {
+				idx[i] = hi
+				hi--
+			} else {
+				idx[i] = lo
+				lo++
+			}
+		}
+	}
+	return idx
+}
+
+// isBreakdown returns whether the norm beta of a vector orthogonalized
+// against a Krylov basis is negligible compared to its norm before
+// orthogonalization, norm0, indicating that the basis spans an invariant
+// subspace.
+func isBreakdown(beta, norm0 float64) bool {
+	// dlamchE is the machine epsilon.
+	const dlamchE = 1.0 / (1 << 53)
+	return beta <= 16*dlamchE*norm0
+}
+
+// newBasisVector stores f/beta into row j of the basis v. If beta is zero,
+// a random unit vector orthogonal to the leading j rows of v is stored
+// instead, generated using norm.
+func newBasisVector(v *Dense, j int, f []float64, beta float64, norm func() float64) {
+	row := v.RawRowView(j)
+	if beta != 0 {
+		for i, fi := range f {
+			row[i] = fi / beta
+		}
+		return
+	}
+	basis := v.mat
+	basis.Rows = j
+	for {
+		for i := range row {
+			row[i] = norm()
+		}
+		nrm := blas64.Nrm2(len(row), blas64.Vector{Inc: 1, Data: row})
+		if !isBreakdown(gramSchmidt(basis, row, nil), nrm) {
+			break
+		}
+	}
+	blas64.Scal(len(row), 1/blas64.Nrm2(len(row), blas64.Vector{Inc: 1, Data: row}), blas64.Vector{Inc: 1, Data: row})
+}
+
+// FactorizeLanczos computes the k leading singular triplets of the m×n
+// matrix a using the thick-restart Golub-Kahan-Lanczos bidiagonalization
+// method. FactorizeLanczos panics if k is not positive or is greater than
+// min(m,n). If settings is nil, the default settings are used.
+//
+// A is only accessed through matrix-vector products with A and A^T, so it
+// may be sparse. Unlike FactorizeRandomized, the triplets are computed to
+// the accuracy given by settings.Tol.
+//
+// FactorizeLanczos returns whether the decomposition succeeded, that is
+// whether all k triplets converged within settings.MaxIter restarts. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+//
+// The algorithm builds orthonormal bases U and V satisfying
+//  A * V = U * B
+//  A^T * U = V * B^T + f * e^T
+// where B = U^T * A * V is upper triangular, and restarts the process with
+// the singular vectors of B corresponding to its largest singular values as
+// described in
+//  Baglama, J. and Reichel, L. Augmented implicitly restarted Lanczos
+//  bidiagonalization methods. SIAM J. Sci. Comput. 27(1) (2005) 19–42.
+func (svd *TruncatedSVD) FactorizeLanczos(a Matrix, k int, settings *LanczosSettings) (ok bool) {
+	m, n := a.Dims()
+	if k <= 0 || min(m, n) < k {
+		panic(badTruncRank)
+	}
+	svd.s = nil
+	if k == min(m, n) {
+		// The Krylov subspaces cannot be extended beyond the
+		// requested triplets, so compute the thin SVD directly.
+		var full SVD
+		if !full.Factorize(a, SVDThin) {
+			return false
+		}
+		svd.s = full.Values(nil)
+		svd.u = full.UTo(nil)
+		svd.v = full.VTo(nil)
+		return true
+	}
+	w, tol, maxIter, norm := lanczosParams(settings, k, min(m, n))
+
+	// The rows of u and v hold the left and right basis vectors.
+	u := NewDense(w, m, nil)
+	v := NewDense(w, n, nil)
+	b := NewDense(w, w, nil)
+	p := make([]float64, m)
+	f := make([]float64, n)
+	coef := make([]float64, w)
+	var beta float64
+	var kept int
+	var small SVD
+	var tmp Dense
+	for iter := 0; ; iter++ {
+		newBasisVector(v, kept, f, beta, norm)
+		for j := kept; j < w; j++ {
+			NewVecDense(m, p).MulVec(a, NewVecDense(n, v.RawRowView(j)))
+			for i := range coef {
+				coef[i] = 0
+			}
+			basis := u.mat
+			basis.Rows = j
+			norm0 := blas64.Nrm2(m, blas64.Vector{Inc: 1, Data: p})
+			alpha := gramSchmidt(basis, p, coef)
+			for i := 0; i < j; i++ {
+				b.set(i, j, coef[i])
+			}
+			if isBreakdown(alpha, norm0) {
+				alpha = 0
+			}
+			b.set(j, j, alpha)
+			newBasisVector(u, j, p, alpha, norm)
+
+			NewVecDense(n, f).MulVec(a.T(), NewVecDense(m, u.RawRowView(j)))
+			basis = v.mat
+			basis.Rows = j + 1
+			norm0 = blas64.Nrm2(n, blas64.Vector{Inc: 1, Data: f})
+			beta = gramSchmidt(basis, f, nil)
+			if isBreakdown(beta, norm0) || j+1 == n {
+				beta = 0
+			}
+			if j+1 < w {
+				newBasisVector(v, j+1, f, beta, norm)
+			}
+		}
+
+		// Compute the singular triplets of B = P * Σ * Q^T.
+		if !small.Factorize(b, SVDFull) {
+			return false
+		}
+		s := small.Values(nil)
+		pm := small.UTo(nil)
+		qm := small.VTo(nil)
+		converged := true
+		for i := 0; i < k; i++ {
+			if beta*math.Abs(pm.At(w-1, i)) > tol*s[0] {
+				converged = false
+				break
+			}
+		}
+		if converged || iter == maxIter {
+			if !converged {
+				return false
+			}
+			svd.s = s[:k]
+			svd.u = NewDense(m, k, nil)
+			svd.u.Mul(u.T(), pm.Slice(0, w, 0, k))
+			svd.v = NewDense(n, k, nil)
+			svd.v.Mul(v.T(), qm.Slice(0, w, 0, k))
+			return true
+		}
+
+		// Restart with the leading singular vectors of B and the
+		// residual f.
+		kept = k + (w-k)/2
+		tmp.Reset()
+		tmp.Mul(pm.Slice(0, w, 0, kept).T(), u)
+		u.Slice(0, kept, 0, m).(*Dense).Copy(&tmp)
+		tmp.Reset()
+		tmp.Mul(qm.Slice(0, w, 0, kept).T(), v)
+		v.Slice(0, kept, 0, n).(*Dense).Copy(&tmp)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = 0
+		}
+		for i := 0; i < kept; i++ {
+			b.set(i, i, s[i])
+		}
+	}
+}
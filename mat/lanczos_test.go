@@ -0,0 +1,207 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestPartialEigenSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, k int
+		ncv  int
+	}{
+		{1, 1, 0},
+		{10, 3, 0},
+		{10, 10, 0},
+		{50, 5, 0},
+		{100, 1, 0},
+		{100, 6, 10},
+		{200, 10, 0},
+	} {
+		n, k := test.n, test.k
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, rnd.NormFloat64())
+			}
+		}
+		var es EigenSym
+		es.Factorize(a, false)
+		all := es.Values(nil)
+		want := make([]float64, k)
+		for i := range want {
+			want[i] = all[n-1-i]
+		}
+
+		var e PartialEigenSym
+		ok := e.Factorize(a, k, LargestEigen, &LanczosSettings{NCV: test.ncv, Src: rnd})
+		if !ok {
+			t.Errorf("n=%d,k=%d: factorization failed", n, k)
+			continue
+		}
+		got := e.Values(nil)
+		if !floats.EqualApprox(got, want, 1e-10) {
+			t.Errorf("n=%d,k=%d: unexpected eigenvalues:\ngot: %v\nwant:%v", n, k, got, want)
+		}
+		checkEigenpairs(t, n, k, a, got, e.VectorsTo(nil), 1e-10)
+	}
+
+	// The tridiagonal matrix of the discrete Laplacian in one dimension
+	// stored in a sparse format. Its eigenvalues are known analytically.
+	const n = 300
+	coo := NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, 2)
+		if i > 0 {
+			coo.Append(i, i-1, -1)
+			coo.Append(i-1, i, -1)
+		}
+	}
+	a := symCSR{coo.ToCSR()}
+	const k = 3
+	var e PartialEigenSym
+	ok := e.Factorize(a, k, LargestEigen, &LanczosSettings{Src: rnd})
+	if !ok {
+		t.Fatalf("sparse: factorization failed")
+	}
+	want := make([]float64, k)
+	for i := range want {
+		want[i] = 2 - 2*math.Cos(float64(n-i)*math.Pi/(n+1))
+	}
+	got := e.Values(nil)
+	if !floats.EqualApprox(got, want, 1e-10) {
+		t.Errorf("sparse: unexpected eigenvalues: got:%v want:%v", got, want)
+	}
+	checkEigenpairs(t, n, k, a, got, e.VectorsTo(nil), 1e-10)
+}
+
+// symCSR is a symmetric matrix stored in a CSR matrix.
+type symCSR struct {
+	*CSR
+}
+
+func (m symCSR) Symmetric() int {
+	n, _ := m.Dims()
+	return n
+}
+
+// checkEigenpairs checks that the columns of vectors are orthonormal and
+// that they are eigenvectors of a with the eigenvalues values.
+func checkEigenpairs(t *testing.T, n, k int, a Matrix, values []float64, vectors *Dense, tol float64) {
+	if !hasOrthonormalColumns(vectors, tol) {
+		t.Errorf("n=%d,k=%d: eigenvectors are not orthonormal", n, k)
+	}
+	scale := math.Max(math.Abs(values[0]), math.Abs(values[k-1]))
+	var av Dense
+	av.Mul(a, vectors)
+	for j, ev := range values {
+		for i := 0; i < n; i++ {
+			if math.Abs(av.At(i, j)-ev*vectors.At(i, j)) > tol*scale {
+				t.Errorf("n=%d,k=%d: A*x_%d != λ_%d*x_%d", n, k, j, j, j)
+				break
+			}
+		}
+	}
+}
+
+func TestPartialEigenSymFunc(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// The Laplacian of a path graph with n nodes has the eigenvalues
+	//  2 - 2*cos(j*π/n), j = 0, ..., n-1.
+	const n = 100
+	laplacian := func(dst, x []float64) {
+		for i := range dst {
+			var v float64
+			if i > 0 {
+				v += x[i] - x[i-1]
+			}
+			if i < n-1 {
+				v += x[i] - x[i+1]
+			}
+			dst[i] = v
+		}
+	}
+	pathEigen := func(j int) float64 {
+		return 2 - 2*math.Cos(float64(j)*math.Pi/n)
+	}
+	lap := NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		x := make([]float64, n)
+		x[i] = 1
+		col := make([]float64, n)
+		laplacian(col, x)
+		for j := i; j < n; j++ {
+			lap.SetSym(i, j, col[j])
+		}
+	}
+
+	const k = 4
+	var e PartialEigenSym
+	ok := e.FactorizeFunc(n, laplacian, k, SmallestEigen, &LanczosSettings{NCV: 40, Src: rnd, MaxIter: 1000})
+	if !ok {
+		t.Fatalf("smallest: factorization failed")
+	}
+	want := make([]float64, k)
+	for i := range want {
+		want[i] = pathEigen(i)
+	}
+	got := e.Values(nil)
+	if !floats.EqualApprox(got, want, 1e-10) {
+		t.Errorf("smallest: unexpected eigenvalues: got:%v want:%v", got, want)
+	}
+	checkEigenpairs(t, n, k, lap, got, e.VectorsTo(nil), 1e-8)
+	// The eigenvector of the zero eigenvalue is constant.
+	vecs := e.VectorsTo(nil)
+	for i := 1; i < n; i++ {
+		if math.Abs(vecs.At(i, 0)-vecs.At(0, 0)) > 1e-8 {
+			t.Errorf("smallest: eigenvector of zero eigenvalue is not constant")
+			break
+		}
+	}
+
+	// Shifting the Laplacian makes the eigenvalues at both ends of
+	// the spectrum the largest in magnitude.
+	const shift = 2.001
+	shifted := func(dst, x []float64) {
+		laplacian(dst, x)
+		for i, v := range x {
+			dst[i] -= shift * v
+		}
+	}
+	ok = e.FactorizeFunc(n, shifted, k, LargestMagnitudeEigen, &LanczosSettings{Src: rnd})
+	if !ok {
+		t.Fatalf("magnitude: factorization failed")
+	}
+	all := make([]float64, n)
+	for i := range all {
+		all[i] = pathEigen(i) - shift
+	}
+	for i := range want {
+		// Move the eigenvalue with the largest magnitude to the front.
+		for j := i + 1; j < n; j++ {
+			if math.Abs(all[j]) > math.Abs(all[i]) {
+				all[i], all[j] = all[j], all[i]
+			}
+		}
+		want[i] = all[i]
+	}
+	got = e.Values(nil)
+	if !floats.EqualApprox(got, want, 1e-10) {
+		t.Errorf("magnitude: unexpected eigenvalues: got:%v want:%v", got, want)
+	}
+
+	for _, which := range []EigenSelection{0, 4} {
+		if panicked, _ := panics(func() { e.FactorizeFunc(n, laplacian, k, which, nil) }); !panicked {
+			t.Errorf("expected panic for bad selection %d", which)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// Sqrt calculates the principal square root of the matrix a, the unique
+// square root whose eigenvalues have positive real parts, placing the result
+// in the receiver. Sqrt will panic with ErrSquare if a is not square.
+//
+// If a is Symmetric, the square root is computed from the eigendecomposition
+// of a and Sqrt returns ErrNotPSD if a is not positive semi-definite.
+// Otherwise Sqrt uses the real Schur method of Higham, described in
+// http://eprints.ma.man.ac.uk/695/01/covered/MIMS_ep2006_161.pdf, and returns
+// ErrNegativeEigenvalue if a has a negative real eigenvalue, in which case a
+// real principal square root does not exist, and ErrSingular if a has
+// a repeated zero eigenvalue.
+func (m *Dense) Sqrt(a Matrix) error {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if s, ok := a.(Symmetric); ok {
+		w := getWorkspaceSym(r, false)
+		defer putWorkspaceSym(w)
+		err := w.SqrtSym(s)
+		if err != nil {
+			return err
+		}
+		m.reuseAs(r, r)
+		m.Copy(w)
+		return nil
+	}
+
+	var schur Schur
+	ok := schur.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	t := schur.TTo(nil)
+	err := checkPrincipal(t, false)
+	if err != nil {
+		return err
+	}
+	root := getWorkspace(r, r, true)
+	defer putWorkspace(root)
+	err = sqrtQuasiTri(root, t)
+	if err != nil {
+		return err
+	}
+	m.reuseAs(r, r)
+	m.schurProduct(schur.z, root, t)
+	return nil
+}
+
+// Log calculates the principal logarithm of the matrix a, the unique
+// logarithm whose eigenvalues have imaginary parts in (-π, π), placing the
+// result in the receiver. Log will panic with ErrSquare if a is not square.
+//
+// Log returns ErrSingular if a has a zero eigenvalue and ErrNegativeEigenvalue
+// if a has a negative real eigenvalue, in which case a real principal
+// logarithm does not exist.
+//
+// Log uses the inverse scaling and squaring method described in
+// http://eprints.ma.man.ac.uk/1687/02/logm.pdf. The Schur form of a is
+// repeatedly replaced by its square root until it is close to the identity
+// and the logarithm of the result is evaluated with a diagonal Padé
+// approximant.
+func (m *Dense) Log(a Matrix) error {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+
+	var schur Schur
+	ok := schur.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	t := schur.TTo(nil)
+	err := checkPrincipal(t, true)
+	if err != nil {
+		return err
+	}
+
+	const (
+		// theta is the bound on the norm of T-I for which the degree 8
+		// Padé approximant is accurate to double precision.
+		theta = 0.25
+
+		// maxSqrt is the maximum number of square roots taken.
+		maxSqrt = 64
+	)
+	root := getWorkspace(r, r, false)
+	defer putWorkspace(root)
+	var k int
+	for ; k < maxSqrt; k++ {
+		if normDiffIdentity(t) <= theta {
+			break
+		}
+		zero(root.mat.Data)
+		err = sqrtQuasiTri(root, t)
+		if err != nil {
+			return err
+		}
+		t.Copy(root)
+	}
+
+	// Evaluate the Padé approximant of log(I+X) in partial
+	// fraction form
+	//  r(X) = \sum_j w_j * X * (I + x_j*X)^-1
+	// where x_j and w_j are the nodes and weights of the
+	// 8-point Gauss-Legendre quadrature rule on [0, 1].
+	x := t
+	for i := 0; i < r; i++ {
+		x.set(i, i, x.at(i, i)-1)
+	}
+	logT := root
+	zero(logT.mat.Data)
+	y := getWorkspace(r, r, false)
+	defer putWorkspace(y)
+	d := getWorkspace(r, r, false)
+	defer putWorkspace(d)
+	for j, node := range gaussLegendreNodes {
+		d.Scale(node, x)
+		for i := 0; i < r; i++ {
+			d.set(i, i, d.at(i, i)+1)
+		}
+		err = y.Solve(d, x)
+		if err != nil {
+			if _, ok := err.(Condition); !ok {
+				return err
+			}
+		}
+		y.Scale(gaussLegendreWeights[j], y)
+		logT.Add(logT, y)
+	}
+	logT.Scale(math.Pow(2, float64(k)), logT)
+
+	m.reuseAs(r, r)
+	m.schurProduct(schur.z, logT, y)
+	return nil
+}
+
+// gaussLegendreNodes and gaussLegendreWeights hold the nodes and weights of
+// the 8-point Gauss-Legendre quadrature rule on [0, 1].
+var (
+	gaussLegendreNodes = [8]float64{
+		0.5 * (1 - 0.9602898564975363),
+		0.5 * (1 - 0.7966664774136267),
+		0.5 * (1 - 0.5255324099163290),
+		0.5 * (1 - 0.1834346424956498),
+		0.5 * (1 + 0.1834346424956498),
+		0.5 * (1 + 0.5255324099163290),
+		0.5 * (1 + 0.7966664774136267),
+		0.5 * (1 + 0.9602898564975363),
+	}
+	gaussLegendreWeights = [8]float64{
+		0.5 * 0.1012285362903763,
+		0.5 * 0.2223810344533745,
+		0.5 * 0.3137066458778873,
+		0.5 * 0.3626837833783620,
+		0.5 * 0.3626837833783620,
+		0.5 * 0.3137066458778873,
+		0.5 * 0.2223810344533745,
+		0.5 * 0.1012285362903763,
+	}
+)
+
+// schurProduct places z * f * z^T into the receiver, using work as
+// temporary storage. The receiver must not alias z, f or work.
+func (m *Dense) schurProduct(z, f, work *Dense) {
+	work.Mul(z, f)
+	m.Mul(work, z.T())
+}
+
+// checkPrincipal checks that the real eigenvalues on the diagonal of the
+// quasi-triangular matrix t are non-negative. If log is true, no zero
+// eigenvalue is permitted, otherwise a single zero eigenvalue is permitted.
+func checkPrincipal(t *Dense, log bool) error {
+	n, _ := t.Dims()
+	var zeros int
+	for i := 0; i < n; i++ {
+		if i < n-1 && t.at(i+1, i) != 0 {
+			// Skip complex conjugate pairs.
+			i++
+			continue
+		}
+		switch v := t.at(i, i); {
+		case v < 0:
+			return ErrNegativeEigenvalue
+		case v == 0:
+			zeros++
+		}
+	}
+	if zeros > 1 || (log && zeros > 0) {
+		return ErrSingular
+	}
+	return nil
+}
+
+// normDiffIdentity returns the 1-norm of t-I.
+func normDiffIdentity(t *Dense) float64 {
+	n, _ := t.Dims()
+	var norm float64
+	for j := 0; j < n; j++ {
+		var sum float64
+		for i := 0; i < n; i++ {
+			v := t.at(i, j)
+			if i == j {
+				v--
+			}
+			sum += math.Abs(v)
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+// sqrtQuasiTri computes the principal square root of the upper
+// quasi-triangular matrix t in real Schur form and stores it into the zeroed
+// receiver r. The real eigenvalues of t must be non-negative.
+func sqrtQuasiTri(r, t *Dense) error {
+	n, _ := t.Dims()
+
+	// Find the diagonal blocks of t.
+	var start []int
+	for i := 0; i < n; i++ {
+		start = append(start, i)
+		if i < n-1 && t.at(i+1, i) != 0 {
+			i++
+		}
+	}
+	start = append(start, n)
+
+	// Compute the square roots of the diagonal blocks.
+	for b := 0; b < len(start)-1; b++ {
+		i := start[b]
+		if start[b+1]-i == 1 {
+			r.set(i, i, math.Sqrt(t.at(i, i)))
+			continue
+		}
+		// The eigenvalues of the 2×2 block T_ii are θ±iμ. If α+iβ
+		// is the principal square root of θ+iμ, the principal
+		// square root of T_ii is
+		//  α*I + (T_ii - θ*I)/(2*α).
+		t11 := t.at(i, i)
+		t12 := t.at(i, i+1)
+		t21 := t.at(i+1, i)
+		t22 := t.at(i+1, i+1)
+		theta := (t11 + t22) / 2
+		p := (t11 - t22) / 2
+		mu := math.Sqrt(-t12*t21 - p*p)
+		alpha := real(cmplx.Sqrt(complex(theta, mu)))
+		r.set(i, i, alpha+(t11-theta)/(2*alpha))
+		r.set(i, i+1, t12/(2*alpha))
+		r.set(i+1, i, t21/(2*alpha))
+		r.set(i+1, i+1, alpha+(t22-theta)/(2*alpha))
+	}
+
+	// Compute the off-diagonal blocks a block column at a time
+	// by solving the Sylvester equations
+	//  R_ii * R_ij + R_ij * R_jj = T_ij - \sum_{k=i+1}^{j-1} R_ik * R_kj.
+	var sylv [16]float64
+	var rhs [4]float64
+	var ipiv [4]int
+	for bj := 1; bj < len(start)-1; bj++ {
+		j0, j1 := start[bj], start[bj+1]
+		q := j1 - j0
+		for bi := bj - 1; bi >= 0; bi-- {
+			i0, i1 := start[bi], start[bi+1]
+			p := i1 - i0
+
+			// Form the right hand side column-wise.
+			for s := 0; s < q; s++ {
+				for u := 0; u < p; u++ {
+					v := t.at(i0+u, j0+s)
+					for k := i1; k < j0; k++ {
+						v -= r.at(i0+u, k) * r.at(k, j0+s)
+					}
+					rhs[s*p+u] = v
+				}
+			}
+
+			// Form the Kronecker product representation of the
+			// Sylvester operator
+			//  I_q ⊗ R_ii + R_jj^T ⊗ I_p.
+			pq := p * q
+			k := sylv[:pq*pq]
+			zero(k)
+			for s := 0; s < q; s++ {
+				for u := 0; u < p; u++ {
+					row := s*p + u
+					for v := 0; v < p; v++ {
+						k[row*pq+s*p+v] += r.at(i0+u, i0+v)
+					}
+					for v := 0; v < q; v++ {
+						k[row*pq+v*p+u] += r.at(j0+v, j0+s)
+					}
+				}
+			}
+			kMat := blas64.General{Rows: pq, Cols: pq, Stride: pq, Data: k}
+			ok := lapack64.Getrf(kMat, ipiv[:pq])
+			if !ok {
+				return ErrSingular
+			}
+			lapack64.Getrs(blas.NoTrans, kMat, blas64.General{Rows: pq, Cols: 1, Stride: 1, Data: rhs[:pq]}, ipiv[:pq])
+			for s := 0; s < q; s++ {
+				for u := 0; u < p; u++ {
+					r.set(i0+u, j0+s, rhs[s*p+u])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SqrtSym calculates the principal square root of the positive semi-definite
+// symmetric matrix a, placing the result in the receiver. The square root is
+// computed from the eigendecomposition of a, and eigenvalues that are
+// negative due to rounding are treated as zero.
+//
+// SqrtSym returns ErrNotPSD if a is not positive semi-definite and
+// ErrFailedEigen if the eigendecomposition is not successful.
+func (s *SymDense) SqrtSym(a Symmetric) error {
+	var eigen EigenSym
+	ok := eigen.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	// dlamchE is the machine epsilon.
+	const dlamchE = 1.0 / (1 << 53)
+
+	values := eigen.Values(nil)
+	var vmax float64
+	for _, v := range values {
+		vmax = math.Max(vmax, math.Abs(v))
+	}
+	tol := float64(len(values)) * dlamchE * vmax
+	for i, v := range values {
+		if v < -tol {
+			return ErrNotPSD
+		}
+		values[i] = math.Sqrt(math.Max(v, 0))
+	}
+	s.fromEigen(values, &eigen)
+	return nil
+}
+
+// FuncSym calculates the matrix function f(a) of the symmetric matrix a,
+// placing the result in the receiver. The matrix function is computed by
+// applying fn to the eigenvalues of a, so that
+//  f(A) = U * diag(fn(λ_i)) * U^T
+// where A = U * diag(λ_i) * U^T is the eigendecomposition of a. For example
+// the logarithm of a symmetric positive definite matrix is computed by
+//  s.FuncSym(a, math.Log)
+//
+// FuncSym returns ErrFailedEigen if the eigendecomposition is not successful.
+func (s *SymDense) FuncSym(a Symmetric, fn func(float64) float64) error {
+	var eigen EigenSym
+	ok := eigen.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	values := eigen.Values(nil)
+	for i, v := range values {
+		values[i] = fn(v)
+	}
+	s.fromEigen(values, &eigen)
+	return nil
+}
+
+// fromEigen places U * diag(values) * U^T into the receiver, where U is the
+// matrix of eigenvectors held by eigen.
+func (s *SymDense) fromEigen(values []float64, eigen *EigenSym) {
+	n := len(values)
+	s.reuseAs(n)
+	if n == 0 {
+		return
+	}
+	u := eigen.vectors
+	w := getWorkspace(n, n, false)
+	defer putWorkspace(w)
+	w.Copy(u)
+	for j, v := range values {
+		blas64.Scal(n, v, blas64.Vector{Inc: w.mat.Stride, Data: w.mat.Data[j:]})
+	}
+	// U * D * U^T = 1/2 * (W * U^T + U * W^T) where W = U * D.
+	blas64.Syr2k(blas.NoTrans, 0.5, w.mat, u.mat, 0, s.mat)
+}
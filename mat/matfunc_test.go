@@ -0,0 +1,220 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSqrt(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		// The eigenvalues of b have positive real parts, so b
+		// is the principal square root of b*b.
+		b := NewDense(n, n, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		for i := 0; i < n; i++ {
+			b.set(i, i, b.at(i, i)+2*math.Sqrt(float64(n)))
+		}
+		var a Dense
+		a.Mul(b, b)
+
+		var got Dense
+		err := got.Sqrt(&a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&got, b, 1e-10) {
+			t.Errorf("n=%d: unexpected square root:\ngot:\n% .4v\nwant:\n% .4v", n, Formatted(&got), Formatted(b))
+		}
+
+		// Check aliased receiver.
+		err = a.Sqrt(&a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error for aliased receiver: %v", n, err)
+		}
+		if !EqualApprox(&a, b, 1e-10) {
+			t.Errorf("n=%d: unexpected square root for aliased receiver", n)
+		}
+	}
+
+	a := NewDense(2, 2, []float64{
+		-1, 1,
+		0, 2,
+	})
+	var m Dense
+	if err := m.Sqrt(a); err != ErrNegativeEigenvalue {
+		t.Errorf("unexpected error for negative eigenvalue: got:%v want:%v", err, ErrNegativeEigenvalue)
+	}
+	a = NewDense(3, 3, []float64{
+		0, 1, 2,
+		0, 0, 3,
+		0, 0, 1,
+	})
+	if err := m.Sqrt(a); err != ErrSingular {
+		t.Errorf("unexpected error for repeated zero eigenvalue: got:%v want:%v", err, ErrSingular)
+	}
+}
+
+func TestSqrtSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		x := NewDense(n+2, n, nil)
+		for i := range x.mat.Data {
+			x.mat.Data[i] = rnd.NormFloat64()
+		}
+		a := NewSymDense(n, nil)
+		a.SymOuterK(1, x.T())
+
+		var s SymDense
+		err := s.SqrtSym(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var got Dense
+		got.Mul(&s, &s)
+		if !EqualApprox(&got, a, 1e-10) {
+			t.Errorf("n=%d: square root squared does not match input", n)
+		}
+		var eig EigenSym
+		eig.Factorize(&s, false)
+		for _, v := range eig.Values(nil) {
+			if v < 0 {
+				t.Errorf("n=%d: square root is not positive semi-definite", n)
+				break
+			}
+		}
+
+		// The symmetric path of Dense.Sqrt must agree.
+		var d Dense
+		err = d.Sqrt(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error from Dense.Sqrt: %v", n, err)
+		}
+		if !EqualApprox(&d, &s, 1e-12) {
+			t.Errorf("n=%d: mismatch between Dense.Sqrt and SqrtSym", n)
+		}
+	}
+
+	// A rank-deficient positive semi-definite matrix.
+	a := NewSymDense(2, []float64{
+		1, 1,
+		1, 1,
+	})
+	var s SymDense
+	err := s.SqrtSym(a)
+	if err != nil {
+		t.Errorf("unexpected error for semi-definite matrix: %v", err)
+	}
+	want := NewSymDense(2, []float64{
+		math.Sqrt2 / 2, math.Sqrt2 / 2,
+		math.Sqrt2 / 2, math.Sqrt2 / 2,
+	})
+	if !EqualApprox(&s, want, 1e-14) {
+		t.Errorf("unexpected square root of semi-definite matrix:\ngot:\n% v\nwant:\n% v", Formatted(&s), Formatted(want))
+	}
+
+	a = NewSymDense(2, []float64{
+		1, 2,
+		2, 1,
+	})
+	if err := s.SqrtSym(a); err != ErrNotPSD {
+		t.Errorf("unexpected error for indefinite matrix: got:%v want:%v", err, ErrNotPSD)
+	}
+}
+
+func TestLog(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		for _, scale := range []float64{0.01, 0.5, 2} {
+			// The eigenvalues of b have imaginary parts in
+			// (-π, π), so b is the principal logarithm of e^b.
+			b := NewDense(n, n, nil)
+			for i := range b.mat.Data {
+				b.mat.Data[i] = scale * rnd.NormFloat64() / math.Sqrt(float64(n))
+			}
+			var a Dense
+			a.Exp(b)
+
+			var got Dense
+			err := got.Log(&a)
+			if err != nil {
+				t.Errorf("n=%d,scale=%v: unexpected error: %v", n, scale, err)
+				continue
+			}
+			if !EqualApprox(&got, b, 1e-8) {
+				t.Errorf("n=%d,scale=%v: unexpected logarithm:\ngot:\n% .4v\nwant:\n% .4v", n, scale, Formatted(&got), Formatted(b))
+			}
+		}
+	}
+
+	a := NewDense(2, 2, []float64{
+		-1, 1,
+		0, 2,
+	})
+	var m Dense
+	if err := m.Log(a); err != ErrNegativeEigenvalue {
+		t.Errorf("unexpected error for negative eigenvalue: got:%v want:%v", err, ErrNegativeEigenvalue)
+	}
+	a = NewDense(2, 2, []float64{
+		0, 1,
+		0, 2,
+	})
+	if err := m.Log(a); err != ErrSingular {
+		t.Errorf("unexpected error for singular matrix: got:%v want:%v", err, ErrSingular)
+	}
+}
+
+func TestFuncSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		x := NewDense(n+2, n, nil)
+		for i := range x.mat.Data {
+			x.mat.Data[i] = rnd.NormFloat64()
+		}
+		a := NewSymDense(n, nil)
+		a.SymOuterK(1/float64(n), x.T())
+
+		// exp(A) computed from the eigendecomposition.
+		var s SymDense
+		err := s.FuncSym(a, math.Exp)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var want Dense
+		want.Exp(a)
+		if !EqualApprox(&s, &want, 1e-10) {
+			t.Errorf("n=%d: unexpected exponential:\ngot:\n% .4v\nwant:\n% .4v", n, Formatted(&s), Formatted(&want))
+		}
+
+		// log(A) computed from the eigendecomposition must
+		// agree with the general matrix logarithm.
+		err = s.FuncSym(a, math.Log)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		want.Log(a)
+		if !EqualApprox(&s, &want, 1e-10) {
+			t.Errorf("n=%d: unexpected logarithm:\ngot:\n% .4v\nwant:\n% .4v", n, Formatted(&s), Formatted(&want))
+		}
+
+		// Check aliased receiver.
+		b := NewSymDense(n, nil)
+		b.CopySym(a)
+		b.FuncSym(b, func(v float64) float64 { return v * v })
+		want.Mul(a, a)
+		if !EqualApprox(b, &want, 1e-10) {
+			t.Errorf("n=%d: unexpected square for aliased receiver", n)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const mmBanner = "%%MatrixMarket"
+
+var errMMNoSize = errors.New("mat: missing MatrixMarket size line")
+
+// ReadMatrixMarket reads a real matrix in the MatrixMarket exchange format
+// from r. Both the array (dense) and the coordinate (sparse) formats are
+// supported with real, integer and pattern fields. Elements of a pattern
+// matrix are set to one.
+//
+// If the header declares a symmetric matrix, the returned Matrix is a
+// *SymDense. Otherwise the returned Matrix is a *COO for the coordinate
+// format and a *Dense for the array format. Duplicate entries in the
+// coordinate format are summed.
+// General, symmetric and skew-symmetric matrices are supported. An error is
+// returned for complex and Hermitian matrices, for a malformed header or size
+// line, for indices outside the declared dimensions, for dimensions that are
+// too large to be allocated and if the number of entries does not match the
+// size line.
+//
+// The MatrixMarket format is described at
+// http://math.nist.gov/MatrixMarket/formats.html.
+func ReadMatrixMarket(r io.Reader) (Matrix, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("mat: empty MatrixMarket input")
+	}
+	banner := strings.Fields(strings.ToLower(sc.Text()))
+	if len(banner) != 5 || banner[0] != strings.ToLower(mmBanner) || banner[1] != "matrix" {
+		return nil, fmt.Errorf("mat: invalid MatrixMarket header %q", sc.Text())
+	}
+	format, field, symmetry := banner[2], banner[3], banner[4]
+	switch format {
+	case "array", "coordinate":
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket format %q", format)
+	}
+	switch field {
+	case "real", "double", "integer":
+	case "pattern":
+		if format == "array" {
+			return nil, errors.New("mat: MatrixMarket pattern field requires coordinate format")
+		}
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket field %q", field)
+	}
+	switch symmetry {
+	case "general", "symmetric", "skew-symmetric":
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket symmetry %q", symmetry)
+	}
+
+	// Skip comments and blank lines up to the size line.
+	var size []string
+	line := 1
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || text[0] == '%' {
+			continue
+		}
+		size = strings.Fields(text)
+		break
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if size == nil {
+		return nil, errMMNoSize
+	}
+	wantSize := 2
+	if format == "coordinate" {
+		wantSize = 3
+	}
+	if len(size) != wantSize {
+		return nil, fmt.Errorf("mat: line %d: invalid MatrixMarket size line %q", line, sc.Text())
+	}
+	dims := make([]int, wantSize)
+	for i, s := range size {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("mat: line %d: invalid MatrixMarket size %q", line, s)
+		}
+		dims[i] = v
+	}
+	rows, cols := dims[0], dims[1]
+	if rows == 0 || cols == 0 {
+		return nil, ErrZeroLength
+	}
+	if symmetry != "general" && rows != cols {
+		return nil, fmt.Errorf("mat: %s MatrixMarket matrix is not square: %d×%d", symmetry, rows, cols)
+	}
+	if format == "array" || symmetry == "symmetric" {
+		// The matrix is stored densely, so the number of
+		// elements must not overflow.
+		if int64(rows) > maxLen/int64(cols) || int64(rows)*int64(cols) > maxLen/8 {
+			return nil, errTooBig
+		}
+	}
+
+	var (
+		dst Matrix
+		set func(i, j int, v float64)
+	)
+	switch {
+	case symmetry == "symmetric":
+		sym := NewSymDense(rows, nil)
+		dst = sym
+		set = func(i, j int, v float64) {
+			sym.SetSym(i, j, sym.At(i, j)+v)
+		}
+	case format == "coordinate":
+		coo := NewCOO(rows, cols, nil, nil, nil)
+		dst, set = coo, coo.Append
+	default:
+		dense := NewDense(rows, cols, nil)
+		dst, set = dense, dense.set
+	}
+	if symmetry == "skew-symmetric" {
+		setLower := set
+		set = func(i, j int, v float64) {
+			setLower(i, j, v)
+			setLower(j, i, -v)
+		}
+	}
+
+	// next returns the fields of the next non-blank line.
+	next := func() ([]string, error) {
+		for sc.Scan() {
+			line++
+			f := strings.Fields(sc.Text())
+			if len(f) != 0 && f[0][0] != '%' {
+				return f, nil
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if format == "array" {
+		// Array entries are listed in column-major order. Only the
+		// lower triangle is listed for symmetric matrices and only
+		// the strictly lower triangle for skew-symmetric matrices.
+		for j := 0; j < cols; j++ {
+			i0 := 0
+			switch symmetry {
+			case "symmetric":
+				i0 = j
+			case "skew-symmetric":
+				i0 = j + 1
+			}
+			for i := i0; i < rows; i++ {
+				f, err := next()
+				if err != nil {
+					return nil, fmt.Errorf("mat: too few MatrixMarket entries: %v", err)
+				}
+				if len(f) != 1 {
+					return nil, fmt.Errorf("mat: line %d: invalid MatrixMarket entry %q", line, sc.Text())
+				}
+				v, err := strconv.ParseFloat(f[0], 64)
+				if err != nil {
+					return nil, fmt.Errorf("mat: line %d: %v", line, err)
+				}
+				set(i, j, v)
+			}
+		}
+	} else {
+		nnz := dims[2]
+		wantFields := 3
+		if field == "pattern" {
+			wantFields = 2
+		}
+		for k := 0; k < nnz; k++ {
+			f, err := next()
+			if err != nil {
+				return nil, fmt.Errorf("mat: too few MatrixMarket entries: %v", err)
+			}
+			if len(f) != wantFields {
+				return nil, fmt.Errorf("mat: line %d: invalid MatrixMarket entry %q", line, sc.Text())
+			}
+			i, erri := strconv.Atoi(f[0])
+			j, errj := strconv.Atoi(f[1])
+			if erri != nil || errj != nil || i < 1 || rows < i || j < 1 || cols < j {
+				return nil, fmt.Errorf("mat: line %d: MatrixMarket index out of range %q", line, sc.Text())
+			}
+			if symmetry == "skew-symmetric" && i == j {
+				return nil, fmt.Errorf("mat: line %d: diagonal entry in skew-symmetric MatrixMarket matrix", line)
+			}
+			v := 1.0
+			if field != "pattern" {
+				v, err = strconv.ParseFloat(f[2], 64)
+				if err != nil {
+					return nil, fmt.Errorf("mat: line %d: %v", line, err)
+				}
+			}
+			set(i-1, j-1, v)
+		}
+	}
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text != "" && text[0] != '%' {
+			return nil, fmt.Errorf("mat: line %d: too many MatrixMarket entries", line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// WriteMatrixMarket writes the matrix m to w in the MatrixMarket exchange
+// format with a real field. If m is Symmetric, the symmetric header is written
+// and only the lower triangle of m is stored. If m implements NonZeroDoer,
+// the coordinate format is written, otherwise the array format is written.
+//
+// Values are written with the minimal number of digits required to represent
+// them exactly, so a matrix read by ReadMatrixMarket is equal to the matrix
+// that was written.
+func WriteMatrixMarket(w io.Writer, m Matrix) error {
+	r, c := m.Dims()
+	symmetry := "general"
+	_, isSym := m.(Symmetric)
+	if isSym {
+		symmetry = "symmetric"
+	}
+	nz, isSparse := m.(NonZeroDoer)
+	format := "array"
+	if isSparse {
+		format = "coordinate"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix %s real %s\n", mmBanner, format, symmetry)
+
+	if !isSparse {
+		fmt.Fprintf(bw, "%d %d\n", r, c)
+		for j := 0; j < c; j++ {
+			i0 := 0
+			if isSym {
+				i0 = j
+			}
+			for i := i0; i < r; i++ {
+				bw.WriteString(strconv.FormatFloat(m.At(i, j), 'g', -1, 64))
+				bw.WriteByte('\n')
+			}
+		}
+		return bw.Flush()
+	}
+
+	type entry struct {
+		i, j int
+		v    float64
+	}
+	var entries []entry
+	nz.DoNonZero(func(i, j int, v float64) {
+		if isSym && i < j {
+			return
+		}
+		entries = append(entries, entry{i: i, j: j, v: v})
+	})
+	fmt.Fprintf(bw, "%d %d %d\n", r, c, len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(bw, "%d %d %s\n", e.i+1, e.j+1, strconv.FormatFloat(e.v, 'g', -1, 64))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,241 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   string
+		want Matrix
+	}{
+		{
+			name: "array general",
+			in: `%%MatrixMarket matrix array real general
+% A comment.
+2 3
+1
+4
+2
+5
+3
+6
+`,
+			want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "array symmetric",
+			in: `%%MatrixMarket matrix array real symmetric
+3 3
+1
+2
+3
+4
+5
+6
+`,
+			want: NewSymDense(3, []float64{
+				1, 2, 3,
+				2, 4, 5,
+				3, 5, 6,
+			}),
+		},
+		{
+			name: "array skew-symmetric",
+			in: `%%MatrixMarket matrix array integer skew-symmetric
+2 2
+7
+`,
+			want: NewDense(2, 2, []float64{0, -7, 7, 0}),
+		},
+		{
+			name: "coordinate general",
+			in: `%%MatrixMarket matrix coordinate real general
+%
+3 4 4
+1 1 1.5
+3 2 -2e3
+
+2 4 3
+1 1 1
+`,
+			want: NewCOO(3, 4, []int{0, 2, 1, 0}, []int{0, 1, 3, 0}, []float64{1.5, -2e3, 3, 1}),
+		},
+		{
+			name: "coordinate symmetric pattern",
+			in: `%%MatrixMarket matrix coordinate pattern symmetric
+3 3 3
+1 1
+3 1
+3 2
+`,
+			want: NewSymDense(3, []float64{
+				1, 0, 1,
+				0, 0, 1,
+				1, 1, 0,
+			}),
+		},
+		{
+			name: "coordinate symmetric duplicates",
+			in: `%%MatrixMarket matrix coordinate real symmetric
+2 2 4
+1 1 2
+2 1 3
+1 1 0.5
+1 2 1
+`,
+			want: NewSymDense(2, []float64{
+				2.5, 4,
+				4, 0,
+			}),
+		},
+	} {
+		got, err := ReadMatrixMarket(strings.NewReader(test.in))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(test.want) {
+			t.Errorf("%s: unexpected type: got:%T want:%T", test.name, got, test.want)
+			continue
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("%s: unexpected result:\ngot:\n% v\nwant:\n% v", test.name, Formatted(got), Formatted(test.want))
+		}
+	}
+}
+
+func TestReadMatrixMarketError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: "empty",
+		},
+		{
+			name: "bad banner",
+			in:   "%%MatrixMarket vector array real general\n1 1\n1\n",
+			want: "header",
+		},
+		{
+			name: "complex",
+			in:   "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+			want: "field",
+		},
+		{
+			name: "hermitian",
+			in:   "%%MatrixMarket matrix coordinate real hermitian\n1 1 1\n1 1 1\n",
+			want: "symmetry",
+		},
+		{
+			name: "no size",
+			in:   "%%MatrixMarket matrix array real general\n% comment\n",
+			want: "size",
+		},
+		{
+			name: "non-square symmetric",
+			in:   "%%MatrixMarket matrix array real symmetric\n2 3\n",
+			want: "not square",
+		},
+		{
+			name: "index out of range",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+			want: "out of range",
+		},
+		{
+			name: "too few entries",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+			want: "too few",
+		},
+		{
+			name: "too many entries",
+			in:   "%%MatrixMarket matrix array real general\n1 1\n1\n2\n",
+			want: "too many",
+		},
+		{
+			name: "bad value",
+			in:   "%%MatrixMarket matrix array real general\n1 1\nx\n",
+			want: "line 3",
+		},
+		{
+			name: "overflowing array size",
+			in:   "%%MatrixMarket matrix array real general\n4000000000 4000000000\n",
+			want: "too big",
+		},
+		{
+			name: "overflowing symmetric size",
+			in:   "%%MatrixMarket matrix coordinate real symmetric\n4000000000 4000000000 1\n1 1 1\n",
+			want: "too big",
+		},
+	} {
+		_, err := ReadMatrixMarket(strings.NewReader(test.in))
+		if err == nil {
+			t.Errorf("%s: expected error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: unexpected error: got:%v want containing %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		m        Matrix
+		header   string
+		wantType Matrix
+	}{
+		{
+			m:        NewDense(2, 3, []float64{1, 2, 3, 4, 5, 1.0 / 3}),
+			header:   "%%MatrixMarket matrix array real general",
+			wantType: &Dense{},
+		},
+		{
+			m:        NewSymDense(3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}),
+			header:   "%%MatrixMarket matrix array real symmetric",
+			wantType: &SymDense{},
+		},
+		{
+			m:        NewCOO(3, 2, []int{0, 2}, []int{1, 0}, []float64{-1, 0.1}),
+			header:   "%%MatrixMarket matrix coordinate real general",
+			wantType: &COO{},
+		},
+		{
+			m:        NewSymBandDense(3, 1, []float64{1, 2, 3, 4, 5, 0}),
+			header:   "%%MatrixMarket matrix coordinate real symmetric",
+			wantType: &SymDense{},
+		},
+	} {
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, test.m)
+		if err != nil {
+			t.Fatalf("unexpected error writing MatrixMarket: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), test.header+"\n") {
+			t.Errorf("unexpected header: got:%q want:%q", strings.SplitN(buf.String(), "\n", 2)[0], test.header)
+		}
+		got, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Errorf("unexpected error reading MatrixMarket: %v", err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(test.wantType) {
+			t.Errorf("unexpected type: got:%T want:%T", got, test.wantType)
+		}
+		if !Equal(got, test.m) {
+			t.Errorf("unexpected round trip result:\ngot:\n% v\nwant:\n% v", Formatted(got), Formatted(test.m))
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// npyMagic is the magic string at the start of an .npy file.
+const npyMagic = "\x93NUMPY"
+
+var errNpyMagic = errors.New("mat: invalid npy magic string")
+
+// ReadNpy reads a NumPy array in the .npy format from r. A one-dimensional
+// array is returned as a *VecDense and a two-dimensional array as a *Dense.
+// Arrays stored in Fortran (column-major) order are transposed into the
+// row-major layout used by Dense.
+//
+// Floating point, signed and unsigned integer and boolean arrays of either
+// byte order are supported and their elements converted to float64. An error
+// is returned for other dtypes, such as complex or structured dtypes, and for
+// arrays that do not have one or two dimensions.
+//
+// The .npy format is described at
+// https://docs.scipy.org/doc/numpy/neps/npy-format.html.
+func ReadNpy(r io.Reader) (Matrix, error) {
+	var pre [len(npyMagic) + 2]byte
+	_, err := io.ReadFull(r, pre[:])
+	if err != nil {
+		return nil, err
+	}
+	if string(pre[:len(npyMagic)]) != npyMagic {
+		return nil, errNpyMagic
+	}
+	var hlen int
+	switch major := pre[len(npyMagic)]; major {
+	case 1:
+		var b [2]byte
+		_, err = io.ReadFull(r, b[:])
+		hlen = int(binary.LittleEndian.Uint16(b[:]))
+	case 2, 3:
+		var b [4]byte
+		_, err = io.ReadFull(r, b[:])
+		hlen = int(binary.LittleEndian.Uint32(b[:]))
+	default:
+		return nil, fmt.Errorf("mat: unsupported npy version %d.%d", major, pre[len(npyMagic)+1])
+	}
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, hlen)
+	_, err = io.ReadFull(r, header)
+	if err != nil {
+		return nil, err
+	}
+	descr, fortran, shape, err := parseNpyHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+	dec, size, err := npyDecoder(descr)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows, cols int
+	switch len(shape) {
+	case 1:
+		rows, cols = shape[0], 1
+	case 2:
+		rows, cols = shape[0], shape[1]
+	default:
+		return nil, fmt.Errorf("mat: unsupported npy shape %v: array must have one or two dimensions", shape)
+	}
+	if rows == 0 || cols == 0 {
+		return nil, ErrZeroLength
+	}
+	// The element count and the lengths of both the raw
+	// and the decoded data must not overflow.
+	if int64(rows) > maxLen/int64(cols) {
+		return nil, errTooBig
+	}
+	n := int64(rows) * int64(cols)
+	if n > maxLen/int64(size) || n > maxLen/8 {
+		return nil, errTooBig
+	}
+
+	// The data are read without preallocating the length
+	// given in the header so that a header that claims more
+	// data than is present cannot cause a large allocation.
+	buf, err := ioutil.ReadAll(io.LimitReader(r, n*int64(size)))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) != n*int64(size) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	data := make([]float64, n)
+	for k := range data {
+		v := dec(buf[k*size : (k+1)*size])
+		if fortran {
+			// Element k is at row k%rows and column k/rows.
+			data[(k%rows)*cols+k/rows] = v
+		} else {
+			data[k] = v
+		}
+	}
+	if len(shape) == 1 {
+		return NewVecDense(rows, data), nil
+	}
+	return NewDense(rows, cols, data), nil
+}
+
+// WriteNpy writes the matrix m to w as a little-endian float64 NumPy array in
+// the .npy format. A *VecDense is written as a one-dimensional array and all
+// other matrices as two-dimensional arrays in C (row-major) order.
+func WriteNpy(w io.Writer, m Matrix) error {
+	r, c := m.Dims()
+	shape := fmt.Sprintf("(%d, %d)", r, c)
+	if _, ok := m.(*VecDense); ok {
+		shape = fmt.Sprintf("(%d,)", r)
+	}
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': %s, }", shape)
+	// The total length of the preamble and header, terminated by
+	// a newline, is padded with spaces to a multiple of 64 bytes
+	// so that the data is aligned.
+	const preamble = len(npyMagic) + 2 + 2
+	pad := 63 - (preamble+len(header))%64
+	header += strings.Repeat(" ", pad) + "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	if len(header) > math.MaxUint16 {
+		return errors.New("mat: npy header too long")
+	}
+	var b [8]byte
+	binary.LittleEndian.PutUint16(b[:2], uint16(len(header)))
+	buf.Write(b[:2])
+	buf.WriteString(header)
+	_, err := w.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	row := make([]byte, c*sizeFloat64)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			binary.LittleEndian.PutUint64(row[j*sizeFloat64:], math.Float64bits(m.At(i, j)))
+		}
+		_, err = w.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadNpz reads the NumPy arrays held in the .npz archive in r, which has the
+// given size, and returns them keyed by their names without the .npy suffix.
+// Both stored archives written by numpy.savez and compressed archives written
+// by numpy.savez_compressed are supported. Each array is read as described
+// by ReadNpy.
+func ReadNpz(r io.ReaderAt, size int64) (map[string]Matrix, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make(map[string]Matrix, len(zr.File))
+	for _, f := range zr.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		m, err := ReadNpy(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("mat: npz array %q: %v", name, err)
+		}
+		arrays[name] = m
+	}
+	return arrays, nil
+}
+
+// WriteNpz writes the matrices in arrays to w as an uncompressed .npz archive
+// that can be read by numpy.load. Each matrix is written as described by
+// WriteNpy into an archive member named by its key with a .npy suffix.
+func WriteNpz(w io.Writer, arrays map[string]Matrix) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		err = WriteNpy(f, arrays[name])
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// parseNpyHeader parses the Python dictionary literal held in the header of
+// an .npy file.
+func parseNpyHeader(h string) (descr string, fortran bool, shape []int, err error) {
+	h = strings.TrimSpace(h)
+	if len(h) < 2 || h[0] != '{' || h[len(h)-1] != '}' {
+		return "", false, nil, fmt.Errorf("mat: invalid npy header %q", h)
+	}
+
+	v, ok := npyHeaderValue(h, "descr")
+	if !ok || len(v) < 2 || (v[0] != '\'' && v[0] != '"') || v[len(v)-1] != v[0] {
+		return "", false, nil, fmt.Errorf("mat: invalid npy descr in header %q", h)
+	}
+	descr = v[1 : len(v)-1]
+
+	v, ok = npyHeaderValue(h, "fortran_order")
+	switch {
+	case ok && v == "True":
+		fortran = true
+	case ok && v == "False":
+	default:
+		return "", false, nil, fmt.Errorf("mat: invalid npy fortran_order in header %q", h)
+	}
+
+	v, ok = npyHeaderValue(h, "shape")
+	if !ok || len(v) < 2 || v[0] != '(' || v[len(v)-1] != ')' {
+		return "", false, nil, fmt.Errorf("mat: invalid npy shape in header %q", h)
+	}
+	for _, d := range strings.Split(v[1:len(v)-1], ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(d, "L"))
+		if err != nil || n < 0 {
+			return "", false, nil, fmt.Errorf("mat: invalid npy shape in header %q", h)
+		}
+		shape = append(shape, n)
+	}
+	return descr, fortran, shape, nil
+}
+
+// npyHeaderValue returns the literal value for key in the dictionary literal h.
+func npyHeaderValue(h, key string) (string, bool) {
+	i := strings.Index(h, "'"+key+"'")
+	if i < 0 {
+		i = strings.Index(h, `"`+key+`"`)
+		if i < 0 {
+			return "", false
+		}
+	}
+	rest := strings.TrimSpace(h[i+len(key)+2:])
+	if len(rest) == 0 || rest[0] != ':' {
+		return "", false
+	}
+	rest = strings.TrimSpace(rest[1:])
+	var depth int
+	for j, c := range rest {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',', '}':
+			if depth == 0 {
+				return strings.TrimSpace(rest[:j]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// npyDecoder returns a function that decodes a single element of an array
+// with the given NumPy dtype descr into a float64, and the size of the
+// element in bytes.
+func npyDecoder(descr string) (dec func([]byte) float64, size int, err error) {
+	if len(descr) < 3 {
+		return nil, 0, fmt.Errorf("mat: unsupported npy dtype %q", descr)
+	}
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, 0, fmt.Errorf("mat: unsupported npy dtype %q", descr)
+	}
+	switch descr[1:] {
+	case "f8":
+		return func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }, 8, nil
+	case "f4":
+		return func(b []byte) float64 { return float64(math.Float32frombits(order.Uint32(b))) }, 4, nil
+	case "i8":
+		return func(b []byte) float64 { return float64(int64(order.Uint64(b))) }, 8, nil
+	case "i4":
+		return func(b []byte) float64 { return float64(int32(order.Uint32(b))) }, 4, nil
+	case "i2":
+		return func(b []byte) float64 { return float64(int16(order.Uint16(b))) }, 2, nil
+	case "i1":
+		return func(b []byte) float64 { return float64(int8(b[0])) }, 1, nil
+	case "u8":
+		return func(b []byte) float64 { return float64(order.Uint64(b)) }, 8, nil
+	case "u4":
+		return func(b []byte) float64 { return float64(order.Uint32(b)) }, 4, nil
+	case "u2":
+		return func(b []byte) float64 { return float64(order.Uint16(b)) }, 2, nil
+	case "u1", "b1":
+		return func(b []byte) float64 { return float64(b[0]) }, 1, nil
+	}
+	return nil, 0, fmt.Errorf("mat: unsupported npy dtype %q", descr)
+}
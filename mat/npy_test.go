@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// npyBytes returns an .npy version 1.0 file with the given header dictionary
+// and raw data, laid out as written by numpy.save.
+func npyBytes(header string, data []byte) []byte {
+	const preamble = len(npyMagic) + 2 + 2
+	header += strings.Repeat(" ", 63-(preamble+len(header))%64) + "\n"
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func TestReadNpy(t *testing.T) {
+	f8 := func(order binary.ByteOrder, v ...float64) []byte {
+		var buf bytes.Buffer
+		binary.Write(&buf, order, v)
+		return buf.Bytes()
+	}
+	for _, test := range []struct {
+		name string
+		npy  []byte
+		want Matrix
+	}{
+		{
+			name: "f8 C order",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (3, 2), }", f8(binary.LittleEndian, 1.5, 2, 3, 4, 5, 6)),
+			want: NewDense(3, 2, []float64{1.5, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "f8 Fortran order",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': True, 'shape': (3, 2), }", f8(binary.LittleEndian, 1, 3, 5, 2, 4, 6)),
+			want: NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "big-endian f8",
+			npy:  npyBytes("{'descr': '>f8', 'fortran_order': False, 'shape': (1, 2), }", f8(binary.BigEndian, -1, math.Inf(1))),
+			want: NewDense(1, 2, []float64{-1, math.Inf(1)}),
+		},
+		{
+			name: "1-D f4",
+			npy: npyBytes("{'descr': '<f4', 'fortran_order': False, 'shape': (3,), }", func() []byte {
+				var buf bytes.Buffer
+				binary.Write(&buf, binary.LittleEndian, []float32{0.5, -2, 8})
+				return buf.Bytes()
+			}()),
+			want: NewVecDense(3, []float64{0.5, -2, 8}),
+		},
+		{
+			name: "big-endian i4",
+			npy: npyBytes("{'descr': '>i4', 'fortran_order': False, 'shape': (2, 2), }", func() []byte {
+				var buf bytes.Buffer
+				binary.Write(&buf, binary.BigEndian, []int32{-3, 7, 1 << 20, 0})
+				return buf.Bytes()
+			}()),
+			want: NewDense(2, 2, []float64{-3, 7, 1 << 20, 0}),
+		},
+		{
+			name: "bool",
+			npy:  npyBytes("{'descr': '|b1', 'fortran_order': False, 'shape': (1, 3), }", []byte{1, 0, 1}),
+			want: NewDense(1, 3, []float64{1, 0, 1}),
+		},
+	} {
+		got, err := ReadNpy(bytes.NewReader(test.npy))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(test.want) {
+			t.Errorf("%s: unexpected type: got:%T want:%T", test.name, got, test.want)
+			continue
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("%s: unexpected result:\ngot:\n% v\nwant:\n% v", test.name, Formatted(got), Formatted(test.want))
+		}
+	}
+}
+
+func TestReadNpyError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		npy  []byte
+		want string
+	}{
+		{
+			name: "bad magic",
+			npy:  []byte("\x93NUMPX\x01\x00\x00\x00"),
+			want: "magic",
+		},
+		{
+			name: "complex dtype",
+			npy:  npyBytes("{'descr': '<c16', 'fortran_order': False, 'shape': (1, 1), }", make([]byte, 16)),
+			want: "dtype",
+		},
+		{
+			name: "structured dtype",
+			npy:  npyBytes("{'descr': [('x', '<f8')], 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)),
+			want: "descr",
+		},
+		{
+			name: "3-D shape",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1, 1), }", make([]byte, 8)),
+			want: "shape",
+		},
+		{
+			name: "short data",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }", make([]byte, 24)),
+			want: "EOF",
+		},
+		{
+			name: "overflowing shape",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (3000000000, 3000000000), }", nil),
+			want: "too big",
+		},
+		{
+			name: "overflowing byte count",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2000000000000000000,), }", nil),
+			want: "too big",
+		},
+		{
+			name: "large shape with short data",
+			npy:  npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (100000000,), }", make([]byte, 8)),
+			want: "EOF",
+		},
+	} {
+		_, err := ReadNpy(bytes.NewReader(test.npy))
+		if err == nil {
+			t.Errorf("%s: expected error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: unexpected error: got:%v want containing %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestNpyRoundTrip(t *testing.T) {
+	for _, m := range []Matrix{
+		NewDense(1, 1, []float64{math.Pi}),
+		NewDense(2, 3, []float64{1, 2, 3, 4, 5, math.NaN()}),
+		NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}).Slice(1, 3, 0, 2),
+		NewDense(2, 2, []float64{1, 2, 3, 4}).T(),
+		NewSymDense(2, []float64{1, 2, 2, 3}),
+		NewVecDense(4, []float64{1, -1, 0.5, 1e300}),
+	} {
+		var buf bytes.Buffer
+		err := WriteNpy(&buf, m)
+		if err != nil {
+			t.Fatalf("unexpected error writing npy: %v", err)
+		}
+		if off := 10 + int(binary.LittleEndian.Uint16(buf.Bytes()[8:10])); off%64 != 0 {
+			t.Errorf("data not aligned: offset %d", off)
+		}
+		got, err := ReadNpy(&buf)
+		if err != nil {
+			t.Errorf("unexpected error reading npy: %v", err)
+			continue
+		}
+		_, isVec := m.(*VecDense)
+		if _, ok := got.(*VecDense); ok != isVec {
+			t.Errorf("unexpected type: got:%T for %T", got, m)
+		}
+		if !equalNaN(got, m) {
+			t.Errorf("unexpected round trip result:\ngot:\n% v\nwant:\n% v", Formatted(got), Formatted(m))
+		}
+	}
+}
+
+func TestNpz(t *testing.T) {
+	arrays := map[string]Matrix{
+		"a": NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		"b": NewVecDense(2, []float64{-1, 1}),
+	}
+	var buf bytes.Buffer
+	err := WriteNpz(&buf, arrays)
+	if err != nil {
+		t.Fatalf("unexpected error writing npz: %v", err)
+	}
+	got, err := ReadNpz(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error reading npz: %v", err)
+	}
+	if len(got) != len(arrays) {
+		t.Errorf("unexpected number of arrays: got:%d want:%d", len(got), len(arrays))
+	}
+	for name, want := range arrays {
+		if !Equal(got[name], want) {
+			t.Errorf("unexpected array %q", name)
+		}
+	}
+
+	// Compressed archives are written by numpy.savez_compressed.
+	buf.Reset()
+	zw := zip.NewWriter(&buf)
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "x.npy", Method: zip.Deflate})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Write(npyBytes("{'descr': '<i8', 'fortran_order': False, 'shape': (2,), }", []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}))
+	f, err = zw.Create("bad.npy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zw.Close()
+	_, err = ReadNpz(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err == nil || !strings.Contains(err.Error(), `"bad"`) {
+		t.Errorf("unexpected error for invalid member: %v", err)
+	}
+}
+
+// equalNaN returns whether a and b are equal, treating NaN values as equal.
+func equalNaN(a, b Matrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			av, bv := a.At(i, j), b.At(i, j)
+			if av != bv && !(math.IsNaN(av) && math.IsNaN(bv)) {
+				return false
+			}
+		}
+	}
+	return true
+}
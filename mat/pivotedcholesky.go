@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedCholesky = "mat: invalid pivoted Cholesky factorization"
+
+// PivotedCholesky is a type for creating and using the Cholesky factorization
+// with complete pivoting of a symmetric positive semidefinite matrix. The
+// factorization reveals the numerical rank of the matrix, and for a matrix of
+// low rank, such as a kernel matrix in Gaussian process regression, the
+// leading rows of the factor give a low-rank approximation of the matrix.
+type PivotedCholesky struct {
+	chol *TriDense
+	piv  []int
+	rank int
+}
+
+// Factorize computes the Cholesky factorization with complete pivoting of the
+// symmetric positive semidefinite matrix a. The factorization has the form
+//  P^T * A * P = U^T * U
+// where P is a permutation matrix and U is an upper triangular matrix whose
+// trailing n-rank rows are zero. The pivots are chosen so that the diagonal
+// elements of U are non-increasing.
+//
+// The factorization stops when the largest diagonal element of the remaining
+// Schur complement is less than or equal to tol, and the number of computed
+// rows is the numerical rank of A. If tol is negative, n*ε*max(A[i,i]) is
+// used, where ε is the machine epsilon. Factorize does not check that A is
+// positive semidefinite and an indefinite matrix is treated as rank-deficient.
+//
+// Factorize returns whether A has full rank.
+func (c *PivotedCholesky) Factorize(a Symmetric, tol float64) (ok bool) {
+	n := a.Symmetric()
+	if c.chol == nil {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	copySymIntoTriangle(c.chol, a)
+	if cap(c.piv) < n {
+		c.piv = make([]int, n)
+	}
+	c.piv = c.piv[:n]
+
+	work := getFloats(2*n, false)
+	_, c.rank, ok = lapack64.Pstrf(c.chol.asSymBlas(), c.piv, tol, work)
+	putFloats(work)
+
+	// Zero the rows of the trailing Schur complement that has
+	// not been factorized.
+	for i := c.rank; i < n; i++ {
+		zero(c.chol.mat.Data[i*c.chol.mat.Stride+i : i*c.chol.mat.Stride+n])
+	}
+	return ok
+}
+
+func (c *PivotedCholesky) isZero() bool {
+	return c.chol == nil || c.chol.IsZero()
+}
+
+// Size returns the dimension of the factorized matrix.
+func (c *PivotedCholesky) Size() int {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	return c.chol.mat.N
+}
+
+// Rank returns the numerical rank of the factorized matrix.
+func (c *PivotedCholesky) Rank() int {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	return c.rank
+}
+
+// Pivot returns the permutation P of the factorization. On return, the jth
+// row and column of P^T * A * P are the pivot[j]th row and column of A, that
+// is, P has ones at the elements (pivot[j], j). If pivot is nil, a new slice
+// is allocated, otherwise the length of pivot must be equal to the size of
+// the factorized matrix.
+func (c *PivotedCholesky) Pivot(pivot []int) []int {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	if pivot == nil {
+		pivot = make([]int, len(c.piv))
+	}
+	if len(pivot) != len(c.piv) {
+		panic(badSliceLength)
+	}
+	copy(pivot, c.piv)
+	return pivot
+}
+
+// UTo extracts the n×n upper triangular matrix U from a pivoted Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// TriDense is allocated. Only the leading rank rows of U are non-zero.
+//  P^T * A * P = U^T * U.
+func (c *PivotedCholesky) UTo(dst *TriDense) *TriDense {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewTriDense(n, Upper, make([]float64, n*n))
+	} else {
+		dst.reuseAs(n, Upper)
+	}
+	dst.Copy(c.chol)
+	return dst
+}
+
+// FactorTo extracts the rank×n matrix F such that
+//  A ≈ F^T * F
+// from a pivoted Cholesky decomposition into dst and returns the result. The
+// approximation is exact up to the tolerance used in Factorize. F is the
+// matrix U with its columns permuted back by P and its zero rows removed. If
+// dst is nil a new matrix is allocated.
+func (c *PivotedCholesky) FactorTo(dst *Dense) *Dense {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewDense(c.rank, n, nil)
+	} else {
+		dst.reuseAsZeroed(c.rank, n)
+	}
+	for i := 0; i < c.rank; i++ {
+		for j := i; j < n; j++ {
+			dst.set(i, c.piv[j], c.chol.at(i, j))
+		}
+	}
+	return dst
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented by
+// the pivoted Cholesky decomposition, placing the result in m. If A is
+// rank-deficient, m is not modified and a Condition error is returned.
+func (c *PivotedCholesky) Solve(m *Dense, b Matrix) error {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+	if c.rank < n {
+		return Condition(math.Inf(1))
+	}
+
+	// Solve U^T * U * y = P^T * b and form m = P * y.
+	y := getWorkspace(n, bn, false)
+	defer putWorkspace(y)
+	for i, p := range c.piv {
+		for j := 0; j < bn; j++ {
+			y.set(i, j, b.At(p, j))
+		}
+	}
+	blas64.Trsm(blas.Left, blas.Trans, 1, c.chol.mat, y.mat)
+	blas64.Trsm(blas.Left, blas.NoTrans, 1, c.chol.mat, y.mat)
+	m.reuseAs(n, bn)
+	for i, p := range c.piv {
+		copy(m.mat.Data[p*m.mat.Stride:p*m.mat.Stride+bn], y.mat.Data[i*y.mat.Stride:i*y.mat.Stride+bn])
+	}
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented by
+// the pivoted Cholesky decomposition, placing the result in v. Please see
+// PivotedCholesky.Solve for the full documentation.
+func (c *PivotedCholesky) SolveVec(v, b *VecDense) error {
+	if c.isZero() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if c.rank < n {
+		return Condition(math.Inf(1))
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	v.reuseAs(n)
+	return c.Solve(v.asDense(), b.asDense())
+}
@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPivotedCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, rank int
+	}{
+		{1, 1},
+		{2, 1},
+		{5, 5},
+		{10, 10},
+		{10, 4},
+		{20, 1},
+		{50, 30},
+		{100, 100},
+		{150, 70},
+	} {
+		n, rank := test.n, test.rank
+		var a SymDense
+		a.SymOuterK(1, randRankDeficient(n, rank, rank, rnd))
+		if rank == n {
+			// Make the matrix well-conditioned so that the
+			// residual of the solution can be checked.
+			for i := 0; i < n; i++ {
+				a.SetSym(i, i, a.At(i, i)+1)
+			}
+		}
+
+		var chol PivotedCholesky
+		ok := chol.Factorize(&a, 1e-10)
+		if ok != (rank == n) {
+			t.Errorf("n=%d,rank=%d: unexpected ok: got:%t want:%t", n, rank, ok, rank == n)
+		}
+		if got := chol.Rank(); got != rank {
+			t.Errorf("n=%d,rank=%d: unexpected rank: got:%d", n, rank, got)
+		}
+
+		u := chol.UTo(nil)
+		for i := 1; i < n; i++ {
+			if u.At(i, i) > u.At(i-1, i-1) {
+				t.Errorf("n=%d,rank=%d: diagonal of U is not non-increasing", n, rank)
+				break
+			}
+		}
+		for i := rank; i < n; i++ {
+			for j := i; j < n; j++ {
+				if u.At(i, j) != 0 {
+					t.Errorf("n=%d,rank=%d: trailing rows of U are not zero", n, rank)
+				}
+			}
+		}
+
+		// Check P^T * A * P = U^T * U.
+		pivot := chol.Pivot(nil)
+		pap := NewDense(n, n, nil)
+		for i, p := range pivot {
+			for j, q := range pivot {
+				pap.Set(i, j, a.At(p, q))
+			}
+		}
+		var utu Dense
+		utu.Mul(u.T(), u)
+		if !EqualApprox(&utu, pap, 1e-10) {
+			t.Errorf("n=%d,rank=%d: P^T*A*P != U^T*U", n, rank)
+		}
+
+		// Check A = F^T * F.
+		f := chol.FactorTo(nil)
+		if r, c := f.Dims(); r != rank || c != n {
+			t.Errorf("n=%d,rank=%d: unexpected shape of F: %d×%d", n, rank, r, c)
+		}
+		var ftf Dense
+		ftf.Mul(f.T(), f)
+		if !EqualApprox(&ftf, &a, 1e-10) {
+			t.Errorf("n=%d,rank=%d: A != F^T*F", n, rank)
+		}
+
+		b := NewDense(n, 3, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		var x Dense
+		err := chol.Solve(&x, b)
+		if rank < n {
+			if err == nil {
+				t.Errorf("n=%d,rank=%d: expected error solving rank-deficient system", n, rank)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("n=%d,rank=%d: unexpected error: %v", n, rank, err)
+		}
+		var ax Dense
+		ax.Mul(&a, &x)
+		if !EqualApprox(&ax, b, 1e-8) {
+			t.Errorf("n=%d,rank=%d: A*X != B", n, rank)
+		}
+
+		bv := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			bv.SetVec(i, b.At(i, 2))
+		}
+		var xv VecDense
+		if err := chol.SolveVec(&xv, bv); err != nil {
+			t.Errorf("n=%d,rank=%d: unexpected error: %v", n, rank, err)
+		}
+		if !EqualApprox(&xv, x.ColView(2), 1e-12) {
+			t.Errorf("n=%d,rank=%d: unexpected vector solution", n, rank)
+		}
+	}
+}
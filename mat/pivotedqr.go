@@ -0,0 +1,278 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedQR = "mat: invalid pivoted QR factorization"
+
+// PivotedQR is a type for creating and using the QR factorization with column
+// pivoting of a matrix. The factorization reveals the numerical rank of the
+// matrix and can be used to find minimum-norm solutions of rank-deficient
+// least-squares problems.
+type PivotedQR struct {
+	qr   *Dense
+	tau  []float64
+	jpvt []int
+}
+
+// Factorize computes the QR factorization with column pivoting of the m×n
+// matrix a. The factorization always exists even if A is rank-deficient.
+//
+// The QR decomposition with column pivoting is a factorization of the matrix
+// A such that
+//  A * P = Q * R
+// where P is an n×n permutation matrix, Q is an m×m orthonormal matrix and R
+// is an m×n upper trapezoidal matrix. The columns are chosen so that the
+// magnitudes of the diagonal elements of R are non-increasing.
+func (qr *PivotedQR) Factorize(a Matrix) {
+	m, n := a.Dims()
+	if qr.qr == nil {
+		qr.qr = &Dense{}
+	}
+	qr.qr.Clone(a)
+	k := min(m, n)
+	qr.tau = use(qr.tau, k)
+	if cap(qr.jpvt) < n {
+		qr.jpvt = make([]int, n)
+	}
+	qr.jpvt = qr.jpvt[:n]
+	for i := range qr.jpvt {
+		qr.jpvt[i] = -1
+	}
+	work := []float64{0}
+	lapack64.Geqp3(qr.qr.mat, qr.jpvt, qr.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqp3(qr.qr.mat, qr.jpvt, qr.tau, work, len(work))
+	putFloats(work)
+}
+
+func (qr *PivotedQR) isZero() bool {
+	return qr.qr == nil || qr.qr.IsZero()
+}
+
+// reflectors returns the elementary reflectors of Q as an m×min(m,n) matrix.
+func (qr *PivotedQR) reflectors() blas64.General {
+	a := qr.qr.mat
+	a.Cols = len(qr.tau)
+	return a
+}
+
+// Rank returns the numerical rank of the factorized matrix, the number of
+// diagonal elements of R with magnitude greater than rcond times the
+// magnitude of the leading diagonal element. If rcond is not positive,
+// max(m,n)*ε is used, where ε is the machine epsilon.
+func (qr *PivotedQR) Rank(rcond float64) int {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	m, n := qr.qr.Dims()
+	if rcond <= 0 {
+		// dlamchE is the machine epsilon.
+		const dlamchE = 1.0 / (1 << 53)
+		rcond = float64(max(m, n)) * dlamchE
+	}
+	tol := rcond * math.Abs(qr.qr.at(0, 0))
+	var rank int
+	for rank < len(qr.tau) && math.Abs(qr.qr.at(rank, rank)) > tol {
+		rank++
+	}
+	return rank
+}
+
+// Pivot returns the column permutation P of the factorization. On return,
+// the jth column of A*P is the pivot[j]th column of A, that is, P has ones at
+// the elements (pivot[j], j). If pivot is nil, a new slice is allocated,
+// otherwise the length of pivot must be equal to the number of columns of
+// the factorized matrix.
+func (qr *PivotedQR) Pivot(pivot []int) []int {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	if pivot == nil {
+		pivot = make([]int, len(qr.jpvt))
+	}
+	if len(pivot) != len(qr.jpvt) {
+		panic(badSliceLength)
+	}
+	copy(pivot, qr.jpvt)
+	return pivot
+}
+
+// RTo extracts the m×n upper trapezoidal matrix R from a pivoted QR
+// decomposition. If dst is nil, a new matrix is allocated. The resulting dst
+// matrix is returned.
+func (qr *PivotedQR) RTo(dst *Dense) *Dense {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+	for i := 0; i < min(r, c); i++ {
+		copy(dst.mat.Data[i*dst.mat.Stride+i:i*dst.mat.Stride+c], qr.qr.mat.Data[i*qr.qr.mat.Stride+i:i*qr.qr.mat.Stride+c])
+	}
+	return dst
+}
+
+// QTo extracts the m×m orthonormal matrix Q from a pivoted QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is
+// returned.
+func (qr *PivotedQR) QTo(dst *Dense) *Dense {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	a := qr.reflectors()
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a, qr.tau, dst.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a, qr.tau, dst.mat, work, len(work))
+	putFloats(work)
+
+	return dst
+}
+
+// Solve finds the minimum-norm solution X of the least-squares problem
+//  minimize ||A * X - b||_2
+// where A is the m×n matrix represented by the pivoted QR factorization and
+// b is an m×k matrix, and stores X into m. Solve returns the numerical rank
+// of A determined by Rank(rcond).
+//
+// Columns of A that are numerically linearly dependent on the preceding
+// pivot columns are treated as exactly dependent, and the solution is found
+// using the complete orthogonal decomposition
+//  A * P = Q * [ T_11 0 ] * Z^T
+//              [ 0    0 ]
+// where T_11 is a rank×rank lower triangular matrix and Z is an orthonormal
+// n×n matrix. Unlike QR.Solve, Solve therefore returns a meaningful solution
+// when A is rank-deficient, such as a design matrix with collinear columns.
+func (qr *PivotedQR) Solve(m *Dense, b Matrix, rcond float64) (rank int) {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	r, c := qr.qr.Dims()
+	br, bc := b.Dims()
+	if br != r {
+		panic(ErrShape)
+	}
+	rank = qr.Rank(rcond)
+
+	// x holds b on entry and the permuted solution on return.
+	// It must be large enough to hold both.
+	x := getWorkspace(max(r, c), bc, true)
+	defer putWorkspace(x)
+	x.Copy(b)
+
+	// Compute Q^T * b.
+	a := qr.reflectors()
+	xm := x.mat
+	xm.Rows = r
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.Trans, a, qr.tau, xm, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.Trans, a, qr.tau, xm, work, len(work))
+	putFloats(work)
+
+	for i := rank; i < max(r, c); i++ {
+		zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
+	}
+	if rank > 0 {
+		xr := x.mat
+		xr.Rows = rank
+		if rank == c {
+			// A has full column rank, so the leading rank×rank
+			// block of R is upper triangular and non-singular.
+			r11 := qr.qr.asTriDense(rank, blas.NonUnit, blas.Upper).mat
+			lapack64.Trtrs(blas.NoTrans, r11, xr)
+		} else {
+			qr.solveCOD(x, rank)
+		}
+	}
+
+	// Undo the column permutation.
+	m.reuseAs(c, bc)
+	for j, p := range qr.jpvt {
+		copy(m.mat.Data[p*m.mat.Stride:p*m.mat.Stride+bc], x.mat.Data[j*x.mat.Stride:j*x.mat.Stride+bc])
+	}
+	return rank
+}
+
+// solveCOD computes the minimum-norm solution y of
+//  [ R_11 R_12 ] * y = c
+// where R_11 R_12 are the leading rank rows of R, c is held in the leading
+// rank rows of x on entry and y is stored in the leading n rows of x on
+// return. The rows of x between rank and n must be zero on entry.
+func (qr *PivotedQR) solveCOD(x *Dense, rank int) {
+	_, c := qr.qr.Dims()
+	// Compute the QR factorization of the transpose of the
+	// leading rank rows of R
+	//  [ R_11 R_12 ]^T = Z * [ T_11^T ]
+	//                        [   0    ]
+	// so that [ R_11 R_12 ] = [ T_11 0 ] * Z^T.
+	t := getWorkspace(c, rank, true)
+	defer putWorkspace(t)
+	for i := 0; i < rank; i++ {
+		for j := i; j < c; j++ {
+			t.set(j, i, qr.qr.at(i, j))
+		}
+	}
+	tau := getFloats(rank, false)
+	defer putFloats(tau)
+	work := []float64{0}
+	lapack64.Geqrf(t.mat, tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqrf(t.mat, tau, work, len(work))
+	putFloats(work)
+
+	// Solve T_11 * w = c and form y = Z * [ w ].
+	//                                     [ 0 ]
+	xr := x.mat
+	xr.Rows = rank
+	lapack64.Trtrs(blas.Trans, t.asTriDense(rank, blas.NonUnit, blas.Upper).mat, xr)
+	xc := x.mat
+	xc.Rows = c
+	work = []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, t.mat, tau, xc, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, t.mat, tau, xc, work, len(work))
+	putFloats(work)
+}
+
+// SolveVec finds the minimum-norm solution x of the least-squares problem
+//  minimize ||A * x - b||_2
+// and stores x into v. Please see PivotedQR.Solve for the full documentation.
+func (qr *PivotedQR) SolveVec(v, b *VecDense, rcond float64) (rank int) {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	_, c := qr.qr.Dims()
+	v.reuseAs(c)
+	return qr.Solve(v.asDense(), b.asDense(), rcond)
+}
@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randRankDeficient returns a random m×n matrix of rank r.
+func randRankDeficient(m, n, r int, rnd *rand.Rand) *Dense {
+	x := NewDense(m, r, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = rnd.NormFloat64()
+	}
+	y := NewDense(r, n, nil)
+	for i := range y.mat.Data {
+		y.mat.Data[i] = rnd.NormFloat64()
+	}
+	var a Dense
+	a.Mul(x, y)
+	return &a
+}
+
+func TestPivotedQR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{1, 1, 1},
+		{5, 3, 3},
+		{3, 5, 3},
+		{10, 10, 10},
+		{10, 10, 4},
+		{20, 8, 5},
+		{8, 20, 5},
+		{15, 15, 1},
+	} {
+		m, n := test.m, test.n
+		a := randRankDeficient(m, n, test.rank, rnd)
+
+		var qr PivotedQR
+		qr.Factorize(a)
+		if got := qr.Rank(0); got != test.rank {
+			t.Errorf("m=%d,n=%d: unexpected rank: got:%d want:%d", m, n, got, test.rank)
+		}
+
+		q := qr.QTo(nil)
+		if !isOrthonormal(q, 1e-10) {
+			t.Errorf("m=%d,n=%d: Q is not orthonormal", m, n)
+		}
+		r := qr.RTo(nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("m=%d,n=%d: R is not upper trapezoidal", m, n)
+				}
+			}
+		}
+		for i := 1; i < min(m, n); i++ {
+			if math.Abs(r.At(i, i)) > math.Abs(r.At(i-1, i-1))*(1+1e-14) {
+				t.Errorf("m=%d,n=%d: diagonal of R is not non-increasing", m, n)
+				break
+			}
+		}
+
+		// Check A*P = Q*R.
+		pivot := qr.Pivot(nil)
+		ap := NewDense(m, n, nil)
+		for j, p := range pivot {
+			for i := 0; i < m; i++ {
+				ap.Set(i, j, a.At(i, p))
+			}
+		}
+		var qrProd Dense
+		qrProd.Mul(q, r)
+		if !EqualApprox(&qrProd, ap, 1e-12) {
+			t.Errorf("m=%d,n=%d: A*P != Q*R", m, n)
+		}
+	}
+}
+
+func TestPivotedQRSolve(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{1, 1, 1},
+		{5, 3, 3},
+		{3, 5, 3},
+		{10, 10, 10},
+		{10, 10, 4},
+		{20, 8, 5},
+		{8, 20, 5},
+		{15, 15, 1},
+	} {
+		m, n, rank := test.m, test.n, test.rank
+		a := randRankDeficient(m, n, rank, rnd)
+		b := NewDense(m, 3, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+
+		// Compute the minimum-norm solution using the
+		// pseudo-inverse formed from the SVD of A.
+		var svd SVD
+		svd.Factorize(a, SVDThin)
+		s := svd.Values(nil)
+		u := svd.UTo(nil)
+		v := svd.VTo(nil)
+		for j := 0; j < len(s); j++ {
+			inv := 0.0
+			if j < rank {
+				inv = 1 / s[j]
+			}
+			for i := 0; i < n; i++ {
+				v.Set(i, j, v.At(i, j)*inv)
+			}
+		}
+		var utb, want Dense
+		utb.Mul(u.T(), b)
+		want.Mul(v, &utb)
+
+		var qr PivotedQR
+		qr.Factorize(a)
+		var x Dense
+		gotRank := qr.Solve(&x, b, 0)
+		if gotRank != rank {
+			t.Errorf("m=%d,n=%d: unexpected rank: got:%d want:%d", m, n, gotRank, rank)
+		}
+		if !EqualApprox(&x, &want, 1e-8) {
+			t.Errorf("m=%d,n=%d,rank=%d: unexpected solution:\ngot:\n% .4v\nwant:\n% .4v", m, n, rank, Formatted(&x), Formatted(&want))
+		}
+
+		bv := NewVecDense(m, nil)
+		for i := 0; i < m; i++ {
+			bv.SetVec(i, b.At(i, 1))
+		}
+		var xv VecDense
+		qr.SolveVec(&xv, bv, 0)
+		if !EqualApprox(&xv, want.ColView(1), 1e-8) {
+			t.Errorf("m=%d,n=%d,rank=%d: unexpected vector solution", m, n, rank)
+		}
+	}
+
+	// Collinear columns of a design matrix.
+	a := NewDense(4, 3, []float64{
+		1, 1, 2,
+		1, 2, 3,
+		1, 3, 4,
+		1, 4, 5,
+	})
+	b := NewVecDense(4, []float64{2, 3, 4, 5})
+	var qr PivotedQR
+	qr.Factorize(a)
+	var x VecDense
+	rank := qr.SolveVec(&x, b, 0)
+	if rank != 2 {
+		t.Errorf("unexpected rank for collinear columns: got:%d want:2", rank)
+	}
+	// The columns satisfy a_2 = a_0 + a_1 and b = a_2, so the solutions
+	// are x = (1-s, 1-s, s) with minimum norm at s = 2/3.
+	want := NewVecDense(3, []float64{1.0 / 3, 1.0 / 3, 2.0 / 3})
+	if !EqualApprox(&x, want, 1e-12) {
+		t.Errorf("unexpected solution for collinear columns: got:%v want:%v", x.RawVector().Data, want.RawVector().Data)
+	}
+}
+
@@ -18,6 +18,11 @@ type QR struct {
 	qr   *Dense
 	tau  []float64
 	cond float64
+
+	// q holds the explicit orthonormal factor Q after the
+	// factorization has been updated. If q is nil, Q is held
+	// as elementary reflectors in qr and tau.
+	q *Dense
 }
 
 func (qr *QR) updateCond(norm lapack.MatrixNorm) {
@@ -58,6 +63,7 @@ func (qr *QR) factorize(a Matrix, norm lapack.MatrixNorm) {
 		qr.qr = &Dense{}
 	}
 	qr.qr.Clone(a)
+	qr.q = nil
 	work := []float64{0}
 	qr.tau = make([]float64, k)
 	lapack64.Geqrf(qr.qr.mat, qr.tau, work, -1)
@@ -127,19 +133,33 @@ func (qr *QR) QTo(dst *Dense) *Dense {
 	}
 
 	// Construct Q from the elementary reflectors.
+	qr.applyQ(blas.NoTrans, dst)
+
+	return dst
+}
+
+// applyQ stores Q * x into x if trans is blas.NoTrans and Q^T * x otherwise.
+func (qr *QR) applyQ(trans blas.Transpose, x *Dense) {
+	if qr.q != nil {
+		r, c := x.Dims()
+		tmp := getWorkspace(r, c, false)
+		blas64.Gemm(trans, blas.NoTrans, 1, qr.q.mat, x.mat, 0, tmp.mat)
+		x.Copy(tmp)
+		putWorkspace(tmp)
+		return
+	}
 	work := []float64{0}
-	lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, -1)
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, -1)
 	work = getFloats(int(work[0]), false)
-	lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, len(work))
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
 	putFloats(work)
-
-	return dst
 }
 
 // Solve finds a minimum-norm solution to a system of linear equations defined
 // by the matrices A and b, where A is an m×n matrix represented in its QR factorized
 // form. If A is singular or near-singular a Condition error is returned. Please
-// see the documentation for Condition for more information.
+// see the documentation for Condition for more information. Rank-deficient
+// problems should be solved with PivotedQR.Solve.
 //
 // The minimization problem solved depends on the input parameters.
 //  If trans == false, find X such that ||A*X - b||_2 is minimized.
@@ -177,17 +197,9 @@ func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
 		for i := c; i < r; i++ {
 			zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
 		}
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
+		qr.applyQ(blas.NoTrans, x)
 	} else {
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
+		qr.applyQ(blas.Trans, x)
 
 		ok := lapack64.Trtrs(blas.NoTrans, t, x.mat)
 		if !ok {
@@ -219,3 +231,261 @@ func (qr *QR) SolveVec(v *VecDense, trans bool, b *VecDense) error {
 	}
 	return qr.Solve(v.asDense(), trans, b.asDense())
 }
+
+func (qr *QR) isZero() bool {
+	return qr.qr == nil || qr.qr.IsZero()
+}
+
+// explicitQR returns newly allocated copies of the explicit factors Q and R
+// of the QR factorization.
+func (qr *QR) explicitQR() (q, r *Dense) {
+	m, n := qr.qr.Dims()
+	r = NewDense(m, n, nil)
+	for i := 0; i < n; i++ {
+		copy(r.mat.Data[i*r.mat.Stride+i:i*r.mat.Stride+n], qr.qr.mat.Data[i*qr.qr.mat.Stride+i:i*qr.qr.mat.Stride+n])
+	}
+	if qr.q != nil {
+		q = DenseCopyOf(qr.q)
+	} else {
+		q = qr.QTo(nil)
+	}
+	return q, r
+}
+
+// setExplicit stores the explicit factors Q and R into the receiver.
+func (qr *QR) setExplicit(q, r *Dense) {
+	qr.q = q
+	qr.qr = r
+	qr.tau = nil
+	qr.updateCond(CondNorm)
+}
+
+// rotate applies the plane rotation
+//  [ c s]
+//  [-s c]
+// from the left to rows i and i+1 of r, starting from column j, and the
+// transpose of the rotation from the right to columns i and i+1 of q, so that
+// the product q*r is unchanged.
+func rotate(q, r *Dense, i, j int, c, s float64) {
+	n := r.mat.Cols
+	if j < n {
+		blas64.Rot(n-j,
+			blas64.Vector{Inc: 1, Data: r.mat.Data[i*r.mat.Stride+j:]},
+			blas64.Vector{Inc: 1, Data: r.mat.Data[(i+1)*r.mat.Stride+j:]},
+			c, s)
+	}
+	blas64.Rot(q.mat.Rows,
+		blas64.Vector{Inc: q.mat.Stride, Data: q.mat.Data[i:]},
+		blas64.Vector{Inc: q.mat.Stride, Data: q.mat.Data[i+1:]},
+		c, s)
+}
+
+// retriangularize restores the upper triangular form of the upper Hessenberg
+// matrix r, starting at column j, by applying plane rotations to r and q.
+func retriangularize(q, r *Dense, j int) {
+	m, n := r.Dims()
+	for k := j; k < min(n, m-1); k++ {
+		c, s, v := lapack64.Lartg(r.at(k, k), r.at(k+1, k))
+		rotate(q, r, k, k+1, c, s)
+		r.set(k, k, v)
+		r.set(k+1, k, 0)
+	}
+}
+
+// RankOne updates a QR factorization as if a rank-one update had been applied
+// to the original m×n matrix A, storing the result into the receiver. That is,
+// if in the original QR factorization Q * R = A, in the updated factorization
+//  Q * R = A + alpha * x * y^T.
+// The length of x must be m and the length of y must be n, otherwise RankOne
+// will panic.
+//
+// RankOne updates the factorization using plane rotations in O(m²) time.
+// Computing the QR factorization from scratch is O(m*n²).
+func (qr *QR) RankOne(orig *QR, alpha float64, x, y *VecDense) {
+	if orig.isZero() {
+		panic("qr: no decomposition computed")
+	}
+	m, n := orig.qr.Dims()
+	if x.Len() != m || y.Len() != n {
+		panic(ErrShape)
+	}
+	q, r := orig.explicitQR()
+
+	// Compute w = Q^T * x and reduce it to a multiple of e_0,
+	// which makes R upper Hessenberg.
+	w := getFloats(m, false)
+	defer putFloats(w)
+	blas64.Gemv(blas.Trans, 1, q.mat, x.mat, 0, blas64.Vector{Inc: 1, Data: w})
+	for k := m - 1; k > 0; k-- {
+		c, s, v := lapack64.Lartg(w[k-1], w[k])
+		w[k-1] = v
+		w[k] = 0
+		rotate(q, r, k-1, max(0, k-2), c, s)
+	}
+
+	// Add the update to the first row of R and restore its upper
+	// triangular form.
+	blas64.Axpy(n, alpha*w[0], y.mat, blas64.Vector{Inc: 1, Data: r.mat.Data[:n]})
+	retriangularize(q, r, 0)
+	qr.setExplicit(q, r)
+}
+
+// InsertRow updates a QR factorization as if the row x had been inserted into
+// the original m×n matrix A before its ith row, storing the (m+1)×n result
+// into the receiver. If i is m, x is appended after the last row of A. The
+// length of x must be n, otherwise InsertRow will panic.
+//
+// InsertRow updates the factorization using plane rotations in O(m*n) time,
+// plus the O(m²) time needed to form the enlarged Q.
+func (qr *QR) InsertRow(orig *QR, i int, x *VecDense) {
+	if orig.isZero() {
+		panic("qr: no decomposition computed")
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m < i {
+		panic(ErrRowAccess)
+	}
+	if x.Len() != n {
+		panic(ErrShape)
+	}
+	q0, r0 := orig.explicitQR()
+
+	// The enlarged matrix satisfies
+	//  [ x^T ] = [ 1 0 ] * [ x^T ]
+	//  [  A  ]   [ 0 Q ]   [  R  ]
+	// where the first row is moved into the ith position.
+	q := NewDense(m+1, m+1, nil)
+	r := NewDense(m+1, n, nil)
+	for k := 0; k < m+1; k++ {
+		switch {
+		case k < i:
+			copy(q.mat.Data[k*q.mat.Stride+1:k*q.mat.Stride+m+1], q0.RawRowView(k))
+		case k == i:
+			q.set(k, 0, 1)
+		default:
+			copy(q.mat.Data[k*q.mat.Stride+1:k*q.mat.Stride+m+1], q0.RawRowView(k-1))
+		}
+	}
+	for j := 0; j < n; j++ {
+		r.set(0, j, x.at(j))
+	}
+	r.Slice(1, m+1, 0, n).(*Dense).Copy(r0)
+	retriangularize(q, r, 0)
+	qr.setExplicit(q, r)
+}
+
+// DeleteRow updates a QR factorization as if the ith row had been removed
+// from the original m×n matrix A, storing the (m-1)×n result into the
+// receiver. DeleteRow will panic if m-1 < n.
+//
+// DeleteRow updates the factorization using plane rotations in O(m²) time.
+func (qr *QR) DeleteRow(orig *QR, i int) {
+	if orig.isZero() {
+		panic("qr: no decomposition computed")
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m <= i {
+		panic(ErrRowAccess)
+	}
+	if m-1 < n {
+		panic(ErrShape)
+	}
+	q0, r0 := orig.explicitQR()
+
+	// Reduce the ith row of Q to a multiple of e_0^T. The first column
+	// of Q is then ±e_i and the trailing rows of R are upper triangular.
+	w := make([]float64, m)
+	copy(w, q0.RawRowView(i))
+	for k := m - 1; k > 0; k-- {
+		c, s, v := lapack64.Lartg(w[k-1], w[k])
+		w[k-1] = v
+		w[k] = 0
+		rotate(q0, r0, k-1, max(0, k-1), c, s)
+	}
+
+	q := NewDense(m-1, m-1, nil)
+	for k := 0; k < m-1; k++ {
+		src := k
+		if k >= i {
+			src++
+		}
+		copy(q.RawRowView(k), q0.RawRowView(src)[1:])
+	}
+	r := NewDense(m-1, n, nil)
+	r.Copy(r0.Slice(1, m, 0, n))
+	qr.setExplicit(q, r)
+}
+
+// InsertCol updates a QR factorization as if the column x had been inserted
+// into the original m×n matrix A before its jth column, storing the
+// m×(n+1) result into the receiver. If j is n, x is appended after the last
+// column of A. The length of x must be m and InsertCol will panic if m < n+1.
+//
+// InsertCol updates the factorization using plane rotations in O(m²) time.
+func (qr *QR) InsertCol(orig *QR, j int, x *VecDense) {
+	if orig.isZero() {
+		panic("qr: no decomposition computed")
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n < j {
+		panic(ErrColAccess)
+	}
+	if x.Len() != m {
+		panic(ErrShape)
+	}
+	if m < n+1 {
+		panic(ErrShape)
+	}
+	q, r0 := orig.explicitQR()
+
+	// Insert w = Q^T * x as the jth column of R.
+	r := NewDense(m, n+1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(r0.Slice(0, m, 0, j))
+	}
+	if j < n {
+		r.Slice(0, m, j+1, n+1).(*Dense).Copy(r0.Slice(0, m, j, n))
+	}
+	w := r.ColView(j).(*VecDense)
+	w.MulVec(q.T(), x)
+
+	// Zero the new column below the diagonal from the bottom up. The
+	// shifted columns after j remain upper triangular.
+	for k := m - 1; k > j; k-- {
+		c, s, v := lapack64.Lartg(r.at(k-1, j), r.at(k, j))
+		rotate(q, r, k-1, j+1, c, s)
+		r.set(k-1, j, v)
+		r.set(k, j, 0)
+	}
+	qr.setExplicit(q, r)
+}
+
+// DeleteCol updates a QR factorization as if the jth column had been removed
+// from the original m×n matrix A, storing the m×(n-1) result into the
+// receiver. DeleteCol will panic if n is one.
+//
+// DeleteCol updates the factorization using plane rotations in O(m*n) time.
+func (qr *QR) DeleteCol(orig *QR, j int) {
+	if orig.isZero() {
+		panic("qr: no decomposition computed")
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n <= j {
+		panic(ErrColAccess)
+	}
+	if n == 1 {
+		panic(ErrShape)
+	}
+	q, r0 := orig.explicitQR()
+
+	// Removing the column leaves R upper Hessenberg from column j.
+	r := NewDense(m, n-1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(r0.Slice(0, m, 0, j))
+	}
+	if j < n-1 {
+		r.Slice(0, m, j, n-1).(*Dense).Copy(r0.Slice(0, m, j+1, n))
+	}
+	retriangularize(q, r, j)
+	qr.setExplicit(q, r)
+}
@@ -203,3 +203,199 @@ func TestSolveQRCond(t *testing.T) {
 		}
 	}
 }
+
+// checkQR checks that qr is a valid QR factorization of a.
+func checkQR(t *testing.T, name string, qr *QR, a *Dense) {
+	m, n := a.Dims()
+	q := qr.QTo(nil)
+	if !isOrthonormal(q, 1e-12) {
+		t.Errorf("%s: Q is not orthonormal", name)
+	}
+	r := qr.RTo(nil)
+	if rr, rc := r.Dims(); rr != m || rc != n {
+		t.Errorf("%s: unexpected dimensions of R: got:%d×%d want:%d×%d", name, rr, rc, m, n)
+		return
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < min(i, n); j++ {
+			if r.At(i, j) != 0 {
+				t.Errorf("%s: R is not upper triangular", name)
+				return
+			}
+		}
+	}
+	var got Dense
+	got.Mul(q, r)
+	if !EqualApprox(&got, a, 1e-12) {
+		t.Errorf("%s: Q*R does not equal the updated matrix", name)
+	}
+
+	// Check that the updated factorization can be used to solve a
+	// least-squares problem.
+	b := NewDense(m, 2, nil)
+	for i := range b.mat.Data {
+		b.mat.Data[i] = rand.NormFloat64()
+	}
+	var x, want Dense
+	if err := qr.Solve(&x, false, b); err != nil {
+		t.Errorf("%s: unexpected error from Solve: %v", name, err)
+		return
+	}
+	var fresh QR
+	fresh.Factorize(a)
+	fresh.Solve(&want, false, b)
+	if !EqualApprox(&x, &want, 1e-10) {
+		t.Errorf("%s: unexpected solution from updated factorization", name)
+	}
+}
+
+// randNormDense returns an m×n matrix with elements drawn from the standard
+// normal distribution.
+func randNormDense(m, n int, rnd *rand.Rand) *Dense {
+	a := NewDense(m, n, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.NormFloat64()
+	}
+	return a
+}
+
+func TestQRUpdate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{2, 1},
+		{5, 5},
+		{8, 5},
+		{10, 3},
+	} {
+		m, n := test.m, test.n
+		a := randNormDense(m, n, rnd)
+		var orig QR
+		orig.Factorize(a)
+
+		// Rank-one update.
+		x := randVecDense(m, 1, 1, rnd.NormFloat64)
+		y := randVecDense(n, 1, 1, rnd.NormFloat64)
+		alpha := rnd.NormFloat64()
+		var want Dense
+		want.Outer(alpha, x, y)
+		want.Add(&want, a)
+		var qr QR
+		qr.RankOne(&orig, alpha, x, y)
+		checkQR(t, "RankOne", &qr, &want)
+
+		// Row insertion at every position.
+		for i := 0; i <= m; i++ {
+			x := randVecDense(n, 1, 1, rnd.NormFloat64)
+			want := NewDense(m+1, n, nil)
+			for k := 0; k < m+1; k++ {
+				for j := 0; j < n; j++ {
+					switch {
+					case k < i:
+						want.Set(k, j, a.At(k, j))
+					case k == i:
+						want.Set(k, j, x.At(j, 0))
+					default:
+						want.Set(k, j, a.At(k-1, j))
+					}
+				}
+			}
+			var qr QR
+			qr.InsertRow(&orig, i, x)
+			checkQR(t, "InsertRow", &qr, want)
+		}
+
+		// Row deletion at every position.
+		if m > n {
+			for i := 0; i < m; i++ {
+				want := NewDense(m-1, n, nil)
+				for k := 0; k < m-1; k++ {
+					src := k
+					if k >= i {
+						src++
+					}
+					for j := 0; j < n; j++ {
+						want.Set(k, j, a.At(src, j))
+					}
+				}
+				var qr QR
+				qr.DeleteRow(&orig, i)
+				checkQR(t, "DeleteRow", &qr, want)
+			}
+		} else if panicked, _ := panics(func() { new(QR).DeleteRow(&orig, 0) }); !panicked {
+			t.Errorf("expected panic deleting a row from a square factorization")
+		}
+
+		// Column insertion at every position.
+		if m > n {
+			for j := 0; j <= n; j++ {
+				x := randVecDense(m, 1, 1, rnd.NormFloat64)
+				want := NewDense(m, n+1, nil)
+				for i := 0; i < m; i++ {
+					for k := 0; k < n+1; k++ {
+						switch {
+						case k < j:
+							want.Set(i, k, a.At(i, k))
+						case k == j:
+							want.Set(i, k, x.At(i, 0))
+						default:
+							want.Set(i, k, a.At(i, k-1))
+						}
+					}
+				}
+				var qr QR
+				qr.InsertCol(&orig, j, x)
+				checkQR(t, "InsertCol", &qr, want)
+			}
+		}
+
+		// Column deletion at every position.
+		if n > 1 {
+			for j := 0; j < n; j++ {
+				want := NewDense(m, n-1, nil)
+				for i := 0; i < m; i++ {
+					for k := 0; k < n-1; k++ {
+						src := k
+						if k >= j {
+							src++
+						}
+						want.Set(i, k, a.At(i, src))
+					}
+				}
+				var qr QR
+				qr.DeleteCol(&orig, j)
+				checkQR(t, "DeleteCol", &qr, want)
+			}
+		}
+	}
+
+	// A sequence of in-place updates of an active set.
+	a := randNormDense(12, 3, rnd)
+	var qr QR
+	qr.Factorize(a)
+	col := randVecDense(12, 1, 1, rnd.NormFloat64)
+	qr.InsertCol(&qr, 1, col)
+	qr.DeleteRow(&qr, 4)
+	row := randVecDense(4, 1, 1, rnd.NormFloat64)
+	qr.InsertRow(&qr, 11, row)
+	qr.DeleteCol(&qr, 0)
+	want := NewDense(12, 3, nil)
+	for i, src := 0, 0; i < 12; i++ {
+		if i == 11 {
+			want.Set(i, 0, row.At(1, 0))
+			want.Set(i, 1, row.At(2, 0))
+			want.Set(i, 2, row.At(3, 0))
+			continue
+		}
+		if src == 4 {
+			src++
+		}
+		want.Set(i, 0, col.At(src, 0))
+		want.Set(i, 1, a.At(src, 1))
+		want.Set(i, 2, a.At(src, 2))
+		src++
+	}
+	checkQR(t, "sequence", &qr, want)
+}
@@ -0,0 +1,222 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badNoSchurVect = "mat: Schur vectors not computed"
+
+// Schur is a type for creating and using the real Schur decomposition of a
+// square matrix.
+type Schur struct {
+	n int // The size of the factorized matrix.
+
+	vectors bool // have the Schur vectors been computed
+
+	values []complex128
+	t      *Dense
+	z      *Dense
+}
+
+// Factorize computes the real Schur decomposition of the square matrix a.
+// The real Schur decomposition is a factorization of the matrix A such that
+//  A = Z * T * Z^T
+// where Z is an orthogonal matrix of Schur vectors and T is an upper
+// quasi-triangular matrix, the Schur form. T is block upper triangular with
+// 1×1 and 2×2 diagonal blocks, where each 2×2 diagonal block corresponds to
+// a complex conjugate pair of eigenvalues and has its diagonal elements equal
+// and its off-diagonal elements of opposite sign. If vectors is false, the
+// Schur vectors are not computed.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+//
+// Factorize will panic if a is not square.
+func (s *Schur) Factorize(a Matrix, vectors bool) (ok bool) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	s.values = nil
+	if r == 0 {
+		return false
+	}
+
+	var hess Hessenberg
+	hess.Factorize(a)
+	t := hess.HTo(nil)
+	compz := lapack.EVComp(lapack.None)
+	var z Dense
+	if vectors {
+		hess.QTo(&z)
+		compz = lapack.OriginalEV
+	}
+
+	wr := make([]float64, r)
+	wi := make([]float64, r)
+	work := []float64{0}
+	lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, t.mat, 0, r-1, wr, wi, z.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	unconverged := lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, t.mat, 0, r-1, wr, wi, z.mat, work, len(work))
+	putFloats(work)
+	if unconverged != 0 {
+		return false
+	}
+	// Zero the elements below the first subdiagonal
+	// that are not referenced by Hseqr.
+	for i := 2; i < r; i++ {
+		zero(t.mat.Data[i*t.mat.Stride : i*t.mat.Stride+i-1])
+	}
+
+	s.n = r
+	s.vectors = vectors
+	s.t = t
+	s.z = &z
+	s.values = make([]complex128, r)
+	for i, v := range wr {
+		s.values[i] = complex(v, wi[i])
+	}
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (s *Schur) succFact() bool {
+	return len(s.values) != 0
+}
+
+// Values extracts the eigenvalues of the factorized matrix in the order they
+// appear on the diagonal of the Schur form T. Complex conjugate pairs of
+// eigenvalues appear consecutively with the eigenvalue having the positive
+// imaginary part first. If dst is non-nil, the values are stored in-place
+// into dst. In this case dst must have length n, otherwise Values will panic.
+// If dst is nil, then a new slice will be allocated of the proper length and
+// filled with the eigenvalues.
+//
+// Values panics if the Schur decomposition was not successful.
+func (s *Schur) Values(dst []complex128) []complex128 {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, s.n)
+	}
+	if len(dst) != s.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, s.values)
+	return dst
+}
+
+// TTo extracts the n×n upper quasi-triangular Schur form T from a Schur
+// decomposition. If dst is nil, a new matrix is allocated. The resulting T
+// matrix is returned.
+//
+// TTo panics if the Schur decomposition was not successful.
+func (s *Schur) TTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = NewDense(s.n, s.n, nil)
+	} else {
+		dst.reuseAs(s.n, s.n)
+	}
+	dst.Copy(s.t)
+	return dst
+}
+
+// ZTo extracts the n×n orthogonal matrix of Schur vectors Z from a Schur
+// decomposition. If dst is nil, a new matrix is allocated. The resulting Z
+// matrix is returned.
+//
+// ZTo panics if the Schur decomposition was not successful or if the Schur
+// vectors were not computed.
+func (s *Schur) ZTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if !s.vectors {
+		panic(badNoSchurVect)
+	}
+	if dst == nil {
+		dst = NewDense(s.n, s.n, nil)
+	} else {
+		dst.reuseAs(s.n, s.n)
+	}
+	dst.Copy(s.z)
+	return dst
+}
+
+// Reorder reorders the Schur decomposition so that the eigenvalues for which
+// sel returns true appear in the leading diagonal blocks of T, preserving the
+// relative order of the selected and of the remaining eigenvalues otherwise.
+// The leading columns of Z then form an orthonormal basis of the invariant
+// subspace corresponding to the selected eigenvalues. A complex conjugate
+// pair of eigenvalues is moved as a whole if sel returns true for either of
+// its members.
+//
+// Reorder returns whether the reordering succeeded. Reordering fails if two
+// adjacent blocks are too close to swap because the problem is very
+// ill-conditioned, in which case T and Z may have been partially reordered,
+// but remain a valid Schur decomposition.
+//
+// Reorder panics if the Schur decomposition was not successful.
+func (s *Schur) Reorder(sel func(complex128) bool) (ok bool) {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	compq := lapack.EVComp(lapack.None)
+	if s.vectors {
+		compq = lapack.UpdateSchur
+	}
+	work := getFloats(s.n, false)
+	defer putFloats(work)
+	defer s.updateValues()
+
+	var ks int // Position of the next selected block.
+	for k := 0; k < s.n; k++ {
+		pair := k < s.n-1 && s.t.at(k+1, k) != 0
+		selected := sel(s.values[k]) || (pair && sel(s.values[k+1]))
+		if selected {
+			if k != ks {
+				_, _, ok = lapack64.Trexc(compq, s.t.mat, s.z.mat, k, ks, work)
+				if !ok {
+					return false
+				}
+			}
+			ks++
+			if pair {
+				ks++
+			}
+		}
+		if pair {
+			k++
+		}
+	}
+	return true
+}
+
+// updateValues recomputes the eigenvalues from the diagonal blocks of the
+// Schur form.
+func (s *Schur) updateValues() {
+	t := s.t
+	for i := 0; i < s.n; i++ {
+		if i == s.n-1 || t.at(i+1, i) == 0 {
+			s.values[i] = complex(t.at(i, i), 0)
+			continue
+		}
+		// The 2×2 block is in standard form, so its
+		// eigenvalues are a ± i*sqrt(-b*c).
+		im := math.Sqrt(math.Abs(t.at(i, i+1))) * math.Sqrt(math.Abs(t.at(i+1, i)))
+		s.values[i] = complex(t.at(i, i), im)
+		s.values[i+1] = complex(t.at(i+1, i+1), -im)
+		i++
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+func TestSchur(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := NewDense(n, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+
+		var s Schur
+		ok := s.Factorize(a, true)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		checkSchur(t, n, "", a, &s)
+
+		var eig Eigen
+		eig.Factorize(a, false, false)
+		want := eig.Values(nil)
+		for _, v := range s.Values(nil) {
+			if !containsValue(want, v, 1e-10) {
+				t.Errorf("n=%d: unexpected eigenvalue %v", n, v)
+			}
+		}
+
+		// Move the eigenvalues with positive real part to the top.
+		sel := func(v complex128) bool { return real(v) > 0 }
+		ok = s.Reorder(sel)
+		if !ok {
+			t.Errorf("n=%d: unexpected reordering failure", n)
+			continue
+		}
+		checkSchur(t, n, " after reordering", a, &s)
+		values := s.Values(nil)
+		var seen bool
+		for i, v := range values {
+			if !containsValue(want, v, 1e-10) {
+				t.Errorf("n=%d: unexpected eigenvalue %v after reordering", n, v)
+			}
+			if !sel(v) {
+				seen = true
+			} else if seen {
+				t.Errorf("n=%d: selected eigenvalue %v at position %d not leading", n, v, i)
+			}
+		}
+
+		// Eigenvalues only.
+		var s2 Schur
+		s2.Factorize(a, false)
+		for _, v := range s2.Values(nil) {
+			if !containsValue(want, v, 1e-10) {
+				t.Errorf("n=%d: unexpected eigenvalue %v without Schur vectors", n, v)
+			}
+		}
+		if panicked, _ := panics(func() { s2.ZTo(nil) }); !panicked {
+			t.Errorf("n=%d: expected panic for Schur vectors not computed", n)
+		}
+	}
+}
+
+func checkSchur(t *testing.T, n int, when string, a *Dense, s *Schur) {
+	tm := s.TTo(nil)
+	z := s.ZTo(nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if tm.At(i, j) != 0 {
+				t.Errorf("n=%d: T not quasi-triangular at (%d,%d)%s", n, i, j, when)
+			}
+		}
+		if i > 0 && i < n-1 && tm.At(i, i-1) != 0 && tm.At(i+1, i) != 0 {
+			t.Errorf("n=%d: adjacent 2×2 blocks overlap at %d%s", n, i, when)
+		}
+	}
+	var ztz Dense
+	ztz.Mul(z.T(), z)
+	if !EqualApprox(&ztz, eye(n), 1e-12) {
+		t.Errorf("n=%d: Z not orthogonal%s", n, when)
+	}
+	var zt, ztzt Dense
+	zt.Mul(z, tm)
+	ztzt.Mul(&zt, z.T())
+	if !EqualApprox(&ztzt, a, 1e-10) {
+		t.Errorf("n=%d: A != Z*T*Z^T%s", n, when)
+	}
+}
+
+// containsValue returns whether v is within tol of an element of values.
+func containsValue(values []complex128, v complex128, tol float64) bool {
+	for _, w := range values {
+		if cmplx.Abs(w-v) <= tol*(1+cmplx.Abs(w)) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,8 +5,11 @@
 package mat
 
 import (
+	"math"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
@@ -30,6 +33,14 @@ func (m *Dense) Solve(a, b Matrix) error {
 	aU, aTrans := untranspose(a)
 	bU, bTrans := untranspose(b)
 	switch rma := aU.(type) {
+	case *DiagDense:
+		return rma.solve(m, b)
+	case *Tridiag:
+		return rma.Solve(m, aTrans, b)
+	case *SymBandDense:
+		if rma.mat.K <= 1 {
+			return m.solveSymTridiag(rma, b)
+		}
 	case RawTriangular:
 		side := blas.Left
 		tA := blas.NoTrans
@@ -103,6 +114,74 @@ func (m *Dense) Solve(a, b Matrix) error {
 	}
 }
 
+// SolveRefined solves the system of linear equations
+//  A * X = B    if trans == false
+//  A^T * X = B  if trans == true
+// for the square matrix A using its LU decomposition. A is equilibrated
+// before factorization if it is badly scaled, and the solution is improved by
+// iterative refinement. The solution matrix, X, is stored in-place into the
+// receiver.
+//
+// For each column of X, ferr holds an estimated bound on the relative error
+//  max_i |X_ij - Xtrue_ij| / max_i |X_ij|
+// and berr holds the componentwise relative backward error, the smallest
+// relative change in any element of A or B that makes the column an exact
+// solution.
+//
+// If A is singular or near-singular, a Condition error is returned. If A is
+// exactly singular, the receiver is not modified and ferr and berr are nil.
+func (m *Dense) SolveRefined(a Matrix, trans bool, b Matrix) (ferr, berr []float64, err error) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	// A and B are modified by equilibration, and they or the receiver may
+	// share data, so the solution is computed using copies.
+	aCopy := getWorkspace(n, n, false)
+	defer putWorkspace(aCopy)
+	aCopy.Copy(a)
+	bCopy := getWorkspace(n, bc, false)
+	defer putWorkspace(bCopy)
+	bCopy.Copy(b)
+	af := getWorkspace(n, n, false)
+	defer putWorkspace(af)
+	x := getWorkspace(n, bc, false)
+	defer putWorkspace(x)
+
+	ipiv := getInts(n, false)
+	defer putInts(ipiv)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+	r := getFloats(n, false)
+	defer putFloats(r)
+	cs := getFloats(n, false)
+	defer putFloats(cs)
+	work := getFloats(4*n, false)
+	defer putFloats(work)
+
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	ferr = make([]float64, bc)
+	berr = make([]float64, bc)
+	_, rcond, _, ok := lapack64.Gesvx(lapack.FactorEquilibrate, t, aCopy.mat, af.mat, ipiv, lapack.NoEquilibration, r, cs, bCopy.mat, x.mat, ferr, berr, work, iwork)
+	if !ok {
+		return nil, nil, Condition(math.Inf(1))
+	}
+	m.reuseAs(n, bc)
+	m.Copy(x)
+	if 1/rcond > ConditionTolerance {
+		return ferr, berr, Condition(1 / rcond)
+	}
+	return ferr, berr, nil
+}
+
 // SolveVec finds a minimum-norm solution to a system of linear equations defined
 // by the matrix a and the right-hand side vector b. If A is singular or
 // near-singular, a Condition error is returned. Please see the documentation for
@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -294,3 +295,85 @@ func TestSolveVec(t *testing.T) {
 	}
 	testTwoInput(t, "SolveVec", &VecDense{}, method, denseComparison, legalTypesNotVecVec, legalSizeSolve, 1e-12)
 }
+
+func TestSolveRefined(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 50} {
+		for _, bc := range []int{1, 3} {
+			for _, trans := range []bool{false, true} {
+				for _, scaled := range []bool{false, true} {
+					a := NewDense(n, n, nil)
+					for i := 0; i < n; i++ {
+						scale := 1.0
+						if scaled {
+							scale = math.Pow(10, float64(rnd.Intn(17)-8))
+						}
+						for j := 0; j < n; j++ {
+							a.Set(i, j, scale*rnd.NormFloat64())
+						}
+					}
+					xTrue := NewDense(n, bc, nil)
+					for i := 0; i < n; i++ {
+						for j := 0; j < bc; j++ {
+							xTrue.Set(i, j, rnd.NormFloat64())
+						}
+					}
+					var b Dense
+					if trans {
+						b.Mul(a.T(), xTrue)
+					} else {
+						b.Mul(a, xTrue)
+					}
+					bCopy := DenseCopyOf(&b)
+					aCopy := DenseCopyOf(a)
+
+					var x Dense
+					ferr, berr, err := x.SolveRefined(a, trans, &b)
+					if err != nil {
+						t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: unexpected error: %v", n, bc, trans, scaled, err)
+						continue
+					}
+					if !Equal(a, aCopy) || !Equal(&b, bCopy) {
+						t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: input modified", n, bc, trans, scaled)
+					}
+					if len(ferr) != bc || len(berr) != bc {
+						t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: unexpected error bound lengths", n, bc, trans, scaled)
+						continue
+					}
+					for j := 0; j < bc; j++ {
+						if berr[j] > 1e-14 {
+							t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: large backward error for column %d: %v", n, bc, trans, scaled, j, berr[j])
+						}
+						var diff, xnrm float64
+						for i := 0; i < n; i++ {
+							diff = math.Max(diff, math.Abs(x.At(i, j)-xTrue.At(i, j)))
+							xnrm = math.Max(xnrm, math.Abs(x.At(i, j)))
+						}
+						if diff/xnrm > ferr[j] {
+							t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: forward error %v larger than bound %v for column %d", n, bc, trans, scaled, diff/xnrm, ferr[j], j)
+						}
+					}
+
+					// Check that solving in place gives the same result.
+					_, _, err = b.SolveRefined(a, trans, &b)
+					if err != nil {
+						t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: unexpected error solving in place: %v", n, bc, trans, scaled, err)
+					}
+					if !Equal(&b, &x) {
+						t.Errorf("n=%d,bc=%d,trans=%t,scaled=%t: mismatch solving in place", n, bc, trans, scaled)
+					}
+				}
+			}
+		}
+	}
+
+	// Check that a singular matrix is detected.
+	var x Dense
+	_, _, err := x.SolveRefined(NewDense(2, 2, []float64{1, 2, 2, 4}), false, NewDense(2, 1, []float64{1, 1}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular matrix: %v", err)
+	}
+	if !x.IsZero() {
+		t.Errorf("unexpected modification of receiver for singular matrix")
+	}
+}
@@ -0,0 +1,483 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"sort"
+)
+
+var (
+	csr *CSR
+	_   Matrix         = csr
+	_   NonZeroDoer    = csr
+	_   RowNonZeroDoer = csr
+	_   ColNonZeroDoer = csr
+
+	csc *CSC
+	_   Matrix         = csc
+	_   NonZeroDoer    = csc
+	_   RowNonZeroDoer = csc
+	_   ColNonZeroDoer = csc
+
+	coo *COO
+	_   Matrix         = coo
+	_   NonZeroDoer    = coo
+	_   RowNonZeroDoer = coo
+	_   ColNonZeroDoer = coo
+)
+
+// sparse is a matrix stored in a sparse format. Dense.Mul and VecDense.MulVec
+// use the DoNonZero method of a sparse matrix to avoid element access through At.
+type sparse interface {
+	Matrix
+	NonZeroDoer
+
+	// NNZ returns the number of stored elements.
+	NNZ() int
+}
+
+// compressed is the storage shared by the CSR and CSC formats. The major
+// dimension is the dimension that is compressed, rows for CSR and columns
+// for CSC. The minor indices of the elements in major index p are held in
+// ind[indptr[p]:indptr[p+1]] in strictly increasing order, with the
+// corresponding values in data[indptr[p]:indptr[p+1]].
+type compressed struct {
+	major, minor int
+	indptr       []int
+	ind          []int
+	data         []float64
+}
+
+// newCompressed returns a compressed storage after checking that the provided
+// index slices are valid.
+func newCompressed(major, minor int, indptr, ind []int, data []float64) compressed {
+	if major < 0 || minor < 0 {
+		panic("mat: negative dimension")
+	}
+	if len(indptr) != major+1 || len(ind) != len(data) {
+		panic(ErrShape)
+	}
+	if indptr[0] != 0 || indptr[major] != len(ind) {
+		panic("mat: invalid compressed index pointer")
+	}
+	for p := 0; p < major; p++ {
+		if indptr[p] > indptr[p+1] {
+			panic("mat: invalid compressed index pointer")
+		}
+		for k := indptr[p]; k < indptr[p+1]; k++ {
+			if ind[k] < 0 || minor <= ind[k] {
+				panic(ErrIndexOutOfRange)
+			}
+			if k > indptr[p] && ind[k] <= ind[k-1] {
+				panic("mat: unsorted or duplicate compressed index")
+			}
+		}
+	}
+	return compressed{
+		major:  major,
+		minor:  minor,
+		indptr: indptr,
+		ind:    ind,
+		data:   data,
+	}
+}
+
+// compress returns a compressed storage holding the elements described by
+// the major and minor index slices and data. Duplicate elements are summed.
+func compress(major, minor int, majIdx, minIdx []int, data []float64) compressed {
+	indptr := make([]int, major+1)
+	for _, p := range majIdx {
+		indptr[p+1]++
+	}
+	for p := 0; p < major; p++ {
+		indptr[p+1] += indptr[p]
+	}
+	next := make([]int, major)
+	copy(next, indptr)
+	ind := make([]int, len(data))
+	val := make([]float64, len(data))
+	for k, p := range majIdx {
+		ind[next[p]] = minIdx[k]
+		val[next[p]] = data[k]
+		next[p]++
+	}
+
+	// Sort each major slice by minor index and merge duplicates
+	// in place, compacting the storage as we go.
+	var n int
+	for p := 0; p < major; p++ {
+		start, end := indptr[p], indptr[p+1]
+		sort.Sort(byIndex{ind: ind[start:end], data: val[start:end]})
+		indptr[p] = n
+		for k := start; k < end; k++ {
+			if n > indptr[p] && ind[n-1] == ind[k] {
+				val[n-1] += val[k]
+				continue
+			}
+			ind[n] = ind[k]
+			val[n] = val[k]
+			n++
+		}
+	}
+	indptr[major] = n
+	return compressed{
+		major:  major,
+		minor:  minor,
+		indptr: indptr,
+		ind:    ind[:n:n],
+		data:   val[:n:n],
+	}
+}
+
+// byIndex sorts a set of minor indices and their associated values.
+type byIndex struct {
+	ind  []int
+	data []float64
+}
+
+func (b byIndex) Len() int           { return len(b.ind) }
+func (b byIndex) Less(i, j int) bool { return b.ind[i] < b.ind[j] }
+func (b byIndex) Swap(i, j int) {
+	b.ind[i], b.ind[j] = b.ind[j], b.ind[i]
+	b.data[i], b.data[j] = b.data[j], b.data[i]
+}
+
+// at returns the element at major index p and minor index q.
+func (c *compressed) at(p, q int) float64 {
+	start, end := c.indptr[p], c.indptr[p+1]
+	k := start + sort.SearchInts(c.ind[start:end], q)
+	if k < end && c.ind[k] == q {
+		return c.data[k]
+	}
+	return 0
+}
+
+// doNonZero calls fn for each non-zero element with the major and
+// minor indices of the element.
+func (c *compressed) doNonZero(fn func(p, q int, v float64)) {
+	for p := 0; p < c.major; p++ {
+		c.doMajorNonZero(p, fn)
+	}
+}
+
+// doMajorNonZero calls fn for each non-zero element in major index p.
+func (c *compressed) doMajorNonZero(p int, fn func(p, q int, v float64)) {
+	for k := c.indptr[p]; k < c.indptr[p+1]; k++ {
+		if v := c.data[k]; v != 0 {
+			fn(p, c.ind[k], v)
+		}
+	}
+}
+
+// doMinorNonZero calls fn for each non-zero element in minor index q.
+func (c *compressed) doMinorNonZero(q int, fn func(p, q int, v float64)) {
+	for p := 0; p < c.major; p++ {
+		if v := c.at(p, q); v != 0 {
+			fn(p, q, v)
+		}
+	}
+}
+
+// transpose returns the compressed storage of the transpose of the
+// receiver, swapping the major and minor dimensions.
+func (c *compressed) transpose() compressed {
+	majIdx := make([]int, 0, len(c.ind))
+	for p := 0; p < c.major; p++ {
+		for k := c.indptr[p]; k < c.indptr[p+1]; k++ {
+			majIdx = append(majIdx, p)
+		}
+	}
+	return compress(c.minor, c.major, c.ind, majIdx, c.data)
+}
+
+// CSR represents a sparse matrix in compressed sparse row format.
+type CSR struct {
+	mat compressed
+}
+
+// NewCSR creates a new compressed sparse row matrix with r rows and c columns.
+// The column indices of the elements in row i are held in
+// ind[indptr[i]:indptr[i+1]] and the element values in
+// data[indptr[i]:indptr[i+1]]. The column indices within each row must
+// be strictly increasing. The slices are used as the backing data of the
+// returned CSR and changes to the elements of data will be reflected in the
+// matrix. NewCSR will panic if the index slices do not describe a valid
+// r×c matrix.
+//
+// For example, the matrix
+//    1  0  2
+//    0  0  3
+//    4  5  0
+// is represented by indptr = []int{0, 2, 3, 5}, ind = []int{0, 2, 2, 0, 1}
+// and data = []float64{1, 2, 3, 4, 5}.
+func NewCSR(r, c int, indptr, ind []int, data []float64) *CSR {
+	return &CSR{mat: newCompressed(r, c, indptr, ind, data)}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSR) Dims() (r, c int) {
+	return m.mat.major, m.mat.minor
+}
+
+// At returns the element at row i, column j.
+func (m *CSR) At(i, j int) float64 {
+	if uint(i) >= uint(m.mat.major) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.minor) {
+		panic(ErrColAccess)
+	}
+	return m.mat.at(i, j)
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSR) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix. This may include
+// explicitly stored zeros.
+func (m *CSR) NNZ() int {
+	return len(m.mat.data)
+}
+
+// ToCSC returns a copy of the receiver in compressed sparse column format.
+func (m *CSR) ToCSC() *CSC {
+	return &CSC{mat: m.mat.transpose()}
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSR) DoNonZero(fn func(i, j int, v float64)) {
+	m.mat.doNonZero(fn)
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSR) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || m.mat.major <= i {
+		panic(ErrRowAccess)
+	}
+	m.mat.doMajorNonZero(i, fn)
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSR) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || m.mat.minor <= j {
+		panic(ErrColAccess)
+	}
+	m.mat.doMinorNonZero(j, fn)
+}
+
+// CSC represents a sparse matrix in compressed sparse column format.
+type CSC struct {
+	mat compressed
+}
+
+// NewCSC creates a new compressed sparse column matrix with r rows and c columns.
+// The row indices of the elements in column j are held in
+// ind[indptr[j]:indptr[j+1]] and the element values in
+// data[indptr[j]:indptr[j+1]]. The row indices within each column must
+// be strictly increasing. The slices are used as the backing data of the
+// returned CSC and changes to the elements of data will be reflected in the
+// matrix. NewCSC will panic if the index slices do not describe a valid
+// r×c matrix.
+//
+// For example, the matrix
+//    1  0  2
+//    0  0  3
+//    4  5  0
+// is represented by indptr = []int{0, 2, 3, 5}, ind = []int{0, 2, 2, 0, 1}
+// and data = []float64{1, 4, 5, 2, 3}.
+func NewCSC(r, c int, indptr, ind []int, data []float64) *CSC {
+	return &CSC{mat: newCompressed(c, r, indptr, ind, data)}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSC) Dims() (r, c int) {
+	return m.mat.minor, m.mat.major
+}
+
+// At returns the element at row i, column j.
+func (m *CSC) At(i, j int) float64 {
+	if uint(i) >= uint(m.mat.minor) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.major) {
+		panic(ErrColAccess)
+	}
+	return m.mat.at(j, i)
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSC) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix. This may include
+// explicitly stored zeros.
+func (m *CSC) NNZ() int {
+	return len(m.mat.data)
+}
+
+// ToCSR returns a copy of the receiver in compressed sparse row format.
+func (m *CSC) ToCSR() *CSR {
+	return &CSR{mat: m.mat.transpose()}
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSC) DoNonZero(fn func(i, j int, v float64)) {
+	m.mat.doNonZero(func(j, i int, v float64) { fn(i, j, v) })
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSC) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || m.mat.minor <= i {
+		panic(ErrRowAccess)
+	}
+	m.mat.doMinorNonZero(i, func(j, i int, v float64) { fn(i, j, v) })
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSC) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || m.mat.major <= j {
+		panic(ErrColAccess)
+	}
+	m.mat.doMajorNonZero(j, func(j, i int, v float64) { fn(i, j, v) })
+}
+
+// COO represents a sparse matrix in coordinate format. A COO is intended
+// for the incremental construction of sparse matrices which are then
+// converted to CSR or CSC format for computation. Elements may be
+// duplicated in a COO, in which case the value of the element is the sum
+// of the duplicates.
+type COO struct {
+	r, c int
+	rows []int
+	cols []int
+	data []float64
+}
+
+// NewCOO creates a new coordinate format matrix with r rows and c columns.
+// The kth element is at row rows[k] and column cols[k] and has the value data[k].
+// If all of rows, cols and data are nil, an empty matrix is returned,
+// otherwise the slices must have the same length. The slices are used as the
+// backing data of the returned COO.
+func NewCOO(r, c int, rows, cols []int, data []float64) *COO {
+	if r < 0 || c < 0 {
+		panic("mat: negative dimension")
+	}
+	if len(rows) != len(data) || len(cols) != len(data) {
+		panic(ErrShape)
+	}
+	for k := range data {
+		if rows[k] < 0 || r <= rows[k] || cols[k] < 0 || c <= cols[k] {
+			panic(ErrIndexOutOfRange)
+		}
+	}
+	return &COO{r: r, c: c, rows: rows, cols: cols, data: data}
+}
+
+// Append adds the value v to the element at row i, column j.
+func (m *COO) Append(i, j int, v float64) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	m.rows = append(m.rows, i)
+	m.cols = append(m.cols, j)
+	m.data = append(m.data, v)
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *COO) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// At returns the element at row i, column j. At has a cost proportional to
+// the number of stored elements.
+func (m *COO) At(i, j int) float64 {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	var v float64
+	for k, r := range m.rows {
+		if r == i && m.cols[k] == j {
+			v += m.data[k]
+		}
+	}
+	return v
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *COO) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix. This may include
+// explicitly stored zeros and duplicate elements.
+func (m *COO) NNZ() int {
+	return len(m.data)
+}
+
+// ToCSR returns a copy of the receiver in compressed sparse row format.
+// Duplicate elements are summed.
+func (m *COO) ToCSR() *CSR {
+	return &CSR{mat: compress(m.r, m.c, m.rows, m.cols, m.data)}
+}
+
+// ToCSC returns a copy of the receiver in compressed sparse column format.
+// Duplicate elements are summed.
+func (m *COO) ToCSC() *CSC {
+	return &CSC{mat: compress(m.c, m.r, m.cols, m.rows, m.data)}
+}
+
+// DoNonZero calls the function fn for each of the non-zero stored elements
+// of m. The function fn takes a row/column index and the element value of m
+// at (i, j). Duplicate elements are passed to fn individually.
+func (m *COO) DoNonZero(fn func(i, j int, v float64)) {
+	for k, v := range m.data {
+		if v != 0 {
+			fn(m.rows[k], m.cols[k], v)
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero stored elements
+// of row i of m. The function fn takes a row/column index and the element
+// value of m at (i, j). Duplicate elements are passed to fn individually.
+func (m *COO) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || m.r <= i {
+		panic(ErrRowAccess)
+	}
+	for k, v := range m.data {
+		if m.rows[k] == i && v != 0 {
+			fn(i, m.cols[k], v)
+		}
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero stored elements
+// of column j of m. The function fn takes a row/column index and the element
+// value of m at (i, j). Duplicate elements are passed to fn individually.
+func (m *COO) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || m.c <= j {
+		panic(ErrColAccess)
+	}
+	for k, v := range m.data {
+		if m.cols[k] == j && v != 0 {
+			fn(m.rows[k], j, v)
+		}
+	}
+}
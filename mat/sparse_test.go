@@ -0,0 +1,227 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randCOO returns a random r×c COO with approximately a fraction
+// rho of non-zero elements, including some duplicated elements.
+func randCOO(r, c int, rho float64, rnd *rand.Rand) *COO {
+	m := NewCOO(r, c, nil, nil, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if rnd.Float64() < rho {
+				m.Append(i, j, rnd.NormFloat64())
+				if rnd.Float64() < 0.1 {
+					m.Append(i, j, rnd.NormFloat64())
+				}
+			}
+		}
+	}
+	return m
+}
+
+func TestNewCSR(t *testing.T) {
+	want := NewDense(3, 3, []float64{
+		1, 0, 2,
+		0, 0, 3,
+		4, 5, 0,
+	})
+	m := NewCSR(3, 3, []int{0, 2, 3, 5}, []int{0, 2, 2, 0, 1}, []float64{1, 2, 3, 4, 5})
+	if !Equal(m, want) {
+		t.Errorf("unexpected CSR matrix:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	if m.NNZ() != 5 {
+		t.Errorf("unexpected number of stored elements: got:%d want:5", m.NNZ())
+	}
+	c := NewCSC(3, 3, []int{0, 2, 3, 5}, []int{0, 2, 2, 0, 1}, []float64{1, 4, 5, 2, 3})
+	if !Equal(c, want) {
+		t.Errorf("unexpected CSC matrix:\ngot:\n%v\nwant:\n%v", Formatted(c), Formatted(want))
+	}
+
+	for _, test := range []struct {
+		name   string
+		indptr []int
+		ind    []int
+		data   []float64
+	}{
+		{name: "short indptr", indptr: []int{0, 2, 3}, ind: []int{0, 2, 2}, data: []float64{1, 2, 3}},
+		{name: "data length", indptr: []int{0, 1, 1, 1}, ind: []int{0}, data: []float64{1, 2}},
+		{name: "decreasing indptr", indptr: []int{0, 2, 1, 2}, ind: []int{0, 1}, data: []float64{1, 2}},
+		{name: "index range", indptr: []int{0, 1, 1, 1}, ind: []int{3}, data: []float64{1}},
+		{name: "unsorted", indptr: []int{0, 2, 2, 2}, ind: []int{1, 0}, data: []float64{1, 2}},
+		{name: "duplicate", indptr: []int{0, 2, 2, 2}, ind: []int{1, 1}, data: []float64{1, 2}},
+	} {
+		if panicked, _ := panics(func() { NewCSR(3, 3, test.indptr, test.ind, test.data) }); !panicked {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func TestSparseConversion(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c int
+		rho  float64
+	}{
+		{1, 1, 1},
+		{3, 5, 0.3},
+		{10, 4, 0.5},
+		{20, 20, 0.1},
+		{7, 9, 0},
+	} {
+		coo := randCOO(test.r, test.c, test.rho, rnd)
+		want := NewDense(test.r, test.c, nil)
+		want.Copy(coo)
+
+		csr := coo.ToCSR()
+		csc := coo.ToCSC()
+		for _, m := range []Matrix{coo, csr, csc, csr.ToCSC(), csc.ToCSR()} {
+			if !EqualApprox(m, want, 1e-14) {
+				t.Errorf("unexpected %T for %d×%d:\ngot:\n%v\nwant:\n%v",
+					m, test.r, test.c, Formatted(m), Formatted(want))
+			}
+		}
+		if csr.NNZ() > coo.NNZ() {
+			t.Errorf("duplicates not merged: CSR has %d elements, COO has %d", csr.NNZ(), coo.NNZ())
+		}
+
+		for _, m := range []interface {
+			Matrix
+			NonZeroDoer
+			RowNonZeroDoer
+			ColNonZeroDoer
+		}{csr, csc} {
+			got := NewDense(test.r, test.c, nil)
+			m.DoNonZero(func(i, j int, v float64) { got.Set(i, j, v) })
+			if !Equal(got, m) {
+				t.Errorf("unexpected DoNonZero result for %T", m)
+			}
+			got = NewDense(test.r, test.c, nil)
+			for i := 0; i < test.r; i++ {
+				m.DoRowNonZero(i, func(r, j int, v float64) {
+					if r != i {
+						t.Errorf("unexpected row in DoRowNonZero for %T: got:%d want:%d", m, r, i)
+					}
+					got.Set(r, j, v)
+				})
+			}
+			if !Equal(got, m) {
+				t.Errorf("unexpected DoRowNonZero result for %T", m)
+			}
+			got = NewDense(test.r, test.c, nil)
+			for j := 0; j < test.c; j++ {
+				m.DoColNonZero(j, func(i, c int, v float64) {
+					if c != j {
+						t.Errorf("unexpected column in DoColNonZero for %T: got:%d want:%d", m, c, j)
+					}
+					got.Set(i, c, v)
+				})
+			}
+			if !Equal(got, m) {
+				t.Errorf("unexpected DoColNonZero result for %T", m)
+			}
+		}
+	}
+}
+
+func TestSparseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ac, bc int
+	}{
+		{1, 1, 1},
+		{3, 4, 5},
+		{10, 3, 7},
+		{6, 12, 2},
+		{15, 15, 15},
+	} {
+		coo := randCOO(test.ar, test.ac, 0.3, rnd)
+		sparseA := []Matrix{coo, coo.ToCSR(), coo.ToCSC()}
+		denseA := NewDense(test.ar, test.ac, nil)
+		denseA.Copy(coo)
+
+		b := NewDense(test.ac, test.bc, nil)
+		for i := 0; i < test.ac; i++ {
+			for j := 0; j < test.bc; j++ {
+				b.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		bT := NewDense(test.bc, test.ac, nil)
+		bT.Copy(b.T())
+
+		var want Dense
+		want.Mul(denseA, b)
+		for _, a := range sparseA {
+			for _, bm := range []Matrix{b, bT.T(), asBasicMatrix(b)} {
+				var got Dense
+				got.Mul(a, bm)
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("unexpected result for %T×%T %d×%d×%d", a, bm, test.ar, test.ac, test.bc)
+				}
+			}
+		}
+
+		// Sparse on the right.
+		c := NewDense(test.bc, test.ar, nil)
+		for i := 0; i < test.bc; i++ {
+			for j := 0; j < test.ar; j++ {
+				c.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		cT := NewDense(test.ar, test.bc, nil)
+		cT.Copy(c.T())
+		want.Reset()
+		want.Mul(c, denseA)
+		for _, a := range sparseA {
+			for _, cm := range []Matrix{c, cT.T(), asBasicMatrix(c)} {
+				var got Dense
+				got.Mul(cm, a)
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("unexpected result for %T×%T %d×%d×%d", cm, a, test.bc, test.ar, test.ac)
+				}
+			}
+		}
+
+		// Transposed sparse.
+		want.Reset()
+		want.Mul(denseA.T(), cT)
+		for _, a := range sparseA {
+			var got Dense
+			got.Mul(a.T(), cT)
+			if !EqualApprox(&got, &want, 1e-12) {
+				t.Errorf("unexpected result for %T^T×Dense", a)
+			}
+		}
+
+		// Matrix-vector products.
+		x := NewVecDense(test.ac, nil)
+		for i := 0; i < test.ac; i++ {
+			x.SetVec(i, rnd.NormFloat64())
+		}
+		y := NewVecDense(test.ar, nil)
+		for i := 0; i < test.ar; i++ {
+			y.SetVec(i, rnd.NormFloat64())
+		}
+		var wantVec, wantVecT VecDense
+		wantVec.MulVec(denseA, x)
+		wantVecT.MulVec(denseA.T(), y)
+		for _, a := range sparseA {
+			var got VecDense
+			got.MulVec(a, x)
+			if !EqualApprox(&got, &wantVec, 1e-12) {
+				t.Errorf("unexpected MulVec result for %T", a)
+			}
+			var gotT VecDense
+			gotT.MulVec(a.T(), y)
+			if !EqualApprox(&gotT, &wantVecT, 1e-12) {
+				t.Errorf("unexpected MulVec result for %T^T", a)
+			}
+		}
+	}
+}
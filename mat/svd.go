@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
@@ -14,6 +15,7 @@ import (
 // of a matrix.
 type SVD struct {
 	kind SVDKind
+	r, c int // The dimensions of the factorized matrix.
 
 	s  []float64
 	u  blas64.General
@@ -40,13 +42,61 @@ type SVD struct {
 // failed, routines that require a successful factorization will panic.
 func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	m, n := a.Dims()
-	var jobU, jobVT lapack.SVDJob
+	job := svd.reset(m, n, kind)
+
+	// A is destroyed on call, so copy the matrix.
+	aCopy := DenseCopyOf(a)
+
+	work := []float64{0}
+	lapack64.Gesvd(job, job, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Gesvd(job, job, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
+	putFloats(work)
+	if !ok {
+		svd.kind = 0
+	}
+	return ok
+}
+
+// FactorizeDC computes the singular value decomposition (SVD) of the input
+// matrix A in the same way as Factorize, but using a divide and conquer
+// algorithm. FactorizeDC is significantly faster than Factorize for large
+// matrices when singular vectors are computed, at the cost of additional
+// working memory. The decomposition is retrieved with the same methods as
+// for Factorize.
+//
+// FactorizeDC returns whether the decomposition succeeded. If the decomposition
+// failed, routines that require a successful factorization will panic.
+func (svd *SVD) FactorizeDC(a Matrix, kind SVDKind) (ok bool) {
+	m, n := a.Dims()
+	job := svd.reset(m, n, kind)
+
+	// A is destroyed on call, so copy the matrix.
+	aCopy := DenseCopyOf(a)
+
+	work := []float64{0}
+	iwork := getInts(8*min(m, n), false)
+	lapack64.Gesdd(job, aCopy.mat, svd.u, svd.vt, svd.s, work, -1, iwork)
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Gesdd(job, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work), iwork)
+	putFloats(work)
+	putInts(iwork)
+	if !ok {
+		svd.kind = 0
+	}
+	return ok
+}
+
+// reset prepares the receiver for the decomposition of an m×n matrix with
+// the given kind, allocating the storage for the singular values and vectors,
+// and returns the corresponding LAPACK job for both sets of singular vectors.
+func (svd *SVD) reset(m, n int, kind SVDKind) lapack.SVDJob {
+	var job lapack.SVDJob
 	switch kind {
 	default:
 		panic("svd: bad input kind")
 	case SVDNone:
-		jobU = lapack.SVDNone
-		jobVT = lapack.SVDNone
+		job = lapack.SVDNone
 	case SVDFull:
 		// TODO(btracey): This code should be modified to have the smaller
 		// matrix written in-place into aCopy when the lapack/native/dgesvd
@@ -63,8 +113,7 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 			Stride: n,
 			Data:   use(svd.vt.Data, n*n),
 		}
-		jobU = lapack.SVDAll
-		jobVT = lapack.SVDAll
+		job = lapack.SVDAll
 	case SVDThin:
 		// TODO(btracey): This code should be modified to have the larger
 		// matrix written in-place into aCopy when the lapack/native/dgesvd
@@ -81,24 +130,12 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 			Stride: n,
 			Data:   use(svd.vt.Data, min(m, n)*n),
 		}
-		jobU = lapack.SVDInPlace
-		jobVT = lapack.SVDInPlace
+		job = lapack.SVDInPlace
 	}
-
-	// A is destroyed on call, so copy the matrix.
-	aCopy := DenseCopyOf(a)
 	svd.kind = kind
+	svd.r, svd.c = m, n
 	svd.s = use(svd.s, min(m, n))
-
-	work := []float64{0}
-	lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
-	work = getFloats(int(work[0]), false)
-	ok = lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
-	putFloats(work)
-	if !ok {
-		svd.kind = 0
-	}
-	return ok
+	return job
 }
 
 // Kind returns the matrix.SVDKind of the decomposition. If no decomposition has been
@@ -188,3 +225,140 @@ func (svd *SVD) VTo(dst *Dense) *Dense {
 
 	return dst
 }
+
+// Rank returns the numerical rank of the factorized matrix, the number of
+// singular values greater than rcond times the largest singular value. If
+// rcond is not positive, max(m,n)*ε is used, where ε is the machine epsilon.
+//
+// Rank will panic if the receiver does not contain a successful factorization.
+func (svd *SVD) Rank(rcond float64) int {
+	if svd.kind == 0 {
+		panic("svd: no decomposition computed")
+	}
+	if rcond <= 0 {
+		// dlamchE is the machine epsilon.
+		const dlamchE = 1.0 / (1 << 53)
+		rcond = float64(max(svd.r, svd.c)) * dlamchE
+	}
+	if len(svd.s) == 0 {
+		return 0
+	}
+	tol := rcond * svd.s[0]
+	var rank int
+	for rank < len(svd.s) && svd.s[rank] > tol {
+		rank++
+	}
+	return rank
+}
+
+// PseudoInverseTo computes the Moore-Penrose pseudo-inverse of the factorized
+// m×n matrix A, storing the n×m result in-place into dst. Singular values
+// less than or equal to rcond times the largest singular value are treated
+// as zero, with rcond interpreted as described in Rank. If dst is nil, a new
+// matrix is allocated. The resulting matrix is returned.
+//
+// The pseudo-inverse is computed as
+//  A^+ = V_r * Σ_r^-1 * U_r^T
+// where U_r and V_r hold the leading rank left and right singular vectors
+// and Σ_r the corresponding singular values. PseudoInverseTo panics if
+// svd.Kind() is not SVDFull or SVDThin.
+func (svd *SVD) PseudoInverseTo(dst *Dense, rcond float64) *Dense {
+	kind := svd.kind
+	if kind != SVDFull && kind != SVDThin {
+		panic("mat: improper SVD kind")
+	}
+	m, n := svd.r, svd.c
+	if dst == nil {
+		dst = NewDense(n, m, nil)
+	} else {
+		dst.reuseAsZeroed(n, m)
+	}
+	rank := svd.Rank(rcond)
+	if rank == 0 {
+		return dst
+	}
+
+	// Form Σ_r^-1 * V_r^T.
+	w := getWorkspace(rank, n, false)
+	defer putWorkspace(w)
+	for i := 0; i < rank; i++ {
+		row := w.mat.Data[i*w.mat.Stride : i*w.mat.Stride+n]
+		copy(row, svd.vt.Data[i*svd.vt.Stride:i*svd.vt.Stride+n])
+		blas64.Scal(n, 1/svd.s[i], blas64.Vector{Inc: 1, Data: row})
+	}
+	u := svd.u
+	u.Cols = rank
+	blas64.Gemm(blas.Trans, blas.Trans, 1, w.mat, u, 0, dst.mat)
+	return dst
+}
+
+// RangeTo extracts an orthonormal basis for the range (column space) of the
+// factorized matrix, storing the m×rank result in-place into dst. The basis
+// is formed from the left singular vectors corresponding to the singular
+// values greater than rcond times the largest singular value, with rcond
+// interpreted as described in Rank. If dst is nil, a new matrix is allocated.
+// The resulting matrix is returned. If the rank of the matrix is zero, RangeTo
+// returns nil and dst is not modified.
+//
+// RangeTo panics if svd.Kind() is not SVDFull or SVDThin.
+func (svd *SVD) RangeTo(dst *Dense, rcond float64) *Dense {
+	kind := svd.kind
+	if kind != SVDFull && kind != SVDThin {
+		panic("mat: improper SVD kind")
+	}
+	rank := svd.Rank(rcond)
+	if rank == 0 {
+		return nil
+	}
+	m := svd.r
+	if dst == nil {
+		dst = NewDense(m, rank, nil)
+	} else {
+		dst.reuseAs(m, rank)
+	}
+	u := svd.u
+	u.Cols = rank
+	dst.Copy(&Dense{mat: u, capRows: u.Rows, capCols: rank})
+	return dst
+}
+
+// NullSpaceTo extracts an orthonormal basis for the null space of the
+// factorized m×n matrix A, the vectors x such that A*x = 0, storing the
+// n×(n-rank) result in-place into dst. The basis is formed from the right
+// singular vectors corresponding to the singular values less than or equal
+// to rcond times the largest singular value and to the n-min(m,n) columns
+// of V without a singular value, with rcond interpreted as described in
+// Rank. If dst is nil, a new matrix is allocated. The resulting matrix is
+// returned. If the null space is trivial, NullSpaceTo returns nil and dst is
+// not modified.
+//
+// NullSpaceTo panics if svd.Kind() is not SVDFull or SVDThin, or if
+// svd.Kind() is SVDThin and m < n, since the thin decomposition then does not
+// hold a basis for the whole null space.
+func (svd *SVD) NullSpaceTo(dst *Dense, rcond float64) *Dense {
+	kind := svd.kind
+	if kind != SVDFull && kind != SVDThin {
+		panic("mat: improper SVD kind")
+	}
+	n := svd.c
+	if kind == SVDThin && svd.vt.Rows < n {
+		panic("mat: improper SVD kind")
+	}
+	rank := svd.Rank(rcond)
+	if rank == n {
+		return nil
+	}
+	if dst == nil {
+		dst = NewDense(n, n-rank, nil)
+	} else {
+		dst.reuseAs(n, n-rank)
+	}
+	vt := blas64.General{
+		Rows:   n - rank,
+		Cols:   n,
+		Stride: svd.vt.Stride,
+		Data:   svd.vt.Data[rank*svd.vt.Stride:],
+	}
+	dst.Copy((&Dense{mat: vt, capRows: n - rank, capCols: n}).T())
+	return dst
+}
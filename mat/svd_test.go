@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 
@@ -170,3 +171,212 @@ func TestSVD(t *testing.T) {
 func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	return svd.Values(nil), svd.UTo(nil), svd.VTo(nil)
 }
+
+func TestSVDFactorizeDC(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{5, 3},
+		{3, 5},
+		{40, 40},
+		{150, 150},
+		{200, 150},
+		{150, 200},
+	} {
+		m := test.m
+		n := test.n
+		a := NewDense(m, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		aCopy := DenseCopyOf(a)
+
+		for _, kind := range []SVDKind{SVDNone, SVDThin, SVDFull} {
+			var want, got SVD
+			if !want.Factorize(a, kind) {
+				t.Errorf("m=%d,n=%d,kind=%v: Factorize failed", m, n, kind)
+				continue
+			}
+			if !got.FactorizeDC(a, kind) {
+				t.Errorf("m=%d,n=%d,kind=%v: FactorizeDC failed", m, n, kind)
+				continue
+			}
+			if !Equal(a, aCopy) {
+				t.Errorf("m=%d,n=%d,kind=%v: A changed during call to FactorizeDC", m, n, kind)
+			}
+			if got.Kind() != kind {
+				t.Errorf("m=%d,n=%d,kind=%v: unexpected kind %v", m, n, kind, got.Kind())
+			}
+			if !floats.EqualApprox(got.Values(nil), want.Values(nil), 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: singular value mismatch between FactorizeDC and Factorize", m, n, kind)
+			}
+			if kind == SVDNone {
+				continue
+			}
+
+			// The singular values are distinct, so the singular vectors
+			// agree with those computed by Factorize up to sign. The
+			// remaining columns of the full decomposition only span the
+			// same subspace.
+			sGot, uGot, vGot := extractSVD(&got)
+			_, uWant, vWant := extractSVD(&want)
+			for j := 0; j < min(m, n); j++ {
+				sign := 1.0
+				if uGot.At(0, j)*uWant.At(0, j) < 0 {
+					sign = -1
+				}
+				for i := 0; i < m; i++ {
+					if math.Abs(uGot.At(i, j)-sign*uWant.At(i, j)) > 1e-8 {
+						t.Errorf("m=%d,n=%d,kind=%v: left singular vector %d mismatch", m, n, kind, j)
+						break
+					}
+				}
+				for i := 0; i < n; i++ {
+					if math.Abs(vGot.At(i, j)-sign*vWant.At(i, j)) > 1e-8 {
+						t.Errorf("m=%d,n=%d,kind=%v: right singular vector %d mismatch", m, n, kind, j)
+						break
+					}
+				}
+			}
+
+			_, uc := uGot.Dims()
+			_, vc := vGot.Dims()
+			sigma := NewDense(uc, vc, nil)
+			for i := 0; i < min(m, n); i++ {
+				sigma.Set(i, i, sGot[i])
+			}
+			var ans Dense
+			ans.Product(uGot, sigma, vGot.T())
+			if !EqualApprox(&ans, a, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: A reconstruction mismatch", m, n, kind)
+			}
+			for _, q := range []*Dense{uGot, vGot} {
+				_, c := q.Dims()
+				var qtq Dense
+				qtq.Mul(q.T(), q)
+				if !EqualApprox(&qtq, eye(c), 1e-10) {
+					t.Errorf("m=%d,n=%d,kind=%v: singular vectors are not orthonormal", m, n, kind)
+				}
+			}
+		}
+	}
+}
+
+func TestSVDPseudoInverse(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{1, 1, 1},
+		{5, 3, 3},
+		{3, 5, 3},
+		{10, 10, 10},
+		{10, 10, 4},
+		{20, 8, 5},
+		{8, 20, 5},
+	} {
+		m, n, rank := test.m, test.n, test.rank
+		a := randRankDeficient(m, n, rank, rnd)
+		for _, kind := range []SVDKind{SVDThin, SVDFull} {
+			var svd SVD
+			ok := svd.Factorize(a, kind)
+			if !ok {
+				t.Fatalf("m=%d,n=%d: SVD factorization failed", m, n)
+			}
+			if got := svd.Rank(0); got != rank {
+				t.Errorf("m=%d,n=%d,kind=%v: unexpected rank: got:%d want:%d", m, n, kind, got, rank)
+			}
+
+			// Check the Moore-Penrose conditions
+			//  A * A^+ * A = A
+			//  A^+ * A * A^+ = A^+
+			//  (A * A^+)^T = A * A^+
+			//  (A^+ * A)^T = A^+ * A
+			pinv := svd.PseudoInverseTo(nil, 0)
+			if r, c := pinv.Dims(); r != n || c != m {
+				t.Errorf("m=%d,n=%d,kind=%v: unexpected pseudo-inverse dimensions: got:%d×%d", m, n, kind, r, c)
+				continue
+			}
+			var aap, apa, tmp Dense
+			aap.Mul(a, pinv)
+			apa.Mul(pinv, a)
+			tmp.Mul(&aap, a)
+			if !EqualApprox(&tmp, a, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: A*A^+*A != A", m, n, kind)
+			}
+			tmp.Reset()
+			tmp.Mul(&apa, pinv)
+			if !EqualApprox(&tmp, pinv, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: A^+*A*A^+ != A^+", m, n, kind)
+			}
+			if !EqualApprox(&aap, aap.T(), 1e-10) || !EqualApprox(&apa, apa.T(), 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: A*A^+ or A^+*A is not symmetric", m, n, kind)
+			}
+
+			// The range is spanned by the columns of A.
+			rng := svd.RangeTo(nil, 0)
+			if _, c := rng.Dims(); c != rank {
+				t.Errorf("m=%d,n=%d,kind=%v: unexpected range dimension: got:%d want:%d", m, n, kind, c, rank)
+			}
+			var proj Dense
+			proj.Mul(rng.T(), a)
+			tmp.Reset()
+			tmp.Mul(rng, &proj)
+			if !EqualApprox(&tmp, a, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: columns of A are not in the range", m, n, kind)
+			}
+
+			if kind == SVDThin && m < n {
+				if panicked, _ := panics(func() { svd.NullSpaceTo(nil, 0) }); !panicked {
+					t.Errorf("m=%d,n=%d: expected panic for thin null space", m, n)
+				}
+				continue
+			}
+			null := svd.NullSpaceTo(nil, 0)
+			if rank == n {
+				if null != nil {
+					t.Errorf("m=%d,n=%d,kind=%v: unexpected non-trivial null space", m, n, kind)
+				}
+				continue
+			}
+			if _, c := null.Dims(); c != n-rank {
+				t.Errorf("m=%d,n=%d,kind=%v: unexpected null space dimension: got:%d want:%d", m, n, kind, c, n-rank)
+			}
+			var an Dense
+			an.Mul(a, null)
+			if !EqualApprox(&an, NewDense(m, n-rank, nil), 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: A*N != 0", m, n, kind)
+			}
+			var ntn Dense
+			ntn.Mul(null.T(), null)
+			for i := 0; i < n-rank; i++ {
+				ntn.Set(i, i, ntn.At(i, i)-1)
+			}
+			if !EqualApprox(&ntn, NewDense(n-rank, n-rank, nil), 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%v: null space basis is not orthonormal", m, n, kind)
+			}
+		}
+	}
+
+	// A loose cutoff truncates small singular values.
+	a := NewDense(2, 2, []float64{
+		1, 0,
+		0, 1e-8,
+	})
+	var svd SVD
+	svd.Factorize(a, SVDFull)
+	if rank := svd.Rank(1e-6); rank != 1 {
+		t.Errorf("unexpected rank with cutoff: got:%d want:1", rank)
+	}
+	pinv := svd.PseudoInverseTo(nil, 1e-6)
+	want := NewDense(2, 2, []float64{1, 0, 0, 0})
+	if !EqualApprox(pinv, want, 1e-14) {
+		t.Errorf("unexpected pseudo-inverse with cutoff:\ngot:\n% v\nwant:\n% v", Formatted(pinv), Formatted(want))
+	}
+	null := svd.NullSpaceTo(nil, 1e-6)
+	if null == nil || math.Abs(math.Abs(null.At(1, 0))-1) > 1e-14 {
+		t.Errorf("unexpected null space with cutoff")
+	}
+}
@@ -14,6 +14,7 @@ var (
 	_            Matrix           = symBandDense
 	_            Symmetric        = symBandDense
 	_            Banded           = symBandDense
+	_            SymBanded        = symBandDense
 	_            RawSymBander     = symBandDense
 	_            MutableSymBanded = symBandDense
 
@@ -27,11 +28,19 @@ type SymBandDense struct {
 	mat blas64.SymmetricBand
 }
 
+// SymBanded is a symmetric band matrix interface type.
+type SymBanded interface {
+	Symmetric
+	// Bandwidth returns the lower and upper bandwidth values for
+	// the matrix. The lower and upper bandwidths of a symmetric
+	// band matrix are equal.
+	Bandwidth() (kl, ku int)
+}
+
 // MutableSymBanded is a symmetric band matrix interface type that allows elements
 // to be altered.
 type MutableSymBanded interface {
-	Symmetric
-	Bandwidth() (kl, ku int)
+	SymBanded
 	SetSymBand(i, j int, v float64)
 }
 
@@ -0,0 +1,342 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+var (
+	tridiag *Tridiag
+	_       Matrix        = tridiag
+	_       Banded        = tridiag
+	_       MutableBanded = tridiag
+
+	_ NonZeroDoer    = tridiag
+	_ RowNonZeroDoer = tridiag
+	_ ColNonZeroDoer = tridiag
+)
+
+// Tridiag represents a square tridiagonal matrix stored as its three
+// diagonals.
+type Tridiag struct {
+	n  int
+	dl []float64 // Sub-diagonal, length n-1.
+	d  []float64 // Diagonal, length n.
+	du []float64 // Super-diagonal, length n-1.
+}
+
+// NewTridiag creates a new n×n tridiagonal matrix with the sub-diagonal dl,
+// the diagonal d and the super-diagonal du. If dl, d and du are all nil, new
+// slices are allocated for the diagonals. Otherwise dl and du must have length
+// n-1 and d must have length n, and they are used as the backing slices, so
+// changes to the elements of the returned Tridiag will be reflected in them.
+// If neither of these is true, NewTridiag will panic.
+//
+// For example, the matrix
+//  1  2  0  0
+//  3  4  5  0
+//  0  6  7  8
+//  0  0  9 10
+// is created with
+//  NewTridiag(4, []float64{3, 6, 9}, []float64{1, 4, 7, 10}, []float64{2, 5, 8})
+func NewTridiag(n int, dl, d, du []float64) *Tridiag {
+	if n < 0 {
+		panic("mat: negative dimension")
+	}
+	if dl == nil && d == nil && du == nil {
+		d = make([]float64, n)
+		if n > 0 {
+			dl = make([]float64, n-1)
+			du = make([]float64, n-1)
+		}
+	}
+	if len(d) != n || len(dl) != max(0, n-1) || len(du) != max(0, n-1) {
+		panic(ErrShape)
+	}
+	return &Tridiag{n: n, dl: dl, d: d, du: du}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (t *Tridiag) Dims() (r, c int) {
+	return t.n, t.n
+}
+
+// Bandwidth returns the lower and upper bandwidths of the matrix.
+func (t *Tridiag) Bandwidth() (kl, ku int) {
+	if t.n < 2 {
+		return 0, 0
+	}
+	return 1, 1
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (t *Tridiag) T() Matrix {
+	return Transpose{t}
+}
+
+// TBand performs an implicit transpose by returning the receiver inside a TransposeBand.
+func (t *Tridiag) TBand() Banded {
+	return TransposeBand{t}
+}
+
+// NNZ returns the number of stored elements of the matrix.
+func (t *Tridiag) NNZ() int {
+	return len(t.dl) + len(t.d) + len(t.du)
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < t.n; i++ {
+		t.doRowNonZero(i, fn)
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || t.n <= i {
+		panic(ErrRowAccess)
+	}
+	t.doRowNonZero(i, fn)
+}
+
+func (t *Tridiag) doRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i > 0 && t.dl[i-1] != 0 {
+		fn(i, i-1, t.dl[i-1])
+	}
+	if t.d[i] != 0 {
+		fn(i, i, t.d[i])
+	}
+	if i < t.n-1 && t.du[i] != 0 {
+		fn(i, i+1, t.du[i])
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || t.n <= j {
+		panic(ErrColAccess)
+	}
+	if j > 0 && t.du[j-1] != 0 {
+		fn(j-1, j, t.du[j-1])
+	}
+	if t.d[j] != 0 {
+		fn(j, j, t.d[j])
+	}
+	if j < t.n-1 && t.dl[j] != 0 {
+		fn(j+1, j, t.dl[j])
+	}
+}
+
+// Solve solves the system of linear equations
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// where A is the receiver, and stores the solution into m. The system is
+// solved in O(n) time by Gaussian elimination with partial pivoting, a stable
+// variant of the Thomas algorithm.
+//
+// If A is exactly singular a Condition error is returned. Solve does not
+// estimate the condition number of A, so near-singularity is not reported.
+func (t *Tridiag) Solve(m *Dense, trans bool, b Matrix) error {
+	n := t.n
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	if n == 0 {
+		return nil
+	}
+	if !t.gtsv(trans, m.mat) {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// SolveVec solves the system of linear equations
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// where A is the receiver, and stores the solution into v. The system is
+// solved in O(n) time by Gaussian elimination with partial pivoting, a stable
+// variant of the Thomas algorithm.
+//
+// If A is exactly singular a Condition error is returned. SolveVec does not
+// estimate the condition number of A, so near-singularity is not reported.
+func (t *Tridiag) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	n := t.n
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+
+	v.reuseAs(n)
+	var restore func()
+	if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+	v.CopyVec(b)
+	if n == 0 {
+		return nil
+	}
+	vMat := blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+	if !t.gtsv(trans, vMat) {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// gtsv solves the tridiagonal system with right-hand sides b in place using
+// copies of the diagonals of the receiver. It returns whether A is
+// non-singular.
+func (t *Tridiag) gtsv(trans bool, b blas64.General) (ok bool) {
+	n := t.n
+	work := getFloats(3*n-2, false)
+	defer putFloats(work)
+	dl := work[:n-1]
+	d := work[n-1 : 2*n-1]
+	du := work[2*n-1:]
+	copy(dl, t.dl)
+	copy(d, t.d)
+	copy(du, t.du)
+	tA := blas.NoTrans
+	if trans {
+		tA = blas.Trans
+	}
+	return lapack64.Gtsv(tA, dl, d, du, b)
+}
+
+// SolveThomas solves the system of linear equations A * x = b, where A is the
+// receiver, using the Thomas algorithm and stores the solution into v.
+//
+// The Thomas algorithm is Gaussian elimination without pivoting and is only
+// guaranteed to be numerically stable when A is diagonally dominant or
+// symmetric positive definite, as is typical for spline and finite difference
+// systems. For general tridiagonal matrices SolveVec should be used.
+//
+// If a zero pivot is encountered, a Condition error is returned and the
+// contents of v are undefined.
+func (t *Tridiag) SolveThomas(v, b *VecDense) error {
+	n := t.n
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+
+	v.reuseAs(n)
+	v.CopyVec(b)
+	if n == 0 {
+		return nil
+	}
+	x := v.mat.Data
+	inc := v.mat.Inc
+
+	// w holds the super-diagonal of the unit upper
+	// bidiagonal factor.
+	w := getFloats(n-1, false)
+	defer putFloats(w)
+
+	// Forward sweep.
+	beta := t.d[0]
+	if beta == 0 {
+		return Condition(math.Inf(1))
+	}
+	x[0] /= beta
+	for i := 1; i < n; i++ {
+		w[i-1] = t.du[i-1] / beta
+		beta = t.d[i] - t.dl[i-1]*w[i-1]
+		if beta == 0 {
+			return Condition(math.Inf(1))
+		}
+		x[i*inc] = (x[i*inc] - t.dl[i-1]*x[(i-1)*inc]) / beta
+	}
+
+	// Back substitution.
+	for i := n - 2; i >= 0; i-- {
+		x[i*inc] -= w[i] * x[(i+1)*inc]
+	}
+	return nil
+}
+
+// solveSymTridiag solves the system A * X = B where A is a symmetric band
+// matrix with at most one super-diagonal, placing the result in m. The system
+// is first solved with the L*D*L^T factorization of A, and if A is found not
+// to be positive definite, with Gaussian elimination with partial pivoting.
+func (m *Dense) solveSymTridiag(a *SymBandDense, b Matrix) error {
+	n := a.mat.N
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	if n == 0 {
+		return nil
+	}
+
+	work := getFloats(3*n-2, false)
+	defer putFloats(work)
+	d := work[:n]
+	e := work[n : 2*n-1]
+	du := work[2*n-1:]
+	setDiags := func() {
+		for i := 0; i < n; i++ {
+			d[i] = a.at(i, i)
+			if i < n-1 {
+				e[i] = a.at(i, i+1)
+				du[i] = e[i]
+			}
+		}
+	}
+	setDiags()
+	if lapack64.Ptsv(d, e, m.mat) {
+		return nil
+	}
+	// A is not positive definite. Ptsv does not modify m when
+	// the factorization fails, so only the diagonals need to
+	// be restored.
+	setDiags()
+	if !lapack64.Gtsv(blas.NoTrans, e, d, du, m.mat) {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
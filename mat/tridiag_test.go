@@ -0,0 +1,232 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randTridiag returns a random n×n tridiagonal matrix. If dominant is true
+// the matrix is strictly diagonally dominant.
+func randTridiag(n int, dominant bool, rnd *rand.Rand) *Tridiag {
+	t := NewTridiag(n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		t.d[i] = rnd.NormFloat64()
+		if i < n-1 {
+			t.dl[i] = rnd.NormFloat64()
+			t.du[i] = rnd.NormFloat64()
+		}
+	}
+	if dominant {
+		for i := range t.d {
+			t.d[i] += math.Copysign(4, t.d[i])
+		}
+	}
+	return t
+}
+
+func TestNewTridiag(t *testing.T) {
+	a := NewTridiag(4, []float64{3, 6, 9}, []float64{1, 4, 7, 10}, []float64{2, 5, 8})
+	want := NewDense(4, 4, []float64{
+		1, 2, 0, 0,
+		3, 4, 5, 0,
+		0, 6, 7, 8,
+		0, 0, 9, 10,
+	})
+	if !Equal(a, want) {
+		t.Errorf("unexpected value via mat.Equal:\ngot:\n% v\nwant:\n% v", Formatted(a), Formatted(want))
+	}
+	if kl, ku := a.Bandwidth(); kl != 1 || ku != 1 {
+		t.Errorf("unexpected bandwidth: got:(%d,%d) want:(1,1)", kl, ku)
+	}
+	if a.NNZ() != 10 {
+		t.Errorf("unexpected number of stored elements: got:%d want:10", a.NNZ())
+	}
+
+	var got Dense
+	got.Clone(a.TBand())
+	var wantT Dense
+	wantT.Clone(want.T())
+	if !Equal(&got, &wantT) {
+		t.Errorf("unexpected transpose")
+	}
+
+	a.SetBand(2, 1, -6)
+	if a.At(2, 1) != -6 {
+		t.Errorf("unexpected value after SetBand: got:%v want:-6", a.At(2, 1))
+	}
+	if panicked, _ := panics(func() { a.SetBand(0, 2, 1) }); !panicked {
+		t.Errorf("expected panic for SetBand outside band")
+	}
+	if panicked, _ := panics(func() { NewTridiag(3, []float64{1}, []float64{1, 2, 3}, []float64{1, 2}) }); !panicked {
+		t.Errorf("expected panic for short sub-diagonal")
+	}
+
+	var count int
+	a.DoNonZero(func(i, j int, v float64) {
+		count++
+		if a.At(i, j) != v {
+			t.Errorf("unexpected value in DoNonZero at (%d,%d)", i, j)
+		}
+	})
+	if count != 10 {
+		t.Errorf("unexpected number of non-zero elements: got:%d want:10", count)
+	}
+}
+
+func TestTridiagSolve(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, trans := range []bool{false, true} {
+			a := randTridiag(n, true, rnd)
+			var op Matrix = a
+			if trans {
+				op = a.T()
+			}
+
+			want := NewDense(n, 3, nil)
+			for i := range want.mat.Data {
+				want.mat.Data[i] = rnd.NormFloat64()
+			}
+			var b Dense
+			b.Mul(op, want)
+
+			var x Dense
+			if err := a.Solve(&x, trans, &b); err != nil {
+				t.Errorf("n=%d,trans=%t: unexpected error from Solve: %v", n, trans, err)
+			}
+			if !EqualApprox(&x, want, 1e-12) {
+				t.Errorf("n=%d,trans=%t: unexpected Solve result", n, trans)
+			}
+
+			var xd Dense
+			if err := xd.Solve(op, &b); err != nil {
+				t.Errorf("n=%d,trans=%t: unexpected error from Dense.Solve: %v", n, trans, err)
+			}
+			if !EqualApprox(&xd, want, 1e-12) {
+				t.Errorf("n=%d,trans=%t: unexpected Dense.Solve result", n, trans)
+			}
+
+			// In-place solve.
+			b.Solve(op, &b)
+			if !EqualApprox(&b, want, 1e-12) {
+				t.Errorf("n=%d,trans=%t: unexpected in-place Dense.Solve result", n, trans)
+			}
+
+			wantVec := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				wantVec.SetVec(i, rnd.NormFloat64())
+			}
+			var bv VecDense
+			bv.MulVec(op, wantVec)
+			var xv VecDense
+			if err := a.SolveVec(&xv, trans, &bv); err != nil {
+				t.Errorf("n=%d,trans=%t: unexpected error from SolveVec: %v", n, trans, err)
+			}
+			if !EqualApprox(&xv, wantVec, 1e-12) {
+				t.Errorf("n=%d,trans=%t: unexpected SolveVec result", n, trans)
+			}
+			if !trans {
+				var xt VecDense
+				if err := a.SolveThomas(&xt, &bv); err != nil {
+					t.Errorf("n=%d: unexpected error from SolveThomas: %v", n, err)
+				}
+				if !EqualApprox(&xt, wantVec, 1e-12) {
+					t.Errorf("n=%d: unexpected SolveThomas result", n)
+				}
+			}
+		}
+	}
+
+	// A matrix with a zero diagonal requires pivoting.
+	a := NewTridiag(4, []float64{1, 2, 3}, []float64{0, 0, 0, 0}, []float64{4, 5, 6})
+	want := NewVecDense(4, []float64{1, -2, 3, -4})
+	var b, x VecDense
+	b.MulVec(a, want)
+	if err := a.SolveVec(&x, false, &b); err != nil {
+		t.Errorf("unexpected error for zero diagonal: %v", err)
+	}
+	if !EqualApprox(&x, want, 1e-14) {
+		t.Errorf("unexpected SolveVec result for zero diagonal")
+	}
+	if _, ok := a.SolveThomas(&x, &b).(Condition); !ok {
+		t.Errorf("expected Condition error from SolveThomas for zero pivot")
+	}
+
+	// A singular matrix.
+	s := NewTridiag(3, []float64{1, 1}, []float64{1, 1, 1}, []float64{1, 0})
+	var xs VecDense
+	if _, ok := s.SolveVec(&xs, false, NewVecDense(3, []float64{1, 2, 3})).(Condition); !ok {
+		t.Errorf("expected Condition error for singular matrix")
+	}
+}
+
+func TestSolveSymTridiag(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 10} {
+		for _, posdef := range []bool{true, false} {
+			a := NewSymBandDense(n, min(1, n-1), nil)
+			for i := 0; i < n; i++ {
+				v := rnd.NormFloat64()
+				if posdef {
+					v = 3 + math.Abs(v)
+				}
+				a.SetSymBand(i, i, v)
+				if i < n-1 {
+					a.SetSymBand(i, i+1, rnd.NormFloat64())
+				}
+			}
+			if !posdef {
+				a.SetSymBand(0, 0, -3)
+			}
+
+			want := NewDense(n, 2, nil)
+			for i := range want.mat.Data {
+				want.mat.Data[i] = rnd.NormFloat64()
+			}
+			var b, x Dense
+			b.Mul(a, want)
+			if err := x.Solve(a, &b); err != nil {
+				t.Errorf("n=%d,posdef=%t: unexpected error: %v", n, posdef, err)
+			}
+			if !EqualApprox(&x, want, 1e-10) {
+				t.Errorf("n=%d,posdef=%t: unexpected solution", n, posdef)
+			}
+		}
+	}
+}
+
+func TestTridiagMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		a := randTridiag(n, false, rnd)
+		var ad Dense
+		ad.Clone(a)
+		b := NewDense(n, 4, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+
+		var got, want Dense
+		got.Mul(a, b)
+		want.Mul(&ad, b)
+		if !EqualApprox(&got, &want, 1e-14) {
+			t.Errorf("n=%d: unexpected Mul result", n)
+		}
+
+		x := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			x.SetVec(i, rnd.NormFloat64())
+		}
+		var gotVec, wantVec VecDense
+		gotVec.MulVec(a.T(), x)
+		wantVec.MulVec(ad.T(), x)
+		if !EqualApprox(&gotVec, &wantVec, 1e-14) {
+			t.Errorf("n=%d: unexpected MulVec result", n)
+		}
+	}
+}
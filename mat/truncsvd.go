@@ -0,0 +1,245 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badTruncRank = "mat: number of requested values out of range"
+
+// TruncatedSVD is a type for computing and using the k leading singular
+// triplets of a matrix, the k largest singular values and their singular
+// vectors. The truncated decomposition
+//  A_k = U_k * Σ_k * V_k^T
+// is the best rank-k approximation of A. Computing it is much cheaper than
+// the full or thin SVD when k is small compared to the dimensions of A.
+type TruncatedSVD struct {
+	s []float64
+	u *Dense // m×k
+	v *Dense // n×k
+}
+
+// RandomizedSVDSettings holds parameters of the randomized SVD algorithm used
+// by TruncatedSVD.FactorizeRandomized.
+type RandomizedSVDSettings struct {
+	// Oversample is the number of random samples of the range of A in
+	// addition to the k requested singular triplets. If Oversample is
+	// zero, 10 is used. If Oversample is negative, no additional samples
+	// are taken.
+	Oversample int
+
+	// PowerIters is the number of power iterations used to improve the
+	// accuracy of the computed range of A when the singular values of A
+	// decay slowly. If PowerIters is zero, 2 is used. If PowerIters is
+	// negative, no power iterations are performed.
+	PowerIters int
+
+	// Src is the source of random numbers. If Src is nil, the global
+	// source in math/rand is used.
+	Src *rand.Rand
+}
+
+// FactorizeRandomized computes the k leading singular triplets of the m×n
+// matrix a using the randomized range finder of Halko, Martinsson and Tropp.
+// FactorizeRandomized panics if k is not positive or is greater than
+// min(m,n). If settings is nil, the default settings are used.
+//
+// The range of A is sampled by multiplying A with a random n×l matrix, with
+// l = k + settings.Oversample, and an orthonormal basis Q of the samples is
+// computed. The SVD of the small l×n matrix Q^T * A then gives approximate
+// singular triplets of A. Each power iteration improves the basis at the cost
+// of two additional multiplications by A. A is only accessed through
+// products with dense matrices, so it may be sparse.
+//
+// The accuracy of the computed triplets depends on the decay of the singular
+// values of A. For matrices where the decay is slow and high accuracy is
+// required, TruncatedSVD.FactorizeLanczos should be used.
+//
+// FactorizeRandomized returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+//
+// See https://arxiv.org/abs/0909.4061 for details of the algorithm.
+func (svd *TruncatedSVD) FactorizeRandomized(a Matrix, k int, settings *RandomizedSVDSettings) (ok bool) {
+	m, n := a.Dims()
+	if k <= 0 || min(m, n) < k {
+		panic(badTruncRank)
+	}
+	var s RandomizedSVDSettings
+	if settings != nil {
+		s = *settings
+	}
+	switch {
+	case s.Oversample == 0:
+		s.Oversample = 10
+	case s.Oversample < 0:
+		s.Oversample = 0
+	}
+	switch {
+	case s.PowerIters == 0:
+		s.PowerIters = 2
+	case s.PowerIters < 0:
+		s.PowerIters = 0
+	}
+	l := min(k+s.Oversample, min(m, n))
+
+	// Sample the range of A.
+	norm := rand.NormFloat64
+	if s.Src != nil {
+		norm = s.Src.NormFloat64
+	}
+	omega := NewDense(n, l, nil)
+	for i := range omega.mat.Data {
+		omega.mat.Data[i] = norm()
+	}
+	var q, z Dense
+	q.Mul(a, omega)
+	orthonormalize(&q)
+	for i := 0; i < s.PowerIters; i++ {
+		z.Mul(a.T(), &q)
+		orthonormalize(&z)
+		q.Mul(a, &z)
+		orthonormalize(&q)
+	}
+
+	// Compute the SVD of the l×n matrix B = Q^T * A through its
+	// transpose B^T = W * Σ * Z^T, so that A ≈ (Q * Z) * Σ * W^T.
+	z.Mul(a.T(), &q)
+	var small SVD
+	ok = small.Factorize(&z, SVDThin)
+	if !ok {
+		svd.s = nil
+		return false
+	}
+	svd.s = small.Values(nil)[:k]
+	w := small.UTo(nil)
+	zz := small.VTo(nil)
+	if svd.u == nil {
+		svd.u = &Dense{}
+	}
+	if svd.v == nil {
+		svd.v = &Dense{}
+	}
+	svd.u.Reset()
+	svd.u.Mul(&q, zz.Slice(0, l, 0, k))
+	svd.v.Reset()
+	svd.v.Clone(w.Slice(0, n, 0, k))
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (svd *TruncatedSVD) succFact() bool {
+	return len(svd.s) != 0
+}
+
+// Values returns the k computed singular values in decreasing order. If the
+// input slice is non-nil, the values will be stored in-place into the slice.
+// In this case, the slice must have length k, and Values will panic with
+// ErrSliceLengthMismatch otherwise. If the input slice is nil, a new slice of
+// the appropriate length will be allocated and returned.
+//
+// Values will panic if the receiver does not contain a successful
+// factorization.
+func (svd *TruncatedSVD) Values(s []float64) []float64 {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	if s == nil {
+		s = make([]float64, len(svd.s))
+	}
+	if len(s) != len(svd.s) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(s, svd.s)
+	return s
+}
+
+// UTo extracts the m×k matrix of the leading left singular vectors, storing
+// the result in-place into dst. If dst is nil, a new matrix is allocated. The
+// resulting matrix is returned.
+//
+// UTo will panic if the receiver does not contain a successful factorization.
+func (svd *TruncatedSVD) UTo(dst *Dense) *Dense {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = &Dense{}
+	}
+	dst.reuseAs(svd.u.Dims())
+	dst.Copy(svd.u)
+	return dst
+}
+
+// VTo extracts the n×k matrix of the leading right singular vectors, storing
+// the result in-place into dst. If dst is nil, a new matrix is allocated. The
+// resulting matrix is returned.
+//
+// VTo will panic if the receiver does not contain a successful factorization.
+func (svd *TruncatedSVD) VTo(dst *Dense) *Dense {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = &Dense{}
+	}
+	dst.reuseAs(svd.v.Dims())
+	dst.Copy(svd.v)
+	return dst
+}
+
+// orthonormalize replaces the columns of the m×n matrix a, m >= n, with an
+// orthonormal basis for their span computed by a QR factorization of a.
+func orthonormalize(a *Dense) {
+	m, n := a.Dims()
+	tau := getFloats(n, false)
+	defer putFloats(tau)
+	work := []float64{0}
+	lapack64.Geqrf(a.mat, tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqrf(a.mat, tau, work, len(work))
+	putFloats(work)
+
+	// Form the leading n columns of Q.
+	q := getWorkspace(m, n, true)
+	defer putWorkspace(q)
+	for i := 0; i < n; i++ {
+		q.mat.Data[i*q.mat.Stride+i] = 1
+	}
+	work = []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a.mat, tau, q.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a.mat, tau, q.mat, work, len(work))
+	putFloats(work)
+	a.Copy(q)
+}
+
+// gramSchmidt orthogonalizes x against the orthonormal rows of basis using
+// classical Gram-Schmidt with one step of reorthogonalization. If coef is not
+// nil, the projection coefficients of x onto the rows of basis are added to
+// coef. gramSchmidt returns the Euclidean norm of the orthogonalized x.
+func gramSchmidt(basis blas64.General, x, coef []float64) float64 {
+	xv := blas64.Vector{Inc: 1, Data: x}
+	if basis.Rows != 0 {
+		c := getFloats(basis.Rows, false)
+		cv := blas64.Vector{Inc: 1, Data: c}
+		for pass := 0; pass < 2; pass++ {
+			blas64.Gemv(blas.NoTrans, 1, basis, xv, 0, cv)
+			blas64.Gemv(blas.Trans, -1, basis, cv, 1, xv)
+			if coef != nil {
+				for i, v := range c {
+					coef[i] += v
+				}
+			}
+		}
+		putFloats(c)
+	}
+	return blas64.Nrm2(len(x), xv)
+}
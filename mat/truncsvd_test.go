@@ -0,0 +1,170 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// randOrthonormal returns a random m×n matrix with orthonormal columns.
+func randOrthonormal(m, n int, rnd *rand.Rand) *Dense {
+	q := NewDense(m, n, nil)
+	for i := range q.mat.Data {
+		q.mat.Data[i] = rnd.NormFloat64()
+	}
+	orthonormalize(q)
+	return q
+}
+
+// randWithValues returns a random m×n matrix with the singular values s.
+func randWithValues(m, n int, s []float64, rnd *rand.Rand) *Dense {
+	u := randOrthonormal(m, len(s), rnd)
+	v := randOrthonormal(n, len(s), rnd)
+	for j, sj := range s {
+		for i := 0; i < m; i++ {
+			u.set(i, j, u.at(i, j)*sj)
+		}
+	}
+	var a Dense
+	a.Mul(u, v.T())
+	return &a
+}
+
+// hasOrthonormalColumns returns whether the columns of q are orthonormal
+// to within tol.
+func hasOrthonormalColumns(q *Dense, tol float64) bool {
+	_, n := q.Dims()
+	var qtq Dense
+	qtq.Mul(q.T(), q)
+	for i := 0; i < n; i++ {
+		qtq.set(i, i, qtq.at(i, i)-1)
+	}
+	return EqualApprox(&qtq, NewDense(n, n, nil), tol)
+}
+
+// checkSingularTriplets checks that the columns of u and v are orthonormal
+// and that they are singular vectors of a with the singular values s.
+func checkSingularTriplets(t *testing.T, name string, a Matrix, s []float64, u, v *Dense, tol float64) {
+	if !hasOrthonormalColumns(u, tol) {
+		t.Errorf("%s: U is not orthonormal", name)
+	}
+	if !hasOrthonormalColumns(v, tol) {
+		t.Errorf("%s: V is not orthonormal", name)
+	}
+	var av Dense
+	av.Mul(a, v)
+	for j, sj := range s {
+		for i := 0; i < av.mat.Rows; i++ {
+			if math.Abs(av.At(i, j)-sj*u.At(i, j)) > tol*s[0] {
+				t.Errorf("%s: A*v_%d != s_%d*u_%d", name, j, j, j)
+				break
+			}
+		}
+	}
+}
+
+func TestTruncatedSVDRandomized(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{100, 40, 5},
+		{40, 100, 5},
+		{60, 60, 10},
+		{200, 30, 1},
+		{30, 20, 20},
+	} {
+		m, n, k := test.m, test.n, test.k
+		// The singular values decay rapidly, so the randomized
+		// range finder captures the leading triplets accurately.
+		s := make([]float64, min(m, n))
+		for i := range s {
+			s[i] = math.Pow(0.5, float64(i))
+		}
+		a := randWithValues(m, n, s, rnd)
+
+		var svd TruncatedSVD
+		ok := svd.FactorizeRandomized(a, k, &RandomizedSVDSettings{Src: rnd})
+		if !ok {
+			t.Errorf("m=%d,n=%d,k=%d: factorization failed", m, n, k)
+			continue
+		}
+		got := svd.Values(nil)
+		if !floats.EqualApprox(got, s[:k], 1e-8) {
+			t.Errorf("m=%d,n=%d,k=%d: unexpected singular values:\ngot: %v\nwant:%v", m, n, k, got, s[:k])
+		}
+		u := svd.UTo(nil)
+		v := svd.VTo(nil)
+		if r, c := u.Dims(); r != m || c != k {
+			t.Errorf("m=%d,n=%d,k=%d: unexpected dimensions of U: %d×%d", m, n, k, r, c)
+		}
+		if r, c := v.Dims(); r != n || c != k {
+			t.Errorf("m=%d,n=%d,k=%d: unexpected dimensions of V: %d×%d", m, n, k, r, c)
+		}
+		checkSingularTriplets(t, "randomized", a, got, u, v, 1e-8)
+	}
+}
+
+func TestTruncatedSVDLanczos(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+		ncv     int
+	}{
+		{100, 40, 5, 0},
+		{40, 100, 5, 0},
+		{60, 60, 10, 0},
+		{200, 30, 1, 0},
+		{30, 20, 20, 0},
+		{30, 20, 19, 0},
+		{150, 120, 4, 8},
+	} {
+		m, n, k := test.m, test.n, test.k
+		// The singular values decay slowly, so many restarts are
+		// needed with a small Krylov subspace.
+		a := NewDense(m, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		var full SVD
+		full.Factorize(a, SVDNone)
+		want := full.Values(nil)[:k]
+
+		var svd TruncatedSVD
+		ok := svd.FactorizeLanczos(a, k, &LanczosSettings{NCV: test.ncv, Src: rnd})
+		if !ok {
+			t.Errorf("m=%d,n=%d,k=%d: factorization failed", m, n, k)
+			continue
+		}
+		got := svd.Values(nil)
+		if !floats.EqualApprox(got, want, 1e-10) {
+			t.Errorf("m=%d,n=%d,k=%d: unexpected singular values:\ngot: %v\nwant:%v", m, n, k, got, want)
+		}
+		checkSingularTriplets(t, "Lanczos", a, got, svd.UTo(nil), svd.VTo(nil), 1e-10)
+	}
+
+	// A sparse matrix with repeated singular values.
+	const n = 50
+	a := NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		a.Append(i, i, float64(i/2+1))
+	}
+	csr := a.ToCSR()
+	var svd TruncatedSVD
+	ok := svd.FactorizeLanczos(csr, 4, &LanczosSettings{Src: rnd})
+	if !ok {
+		t.Fatalf("sparse: factorization failed")
+	}
+	want := []float64{25, 25, 24, 24}
+	got := svd.Values(nil)
+	if !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("sparse: unexpected singular values: got:%v want:%v", got, want)
+	}
+	checkSingularTriplets(t, "sparse", csr, got, svd.UTo(nil), svd.VTo(nil), 1e-10)
+}
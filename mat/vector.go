@@ -400,6 +400,16 @@ func (v *VecDense) MulVec(a Matrix, b *VecDense) {
 			t = blas.Trans
 		}
 		blas64.Gemv(t, 1, amat, b.mat, 0, v.mat)
+	case sparse:
+		for i := 0; i < r; i++ {
+			v.mat.Data[i*v.mat.Inc] = 0
+		}
+		a.DoNonZero(func(i, j int, e float64) {
+			if trans {
+				i, j = j, i
+			}
+			v.mat.Data[i*v.mat.Inc] += e * b.mat.Data[j*b.mat.Inc]
+		})
 	default:
 		if trans {
 			col := make([]float64, ar)
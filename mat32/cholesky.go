@@ -0,0 +1,185 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+const badCholesky = "mat32: invalid Cholesky factorization"
+
+// Cholesky is a type for creating and using the Cholesky factorization of a
+// symmetric positive definite matrix.
+//
+// Cholesky methods may only be called on a value that has been successfully
+// initialized by a call to Factorize that has returned true. Calls to methods
+// of an unsuccessful Cholesky factorization will panic.
+type Cholesky struct {
+	// The chol pointer must never be retained as a pointer outside the Cholesky
+	// struct, either by returning chol outside the struct or by setting it to
+	// a pointer coming from outside. The same prohibition applies to the data
+	// slice within chol.
+	chol *TriDense
+}
+
+// Factorize calculates the Cholesky decomposition of the matrix A and returns
+// whether the matrix is positive definite. If Factorize returns false, the
+// factorization must not be used.
+func (c *Cholesky) Factorize(a Symmetric) (ok bool) {
+	n := a.Symmetric()
+	if c.isZero() {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	copySymIntoTriangle(c.chol, a)
+
+	_, ok = lapack32.Potrf(c.chol.asSymBlas())
+	if !ok {
+		c.Reset()
+	}
+	return ok
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *Cholesky) Reset() {
+	if c.chol != nil {
+		c.chol.Reset()
+	}
+}
+
+func (c *Cholesky) isZero() bool {
+	return c.chol == nil || c.chol.IsZero()
+}
+
+func (c *Cholesky) valid() bool {
+	return c.chol != nil && !c.chol.IsZero()
+}
+
+// Size returns the dimension of the factorized matrix.
+func (c *Cholesky) Size() int {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return c.chol.mat.N
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (c *Cholesky) Det() float32 {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return float32(math.Exp(float64(c.LogDet())))
+}
+
+// LogDet returns the log of the determinant of the matrix that has been factorized.
+func (c *Cholesky) LogDet() float32 {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	var det float64
+	for i := 0; i < c.chol.mat.N; i++ {
+		det += 2 * math.Log(float64(c.chol.mat.Data[i*c.chol.mat.Stride+i]))
+	}
+	return float32(det)
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented
+// by the Cholesky decomposition, placing the result in m.
+func (c *Cholesky) Solve(m *Dense, b Matrix) error {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.chol.mat.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(bm, bn)
+	if b != m {
+		m.Copy(b)
+	}
+	blas32.Trsm(blas.Left, blas.Trans, 1, c.chol.mat, m.mat)
+	blas32.Trsm(blas.Left, blas.NoTrans, 1, c.chol.mat, m.mat)
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented
+// by the Cholesky decomposition, placing the result in v.
+func (c *Cholesky) SolveVec(v, b *VecDense) error {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.chol.mat.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	v.reuseAs(n)
+	if v != b {
+		v.CopyVec(b)
+	}
+	blas32.Trsv(blas.Trans, c.chol.mat, v.mat)
+	blas32.Trsv(blas.NoTrans, c.chol.mat, v.mat)
+	return nil
+}
+
+// UTo extracts the n×n upper triangular matrix U from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// TriDense is allocated.
+//  A = U^T * U.
+func (c *Cholesky) UTo(dst *TriDense) *TriDense {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewTriDense(n, Upper, make([]float32, n*n))
+	} else {
+		dst.reuseAs(n, Upper)
+	}
+	dst.Copy(c.chol)
+	return dst
+}
+
+// LTo extracts the n×n lower triangular matrix L from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// TriDense is allocated.
+//  A = L * L^T.
+func (c *Cholesky) LTo(dst *TriDense) *TriDense {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewTriDense(n, Lower, make([]float32, n*n))
+	} else {
+		dst.reuseAs(n, Lower)
+	}
+	dst.Copy(c.chol.TTri())
+	return dst
+}
+
+// ToSym reconstructs the original positive definite matrix given its
+// Cholesky decomposition into dst and returns the result. If dst is nil
+// a new SymDense is allocated.
+func (c *Cholesky) ToSym(dst *SymDense) *SymDense {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewSymDense(n, make([]float32, n*n))
+	} else {
+		dst.reuseAs(n)
+	}
+	dst.SymOuterK(1, c.chol.T())
+	return dst
+}
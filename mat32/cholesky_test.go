@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randSPD returns a random well-conditioned symmetric positive definite
+// n×n matrix.
+func randSPD(n int, rnd *rand.Rand) *SymDense {
+	var a SymDense
+	a.SymOuterK(1, randDense(n, n, rnd))
+	for i := 0; i < n; i++ {
+		a.SetSym(i, i, a.At(i, i)+float32(n))
+	}
+	return &a
+}
+
+func TestCholesky(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 50} {
+		a := randSPD(n, rnd)
+
+		var chol Cholesky
+		if ok := chol.Factorize(a); !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		if chol.Size() != n {
+			t.Errorf("n=%d: unexpected size %d", n, chol.Size())
+		}
+
+		u := chol.UTo(nil)
+		var utu Dense
+		utu.Mul(u.T(), u)
+		if !EqualApprox(&utu, a, tol) {
+			t.Errorf("n=%d: U^T*U != A", n)
+		}
+		l := chol.LTo(nil)
+		var llt Dense
+		llt.Mul(l, l.T())
+		if !EqualApprox(&llt, a, tol) {
+			t.Errorf("n=%d: L*L^T != A", n)
+		}
+		if !EqualApprox(chol.ToSym(nil), a, tol) {
+			t.Errorf("n=%d: unexpected result of ToSym", n)
+		}
+
+		var chol64 mat.Cholesky
+		a64 := mat.NewSymDense(n, toMat(a).RawMatrix().Data)
+		chol64.Factorize(a64)
+		if got, want := float64(chol.LogDet()), chol64.LogDet(); math.Abs(got-want) > tol*math.Abs(want) {
+			t.Errorf("n=%d: unexpected log determinant: got %v want %v", n, got, want)
+		}
+
+		b := randDense(n, 3, rnd)
+		var x Dense
+		if err := chol.Solve(&x, b); err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+		}
+		var want mat.Dense
+		want.Solve(a64, toMat(b))
+		if !equalApproxMat(&x, &want, tol) {
+			t.Errorf("n=%d: unexpected solution", n)
+		}
+
+		bv := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			bv.SetVec(i, b.At(i, 1))
+		}
+		var xv VecDense
+		if err := chol.SolveVec(&xv, bv); err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+		}
+		if !EqualApprox(&xv, x.ColView(1), tol) {
+			t.Errorf("n=%d: unexpected vector solution", n)
+		}
+	}
+
+	a := NewSymDense(2, []float32{
+		1, 2,
+		2, 1,
+	})
+	var chol Cholesky
+	if chol.Factorize(a) {
+		t.Errorf("unexpected success for indefinite matrix")
+	}
+	if !panics(func() { chol.Size() }) {
+		t.Errorf("expected panic for failed factorization")
+	}
+}
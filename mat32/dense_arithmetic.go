@@ -0,0 +1,184 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// isolatedWorkspace returns a new dense matrix w with the size of a and
+// returns a callback to defer which performs cleanup at the return of the call.
+// This should be used when a method receiver is the same pointer as an input argument.
+func (m *Dense) isolatedWorkspace(a Matrix) (w *Dense, restore func()) {
+	r, c := a.Dims()
+	w = NewDense(r, c, nil)
+	return w, func() {
+		m.Copy(w)
+	}
+}
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *Dense) Add(a, b Matrix) {
+	m.elementwise(a, b, func(x, y float32) float32 { return x + y })
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *Dense) Sub(a, b Matrix) {
+	m.elementwise(a, b, func(x, y float32) float32 { return x - y })
+}
+
+// MulElem performs element-wise multiplication of a and b, placing the result
+// in the receiver. MulElem will panic if the two matrices do not have the same
+// shape.
+func (m *Dense) MulElem(a, b Matrix) {
+	m.elementwise(a, b, func(x, y float32) float32 { return x * y })
+}
+
+// elementwise places fn(a[i,j], b[i,j]) into the receiver for all elements.
+func (m *Dense) elementwise(a, b Matrix, fn func(x, y float32) float32) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := untranspose(a)
+	bU, bTrans := untranspose(b)
+	m.reuseAs(ar, ac)
+
+	if arm, ok := a.(RawMatrixer); ok {
+		if brm, ok := b.(RawMatrixer); ok {
+			// Neither a nor b is transposed, so the
+			// receiver may be the same matrix as either.
+			amat, bmat := arm.RawMatrix(), brm.RawMatrix()
+			for ja, jb, jm := 0, 0, 0; ja < ar*amat.Stride; ja, jb, jm = ja+amat.Stride, jb+bmat.Stride, jm+m.mat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					m.mat.Data[i+jm] = fn(v, bmat.Data[i+jb])
+				}
+			}
+			return
+		}
+	}
+
+	if (m == aU && aTrans) || (m == bU && bTrans) {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, fn(a.At(r, c), b.At(r, c)))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *Dense) Scale(f float32, a Matrix) {
+	ar, ac := a.Dims()
+
+	m.reuseAs(ar, ac)
+
+	aU, aTrans := untranspose(a)
+	if rm, ok := aU.(RawMatrixer); ok && !aTrans {
+		amat := rm.RawMatrix()
+		for ja, jm := 0, 0; ja < ar*amat.Stride; ja, jm = ja+amat.Stride, jm+m.mat.Stride {
+			for i, v := range amat.Data[ja : ja+ac] {
+				m.mat.Data[i+jm] = v * f
+			}
+		}
+		return
+	}
+
+	if m == aU {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, f*a.At(r, c))
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+//
+// The product is computed by blas32.Gemm. Operands that are not RawMatrixer
+// values, or their implicit transposes, are first copied into a Dense.
+func (m *Dense) Mul(a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+
+	if ac != br {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := untranspose(a)
+	bU, bTrans := untranspose(b)
+	m.reuseAs(ar, bc)
+	if ar == 0 || bc == 0 {
+		return
+	}
+	if m == aU || m == bU {
+		var restore func()
+		m, restore = m.isolatedWorkspace(m)
+		defer restore()
+	}
+
+	amat, aT := rawGeneral(aU, aTrans)
+	bmat, bT := rawGeneral(bU, bTrans)
+	blas32.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
+}
+
+// rawGeneral returns a blas32.General holding the untransposed matrix a and
+// the transpose flag to use with it in a BLAS call. If a is not a RawMatrixer,
+// its elements are copied.
+func rawGeneral(a Matrix, trans bool) (blas32.General, blas.Transpose) {
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	switch a := a.(type) {
+	case RawMatrixer:
+		return a.RawMatrix(), t
+	case *VecDense:
+		return a.asGeneral(), t
+	}
+	return DenseCopyOf(a).mat, t
+}
+
+// Outer calculates the outer product of the column vectors x and y,
+// and stores the result in the receiver.
+//  m = alpha * x * y^T
+// In order to update an existing matrix, see RankOne.
+func (m *Dense) Outer(alpha float32, x, y Vector) {
+	xr := x.Len()
+	yr := y.Len()
+	m.reuseAsZeroed(xr, yr)
+	m.RankOne(m, alpha, x, y)
+}
+
+// RankOne performs a rank-one update to the matrix a and stores the result
+// in the receiver. If a is zero, see Outer.
+//  m = a + alpha * x * y^T
+func (m *Dense) RankOne(a Matrix, alpha float32, x, y Vector) {
+	ar, ac := a.Dims()
+	if x.Len() != ar || y.Len() != ac {
+		panic(ErrShape)
+	}
+	if a != m {
+		m.reuseAs(ar, ac)
+		m.Copy(a)
+	}
+	xv := vecDenseCopyOf(x)
+	yv := vecDenseCopyOf(y)
+	blas32.Ger(alpha, xv.mat, yv.mat, m.mat)
+}
@@ -0,0 +1,219 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewDense(t *testing.T) {
+	d := NewDense(2, 3, []float32{1, 2, 3, 4, 5, 6})
+	if r, c := d.Dims(); r != 2 || c != 3 {
+		t.Errorf("unexpected dimensions: got %d×%d want 2×3", r, c)
+	}
+	if d.At(1, 2) != 6 {
+		t.Errorf("unexpected value: got %v want 6", d.At(1, 2))
+	}
+	d.Set(0, 1, -1)
+	if d.RawMatrix().Data[1] != -1 {
+		t.Errorf("Set not reflected in backing data")
+	}
+	if !panics(func() { NewDense(2, 2, make([]float32, 3)) }) {
+		t.Errorf("expected panic for bad data length")
+	}
+	if !panics(func() { d.At(2, 0) }) {
+		t.Errorf("expected panic for row out of range")
+	}
+	if !panics(func() { d.At(0, 3) }) {
+		t.Errorf("expected panic for column out of range")
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return
+}
+
+func TestDenseCopySlice(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randDense(5, 4, rnd)
+
+	s := a.Slice(1, 4, 1, 3)
+	if r, c := s.Dims(); r != 3 || c != 2 {
+		t.Fatalf("unexpected slice dimensions: got %d×%d want 3×2", r, c)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			if s.At(i, j) != a.At(i+1, j+1) {
+				t.Errorf("unexpected slice element at %d,%d", i, j)
+			}
+		}
+	}
+
+	var b Dense
+	b.Clone(a.T())
+	if !Equal(&b, a.T()) {
+		t.Errorf("unexpected clone of transpose")
+	}
+
+	sq := randDense(4, 4, rnd)
+	want := DenseCopyOf(sq.T())
+	sq.Copy(sq.T())
+	if !Equal(sq, want) {
+		t.Errorf("unexpected result of aliased transpose copy")
+	}
+
+	col := a.ColView(2)
+	row := a.RowView(3)
+	for i := 0; i < 5; i++ {
+		if col.At(i, 0) != a.At(i, 2) {
+			t.Errorf("unexpected column view element %d", i)
+		}
+	}
+	for j := 0; j < 4; j++ {
+		if row.At(j, 0) != a.At(3, j) {
+			t.Errorf("unexpected row view element %d", j)
+		}
+	}
+}
+
+func TestDenseArithmetic(t *testing.T) {
+	const tol = 1e-5
+	rnd := rand.New(rand.NewSource(1))
+	a := randDense(4, 3, rnd)
+	b := randDense(4, 3, rnd)
+	a64 := toMat(a)
+	b64 := toMat(b)
+
+	var got Dense
+	var want mat.Dense
+
+	got.Add(a, b)
+	want.Add(a64, b64)
+	if !equalApproxMat(&got, &want, tol) {
+		t.Errorf("unexpected result of Add")
+	}
+
+	got.Sub(a, b)
+	want.Sub(a64, b64)
+	if !equalApproxMat(&got, &want, tol) {
+		t.Errorf("unexpected result of Sub")
+	}
+
+	got.MulElem(a, b)
+	want.MulElem(a64, b64)
+	if !equalApproxMat(&got, &want, tol) {
+		t.Errorf("unexpected result of MulElem")
+	}
+
+	got.Scale(2.5, a)
+	want.Scale(2.5, a64)
+	if !equalApproxMat(&got, &want, tol) {
+		t.Errorf("unexpected result of Scale")
+	}
+
+	// Check the in-place operations.
+	c := DenseCopyOf(a)
+	c.Add(c, b)
+	want.Add(a64, b64)
+	if !equalApproxMat(c, &want, tol) {
+		t.Errorf("unexpected result of in-place Add")
+	}
+
+	sq := randDense(4, 4, rnd)
+	sq64 := toMat(sq)
+	sq.Add(sq, sq.T())
+	want.Reset()
+	want.Add(sq64, sq64.T())
+	if !equalApproxMat(sq, &want, tol) {
+		t.Errorf("unexpected result of Add with aliased transpose")
+	}
+}
+
+func TestDenseMul(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, k, n int
+	}{
+		{1, 1, 1},
+		{3, 4, 5},
+		{10, 7, 3},
+		{50, 40, 30},
+	} {
+		m, k, n := test.m, test.k, test.n
+		a := randDense(m, k, rnd)
+		b := randDense(k, n, rnd)
+		var want mat.Dense
+		want.Mul(toMat(a), toMat(b))
+
+		var got Dense
+		got.Mul(a, b)
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("m=%d,k=%d,n=%d: unexpected result of Mul", m, k, n)
+		}
+
+		// Transposed operands.
+		at := DenseCopyOf(a.T())
+		bt := DenseCopyOf(b.T())
+		got.Reset()
+		got.Mul(at.T(), bt.T())
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("m=%d,k=%d,n=%d: unexpected result of Mul with transposes", m, k, n)
+		}
+
+		// Operands that are not RawMatrixers.
+		tri := NewTriDense(k, Upper, nil)
+		tri.Copy(randDense(k, k, rnd))
+		want.Reset()
+		want.Mul(toMat(a), toMat(tri))
+		got.Reset()
+		got.Mul(a, tri)
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("m=%d,k=%d,n=%d: unexpected result of Mul with triangular", m, k, n)
+		}
+	}
+
+	// Aliased receiver.
+	a := randDense(5, 5, rnd)
+	var want mat.Dense
+	want.Mul(toMat(a), toMat(a).T())
+	a.Mul(a, a.T())
+	if !equalApproxMat(a, &want, tol) {
+		t.Errorf("unexpected result of aliased Mul")
+	}
+
+	if !panics(func() {
+		var c Dense
+		c.Mul(NewDense(2, 3, nil), NewDense(2, 3, nil))
+	}) {
+		t.Errorf("expected panic for mismatched shapes")
+	}
+}
+
+func TestDenseOuter(t *testing.T) {
+	x := NewVecDense(3, []float32{1, 2, 3})
+	y := NewVecDense(2, []float32{4, 5})
+	var got Dense
+	got.Outer(2, x, y)
+	want := NewDense(3, 2, []float32{
+		8, 10,
+		16, 20,
+		24, 30,
+	})
+	if !Equal(&got, want) {
+		t.Errorf("unexpected result of Outer: got %v want %v", got.mat.Data, want.mat.Data)
+	}
+	got.RankOne(&got, -2, x, y)
+	if !Equal(&got, NewDense(3, 2, nil)) {
+		t.Errorf("unexpected result of RankOne: got %v", got.mat.Data)
+	}
+}
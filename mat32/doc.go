@@ -0,0 +1,35 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mat32 provides implementations of float32 matrix structures and
+// linear algebra operations on them.
+//
+// mat32 mirrors a subset of the mat package for single precision data. It
+// is intended for memory-bound work, such as operating on large embedding
+// matrices, where the halved storage and bandwidth of float32 outweigh the
+// loss of precision. Matrix operations are performed through the blas32
+// package and factorizations through the lapack32 package, so the backing
+// implementations can be changed with blas32.Use and lapack32.Use.
+//
+// mat32 provides:
+//  - Interfaces for Matrix classes (Matrix, Vector, Symmetric, Triangular)
+//  - Concrete implementations (Dense, VecDense, SymDense, TriDense)
+//  - Methods and functions for using matrix data (Mul, Add, Dot, SymOuterK)
+//  - Types for constructing and using matrix factorizations (Cholesky, LU, QR)
+//
+// The conventions of the mat package apply. Matrices are stored in row-major
+// format and are constructed through the corresponding New function.
+//  // Allocate a zeroed float32 matrix of size 3×5
+//  zero := mat32.NewDense(3, 5, nil)
+// Receivers must be the correct size for the matrix operations, otherwise the
+// operation will panic. As a special case for convenience, a zero-value matrix
+// will be modified to have the correct size, allocating data if necessary.
+//  var c mat32.Dense     // construct a new zero-sized matrix
+//  c.Mul(zero, zero.T()) // c is automatically adjusted to be 3×3
+//
+// Single precision arithmetic has a machine epsilon of about 6e-8, so the
+// results of mat32 operations are accurate to roughly seven significant
+// digits, and factorizations of ill-conditioned matrices may lose all
+// accuracy. Tolerances used with EqualApprox should be chosen accordingly.
+package mat32 // import "gonum.org/v1/gonum/mat32"
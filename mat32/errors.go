@@ -0,0 +1,38 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import "fmt"
+
+// Condition is the condition number of a matrix. The condition
+// number is defined as |A| * |A^-1|.
+//
+// A Condition error is returned by the solve routines of the factorization
+// types when the factorized matrix is exactly singular to working precision,
+// in which case Condition == ∞.
+type Condition float64
+
+func (c Condition) Error() string {
+	return fmt.Sprintf("matrix singular or near-singular with condition number %.4e", c)
+}
+
+// Error represents matrix handling errors.
+type Error struct{ string }
+
+func (err Error) Error() string { return err.string }
+
+var (
+	ErrIndexOutOfRange     = Error{"matrix: index out of range"}
+	ErrRowAccess           = Error{"matrix: row index out of range"}
+	ErrColAccess           = Error{"matrix: column index out of range"}
+	ErrVectorAccess        = Error{"matrix: vector index out of range"}
+	ErrZeroLength          = Error{"matrix: zero length in matrix definition"}
+	ErrSquare              = Error{"matrix: expect square matrix"}
+	ErrNormOrder           = Error{"matrix: invalid norm order for matrix"}
+	ErrShape               = Error{"matrix: dimension mismatch"}
+	ErrTriangle            = Error{"matrix: triangular storage mismatch"}
+	ErrTriangleSet         = Error{"matrix: triangular set out of bounds"}
+	ErrSliceLengthMismatch = Error{"matrix: input slice length mismatch"}
+)
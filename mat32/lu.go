@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+const (
+	badSliceLength = "mat32: improper slice length"
+	badLU          = "mat32: no LU decomposition computed"
+)
+
+// LU is a type for creating and using the LU factorization of a matrix.
+type LU struct {
+	lu       *Dense
+	pivot    []int
+	singular bool
+}
+
+// Factorize computes the LU factorization of the square matrix a and stores the
+// result. The LU decomposition will complete regardless of the singularity of a.
+//
+// The LU factorization is computed with pivoting, and so really the decomposition
+// is a PLU decomposition where P is a permutation matrix. The individual matrix
+// factors can be extracted from the factorization using the Permutation method
+// on Dense, and the LU LTo and UTo methods.
+func (lu *LU) Factorize(a Matrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if lu.lu == nil {
+		lu.lu = NewDense(r, r, nil)
+	} else {
+		lu.lu.Reset()
+		lu.lu.reuseAs(r, r)
+	}
+	lu.lu.Copy(a)
+	lu.pivot = useInt(lu.pivot, r)
+	lu.singular = !lapack32.Getrf(lu.lu.mat, lu.pivot)
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *LU) Reset() {
+	if lu.lu != nil {
+		lu.lu.Reset()
+	}
+	lu.pivot = lu.pivot[:0]
+}
+
+func (lu *LU) isZero() bool {
+	return len(lu.pivot) == 0
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+func (lu *LU) Det() float32 {
+	det, sign := lu.LogDet()
+	return float32(math.Exp(float64(det))) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+func (lu *LU) LogDet() (det float32, sign float32) {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	sign = 1
+	// Accumulate in float64 to avoid losing the
+	// contribution of the smaller diagonal elements.
+	var logDet float64
+	for i := 0; i < n; i++ {
+		v := lu.lu.at(i, i)
+		if v < 0 {
+			sign *= -1
+		}
+		if lu.pivot[i] != i {
+			sign *= -1
+		}
+		logDet += math.Log(math.Abs(float64(v)))
+	}
+	return float32(logDet), sign
+}
+
+// Pivot returns pivot indices that enable the construction of the permutation
+// matrix P (see Dense.Permutation). If swaps == nil, then new memory will be
+// allocated, otherwise the length of the input must be equal to the size of the
+// factorized matrix.
+func (lu *LU) Pivot(swaps []int) []int {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	if swaps == nil {
+		swaps = make([]int, n)
+	}
+	if len(swaps) != n {
+		panic(badSliceLength)
+	}
+	// Perform the inverse of the row swaps in order to find the final
+	// row swap position.
+	for i := range swaps {
+		swaps[i] = i
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := lu.pivot[i]
+		swaps[i], swaps[v] = swaps[v], swaps[i]
+	}
+	return swaps
+}
+
+// LTo extracts the lower triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting L matrix is returned.
+func (lu *LU) LTo(dst *TriDense) *TriDense {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewTriDense(n, Lower, nil)
+	} else {
+		dst.reuseAs(n, Lower)
+	}
+	// Extract the lower triangular elements.
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	// Set ones on the diagonal.
+	for i := 0; i < n; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+	return dst
+}
+
+// UTo extracts the upper triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting U matrix is returned.
+func (lu *LU) UTo(dst *TriDense) *TriDense {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewTriDense(n, Upper, nil)
+	} else {
+		dst.reuseAs(n, Upper)
+	}
+	// Extract the upper triangular elements.
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// Permutation constructs an r×r permutation matrix with the given row swaps.
+// A permutation matrix has exactly one element equal to one in each row and column
+// and all other elements equal to zero. swaps[i] specifies the row with which
+// i will be swapped, which is equivalent to the non-zero column of row i.
+func (m *Dense) Permutation(r int, swaps []int) {
+	m.reuseAs(r, r)
+	for i := 0; i < r; i++ {
+		zero(m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+r])
+		v := swaps[i]
+		if v < 0 || v >= r {
+			panic(ErrRowAccess)
+		}
+		m.mat.Data[i*m.mat.Stride+v] = 1
+	}
+}
+
+// Solve solves a system of linear equations using the LU decomposition of a matrix.
+// It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into m.
+//
+// If A is exactly singular, m is not modified and a Condition error is
+// returned. The condition number of A is not estimated, so the solution of a
+// near-singular system may be inaccurate without an error being returned.
+func (lu *LU) Solve(m *Dense, trans bool, b Matrix) error {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if lu.singular {
+		return Condition(math.Inf(1))
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	if m == bU {
+		var restore func()
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	}
+
+	m.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack32.Getrs(t, lu.lu.mat, m.mat, lu.pivot)
+	return nil
+}
+
+// SolveVec solves a system of linear equations using the LU decomposition of a matrix.
+// It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into v.
+//
+// Please see LU.Solve for the full documentation.
+func (lu *LU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	if lu.isZero() {
+		panic(badLU)
+	}
+	_, n := lu.lu.Dims()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if lu.singular {
+		return Condition(math.Inf(1))
+	}
+	v.reuseAs(n)
+	return lu.Solve(v.asDense(), trans, b)
+}
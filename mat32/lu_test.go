@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLU(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 50} {
+		a := randDense(n, n, rnd)
+		for i := 0; i < n; i++ {
+			a.Set(i, i, a.At(i, i)+float32(n))
+		}
+		a64 := toMat(a)
+
+		var lu LU
+		lu.Factorize(a)
+
+		// Check that P * L * U = A.
+		var p Dense
+		p.Permutation(n, lu.Pivot(nil))
+		l := lu.LTo(nil)
+		u := lu.UTo(nil)
+		var got Dense
+		got.Mul(l, u)
+		got.Mul(&p, &got)
+		if !EqualApprox(&got, a, tol) {
+			t.Errorf("n=%d: P*L*U != A", n)
+		}
+
+		var lu64 mat.LU
+		lu64.Factorize(a64)
+		det, sign := lu.LogDet()
+		det64, sign64 := lu64.LogDet()
+		if float64(sign) != sign64 || math.Abs(float64(det)-det64) > tol*math.Abs(det64) {
+			t.Errorf("n=%d: unexpected log determinant: got %v,%v want %v,%v", n, det, sign, det64, sign64)
+		}
+
+		for _, trans := range []bool{false, true} {
+			b := randDense(n, 3, rnd)
+			var x Dense
+			if err := lu.Solve(&x, trans, b); err != nil {
+				t.Errorf("n=%d,trans=%t: unexpected error: %v", n, trans, err)
+			}
+			var want mat.Dense
+			if trans {
+				want.Solve(a64.T(), toMat(b))
+			} else {
+				want.Solve(a64, toMat(b))
+			}
+			if !equalApproxMat(&x, &want, tol) {
+				t.Errorf("n=%d,trans=%t: unexpected solution", n, trans)
+			}
+
+			bv := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				bv.SetVec(i, b.At(i, 0))
+			}
+			if err := lu.SolveVec(bv, trans, bv); err != nil {
+				t.Errorf("n=%d,trans=%t: unexpected error: %v", n, trans, err)
+			}
+			if !EqualApprox(bv, x.ColView(0), tol) {
+				t.Errorf("n=%d,trans=%t: unexpected in-place vector solution", n, trans)
+			}
+		}
+	}
+
+	a := NewDense(2, 2, []float32{
+		1, 2,
+		2, 4,
+	})
+	var lu LU
+	lu.Factorize(a)
+	var x Dense
+	err := lu.Solve(&x, false, NewDense(2, 1, nil))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("expected Condition error for singular matrix, got %v", err)
+	}
+	if !x.IsZero() {
+		t.Errorf("unexpected modification of receiver for singular matrix")
+	}
+}
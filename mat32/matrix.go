@@ -0,0 +1,352 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/internal/math32"
+)
+
+// Matrix is the basic matrix interface type.
+type Matrix interface {
+	// Dims returns the dimensions of a Matrix.
+	Dims() (r, c int)
+
+	// At returns the value of a matrix element at row i, column j.
+	// It will panic if i or j are out of bounds for the matrix.
+	At(i, j int) float32
+
+	// T returns the transpose of the Matrix. Whether T returns a copy of the
+	// underlying data is implementation dependent.
+	// This method may be implemented using the Transpose type, which
+	// provides an implicit matrix transpose.
+	T() Matrix
+}
+
+var (
+	_ Matrix       = Transpose{}
+	_ Untransposer = Transpose{}
+)
+
+// Transpose is a type for performing an implicit matrix transpose. It implements
+// the Matrix interface, returning values from the transpose of the matrix within.
+type Transpose struct {
+	Matrix Matrix
+}
+
+// At returns the value of the element at row i and column j of the transposed
+// matrix, that is, row j and column i of the Matrix field.
+func (t Transpose) At(i, j int) float32 {
+	return t.Matrix.At(j, i)
+}
+
+// Dims returns the dimensions of the transposed matrix. The number of rows returned
+// is the number of columns in the Matrix field, and the number of columns is
+// the number of rows in the Matrix field.
+func (t Transpose) Dims() (r, c int) {
+	c, r = t.Matrix.Dims()
+	return r, c
+}
+
+// T performs an implicit transpose by returning the Matrix field.
+func (t Transpose) T() Matrix {
+	return t.Matrix
+}
+
+// Untranspose returns the Matrix field.
+func (t Transpose) Untranspose() Matrix {
+	return t.Matrix
+}
+
+// Untransposer is a type that can undo an implicit transpose.
+type Untransposer interface {
+	// Untranspose returns the underlying Matrix stored for the implicit transpose.
+	Untranspose() Matrix
+}
+
+// Mutable is a matrix interface type that allows elements to be altered.
+type Mutable interface {
+	// Set alters the matrix element at row i, column j to v.
+	// It will panic if i or j are out of bounds for the matrix.
+	Set(i, j int, v float32)
+
+	Matrix
+}
+
+// A RawMatrixer can return a blas32.General representation of the receiver. Changes to the blas32.General.Data
+// slice will be reflected in the original matrix, changes to the Rows, Cols and Stride fields will not.
+type RawMatrixer interface {
+	RawMatrix() blas32.General
+}
+
+// A RawVectorer can return a blas32.Vector representation of the receiver. Changes to the blas32.Vector.Data
+// slice will be reflected in the original matrix, changes to the Inc field will not.
+type RawVectorer interface {
+	RawVector() blas32.Vector
+}
+
+// untranspose untransposes a matrix if applicable. If a is an Untransposer, then
+// untranspose returns the underlying matrix and true. If it is not, then it returns
+// the input matrix and false.
+func untranspose(a Matrix) (Matrix, bool) {
+	if ut, ok := a.(Untransposer); ok {
+		return ut.Untranspose(), true
+	}
+	return a, false
+}
+
+// Dot returns the sum of the element-wise product of a and b.
+// Dot panics if the matrix sizes are unequal.
+func Dot(a, b Vector) float32 {
+	la := a.Len()
+	lb := b.Len()
+	if la != lb {
+		panic(ErrShape)
+	}
+	if arv, ok := a.(RawVectorer); ok {
+		if brv, ok := b.(RawVectorer); ok {
+			return blas32.Dot(la, arv.RawVector(), brv.RawVector())
+		}
+	}
+	var sum float32
+	for i := 0; i < la; i++ {
+		sum += a.At(i, 0) * b.At(i, 0)
+	}
+	return sum
+}
+
+// Equal returns whether the matrices a and b have the same size
+// and are element-wise equal.
+func Equal(a, b Matrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if a.At(i, j) != b.At(i, j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EqualApprox returns whether the matrices a and b have the same size and contain all equal
+// elements with tolerance for element-wise equality specified by epsilon. Matrices
+// with non-equal shapes are not equal.
+func EqualApprox(a, b Matrix, epsilon float32) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if !equalWithinAbsOrRel(a.At(i, j), b.At(i, j), epsilon, epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalWithinAbsOrRel returns true if a and b are equal to within
+// the absolute tolerance absTol or the relative tolerance relTol.
+func equalWithinAbsOrRel(a, b, absTol, relTol float32) bool {
+	if a == b {
+		return true
+	}
+	delta := math32.Abs(a - b)
+	if delta <= absTol {
+		return true
+	}
+	// Rely on the division to identify infinities and
+	// on the comparison with NaN to fail.
+	max := math32.Abs(a)
+	if v := math32.Abs(b); v > max {
+		max = v
+	}
+	return delta/max <= relTol
+}
+
+// Max returns the largest element value of the matrix A.
+// Max will panic with ErrShape if the matrix has zero size.
+func Max(a Matrix) float32 {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	max := a.At(0, 0)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if v := a.At(i, j); v > max {
+				max = v
+			}
+		}
+	}
+	return max
+}
+
+// Min returns the smallest element value of the matrix A.
+// Min will panic with ErrShape if the matrix has zero size.
+func Min(a Matrix) float32 {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	min := a.At(0, 0)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if v := a.At(i, j); v < min {
+				min = v
+			}
+		}
+	}
+	return min
+}
+
+// Norm returns the specified norm of the matrix A. Valid norms are:
+//    1 - The maximum absolute column sum
+//    2 - Frobenius norm, the square root of the sum of the squares of the elements.
+//  Inf - The maximum absolute row sum.
+// Norm will panic with ErrNormOrder if an illegal norm order is specified and
+// with ErrShape if the matrix has zero size.
+func Norm(a Matrix, norm float64) float32 {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	switch norm {
+	default:
+		panic(ErrNormOrder)
+	case 1:
+		var max float32
+		for j := 0; j < c; j++ {
+			var sum float32
+			for i := 0; i < r; i++ {
+				sum += math32.Abs(a.At(i, j))
+			}
+			if sum > max {
+				max = sum
+			}
+		}
+		return max
+	case 2:
+		if rv, ok := a.(RawVectorer); ok {
+			return blas32.Nrm2(r*c, rv.RawVector())
+		}
+		// Scale the sum of squares to avoid overflow, which
+		// happens for elements as small as about 1e19.
+		var scale, ssq float32 = 0, 1
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				v := math32.Abs(a.At(i, j))
+				if v == 0 {
+					continue
+				}
+				if scale < v {
+					ssq = 1 + ssq*(scale/v)*(scale/v)
+					scale = v
+				} else {
+					ssq += (v / scale) * (v / scale)
+				}
+			}
+		}
+		return scale * math32.Sqrt(ssq)
+	case math.Inf(1):
+		var max float32
+		for i := 0; i < r; i++ {
+			var sum float32
+			for j := 0; j < c; j++ {
+				sum += math32.Abs(a.At(i, j))
+			}
+			if sum > max {
+				max = sum
+			}
+		}
+		return max
+	}
+}
+
+// Sum returns the sum of the elements of the matrix.
+func Sum(a Matrix) float32 {
+	r, c := a.Dims()
+	var sum float32
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			sum += a.At(i, j)
+		}
+	}
+	return sum
+}
+
+// Trace returns the trace of the matrix. Trace will panic if the
+// matrix is not square.
+func Trace(a Matrix) float32 {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	var t float32
+	for i := 0; i < r; i++ {
+		t += a.At(i, i)
+	}
+	return t
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// use returns a float32 slice with l elements, using f if it
+// has the necessary capacity, otherwise creating a new slice.
+func use(f []float32, l int) []float32 {
+	if l <= cap(f) {
+		return f[:l]
+	}
+	return make([]float32, l)
+}
+
+// useZeroed returns a float32 slice with l elements, using f if it
+// has the necessary capacity, otherwise creating a new slice. The
+// elements of the returned slice are guaranteed to be zero.
+func useZeroed(f []float32, l int) []float32 {
+	if l <= cap(f) {
+		f = f[:l]
+		zero(f)
+		return f
+	}
+	return make([]float32, l)
+}
+
+// zero zeros the given slice's elements.
+func zero(f []float32) {
+	for i := range f {
+		f[i] = 0
+	}
+}
+
+// useInt returns an int slice with l elements, using i if it
+// has the necessary capacity, otherwise creating a new slice.
+func useInt(i []int, l int) []int {
+	if l <= cap(i) {
+		return i[:l]
+	}
+	return make([]int, l)
+}
@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randDense returns a new r×c Dense filled with normally distributed values.
+func randDense(r, c int, rnd *rand.Rand) *Dense {
+	d := NewDense(r, c, nil)
+	for i := range d.mat.Data {
+		d.mat.Data[i] = float32(rnd.NormFloat64())
+	}
+	return d
+}
+
+// toMat returns a float64 copy of the float32 matrix a.
+func toMat(a Matrix) *mat.Dense {
+	r, c := a.Dims()
+	d := mat.NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			d.Set(i, j, float64(a.At(i, j)))
+		}
+	}
+	return d
+}
+
+// equalApproxMat returns whether the float32 matrix a is element-wise
+// equal to the float64 matrix b within the tolerance tol.
+func equalApproxMat(a Matrix, b mat.Matrix, tol float64) bool {
+	return mat.EqualApprox(toMat(a), b, tol)
+}
+
+func TestNorm(t *testing.T) {
+	a := NewDense(3, 2, []float32{
+		1, -2,
+		3, 4,
+		-5, 6,
+	})
+	for _, test := range []struct {
+		norm float64
+		want float32
+	}{
+		{1, 12},
+		{2, float32(math.Sqrt(91))},
+		{math.Inf(1), 11},
+	} {
+		if got := Norm(a, test.norm); !equalWithinAbsOrRel(got, test.want, 1e-6, 1e-6) {
+			t.Errorf("unexpected norm %v: got %v want %v", test.norm, got, test.want)
+		}
+		// The norm of the transpose swaps the 1 and ∞ norms.
+		want := test.want
+		switch test.norm {
+		case 1:
+			want = 11
+		case math.Inf(1):
+			want = 12
+		}
+		if got := Norm(a.T(), test.norm); !equalWithinAbsOrRel(got, want, 1e-6, 1e-6) {
+			t.Errorf("unexpected norm %v of transpose: got %v want %v", test.norm, got, want)
+		}
+	}
+	v := NewVecDense(2, []float32{3, 4})
+	if got := Norm(v, 2); got != 5 {
+		t.Errorf("unexpected vector norm: got %v want 5", got)
+	}
+	big := NewDense(1, 2, []float32{3e20, 4e20})
+	if got := Norm(big, 2); math.Abs(float64(got)-5e20) > 1e14 {
+		t.Errorf("unexpected norm of large matrix: got %v want 5e20", got)
+	}
+}
+
+func TestMaxMinSumTrace(t *testing.T) {
+	a := NewDense(3, 3, []float32{
+		1, -2, 3,
+		4, 5, -6,
+		7, -8, 9,
+	})
+	if got := Max(a); got != 9 {
+		t.Errorf("unexpected max: got %v want 9", got)
+	}
+	if got := Min(a); got != -8 {
+		t.Errorf("unexpected min: got %v want -8", got)
+	}
+	if got := Sum(a); got != 13 {
+		t.Errorf("unexpected sum: got %v want 13", got)
+	}
+	if got := Trace(a); got != 15 {
+		t.Errorf("unexpected trace: got %v want 15", got)
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	a := NewDense(2, 2, []float32{1, 2, 3, 4})
+	b := NewDense(2, 2, []float32{1, 2, 3, 4.0001})
+	if Equal(a, b) {
+		t.Errorf("unexpected equality")
+	}
+	if !EqualApprox(a, b, 1e-3) {
+		t.Errorf("unexpected inequality within tolerance")
+	}
+	if EqualApprox(a, b, 1e-6) {
+		t.Errorf("unexpected equality outside tolerance")
+	}
+	if EqualApprox(a, a.Slice(0, 1, 0, 2), 1) {
+		t.Errorf("unexpected equality of matrices with different shapes")
+	}
+	if !Equal(a.T(), NewDense(2, 2, []float32{1, 3, 2, 4})) {
+		t.Errorf("unexpected inequality of transpose")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestQR(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{5, 5},
+		{10, 5},
+		{50, 30},
+	} {
+		m, n := test.m, test.n
+		a := randDense(m, n, rnd)
+
+		var qr QR
+		qr.Factorize(a)
+		q := qr.QTo(nil)
+		r := qr.RTo(nil)
+
+		var qtq Dense
+		qtq.Mul(q.T(), q)
+		eye := NewDense(m, m, nil)
+		for i := 0; i < m; i++ {
+			eye.Set(i, i, 1)
+		}
+		if !EqualApprox(&qtq, eye, tol) {
+			t.Errorf("m=%d,n=%d: Q is not orthonormal", m, n)
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("m=%d,n=%d: R is not upper triangular", m, n)
+				}
+			}
+		}
+		var got Dense
+		got.Mul(q, r)
+		if !EqualApprox(&got, a, tol) {
+			t.Errorf("m=%d,n=%d: Q*R != A", m, n)
+		}
+
+		// Check the least squares solution against the float64 result.
+		a64 := toMat(a)
+		var qr64 mat.QR
+		qr64.Factorize(a64)
+		b := randDense(m, 2, rnd)
+		var x Dense
+		if err := qr.Solve(&x, false, b); err != nil {
+			t.Errorf("m=%d,n=%d: unexpected error: %v", m, n, err)
+		}
+		var want mat.Dense
+		qr64.Solve(&want, false, toMat(b))
+		if !equalApproxMat(&x, &want, tol) {
+			t.Errorf("m=%d,n=%d: unexpected least squares solution", m, n)
+		}
+
+		// Check the minimum norm solution of the transposed problem.
+		bt := randDense(n, 2, rnd)
+		x.Reset()
+		if err := qr.Solve(&x, true, bt); err != nil {
+			t.Errorf("m=%d,n=%d: unexpected error: %v", m, n, err)
+		}
+		want.Reset()
+		qr64.Solve(&want, true, toMat(bt))
+		if !equalApproxMat(&x, &want, tol) {
+			t.Errorf("m=%d,n=%d: unexpected minimum norm solution", m, n)
+		}
+
+		bv := NewVecDense(m, nil)
+		for i := 0; i < m; i++ {
+			bv.SetVec(i, b.At(i, 1))
+		}
+		var xv VecDense
+		if err := qr.SolveVec(&xv, false, bv); err != nil {
+			t.Errorf("m=%d,n=%d: unexpected error: %v", m, n, err)
+		}
+		x.Reset()
+		qr.Solve(&x, false, b)
+		if !EqualApprox(&xv, x.ColView(1), tol) {
+			t.Errorf("m=%d,n=%d: unexpected vector solution", m, n)
+		}
+	}
+}
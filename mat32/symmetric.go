@@ -0,0 +1,333 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+var (
+	symDense *SymDense
+
+	_ Matrix           = symDense
+	_ Symmetric        = symDense
+	_ RawSymmetricer   = symDense
+	_ MutableSymmetric = symDense
+)
+
+const (
+	badSymTriangle = "mat32: blas32.Symmetric not upper"
+	badSymCap      = "mat32: bad capacity for SymDense"
+)
+
+// SymDense is a symmetric matrix that uses dense storage. SymDense
+// matrices are stored in the upper triangle.
+type SymDense struct {
+	mat blas32.Symmetric
+	cap int
+}
+
+// Symmetric represents a symmetric matrix (where the element at {i, j} equals
+// the element at {j, i}). Symmetric matrices are always square.
+type Symmetric interface {
+	Matrix
+	// Symmetric returns the number of rows/columns in the matrix.
+	Symmetric() int
+}
+
+// A RawSymmetricer can return a view of itself as a BLAS Symmetric matrix.
+type RawSymmetricer interface {
+	RawSymmetric() blas32.Symmetric
+}
+
+// A MutableSymmetric can set elements of a symmetric matrix.
+type MutableSymmetric interface {
+	Symmetric
+	SetSym(i, j int, v float32)
+}
+
+// NewSymDense creates a new Symmetric matrix with n rows and columns. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n*n, data is
+// used as the backing slice, and changes to the elements of the returned SymDense
+// will be reflected in data. If neither of these is true, NewSymDense will panic.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+// Only the values in the upper triangular portion of the matrix are used.
+func NewSymDense(n int, data []float32) *SymDense {
+	if n < 0 {
+		panic("mat32: negative dimension")
+	}
+	if data != nil && n*n != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n*n)
+	}
+	return &SymDense{
+		mat: blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   data,
+			Uplo:   blas.Upper,
+		},
+		cap: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (s *SymDense) Dims() (r, c int) {
+	return s.mat.N, s.mat.N
+}
+
+// T implements the Matrix interface. Symmetric matrices, by definition, are
+// equal to their transpose, and this is a no-op.
+func (s *SymDense) T() Matrix {
+	return s
+}
+
+// Symmetric returns the number of rows/columns in the matrix.
+func (s *SymDense) Symmetric() int {
+	return s.mat.N
+}
+
+// RawSymmetric returns the matrix as a blas32.Symmetric. The returned
+// value must be stored in upper triangular format.
+func (s *SymDense) RawSymmetric() blas32.Symmetric {
+	return s.mat
+}
+
+// SetRawSymmetric sets the underlying blas32.Symmetric used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in b. SetRawSymmetric will panic if b is not an upper-encoded symmetric
+// matrix.
+func (s *SymDense) SetRawSymmetric(b blas32.Symmetric) {
+	if b.Uplo != blas.Upper {
+		panic(badSymTriangle)
+	}
+	s.mat = b
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+func (s *SymDense) Reset() {
+	// N and Stride must be zeroed in unison.
+	s.mat.N, s.mat.Stride = 0, 0
+	s.mat.Data = s.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. SymDense matrices can be zeroed using Reset.
+func (s *SymDense) IsZero() bool {
+	// It must be the case that m.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return s.mat.N == 0
+}
+
+// reuseAs resizes an empty matrix to a n×n matrix,
+// or checks that a non-empty matrix is n×n.
+func (s *SymDense) reuseAs(n int) {
+	if s.mat.N > s.cap {
+		panic(badSymCap)
+	}
+	if s.IsZero() {
+		s.mat = blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   use(s.mat.Data, n*n),
+			Uplo:   blas.Upper,
+		}
+		s.cap = n
+		return
+	}
+	if s.mat.Uplo != blas.Upper {
+		panic(badSymTriangle)
+	}
+	if s.mat.N != n {
+		panic(ErrShape)
+	}
+}
+
+// At returns the element at row i, column j.
+func (s *SymDense) At(i, j int) float32 {
+	if uint(i) >= uint(s.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(ErrColAccess)
+	}
+	if i > j {
+		i, j = j, i
+	}
+	return s.mat.Data[i*s.mat.Stride+j]
+}
+
+// SetSym sets the elements at (i,j) and (j,i) to the value v.
+func (s *SymDense) SetSym(i, j int, v float32) {
+	if uint(i) >= uint(s.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(ErrColAccess)
+	}
+	if i > j {
+		i, j = j, i
+	}
+	s.mat.Data[i*s.mat.Stride+j] = v
+}
+
+// AddSym adds the symmetric matrices a and b, placing the result in the receiver.
+func (s *SymDense) AddSym(a, b Symmetric) {
+	n := a.Symmetric()
+	if n != b.Symmetric() {
+		panic(ErrShape)
+	}
+	s.reuseAs(n)
+
+	if a, ok := a.(RawSymmetricer); ok {
+		if b, ok := b.(RawSymmetricer); ok {
+			amat, bmat := a.RawSymmetric(), b.RawSymmetric()
+			for i := 0; i < n; i++ {
+				btmp := bmat.Data[i*bmat.Stride+i : i*bmat.Stride+n]
+				stmp := s.mat.Data[i*s.mat.Stride+i : i*s.mat.Stride+n]
+				for j, v := range amat.Data[i*amat.Stride+i : i*amat.Stride+n] {
+					stmp[j] = v + btmp[j]
+				}
+			}
+			return
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		stmp := s.mat.Data[i*s.mat.Stride : i*s.mat.Stride+n]
+		for j := i; j < n; j++ {
+			stmp[j] = a.At(i, j) + b.At(i, j)
+		}
+	}
+}
+
+// CopySym makes a copy of elements of a into the receiver. It is similar to
+// the built-in copy; it copies as much as the overlap between the two matrices
+// and returns the number of rows and columns it copied.
+func (s *SymDense) CopySym(a Symmetric) int {
+	n := a.Symmetric()
+	n = min(n, s.mat.N)
+	if n == 0 {
+		return 0
+	}
+	switch a := a.(type) {
+	case RawSymmetricer:
+		amat := a.RawSymmetric()
+		if amat.Uplo != blas.Upper {
+			panic(badSymTriangle)
+		}
+		for i := 0; i < n; i++ {
+			copy(s.mat.Data[i*s.mat.Stride+i:i*s.mat.Stride+n], amat.Data[i*amat.Stride+i:i*amat.Stride+n])
+		}
+	default:
+		for i := 0; i < n; i++ {
+			stmp := s.mat.Data[i*s.mat.Stride : i*s.mat.Stride+n]
+			for j := i; j < n; j++ {
+				stmp[j] = a.At(i, j)
+			}
+		}
+	}
+	return n
+}
+
+// SymRankOne performs a symetric rank-one update to the matrix a and stores
+// the result in the receiver
+//  s = a + alpha * x * x'
+func (s *SymDense) SymRankOne(a Symmetric, alpha float32, x *VecDense) {
+	n := x.Len()
+	if a.Symmetric() != n {
+		panic(ErrShape)
+	}
+	s.reuseAs(n)
+	if s != a {
+		s.CopySym(a)
+	}
+	blas32.Syr(alpha, x.mat, s.mat)
+}
+
+// SymRankK performs a symmetric rank-k update to the matrix a and stores the
+// result into the receiver. If a is zero, see SymOuterK.
+//  s = a + alpha * x * x'
+func (s *SymDense) SymRankK(a Symmetric, alpha float32, x Matrix) {
+	n := a.Symmetric()
+	r, _ := x.Dims()
+	if r != n {
+		panic(ErrShape)
+	}
+	xMat, aTrans := untranspose(x)
+	g, t := rawGeneral(xMat, aTrans)
+	if xMat == Matrix(s) {
+		// x will be overwritten by the update.
+		g = DenseCopyOf(xMat).mat
+	}
+	if a != s {
+		s.reuseAs(n)
+		s.CopySym(a)
+	}
+	blas32.Syrk(t, alpha, g, 1, s.mat)
+}
+
+// SymOuterK calculates the outer product of x with itself and stores
+// the result into the receiver. It is equivalent to the matrix
+// multiplication
+//  s = alpha * x * x'.
+// In order to update an existing matrix, see SymRankOne.
+func (s *SymDense) SymOuterK(alpha float32, x Matrix) {
+	n, _ := x.Dims()
+	switch {
+	case s.IsZero():
+		s.mat = blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   useZeroed(s.mat.Data, n*n),
+			Uplo:   blas.Upper,
+		}
+		s.cap = n
+		s.SymRankK(s, alpha, x)
+	case s.mat.Uplo != blas.Upper:
+		panic(badSymTriangle)
+	case s.mat.N == n:
+		if s == x {
+			w := NewSymDense(n, nil)
+			w.SymRankK(w, alpha, x)
+			s.CopySym(w)
+			return
+		}
+		// Only zero the upper triangle.
+		for i := 0; i < n; i++ {
+			ri := i * s.mat.Stride
+			zero(s.mat.Data[ri+i : ri+n])
+		}
+		s.SymRankK(s, alpha, x)
+	default:
+		panic(ErrShape)
+	}
+}
+
+// ScaleSym multiplies the elements of a by f, placing the result in the receiver.
+func (s *SymDense) ScaleSym(f float32, a Symmetric) {
+	n := a.Symmetric()
+	s.reuseAs(n)
+	if a, ok := a.(RawSymmetricer); ok {
+		amat := a.RawSymmetric()
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				s.mat.Data[i*s.mat.Stride+j] = f * amat.Data[i*amat.Stride+j]
+			}
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.mat.Data[i*s.mat.Stride+j] = f * a.At(i, j)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSymDense(t *testing.T) {
+	s := NewSymDense(3, []float32{
+		1, 2, 3,
+		0, 4, 5,
+		0, 0, 6,
+	})
+	if s.At(2, 0) != 3 || s.At(0, 2) != 3 {
+		t.Errorf("unexpected symmetric element")
+	}
+	s.SetSym(2, 1, -5)
+	if s.At(1, 2) != -5 {
+		t.Errorf("unexpected element after SetSym")
+	}
+	var c SymDense
+	c.AddSym(s, s)
+	c.ScaleSym(0.5, &c)
+	if !Equal(&c, s) {
+		t.Errorf("unexpected result of AddSym and ScaleSym")
+	}
+}
+
+func TestSymOuterK(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, k int
+	}{
+		{1, 1},
+		{3, 5},
+		{10, 4},
+		{50, 30},
+	} {
+		n, k := test.n, test.k
+		x := randDense(n, k, rnd)
+		var want mat.Dense
+		want.Mul(toMat(x), toMat(x).T())
+		want.Scale(0.5, &want)
+
+		var s SymDense
+		s.SymOuterK(0.5, x)
+		if !equalApproxMat(&s, &want, tol) {
+			t.Errorf("n=%d,k=%d: unexpected result of SymOuterK", n, k)
+		}
+
+		// A second call on a non-zero receiver must
+		// overwrite the previous result.
+		s.SymOuterK(0.5, x)
+		if !equalApproxMat(&s, &want, tol) {
+			t.Errorf("n=%d,k=%d: unexpected result of SymOuterK with reused receiver", n, k)
+		}
+
+		v := NewVecDense(n, nil)
+		for i := range v.mat.Data {
+			v.mat.Data[i] = float32(rnd.NormFloat64())
+		}
+		vv := toMat(v)
+		var outer mat.Dense
+		outer.Mul(vv, vv.T())
+		outer.Scale(2, &outer)
+		want.Add(&want, &outer)
+		s.SymRankOne(&s, 2, v)
+		if !equalApproxMat(&s, &want, tol) {
+			t.Errorf("n=%d,k=%d: unexpected result of SymRankOne", n, k)
+		}
+	}
+
+	// Aliased receiver.
+	s := NewSymDense(4, nil)
+	for i := 0; i < 4; i++ {
+		for j := i; j < 4; j++ {
+			s.SetSym(i, j, float32(rnd.NormFloat64()))
+		}
+	}
+	var want mat.Dense
+	want.Mul(toMat(s), toMat(s))
+	s.SymOuterK(1, s)
+	if !equalApproxMat(s, &want, tol) {
+		t.Errorf("unexpected result of aliased SymOuterK")
+	}
+}
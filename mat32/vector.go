@@ -0,0 +1,330 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+var (
+	vector *VecDense
+
+	_ Matrix      = vector
+	_ Vector      = vector
+	_ RawVectorer = vector
+)
+
+// Vector is a column vector.
+type Vector interface {
+	Matrix
+	Len() int
+}
+
+// VecDense represents a column vector with float32 data.
+type VecDense struct {
+	mat blas32.Vector
+	n   int
+	// VecDense must have positive increment in this package.
+}
+
+// NewVecDense creates a new VecDense of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned VecDense
+// will be reflected in data. If neither of these is true, NewVecDense will panic.
+func NewVecDense(n int, data []float32) *VecDense {
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n)
+	}
+	return &VecDense{
+		mat: blas32.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// vecDenseCopyOf returns a newly allocated copy of the elements of a.
+func vecDenseCopyOf(a Vector) *VecDense {
+	n := a.Len()
+	v := NewVecDense(n, nil)
+	if rv, ok := a.(RawVectorer); ok {
+		blas32.Copy(n, rv.RawVector(), v.mat)
+		return v
+	}
+	for i := 0; i < n; i++ {
+		v.mat.Data[i] = a.At(i, 0)
+	}
+	return v
+}
+
+// SliceVec returns a new VecDense that shares backing data with the receiver.
+// The returned matrix starts at i of the receiver and extends k-i elements.
+// SliceVec panics with ErrIndexOutOfRange if the slice is outside the capacity
+// of the receiver.
+func (v *VecDense) SliceVec(i, k int) *VecDense {
+	if i < 0 || k <= i || v.Cap() < k {
+		panic(ErrIndexOutOfRange)
+	}
+	return &VecDense{
+		n: k - i,
+		mat: blas32.Vector{
+			Inc:  v.mat.Inc,
+			Data: v.mat.Data[i*v.mat.Inc : (k-1)*v.mat.Inc+1],
+		},
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *VecDense) Dims() (r, c int) {
+	if v.IsZero() {
+		return 0, 0
+	}
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *VecDense) Len() int {
+	return v.n
+}
+
+// Cap returns the capacity of the vector.
+func (v *VecDense) Cap() int {
+	if v.IsZero() {
+		return 0
+	}
+	return (cap(v.mat.Data)-1)/v.mat.Inc + 1
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (v *VecDense) T() Matrix {
+	return Transpose{v}
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+func (v *VecDense) Reset() {
+	// No change of Inc or n to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.n = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized vectors can be the
+// receiver for size-restricted operations. VecDenses can be zeroed using Reset.
+func (v *VecDense) IsZero() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// reuseAs resizes an empty vector to a r×1 vector,
+// or checks that a non-empty matrix is r×1.
+func (v *VecDense) reuseAs(r int) {
+	if v.IsZero() {
+		v.mat = blas32.Vector{
+			Inc:  1,
+			Data: use(v.mat.Data, r),
+		}
+		v.n = r
+		return
+	}
+	if r != v.n {
+		panic(ErrShape)
+	}
+}
+
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *VecDense) At(i, j int) float32 {
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.AtVec(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *VecDense) AtVec(i int) float32 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *VecDense) SetVec(i int, val float32) {
+	if uint(i) >= uint(v.n) {
+		panic(ErrVectorAccess)
+	}
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
+// RawVector returns the underlying blas32.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.Vector.
+func (v *VecDense) RawVector() blas32.Vector {
+	return v.mat
+}
+
+// CloneVec makes a copy of a into the receiver, overwriting the previous value
+// of the receiver.
+func (v *VecDense) CloneVec(a *VecDense) {
+	if v == a {
+		return
+	}
+	v.n = a.n
+	v.mat = blas32.Vector{
+		Inc:  1,
+		Data: use(v.mat.Data, v.n),
+	}
+	blas32.Copy(v.n, a.mat, v.mat)
+}
+
+// CopyVec makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two vectors and
+// returns the number of elements it copied.
+func (v *VecDense) CopyVec(a *VecDense) int {
+	n := min(v.Len(), a.Len())
+	if v != a {
+		blas32.Copy(n, a.mat, v.mat)
+	}
+	return n
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *VecDense) ScaleVec(alpha float32, a *VecDense) {
+	n := a.Len()
+	if v != a {
+		v.reuseAs(n)
+		blas32.Copy(n, a.mat, v.mat)
+	}
+	blas32.Scal(n, alpha, v.mat)
+}
+
+// AddScaledVec adds the vectors a and alpha*b, placing the result in the receiver.
+func (v *VecDense) AddScaledVec(a *VecDense, alpha float32, b *VecDense) {
+	ar := a.Len()
+	br := b.Len()
+	if ar != br {
+		panic(ErrShape)
+	}
+
+	v.reuseAs(ar)
+
+	switch {
+	case alpha == 0: // v <- a
+		v.CopyVec(a)
+	case v == a && v == b: // v <- v + alpha * v = (alpha + 1) * v
+		blas32.Scal(ar, alpha+1, v.mat)
+	case v == a: // v <- v + alpha * b
+		blas32.Axpy(ar, alpha, b.mat, v.mat)
+	case v == b: // v <- a + alpha * v
+		blas32.Scal(ar, alpha, v.mat)
+		blas32.Axpy(ar, 1, a.mat, v.mat)
+	default: // v <- a + alpha * b
+		blas32.Copy(ar, a.mat, v.mat)
+		blas32.Axpy(ar, alpha, b.mat, v.mat)
+	}
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *VecDense) AddVec(a, b *VecDense) {
+	v.AddScaledVec(a, 1, b)
+}
+
+// SubVec subtracts the vector b from a, placing the result in the receiver.
+func (v *VecDense) SubVec(a, b *VecDense) {
+	v.AddScaledVec(a, -1, b)
+}
+
+// MulElemVec performs element-wise multiplication of a and b, placing the result
+// in the receiver.
+func (v *VecDense) MulElemVec(a, b *VecDense) {
+	ar := a.Len()
+	br := b.Len()
+	if ar != br {
+		panic(ErrShape)
+	}
+
+	v.reuseAs(ar)
+
+	amat, bmat := a.RawVector(), b.RawVector()
+	for i := 0; i < v.n; i++ {
+		v.mat.Data[i*v.mat.Inc] = amat.Data[i*amat.Inc] * bmat.Data[i*bmat.Inc]
+	}
+}
+
+// MulVec computes a * b. The result is stored into the receiver.
+// MulVec panics if the number of columns in a does not equal the number of rows in b.
+func (v *VecDense) MulVec(a Matrix, b *VecDense) {
+	r, c := a.Dims()
+	br := b.Len()
+	if c != br {
+		panic(ErrShape)
+	}
+
+	a, trans := untranspose(a)
+	v.reuseAs(r)
+	if v == a || v == b {
+		w := NewVecDense(r, nil)
+		w.MulVec(restoreTranspose(a, trans), b)
+		v.CopyVec(w)
+		return
+	}
+
+	switch a := a.(type) {
+	case RawSymmetricer:
+		amat := a.RawSymmetric()
+		blas32.Symv(1, amat, b.mat, 0, v.mat)
+	case RawTriangular:
+		v.CopyVec(b)
+		amat := a.RawTriangular()
+		ta := blas.NoTrans
+		if trans {
+			ta = blas.Trans
+		}
+		blas32.Trmv(ta, amat, v.mat)
+	default:
+		amat, t := rawGeneral(a, trans)
+		blas32.Gemv(t, 1, amat, b.mat, 0, v.mat)
+	}
+}
+
+// restoreTranspose returns a wrapped in a Transpose if trans is true.
+func restoreTranspose(a Matrix, trans bool) Matrix {
+	if trans {
+		return Transpose{a}
+	}
+	return a
+}
+
+// asDense returns a Dense representation of the receiver with the same
+// underlying data.
+func (v *VecDense) asDense() *Dense {
+	return &Dense{
+		mat:     v.asGeneral(),
+		capRows: v.n,
+		capCols: 1,
+	}
+}
+
+// asGeneral returns a blas32.General representation of the receiver with the
+// same underlying data.
+func (v *VecDense) asGeneral() blas32.General {
+	return blas32.General{
+		Rows:   v.n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestVecDense(t *testing.T) {
+	v := NewVecDense(3, []float32{1, 2, 3})
+	if r, c := v.Dims(); r != 3 || c != 1 {
+		t.Errorf("unexpected dimensions: got %d×%d want 3×1", r, c)
+	}
+	v.SetVec(1, -2)
+	if v.AtVec(1) != -2 || v.At(1, 0) != -2 {
+		t.Errorf("unexpected value after SetVec")
+	}
+	if !panics(func() { v.At(0, 1) }) {
+		t.Errorf("expected panic for column out of range")
+	}
+	s := v.SliceVec(1, 3)
+	if s.Len() != 2 || s.AtVec(0) != -2 || s.AtVec(1) != 3 {
+		t.Errorf("unexpected slice of vector")
+	}
+
+	w := NewVecDense(3, []float32{4, 5, 6})
+	if got := Dot(v, w); got != 4-10+18 {
+		t.Errorf("unexpected dot product: got %v want 12", got)
+	}
+
+	var u VecDense
+	u.AddScaledVec(v, 2, w)
+	if !Equal(&u, NewVecDense(3, []float32{9, 8, 15})) {
+		t.Errorf("unexpected result of AddScaledVec: got %v", u.mat.Data)
+	}
+	u.SubVec(&u, w)
+	if !Equal(&u, NewVecDense(3, []float32{5, 3, 9})) {
+		t.Errorf("unexpected result of SubVec: got %v", u.mat.Data)
+	}
+	u.AddScaledVec(w, 2, &u)
+	if !Equal(&u, NewVecDense(3, []float32{14, 11, 24})) {
+		t.Errorf("unexpected result of aliased AddScaledVec: got %v", u.mat.Data)
+	}
+	u.ScaleVec(0.5, &u)
+	if !Equal(&u, NewVecDense(3, []float32{7, 5.5, 12})) {
+		t.Errorf("unexpected result of ScaleVec: got %v", u.mat.Data)
+	}
+	u.MulElemVec(&u, v)
+	if !Equal(&u, NewVecDense(3, []float32{7, -11, 36})) {
+		t.Errorf("unexpected result of MulElemVec: got %v", u.mat.Data)
+	}
+}
+
+func TestVecDenseMulVec(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 5},
+		{5, 3},
+		{40, 30},
+	} {
+		m, n := test.m, test.n
+		a := randDense(m, n, rnd)
+		b := NewVecDense(n, nil)
+		bt := NewVecDense(m, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = float32(rnd.NormFloat64())
+		}
+		for i := range bt.mat.Data {
+			bt.mat.Data[i] = float32(rnd.NormFloat64())
+		}
+
+		var got VecDense
+		var want mat.VecDense
+		got.MulVec(a, b)
+		want.MulVec(toMat(a), mat.NewVecDense(n, toMat(b).RawMatrix().Data))
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("m=%d,n=%d: unexpected result of MulVec", m, n)
+		}
+
+		got.Reset()
+		want.Reset()
+		got.MulVec(a.T(), bt)
+		want.MulVec(toMat(a).T(), mat.NewVecDense(m, toMat(bt).RawMatrix().Data))
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("m=%d,n=%d: unexpected result of MulVec with transpose", m, n)
+		}
+	}
+
+	// Symmetric, triangular and aliased receivers.
+	n := 6
+	var s SymDense
+	s.SymOuterK(1, randDense(n, n, rnd))
+	tri := NewTriDense(n, Lower, nil)
+	tri.Copy(randDense(n, n, rnd))
+	x := NewVecDense(n, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = float32(rnd.NormFloat64())
+	}
+	for _, a := range []Matrix{&s, tri, tri.T(), DenseCopyOf(&s)} {
+		var want mat.Dense
+		want.Mul(toMat(a), toMat(x))
+		var got VecDense
+		got.MulVec(a, x)
+		if !equalApproxMat(&got, &want, tol) {
+			t.Errorf("unexpected result of MulVec for %T", a)
+		}
+		v := NewVecDense(n, nil)
+		v.CopyVec(x)
+		v.MulVec(a, v)
+		if !equalApproxMat(v, &want, tol) {
+			t.Errorf("unexpected result of aliased MulVec for %T", a)
+		}
+	}
+}